@@ -38,6 +38,9 @@ type ProviderJSON struct {
 	VolcengineCredentials *VolcengineCredentials      `json:"volcengine_credentials,omitempty"`
 	OpenaiCredentials     *OpenaiCredentials          `json:"openai_credentials,omitempty"`
 	KnowledgebaseConfig   *KnowledgebaseConfig        `json:"knowledgebase_config,omitempty"`
+	FetchConfig           *FetchConfig                `json:"fetch_config,omitempty"`
+	SearchBackendConfig   *SearchBackendConfig        `json:"search_config,omitempty"`
+	Maintenance           *domain.MaintenanceWindow   `json:"maintenance,omitempty"`
 }
 
 // PermissionJSON represents the structure of a permission in the JSON file
@@ -56,6 +59,8 @@ type OperationJSON struct {
 	Category            string          `json:"category"`
 	RequiredPermissions []string        `json:"required_permissions,omitempty"`
 	Parameters          []ParameterJSON `json:"parameters,omitempty"`
+	RiskLevel           string          `json:"risk_level,omitempty"`
+	CompactFields       []string        `json:"compact_fields,omitempty"`
 }
 
 // ParameterJSON represents the structure of a parameter in the JSON file
@@ -126,6 +131,25 @@ type QueryConfig struct {
 	LLMTemperature      float64 `json:"llm_temperature"`
 }
 
+type FetchConfig struct {
+	Backend          string `json:"backend"`
+	TimeoutSeconds   int    `json:"timeout_seconds,omitempty"`
+	MaxBytes         int64  `json:"max_bytes,omitempty"`
+	MaxRedirects     int    `json:"max_redirects,omitempty"`
+	RespectRobotsTxt bool   `json:"respect_robots_txt"`
+	UserAgent        string `json:"user_agent,omitempty"`
+}
+
+type SearchBackendConfig struct {
+	DefaultBackend string `json:"default_backend,omitempty"`
+	SearXNG        *struct {
+		BaseURL string `json:"base_url"`
+	} `json:"searxng,omitempty"`
+	Brave *struct {
+		APIKey string `json:"api_key"`
+	} `json:"brave,omitempty"`
+}
+
 // TranslationData represents the structure of translation data
 type TranslationData struct {
 	ID                 string `json:"-"`
@@ -458,6 +482,10 @@ func loadProviderFromFile(filePath string) (*domain.Provider, *adapter_domain.Pr
 		}
 
 		op := domain.NewOperation(opJSON.Identifier, providerID, opJSON.Name, opJSON.Description, opJSON.Category, requiredPermissions, parameters)
+		if opJSON.RiskLevel != "" {
+			op.SetRiskLevel(types.OperationRiskLevel(opJSON.RiskLevel))
+		}
+		op.CompactFields = opJSON.CompactFields
 		operations = append(operations, *op)
 	}
 
@@ -478,6 +506,7 @@ func loadProviderFromFile(filePath string) (*domain.Provider, *adapter_domain.Pr
 		IconURL:     providerJSON.IconURL,
 		Categories:  providerJSON.Categories,
 		Operations:  operations,
+		Maintenance: providerJSON.Maintenance,
 	}
 
 	adapter := &adapter_domain.ProviderAdapterConfig{
@@ -505,6 +534,12 @@ func loadProviderFromFile(filePath string) (*domain.Provider, *adapter_domain.Pr
 	if providerJSON.KnowledgebaseConfig != nil {
 		adapter.CustomConfig["knowledgebase_config"] = providerJSON.KnowledgebaseConfig
 	}
+	if providerJSON.FetchConfig != nil {
+		adapter.CustomConfig["fetch_config"] = providerJSON.FetchConfig
+	}
+	if providerJSON.SearchBackendConfig != nil {
+		adapter.CustomConfig["search_config"] = providerJSON.SearchBackendConfig
+	}
 	if len(adapter.CustomConfig) == 0 {
 		adapter.CustomConfig = nil
 	}
@@ -633,6 +668,9 @@ func generateProvidersInsertSQL(providersWithTranslations []*ProviderWithTransla
 		jsonAttributes := map[string]interface{}{
 			"categories": provider.Categories,
 		}
+		if provider.Maintenance != nil {
+			jsonAttributes["maintenance"] = provider.Maintenance
+		}
 		jsonAttributesData, err := sonic.Marshal(jsonAttributes)
 		if err != nil {
 			return fmt.Errorf("failed to marshal json_attributes: %w", err)
@@ -707,6 +745,8 @@ func generateOperationsInsertSQL(providersWithTranslations []*ProviderWithTransl
 			jsonAttributes := map[string]interface{}{
 				"required_permissions": operation.RequiredPermissions,
 				"parameters":           operation.Parameters,
+				"risk_level":           operation.RiskLevel,
+				"compact_fields":       operation.CompactFields,
 			}
 			jsonAttributesData, err := sonic.Marshal(jsonAttributes)
 			if err != nil {
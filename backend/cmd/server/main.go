@@ -23,6 +23,7 @@ import (
 	"github.com/context-space/context-space/backend/internal/shared/config"
 	"github.com/context-space/context-space/backend/internal/shared/cron"
 	"github.com/context-space/context-space/backend/internal/shared/events"
+	"github.com/context-space/context-space/backend/internal/shared/health"
 	"github.com/context-space/context-space/backend/internal/shared/infrastructure/cache"
 	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
 	"github.com/context-space/context-space/backend/internal/shared/interfaces/http/middleware"
@@ -46,6 +47,9 @@ var (
 	GoVersion = "unknown" // Go version used to build
 )
 
+// readinessCacheTTL is how long a readiness report is served before the checks run again
+const readinessCacheTTL = 5 * time.Second
+
 // VersionInfo represents the version information structure
 type VersionInfo struct {
 	Version   string `json:"version"`
@@ -168,6 +172,7 @@ func main() {
 	logger.Info("Configuration loaded",
 		zap.String("environment", cfg.Environment),
 		zap.String("server_address", cfg.Server.Address),
+		zap.String("auth_provider", cfg.Auth.Provider),
 		zap.String("supabase_project_ref", cfg.Supabase.ProjectRef),
 		zap.String("database_host", cfg.Database.Host),
 		zap.Int("database_port", cfg.Database.Port),
@@ -278,6 +283,7 @@ func main() {
 		observabilityProvider,
 		providerCoreModule.GetProviderService(),
 		providerTranslationModule.GetProviderTranslationService(),
+		cfg,
 	)
 	if err != nil {
 		observabilityProvider.Logger.Fatal(ctx, "Failed to initialize provider adapter module", zap.Error(err))
@@ -291,6 +297,7 @@ func main() {
 		eventBus,
 		observabilityProvider,
 		providerAdapterModule.GetAdapterContractFacade(),
+		identityAccessModule.GetOrganizationContract(),
 		redisClient,
 	)
 	if err != nil {
@@ -305,8 +312,10 @@ func main() {
 		providerCoreModule.GetProviderService(),
 		providerAdapterModule.GetAdapterContractFacade(),
 		credentialManagementModule.GetCredentialContractFacade(),
+		identityAccessModule.GetOrganizationContract(),
 		providerCoreModule.GetProviderService(),
 		redisClient,
+		cfg,
 	)
 	if err != nil {
 		observabilityProvider.Logger.Fatal(ctx, "Failed to initialize integration module", zap.Error(err))
@@ -314,12 +323,16 @@ func main() {
 
 	router := gin.New()
 
-	// Configure CORS middleware
+	// Configure CORS middleware, browsers may only select the record/replay mode when the header is enabled
+	allowHeaders := []string{"Origin", "Authorization", "Content-Type", "Accept", "Content-Length", "X-Requested-With", "X-CSRF-Token", "X-Organization-ID"}
+	if cfg.VCR.AllowHeader {
+		allowHeaders = append(allowHeaders, "X-VCR-Mode")
+	}
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:           cfg.Security.CORS.AllowedOrigins,
 		AllowMethods:           []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:           []string{"Origin", "Authorization", "Content-Type", "Accept", "Content-Length", "X-Requested-With", "X-CSRF-Token"},
-		ExposeHeaders:          []string{"Content-Length", "Content-Type"},
+		AllowHeaders:           allowHeaders,
+		ExposeHeaders:          []string{"Content-Length", "Content-Type", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After"},
 		AllowCredentials:       true,
 		MaxAge:                 12 * time.Hour,
 		AllowWildcard:          true,
@@ -332,6 +345,12 @@ func main() {
 	// Register observability middleware
 	middleware.RegisterObservabilityMiddleware(router, observabilityProvider)
 
+	// Capture the client of each request for the audit log
+	router.Use(middleware.AuditMiddleware())
+
+	// Allow selecting record/replay of provider traffic per request when enabled in config
+	router.Use(providerAdapterModule.RecordReplayMiddleware())
+
 	// Initialize routes
 	initializeRoutes(
 		router,
@@ -340,6 +359,10 @@ func main() {
 		identityAccessModule,
 		credentialManagementModule,
 		integrationModule,
+		health.NewRunner(func() []health.Check {
+			return readinessChecks(postgresClient, redisClient, providerAdapterModule, credentialManagementModule)
+		}, time.Duration(cfg.HealthProbe.ReadinessTimeoutMs)*time.Millisecond, readinessCacheTTL),
+		logger,
 	)
 
 	// Initialize modules
@@ -371,7 +394,9 @@ func main() {
 	}
 
 	// Initialize cron jobs system
-	if err := initializeCronJobs(ctx, credentialManagementModule.TokenRefreshService(), observabilityProvider, redisClient); err != nil {
+	if err := initializeCronJobs(ctx, credentialManagementModule.TokenRefreshService(), observabilityProvider, redisClient,
+		append(append(integrationModule.CronTaskGroups(), credentialManagementModule.CronTaskGroups()...),
+			providerAdapterModule.CronTaskGroups()...)); err != nil {
 		observabilityProvider.Logger.Fatal(ctx, "Failed to initialize cron jobs system", zap.Error(err))
 	}
 
@@ -423,6 +448,8 @@ func initializeRoutes(
 	identityAccessModule *identityaccess.Module,
 	credentialManagementModule *credentialmanagement.Module,
 	integrationModule *integration.Module,
+	readiness *health.Runner,
+	logger *zap.Logger,
 ) {
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
@@ -450,6 +477,28 @@ func initializeRoutes(
 		c.JSON(http.StatusOK, healthInfo)
 	})
 
+	// Readiness endpoint, fails when any dependency needed to serve traffic is down
+	// It is unauthenticated, so only check names and statuses are returned and failures are logged instead
+	router.GET("/readyz", func(c *gin.Context) {
+		report, fresh := readiness.Run(c.Request.Context())
+		if fresh {
+			for _, result := range report.Checks {
+				if result.Status != health.StatusUp {
+					logger.Warn("Readiness check failed",
+						zap.String("check", result.Name),
+						zap.String("status", string(result.Status)),
+						zap.String("error", result.Error))
+				}
+			}
+		}
+
+		status := http.StatusOK
+		if report.Status != health.StatusUp {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, report.Public())
+	})
+
 	// Serve static files from resources directory
 	router.Static("/resources", "./resources")
 
@@ -548,10 +597,35 @@ func initializeRoutes(
 	}
 }
 
+// readinessChecks returns the checks of every dependency the server needs to serve traffic
+func readinessChecks(
+	db database.Database,
+	redisClient cache.Cache,
+	providerAdapterModule *provideradapter.Module,
+	credentialManagementModule *credentialmanagement.Module,
+) []health.Check {
+	checks := []health.Check{
+		{
+			Name: "postgres",
+			Run: func(ctx context.Context) error {
+				return db.WithContext(ctx).Exec("SELECT 1").Error
+			},
+		},
+		{
+			Name: "redis",
+			Run:  redisClient.Ping,
+		},
+	}
+	checks = append(checks, credentialManagementModule.ReadinessChecks()...)
+	checks = append(checks, providerAdapterModule.ReadinessChecks()...)
+	return checks
+}
+
 func initializeCronJobs(ctx context.Context,
 	tokenRefreshService domain.TokenRefresh,
 	observabilityProvider *observability.ObservabilityProvider,
 	redisClient cache.Cache,
+	moduleTaskGroups []*cron.TaskGroup,
 ) error {
 	observabilityProvider.Logger.Info(ctx, "Initializing cron jobs system")
 
@@ -559,7 +633,7 @@ func initializeCronJobs(ctx context.Context,
 
 	taskBuilder := cron.NewCronTaskBuilder(tokenRefreshService, observabilityProvider)
 
-	taskGroups := taskBuilder.CreateAllTaskGroups()
+	taskGroups := append(taskBuilder.CreateAllTaskGroups(), moduleTaskGroups...)
 	for _, group := range taskGroups {
 		if err := cronManager.RegisterTaskGroup(ctx, group); err != nil {
 			observabilityProvider.Logger.Error(ctx, "Failed to register task group",
@@ -0,0 +1,79 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	observability "github.com/context-space/cloud-observability"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	"github.com/context-space/context-space/backend/internal/shared/events"
+)
+
+// CredentialInvalidationService invalidates credentials their provider no longer accepts
+type CredentialInvalidationService struct {
+	credentialRepo domain.CredentialRepository
+	eventBus       events.EventBus
+	eventTypes     CredentialEventTypes
+	obs            *observability.ObservabilityProvider
+}
+
+var _ domain.CredentialInvalidator = (*CredentialInvalidationService)(nil)
+
+// NewCredentialInvalidationService creates a new credential invalidation service
+func NewCredentialInvalidationService(
+	credentialRepo domain.CredentialRepository,
+	eventBus events.EventBus,
+	observabilityProvider *observability.ObservabilityProvider,
+) *CredentialInvalidationService {
+	return &CredentialInvalidationService{
+		credentialRepo: credentialRepo,
+		eventBus:       eventBus,
+		eventTypes:     DefaultCredentialEventTypes(),
+		obs:            observabilityProvider,
+	}
+}
+
+// Invalidate marks the credential as invalid and publishes a credential.invalidated event,
+// invalid credentials are no longer returned so their owner has to connect the provider again
+func (s *CredentialInvalidationService) Invalidate(ctx context.Context, credential *domain.Credential, reason string) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "CredentialInvalidationService.Invalidate")
+	defer span.End()
+
+	credential.Invalidate(reason)
+	if err := s.credentialRepo.UpdateVerification(ctx, credential); err != nil {
+		return fmt.Errorf("failed to invalidate credential: %w", err)
+	}
+
+	s.obs.Logger.Info(ctx, "Credential invalidated",
+		zap.String("credential_id", credential.ID),
+		zap.String("provider_identifier", credential.ProviderIdentifier),
+		zap.String("reason", reason))
+
+	spanContext := trace.SpanFromContext(ctx).SpanContext()
+	event := events.NewEvent(
+		s.eventTypes.Invalidated,
+		events.Payload{
+			"credential_id":       credential.ID,
+			"user_id":             credential.UserID,
+			"organization_id":     credential.OrganizationID,
+			"provider_identifier": credential.ProviderIdentifier,
+			"type":                string(credential.Type),
+			"reason":              reason,
+		},
+		events.Metadata{
+			UserID:             credential.UserID,
+			ProviderIdentifier: credential.ProviderIdentifier,
+			TraceID:            spanContext.TraceID().String(),
+			SpanID:             spanContext.SpanID().String(),
+		},
+	)
+
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return fmt.Errorf("failed to publish credential invalidated event: %w", err)
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	observability "github.com/context-space/cloud-observability"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"golang.org/x/oauth2"
 
@@ -27,19 +28,25 @@ var (
 
 // CredentialEventTypes defines the event types for the credential service
 type CredentialEventTypes struct {
-	Created   events.EventType
-	Updated   events.EventType
-	Deleted   events.EventType
-	Refreshed events.EventType
+	Created               events.EventType
+	Updated               events.EventType
+	Deleted               events.EventType
+	Refreshed             events.EventType
+	Invalidated           events.EventType
+	OAuthConsentCompleted events.EventType
+	OAuthConsentFailed    events.EventType
 }
 
 // DefaultCredentialEventTypes returns the default credential event types
 func DefaultCredentialEventTypes() CredentialEventTypes {
 	return CredentialEventTypes{
-		Created:   events.EventType("credential.created"),
-		Updated:   events.EventType("credential.updated"),
-		Deleted:   events.EventType("credential.deleted"),
-		Refreshed: events.EventType("credential.refreshed"),
+		Created:               events.EventType("credential.created"),
+		Updated:               events.EventType("credential.updated"),
+		Deleted:               events.EventType("credential.deleted"),
+		Refreshed:             events.EventType("credential.refreshed"),
+		Invalidated:           events.EventType("credential.invalidated"),
+		OAuthConsentCompleted: events.EventType("credential.oauth_consent_completed"),
+		OAuthConsentFailed:    events.EventType("credential.oauth_consent_failed"),
 	}
 }
 
@@ -55,6 +62,8 @@ type CredentialService struct {
 	unitOfWorkFactory   database.UnitOfWorkFactory
 	redisClient         cache.Cache
 	tokenRefreshService domain.TokenRefresh
+	revocationService   *TokenRevocationService
+	oauthAppResolver    domain.OAuthAppResolver
 }
 
 // NewCredentialService creates a new credential service
@@ -68,6 +77,8 @@ func NewCredentialService(
 	oAuthRedirectURL string,
 	redisClient cache.Cache,
 	tokenRefreshService domain.TokenRefresh,
+	revocationService *TokenRevocationService,
+	oauthAppResolver domain.OAuthAppResolver,
 ) *CredentialService {
 	return &CredentialService{
 		credentialRepo:      credentialRepo,
@@ -80,6 +91,8 @@ func NewCredentialService(
 		oAuthRedirectURL:    oAuthRedirectURL,
 		redisClient:         redisClient,
 		tokenRefreshService: tokenRefreshService,
+		revocationService:   revocationService,
+		oauthAppResolver:    oauthAppResolver,
 	}
 }
 
@@ -93,6 +106,18 @@ func (s *CredentialService) CreateOAuthCredential(
 	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.CreateOAuthCredential")
 	defer span.End()
 
+	return s.createOAuthCredential(ctx, userID, "", providerIdentifier, oauth2Token, scopes)
+}
+
+// createOAuthCredential creates a new OAuth credential, replacing the existing one of the same owner
+func (s *CredentialService) createOAuthCredential(
+	ctx context.Context,
+	userID, organizationID, providerIdentifier string,
+	oauth2Token *oauth2.Token,
+	scopes []string,
+) (*domain.OAuthCredential, error) {
+	span := trace.SpanFromContext(ctx)
+
 	// Start a new transaction
 	unitOfWork := s.unitOfWorkFactory.Create()
 	err := unitOfWork.Begin(ctx)
@@ -100,8 +125,8 @@ func (s *CredentialService) CreateOAuthCredential(
 		return nil, err
 	}
 
-	// Check if a credential already exists for this user and provider
-	cred, err := s.credentialRepo.GetByUserAndProvider(ctx, userID, providerIdentifier)
+	// Check if a credential already exists for this owner and provider
+	cred, err := s.getExistingCredential(ctx, userID, organizationID, providerIdentifier)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +138,7 @@ func (s *CredentialService) CreateOAuthCredential(
 	}
 
 	// Create the OAuth credential
-	oauthCred, err := s.credFactory.CreateOAuth(ctx, userID, providerIdentifier, oauth2Token, scopes)
+	oauthCred, err := s.credFactory.CreateOAuth(ctx, userID, organizationID, providerIdentifier, oauth2Token, scopes)
 	if err != nil {
 		unitOfWork.Rollback(ctx)
 		return nil, err
@@ -125,6 +150,7 @@ func (s *CredentialService) CreateOAuthCredential(
 		events.Payload{
 			"credential_id":       oauthCred.ID,
 			"user_id":             userID,
+			"organization_id":     organizationID,
 			"provider_identifier": providerIdentifier,
 			"type":                string(oauthCred.Type),
 		},
@@ -157,6 +183,27 @@ func (s *CredentialService) CreateAPIKeyCredential(
 	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.CreateAPIKeyCredential")
 	defer span.End()
 
+	return s.createAPIKeyCredential(ctx, userID, "", providerIdentifier, apiKey)
+}
+
+// CreateOrganizationAPIKeyCredential creates a new API key credential shared with an organization
+func (s *CredentialService) CreateOrganizationAPIKeyCredential(
+	ctx context.Context,
+	organizationID, userID, providerIdentifier, apiKey string,
+) (*domain.APIKeyCredential, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.CreateOrganizationAPIKeyCredential")
+	defer span.End()
+
+	return s.createAPIKeyCredential(ctx, userID, organizationID, providerIdentifier, apiKey)
+}
+
+// createAPIKeyCredential creates a new API key credential, replacing the existing one of the same owner
+func (s *CredentialService) createAPIKeyCredential(
+	ctx context.Context,
+	userID, organizationID, providerIdentifier, apiKey string,
+) (*domain.APIKeyCredential, error) {
+	span := trace.SpanFromContext(ctx)
+
 	// Start a new transaction
 	unitOfWork := s.unitOfWorkFactory.Create()
 	err := unitOfWork.Begin(ctx)
@@ -164,8 +211,8 @@ func (s *CredentialService) CreateAPIKeyCredential(
 		return nil, err
 	}
 
-	// Check if a credential already exists for this user and provider
-	cred, err := s.credentialRepo.GetByUserAndProvider(ctx, userID, providerIdentifier)
+	// Check if a credential already exists for this owner and provider
+	cred, err := s.getExistingCredential(ctx, userID, organizationID, providerIdentifier)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +224,7 @@ func (s *CredentialService) CreateAPIKeyCredential(
 	}
 
 	// Create the API key credential
-	apiKeyCred, err := s.credFactory.CreateAPIKey(ctx, userID, providerIdentifier, apiKey)
+	apiKeyCred, err := s.credFactory.CreateAPIKey(ctx, userID, organizationID, providerIdentifier, apiKey)
 	if err != nil {
 		unitOfWork.Rollback(ctx)
 		return nil, err
@@ -189,6 +236,7 @@ func (s *CredentialService) CreateAPIKeyCredential(
 		events.Payload{
 			"credential_id":       apiKeyCred.ID,
 			"user_id":             userID,
+			"organization_id":     organizationID,
 			"provider_identifier": providerIdentifier,
 			"type":                string(apiKeyCred.Type),
 		},
@@ -213,6 +261,100 @@ func (s *CredentialService) CreateAPIKeyCredential(
 	return apiKeyCred, nil
 }
 
+// CreateAppInstallationCredential creates a new app installation credential
+func (s *CredentialService) CreateAppInstallationCredential(
+	ctx context.Context,
+	userID, providerIdentifier, appID, installationID, privateKey string,
+) (*domain.AppInstallationCredential, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.CreateAppInstallationCredential")
+	defer span.End()
+
+	return s.createAppInstallationCredential(ctx, userID, "", providerIdentifier, appID, installationID, privateKey)
+}
+
+// CreateOrganizationAppInstallationCredential creates a new app installation credential shared with an organization
+func (s *CredentialService) CreateOrganizationAppInstallationCredential(
+	ctx context.Context,
+	organizationID, userID, providerIdentifier, appID, installationID, privateKey string,
+) (*domain.AppInstallationCredential, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.CreateOrganizationAppInstallationCredential")
+	defer span.End()
+
+	return s.createAppInstallationCredential(ctx, userID, organizationID, providerIdentifier, appID, installationID, privateKey)
+}
+
+// createAppInstallationCredential creates a new app installation credential, replacing the existing one of the same owner
+func (s *CredentialService) createAppInstallationCredential(
+	ctx context.Context,
+	userID, organizationID, providerIdentifier, appID, installationID, privateKey string,
+) (*domain.AppInstallationCredential, error) {
+	span := trace.SpanFromContext(ctx)
+
+	// Start a new transaction
+	unitOfWork := s.unitOfWorkFactory.Create()
+	err := unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if a credential already exists for this owner and provider
+	cred, err := s.getExistingCredential(ctx, userID, organizationID, providerIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	if cred != nil && cred.Type == domain.CredentialTypeAppInstallation {
+		if err := s.credentialRepo.Delete(ctx, cred.ID); err != nil {
+			unitOfWork.Rollback(ctx)
+			return nil, err
+		}
+	}
+
+	// Create the app installation credential
+	installationCred, err := s.credFactory.CreateAppInstallation(ctx, userID, organizationID, providerIdentifier, appID, installationID, privateKey)
+	if err != nil {
+		unitOfWork.Rollback(ctx)
+		return nil, err
+	}
+
+	// Emit credential created event
+	event := events.NewEvent(
+		s.eventTypes.Created,
+		events.Payload{
+			"credential_id":       installationCred.ID,
+			"user_id":             userID,
+			"organization_id":     organizationID,
+			"provider_identifier": providerIdentifier,
+			"type":                string(installationCred.Type),
+		},
+		events.Metadata{
+			UserID:             userID,
+			ProviderIdentifier: providerIdentifier,
+			TraceID:            span.SpanContext().TraceID().String(),
+			SpanID:             span.SpanContext().SpanID().String(),
+		},
+	)
+
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		unitOfWork.Rollback(ctx)
+		return nil, err
+	}
+
+	// Commit the transaction
+	if err := unitOfWork.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return installationCred, nil
+}
+
+// getExistingCredential retrieves the credential of an organization if organizationID is set, or the personal credential of a user
+func (s *CredentialService) getExistingCredential(ctx context.Context, userID, organizationID, providerIdentifier string) (*domain.Credential, error) {
+	if organizationID != "" {
+		return s.credentialRepo.GetByOrganizationAndProvider(ctx, organizationID, providerIdentifier)
+	}
+	return s.credentialRepo.GetByUserAndProvider(ctx, userID, providerIdentifier)
+}
+
 // GetCredential retrieves a credential by ID
 func (s *CredentialService) GetCredential(ctx context.Context, id string) (interface{}, error) {
 	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.GetCredential")
@@ -236,8 +378,29 @@ func (s *CredentialService) GetCredentialByUserAndProvider(ctx context.Context,
 	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.GetCredentialByUserAndProvider")
 	defer span.End()
 
+	return s.getCredentialWithRefresh(ctx, userID, providerIdentifier, func(ctx context.Context) (interface{}, error) {
+		return s.credFactory.GetCredentialByUserAndProvider(ctx, userID, providerIdentifier)
+	})
+}
+
+// GetCredentialByOrganizationAndProvider retrieves the credential an organization shares for a provider
+func (s *CredentialService) GetCredentialByOrganizationAndProvider(ctx context.Context, organizationID, providerIdentifier string) (interface{}, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.GetCredentialByOrganizationAndProvider")
+	defer span.End()
+
+	return s.getCredentialWithRefresh(ctx, organizationID, providerIdentifier, func(ctx context.Context) (interface{}, error) {
+		return s.credFactory.GetCredentialByOrganizationAndProvider(ctx, organizationID, providerIdentifier)
+	})
+}
+
+// getCredentialWithRefresh loads a credential of the given owner under the access token lock and refreshes it if needed
+func (s *CredentialService) getCredentialWithRefresh(
+	ctx context.Context,
+	ownerID, providerIdentifier string,
+	load func(ctx context.Context) (interface{}, error),
+) (interface{}, error) {
 	// lock the credential prevent refresh token when getting credential
-	lockKey := fmt.Sprintf(cache.AccessTokenLockKey, providerIdentifier, userID)
+	lockKey := fmt.Sprintf(cache.AccessTokenLockKey, providerIdentifier, ownerID)
 
 	// use retry mechanism to get lock
 	const maxRetries = 5
@@ -277,7 +440,7 @@ func (s *CredentialService) GetCredentialByUserAndProvider(ctx context.Context,
 	defer s.redisClient.ReleaseLock(ctx, lockKey)
 
 	// Get the credential from the factory
-	cred, err := s.credFactory.GetCredentialByUserAndProvider(ctx, userID, providerIdentifier)
+	cred, err := load(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -290,7 +453,7 @@ func (s *CredentialService) GetCredentialByUserAndProvider(ctx context.Context,
 	if err != nil {
 		s.obs.Logger.Error(ctx, "Failed to refresh access token",
 			zap.String("provider_identifier", providerIdentifier),
-			zap.String("user_id", userID),
+			zap.String("owner_id", ownerID),
 			zap.Error(err))
 		return nil, fmt.Errorf("%w: %s", ErrCredentialExpired, err.Error())
 	}
@@ -313,17 +476,37 @@ func (s *CredentialService) DeleteCredential(ctx context.Context, id string) err
 		return ErrCredentialNotFound
 	}
 
+	// Capture the encrypted token before deletion so the grant can be revoked at the provider
+	var revocation *domain.TokenRevocation
+	if s.revocationService != nil {
+		revocation, err = s.revocationService.Prepare(ctx, baseCred)
+		if err != nil {
+			s.obs.Logger.Error(ctx, "Failed to prepare token revocation",
+				zap.String("credential_id", id),
+				zap.Error(err))
+		}
+	}
+
 	// Delete the base credential
 	if err := s.credentialRepo.Delete(ctx, id); err != nil {
 		return err
 	}
 
+	if revocation != nil {
+		if err := s.revocationService.Schedule(ctx, revocation); err != nil {
+			s.obs.Logger.Error(ctx, "Failed to schedule token revocation",
+				zap.String("credential_id", id),
+				zap.Error(err))
+		}
+	}
+
 	// Emit credential deleted event
 	event := events.NewEvent(
 		s.eventTypes.Deleted,
 		events.Payload{
 			"credential_id":       id,
 			"user_id":             baseCred.UserID,
+			"organization_id":     baseCred.OrganizationID,
 			"provider_identifier": baseCred.ProviderIdentifier,
 			"type":                string(baseCred.Type),
 		},
@@ -342,6 +525,84 @@ func (s *CredentialService) DeleteCredential(ctx context.Context, id string) err
 	return nil
 }
 
+// HandleUserDeleted deletes the credentials of a deleted user, revoking their tokens at the providers
+func (s *CredentialService) HandleUserDeleted(ctx context.Context, event events.Event) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.HandleUserDeleted")
+	defer span.End()
+
+	userID := event.Metadata.UserID
+	if userID == "" {
+		return nil
+	}
+
+	creds, err := s.credentialRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list credentials of deleted user: %w", err)
+	}
+
+	// Keep deleting when one credential fails, a credential left behind must not spare the others
+	var errs []error
+	for _, cred := range creds {
+		if err := s.DeleteCredential(ctx, cred.ID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete credential %s of deleted user: %w", cred.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// HandleServiceAccountDeleted deletes the credentials of a deleted service account, revoking their tokens at the providers
+func (s *CredentialService) HandleServiceAccountDeleted(ctx context.Context, event events.Event) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.HandleServiceAccountDeleted")
+	defer span.End()
+
+	serviceAccountID := event.Metadata.Properties["service_account_id"]
+	if serviceAccountID == "" {
+		return nil
+	}
+
+	creds, err := s.credentialRepo.ListByUser(ctx, serviceAccountID)
+	if err != nil {
+		return fmt.Errorf("failed to list credentials of deleted service account: %w", err)
+	}
+
+	// Keep deleting when one credential fails, a credential left behind must not spare the others
+	var errs []error
+	for _, cred := range creds {
+		if err := s.DeleteCredential(ctx, cred.ID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete credential %s of deleted service account: %w", cred.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// HandleOrganizationDeleted deletes the credentials shared with a deleted organization, revoking their tokens at the providers
+func (s *CredentialService) HandleOrganizationDeleted(ctx context.Context, event events.Event) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.HandleOrganizationDeleted")
+	defer span.End()
+
+	organizationID := event.Metadata.Properties["organization_id"]
+	if organizationID == "" {
+		return nil
+	}
+
+	creds, err := s.credentialRepo.ListByOrganization(ctx, organizationID)
+	if err != nil {
+		return fmt.Errorf("failed to list credentials of deleted organization: %w", err)
+	}
+
+	// Keep deleting when one credential fails, a credential left behind must not spare the others
+	var errs []error
+	for _, cred := range creds {
+		if err := s.DeleteCredential(ctx, cred.ID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete credential %s of deleted organization: %w", cred.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // GetAllCredentialsByUser retrieves all credentials for a user
 func (s *CredentialService) GetAllCredentialsByUser(ctx context.Context, userID string) ([]*domain.Credential, error) {
 	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.GetAllCredentialsByUser")
@@ -356,6 +617,14 @@ func (s *CredentialService) GetAllCredentialsByUser(ctx context.Context, userID
 	return baseCreds, nil
 }
 
+// GetAllCredentialsByOrganization retrieves all credentials shared with an organization
+func (s *CredentialService) GetAllCredentialsByOrganization(ctx context.Context, organizationID string) ([]*domain.Credential, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.GetAllCredentialsByOrganization")
+	defer span.End()
+
+	return s.credentialRepo.ListByOrganization(ctx, organizationID)
+}
+
 // GetOAuthURL generates an OAuth authorization URL for a provider
 func (s *CredentialService) GetOAuthURL(ctx context.Context, providerIdentifier, state, codeChallenge string, permissionIdentifiers []string) (string, error) {
 	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.GetOAuthURL")
@@ -374,15 +643,70 @@ func (s *CredentialService) GetOAuthURL(ctx context.Context, providerIdentifier,
 	return oauthURL, nil
 }
 
+// GetOAuthURLForOwner generates an OAuth authorization URL for a provider through the OAuth app of the owner, if any,
+// the owner is the organization if organizationID is not empty
+func (s *CredentialService) GetOAuthURLForOwner(ctx context.Context, userID, organizationID, providerIdentifier, state, codeChallenge string, permissionIdentifiers []string) (string, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.GetOAuthURLForOwner")
+	defer span.End()
+
+	ctx, err := s.withOwnerOAuthApp(ctx, userID, organizationID, providerIdentifier)
+	if err != nil {
+		return "", err
+	}
+
+	return s.GetOAuthURL(ctx, providerIdentifier, state, codeChallenge, permissionIdentifiers)
+}
+
+// withOwnerOAuthApp returns a context whose OAuth calls use the OAuth app registered by the owner for the provider
+func (s *CredentialService) withOwnerOAuthApp(ctx context.Context, userID, organizationID, providerIdentifier string) (context.Context, error) {
+	if s.oauthAppResolver == nil {
+		return ctx, nil
+	}
+
+	app, err := s.oauthAppResolver.GetOAuthAppForOwner(ctx, userID, organizationID, providerIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OAuth app: %w", err)
+	}
+
+	return domain.WithOAuthApp(ctx, app), nil
+}
+
 // HandleOAuthCallback processes an OAuth callback and stores the credentials
 func (s *CredentialService) HandleOAuthCallback(ctx context.Context, code, providerIdentifier, userID string, permissions []string, codeVerifier string) (*domain.OAuthCredential, error) {
 	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.HandleOAuthCallback")
 	defer span.End()
 
+	return s.handleOAuthCallback(ctx, code, providerIdentifier, userID, "", permissions, codeVerifier)
+}
+
+// HandleOrganizationOAuthCallback processes an OAuth callback and stores the credentials as shared with an organization
+func (s *CredentialService) HandleOrganizationOAuthCallback(ctx context.Context, code, providerIdentifier, userID, organizationID string, permissions []string, codeVerifier string) (*domain.OAuthCredential, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.HandleOrganizationOAuthCallback")
+	defer span.End()
+
+	return s.handleOAuthCallback(ctx, code, providerIdentifier, userID, organizationID, permissions, codeVerifier)
+}
+
+// handleOAuthCallback completes the OAuth consent of a user and emits its outcome
+func (s *CredentialService) handleOAuthCallback(ctx context.Context, code, providerIdentifier, userID, organizationID string, permissions []string, codeVerifier string) (*domain.OAuthCredential, error) {
+	oauthCred, err := s.exchangeOAuthCode(ctx, code, providerIdentifier, userID, organizationID, permissions, codeVerifier)
+	s.emitOAuthConsentEvent(ctx, userID, organizationID, providerIdentifier, oauthCred, err)
+	return oauthCred, err
+}
+
+// exchangeOAuthCode exchanges the authorization code and stores the resulting credential for its owner
+func (s *CredentialService) exchangeOAuthCode(ctx context.Context, code, providerIdentifier, userID, organizationID string, permissions []string, codeVerifier string) (*domain.OAuthCredential, error) {
+	// The code was issued to the OAuth app the authorization URL was generated for
+	ctx, err := s.withOwnerOAuthApp(ctx, userID, organizationID, providerIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
 	token, err := s.oauthProvider.ExchangeCodeForToken(ctx, providerIdentifier, code, s.oAuthRedirectURL, codeVerifier)
 	s.obs.Logger.Debug(ctx, "ExchangeCodeForToken",
 		zap.String("provider_identifier", providerIdentifier),
 		zap.String("user_id", userID),
+		zap.String("organization_id", organizationID),
 		zap.Any("token", token),
 	)
 	if err != nil {
@@ -394,7 +718,7 @@ func (s *CredentialService) HandleOAuthCallback(ctx context.Context, code, provi
 		return nil, fmt.Errorf("failed to get scopes from permissions: %w", err)
 	}
 
-	oauthCred, err := s.CreateOAuthCredential(ctx, userID, providerIdentifier, token, scopes)
+	oauthCred, err := s.createOAuthCredential(ctx, userID, organizationID, providerIdentifier, token, scopes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create OAuth credential: %w", err)
 	}
@@ -402,17 +726,85 @@ func (s *CredentialService) HandleOAuthCallback(ctx context.Context, code, provi
 	return oauthCred, nil
 }
 
+// emitOAuthConsentEvent emits whether the OAuth consent of a user completed, failures are only logged
+func (s *CredentialService) emitOAuthConsentEvent(
+	ctx context.Context,
+	userID, organizationID, providerIdentifier string,
+	oauthCred *domain.OAuthCredential,
+	consentErr error,
+) {
+	span := trace.SpanFromContext(ctx)
+
+	eventType := s.eventTypes.OAuthConsentCompleted
+	payload := events.Payload{
+		"user_id":             userID,
+		"organization_id":     organizationID,
+		"provider_identifier": providerIdentifier,
+		"type":                string(domain.CredentialTypeOAuth),
+	}
+	if consentErr != nil {
+		eventType = s.eventTypes.OAuthConsentFailed
+	} else {
+		payload["credential_id"] = oauthCred.ID
+	}
+
+	event := events.NewEvent(
+		eventType,
+		payload,
+		events.Metadata{
+			UserID:             userID,
+			ProviderIdentifier: providerIdentifier,
+			TraceID:            span.SpanContext().TraceID().String(),
+			SpanID:             span.SpanContext().SpanID().String(),
+		},
+	)
+
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		s.obs.Logger.Error(ctx, "Failed to publish OAuth consent event",
+			zap.String("event_type", string(eventType)),
+			zap.String("user_id", userID),
+			zap.Error(err))
+	}
+}
+
 // GetPermissionIdentifiersFromScopes gets the permission identifiers from the scopes
 func (s *CredentialService) GetPermissionIdentifiersFromScopes(ctx context.Context, providerIdentifier string, scopes []string) ([]string, error) {
 	return s.oauthProvider.GetPermissionIdentifiersFromScopes(ctx, providerIdentifier, scopes)
 }
 
+// GetGrantedPermissions returns the permission identifiers granted to the OAuth credential for a provider,
+// the organization's credential when organizationID is set and the user's own otherwise
+func (s *CredentialService) GetGrantedPermissions(ctx context.Context, userID, organizationID, providerIdentifier string) ([]string, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "CredentialService.GetGrantedPermissions")
+	defer span.End()
+
+	var cred interface{}
+	var err error
+	if organizationID != "" {
+		cred, err = s.credFactory.GetCredentialByOrganizationAndProvider(ctx, organizationID, providerIdentifier)
+	} else {
+		cred, err = s.credFactory.GetCredentialByUserAndProvider(ctx, userID, providerIdentifier)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	oauthCred, ok := cred.(*domain.OAuthCredential)
+	if !ok || len(oauthCred.Scopes) == 0 {
+		return nil, nil
+	}
+
+	return s.oauthProvider.GetPermissionIdentifiersFromScopes(ctx, providerIdentifier, oauthCred.Scopes)
+}
+
 func (s *CredentialService) UpdateLastUsedAt(ctx context.Context, credential interface{}) error {
 	switch cred := credential.(type) {
 	case *domain.OAuthCredential:
 		return s.credFactory.UpdateCredentialLastUsedAt(ctx, cred)
 	case *domain.APIKeyCredential:
 		return s.credFactory.UpdateCredentialLastUsedAt(ctx, cred)
+	case *domain.AppInstallationCredential:
+		return s.credFactory.UpdateCredentialLastUsedAt(ctx, cred)
 	}
 
 	return nil
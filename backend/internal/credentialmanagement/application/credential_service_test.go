@@ -104,7 +104,9 @@ func (suite *CredentialServiceTestSuite) SetupTest() {
 		suite.mockCredentialRepo,
 		suite.mockOAuthRepo,
 		suite.mockAPIKeyRepo,
+		nil,
 		suite.mockVaultService,
+		nil,
 	)
 
 	// Create service instance
@@ -118,6 +120,8 @@ func (suite *CredentialServiceTestSuite) SetupTest() {
 		"http://localhost:8080/callback",
 		suite.mockRedisClient,
 		suite.mockTokenRefreshService,
+		nil,
+		nil,
 	)
 }
 
@@ -0,0 +1,176 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"go.uber.org/zap"
+
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/cache"
+)
+
+const (
+	// credentialVerificationLockKey keeps instances from verifying the same credentials concurrently
+	credentialVerificationLockKey = "credential_verification_lock"
+	// credentialVerificationLockTimeout bounds how long a crashed instance blocks the verification
+	credentialVerificationLockTimeout = 10 * time.Minute
+	// credentialVerificationDelay spaces the calls to the providers to avoid triggering rate limits
+	credentialVerificationDelay = 100 * time.Millisecond
+	// defaultCredentialVerificationInterval is used when no interval is configured
+	defaultCredentialVerificationInterval = 24 * time.Hour
+	// defaultCredentialVerificationBatchSize is used when no batch size is configured
+	defaultCredentialVerificationBatchSize = 200
+)
+
+// CredentialVerificationService periodically checks credentials against their provider
+// and invalidates the ones the provider rejects
+type CredentialVerificationService struct {
+	credentialRepo domain.CredentialRepository
+	credFactory    domain.CredentialFactory
+	verifier       domain.CredentialVerifier
+	tokenRefresh   domain.TokenRefresh
+	invalidator    domain.CredentialInvalidator
+	redisClient    cache.Cache
+	interval       time.Duration
+	batchSize      int
+	obs            *observability.ObservabilityProvider
+}
+
+// NewCredentialVerificationService creates a new credential verification service
+func NewCredentialVerificationService(
+	credentialRepo domain.CredentialRepository,
+	credFactory domain.CredentialFactory,
+	verifier domain.CredentialVerifier,
+	tokenRefresh domain.TokenRefresh,
+	invalidator domain.CredentialInvalidator,
+	redisClient cache.Cache,
+	interval time.Duration,
+	batchSize int,
+	observabilityProvider *observability.ObservabilityProvider,
+) *CredentialVerificationService {
+	if interval <= 0 {
+		interval = defaultCredentialVerificationInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultCredentialVerificationBatchSize
+	}
+	return &CredentialVerificationService{
+		credentialRepo: credentialRepo,
+		credFactory:    credFactory,
+		verifier:       verifier,
+		tokenRefresh:   tokenRefresh,
+		invalidator:    invalidator,
+		redisClient:    redisClient,
+		interval:       interval,
+		batchSize:      batchSize,
+		obs:            observabilityProvider,
+	}
+}
+
+// VerifyDue verifies the credentials not verified within the interval, least recently verified first
+func (s *CredentialVerificationService) VerifyDue(ctx context.Context) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "CredentialVerificationService.VerifyDue")
+	defer span.End()
+
+	lock, err := s.redisClient.AcquireLock(ctx, credentialVerificationLockKey, credentialVerificationLockTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to acquire credential verification lock: %w", err)
+	}
+	if !lock {
+		s.obs.Logger.Debug(ctx, "Credentials are being verified by another instance")
+		return nil
+	}
+	defer func() {
+		if err := s.redisClient.ReleaseLock(ctx, credentialVerificationLockKey); err != nil {
+			s.obs.Logger.Error(ctx, "Failed to release credential verification lock", zap.Error(err))
+		}
+	}()
+
+	credentials, err := s.credentialRepo.ListDueForVerification(ctx, time.Now().Add(-s.interval), s.batchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list credentials due for verification: %w", err)
+	}
+
+	counts := make(map[domain.CredentialVerificationStatus]int)
+	for i, credential := range credentials {
+		if i > 0 {
+			time.Sleep(credentialVerificationDelay)
+		}
+
+		status, err := s.Verify(ctx, credential)
+		if err != nil {
+			return err
+		}
+		counts[status]++
+	}
+
+	s.obs.Logger.Info(ctx, "Verified due credentials",
+		zap.Int("count", len(credentials)),
+		zap.Int("valid", counts[domain.VerificationStatusValid]),
+		zap.Int("invalid", counts[domain.VerificationStatusInvalid]),
+		zap.Int("error", counts[domain.VerificationStatusError]),
+		zap.Int("unsupported", counts[domain.VerificationStatusUnsupported]))
+	return nil
+}
+
+// Verify checks a credential against its provider, invalidates it when rejected and records the result,
+// only a failure to store the result is returned
+func (s *CredentialVerificationService) Verify(ctx context.Context, credential *domain.Credential) (domain.CredentialVerificationStatus, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "CredentialVerificationService.Verify")
+	defer span.End()
+
+	err := s.verify(ctx, credential)
+	switch {
+	case err == nil:
+		credential.RecordVerification(domain.VerificationStatusValid, "")
+	case errors.Is(err, domain.ErrCredentialVerificationNotSupported):
+		credential.RecordVerification(domain.VerificationStatusUnsupported, "")
+	case errors.Is(err, domain.ErrCredentialRejected):
+		if !credential.IsValid {
+			// Already invalidated while refreshing the token
+			return domain.VerificationStatusInvalid, nil
+		}
+		if err := s.invalidator.Invalidate(ctx, credential, err.Error()); err != nil {
+			return "", err
+		}
+		return domain.VerificationStatusInvalid, nil
+	default:
+		s.obs.Logger.Warn(ctx, "Failed to verify credential",
+			zap.String("credential_id", credential.ID),
+			zap.String("provider_identifier", credential.ProviderIdentifier),
+			zap.Error(err))
+		credential.RecordVerification(domain.VerificationStatusError, err.Error())
+	}
+
+	if err := s.credentialRepo.UpdateVerification(ctx, credential); err != nil {
+		return "", fmt.Errorf("failed to update verification of credential %s: %w", credential.ID, err)
+	}
+	return credential.VerificationStatus, nil
+}
+
+// verify loads the credential with its secret, refreshes an expiring token and calls the provider
+func (s *CredentialVerificationService) verify(ctx context.Context, credential *domain.Credential) error {
+	detail, err := s.credFactory.GetCredential(ctx, credential.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load credential: %w", err)
+	}
+	if detail == nil {
+		return fmt.Errorf("credential %s no longer exists", credential.ID)
+	}
+
+	// An expired token would be rejected although the credential can still be refreshed
+	detail, err = s.tokenRefresh.RefreshAccessTokenIfNeeded(ctx, credential.ProviderIdentifier, detail)
+	if err != nil {
+		if errors.Is(err, domain.ErrCredentialRejected) {
+			// The token refresh invalidates credentials whose refresh token is rejected
+			credential.IsValid = false
+		}
+		return err
+	}
+
+	return s.verifier.VerifyCredential(ctx, credential.ProviderIdentifier, detail)
+}
@@ -0,0 +1,421 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/cache"
+	"go.uber.org/zap"
+)
+
+const (
+	// keyRotationLockKey serializes the rewrap of the ciphertexts of a region and credential type across instances
+	keyRotationLockKey = "key_rotation_lock:%s:%s"
+	// keyRotationLockTimeout bounds how long a crashed instance blocks a rotation
+	keyRotationLockTimeout = 10 * time.Minute
+	// keyRotationRunBudget stops a run before its lock expires, the next run resumes from the stored cursor
+	keyRotationRunBudget = 8 * time.Minute
+	// defaultKeyRotationBatchSize is used when no batch size is configured
+	defaultKeyRotationBatchSize = 100
+)
+
+var (
+	ErrKeyRotationNotFound          = errors.New("key rotation not found")
+	ErrKeyRotationInProgress        = errors.New("key rotation already in progress")
+	ErrKeyRotationCompleted         = errors.New("key rotation already completed")
+	ErrKeyRotationUnsupportedRegion = errors.New("vault region not configured")
+	ErrKeyRotationUnsupportedType   = errors.New("credential type has no rotatable key")
+)
+
+// KeyRotationService rotates the vault transit keys and rewraps the ciphertexts encrypted with older key versions
+type KeyRotationService struct {
+	rotationRepo   domain.KeyRotationRepository
+	ciphertextRepo domain.CiphertextRepository
+	keyManager     domain.VaultKeyManager
+	redisClient    cache.Cache
+	batchSize      int
+	obs            *observability.ObservabilityProvider
+}
+
+// NewKeyRotationService creates a new key rotation service
+func NewKeyRotationService(
+	rotationRepo domain.KeyRotationRepository,
+	ciphertextRepo domain.CiphertextRepository,
+	keyManager domain.VaultKeyManager,
+	redisClient cache.Cache,
+	batchSize int,
+	observabilityProvider *observability.ObservabilityProvider,
+) *KeyRotationService {
+	if batchSize <= 0 {
+		batchSize = defaultKeyRotationBatchSize
+	}
+	return &KeyRotationService{
+		rotationRepo:   rotationRepo,
+		ciphertextRepo: ciphertextRepo,
+		keyManager:     keyManager,
+		redisClient:    redisClient,
+		batchSize:      batchSize,
+		obs:            observabilityProvider,
+	}
+}
+
+// StartRotation rotates the key of a region and credential type and starts rewrapping its older ciphertexts in the background
+func (s *KeyRotationService) StartRotation(ctx context.Context, region domain.VaultRegion, credentialType domain.CredentialType, triggeredBy string) (*domain.KeyRotation, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "KeyRotationService.StartRotation")
+	defer span.End()
+
+	if !slices.Contains(s.keyManager.Regions(), region) {
+		return nil, ErrKeyRotationUnsupportedRegion
+	}
+	if len(domain.CiphertextSourcesFor(credentialType)) == 0 {
+		return nil, ErrKeyRotationUnsupportedType
+	}
+
+	// The lock keeps concurrent starts from rotating the key twice, it is released before the rewrap takes it
+	unlock, err := s.lockRotation(ctx, region, credentialType)
+	if err != nil {
+		return nil, err
+	}
+	rotation, err := s.startRotation(ctx, region, credentialType, triggeredBy)
+	unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	s.processInBackground(ctx, rotation)
+	return rotation, nil
+}
+
+// startRotation rotates the key and records the rotation, the rotation lock must be held
+func (s *KeyRotationService) startRotation(ctx context.Context, region domain.VaultRegion, credentialType domain.CredentialType, triggeredBy string) (*domain.KeyRotation, error) {
+	active, err := s.rotationRepo.GetActive(ctx, region, credentialType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active key rotation: %w", err)
+	}
+	if active != nil {
+		return nil, ErrKeyRotationInProgress
+	}
+
+	if err := s.keyManager.RotateEncryptionKey(ctx, region, credentialType); err != nil {
+		return nil, fmt.Errorf("failed to rotate key: %w", err)
+	}
+	keyVersion, err := s.keyManager.GetLatestKeyVersion(ctx, region, credentialType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest key version: %w", err)
+	}
+
+	total, err := s.countBelowVersion(ctx, region, credentialType, keyVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	rotation := domain.NewKeyRotation(region, credentialType, keyVersion, total, triggeredBy)
+	if err := s.rotationRepo.Create(ctx, rotation); err != nil {
+		return nil, fmt.Errorf("failed to create key rotation: %w", err)
+	}
+
+	s.obs.Logger.Info(ctx, "Key rotated, rewrapping ciphertexts",
+		zap.String("rotation_id", rotation.ID),
+		zap.String("region", string(region)),
+		zap.String("credential_type", string(credentialType)),
+		zap.Int("key_version", keyVersion),
+		zap.Int("total", total))
+
+	return rotation, nil
+}
+
+// ResumeRotation restarts a failed rotation or resumes a running one that is not being processed
+func (s *KeyRotationService) ResumeRotation(ctx context.Context, id string) (*domain.KeyRotation, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "KeyRotationService.ResumeRotation")
+	defer span.End()
+
+	rotation, err := s.rotationRepo.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key rotation: %w", err)
+	}
+	if rotation == nil {
+		return nil, ErrKeyRotationNotFound
+	}
+
+	switch rotation.Status {
+	case domain.KeyRotationStatusCompleted:
+		return nil, ErrKeyRotationCompleted
+	case domain.KeyRotationStatusFailed:
+		unlock, err := s.lockRotation(ctx, rotation.Region, rotation.CredentialType)
+		if err != nil {
+			return nil, err
+		}
+		err = s.restartRotation(ctx, rotation)
+		unlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.processInBackground(ctx, rotation)
+	return rotation, nil
+}
+
+// restartRotation marks a failed rotation as running again unless another rotation of its key is active,
+// the rotation lock must be held
+func (s *KeyRotationService) restartRotation(ctx context.Context, rotation *domain.KeyRotation) error {
+	active, err := s.rotationRepo.GetActive(ctx, rotation.Region, rotation.CredentialType)
+	if err != nil {
+		return fmt.Errorf("failed to get active key rotation: %w", err)
+	}
+	if active != nil {
+		return ErrKeyRotationInProgress
+	}
+
+	rotation.Restart()
+	if err := s.rotationRepo.Update(ctx, rotation); err != nil {
+		return fmt.Errorf("failed to update key rotation: %w", err)
+	}
+	return nil
+}
+
+// lockRotation takes the rotation lock of a region and credential type, returning ErrKeyRotationInProgress
+// if another instance holds it
+func (s *KeyRotationService) lockRotation(ctx context.Context, region domain.VaultRegion, credentialType domain.CredentialType) (func(), error) {
+	lockKey := fmt.Sprintf(keyRotationLockKey, region, credentialType)
+	lock, err := s.redisClient.AcquireLock(ctx, lockKey, keyRotationLockTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire key rotation lock: %w", err)
+	}
+	if !lock {
+		return nil, ErrKeyRotationInProgress
+	}
+	return func() {
+		if err := s.redisClient.ReleaseLock(ctx, lockKey); err != nil {
+			s.obs.Logger.Error(ctx, "Failed to release key rotation lock", zap.Error(err),
+				zap.String("region", string(region)),
+				zap.String("credential_type", string(credentialType)))
+		}
+	}, nil
+}
+
+// GetRotation retrieves a key rotation by ID
+func (s *KeyRotationService) GetRotation(ctx context.Context, id string) (*domain.KeyRotation, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "KeyRotationService.GetRotation")
+	defer span.End()
+
+	rotation, err := s.rotationRepo.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key rotation: %w", err)
+	}
+	if rotation == nil {
+		return nil, ErrKeyRotationNotFound
+	}
+
+	return rotation, nil
+}
+
+// ListRotations lists key rotations, newest first
+func (s *KeyRotationService) ListRotations(ctx context.Context, limit, offset int) ([]*domain.KeyRotation, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "KeyRotationService.ListRotations")
+	defer span.End()
+
+	return s.rotationRepo.List(ctx, limit, offset)
+}
+
+// RotateAll starts a rotation for every region and credential type, keys with a running rotation are skipped
+func (s *KeyRotationService) RotateAll(ctx context.Context) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "KeyRotationService.RotateAll")
+	defer span.End()
+
+	var errs []error
+	for _, region := range s.keyManager.Regions() {
+		for _, credentialType := range []domain.CredentialType{domain.CredentialTypeOAuth, domain.CredentialTypeAPIKey} {
+			_, err := s.StartRotation(ctx, region, credentialType, "")
+			if errors.Is(err, ErrKeyRotationInProgress) {
+				continue
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("region %s, credential type %s: %w", region, credentialType, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ResumeActive continues the rewrap of the running rotations, picking up the work of crashed or expired runs
+func (s *KeyRotationService) ResumeActive(ctx context.Context) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "KeyRotationService.ResumeActive")
+	defer span.End()
+
+	rotations, err := s.rotationRepo.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list active key rotations: %w", err)
+	}
+
+	for _, rotation := range rotations {
+		if err := s.process(ctx, rotation); err != nil {
+			s.obs.Logger.Error(ctx, "Failed to process key rotation", zap.Error(err),
+				zap.String("rotation_id", rotation.ID))
+		}
+	}
+
+	return nil
+}
+
+// processInBackground rewraps the ciphertexts of a rotation after the request that started it returns
+func (s *KeyRotationService) processInBackground(ctx context.Context, rotation *domain.KeyRotation) {
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := s.process(ctx, rotation); err != nil {
+			s.obs.Logger.Error(ctx, "Failed to process key rotation", zap.Error(err),
+				zap.String("rotation_id", rotation.ID))
+		}
+	}()
+}
+
+// process rewraps the ciphertexts of a rotation batch by batch under the rotation lock,
+// it saves the progress after every batch and stops when the run budget is spent
+func (s *KeyRotationService) process(ctx context.Context, rotation *domain.KeyRotation) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "KeyRotationService.process")
+	defer span.End()
+
+	lockKey := fmt.Sprintf(keyRotationLockKey, rotation.Region, rotation.CredentialType)
+	lock, err := s.redisClient.AcquireLock(ctx, lockKey, keyRotationLockTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to acquire key rotation lock: %w", err)
+	}
+	if !lock {
+		s.obs.Logger.Debug(ctx, "Key rotation is being processed by another instance",
+			zap.String("rotation_id", rotation.ID))
+		return nil
+	}
+	defer func() {
+		if err := s.redisClient.ReleaseLock(ctx, lockKey); err != nil {
+			s.obs.Logger.Error(ctx, "Failed to release key rotation lock", zap.Error(err),
+				zap.String("rotation_id", rotation.ID))
+		}
+	}()
+
+	// Another instance may have made progress since the rotation was loaded
+	current, err := s.rotationRepo.Get(ctx, rotation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get key rotation: %w", err)
+	}
+	if current == nil || !current.IsActive() {
+		return nil
+	}
+	rotation = current
+
+	deadline := time.Now().Add(keyRotationRunBudget)
+	for time.Now().Before(deadline) {
+		ciphertexts, err := s.ciphertextRepo.ListBelowVersion(ctx, rotation.Source, rotation.Region, rotation.KeyVersion, rotation.Cursor, s.batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list ciphertexts: %w", err)
+		}
+
+		for _, ciphertext := range ciphertexts {
+			s.rewrap(ctx, rotation, ciphertext)
+		}
+		if len(ciphertexts) > 0 {
+			rotation.Advance(ciphertexts[len(ciphertexts)-1].RowID)
+		}
+
+		if len(ciphertexts) < s.batchSize && !rotation.NextSource() {
+			return s.complete(ctx, rotation)
+		}
+
+		if err := s.rotationRepo.Update(ctx, rotation); err != nil {
+			return fmt.Errorf("failed to update key rotation: %w", err)
+		}
+	}
+
+	s.obs.Logger.Info(ctx, "Key rotation run budget spent, resuming on next run",
+		zap.String("rotation_id", rotation.ID),
+		zap.Int("rewrapped", rotation.Rewrapped),
+		zap.Int("total", rotation.Total))
+	return nil
+}
+
+// rewrap re-encrypts a ciphertext with the latest key version and stores it unless the row changed meanwhile
+func (s *KeyRotationService) rewrap(ctx context.Context, rotation *domain.KeyRotation, ciphertext *domain.StoredCiphertext) {
+	rewrapped, err := s.keyManager.ReWrapData(ctx, ciphertext.Metadata)
+	if err == nil && rewrapped.KeyVersion < rotation.KeyVersion {
+		err = fmt.Errorf("rewrapped to key version %d, expected at least %d", rewrapped.KeyVersion, rotation.KeyVersion)
+	}
+	if err != nil {
+		rotation.Failed++
+		rotation.LastError = fmt.Sprintf("%s %s: %s", rotation.Source, ciphertext.RowID, err.Error())
+		s.obs.Logger.Warn(ctx, "Failed to rewrap ciphertext", zap.Error(err),
+			zap.String("rotation_id", rotation.ID),
+			zap.String("source", string(rotation.Source)),
+			zap.String("row_id", ciphertext.RowID))
+		return
+	}
+
+	replaced, err := s.ciphertextRepo.Replace(ctx, rotation.Source, ciphertext, rewrapped)
+	if err != nil {
+		rotation.Failed++
+		rotation.LastError = fmt.Sprintf("%s %s: %s", rotation.Source, ciphertext.RowID, err.Error())
+		s.obs.Logger.Warn(ctx, "Failed to store rewrapped ciphertext", zap.Error(err),
+			zap.String("rotation_id", rotation.ID),
+			zap.String("source", string(rotation.Source)),
+			zap.String("row_id", ciphertext.RowID))
+		return
+	}
+
+	// A row updated since it was listed holds a ciphertext of the latest key version already
+	if replaced {
+		rotation.Rewrapped++
+	} else {
+		rotation.Skipped++
+	}
+}
+
+// complete finishes a rotation and, once no ciphertext of an older key version is left, disables decryption with them
+func (s *KeyRotationService) complete(ctx context.Context, rotation *domain.KeyRotation) error {
+	if rotation.Failed == 0 {
+		remaining, err := s.countBelowVersion(ctx, rotation.Region, rotation.CredentialType, rotation.KeyVersion)
+		if err != nil {
+			return err
+		}
+		if remaining > 0 {
+			rotation.Failed = remaining
+			rotation.LastError = fmt.Sprintf("%d ciphertexts still use an older key version", remaining)
+		}
+	}
+
+	if rotation.Failed == 0 {
+		if err := s.keyManager.SetMinDecryptionVersion(ctx, rotation.Region, rotation.CredentialType, rotation.KeyVersion); err != nil {
+			rotation.Failed = 1
+			rotation.LastError = fmt.Sprintf("failed to set min decryption version: %s", err.Error())
+		}
+	}
+
+	rotation.Complete()
+	if err := s.rotationRepo.Update(ctx, rotation); err != nil {
+		return fmt.Errorf("failed to update key rotation: %w", err)
+	}
+
+	s.obs.Logger.Info(ctx, "Key rotation finished",
+		zap.String("rotation_id", rotation.ID),
+		zap.String("status", string(rotation.Status)),
+		zap.Int("rewrapped", rotation.Rewrapped),
+		zap.Int("skipped", rotation.Skipped),
+		zap.Int("failed", rotation.Failed))
+	return nil
+}
+
+// countBelowVersion counts the ciphertexts of a credential type in the region still encrypted with an older key version
+func (s *KeyRotationService) countBelowVersion(ctx context.Context, region domain.VaultRegion, credentialType domain.CredentialType, keyVersion int) (int, error) {
+	total := 0
+	for _, source := range domain.CiphertextSourcesFor(credentialType) {
+		count, err := s.ciphertextRepo.CountBelowVersion(ctx, source, region, keyVersion)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count ciphertexts of %s: %w", source, err)
+		}
+		total += count
+	}
+	return total, nil
+}
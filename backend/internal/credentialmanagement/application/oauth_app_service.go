@@ -0,0 +1,186 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	observability "github.com/context-space/cloud-observability"
+
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+)
+
+// ErrOAuthAppNotFound is returned when the owner registered no OAuth app for the provider
+var ErrOAuthAppNotFound = errors.New("oauth app not found")
+
+// SaveOAuthAppInput holds the fields of an OAuth app to register or update
+type SaveOAuthAppInput struct {
+	ClientID string
+	// ClientSecret may be left empty when updating an app to keep its current secret
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	APIBaseURL   string
+}
+
+// OAuthAppService manages the OAuth apps users and organizations register with providers
+type OAuthAppService struct {
+	appRepo      domain.OAuthAppRepository
+	vaultService domain.VaultService
+	obs          *observability.ObservabilityProvider
+}
+
+var _ domain.OAuthAppResolver = (*OAuthAppService)(nil)
+
+// NewOAuthAppService creates a new OAuth app service
+func NewOAuthAppService(
+	appRepo domain.OAuthAppRepository,
+	vaultService domain.VaultService,
+	observabilityProvider *observability.ObservabilityProvider,
+) *OAuthAppService {
+	return &OAuthAppService{
+		appRepo:      appRepo,
+		vaultService: vaultService,
+		obs:          observabilityProvider,
+	}
+}
+
+// Save registers the OAuth app of an owner for a provider, replacing the fields of the existing one,
+// the app is owned by the organization if organizationID is not empty
+func (s *OAuthAppService) Save(ctx context.Context, userID, organizationID, providerIdentifier string, input SaveOAuthAppInput) (*domain.OAuthApp, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "OAuthAppService.Save")
+	defer span.End()
+
+	app, err := s.appRepo.GetByOwnerAndProvider(ctx, userID, organizationID, providerIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oauth app: %w", err)
+	}
+
+	create := app == nil
+	if create {
+		app, err = domain.NewOAuthApp(userID, organizationID, providerIdentifier, input.ClientID, input.ClientSecret)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if input.ClientID == "" {
+			return nil, domain.ErrOAuthAppClientIDRequired
+		}
+		app.ClientID = input.ClientID
+		app.ClientSecret = input.ClientSecret
+	}
+
+	if err := app.SetEndpoints(input.AuthURL, input.TokenURL, input.APIBaseURL); err != nil {
+		return nil, err
+	}
+
+	if app.ClientSecret != "" {
+		metadata, err := s.vaultService.EncryptData(ctx, app.ClientSecret, domain.RegionEU, domain.CredentialTypeOAuth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt client secret: %w", err)
+		}
+		app.EncryptionMetadata = metadata
+	}
+
+	if create {
+		err = s.appRepo.Create(ctx, app)
+	} else {
+		err = s.appRepo.Update(ctx, app)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save oauth app: %w", err)
+	}
+
+	return app, nil
+}
+
+// Get returns the OAuth app of an owner for a provider, without its client secret
+func (s *OAuthAppService) Get(ctx context.Context, userID, organizationID, providerIdentifier string) (*domain.OAuthApp, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "OAuthAppService.Get")
+	defer span.End()
+
+	app, err := s.appRepo.GetByOwnerAndProvider(ctx, userID, organizationID, providerIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	if app == nil {
+		return nil, ErrOAuthAppNotFound
+	}
+
+	return app, nil
+}
+
+// ListByUser returns the personal OAuth apps of a user, without their client secrets
+func (s *OAuthAppService) ListByUser(ctx context.Context, userID string) ([]*domain.OAuthApp, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "OAuthAppService.ListByUser")
+	defer span.End()
+
+	return s.appRepo.ListByUser(ctx, userID)
+}
+
+// ListByOrganization returns the OAuth apps of an organization, without their client secrets
+func (s *OAuthAppService) ListByOrganization(ctx context.Context, organizationID string) ([]*domain.OAuthApp, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "OAuthAppService.ListByOrganization")
+	defer span.End()
+
+	return s.appRepo.ListByOrganization(ctx, organizationID)
+}
+
+// Delete removes the OAuth app of an owner for a provider, credentials issued through it fall back
+// to the client of the provider manifest and have to be connected again once their tokens expire
+func (s *OAuthAppService) Delete(ctx context.Context, userID, organizationID, providerIdentifier string) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "OAuthAppService.Delete")
+	defer span.End()
+
+	app, err := s.appRepo.GetByOwnerAndProvider(ctx, userID, organizationID, providerIdentifier)
+	if err != nil {
+		return err
+	}
+	if app == nil {
+		return ErrOAuthAppNotFound
+	}
+
+	return s.appRepo.Delete(ctx, app.ID)
+}
+
+// GetOAuthApp returns an OAuth app with its decrypted client secret, or nil if it no longer exists
+func (s *OAuthAppService) GetOAuthApp(ctx context.Context, id string) (*domain.OAuthApp, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "OAuthAppService.GetOAuthApp")
+	defer span.End()
+
+	app, err := s.appRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oauth app: %w", err)
+	}
+
+	return s.decrypt(ctx, app)
+}
+
+// GetOAuthAppForOwner returns the OAuth app registered by an owner for a provider with its decrypted client secret,
+// or nil if the owner uses the client of the provider manifest
+func (s *OAuthAppService) GetOAuthAppForOwner(ctx context.Context, userID, organizationID, providerIdentifier string) (*domain.OAuthApp, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "OAuthAppService.GetOAuthAppForOwner")
+	defer span.End()
+
+	app, err := s.appRepo.GetByOwnerAndProvider(ctx, userID, organizationID, providerIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oauth app: %w", err)
+	}
+
+	return s.decrypt(ctx, app)
+}
+
+// decrypt sets the client secret of an app from its encryption metadata
+func (s *OAuthAppService) decrypt(ctx context.Context, app *domain.OAuthApp) (*domain.OAuthApp, error) {
+	if app == nil {
+		return nil, nil
+	}
+
+	secret, err := s.vaultService.DecryptData(ctx, app.EncryptionMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt client secret: %w", err)
+	}
+	app.ClientSecret = secret
+
+	return app, nil
+}
@@ -0,0 +1,165 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+// revokeDueBatchSize bounds how many due revocations are retried per query
+const revokeDueBatchSize = 50
+
+// TokenRevocationService revokes the tokens of deleted OAuth credentials at their provider
+type TokenRevocationService struct {
+	revocationRepo domain.TokenRevocationRepository
+	oauthRepo      domain.OAuthCredentialRepository
+	oauthProvider  domain.OAuthProvider
+	vaultService   domain.VaultService
+	appResolver    domain.OAuthAppResolver
+	obs            *observability.ObservabilityProvider
+}
+
+// NewTokenRevocationService creates a new token revocation service
+func NewTokenRevocationService(
+	revocationRepo domain.TokenRevocationRepository,
+	oauthRepo domain.OAuthCredentialRepository,
+	oauthProvider domain.OAuthProvider,
+	vaultService domain.VaultService,
+	appResolver domain.OAuthAppResolver,
+	observabilityProvider *observability.ObservabilityProvider,
+) *TokenRevocationService {
+	return &TokenRevocationService{
+		revocationRepo: revocationRepo,
+		oauthRepo:      oauthRepo,
+		oauthProvider:  oauthProvider,
+		vaultService:   vaultService,
+		appResolver:    appResolver,
+		obs:            observabilityProvider,
+	}
+}
+
+// Prepare captures the encrypted token of an OAuth credential before it is deleted,
+// it returns nil when the credential holds no token to revoke
+func (s *TokenRevocationService) Prepare(ctx context.Context, credential *domain.Credential) (*domain.TokenRevocation, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "TokenRevocationService.Prepare")
+	defer span.End()
+
+	if credential.Type != domain.CredentialTypeOAuth {
+		return nil, nil
+	}
+
+	oauthCred, err := s.oauthRepo.GetByCredentialID(ctx, credential.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oauth credential: %w", err)
+	}
+	if oauthCred == nil || oauthCred.EncryptionMetadata == nil {
+		return nil, nil
+	}
+
+	// The OAuth row does not carry the base credential fields
+	oauthCred.Credential = credential
+	return domain.NewTokenRevocation(oauthCred), nil
+}
+
+// Schedule records a revocation and makes the first attempt, failed attempts are retried by RevokeDue
+func (s *TokenRevocationService) Schedule(ctx context.Context, revocation *domain.TokenRevocation) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "TokenRevocationService.Schedule")
+	defer span.End()
+
+	if err := s.revocationRepo.Create(ctx, revocation); err != nil {
+		return fmt.Errorf("failed to create token revocation: %w", err)
+	}
+
+	return s.attempt(ctx, revocation)
+}
+
+// RevokeDue retries the pending revocations whose next attempt is due
+func (s *TokenRevocationService) RevokeDue(ctx context.Context) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "TokenRevocationService.RevokeDue")
+	defer span.End()
+
+	attempted := 0
+	for {
+		revocations, err := s.revocationRepo.ListDue(ctx, time.Now(), revokeDueBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list due token revocations: %w", err)
+		}
+
+		for _, revocation := range revocations {
+			if err := s.attempt(ctx, revocation); err != nil {
+				return err
+			}
+			attempted++
+		}
+
+		// Failed attempts move their next attempt into the future, so the next page holds new revocations
+		if len(revocations) < revokeDueBatchSize {
+			break
+		}
+	}
+
+	s.obs.Logger.Info(ctx, "Retried due token revocations", zap.Int("count", attempted))
+	return nil
+}
+
+// ListByUser returns the token revocations of a user, newest first
+func (s *TokenRevocationService) ListByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.TokenRevocation, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "TokenRevocationService.ListByUser")
+	defer span.End()
+
+	return s.revocationRepo.ListByUser(ctx, userID, limit, offset)
+}
+
+// attempt revokes the token once and stores the outcome, only a failure to store the outcome is returned
+func (s *TokenRevocationService) attempt(ctx context.Context, revocation *domain.TokenRevocation) error {
+	now := time.Now()
+	err := s.revoke(ctx, revocation)
+	switch {
+	case err == nil:
+		revocation.MarkRevoked(now)
+	case errors.Is(err, domain.ErrTokenRevocationNotSupported):
+		revocation.MarkUnsupported(now)
+	default:
+		revocation.MarkAttemptFailed(err, now)
+		s.obs.Logger.Warn(ctx, "Failed to revoke token",
+			zap.String("revocation_id", revocation.ID),
+			zap.String("provider_identifier", revocation.ProviderIdentifier),
+			zap.Int("attempts", revocation.Attempts),
+			zap.Error(err))
+	}
+
+	if err := s.revocationRepo.Update(ctx, revocation); err != nil {
+		return fmt.Errorf("failed to update token revocation %s: %w", revocation.ID, err)
+	}
+
+	return nil
+}
+
+// revoke decrypts the stored token and revokes it at the provider
+func (s *TokenRevocationService) revoke(ctx context.Context, revocation *domain.TokenRevocation) error {
+	if revocation.EncryptionMetadata == nil {
+		return errors.New("no token stored for revocation")
+	}
+
+	token := &oauth2.Token{}
+	if err := s.vaultService.DecryptJSON(ctx, revocation.EncryptionMetadata, token); err != nil {
+		return fmt.Errorf("failed to decrypt token: %w", err)
+	}
+
+	// Tokens are revoked with the client they were issued to
+	if revocation.OAuthAppID != "" && s.appResolver != nil {
+		app, err := s.appResolver.GetOAuthApp(ctx, revocation.OAuthAppID)
+		if err != nil {
+			return fmt.Errorf("failed to get OAuth app: %w", err)
+		}
+		ctx = domain.WithOAuthApp(ctx, app)
+	}
+
+	return s.oauthProvider.RevokeToken(ctx, revocation.ProviderIdentifier, token)
+}
@@ -0,0 +1,108 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	"github.com/context-space/context-space/backend/internal/shared/events"
+	credentialmanagement_mocks "github.com/context-space/context-space/backend/internal/shared/testing/mocks/credentialmanagement"
+	shared_mocks "github.com/context-space/context-space/backend/internal/shared/testing/mocks/shared"
+)
+
+// memoryTokenRevocationRepository keeps token revocations by credential ID
+type memoryTokenRevocationRepository struct {
+	byCredential map[string]*domain.TokenRevocation
+}
+
+func (r *memoryTokenRevocationRepository) Create(ctx context.Context, revocation *domain.TokenRevocation) error {
+	r.byCredential[revocation.CredentialID] = revocation
+	return nil
+}
+
+func (r *memoryTokenRevocationRepository) Update(ctx context.Context, revocation *domain.TokenRevocation) error {
+	r.byCredential[revocation.CredentialID] = revocation
+	return nil
+}
+
+func (r *memoryTokenRevocationRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.TokenRevocation, error) {
+	return nil, nil
+}
+
+func (r *memoryTokenRevocationRepository) ListByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.TokenRevocation, error) {
+	return nil, nil
+}
+
+func TestHandleUserDeletedContinuesAfterFailures(t *testing.T) {
+	ctx := context.Background()
+	obs, _, err := observability.InitializeObservabilityProvider(ctx, &observability.LogConfig{
+		Level:       observability.ParseLogLevel("error"),
+		Format:      observability.ParseLogFormat("json"),
+		OutputPaths: []string{"stdout"},
+	}, &observability.TracingConfig{Enabled: false}, &observability.MetricsConfig{Enabled: false})
+	require.NoError(t, err)
+
+	// The token of the first credential cannot be revoked, the second credential cannot be deleted
+	credentials := []*domain.Credential{
+		{ID: "cred-1", UserID: "user-1", ProviderIdentifier: "github", Type: domain.CredentialTypeOAuth},
+		{ID: "cred-2", UserID: "user-1", ProviderIdentifier: "notion", Type: domain.CredentialTypeOAuth},
+		{ID: "cred-3", UserID: "user-1", ProviderIdentifier: "slack", Type: domain.CredentialTypeOAuth},
+	}
+
+	credentialRepo := &credentialmanagement_mocks.MockCredentialRepository{}
+	oauthRepo := &credentialmanagement_mocks.MockOAuthCredentialRepository{}
+	credentialRepo.On("ListByUser", mock.Anything, "user-1").Return(credentials, nil)
+	for _, credential := range credentials {
+		credentialRepo.On("GetByID", mock.Anything, credential.ID).Return(credential, nil)
+		oauthRepo.On("GetByCredentialID", mock.Anything, credential.ID).
+			Return(&domain.OAuthCredential{EncryptionMetadata: &domain.EncryptionMetadata{}}, nil)
+	}
+	credentialRepo.On("Delete", mock.Anything, "cred-1").Return(nil)
+	credentialRepo.On("Delete", mock.Anything, "cred-2").Return(errors.New("database unavailable"))
+	credentialRepo.On("Delete", mock.Anything, "cred-3").Return(nil)
+
+	vaultService := &credentialmanagement_mocks.MockVaultService{}
+	vaultService.On("DecryptJSON", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	oauthProvider := &credentialmanagement_mocks.MockOAuthProvider{}
+	oauthProvider.On("RevokeToken", mock.Anything, "github", mock.Anything).Return(errors.New("revocation endpoint unavailable"))
+	oauthProvider.On("RevokeToken", mock.Anything, "slack", mock.Anything).Return(nil)
+
+	eventBus := &shared_mocks.MockEventBus{}
+	eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+	revocationRepo := &memoryTokenRevocationRepository{byCredential: map[string]*domain.TokenRevocation{}}
+	service := &CredentialService{
+		credentialRepo:    credentialRepo,
+		eventBus:          eventBus,
+		eventTypes:        CredentialEventTypes{Deleted: "credential.deleted"},
+		obs:               obs,
+		revocationService: NewTokenRevocationService(revocationRepo, oauthRepo, oauthProvider, vaultService, nil, obs),
+	}
+
+	err = service.HandleUserDeleted(ctx, events.Event{Metadata: events.Metadata{UserID: "user-1"}})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cred-2")
+	assert.NotContains(t, err.Error(), "cred-1")
+	for _, credential := range credentials {
+		credentialRepo.AssertCalled(t, "Delete", mock.Anything, credential.ID)
+	}
+	eventBus.AssertNumberOfCalls(t, "Publish", 2)
+
+	// The failed revocation is retried later with its token, the credential left behind has no revocation
+	require.Contains(t, revocationRepo.byCredential, "cred-1")
+	assert.Equal(t, domain.TokenRevocationStatusPending, revocationRepo.byCredential["cred-1"].Status)
+	assert.NotNil(t, revocationRepo.byCredential["cred-1"].EncryptionMetadata)
+	assert.NotContains(t, revocationRepo.byCredential, "cred-2")
+	require.Contains(t, revocationRepo.byCredential, "cred-3")
+	assert.Equal(t, domain.TokenRevocationStatusRevoked, revocationRepo.byCredential["cred-3"].Status)
+	assert.Nil(t, revocationRepo.byCredential["cred-3"].EncryptionMetadata)
+}
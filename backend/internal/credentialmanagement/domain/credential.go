@@ -18,14 +18,18 @@ const (
 	CredentialTypeAPIKey CredentialType = "apikey"
 	// CredentialTypeBasicAuth represents basic authentication credentials
 	CredentialTypeBasicAuth CredentialType = "basicauth"
+	// CredentialTypeAppInstallation represents app installation credentials, such as GitHub App installations
+	CredentialTypeAppInstallation CredentialType = "app_installation"
 	// CredentialTypeNone represents no credentials (public API)
 	CredentialTypeNone CredentialType = "none"
 )
 
 // Credential defines the common attributes for all credentials
 type Credential struct {
-	ID                 string
-	UserID             string
+	ID     string
+	UserID string
+	// OrganizationID is set for credentials shared with an organization, UserID is then the member who connected it
+	OrganizationID     string
 	ProviderIdentifier string
 	Type               CredentialType
 	IsValid            bool
@@ -33,6 +37,10 @@ type Credential struct {
 	UpdatedAt          time.Time
 	LastUsedAt         time.Time
 	DeletedAt          *time.Time
+	// LastVerifiedAt, VerificationStatus and VerificationError hold the result of the last check against the provider
+	LastVerifiedAt     *time.Time
+	VerificationStatus CredentialVerificationStatus
+	VerificationError  string
 }
 
 // NewCredential creates a new credential with default values
@@ -55,6 +63,14 @@ func NewCredential(userID, providerIdentifier string, credType CredentialType) (
 	}, nil
 }
 
+// OwnerID returns the organization ID of a shared credential, or the user ID of a personal one
+func (c *Credential) OwnerID() string {
+	if c.OrganizationID != "" {
+		return c.OrganizationID
+	}
+	return c.UserID
+}
+
 // OAuthCredential represents OAuth credentials
 type OAuthCredential struct {
 	*Credential
@@ -64,6 +80,10 @@ type OAuthCredential struct {
 	Token *oauth2.Token
 	// Scopes granted to this credential
 	Scopes []string
+	// OAuthAppID is set for credentials issued through an OAuth app of their owner
+	OAuthAppID string
+	// In-memory only
+	OAuthApp *OAuthApp
 }
 
 // NewOAuthCredential creates a new OAuth credential
@@ -106,6 +126,45 @@ func NewAPIKeyCredential(userID, providerIdentifier, apiKey string) (*APIKeyCred
 	}, nil
 }
 
+// AppInstallationCredential represents the installation of a provider app, the adapter mints short-lived
+// installation tokens on demand by signing in as the app with its private key
+type AppInstallationCredential struct {
+	*Credential
+	// AppID identifies the app the installation belongs to
+	AppID string
+	// InstallationID identifies the installation of the app on the account it acts for
+	InstallationID string
+	// Encryption metadata
+	EncryptionMetadata *EncryptionMetadata
+	// In-memory only, PEM encoded
+	PrivateKey string
+}
+
+// NewAppInstallationCredential creates a new app installation credential
+func NewAppInstallationCredential(userID, providerIdentifier, appID, installationID, privateKey string) (*AppInstallationCredential, error) {
+	if appID == "" {
+		return nil, errors.New("app ID is required")
+	}
+	if installationID == "" {
+		return nil, errors.New("installation ID is required")
+	}
+	if privateKey == "" {
+		return nil, errors.New("private key is required")
+	}
+
+	cred, err := NewCredential(userID, providerIdentifier, CredentialTypeAppInstallation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AppInstallationCredential{
+		Credential:     cred,
+		AppID:          appID,
+		InstallationID: installationID,
+		PrivateKey:     privateKey,
+	}, nil
+}
+
 // BasicAuthCredential represents basic authentication credentials
 type BasicAuthCredential struct {
 	*Credential
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrCredentialRejected is returned when the provider rejects a credential as invalid, revoked or expired
+var ErrCredentialRejected = errors.New("credential rejected by provider")
+
+// ErrCredentialVerificationNotSupported is returned when the provider adapter cannot verify credentials
+var ErrCredentialVerificationNotSupported = errors.New("credential verification not supported")
+
+// CredentialVerificationStatus is the result of the last verification of a credential
+type CredentialVerificationStatus string
+
+const (
+	// VerificationStatusValid means the provider accepted the credential
+	VerificationStatusValid CredentialVerificationStatus = "valid"
+	// VerificationStatusInvalid means the provider rejected the credential, which was invalidated
+	VerificationStatusInvalid CredentialVerificationStatus = "invalid"
+	// VerificationStatusError means the verification was inconclusive, such as a provider outage
+	VerificationStatusError CredentialVerificationStatus = "error"
+	// VerificationStatusUnsupported means the provider adapter cannot verify credentials
+	VerificationStatusUnsupported CredentialVerificationStatus = "unsupported"
+)
+
+// CredentialVerifier checks credentials against their provider
+// This interface acts as an anti-corruption layer between Credential Management and Provider Adapter
+type CredentialVerifier interface {
+	// VerifyCredential returns ErrCredentialRejected when the provider rejects the credential and
+	// ErrCredentialVerificationNotSupported when the provider adapter cannot verify credentials
+	VerifyCredential(ctx context.Context, providerIdentifier string, credential interface{}) error
+}
+
+// CredentialInvalidator invalidates credentials the provider no longer accepts and notifies their owner
+type CredentialInvalidator interface {
+	// Invalidate marks the credential as invalid and publishes a credential.invalidated event
+	Invalidate(ctx context.Context, credential *Credential, reason string) error
+}
+
+// RecordVerification records the result of a verification
+func (c *Credential) RecordVerification(status CredentialVerificationStatus, verificationError string) {
+	now := time.Now()
+	c.LastVerifiedAt = &now
+	c.VerificationStatus = status
+	c.VerificationError = verificationError
+	c.UpdatedAt = now
+}
+
+// Invalidate marks the credential as no longer accepted by the provider
+func (c *Credential) Invalidate(reason string) {
+	c.IsValid = false
+	c.RecordVerification(VerificationStatusInvalid, reason)
+}
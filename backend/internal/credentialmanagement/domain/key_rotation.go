@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KeyRotationStatus indicates the state of a transit key rotation
+type KeyRotationStatus string
+
+const (
+	// KeyRotationStatusRunning means ciphertexts are being rewrapped with the new key version
+	KeyRotationStatusRunning KeyRotationStatus = "running"
+	// KeyRotationStatusCompleted means every ciphertext was rewrapped and older key versions were disabled for decryption
+	KeyRotationStatusCompleted KeyRotationStatus = "completed"
+	// KeyRotationStatusFailed means some ciphertexts could not be rewrapped, older key versions remain usable
+	KeyRotationStatusFailed KeyRotationStatus = "failed"
+)
+
+// CiphertextSource is a table storing ciphertexts encrypted with the transit key of a credential type
+type CiphertextSource string
+
+const (
+	// CiphertextSourceOAuthCredentials holds the encrypted tokens of OAuth credentials
+	CiphertextSourceOAuthCredentials CiphertextSource = "oauth_credentials"
+	// CiphertextSourceAPIKeyCredentials holds the encrypted keys of API key credentials
+	CiphertextSourceAPIKeyCredentials CiphertextSource = "apikey_credentials"
+	// CiphertextSourceAppInstallationCredentials holds the encrypted private keys of app installation credentials
+	CiphertextSourceAppInstallationCredentials CiphertextSource = "app_installation_credentials"
+	// CiphertextSourceTokenRevocations holds the encrypted tokens of deleted OAuth credentials pending revocation
+	CiphertextSourceTokenRevocations CiphertextSource = "token_revocations"
+	// CiphertextSourceOAuthApps holds the encrypted client secrets of OAuth apps
+	CiphertextSourceOAuthApps CiphertextSource = "oauth_apps"
+	// CiphertextSourceModuleSecrets holds the secrets other modules encrypted through the credential contract,
+	// in the secret stores they registered
+	CiphertextSourceModuleSecrets CiphertextSource = "module_secrets"
+)
+
+// ciphertextSources lists the tables to rewrap after rotating the transit key of a credential type, in order
+var ciphertextSources = map[CredentialType][]CiphertextSource{
+	CredentialTypeOAuth:  {CiphertextSourceOAuthCredentials, CiphertextSourceTokenRevocations, CiphertextSourceOAuthApps},
+	CredentialTypeAPIKey: {CiphertextSourceAPIKeyCredentials, CiphertextSourceAppInstallationCredentials, CiphertextSourceModuleSecrets},
+}
+
+// CiphertextSourcesFor returns the tables holding ciphertexts of a credential type
+func CiphertextSourcesFor(credentialType CredentialType) []CiphertextSource {
+	return ciphertextSources[credentialType]
+}
+
+// KeyRotation tracks the rotation of the transit key of a region and credential type
+// and the resumable rewrap of the ciphertexts encrypted with older versions of the key
+type KeyRotation struct {
+	ID             string
+	Region         VaultRegion
+	CredentialType CredentialType
+	// KeyVersion is the key version created by the rotation, ciphertexts of older versions are rewrapped to it
+	KeyVersion int
+	Status     KeyRotationStatus
+	// Source and Cursor record the progress, the last rewrapped row of the source being processed
+	Source      CiphertextSource
+	Cursor      string
+	Total       int
+	Rewrapped   int
+	Skipped     int
+	Failed      int
+	LastError   string
+	TriggeredBy string
+	StartedAt   time.Time
+	CompletedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewKeyRotation creates a running rotation rewrapping the ciphertexts of a credential type to the given key version
+func NewKeyRotation(region VaultRegion, credentialType CredentialType, keyVersion, total int, triggeredBy string) *KeyRotation {
+	now := time.Now()
+	rotation := &KeyRotation{
+		ID:             uuid.New().String(),
+		Region:         region,
+		CredentialType: credentialType,
+		KeyVersion:     keyVersion,
+		Status:         KeyRotationStatusRunning,
+		Total:          total,
+		TriggeredBy:    triggeredBy,
+		StartedAt:      now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if sources := CiphertextSourcesFor(credentialType); len(sources) > 0 {
+		rotation.Source = sources[0]
+	}
+	return rotation
+}
+
+// Advance records the last processed row of the current source
+func (r *KeyRotation) Advance(cursor string) {
+	r.Cursor = cursor
+	r.UpdatedAt = time.Now()
+}
+
+// NextSource moves on to the next source once the current one is exhausted, it returns false if none is left
+func (r *KeyRotation) NextSource() bool {
+	sources := CiphertextSourcesFor(r.CredentialType)
+	for i, source := range sources {
+		if source == r.Source && i+1 < len(sources) {
+			r.Source = sources[i+1]
+			r.Cursor = ""
+			r.UpdatedAt = time.Now()
+			return true
+		}
+	}
+	return false
+}
+
+// Complete marks the rotation as finished, it fails if any ciphertext could not be rewrapped
+func (r *KeyRotation) Complete() {
+	now := time.Now()
+	if r.Failed > 0 {
+		r.Status = KeyRotationStatusFailed
+	} else {
+		r.Status = KeyRotationStatusCompleted
+	}
+	r.CompletedAt = &now
+	r.UpdatedAt = now
+}
+
+// Restart rescans every source of a failed rotation, ciphertexts already on the key version are skipped
+func (r *KeyRotation) Restart() {
+	sources := CiphertextSourcesFor(r.CredentialType)
+	if len(sources) > 0 {
+		r.Source = sources[0]
+	}
+	r.Cursor = ""
+	r.Failed = 0
+	r.LastError = ""
+	r.Status = KeyRotationStatusRunning
+	r.CompletedAt = nil
+	r.UpdatedAt = time.Now()
+}
+
+// IsActive returns true if the rotation still has ciphertexts to rewrap
+func (r *KeyRotation) IsActive() bool {
+	return r.Status == KeyRotationStatusRunning
+}
+
+// StoredCiphertext is a ciphertext stored in a row of a ciphertext source
+type StoredCiphertext struct {
+	// RowID identifies the row within its source
+	RowID    string
+	Metadata *EncryptionMetadata
+}
+
+// KeyRotationRepository defines the interface for key rotation data access
+type KeyRotationRepository interface {
+	// Get retrieves a key rotation by ID
+	Get(ctx context.Context, id string) (*KeyRotation, error)
+
+	// GetActive retrieves the running rotation of a region and credential type, nil if there is none
+	GetActive(ctx context.Context, region VaultRegion, credentialType CredentialType) (*KeyRotation, error)
+
+	// ListActive lists all running rotations
+	ListActive(ctx context.Context) ([]*KeyRotation, error)
+
+	// List lists key rotations, newest first
+	List(ctx context.Context, limit, offset int) ([]*KeyRotation, error)
+
+	// Create creates a new key rotation
+	Create(ctx context.Context, rotation *KeyRotation) error
+
+	// Update updates a key rotation
+	Update(ctx context.Context, rotation *KeyRotation) error
+}
+
+// CiphertextRepository reads and replaces the ciphertexts stored by credentials for rewrapping
+type CiphertextRepository interface {
+	// CountBelowVersion counts the ciphertexts of a source encrypted in the region with a key version older than keyVersion
+	CountBelowVersion(ctx context.Context, source CiphertextSource, region VaultRegion, keyVersion int) (int, error)
+
+	// ListBelowVersion lists the ciphertexts of a source encrypted in the region with a key version older than keyVersion,
+	// ordered by row ID and starting after the given row ID
+	ListBelowVersion(ctx context.Context, source CiphertextSource, region VaultRegion, keyVersion int, afterRowID string, limit int) ([]*StoredCiphertext, error)
+
+	// Replace stores the rewrapped ciphertext of a row if it still holds the original one, it returns false otherwise
+	Replace(ctx context.Context, source CiphertextSource, original *StoredCiphertext, rewrapped *EncryptionMetadata) (bool, error)
+}
+
+// MinDecryptionVersionRepository stores the min decryption versions of the backends that keep no key state of their own
+type MinDecryptionVersionRepository interface {
+	// List returns the min decryption version of every region and credential type that has one
+	List(ctx context.Context) (map[VaultRegion]map[CredentialType]int, error)
+
+	// Set stores the min decryption version of a region and credential type, a lower version never replaces a higher one
+	Set(ctx context.Context, region VaultRegion, credentialType CredentialType, version int) error
+}
+
+// VaultKeyManager is implemented by vault services whose keys can be rotated
+type VaultKeyManager interface {
+	// Regions returns the configured vault regions
+	Regions() []VaultRegion
+
+	// RotateEncryptionKey creates a new version of the key of a region and credential type
+	RotateEncryptionKey(ctx context.Context, region VaultRegion, credentialType CredentialType) error
+
+	// GetLatestKeyVersion returns the latest version of the key of a region and credential type
+	GetLatestKeyVersion(ctx context.Context, region VaultRegion, credentialType CredentialType) (int, error)
+
+	// ReWrapData re-encrypts a ciphertext with the latest key version without exposing the plaintext
+	ReWrapData(ctx context.Context, currentMetadata *EncryptionMetadata) (*EncryptionMetadata, error)
+
+	// SetMinDecryptionVersion disables decryption with key versions older than the given one
+	SetMinDecryptionVersion(ctx context.Context, region VaultRegion, credentialType CredentialType, version int) error
+}
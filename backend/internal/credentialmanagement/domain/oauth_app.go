@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/context-space/context-space/backend/internal/shared/security"
+)
+
+// Errors returned when validating an OAuth app
+var (
+	ErrOAuthAppClientIDRequired     = errors.New("client ID is required")
+	ErrOAuthAppClientSecretRequired = errors.New("client secret is required")
+	ErrOAuthAppInvalidURL           = errors.New("OAuth app URLs must be absolute https URLs of public hosts")
+)
+
+// OAuthApp is an OAuth client registered by a user or an organization with a provider,
+// used instead of the client of the provider manifest, optionally against a self-hosted instance of the provider
+type OAuthApp struct {
+	ID     string
+	UserID string
+	// OrganizationID is set for apps registered for an organization, UserID is then the member who registered it
+	OrganizationID     string
+	ProviderIdentifier string
+	ClientID           string
+	// Encryption metadata of the client secret
+	EncryptionMetadata *EncryptionMetadata
+	// In-memory only
+	ClientSecret string
+	// AuthURL, TokenURL and APIBaseURL replace the endpoints of the provider when set
+	AuthURL    string
+	TokenURL   string
+	APIBaseURL string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewOAuthApp creates a new OAuth app, owned by the organization if organizationID is not empty
+func NewOAuthApp(userID, organizationID, providerIdentifier, clientID, clientSecret string) (*OAuthApp, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	if providerIdentifier == "" {
+		return nil, errors.New("provider identifier is required")
+	}
+	if clientID == "" {
+		return nil, ErrOAuthAppClientIDRequired
+	}
+	if clientSecret == "" {
+		return nil, ErrOAuthAppClientSecretRequired
+	}
+
+	now := time.Now()
+	return &OAuthApp{
+		ID:                 uuid.New().String(),
+		UserID:             userID,
+		OrganizationID:     organizationID,
+		ProviderIdentifier: providerIdentifier,
+		ClientID:           clientID,
+		ClientSecret:       clientSecret,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}, nil
+}
+
+// SetEndpoints replaces the endpoint overrides of the app, empty URLs fall back to the endpoints of the provider
+// Hosts that are internal addresses are rejected here, hostnames resolving to them when the endpoints are called
+func (a *OAuthApp) SetEndpoints(authURL, tokenURL, apiBaseURL string) error {
+	for _, rawURL := range []string{authURL, tokenURL, apiBaseURL} {
+		if rawURL == "" {
+			continue
+		}
+		parsed, err := url.Parse(rawURL)
+		if err != nil || parsed.Scheme != "https" || parsed.Hostname() == "" || security.IsBlockedHost(parsed.Hostname()) {
+			return ErrOAuthAppInvalidURL
+		}
+	}
+
+	a.AuthURL = authURL
+	a.TokenURL = tokenURL
+	a.APIBaseURL = apiBaseURL
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+// OwnerID returns the organization ID of an organization app, or the user ID of a personal one
+func (a *OAuthApp) OwnerID() string {
+	if a.OrganizationID != "" {
+		return a.OrganizationID
+	}
+	return a.UserID
+}
+
+// OAuthAppResolver resolves the OAuth apps used for the OAuth flows and the API calls of credentials
+type OAuthAppResolver interface {
+	// GetOAuthApp returns an OAuth app with its decrypted client secret, or nil if it no longer exists
+	GetOAuthApp(ctx context.Context, id string) (*OAuthApp, error)
+
+	// GetOAuthAppForOwner returns the OAuth app registered by an owner for a provider with its decrypted client secret,
+	// or nil if the owner uses the client of the provider manifest
+	GetOAuthAppForOwner(ctx context.Context, userID, organizationID, providerIdentifier string) (*OAuthApp, error)
+}
+
+type oauthAppContextKey struct{}
+
+// WithOAuthApp returns a context whose OAuth calls to the provider use the given app
+func WithOAuthApp(ctx context.Context, app *OAuthApp) context.Context {
+	if app == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, oauthAppContextKey{}, app)
+}
+
+// OAuthAppFromContext returns the OAuth app of the context, or nil if the client of the provider manifest is used
+func OAuthAppFromContext(ctx context.Context) *OAuthApp {
+	app, _ := ctx.Value(oauthAppContextKey{}).(*OAuthApp)
+	return app
+}
@@ -2,10 +2,14 @@ package domain
 
 import (
 	"context"
+	"errors"
 
 	"golang.org/x/oauth2"
 )
 
+// ErrTokenRevocationNotSupported is returned when the provider offers no way to revoke issued tokens
+var ErrTokenRevocationNotSupported = errors.New("token revocation not supported")
+
 // OAuthProvider defines the interface for OAuth operations
 // This interface acts as an anti-corruption layer between Credential Management and Provider Adapter
 type OAuthProvider interface {
@@ -20,6 +24,7 @@ type OAuthProvider interface {
 	ShouldRefreshToken(providerIdentifier string, oldToken *oauth2.Token) (bool, error)
 
 	// RefreshToken refreshes an OAuth token
+	// Returns ErrCredentialRejected when the provider no longer accepts the refresh token
 	RefreshToken(ctx context.Context, providerIdentifier string, oldToken *oauth2.Token) (*oauth2.Token, error)
 
 	// GetScopesFromPermissions gets the scopes from the permissions
@@ -27,4 +32,8 @@ type OAuthProvider interface {
 
 	// GetPermissionIdentifiersFromScopes gets the permission identifiers from the scopes
 	GetPermissionIdentifiersFromScopes(ctx context.Context, providerIdentifier string, scopes []string) ([]string, error)
+
+	// RevokeToken revokes an OAuth token at the provider
+	// Returns ErrTokenRevocationNotSupported when the provider has no revocation endpoint
+	RevokeToken(ctx context.Context, providerIdentifier string, token *oauth2.Token) error
 }
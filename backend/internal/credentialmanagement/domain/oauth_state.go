@@ -26,6 +26,7 @@ type OAuthStateData struct {
 	CodeChallenge      string                 `json:"code_challenge"`
 	Status             OAuthStateStatus       `json:"status"`
 	UserID             string                 `json:"user_id"`
+	OrganizationID     string                 `json:"organization_id,omitempty"`
 	ProviderIdentifier string                 `json:"provider_identifier"`
 	RedirectURL        string                 `json:"redirect_url"`
 	Permissions        []string               `json:"permissions"`
@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"context"
+
+	contractIdentity "github.com/context-space/context-space/backend/internal/shared/contract/identityaccess"
+)
+
+// OrganizationMembershipProvider resolves the membership of a user in an organization
+type OrganizationMembershipProvider interface {
+	// GetMembership returns the membership of a user in an organization, or nil if the user is not a member
+	GetMembership(ctx context.Context, organizationID, userID string) (*contractIdentity.MembershipDTO, error)
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	contractCredential "github.com/context-space/context-space/backend/internal/shared/contract/credentialmanagement"
@@ -13,12 +14,18 @@ type CredentialRepository interface {
 	// GetByID retrieves a credential by ID
 	GetByID(ctx context.Context, id string) (*Credential, error)
 
-	// GetByUserAndProvider retrieves a credential by user ID and provider ID
+	// GetByUserAndProvider retrieves a personal credential by user ID and provider ID
 	GetByUserAndProvider(ctx context.Context, userID, providerIdentifier string) (*Credential, error)
 
-	// ListByUser retrieves all credentials for a user
+	// GetByOrganizationAndProvider retrieves an organization credential by organization ID and provider ID
+	GetByOrganizationAndProvider(ctx context.Context, organizationID, providerIdentifier string) (*Credential, error)
+
+	// ListByUser retrieves all personal credentials for a user
 	ListByUser(ctx context.Context, userID string) ([]*Credential, error)
 
+	// ListByOrganization retrieves all credentials of an organization
+	ListByOrganization(ctx context.Context, organizationID string) ([]*Credential, error)
+
 	// ListByID retrieves credentials by IDs
 	ListByID(ctx context.Context, ids []string) ([]*Credential, error)
 
@@ -30,6 +37,12 @@ type CredentialRepository interface {
 
 	// UpdateLastUsedAt updates the last used at time of a credential
 	UpdateLastUsedAt(ctx context.Context, id string) error
+
+	// ListDueForVerification lists valid credentials never verified or last verified before the given time, least recently verified first
+	ListDueForVerification(ctx context.Context, verifiedBefore time.Time, limit int) ([]*Credential, error)
+
+	// UpdateVerification updates the validity and the last verification result of a credential, invalid credentials are left untouched
+	UpdateVerification(ctx context.Context, credential *Credential) error
 }
 
 // OAuthCredentialRepository defines the interface for OAuth credential data access
@@ -56,13 +69,65 @@ type APIKeyCredentialRepository interface {
 	Create(ctx context.Context, credential *APIKeyCredential) error
 }
 
+// AppInstallationCredentialRepository defines the interface for app installation credential data access
+type AppInstallationCredentialRepository interface {
+	// GetByCredentialID retrieves an app installation credential by credential ID
+	GetByCredentialID(ctx context.Context, credentialID string) (*AppInstallationCredential, error)
+
+	// Create creates a new app installation credential
+	Create(ctx context.Context, credential *AppInstallationCredential) error
+}
+
+// TokenRevocationRepository defines the interface for token revocation data access
+type TokenRevocationRepository interface {
+	// Create creates a new token revocation
+	Create(ctx context.Context, revocation *TokenRevocation) error
+
+	// Update updates a token revocation
+	Update(ctx context.Context, revocation *TokenRevocation) error
+
+	// ListDue lists pending revocations whose next attempt is due at the given time
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*TokenRevocation, error)
+
+	// ListByUser lists the revocations of a user, newest first
+	ListByUser(ctx context.Context, userID string, limit, offset int) ([]*TokenRevocation, error)
+}
+
+// OAuthAppRepository defines the interface for OAuth app data access
+type OAuthAppRepository interface {
+	// GetByID retrieves an OAuth app by ID
+	GetByID(ctx context.Context, id string) (*OAuthApp, error)
+
+	// GetByOwnerAndProvider retrieves the OAuth app of a provider registered by a user, or by an organization if organizationID is not empty
+	GetByOwnerAndProvider(ctx context.Context, userID, organizationID, providerIdentifier string) (*OAuthApp, error)
+
+	// ListByUser retrieves the personal OAuth apps of a user
+	ListByUser(ctx context.Context, userID string) ([]*OAuthApp, error)
+
+	// ListByOrganization retrieves the OAuth apps of an organization
+	ListByOrganization(ctx context.Context, organizationID string) ([]*OAuthApp, error)
+
+	// Create creates a new OAuth app
+	Create(ctx context.Context, app *OAuthApp) error
+
+	// Update updates an OAuth app
+	Update(ctx context.Context, app *OAuthApp) error
+
+	// Delete soft-deletes an OAuth app
+	Delete(ctx context.Context, id string) error
+}
+
 // CredentialFactory can create and retrieve specialized credentials
 type CredentialFactory interface {
-	// CreateOAuth creates a new OAuth credential
-	CreateOAuth(ctx context.Context, userID, providerIdentifier string, oauthToken *oauth2.Token, scopes []string) (*OAuthCredential, error)
+	// CreateOAuth creates a new OAuth credential, owned by the organization if organizationID is not empty
+	// The credential is bound to the OAuth app of the context, if any
+	CreateOAuth(ctx context.Context, userID, organizationID, providerIdentifier string, oauthToken *oauth2.Token, scopes []string) (*OAuthCredential, error)
 
-	// CreateAPIKey creates a new API key credential
-	CreateAPIKey(ctx context.Context, userID, providerIdentifier, apiKey string) (*APIKeyCredential, error)
+	// CreateAPIKey creates a new API key credential, owned by the organization if organizationID is not empty
+	CreateAPIKey(ctx context.Context, userID, organizationID, providerIdentifier, apiKey string) (*APIKeyCredential, error)
+
+	// CreateAppInstallation creates a new app installation credential, owned by the organization if organizationID is not empty
+	CreateAppInstallation(ctx context.Context, userID, organizationID, providerIdentifier, appID, installationID, privateKey string) (*AppInstallationCredential, error)
 
 	// CreateNone creates a new no-auth credential
 	CreateNone(ctx context.Context, userID, providerIdentifier string) (*contractCredential.CredentialDTO, error)
@@ -73,6 +138,9 @@ type CredentialFactory interface {
 	// GetCredentialByUserAndProvider retrieves a credential by user ID and provider identifier and converts it to the proper type
 	GetCredentialByUserAndProvider(ctx context.Context, userID, providerIdentifier string) (interface{}, error)
 
+	// GetCredentialByOrganizationAndProvider retrieves an organization credential by provider identifier and converts it to the proper type
+	GetCredentialByOrganizationAndProvider(ctx context.Context, organizationID, providerIdentifier string) (interface{}, error)
+
 	// UpdateCredentialLastUsedAt updates the last used at time of a credential
 	UpdateCredentialLastUsedAt(ctx context.Context, credential interface{}) error
 }
@@ -101,7 +169,7 @@ type EncryptionMetadata struct {
 	KeyVersion     int            `json:"key_version"`
 	CredentialType CredentialType `json:"credential_type"`
 	Algorithm      VaultAlgorithm `json:"algorithm"`
-	Ciphertext     string         `json:"ciphertext"` // The actual base64 Vault ciphertext
+	Ciphertext     string         `json:"ciphertext"` // The Vault transit or envelope ciphertext
 }
 
 // VaultService defines the interface for secure credential storage
@@ -121,6 +189,23 @@ type VaultService interface {
 	DecryptJSON(ctx context.Context, metadata *EncryptionMetadata, target interface{}) error
 }
 
+// ErrVaultPermissionDenied is returned by health checks the vault token is not allowed to perform,
+// such as reading transit key metadata with a policy that only grants encrypt and decrypt
+var ErrVaultPermissionDenied = errors.New("vault permission denied")
+
+// VaultHealthChecker is implemented by vault services that can report the readiness of their backends
+type VaultHealthChecker interface {
+	// Regions returns the configured vault regions
+	Regions() []VaultRegion
+
+	// CheckHealth checks the vault of a region is reachable, initialized and unsealed
+	CheckHealth(ctx context.Context, region VaultRegion) error
+
+	// CheckTransitKeys checks every transit key used for a region exists,
+	// failing with ErrVaultPermissionDenied when the key metadata cannot be read
+	CheckTransitKeys(ctx context.Context, region VaultRegion) error
+}
+
 type TokenRefresh interface {
 	// RefreshAccessTokenIfNeeded refreshes the access token if needed
 	RefreshAccessTokenIfNeeded(ctx context.Context, providerIdentifier string, credential interface{}) (interface{}, error)
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenRevocationStatus indicates the state of an upstream token revocation
+type TokenRevocationStatus string
+
+const (
+	// TokenRevocationStatusPending means the revocation has not succeeded yet and will be retried
+	TokenRevocationStatusPending TokenRevocationStatus = "pending"
+	// TokenRevocationStatusRevoked means the provider confirmed the revocation
+	TokenRevocationStatusRevoked TokenRevocationStatus = "revoked"
+	// TokenRevocationStatusFailed means every attempt failed and no more retries are scheduled
+	TokenRevocationStatusFailed TokenRevocationStatus = "failed"
+	// TokenRevocationStatusUnsupported means the provider offers no way to revoke tokens
+	TokenRevocationStatusUnsupported TokenRevocationStatus = "unsupported"
+)
+
+const (
+	// MaxTokenRevocationAttempts is how many times a revocation is tried before it is marked as failed
+	MaxTokenRevocationAttempts = 6
+
+	tokenRevocationBaseBackoff = time.Minute
+	tokenRevocationMaxBackoff  = 6 * time.Hour
+)
+
+// TokenRevocation tracks the revocation at the provider of the token of a deleted OAuth credential
+type TokenRevocation struct {
+	ID                 string
+	CredentialID       string
+	UserID             string
+	ProviderIdentifier string
+	Status             TokenRevocationStatus
+	Attempts           int
+	LastError          string
+	// EncryptionMetadata holds the encrypted token until the revocation settles
+	EncryptionMetadata *EncryptionMetadata
+	// OAuthAppID is the OAuth app the token was issued through, if any
+	OAuthAppID    string
+	NextAttemptAt *time.Time
+	RevokedAt     *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewTokenRevocation creates a pending revocation for an OAuth credential, due immediately
+func NewTokenRevocation(credential *OAuthCredential) *TokenRevocation {
+	now := time.Now()
+	return &TokenRevocation{
+		ID:                 uuid.New().String(),
+		CredentialID:       credential.ID,
+		UserID:             credential.UserID,
+		ProviderIdentifier: credential.ProviderIdentifier,
+		Status:             TokenRevocationStatusPending,
+		EncryptionMetadata: credential.EncryptionMetadata,
+		OAuthAppID:         credential.OAuthAppID,
+		NextAttemptAt:      &now,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+}
+
+// MarkRevoked records a successful revocation and drops the encrypted token
+func (r *TokenRevocation) MarkRevoked(now time.Time) {
+	r.Attempts++
+	r.Status = TokenRevocationStatusRevoked
+	r.LastError = ""
+	r.RevokedAt = &now
+	r.settle(now)
+}
+
+// MarkUnsupported records that the provider cannot revoke tokens and drops the encrypted token
+func (r *TokenRevocation) MarkUnsupported(now time.Time) {
+	r.Attempts++
+	r.Status = TokenRevocationStatusUnsupported
+	r.settle(now)
+}
+
+// MarkAttemptFailed records a failed attempt and schedules a retry with exponential backoff,
+// the revocation is marked as failed once the attempts are exhausted
+func (r *TokenRevocation) MarkAttemptFailed(err error, now time.Time) {
+	r.Attempts++
+	r.LastError = err.Error()
+	r.UpdatedAt = now
+
+	if r.Attempts >= MaxTokenRevocationAttempts {
+		r.Status = TokenRevocationStatusFailed
+		r.settle(now)
+		return
+	}
+
+	backoff := tokenRevocationBaseBackoff << (r.Attempts - 1)
+	if backoff > tokenRevocationMaxBackoff {
+		backoff = tokenRevocationMaxBackoff
+	}
+	next := now.Add(backoff)
+	r.NextAttemptAt = &next
+}
+
+// IsSettled reports whether no more attempts will be made
+func (r *TokenRevocation) IsSettled() bool {
+	return r.Status != TokenRevocationStatusPending
+}
+
+// settle clears the retry schedule and the encrypted token once the outcome is final
+func (r *TokenRevocation) settle(now time.Time) {
+	r.NextAttemptAt = nil
+	r.EncryptionMetadata = nil
+	r.UpdatedAt = now
+}
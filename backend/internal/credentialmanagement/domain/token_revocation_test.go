@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestTokenRevocation() *TokenRevocation {
+	return NewTokenRevocation(&OAuthCredential{
+		Credential:         &Credential{ID: "cred-1", UserID: "user-1", ProviderIdentifier: "github", Type: CredentialTypeOAuth},
+		EncryptionMetadata: &EncryptionMetadata{},
+		OAuthAppID:         "app-1",
+	})
+}
+
+func TestNewTokenRevocation(t *testing.T) {
+	before := time.Now()
+	revocation := newTestTokenRevocation()
+
+	if revocation.CredentialID != "cred-1" || revocation.UserID != "user-1" || revocation.ProviderIdentifier != "github" || revocation.OAuthAppID != "app-1" {
+		t.Errorf("revocation = %+v, want the fields of the credential", revocation)
+	}
+	if revocation.Status != TokenRevocationStatusPending || revocation.IsSettled() {
+		t.Errorf("Status = %s, want a pending revocation", revocation.Status)
+	}
+	if revocation.EncryptionMetadata == nil {
+		t.Error("EncryptionMetadata = nil, want the encrypted token of the credential")
+	}
+	if revocation.NextAttemptAt == nil || revocation.NextAttemptAt.Before(before) || revocation.NextAttemptAt.After(time.Now()) {
+		t.Errorf("NextAttemptAt = %v, want now", revocation.NextAttemptAt)
+	}
+}
+
+func TestTokenRevocationBackoff(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	revocation := newTestTokenRevocation()
+
+	expectedBackoffs := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 16 * time.Minute}
+	for i, expected := range expectedBackoffs {
+		revocation.MarkAttemptFailed(errors.New("provider unavailable"), now)
+
+		if revocation.Attempts != i+1 || revocation.Status != TokenRevocationStatusPending {
+			t.Fatalf("after %d failures: attempts = %d, status = %s, want a pending revocation", i+1, revocation.Attempts, revocation.Status)
+		}
+		if revocation.NextAttemptAt == nil || !revocation.NextAttemptAt.Equal(now.Add(expected)) {
+			t.Errorf("after %d failures: NextAttemptAt = %v, want %v", i+1, revocation.NextAttemptAt, now.Add(expected))
+		}
+		if revocation.EncryptionMetadata == nil {
+			t.Errorf("after %d failures: the encrypted token was dropped before the revocation settled", i+1)
+		}
+	}
+
+	// The last allowed attempt settles the revocation as failed
+	revocation.MarkAttemptFailed(errors.New("provider unavailable"), now)
+
+	if revocation.Attempts != MaxTokenRevocationAttempts || revocation.Status != TokenRevocationStatusFailed || !revocation.IsSettled() {
+		t.Errorf("attempts = %d, status = %s, want %d attempts and a failed revocation", revocation.Attempts, revocation.Status, MaxTokenRevocationAttempts)
+	}
+	if revocation.NextAttemptAt != nil || revocation.EncryptionMetadata != nil {
+		t.Error("a failed revocation must not be retried nor keep the encrypted token")
+	}
+	if revocation.LastError != "provider unavailable" {
+		t.Errorf("LastError = %q, want the error of the last attempt", revocation.LastError)
+	}
+}
+
+func TestTokenRevocationSettle(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		settle         func(*TokenRevocation)
+		expectedStatus TokenRevocationStatus
+		revoked        bool
+	}{
+		{
+			name:           "Revoked",
+			settle:         func(r *TokenRevocation) { r.MarkRevoked(now) },
+			expectedStatus: TokenRevocationStatusRevoked,
+			revoked:        true,
+		},
+		{
+			name:           "Unsupported",
+			settle:         func(r *TokenRevocation) { r.MarkUnsupported(now) },
+			expectedStatus: TokenRevocationStatusUnsupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revocation := newTestTokenRevocation()
+			revocation.MarkAttemptFailed(errors.New("timeout"), now)
+
+			tt.settle(revocation)
+
+			if revocation.Status != tt.expectedStatus || !revocation.IsSettled() {
+				t.Errorf("Status = %s, want %s", revocation.Status, tt.expectedStatus)
+			}
+			if revocation.Attempts != 2 {
+				t.Errorf("Attempts = %d, want 2", revocation.Attempts)
+			}
+			if revocation.NextAttemptAt != nil || revocation.EncryptionMetadata != nil {
+				t.Error("a settled revocation must not be retried nor keep the encrypted token")
+			}
+			if !revocation.UpdatedAt.Equal(now) {
+				t.Errorf("UpdatedAt = %v, want %v", revocation.UpdatedAt, now)
+			}
+			if tt.revoked {
+				if revocation.RevokedAt == nil || !revocation.RevokedAt.Equal(now) || revocation.LastError != "" {
+					t.Errorf("RevokedAt = %v, LastError = %q, want the revocation time and no error", revocation.RevokedAt, revocation.LastError)
+				}
+			} else if revocation.RevokedAt != nil {
+				t.Errorf("RevokedAt = %v, want nil", revocation.RevokedAt)
+			}
+		})
+	}
+}
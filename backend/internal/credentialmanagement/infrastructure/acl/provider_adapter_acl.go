@@ -2,6 +2,7 @@ package acl
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	observability "github.com/context-space/cloud-observability"
@@ -16,11 +17,16 @@ type ProviderAdapterACL struct {
 	obs            *observability.ObservabilityProvider
 }
 
+var (
+	_ domain.OAuthProvider      = (*ProviderAdapterACL)(nil)
+	_ domain.CredentialVerifier = (*ProviderAdapterACL)(nil)
+)
+
 // NewProviderAdapterACL creates a new provider adapter ACL
 func NewProviderAdapterACL(
 	contractReader contractAdapter.ProviderAdapterContract,
 	obs *observability.ObservabilityProvider,
-) domain.OAuthProvider {
+) *ProviderAdapterACL {
 	return &ProviderAdapterACL{
 		contractReader: contractReader,
 		obs:            obs,
@@ -39,7 +45,7 @@ func (a *ProviderAdapterACL) GenerateOAuthURL(ctx context.Context, providerIdent
 		zap.Strings("scopes", scopes),
 	)
 
-	url, err := a.contractReader.GenerateOAuthURLContract(ctx, providerIdentifier, redirectURL, state, codeChallenge, scopes)
+	url, err := a.contractReader.GenerateOAuthURLContract(withOAuthApp(ctx), providerIdentifier, redirectURL, state, codeChallenge, scopes)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate OAuth URL: %w", err)
 	}
@@ -58,7 +64,7 @@ func (a *ProviderAdapterACL) ExchangeCodeForToken(ctx context.Context, providerI
 		zap.String("code_verifier", codeVerifier),
 	)
 
-	token, err := a.contractReader.ExchangeCodeForTokenContract(ctx, providerIdentifier, code, redirectURL, codeVerifier)
+	token, err := a.contractReader.ExchangeCodeForTokenContract(withOAuthApp(ctx), providerIdentifier, code, redirectURL, codeVerifier)
 	if err != nil {
 		return nil, fmt.Errorf("failed to exchange code for token: %w", err)
 	}
@@ -84,8 +90,11 @@ func (a *ProviderAdapterACL) RefreshToken(ctx context.Context, providerIdentifie
 		zap.Any("old_token", oldToken),
 	)
 
-	token, err := a.contractReader.RefreshTokenContract(ctx, providerIdentifier, oldToken)
+	token, err := a.contractReader.RefreshTokenContract(withOAuthApp(ctx), providerIdentifier, oldToken)
 	if err != nil {
+		if errors.Is(err, contractAdapter.ErrCredentialRejected) {
+			return nil, fmt.Errorf("%w: %v", domain.ErrCredentialRejected, err)
+		}
 		return nil, fmt.Errorf("failed to refresh token: %w", err)
 	}
 
@@ -125,3 +134,58 @@ func (a *ProviderAdapterACL) GetPermissionIdentifiersFromScopes(ctx context.Cont
 
 	return permissionIdentifiers, nil
 }
+
+func (a *ProviderAdapterACL) RevokeToken(ctx context.Context, providerIdentifier string, token *oauth2.Token) error {
+	ctx, span := a.obs.Tracer.Start(ctx, "ProviderAdapterACL.RevokeToken")
+	defer span.End()
+
+	a.obs.Logger.Debug(ctx, "Revoking token",
+		zap.String("provider_identifier", providerIdentifier),
+	)
+
+	if err := a.contractReader.RevokeTokenContract(withOAuthApp(ctx), providerIdentifier, token); err != nil {
+		if errors.Is(err, contractAdapter.ErrTokenRevocationNotSupported) {
+			return domain.ErrTokenRevocationNotSupported
+		}
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	return nil
+}
+
+func (a *ProviderAdapterACL) VerifyCredential(ctx context.Context, providerIdentifier string, credential interface{}) error {
+	ctx, span := a.obs.Tracer.Start(ctx, "ProviderAdapterACL.VerifyCredential")
+	defer span.End()
+
+	a.obs.Logger.Debug(ctx, "Verifying credential",
+		zap.String("provider_identifier", providerIdentifier),
+	)
+
+	if err := a.contractReader.VerifyCredentialContract(ctx, providerIdentifier, credential); err != nil {
+		switch {
+		case errors.Is(err, contractAdapter.ErrCredentialVerificationNotSupported):
+			return domain.ErrCredentialVerificationNotSupported
+		case errors.Is(err, contractAdapter.ErrCredentialRejected):
+			return fmt.Errorf("%w: %v", domain.ErrCredentialRejected, err)
+		}
+		return fmt.Errorf("failed to verify credential: %w", err)
+	}
+
+	return nil
+}
+
+// withOAuthApp passes the OAuth app of the context, if any, on to the provider adapter
+func withOAuthApp(ctx context.Context) context.Context {
+	app := domain.OAuthAppFromContext(ctx)
+	if app == nil {
+		return ctx
+	}
+
+	return contractAdapter.WithOAuthApp(ctx, &contractAdapter.OAuthAppDTO{
+		ClientID:     app.ClientID,
+		ClientSecret: app.ClientSecret,
+		AuthURL:      app.AuthURL,
+		TokenURL:     app.TokenURL,
+		APIBaseURL:   app.APIBaseURL,
+	})
+}
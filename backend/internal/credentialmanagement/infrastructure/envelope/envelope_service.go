@@ -0,0 +1,442 @@
+package envelope
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+)
+
+// ciphertextPrefix marks the ciphertexts produced by the envelope service
+const ciphertextPrefix = "envelope"
+
+// minVersionsRefreshInterval bounds how long a min decryption version raised by another instance is not enforced
+const minVersionsRefreshInterval = time.Minute
+
+var (
+	_ domain.VaultService       = (*EnvelopeService)(nil)
+	_ domain.VaultHealthChecker = (*EnvelopeService)(nil)
+	_ domain.VaultKeyManager    = (*EnvelopeService)(nil)
+)
+
+// EnvelopeService implements the domain.VaultService interface in-process with envelope encryption.
+// Every encryption uses a fresh data key, which is wrapped by the primary key encryption key of the region.
+// Ciphertexts are stored as envelope:v<kek version>:<wrapped data key>:<encrypted data>.
+type EnvelopeService struct {
+	mu      sync.RWMutex
+	loader  KeyRingLoader
+	keyRing *KeyRing
+	// algorithmMap selects the algorithm of new ciphertexts per region, AES-GCM otherwise
+	algorithmMap map[domain.VaultRegion]domain.VaultAlgorithm
+	// minVersionRepo persists the min decryption versions, so that every instance enforces them
+	minVersionRepo domain.MinDecryptionVersionRepository
+	// minVersions caches the min decryption versions per region and credential type until minVersionsExpiry
+	minVersions       map[string]int
+	minVersionsExpiry time.Time
+}
+
+// NewEnvelopeService creates a new envelope encryption VaultService implementation
+func NewEnvelopeService(loader KeyRingLoader, minVersionRepo domain.MinDecryptionVersionRepository, algorithms map[domain.VaultRegion]domain.VaultAlgorithm, defaultRegion domain.VaultRegion) (*EnvelopeService, error) {
+	keyRing, err := loader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key ring: %w", err)
+	}
+
+	s := &EnvelopeService{
+		loader:         loader,
+		keyRing:        keyRing,
+		algorithmMap:   algorithms,
+		minVersionRepo: minVersionRepo,
+		minVersions:    make(map[string]int),
+	}
+
+	if _, err := keyRing.Primary(defaultRegion); err != nil {
+		return nil, fmt.Errorf("default region %s must have a key encryption key", defaultRegion)
+	}
+	if err := s.validateKeyRing(keyRing); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// EncryptData encrypts the data with a new data key wrapped by the primary key of the region
+func (s *EnvelopeService) EncryptData(ctx context.Context, plaintext string, region domain.VaultRegion, credentialType domain.CredentialType) (*domain.EncryptionMetadata, error) {
+	kek, err := s.getKeyRing().Primary(region)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm := s.algorithm(region)
+	dek := make([]byte, keySize(algorithm))
+	if _, err := rand.Read(dek); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	aad := additionalData(region, credentialType)
+	sealedData, err := seal(algorithm, dek, []byte(plaintext), aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt data: %w", err)
+	}
+	wrappedKey, err := seal(algorithm, kek.Key, dek, aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	return &domain.EncryptionMetadata{
+		Region:         region,
+		KeyVersion:     kek.Version,
+		CredentialType: credentialType,
+		Algorithm:      algorithm,
+		Ciphertext:     formatCiphertext(kek.Version, wrappedKey, sealedData),
+	}, nil
+}
+
+// DecryptData unwraps the data key with the key version recorded in the metadata and decrypts the data
+func (s *EnvelopeService) DecryptData(ctx context.Context, metadata *domain.EncryptionMetadata) (string, error) {
+	if metadata == nil {
+		return "", fmt.Errorf("metadata cannot be nil")
+	}
+
+	dek, sealedData, err := s.unwrap(ctx, metadata)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(metadata.Algorithm, dek, sealedData, additionalData(metadata.Region, metadata.CredentialType))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt data: %w", err)
+	}
+
+	return string(plaintext), nil
+}
+
+// EncryptJSON encrypts a JSON-serializable structure
+func (s *EnvelopeService) EncryptJSON(ctx context.Context, data interface{}, region domain.VaultRegion, credentialType domain.CredentialType) (*domain.EncryptionMetadata, error) {
+	jsonData, err := sonic.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal data to JSON: %w", err)
+	}
+
+	return s.EncryptData(ctx, string(jsonData), region, credentialType)
+}
+
+// DecryptJSON decrypts data into the provided target structure
+func (s *EnvelopeService) DecryptJSON(ctx context.Context, metadata *domain.EncryptionMetadata, target interface{}) error {
+	plaintext, err := s.DecryptData(ctx, metadata)
+	if err != nil {
+		return err
+	}
+
+	if err := sonic.Unmarshal([]byte(plaintext), target); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	return nil
+}
+
+// RotateEncryptionKey reloads the key ring, new key versions are added to the key ring by the operator
+func (s *EnvelopeService) RotateEncryptionKey(ctx context.Context, region domain.VaultRegion, credentialType domain.CredentialType) error {
+	keyRing, err := s.loader()
+	if err != nil {
+		return fmt.Errorf("failed to reload key ring: %w", err)
+	}
+	if _, err := keyRing.Primary(region); err != nil {
+		return err
+	}
+	if err := s.validateKeyRing(keyRing); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.keyRing = keyRing
+	s.mu.Unlock()
+
+	return nil
+}
+
+// GetLatestKeyVersion returns the primary key version of the region, key encryption keys are shared by the credential types
+func (s *EnvelopeService) GetLatestKeyVersion(ctx context.Context, region domain.VaultRegion, credentialType domain.CredentialType) (int, error) {
+	kek, err := s.getKeyRing().Primary(region)
+	if err != nil {
+		return 0, err
+	}
+	return kek.Version, nil
+}
+
+// ReWrapData wraps the data key with the primary key of the region, the data is only re-encrypted
+// when the algorithm configured for the region changed
+func (s *EnvelopeService) ReWrapData(ctx context.Context, currentMetadata *domain.EncryptionMetadata) (*domain.EncryptionMetadata, error) {
+	if currentMetadata == nil {
+		return nil, fmt.Errorf("metadata cannot be nil")
+	}
+
+	if currentMetadata.Algorithm != s.algorithm(currentMetadata.Region) {
+		plaintext, err := s.DecryptData(ctx, currentMetadata)
+		if err != nil {
+			return nil, err
+		}
+		return s.EncryptData(ctx, plaintext, currentMetadata.Region, currentMetadata.CredentialType)
+	}
+
+	kek, err := s.getKeyRing().Primary(currentMetadata.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	dek, sealedData, err := s.unwrap(ctx, currentMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedKey, err := seal(currentMetadata.Algorithm, kek.Key, dek, additionalData(currentMetadata.Region, currentMetadata.CredentialType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	return &domain.EncryptionMetadata{
+		Region:         currentMetadata.Region,
+		KeyVersion:     kek.Version,
+		CredentialType: currentMetadata.CredentialType,
+		Algorithm:      currentMetadata.Algorithm,
+		Ciphertext:     formatCiphertext(kek.Version, wrappedKey, sealedData),
+	}, nil
+}
+
+// SetMinDecryptionVersion disables decryption with older key versions, other instances enforce it
+// once they refresh their min decryption versions
+func (s *EnvelopeService) SetMinDecryptionVersion(ctx context.Context, region domain.VaultRegion, credentialType domain.CredentialType, version int) error {
+	if err := s.minVersionRepo.Set(ctx, region, credentialType, version); err != nil {
+		return fmt.Errorf("failed to store min decryption version: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := minVersionKey(region, credentialType)
+	s.minVersions[key] = max(s.minVersions[key], version)
+	return nil
+}
+
+// Regions returns the regions of the key ring
+func (s *EnvelopeService) Regions() []domain.VaultRegion {
+	return s.getKeyRing().Regions()
+}
+
+// CheckHealth checks the key ring holds a primary key for the region
+func (s *EnvelopeService) CheckHealth(ctx context.Context, region domain.VaultRegion) error {
+	_, err := s.getKeyRing().Primary(region)
+	return err
+}
+
+// CheckTransitKeys checks the primary key of the region can wrap a data key for every credential type
+func (s *EnvelopeService) CheckTransitKeys(ctx context.Context, region domain.VaultRegion) error {
+	for _, credType := range []domain.CredentialType{domain.CredentialTypeOAuth, domain.CredentialTypeAPIKey} {
+		metadata, err := s.EncryptData(ctx, "", region, credType)
+		if err != nil {
+			return err
+		}
+		if _, err := s.DecryptData(ctx, metadata); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getKeyRing returns the current key ring
+func (s *EnvelopeService) getKeyRing() *KeyRing {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keyRing
+}
+
+// algorithm returns the algorithm of new ciphertexts of a region
+func (s *EnvelopeService) algorithm(region domain.VaultRegion) domain.VaultAlgorithm {
+	if algorithm, ok := s.algorithmMap[region]; ok {
+		return algorithm
+	}
+	return domain.AlgorithmAESGCM
+}
+
+// validateKeyRing checks the keys of every region match the size required by its algorithm
+func (s *EnvelopeService) validateKeyRing(keyRing *KeyRing) error {
+	for region, keys := range keyRing.regions {
+		algorithm := s.algorithm(region)
+		if keySize(algorithm) == 0 {
+			return fmt.Errorf("unsupported algorithm %s for region %s", algorithm, region)
+		}
+		kek := keys.Keys[keys.PrimaryVersion]
+		if len(kek.Key) != keySize(algorithm) {
+			return fmt.Errorf("key version %d of region %s must be %d bytes for %s", kek.Version, region, keySize(algorithm), algorithm)
+		}
+	}
+	return nil
+}
+
+// minVersion returns the min decryption version of a region and credential type,
+// reloading the stored versions once the cached ones expired
+func (s *EnvelopeService) minVersion(ctx context.Context, region domain.VaultRegion, credentialType domain.CredentialType) (int, error) {
+	key := minVersionKey(region, credentialType)
+
+	s.mu.RLock()
+	minVersion, expired := s.minVersions[key], time.Now().After(s.minVersionsExpiry)
+	s.mu.RUnlock()
+	if !expired {
+		return minVersion, nil
+	}
+
+	stored, err := s.minVersionRepo.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load min decryption versions: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Versions set by this instance meanwhile are kept, min decryption versions only increase
+	for storedRegion, types := range stored {
+		for storedType, version := range types {
+			storedKey := minVersionKey(storedRegion, storedType)
+			s.minVersions[storedKey] = max(s.minVersions[storedKey], version)
+		}
+	}
+	s.minVersionsExpiry = time.Now().Add(minVersionsRefreshInterval)
+
+	return s.minVersions[key], nil
+}
+
+// unwrap parses a ciphertext and returns its data key and encrypted data
+func (s *EnvelopeService) unwrap(ctx context.Context, metadata *domain.EncryptionMetadata) ([]byte, []byte, error) {
+	version, wrappedKey, sealedData, err := parseCiphertext(metadata.Ciphertext)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	minVersion, err := s.minVersion(ctx, metadata.Region, metadata.CredentialType)
+	if err != nil {
+		return nil, nil, err
+	}
+	if version < minVersion {
+		return nil, nil, fmt.Errorf("key version %d is below the min decryption version %d", version, minVersion)
+	}
+
+	kek, err := s.getKeyRing().Get(metadata.Region, version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dek, err := open(metadata.Algorithm, kek.Key, wrappedKey, additionalData(metadata.Region, metadata.CredentialType))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	return dek, sealedData, nil
+}
+
+// keySize returns the key size of an algorithm in bytes, zero if it is not supported
+func keySize(algorithm domain.VaultAlgorithm) int {
+	switch algorithm {
+	case domain.AlgorithmAESGCM:
+		return 32
+	case domain.AlgorithmSM4GCM:
+		return sm4KeySize
+	default:
+		return 0
+	}
+}
+
+// newAEAD creates the GCM AEAD of an algorithm
+func newAEAD(algorithm domain.VaultAlgorithm, key []byte) (cipher.AEAD, error) {
+	var block cipher.Block
+	var err error
+	switch algorithm {
+	case domain.AlgorithmAESGCM:
+		block, err = aes.NewCipher(key)
+	case domain.AlgorithmSM4GCM:
+		block, err = newSM4Cipher(key)
+	default:
+		return nil, fmt.Errorf("unsupported algorithm: %s", algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the plaintext with a random nonce, which is prepended to the result
+func seal(algorithm domain.VaultAlgorithm, key, plaintext, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// open decrypts a result of seal
+func open(algorithm domain.VaultAlgorithm, key, sealed, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, aad)
+}
+
+// additionalData binds a ciphertext to its region and credential type
+func additionalData(region domain.VaultRegion, credentialType domain.CredentialType) []byte {
+	return []byte(fmt.Sprintf("%s:%s", region, credentialType))
+}
+
+// minVersionKey returns the key of the min decryption version of a region and credential type
+func minVersionKey(region domain.VaultRegion, credentialType domain.CredentialType) string {
+	return fmt.Sprintf("%s:%s", region, credentialType)
+}
+
+// formatCiphertext encodes a wrapped data key and encrypted data
+func formatCiphertext(version int, wrappedKey, sealedData []byte) string {
+	return fmt.Sprintf("%s:v%d:%s:%s", ciphertextPrefix, version,
+		base64.StdEncoding.EncodeToString(wrappedKey),
+		base64.StdEncoding.EncodeToString(sealedData))
+}
+
+// parseCiphertext decodes a ciphertext produced by formatCiphertext
+func parseCiphertext(ciphertext string) (int, []byte, []byte, error) {
+	parts := strings.Split(ciphertext, ":")
+	if len(parts) != 4 || parts[0] != ciphertextPrefix {
+		return 0, nil, nil, fmt.Errorf("invalid envelope ciphertext")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[1], "v%d", &version); err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to parse key version from ciphertext: %w", err)
+	}
+
+	wrappedKey, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to decode wrapped data key: %w", err)
+	}
+	sealedData, err := base64.StdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to decode encrypted data: %w", err)
+	}
+
+	return version, wrappedKey, sealedData, nil
+}
@@ -0,0 +1,132 @@
+package envelope
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+)
+
+// memoryMinVersionRepository keeps the min decryption versions in memory
+type memoryMinVersionRepository struct {
+	mu       sync.Mutex
+	versions map[domain.VaultRegion]map[domain.CredentialType]int
+}
+
+func (r *memoryMinVersionRepository) List(ctx context.Context) (map[domain.VaultRegion]map[domain.CredentialType]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versions := make(map[domain.VaultRegion]map[domain.CredentialType]int)
+	for region, types := range r.versions {
+		versions[region] = make(map[domain.CredentialType]int)
+		for credentialType, version := range types {
+			versions[region][credentialType] = version
+		}
+	}
+	return versions, nil
+}
+
+func (r *memoryMinVersionRepository) Set(ctx context.Context, region domain.VaultRegion, credentialType domain.CredentialType, version int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.versions[region] == nil {
+		r.versions[region] = make(map[domain.CredentialType]int)
+	}
+	r.versions[region][credentialType] = max(r.versions[region][credentialType], version)
+	return nil
+}
+
+func TestEnvelopeServiceMinDecryptionVersion(t *testing.T) {
+	ctx := context.Background()
+	region := domain.VaultRegion("eu")
+
+	primaryVersion := 1
+	loader := func() (*KeyRing, error) {
+		return NewKeyRing(map[domain.VaultRegion]*RegionKeys{
+			region: {
+				PrimaryVersion: primaryVersion,
+				Keys: map[int]*KEK{
+					1: {Version: 1, Key: make([]byte, 32)},
+					2: {Version: 2, Key: append(make([]byte, 31), 2)},
+				},
+			},
+		})
+	}
+	repo := &memoryMinVersionRepository{versions: make(map[domain.VaultRegion]map[domain.CredentialType]int)}
+
+	service, err := NewEnvelopeService(loader, repo, nil, region)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	other, err := NewEnvelopeService(loader, repo, nil, region)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	oauthV1, err := service.EncryptData(ctx, "oauth", region, domain.CredentialTypeOAuth)
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	apiKeyV1, err := service.EncryptData(ctx, "apikey", region, domain.CredentialTypeAPIKey)
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+
+	primaryVersion = 2
+	if err := service.RotateEncryptionKey(ctx, region, domain.CredentialTypeOAuth); err != nil {
+		t.Fatalf("Failed to rotate: %v", err)
+	}
+	oauthV2, err := service.ReWrapData(ctx, oauthV1)
+	if err != nil {
+		t.Fatalf("Failed to rewrap: %v", err)
+	}
+	if oauthV2.KeyVersion != 2 {
+		t.Fatalf("Expected key version 2, got: %d", oauthV2.KeyVersion)
+	}
+
+	// The other instance loads the versions before they are raised, and caches them
+	if _, err := other.DecryptData(ctx, oauthV1); err != nil {
+		t.Fatalf("Expected version 1 to decrypt before the min version is raised, got: %v", err)
+	}
+
+	if err := service.SetMinDecryptionVersion(ctx, region, domain.CredentialTypeOAuth, 2); err != nil {
+		t.Fatalf("Failed to set min decryption version: %v", err)
+	}
+	other.mu.Lock()
+	other.minVersionsExpiry = other.minVersionsExpiry.Add(-minVersionsRefreshInterval)
+	other.mu.Unlock()
+
+	tests := []struct {
+		name     string
+		service  *EnvelopeService
+		metadata *domain.EncryptionMetadata
+		want     string
+		wantErr  bool
+	}{
+		{name: "OlderVersionRejected", service: service, metadata: oauthV1, wantErr: true},
+		{name: "OlderVersionRejectedByOtherInstance", service: other, metadata: oauthV1, wantErr: true},
+		{name: "CurrentVersionDecrypted", service: other, metadata: oauthV2, want: "oauth"},
+		{name: "OtherCredentialTypeUnaffected", service: other, metadata: apiKeyV1, want: "apikey"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plaintext, err := tt.service.DecryptData(ctx, tt.metadata)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got plaintext: %s", plaintext)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to decrypt: %v", err)
+			}
+			if plaintext != tt.want {
+				t.Errorf("Expected %s, got: %s", tt.want, plaintext)
+			}
+		})
+	}
+}
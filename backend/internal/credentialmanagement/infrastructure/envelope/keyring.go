@@ -0,0 +1,182 @@
+package envelope
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bytedance/sonic"
+
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+)
+
+// KEK is a version of the key encryption key of a region
+type KEK struct {
+	Version int
+	Key     []byte
+}
+
+// RegionKeys holds the key encryption keys of a region
+type RegionKeys struct {
+	// PrimaryVersion is the version new data keys are wrapped with, the latest version when zero
+	PrimaryVersion int
+	Keys           map[int]*KEK
+}
+
+// KeyRing holds the versioned key encryption keys of every region
+type KeyRing struct {
+	regions map[domain.VaultRegion]*RegionKeys
+}
+
+// KeyRingLoader loads the key ring, it is called again when a key is rotated so that new versions are picked up.
+// Besides the file and environment loaders, a loader can fetch the key material from a KMS.
+type KeyRingLoader func() (*KeyRing, error)
+
+// NewKeyRing creates a key ring, every region must hold its primary version
+func NewKeyRing(regions map[domain.VaultRegion]*RegionKeys) (*KeyRing, error) {
+	if len(regions) == 0 {
+		return nil, fmt.Errorf("key ring has no region")
+	}
+
+	for region, keys := range regions {
+		if len(keys.Keys) == 0 {
+			return nil, fmt.Errorf("key ring has no key for region %s", region)
+		}
+		if keys.PrimaryVersion == 0 {
+			for version := range keys.Keys {
+				keys.PrimaryVersion = max(keys.PrimaryVersion, version)
+			}
+		}
+		if _, ok := keys.Keys[keys.PrimaryVersion]; !ok {
+			return nil, fmt.Errorf("primary key version %d of region %s not found", keys.PrimaryVersion, region)
+		}
+	}
+
+	return &KeyRing{regions: regions}, nil
+}
+
+// Regions returns the regions of the key ring
+func (r *KeyRing) Regions() []domain.VaultRegion {
+	regions := make([]domain.VaultRegion, 0, len(r.regions))
+	for region := range r.regions {
+		regions = append(regions, region)
+	}
+	sort.Slice(regions, func(i, j int) bool { return regions[i] < regions[j] })
+	return regions
+}
+
+// Primary returns the key new data keys of a region are wrapped with
+func (r *KeyRing) Primary(region domain.VaultRegion) (*KEK, error) {
+	keys, ok := r.regions[region]
+	if !ok {
+		return nil, fmt.Errorf("no key encryption key for region %s", region)
+	}
+	return keys.Keys[keys.PrimaryVersion], nil
+}
+
+// Get returns a version of the key of a region
+func (r *KeyRing) Get(region domain.VaultRegion, version int) (*KEK, error) {
+	keys, ok := r.regions[region]
+	if !ok {
+		return nil, fmt.Errorf("no key encryption key for region %s", region)
+	}
+	kek, ok := keys.Keys[version]
+	if !ok {
+		return nil, fmt.Errorf("key encryption key version %d of region %s not found", version, region)
+	}
+	return kek, nil
+}
+
+// keyRingFile is the JSON format of a key ring file
+//
+//	{"regions": {"eu": {"primary_version": 2, "keys": [{"version": 1, "key": "<base64>"}, {"version": 2, "key": "<base64>"}]}}}
+type keyRingFile struct {
+	Regions map[string]struct {
+		PrimaryVersion int `json:"primary_version"`
+		Keys           []struct {
+			Version int    `json:"version"`
+			Key     string `json:"key"`
+		} `json:"keys"`
+	} `json:"regions"`
+}
+
+// FileKeyRingLoader returns a loader reading the key ring from a JSON file
+func FileKeyRingLoader(path string) KeyRingLoader {
+	return func() (*KeyRing, error) {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key ring file: %w", err)
+		}
+
+		var file keyRingFile
+		if err := sonic.Unmarshal(content, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse key ring file: %w", err)
+		}
+
+		regions := make(map[domain.VaultRegion]*RegionKeys)
+		for region, entry := range file.Regions {
+			keys := &RegionKeys{PrimaryVersion: entry.PrimaryVersion, Keys: make(map[int]*KEK)}
+			for _, k := range entry.Keys {
+				kek, err := decodeKEK(k.Version, k.Key)
+				if err != nil {
+					return nil, fmt.Errorf("region %s: %w", region, err)
+				}
+				keys.Keys[k.Version] = kek
+			}
+			regions[domain.VaultRegion(region)] = keys
+		}
+
+		return NewKeyRing(regions)
+	}
+}
+
+// EnvKeyRingLoader returns a loader reading the key ring from environment variables
+// named <prefix>_<REGION>_V<version>, such as ENVELOPE_KEK_EU_V1, the latest version of a region is primary
+func EnvKeyRingLoader(prefix string) KeyRingLoader {
+	return func() (*KeyRing, error) {
+		regions := make(map[domain.VaultRegion]*RegionKeys)
+		for _, env := range os.Environ() {
+			name, value, _ := strings.Cut(env, "=")
+			rest, ok := strings.CutPrefix(name, prefix+"_")
+			if !ok {
+				continue
+			}
+			region, versionPart, ok := strings.Cut(rest, "_V")
+			if !ok {
+				continue
+			}
+			version, err := strconv.Atoi(versionPart)
+			if err != nil {
+				continue
+			}
+
+			kek, err := decodeKEK(version, value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+
+			vaultRegion := domain.VaultRegion(strings.ToLower(region))
+			if regions[vaultRegion] == nil {
+				regions[vaultRegion] = &RegionKeys{Keys: make(map[int]*KEK)}
+			}
+			regions[vaultRegion].Keys[version] = kek
+		}
+
+		return NewKeyRing(regions)
+	}
+}
+
+// decodeKEK decodes a base64 encoded key encryption key
+func decodeKEK(version int, encoded string) (*KEK, error) {
+	if version <= 0 {
+		return nil, fmt.Errorf("invalid key version %d", version)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key version %d: %w", version, err)
+	}
+	return &KEK{Version: version, Key: key}, nil
+}
@@ -0,0 +1,74 @@
+package envelope
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestSM4KnownAnswer(t *testing.T) {
+	// Examples of appendix A of GB/T 32907-2016
+	tests := []struct {
+		name       string
+		key        string
+		plaintext  string
+		ciphertext string
+		iterations int
+	}{
+		{
+			name:       "SingleEncryption",
+			key:        "0123456789abcdeffedcba9876543210",
+			plaintext:  "0123456789abcdeffedcba9876543210",
+			ciphertext: "681edf34d206965e86b3e94f536e4246",
+			iterations: 1,
+		},
+		{
+			name:       "MillionEncryptions",
+			key:        "0123456789abcdeffedcba9876543210",
+			plaintext:  "0123456789abcdeffedcba9876543210",
+			ciphertext: "595298c7c6fd271f0402f804c33d3f66",
+			iterations: 1000000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if testing.Short() && tt.iterations > 1 {
+				t.Skip("skipping iterated example in short mode")
+			}
+
+			key, _ := hex.DecodeString(tt.key)
+			plaintext, _ := hex.DecodeString(tt.plaintext)
+			expected, _ := hex.DecodeString(tt.ciphertext)
+
+			block, err := newSM4Cipher(key)
+			if err != nil {
+				t.Fatalf("Failed to create cipher: %v", err)
+			}
+
+			ciphertext := bytes.Clone(plaintext)
+			for i := 0; i < tt.iterations; i++ {
+				block.Encrypt(ciphertext, ciphertext)
+			}
+			if !bytes.Equal(ciphertext, expected) {
+				t.Errorf("Expected ciphertext %x, got: %x", expected, ciphertext)
+			}
+
+			decrypted := bytes.Clone(ciphertext)
+			for i := 0; i < tt.iterations; i++ {
+				block.Decrypt(decrypted, decrypted)
+			}
+			if !bytes.Equal(decrypted, plaintext) {
+				t.Errorf("Expected plaintext %x, got: %x", plaintext, decrypted)
+			}
+		})
+	}
+}
+
+func TestSM4InvalidKeySize(t *testing.T) {
+	for _, size := range []int{0, 15, 17, 32} {
+		if _, err := newSM4Cipher(make([]byte, size)); err == nil {
+			t.Errorf("Expected an error for a %d byte key", size)
+		}
+	}
+}
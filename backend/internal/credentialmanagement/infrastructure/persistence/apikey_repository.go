@@ -80,11 +80,15 @@ func (r *APIKeyCredentialRepository) mapToDomain(credModel *CredentialModel, api
 	cred := &domain.Credential{
 		ID:                 credModel.ID,
 		UserID:             credModel.UserID,
+		OrganizationID:     parseGormOrganizationID(credModel.OrganizationID),
 		ProviderIdentifier: credModel.ProviderIdentifier,
 		Type:               domain.CredentialType(credModel.CredentialType),
 		IsValid:            credModel.IsValid,
 		CreatedAt:          credModel.CreatedAt,
 		UpdatedAt:          credModel.UpdatedAt,
+		LastVerifiedAt:     credModel.LastVerifiedAt,
+		VerificationStatus: domain.CredentialVerificationStatus(credModel.VerificationStatus),
+		VerificationError:  credModel.VerificationError,
 		DeletedAt:          parseGormDeletedAt(credModel.DeletedAt),
 	}
 
@@ -0,0 +1,123 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bytedance/sonic"
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// AppInstallationCredentialRepository implements the domain.AppInstallationCredentialRepository interface
+type AppInstallationCredentialRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewAppInstallationCredentialRepository creates a new app installation credential repository
+func NewAppInstallationCredentialRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *AppInstallationCredentialRepository {
+	return &AppInstallationCredentialRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// GetByCredentialID retrieves an app installation credential by credential ID
+func (r *AppInstallationCredentialRepository) GetByCredentialID(ctx context.Context, credentialID string) (*domain.AppInstallationCredential, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "AppInstallationCredentialRepository.GetByCredentialID")
+	defer span.End()
+
+	var credModel CredentialModel
+	result := r.db.WithContext(ctx).First(&credModel, "id = ? AND is_valid = true", credentialID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	var installationModel AppInstallationCredentialModel
+	result = r.db.WithContext(ctx).Where("credential_id = ?", credentialID).First(&installationModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.mapToDomain(&credModel, &installationModel)
+}
+
+// Create creates a new app installation credential
+func (r *AppInstallationCredentialRepository) Create(ctx context.Context, credential *domain.AppInstallationCredential) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "AppInstallationCredentialRepository.Create")
+	defer span.End()
+
+	installationModel, err := r.mapToModel(credential)
+	if err != nil {
+		return err
+	}
+
+	result := r.db.WithContext(ctx).Create(installationModel)
+	return result.Error
+}
+
+// mapToDomain maps credential models to a domain app installation credential
+func (r *AppInstallationCredentialRepository) mapToDomain(credModel *CredentialModel, installationModel *AppInstallationCredentialModel) (*domain.AppInstallationCredential, error) {
+	var jsonAttributes struct {
+		EncryptionMetadata *domain.EncryptionMetadata `json:"encryption_metadata"`
+	}
+
+	if err := sonic.Unmarshal(installationModel.JSONAttributes, &jsonAttributes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal app installation credential json attributes: %w", err)
+	}
+
+	cred := &domain.Credential{
+		ID:                 credModel.ID,
+		UserID:             credModel.UserID,
+		OrganizationID:     parseGormOrganizationID(credModel.OrganizationID),
+		ProviderIdentifier: credModel.ProviderIdentifier,
+		Type:               domain.CredentialType(credModel.CredentialType),
+		IsValid:            credModel.IsValid,
+		CreatedAt:          credModel.CreatedAt,
+		UpdatedAt:          credModel.UpdatedAt,
+		LastVerifiedAt:     credModel.LastVerifiedAt,
+		VerificationStatus: domain.CredentialVerificationStatus(credModel.VerificationStatus),
+		VerificationError:  credModel.VerificationError,
+		DeletedAt:          parseGormDeletedAt(credModel.DeletedAt),
+	}
+
+	return &domain.AppInstallationCredential{
+		Credential:         cred,
+		AppID:              installationModel.AppID,
+		InstallationID:     installationModel.InstallationID,
+		EncryptionMetadata: jsonAttributes.EncryptionMetadata,
+	}, nil
+}
+
+func (r *AppInstallationCredentialRepository) mapToModel(credential *domain.AppInstallationCredential) (*AppInstallationCredentialModel, error) {
+	jsonAttributes := struct {
+		EncryptionMetadata *domain.EncryptionMetadata `json:"encryption_metadata"`
+	}{
+		EncryptionMetadata: credential.EncryptionMetadata,
+	}
+
+	jsonAttributesJSON, err := sonic.Marshal(jsonAttributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal app installation credential json attributes: %w", err)
+	}
+
+	return &AppInstallationCredentialModel{
+		CredentialID:   credential.ID,
+		AppID:          credential.AppID,
+		InstallationID: credential.InstallationID,
+		JSONAttributes: jsonAttributesJSON,
+		CreatedAt:      credential.CreatedAt,
+		UpdatedAt:      credential.UpdatedAt,
+		DeletedAt:      parseDomainDeletedAt(credential.DeletedAt),
+	}, nil
+}
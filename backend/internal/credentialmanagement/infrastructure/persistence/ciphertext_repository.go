@@ -0,0 +1,195 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bytedance/sonic"
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	contractCredential "github.com/context-space/context-space/backend/internal/shared/contract/credentialmanagement"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// ciphertextTable describes where a ciphertext source stores its encryption metadata
+type ciphertextTable struct {
+	name     string
+	idColumn string
+	// condition restricts the rows whose ciphertext may still be decrypted
+	condition string
+	args      []interface{}
+}
+
+// ciphertextTables maps the ciphertext sources to their tables
+var ciphertextTables = map[domain.CiphertextSource]ciphertextTable{
+	domain.CiphertextSourceOAuthCredentials: {
+		name:      "oauth_credentials",
+		idColumn:  "credential_id",
+		condition: "deleted_at IS NULL",
+	},
+	domain.CiphertextSourceAPIKeyCredentials: {
+		name:      "apikey_credentials",
+		idColumn:  "credential_id",
+		condition: "deleted_at IS NULL",
+	},
+	domain.CiphertextSourceAppInstallationCredentials: {
+		name:      "app_installation_credentials",
+		idColumn:  "credential_id",
+		condition: "deleted_at IS NULL",
+	},
+	domain.CiphertextSourceTokenRevocations: {
+		name:      "token_revocations",
+		idColumn:  "id",
+		condition: "status = ?",
+		args:      []interface{}{string(domain.TokenRevocationStatusPending)},
+	},
+	domain.CiphertextSourceOAuthApps: {
+		name:      "oauth_apps",
+		idColumn:  "id",
+		condition: "deleted_at IS NULL",
+	},
+}
+
+// storedCiphertextRow is a row ID with the encryption metadata stored in its json attributes
+type storedCiphertextRow struct {
+	RowID              string
+	EncryptionMetadata string
+}
+
+// CiphertextRepository implements the domain.CiphertextRepository interface,
+// the module secrets source is read from the secret stores registered by other modules
+type CiphertextRepository struct {
+	db           database.Database
+	secretStores *secretStores
+	obs          *observability.ObservabilityProvider
+}
+
+// NewCiphertextRepository creates a new ciphertext repository
+func NewCiphertextRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *CiphertextRepository {
+	return &CiphertextRepository{
+		db:           db,
+		secretStores: newSecretStores(),
+		obs:          observabilityProvider,
+	}
+}
+
+// RegisterSecretStore registers the store of a module keeping secrets encrypted through the credential contract
+func (r *CiphertextRepository) RegisterSecretStore(name string, store contractCredential.SecretStore) {
+	r.secretStores.register(name, store)
+}
+
+// CountBelowVersion counts the ciphertexts of a source encrypted in the region with an older key version
+func (r *CiphertextRepository) CountBelowVersion(ctx context.Context, source domain.CiphertextSource, region domain.VaultRegion, keyVersion int) (int, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "CiphertextRepository.CountBelowVersion")
+	defer span.End()
+
+	if source == domain.CiphertextSourceModuleSecrets {
+		return r.secretStores.countBelowVersion(ctx, region, keyVersion)
+	}
+
+	query, err := r.belowVersionQuery(ctx, source, region, keyVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// ListBelowVersion lists the ciphertexts of a source encrypted in the region with an older key version, ordered by row ID
+func (r *CiphertextRepository) ListBelowVersion(ctx context.Context, source domain.CiphertextSource, region domain.VaultRegion, keyVersion int, afterRowID string, limit int) ([]*domain.StoredCiphertext, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "CiphertextRepository.ListBelowVersion")
+	defer span.End()
+
+	if source == domain.CiphertextSourceModuleSecrets {
+		return r.secretStores.listBelowVersion(ctx, region, keyVersion, afterRowID, limit)
+	}
+
+	query, err := r.belowVersionQuery(ctx, source, region, keyVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	table := ciphertextTables[source]
+	if afterRowID != "" {
+		query = query.Where(table.idColumn+" > ?", afterRowID)
+	}
+
+	var rows []storedCiphertextRow
+	result := query.
+		Select(table.idColumn + " AS row_id, json_attributes->>'encryption_metadata' AS encryption_metadata").
+		Order(table.idColumn + " ASC").
+		Limit(limit).
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	ciphertexts := make([]*domain.StoredCiphertext, 0, len(rows))
+	for _, row := range rows {
+		var metadata domain.EncryptionMetadata
+		if err := sonic.UnmarshalString(row.EncryptionMetadata, &metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal encryption metadata of %s %s: %w", table.name, row.RowID, err)
+		}
+		ciphertexts = append(ciphertexts, &domain.StoredCiphertext{
+			RowID:    row.RowID,
+			Metadata: &metadata,
+		})
+	}
+
+	return ciphertexts, nil
+}
+
+// Replace stores the rewrapped ciphertext of a row if it still holds the original one
+func (r *CiphertextRepository) Replace(ctx context.Context, source domain.CiphertextSource, original *domain.StoredCiphertext, rewrapped *domain.EncryptionMetadata) (bool, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "CiphertextRepository.Replace")
+	defer span.End()
+
+	if source == domain.CiphertextSourceModuleSecrets {
+		return r.secretStores.replace(ctx, original, rewrapped)
+	}
+
+	table, ok := ciphertextTables[source]
+	if !ok {
+		return false, fmt.Errorf("unknown ciphertext source: %s", source)
+	}
+
+	metadataJSON, err := sonic.MarshalString(rewrapped)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal encryption metadata: %w", err)
+	}
+
+	// Compare the stored ciphertext so that a credential updated since it was listed is not overwritten
+	result := r.db.WithContext(ctx).
+		Table(table.name).
+		Where(table.idColumn+" = ?", original.RowID).
+		Where("json_attributes->'encryption_metadata'->>'ciphertext' = ?", original.Metadata.Ciphertext).
+		Updates(map[string]interface{}{
+			"json_attributes": gorm.Expr("jsonb_set(json_attributes, '{encryption_metadata}', ?::jsonb)", metadataJSON),
+			"updated_at":      gorm.Expr("NOW()"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// belowVersionQuery selects the rows of a source holding ciphertexts encrypted in the region with an older key version
+func (r *CiphertextRepository) belowVersionQuery(ctx context.Context, source domain.CiphertextSource, region domain.VaultRegion, keyVersion int) (*gorm.DB, error) {
+	table, ok := ciphertextTables[source]
+	if !ok {
+		return nil, fmt.Errorf("unknown ciphertext source: %s", source)
+	}
+
+	return r.db.WithContext(ctx).
+		Table(table.name).
+		Where(table.condition, table.args...).
+		Where("json_attributes->'encryption_metadata'->>'region' = ?", string(region)).
+		Where("(json_attributes->'encryption_metadata'->>'key_version')::int < ?", keyVersion), nil
+}
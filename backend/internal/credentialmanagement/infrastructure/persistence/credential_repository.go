@@ -43,13 +43,13 @@ func (r *CredentialRepository) GetByID(ctx context.Context, id string) (*domain.
 	return r.mapToDomain(&model), nil
 }
 
-// GetByUserAndProvider retrieves a credential by user ID and provider ID
+// GetByUserAndProvider retrieves a personal credential by user ID and provider ID
 func (r *CredentialRepository) GetByUserAndProvider(ctx context.Context, userID, providerID string) (*domain.Credential, error) {
 	ctx, span := r.obs.Tracer.Start(ctx, "CredentialRepository.GetByUserAndProvider")
 	defer span.End()
 
 	var model CredentialModel
-	result := r.db.WithContext(ctx).First(&model, "user_id = ? AND provider_identifier = ? AND is_valid = ?", userID, providerID, true)
+	result := r.db.WithContext(ctx).First(&model, "user_id = ? AND organization_id IS NULL AND provider_identifier = ? AND is_valid = ?", userID, providerID, true)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -60,13 +60,49 @@ func (r *CredentialRepository) GetByUserAndProvider(ctx context.Context, userID,
 	return r.mapToDomain(&model), nil
 }
 
-// ListByUser retrieves all credentials for a user
+// GetByOrganizationAndProvider retrieves an organization credential by organization ID and provider ID
+func (r *CredentialRepository) GetByOrganizationAndProvider(ctx context.Context, organizationID, providerID string) (*domain.Credential, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "CredentialRepository.GetByOrganizationAndProvider")
+	defer span.End()
+
+	var model CredentialModel
+	result := r.db.WithContext(ctx).First(&model, "organization_id = ? AND provider_identifier = ? AND is_valid = ?", organizationID, providerID, true)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.mapToDomain(&model), nil
+}
+
+// ListByUser retrieves all personal credentials for a user
 func (r *CredentialRepository) ListByUser(ctx context.Context, userID string) ([]*domain.Credential, error) {
 	ctx, span := r.obs.Tracer.Start(ctx, "CredentialRepository.ListByUser")
 	defer span.End()
 
 	var models []CredentialModel
-	result := r.db.WithContext(ctx).Find(&models, "user_id = ? AND is_valid = ?", userID, true)
+	result := r.db.WithContext(ctx).Find(&models, "user_id = ? AND organization_id IS NULL AND is_valid = ?", userID, true)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	credentials := make([]*domain.Credential, len(models))
+	for i, model := range models {
+		credentials[i] = r.mapToDomain(&model)
+	}
+
+	return credentials, nil
+}
+
+// ListByOrganization retrieves all credentials of an organization
+func (r *CredentialRepository) ListByOrganization(ctx context.Context, organizationID string) ([]*domain.Credential, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "CredentialRepository.ListByOrganization")
+	defer span.End()
+
+	var models []CredentialModel
+	result := r.db.WithContext(ctx).Find(&models, "organization_id = ? AND is_valid = ?", organizationID, true)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -127,6 +163,8 @@ func (r *CredentialRepository) Delete(ctx context.Context, id string) error {
 			result = tx.Where("credential_id = ?", id).Delete(&OAuthCredentialModel{})
 		case string(domain.CredentialTypeAPIKey):
 			result = tx.Where("credential_id = ?", id).Delete(&APIKeyCredentialModel{})
+		case string(domain.CredentialTypeAppInstallation):
+			result = tx.Where("credential_id = ?", id).Delete(&AppInstallationCredentialModel{})
 		default:
 			return fmt.Errorf("credential type not found with id: %s", id)
 		}
@@ -157,17 +195,65 @@ func (r *CredentialRepository) UpdateLastUsedAt(ctx context.Context, id string)
 	return r.db.WithContext(ctx).Model(&CredentialModel{}).Where("id = ?", id).Update("last_used_at", time.Now()).Error
 }
 
+// ListDueForVerification lists valid credentials never verified or last verified before the given time, least recently verified first
+func (r *CredentialRepository) ListDueForVerification(ctx context.Context, verifiedBefore time.Time, limit int) ([]*domain.Credential, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "CredentialRepository.ListDueForVerification")
+	defer span.End()
+
+	var models []CredentialModel
+	result := r.db.WithContext(ctx).
+		Where("is_valid = ?", true).
+		Where("credential_type IN ?", []string{
+			string(domain.CredentialTypeOAuth),
+			string(domain.CredentialTypeAPIKey),
+			string(domain.CredentialTypeAppInstallation),
+		}).
+		Where("last_verified_at IS NULL OR last_verified_at < ?", verifiedBefore).
+		Order("last_verified_at ASC NULLS FIRST").
+		Limit(limit).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	credentials := make([]*domain.Credential, len(models))
+	for i, model := range models {
+		credentials[i] = r.mapToDomain(&model)
+	}
+
+	return credentials, nil
+}
+
+// UpdateVerification updates the validity and the last verification result of a credential
+func (r *CredentialRepository) UpdateVerification(ctx context.Context, credential *domain.Credential) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "CredentialRepository.UpdateVerification")
+	defer span.End()
+
+	// Only valid credentials are updated, so a stale verification never revives an invalidated credential
+	return r.db.WithContext(ctx).Model(&CredentialModel{}).Where("id = ? AND is_valid = ?", credential.ID, true).Updates(map[string]interface{}{
+		"is_valid":            credential.IsValid,
+		"last_verified_at":    credential.LastVerifiedAt,
+		"verification_status": string(credential.VerificationStatus),
+		"verification_error":  credential.VerificationError,
+		"updated_at":          credential.UpdatedAt,
+	}).Error
+}
+
 // mapToDomain maps a credential model to a domain credential
 func (r *CredentialRepository) mapToDomain(model *CredentialModel) *domain.Credential {
 	return &domain.Credential{
 		ID:                 model.ID,
 		UserID:             model.UserID,
+		OrganizationID:     parseGormOrganizationID(model.OrganizationID),
 		ProviderIdentifier: model.ProviderIdentifier,
 		Type:               domain.CredentialType(model.CredentialType),
 		IsValid:            model.IsValid,
 		CreatedAt:          model.CreatedAt,
 		UpdatedAt:          model.UpdatedAt,
 		LastUsedAt:         model.LastUsedAt,
+		LastVerifiedAt:     model.LastVerifiedAt,
+		VerificationStatus: domain.CredentialVerificationStatus(model.VerificationStatus),
+		VerificationError:  model.VerificationError,
 		DeletedAt:          parseGormDeletedAt(model.DeletedAt),
 	}
 }
@@ -177,11 +263,15 @@ func (r *CredentialRepository) mapToModel(credential *domain.Credential) *Creden
 	return &CredentialModel{
 		ID:                 credential.ID,
 		UserID:             credential.UserID,
+		OrganizationID:     parseDomainOrganizationID(credential.OrganizationID),
 		ProviderIdentifier: credential.ProviderIdentifier,
 		CredentialType:     string(credential.Type),
 		IsValid:            credential.IsValid,
 		CreatedAt:          credential.CreatedAt,
 		UpdatedAt:          credential.UpdatedAt,
+		LastVerifiedAt:     credential.LastVerifiedAt,
+		VerificationStatus: string(credential.VerificationStatus),
+		VerificationError:  credential.VerificationError,
 		DeletedAt:          parseDomainDeletedAt(credential.DeletedAt),
 	}
 }
@@ -17,10 +17,12 @@ var (
 
 // CredentialFactoryImpl implements the CredentialFactory interface
 type CredentialFactoryImpl struct {
-	credentialRepo domain.CredentialRepository
-	oauthRepo      domain.OAuthCredentialRepository
-	apiKeyRepo     domain.APIKeyCredentialRepository
-	vaultService   domain.VaultService
+	credentialRepo   domain.CredentialRepository
+	oauthRepo        domain.OAuthCredentialRepository
+	apiKeyRepo       domain.APIKeyCredentialRepository
+	installationRepo domain.AppInstallationCredentialRepository
+	vaultService     domain.VaultService
+	appResolver      domain.OAuthAppResolver
 }
 
 // NewCredentialFactory creates a new instance of the credential factory
@@ -28,25 +30,36 @@ func NewCredentialFactory(
 	credentialRepo domain.CredentialRepository,
 	oauthRepo domain.OAuthCredentialRepository,
 	apiKeyRepo domain.APIKeyCredentialRepository,
+	installationRepo domain.AppInstallationCredentialRepository,
 	vaultService domain.VaultService,
+	appResolver domain.OAuthAppResolver,
 ) domain.CredentialFactory {
 	return &CredentialFactoryImpl{
-		credentialRepo: credentialRepo,
-		oauthRepo:      oauthRepo,
-		apiKeyRepo:     apiKeyRepo,
-		vaultService:   vaultService,
+		credentialRepo:   credentialRepo,
+		oauthRepo:        oauthRepo,
+		apiKeyRepo:       apiKeyRepo,
+		installationRepo: installationRepo,
+		vaultService:     vaultService,
+		appResolver:      appResolver,
 	}
 }
 
-// CreateOAuth creates a new OAuth credential
+// CreateOAuth creates a new OAuth credential, owned by the organization if organizationID is not empty
 func (f *CredentialFactoryImpl) CreateOAuth(
-	ctx context.Context, userID, providerIdentifier string, oauth2Token *oauth2.Token, scopes []string,
+	ctx context.Context, userID, organizationID, providerIdentifier string, oauth2Token *oauth2.Token, scopes []string,
 ) (*domain.OAuthCredential, error) {
 	// Create the OAuth credential domain object
 	oauthCred, err := domain.NewOAuthCredential(userID, providerIdentifier, oauth2Token, scopes)
 	if err != nil {
 		return nil, err
 	}
+	oauthCred.OrganizationID = organizationID
+
+	// Bind the credential to the OAuth app that issued the token
+	if app := domain.OAuthAppFromContext(ctx); app != nil {
+		oauthCred.OAuthAppID = app.ID
+		oauthCred.OAuthApp = app
+	}
 
 	// Encrypt the OAuth token
 	metadata, err := f.vaultService.EncryptJSON(ctx, oauth2Token, domain.RegionEU, domain.CredentialTypeOAuth)
@@ -72,16 +85,17 @@ func (f *CredentialFactoryImpl) CreateOAuth(
 	return oauthCred, nil
 }
 
-// CreateAPIKey creates a new API key credential
+// CreateAPIKey creates a new API key credential, owned by the organization if organizationID is not empty
 func (f *CredentialFactoryImpl) CreateAPIKey(
 	ctx context.Context,
-	userID, providerIdentifier, apiKey string,
+	userID, organizationID, providerIdentifier, apiKey string,
 ) (*domain.APIKeyCredential, error) {
 	// Create the API key credential domain object
 	apiKeyCred, err := domain.NewAPIKeyCredential(userID, providerIdentifier, apiKey)
 	if err != nil {
 		return nil, err
 	}
+	apiKeyCred.OrganizationID = organizationID
 
 	// Encrypt the OAuth token
 	metadata, err := f.vaultService.EncryptData(ctx, apiKeyCred.APIKey, domain.RegionEU, domain.CredentialTypeAPIKey)
@@ -107,6 +121,42 @@ func (f *CredentialFactoryImpl) CreateAPIKey(
 	return apiKeyCred, nil
 }
 
+// CreateAppInstallation creates a new app installation credential, owned by the organization if organizationID is not empty
+func (f *CredentialFactoryImpl) CreateAppInstallation(
+	ctx context.Context,
+	userID, organizationID, providerIdentifier, appID, installationID, privateKey string,
+) (*domain.AppInstallationCredential, error) {
+	// Create the app installation credential domain object
+	installationCred, err := domain.NewAppInstallationCredential(userID, providerIdentifier, appID, installationID, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	installationCred.OrganizationID = organizationID
+
+	// Encrypt the private key with the transit key of API keys, the installation tokens are never stored
+	metadata, err := f.vaultService.EncryptData(ctx, installationCred.PrivateKey, domain.RegionEU, domain.CredentialTypeAPIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set encryption metadata
+	installationCred.EncryptionMetadata = metadata
+
+	// Create the base credential in the database
+	if err := f.credentialRepo.Create(ctx, installationCred.Credential); err != nil {
+		return nil, err
+	}
+
+	// Create the app installation credential in the database
+	if err := f.installationRepo.Create(ctx, installationCred); err != nil {
+		// Try to clean up the base credential if app installation creation fails
+		_ = f.credentialRepo.Delete(ctx, installationCred.ID)
+		return nil, err
+	}
+
+	return installationCred, nil
+}
+
 // CreateNone creates a new no-auth credential
 func (f *CredentialFactoryImpl) CreateNone(
 	ctx context.Context,
@@ -159,6 +209,22 @@ func (f *CredentialFactoryImpl) GetCredentialByUserAndProvider(ctx context.Conte
 	return f.loadCredentialDetails(ctx, baseCred)
 }
 
+// GetCredentialByOrganizationAndProvider retrieves an organization credential by provider identifier
+func (f *CredentialFactoryImpl) GetCredentialByOrganizationAndProvider(ctx context.Context, organizationID, providerIdentifier string) (interface{}, error) {
+	// Retrieve the base credential to determine its type
+	baseCred, err := f.credentialRepo.GetByOrganizationAndProvider(ctx, organizationID, providerIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the base credential is not found, return nil
+	if baseCred == nil {
+		return nil, nil
+	}
+
+	return f.loadCredentialDetails(ctx, baseCred)
+}
+
 // loadCredentialDetails loads and decrypts the complete credential information based on the base credential
 func (f *CredentialFactoryImpl) loadCredentialDetails(ctx context.Context, baseCred *domain.Credential) (interface{}, error) {
 	switch baseCred.Type {
@@ -172,6 +238,14 @@ func (f *CredentialFactoryImpl) loadCredentialDetails(ctx context.Context, baseC
 			return nil, err
 		}
 		oauthCred.Token = token
+		if oauthCred.OAuthAppID != "" && f.appResolver != nil {
+			// A deleted app leaves the credential on the client of the provider manifest
+			app, err := f.appResolver.GetOAuthApp(ctx, oauthCred.OAuthAppID)
+			if err != nil {
+				return nil, err
+			}
+			oauthCred.OAuthApp = app
+		}
 		return oauthCred, nil
 
 	case domain.CredentialTypeAPIKey:
@@ -186,6 +260,18 @@ func (f *CredentialFactoryImpl) loadCredentialDetails(ctx context.Context, baseC
 		apiKeyCred.APIKey = apiKey
 		return apiKeyCred, nil
 
+	case domain.CredentialTypeAppInstallation:
+		installationCred, err := f.installationRepo.GetByCredentialID(ctx, baseCred.ID)
+		if err != nil {
+			return nil, err
+		}
+		privateKey, err := f.vaultService.DecryptData(ctx, installationCred.EncryptionMetadata)
+		if err != nil {
+			return nil, err
+		}
+		installationCred.PrivateKey = privateKey
+		return installationCred, nil
+
 	default:
 		return nil, ErrCredentialTypeNotSupported
 	}
@@ -197,6 +283,8 @@ func (f *CredentialFactoryImpl) UpdateCredentialLastUsedAt(ctx context.Context,
 		return f.credentialRepo.UpdateLastUsedAt(ctx, cred.Credential.ID)
 	case *domain.APIKeyCredential:
 		return f.credentialRepo.UpdateLastUsedAt(ctx, cred.Credential.ID)
+	case *domain.AppInstallationCredential:
+		return f.credentialRepo.UpdateLastUsedAt(ctx, cred.Credential.ID)
 	}
 
 	return nil
@@ -0,0 +1,167 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// KeyRotationRepository implements the domain.KeyRotationRepository interface
+type KeyRotationRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewKeyRotationRepository creates a new key rotation repository
+func NewKeyRotationRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *KeyRotationRepository {
+	return &KeyRotationRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// Get retrieves a key rotation by ID
+func (r *KeyRotationRepository) Get(ctx context.Context, id string) (*domain.KeyRotation, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "KeyRotationRepository.Get")
+	defer span.End()
+
+	var model KeyRotationModel
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.mapToDomain(&model), nil
+}
+
+// GetActive retrieves the running rotation of a region and credential type
+func (r *KeyRotationRepository) GetActive(ctx context.Context, region domain.VaultRegion, credentialType domain.CredentialType) (*domain.KeyRotation, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "KeyRotationRepository.GetActive")
+	defer span.End()
+
+	var model KeyRotationModel
+	result := r.db.WithContext(ctx).
+		Where("region = ? AND credential_type = ? AND status = ?", string(region), string(credentialType), string(domain.KeyRotationStatusRunning)).
+		First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.mapToDomain(&model), nil
+}
+
+// ListActive lists all running rotations
+func (r *KeyRotationRepository) ListActive(ctx context.Context) ([]*domain.KeyRotation, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "KeyRotationRepository.ListActive")
+	defer span.End()
+
+	var models []KeyRotationModel
+	result := r.db.WithContext(ctx).
+		Where("status = ?", string(domain.KeyRotationStatusRunning)).
+		Order("created_at ASC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.mapToDomainList(models), nil
+}
+
+// List lists key rotations, newest first
+func (r *KeyRotationRepository) List(ctx context.Context, limit, offset int) ([]*domain.KeyRotation, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "KeyRotationRepository.List")
+	defer span.End()
+
+	var models []KeyRotationModel
+	result := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.mapToDomainList(models), nil
+}
+
+// Create creates a new key rotation
+func (r *KeyRotationRepository) Create(ctx context.Context, rotation *domain.KeyRotation) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "KeyRotationRepository.Create")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Create(r.mapToModel(rotation)).Error
+}
+
+// Update updates a key rotation
+func (r *KeyRotationRepository) Update(ctx context.Context, rotation *domain.KeyRotation) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "KeyRotationRepository.Update")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Save(r.mapToModel(rotation)).Error
+}
+
+// mapToDomainList converts key rotation models to domain key rotations
+func (r *KeyRotationRepository) mapToDomainList(models []KeyRotationModel) []*domain.KeyRotation {
+	rotations := make([]*domain.KeyRotation, 0, len(models))
+	for i := range models {
+		rotations = append(rotations, r.mapToDomain(&models[i]))
+	}
+	return rotations
+}
+
+// mapToDomain converts a key rotation model to a domain key rotation
+func (r *KeyRotationRepository) mapToDomain(model *KeyRotationModel) *domain.KeyRotation {
+	return &domain.KeyRotation{
+		ID:             model.ID,
+		Region:         domain.VaultRegion(model.Region),
+		CredentialType: domain.CredentialType(model.CredentialType),
+		KeyVersion:     model.KeyVersion,
+		Status:         domain.KeyRotationStatus(model.Status),
+		Source:         domain.CiphertextSource(model.Source),
+		Cursor:         model.Cursor,
+		Total:          model.Total,
+		Rewrapped:      model.Rewrapped,
+		Skipped:        model.Skipped,
+		Failed:         model.Failed,
+		LastError:      model.LastError,
+		TriggeredBy:    model.TriggeredBy,
+		StartedAt:      model.StartedAt,
+		CompletedAt:    model.CompletedAt,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+// mapToModel converts a domain key rotation to a key rotation model
+func (r *KeyRotationRepository) mapToModel(rotation *domain.KeyRotation) *KeyRotationModel {
+	return &KeyRotationModel{
+		ID:             rotation.ID,
+		Region:         string(rotation.Region),
+		CredentialType: string(rotation.CredentialType),
+		KeyVersion:     rotation.KeyVersion,
+		Status:         string(rotation.Status),
+		Source:         string(rotation.Source),
+		Cursor:         rotation.Cursor,
+		Total:          rotation.Total,
+		Rewrapped:      rotation.Rewrapped,
+		Skipped:        rotation.Skipped,
+		Failed:         rotation.Failed,
+		LastError:      rotation.LastError,
+		TriggeredBy:    rotation.TriggeredBy,
+		StartedAt:      rotation.StartedAt,
+		CompletedAt:    rotation.CompletedAt,
+		CreatedAt:      rotation.CreatedAt,
+		UpdatedAt:      rotation.UpdatedAt,
+	}
+}
@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MinDecryptionVersionRepository implements the domain.MinDecryptionVersionRepository interface
+type MinDecryptionVersionRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewMinDecryptionVersionRepository creates a new min decryption version repository
+func NewMinDecryptionVersionRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *MinDecryptionVersionRepository {
+	return &MinDecryptionVersionRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// List returns the min decryption version of every region and credential type that has one
+func (r *MinDecryptionVersionRepository) List(ctx context.Context) (map[domain.VaultRegion]map[domain.CredentialType]int, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "MinDecryptionVersionRepository.List")
+	defer span.End()
+
+	var models []MinDecryptionVersionModel
+	if err := r.db.WithContext(ctx).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	versions := make(map[domain.VaultRegion]map[domain.CredentialType]int)
+	for _, model := range models {
+		region := domain.VaultRegion(model.Region)
+		if versions[region] == nil {
+			versions[region] = make(map[domain.CredentialType]int)
+		}
+		versions[region][domain.CredentialType(model.CredentialType)] = model.Version
+	}
+
+	return versions, nil
+}
+
+// Set stores the min decryption version of a region and credential type, a lower version never replaces a higher one
+func (r *MinDecryptionVersionRepository) Set(ctx context.Context, region domain.VaultRegion, credentialType domain.CredentialType, version int) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "MinDecryptionVersionRepository.Set")
+	defer span.End()
+
+	now := time.Now()
+	model := &MinDecryptionVersionModel{
+		Region:         string(region),
+		CredentialType: string(credentialType),
+		Version:        version,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "region"}, {Name: "credential_type"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"version":    gorm.Expr("GREATEST(min_decryption_versions.version, EXCLUDED.version)"),
+			"updated_at": now,
+		}),
+	}).Create(model).Error
+}
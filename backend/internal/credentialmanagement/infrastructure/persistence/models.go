@@ -11,12 +11,16 @@ import (
 type CredentialModel struct {
 	ID                 string         `gorm:"type:uuid;primary_key"`
 	UserID             string         `gorm:"type:uuid;not null;index"`
+	OrganizationID     *string        `gorm:"type:uuid;index"`
 	ProviderIdentifier string         `gorm:"type:varchar(50);not null;index"`
 	CredentialType     string         `gorm:"type:credential_type;not null;index"`
 	IsValid            bool           `gorm:"not null;default:true"`
 	CreatedAt          time.Time      `gorm:"type:timestamp with time zone;not null;default:now()"`
 	UpdatedAt          time.Time      `gorm:"type:timestamp with time zone;not null;default:now()"`
 	LastUsedAt         time.Time      `gorm:"type:timestamp with time zone;not null;default:now()"`
+	LastVerifiedAt     *time.Time     `gorm:"type:timestamp with time zone"`
+	VerificationStatus string         `gorm:"type:varchar(20);not null;default:''"`
+	VerificationError  string         `gorm:"type:text;not null;default:''"`
 	DeletedAt          gorm.DeletedAt `gorm:"type:timestamp with time zone;index"`
 }
 
@@ -60,6 +64,25 @@ func (APIKeyCredentialModel) TableName() string {
 	return "apikey_credentials"
 }
 
+// AppInstallationCredentialModel represents the app_installation_credentials table in the database
+type AppInstallationCredentialModel struct {
+	CredentialID   string          `gorm:"type:uuid;primary_key"`
+	AppID          string          `gorm:"type:varchar(255);not null"`
+	InstallationID string          `gorm:"type:varchar(255);not null"`
+	JSONAttributes json.RawMessage `gorm:"type:jsonb;not null"`
+	CreatedAt      time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
+	UpdatedAt      time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
+	DeletedAt      gorm.DeletedAt  `gorm:"type:timestamp with time zone;index"`
+
+	// Relationships
+	Credential CredentialModel `gorm:"foreignKey:CredentialID;references:ID"`
+}
+
+// TableName returns the table name for the AppInstallationCredential model
+func (AppInstallationCredentialModel) TableName() string {
+	return "app_installation_credentials"
+}
+
 // OAuthStateModel represents the oauth_states table in the database
 type OAuthStateModel struct {
 	ID                 string          `gorm:"type:uuid;primary_key"`
@@ -78,6 +101,88 @@ func (OAuthStateModel) TableName() string {
 	return "oauth_states"
 }
 
+// TokenRevocationModel represents the token_revocations table in the database
+type TokenRevocationModel struct {
+	ID                 string          `gorm:"type:uuid;primary_key"`
+	CredentialID       string          `gorm:"type:uuid;not null;index"`
+	UserID             string          `gorm:"type:uuid;not null;index"`
+	ProviderIdentifier string          `gorm:"type:varchar(50);not null"`
+	Status             string          `gorm:"type:varchar(20);not null"`
+	Attempts           int             `gorm:"not null;default:0"`
+	LastError          string          `gorm:"type:text"`
+	NextAttemptAt      *time.Time      `gorm:"type:timestamp with time zone"`
+	RevokedAt          *time.Time      `gorm:"type:timestamp with time zone"`
+	JSONAttributes     json.RawMessage `gorm:"type:jsonb"`
+	CreatedAt          time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
+	UpdatedAt          time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
+}
+
+// TableName returns the table name for the TokenRevocation model
+func (TokenRevocationModel) TableName() string {
+	return "token_revocations"
+}
+
+// OAuthAppModel represents the oauth_apps table in the database
+type OAuthAppModel struct {
+	ID                 string          `gorm:"type:uuid;primary_key"`
+	UserID             string          `gorm:"type:uuid;not null;index"`
+	OrganizationID     *string         `gorm:"type:uuid;index"`
+	ProviderIdentifier string          `gorm:"type:varchar(50);not null"`
+	ClientID           string          `gorm:"type:varchar(255);not null"`
+	AuthURL            string          `gorm:"type:text;not null;default:''"`
+	TokenURL           string          `gorm:"type:text;not null;default:''"`
+	APIBaseURL         string          `gorm:"type:text;not null;default:''"`
+	JSONAttributes     json.RawMessage `gorm:"type:jsonb;not null"`
+	CreatedAt          time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
+	UpdatedAt          time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
+	DeletedAt          gorm.DeletedAt  `gorm:"type:timestamp with time zone;index"`
+}
+
+// TableName returns the table name for the OAuthApp model
+func (OAuthAppModel) TableName() string {
+	return "oauth_apps"
+}
+
+// KeyRotationModel represents the key_rotations table in the database
+type KeyRotationModel struct {
+	ID             string     `gorm:"type:uuid;primary_key"`
+	Region         string     `gorm:"type:varchar(10);not null"`
+	CredentialType string     `gorm:"type:varchar(20);not null"`
+	KeyVersion     int        `gorm:"not null"`
+	Status         string     `gorm:"type:varchar(20);not null"`
+	Source         string     `gorm:"type:varchar(50);not null;default:''"`
+	Cursor         string     `gorm:"type:varchar(36);not null;default:''"`
+	Total          int        `gorm:"not null;default:0"`
+	Rewrapped      int        `gorm:"not null;default:0"`
+	Skipped        int        `gorm:"not null;default:0"`
+	Failed         int        `gorm:"not null;default:0"`
+	LastError      string     `gorm:"type:text"`
+	TriggeredBy    string     `gorm:"type:varchar(36);not null;default:''"`
+	StartedAt      time.Time  `gorm:"type:timestamp with time zone;not null;default:now()"`
+	CompletedAt    *time.Time `gorm:"type:timestamp with time zone"`
+	CreatedAt      time.Time  `gorm:"type:timestamp with time zone;not null;default:now()"`
+	UpdatedAt      time.Time  `gorm:"type:timestamp with time zone;not null;default:now()"`
+}
+
+// TableName returns the table name for the KeyRotation model
+func (KeyRotationModel) TableName() string {
+	return "key_rotations"
+}
+
+// MinDecryptionVersionModel represents the min_decryption_versions table in the database
+type MinDecryptionVersionModel struct {
+	Region         string    `gorm:"type:varchar(10);primary_key"`
+	CredentialType string    `gorm:"type:varchar(20);primary_key"`
+	Version        int       `gorm:"not null"`
+	CreatedAt      time.Time `gorm:"type:timestamp with time zone;not null;default:now()"`
+	UpdatedAt      time.Time `gorm:"type:timestamp with time zone;not null;default:now()"`
+}
+
+// TableName returns the table name for the MinDecryptionVersion model
+func (MinDecryptionVersionModel) TableName() string {
+	return "min_decryption_versions"
+}
+
 // BeforeCreate is called before creating a new record
 func (c *CredentialModel) BeforeCreate(tx *gorm.DB) error {
 	if c.CreatedAt.IsZero() {
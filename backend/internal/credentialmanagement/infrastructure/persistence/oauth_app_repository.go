@@ -0,0 +1,202 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bytedance/sonic"
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// OAuthAppRepository implements the domain.OAuthAppRepository interface
+type OAuthAppRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewOAuthAppRepository creates a new OAuth app repository
+func NewOAuthAppRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *OAuthAppRepository {
+	return &OAuthAppRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// GetByID retrieves an OAuth app by ID
+func (r *OAuthAppRepository) GetByID(ctx context.Context, id string) (*domain.OAuthApp, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "OAuthAppRepository.GetByID")
+	defer span.End()
+
+	var model OAuthAppModel
+	result := r.db.WithContext(ctx).First(&model, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.mapToDomain(&model)
+}
+
+// GetByOwnerAndProvider retrieves the OAuth app of a provider registered by a user, or by an organization if organizationID is not empty
+func (r *OAuthAppRepository) GetByOwnerAndProvider(ctx context.Context, userID, organizationID, providerIdentifier string) (*domain.OAuthApp, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "OAuthAppRepository.GetByOwnerAndProvider")
+	defer span.End()
+
+	query := r.db.WithContext(ctx).Where("provider_identifier = ?", providerIdentifier)
+	if organizationID != "" {
+		query = query.Where("organization_id = ?", organizationID)
+	} else {
+		query = query.Where("user_id = ? AND organization_id IS NULL", userID)
+	}
+
+	var model OAuthAppModel
+	result := query.First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.mapToDomain(&model)
+}
+
+// ListByUser retrieves the personal OAuth apps of a user
+func (r *OAuthAppRepository) ListByUser(ctx context.Context, userID string) ([]*domain.OAuthApp, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "OAuthAppRepository.ListByUser")
+	defer span.End()
+
+	var models []OAuthAppModel
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND organization_id IS NULL", userID).
+		Order("provider_identifier ASC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.mapToDomainList(models)
+}
+
+// ListByOrganization retrieves the OAuth apps of an organization
+func (r *OAuthAppRepository) ListByOrganization(ctx context.Context, organizationID string) ([]*domain.OAuthApp, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "OAuthAppRepository.ListByOrganization")
+	defer span.End()
+
+	var models []OAuthAppModel
+	result := r.db.WithContext(ctx).
+		Where("organization_id = ?", organizationID).
+		Order("provider_identifier ASC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.mapToDomainList(models)
+}
+
+// Create creates a new OAuth app
+func (r *OAuthAppRepository) Create(ctx context.Context, app *domain.OAuthApp) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "OAuthAppRepository.Create")
+	defer span.End()
+
+	model, err := r.mapToModel(app)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Create(model).Error
+}
+
+// Update updates an OAuth app
+func (r *OAuthAppRepository) Update(ctx context.Context, app *domain.OAuthApp) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "OAuthAppRepository.Update")
+	defer span.End()
+
+	model, err := r.mapToModel(app)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Save(model).Error
+}
+
+// Delete soft-deletes an OAuth app
+func (r *OAuthAppRepository) Delete(ctx context.Context, id string) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "OAuthAppRepository.Delete")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Delete(&OAuthAppModel{}, "id = ?", id).Error
+}
+
+// mapToDomainList converts OAuth app models to domain OAuth apps
+func (r *OAuthAppRepository) mapToDomainList(models []OAuthAppModel) ([]*domain.OAuthApp, error) {
+	apps := make([]*domain.OAuthApp, 0, len(models))
+	for i := range models {
+		app, err := r.mapToDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		apps = append(apps, app)
+	}
+	return apps, nil
+}
+
+// mapToDomain converts an OAuth app model to a domain OAuth app
+func (r *OAuthAppRepository) mapToDomain(model *OAuthAppModel) (*domain.OAuthApp, error) {
+	var jsonAttributes struct {
+		EncryptionMetadata *domain.EncryptionMetadata `json:"encryption_metadata"`
+	}
+
+	if err := sonic.Unmarshal(model.JSONAttributes, &jsonAttributes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal oauth app json attributes: %w", err)
+	}
+
+	return &domain.OAuthApp{
+		ID:                 model.ID,
+		UserID:             model.UserID,
+		OrganizationID:     parseGormOrganizationID(model.OrganizationID),
+		ProviderIdentifier: model.ProviderIdentifier,
+		ClientID:           model.ClientID,
+		EncryptionMetadata: jsonAttributes.EncryptionMetadata,
+		AuthURL:            model.AuthURL,
+		TokenURL:           model.TokenURL,
+		APIBaseURL:         model.APIBaseURL,
+		CreatedAt:          model.CreatedAt,
+		UpdatedAt:          model.UpdatedAt,
+	}, nil
+}
+
+// mapToModel converts a domain OAuth app to an OAuth app model
+func (r *OAuthAppRepository) mapToModel(app *domain.OAuthApp) (*OAuthAppModel, error) {
+	jsonAttributes := struct {
+		EncryptionMetadata *domain.EncryptionMetadata `json:"encryption_metadata"`
+	}{
+		EncryptionMetadata: app.EncryptionMetadata,
+	}
+
+	jsonAttributesJSON, err := sonic.Marshal(jsonAttributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal oauth app json attributes: %w", err)
+	}
+
+	return &OAuthAppModel{
+		ID:                 app.ID,
+		UserID:             app.UserID,
+		OrganizationID:     parseDomainOrganizationID(app.OrganizationID),
+		ProviderIdentifier: app.ProviderIdentifier,
+		ClientID:           app.ClientID,
+		AuthURL:            app.AuthURL,
+		TokenURL:           app.TokenURL,
+		APIBaseURL:         app.APIBaseURL,
+		JSONAttributes:     jsonAttributesJSON,
+		CreatedAt:          app.CreatedAt,
+		UpdatedAt:          app.UpdatedAt,
+	}, nil
+}
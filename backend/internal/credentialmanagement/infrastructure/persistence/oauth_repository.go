@@ -114,6 +114,7 @@ func (r *OAuthCredentialRepository) mapToDomain(credModel *CredentialModel, oaut
 	var jsonAttributes struct {
 		EncryptionMetadata *domain.EncryptionMetadata `json:"encryption_metadata"`
 		Scopes             []string                   `json:"scopes"`
+		OAuthAppID         string                     `json:"oauth_app_id,omitempty"`
 	}
 
 	if err := sonic.Unmarshal(oauthCredentialModel.JSONAttributes, &jsonAttributes); err != nil {
@@ -123,11 +124,15 @@ func (r *OAuthCredentialRepository) mapToDomain(credModel *CredentialModel, oaut
 	cred := &domain.Credential{
 		ID:                 credModel.ID,
 		UserID:             credModel.UserID,
+		OrganizationID:     parseGormOrganizationID(credModel.OrganizationID),
 		ProviderIdentifier: credModel.ProviderIdentifier,
 		Type:               domain.CredentialType(credModel.CredentialType),
 		IsValid:            credModel.IsValid,
 		CreatedAt:          credModel.CreatedAt,
 		UpdatedAt:          credModel.UpdatedAt,
+		LastVerifiedAt:     credModel.LastVerifiedAt,
+		VerificationStatus: domain.CredentialVerificationStatus(credModel.VerificationStatus),
+		VerificationError:  credModel.VerificationError,
 		DeletedAt:          parseGormDeletedAt(credModel.DeletedAt),
 	}
 
@@ -136,6 +141,7 @@ func (r *OAuthCredentialRepository) mapToDomain(credModel *CredentialModel, oaut
 		EncryptionMetadata: jsonAttributes.EncryptionMetadata,
 		Token:              nil,
 		Scopes:             jsonAttributes.Scopes,
+		OAuthAppID:         jsonAttributes.OAuthAppID,
 	}, nil
 }
 
@@ -144,9 +150,11 @@ func (r *OAuthCredentialRepository) mapToModel(credential *domain.OAuthCredentia
 	jsonAttributes := struct {
 		EncryptionMetadata *domain.EncryptionMetadata `json:"encryption_metadata"`
 		Scopes             []string                   `json:"scopes"`
+		OAuthAppID         string                     `json:"oauth_app_id,omitempty"`
 	}{
 		EncryptionMetadata: credential.EncryptionMetadata,
 		Scopes:             credential.Scopes,
+		OAuthAppID:         credential.OAuthAppID,
 	}
 
 	jsonAttributesJSON, err := sonic.Marshal(jsonAttributes)
@@ -259,11 +259,13 @@ func (r *RedisOAuthStateRepository) mapToModel(data *domain.OAuthStateData) (*OA
 		RedirectURL    string                 `json:"redirect_url"`
 		UserData       map[string]interface{} `json:"user_data"`
 		CallbackParams map[string]interface{} `json:"callback_params"`
+		OrganizationID string                 `json:"organization_id,omitempty"`
 	}{
 		Permissions:    data.Permissions,
 		RedirectURL:    data.RedirectURL,
 		UserData:       data.UserData,
 		CallbackParams: data.CallbackParams,
+		OrganizationID: data.OrganizationID,
 	}
 
 	jsonAttributes, err := sonic.Marshal(attributes)
@@ -291,6 +293,7 @@ func (r *RedisOAuthStateRepository) mapToDomain(model *OAuthStateModel) (*domain
 		RedirectURL    string                 `json:"redirect_url"`
 		UserData       map[string]interface{} `json:"user_data"`
 		CallbackParams map[string]interface{} `json:"callback_params"`
+		OrganizationID string                 `json:"organization_id,omitempty"`
 	}
 
 	if err := sonic.Unmarshal(model.JSONAttributes, &attributes); err != nil {
@@ -302,6 +305,7 @@ func (r *RedisOAuthStateRepository) mapToDomain(model *OAuthStateModel) (*domain
 		State:              model.State,
 		Status:             domain.OAuthStateStatus(model.Status),
 		UserID:             model.UserID,
+		OrganizationID:     attributes.OrganizationID,
 		ProviderIdentifier: model.ProviderIdentifier,
 		Permissions:        attributes.Permissions,
 		RedirectURL:        attributes.RedirectURL,
@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bytedance/sonic"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	contractCredential "github.com/context-space/context-space/backend/internal/shared/contract/credentialmanagement"
+)
+
+// secretStoreRowSeparator joins the name of a secret store and a row ID of the store into a row ID of the module secrets source
+const secretStoreRowSeparator = "/"
+
+// secretStores are the secret stores registered by other modules, read as a single ciphertext source
+// whose rows are ordered by store name and then by row ID within the store
+type secretStores struct {
+	mu     sync.RWMutex
+	stores map[string]contractCredential.SecretStore
+}
+
+// newSecretStores creates an empty set of secret stores
+func newSecretStores() *secretStores {
+	return &secretStores{
+		stores: make(map[string]contractCredential.SecretStore),
+	}
+}
+
+// register adds the store of a module, replacing a store registered under the same name
+func (s *secretStores) register(name string, store contractCredential.SecretStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stores[name] = store
+}
+
+// sorted returns the names of the registered stores in order, with the stores
+func (s *secretStores) sorted() ([]string, map[string]contractCredential.SecretStore) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.stores))
+	stores := make(map[string]contractCredential.SecretStore, len(s.stores))
+	for name, store := range s.stores {
+		names = append(names, name)
+		stores[name] = store
+	}
+	sort.Strings(names)
+	return names, stores
+}
+
+// countBelowVersion counts the secrets of every store encrypted in the region with an older key version
+func (s *secretStores) countBelowVersion(ctx context.Context, region domain.VaultRegion, keyVersion int) (int, error) {
+	names, stores := s.sorted()
+
+	total := 0
+	for _, name := range names {
+		count, err := stores[name].CountSecretsBelowVersion(ctx, string(region), keyVersion)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count secrets of %s: %w", name, err)
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// listBelowVersion lists the secrets encrypted in the region with an older key version, starting after the given row ID
+func (s *secretStores) listBelowVersion(ctx context.Context, region domain.VaultRegion, keyVersion int, afterRowID string, limit int) ([]*domain.StoredCiphertext, error) {
+	names, stores := s.sorted()
+	afterStore, afterStoreRowID, _ := strings.Cut(afterRowID, secretStoreRowSeparator)
+
+	ciphertexts := make([]*domain.StoredCiphertext, 0, limit)
+	for _, name := range names {
+		if len(ciphertexts) >= limit {
+			break
+		}
+		if afterRowID != "" && name < afterStore {
+			continue
+		}
+		cursor := ""
+		if name == afterStore {
+			cursor = afterStoreRowID
+		}
+
+		secrets, err := stores[name].ListSecretsBelowVersion(ctx, string(region), keyVersion, cursor, limit-len(ciphertexts))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secrets of %s: %w", name, err)
+		}
+		for _, secret := range secrets {
+			var metadata domain.EncryptionMetadata
+			if err := sonic.UnmarshalString(secret.EncryptionMetadata, &metadata); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal encryption metadata of %s %s: %w", name, secret.RowID, err)
+			}
+			ciphertexts = append(ciphertexts, &domain.StoredCiphertext{
+				RowID:    name + secretStoreRowSeparator + secret.RowID,
+				Metadata: &metadata,
+			})
+		}
+	}
+
+	return ciphertexts, nil
+}
+
+// replace stores the rewrapped ciphertext of a secret in its store if it still holds the original one
+func (s *secretStores) replace(ctx context.Context, original *domain.StoredCiphertext, rewrapped *domain.EncryptionMetadata) (bool, error) {
+	name, rowID, ok := strings.Cut(original.RowID, secretStoreRowSeparator)
+	if !ok {
+		return false, fmt.Errorf("invalid module secret row ID: %s", original.RowID)
+	}
+
+	_, stores := s.sorted()
+	store, ok := stores[name]
+	if !ok {
+		return false, fmt.Errorf("unknown secret store: %s", name)
+	}
+
+	metadataJSON, err := sonic.MarshalString(rewrapped)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal encryption metadata: %w", err)
+	}
+
+	return store.ReplaceSecret(ctx, rowID, original.Metadata.Ciphertext, metadataJSON)
+}
@@ -0,0 +1,100 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	contractCredential "github.com/context-space/context-space/backend/internal/shared/contract/credentialmanagement"
+)
+
+// memorySecretStore keeps secrets ordered by row ID, all of them below the key version
+type memorySecretStore struct {
+	rowIDs   []string
+	replaced map[string]string
+}
+
+func (s *memorySecretStore) CountSecretsBelowVersion(ctx context.Context, region string, keyVersion int) (int, error) {
+	return len(s.rowIDs), nil
+}
+
+func (s *memorySecretStore) ListSecretsBelowVersion(ctx context.Context, region string, keyVersion int, afterRowID string, limit int) ([]*contractCredential.StoredSecretDTO, error) {
+	var secrets []*contractCredential.StoredSecretDTO
+	for _, rowID := range s.rowIDs {
+		if rowID > afterRowID && len(secrets) < limit {
+			secrets = append(secrets, &contractCredential.StoredSecretDTO{
+				RowID:              rowID,
+				EncryptionMetadata: fmt.Sprintf(`{"region":"%s","key_version":1,"ciphertext":"vault:v1:%s"}`, region, rowID),
+			})
+		}
+	}
+	return secrets, nil
+}
+
+func (s *memorySecretStore) ReplaceSecret(ctx context.Context, rowID, originalCiphertext, encryptionMetadata string) (bool, error) {
+	s.replaced[rowID] = originalCiphertext
+	return true, nil
+}
+
+func TestSecretStoresListBelowVersion(t *testing.T) {
+	stores := newSecretStores()
+	stores.register("webhooks", &memorySecretStore{rowIDs: []string{"a", "b", "c"}})
+	stores.register("schedules", &memorySecretStore{rowIDs: []string{"x", "y"}})
+
+	tests := []struct {
+		name       string
+		afterRowID string
+		limit      int
+		expected   []string
+	}{
+		{name: "StoresInNameOrder", limit: 10, expected: []string{"schedules/x", "schedules/y", "webhooks/a", "webhooks/b", "webhooks/c"}},
+		{name: "LimitSpansStores", limit: 3, expected: []string{"schedules/x", "schedules/y", "webhooks/a"}},
+		{name: "ResumesWithinStore", afterRowID: "webhooks/a", limit: 10, expected: []string{"webhooks/b", "webhooks/c"}},
+		{name: "ResumesAtNextStore", afterRowID: "schedules/y", limit: 10, expected: []string{"webhooks/a", "webhooks/b", "webhooks/c"}},
+		{name: "Exhausted", afterRowID: "webhooks/c", limit: 10, expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ciphertexts, err := stores.listBelowVersion(context.Background(), domain.RegionEU, 2, tt.afterRowID, tt.limit)
+			if err != nil {
+				t.Fatalf("listBelowVersion() error = %v", err)
+			}
+
+			rowIDs := make([]string, 0, len(ciphertexts))
+			for _, ciphertext := range ciphertexts {
+				rowIDs = append(rowIDs, ciphertext.RowID)
+			}
+			if !reflect.DeepEqual(rowIDs, tt.expected) {
+				t.Errorf("row IDs = %v, want %v", rowIDs, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSecretStoresCountAndReplace(t *testing.T) {
+	webhooks := &memorySecretStore{rowIDs: []string{"a", "b"}, replaced: map[string]string{}}
+	stores := newSecretStores()
+	stores.register("webhooks", webhooks)
+	stores.register("schedules", &memorySecretStore{rowIDs: []string{"x"}})
+
+	count, err := stores.countBelowVersion(context.Background(), domain.RegionEU, 2)
+	if err != nil || count != 3 {
+		t.Errorf("countBelowVersion() = %d, %v, want 3", count, err)
+	}
+
+	original := &domain.StoredCiphertext{RowID: "webhooks/b", Metadata: &domain.EncryptionMetadata{Ciphertext: "vault:v1:b"}}
+	replaced, err := stores.replace(context.Background(), original, &domain.EncryptionMetadata{Ciphertext: "vault:v2:b"})
+	if err != nil || !replaced {
+		t.Fatalf("replace() = %v, %v, want the secret replaced", replaced, err)
+	}
+	if webhooks.replaced["b"] != "vault:v1:b" {
+		t.Errorf("replaced = %v, want row b of the webhooks store compared to its original ciphertext", webhooks.replaced)
+	}
+
+	if _, err := stores.replace(context.Background(), &domain.StoredCiphertext{RowID: "unknown/a", Metadata: &domain.EncryptionMetadata{}}, &domain.EncryptionMetadata{}); err == nil {
+		t.Error("replace() succeeded for an unregistered store")
+	}
+}
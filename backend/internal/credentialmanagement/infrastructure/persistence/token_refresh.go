@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -22,6 +23,8 @@ type TokenRefreshService struct {
 	credentialRepo      domain.CredentialRepository
 	oauthCredentialRepo domain.OAuthCredentialRepository
 	vaultService        domain.VaultService
+	invalidator         domain.CredentialInvalidator
+	appResolver         domain.OAuthAppResolver
 	obs                 *observability.ObservabilityProvider
 }
 
@@ -32,6 +35,8 @@ func NewTokenRefreshService(
 	credentialRepo domain.CredentialRepository,
 	oauthCredentialRepo domain.OAuthCredentialRepository,
 	vaultService domain.VaultService,
+	invalidator domain.CredentialInvalidator,
+	appResolver domain.OAuthAppResolver,
 	obs *observability.ObservabilityProvider,
 ) *TokenRefreshService {
 	return &TokenRefreshService{
@@ -40,6 +45,8 @@ func NewTokenRefreshService(
 		credentialRepo:      credentialRepo,
 		oauthCredentialRepo: oauthCredentialRepo,
 		vaultService:        vaultService,
+		invalidator:         invalidator,
+		appResolver:         appResolver,
 		obs:                 obs,
 	}
 }
@@ -72,7 +79,7 @@ func (s *TokenRefreshService) RefreshAccessTokens(ctx context.Context) error {
 	//Get last used in 24 hours credentials
 	providerRefreshMap := make(map[string][]*domain.OAuthCredential)
 	for _, credential := range credentials {
-		if credential.LastUsedAt.Compare(nowTime.Add(-24*time.Hour)) == -1 {
+		if !credential.IsValid || credential.LastUsedAt.Compare(nowTime.Add(-24*time.Hour)) == -1 {
 			continue
 		}
 
@@ -93,6 +100,7 @@ func (s *TokenRefreshService) RefreshAccessTokens(ctx context.Context) error {
 
 		oauthCred.Token = token
 		oauthCred.UserID = credential.UserID
+		oauthCred.OrganizationID = credential.OrganizationID
 		oauthCred.ProviderIdentifier = credential.ProviderIdentifier
 
 		providerRefreshMap[credential.ProviderIdentifier] = append(
@@ -237,7 +245,7 @@ func (s *TokenRefreshService) refreshSingleTokenWithLockAndRetry(
 
 	initialToken := oauthCred.Token.AccessToken
 
-	lockKey := fmt.Sprintf(cache.AccessTokenLockKey, oauthCred.ProviderIdentifier, oauthCred.UserID)
+	lockKey := fmt.Sprintf(cache.AccessTokenLockKey, oauthCred.ProviderIdentifier, oauthCred.OwnerID())
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		// try to get lock
 		lock, err := s.redisClient.AcquireLock(ctx, lockKey, cache.AccessTokenLockTimeout)
@@ -281,6 +289,10 @@ func (s *TokenRefreshService) refreshSingleTokenWithLockAndRetry(
 		if err != nil {
 			return fmt.Errorf("failed to get latest credential: %w", err)
 		}
+		if latestCred == nil {
+			// deleted or invalidated by another process
+			return nil
+		}
 
 		token := &oauth2.Token{}
 		if err := s.vaultService.DecryptJSON(ctx, latestCred.EncryptionMetadata, token); err != nil {
@@ -305,9 +317,27 @@ func (s *TokenRefreshService) refreshSingleTokenWithLockAndRetry(
 
 // refreshTokenCore execute token refresh core logic
 func (s *TokenRefreshService) refreshTokenCore(ctx context.Context, providerID string, oauthCred *domain.OAuthCredential) error {
+	// tokens issued through an OAuth app are refreshed with the same app
+	if oauthCred.OAuthApp == nil && oauthCred.OAuthAppID != "" && s.appResolver != nil {
+		app, err := s.appResolver.GetOAuthApp(ctx, oauthCred.OAuthAppID)
+		if err != nil {
+			return fmt.Errorf("failed to get OAuth app: %w", err)
+		}
+		oauthCred.OAuthApp = app
+	}
+	ctx = domain.WithOAuthApp(ctx, oauthCred.OAuthApp)
+
 	// execute token refresh
 	newToken, err := s.oauthProvider.RefreshToken(ctx, providerID, oauthCred.Token)
 	if err != nil {
+		// A rejected refresh token will not recover, invalidate the credential so its owner reconnects
+		if errors.Is(err, domain.ErrCredentialRejected) && s.invalidator != nil && oauthCred.Credential != nil {
+			if invalidateErr := s.invalidator.Invalidate(ctx, oauthCred.Credential, err.Error()); invalidateErr != nil {
+				s.obs.Logger.Error(ctx, "Failed to invalidate credential",
+					zap.String("credential_id", oauthCred.ID),
+					zap.Error(invalidateErr))
+			}
+		}
 		return fmt.Errorf("failed to refresh OAuth token: %w", err)
 	}
 
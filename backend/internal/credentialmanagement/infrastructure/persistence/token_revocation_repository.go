@@ -0,0 +1,163 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bytedance/sonic"
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+)
+
+// TokenRevocationRepository implements the domain.TokenRevocationRepository interface
+type TokenRevocationRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewTokenRevocationRepository creates a new token revocation repository
+func NewTokenRevocationRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *TokenRevocationRepository {
+	return &TokenRevocationRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// Create creates a new token revocation
+func (r *TokenRevocationRepository) Create(ctx context.Context, revocation *domain.TokenRevocation) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "TokenRevocationRepository.Create")
+	defer span.End()
+
+	model, err := r.mapToModel(revocation)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Create(model).Error
+}
+
+// Update updates a token revocation
+func (r *TokenRevocationRepository) Update(ctx context.Context, revocation *domain.TokenRevocation) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "TokenRevocationRepository.Update")
+	defer span.End()
+
+	model, err := r.mapToModel(revocation)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Save(model).Error
+}
+
+// ListDue lists pending revocations whose next attempt is due at the given time
+func (r *TokenRevocationRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.TokenRevocation, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "TokenRevocationRepository.ListDue")
+	defer span.End()
+
+	var models []TokenRevocationModel
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", string(domain.TokenRevocationStatusPending), now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.mapToDomainList(models)
+}
+
+// ListByUser lists the revocations of a user, newest first
+func (r *TokenRevocationRepository) ListByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.TokenRevocation, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "TokenRevocationRepository.ListByUser")
+	defer span.End()
+
+	var models []TokenRevocationModel
+	result := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.mapToDomainList(models)
+}
+
+// mapToDomainList converts token revocation models to domain token revocations
+func (r *TokenRevocationRepository) mapToDomainList(models []TokenRevocationModel) ([]*domain.TokenRevocation, error) {
+	revocations := make([]*domain.TokenRevocation, 0, len(models))
+	for i := range models {
+		revocation, err := r.mapToDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		revocations = append(revocations, revocation)
+	}
+	return revocations, nil
+}
+
+// mapToDomain converts a token revocation model to a domain token revocation
+func (r *TokenRevocationRepository) mapToDomain(model *TokenRevocationModel) (*domain.TokenRevocation, error) {
+	var jsonAttributes struct {
+		EncryptionMetadata *domain.EncryptionMetadata `json:"encryption_metadata"`
+		OAuthAppID         string                     `json:"oauth_app_id"`
+	}
+
+	if len(model.JSONAttributes) > 0 {
+		if err := sonic.Unmarshal(model.JSONAttributes, &jsonAttributes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal token revocation json attributes: %w", err)
+		}
+	}
+
+	return &domain.TokenRevocation{
+		ID:                 model.ID,
+		CredentialID:       model.CredentialID,
+		UserID:             model.UserID,
+		ProviderIdentifier: model.ProviderIdentifier,
+		Status:             domain.TokenRevocationStatus(model.Status),
+		Attempts:           model.Attempts,
+		LastError:          model.LastError,
+		EncryptionMetadata: jsonAttributes.EncryptionMetadata,
+		OAuthAppID:         jsonAttributes.OAuthAppID,
+		NextAttemptAt:      model.NextAttemptAt,
+		RevokedAt:          model.RevokedAt,
+		CreatedAt:          model.CreatedAt,
+		UpdatedAt:          model.UpdatedAt,
+	}, nil
+}
+
+// mapToModel converts a domain token revocation to a token revocation model
+func (r *TokenRevocationRepository) mapToModel(revocation *domain.TokenRevocation) (*TokenRevocationModel, error) {
+	jsonAttributes := struct {
+		EncryptionMetadata *domain.EncryptionMetadata `json:"encryption_metadata,omitempty"`
+		OAuthAppID         string                     `json:"oauth_app_id,omitempty"`
+	}{
+		EncryptionMetadata: revocation.EncryptionMetadata,
+		OAuthAppID:         revocation.OAuthAppID,
+	}
+
+	jsonAttributesJSON, err := sonic.Marshal(jsonAttributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal token revocation json attributes: %w", err)
+	}
+
+	return &TokenRevocationModel{
+		ID:                 revocation.ID,
+		CredentialID:       revocation.CredentialID,
+		UserID:             revocation.UserID,
+		ProviderIdentifier: revocation.ProviderIdentifier,
+		Status:             string(revocation.Status),
+		Attempts:           revocation.Attempts,
+		LastError:          revocation.LastError,
+		NextAttemptAt:      revocation.NextAttemptAt,
+		RevokedAt:          revocation.RevokedAt,
+		JSONAttributes:     jsonAttributesJSON,
+		CreatedAt:          revocation.CreatedAt,
+		UpdatedAt:          revocation.UpdatedAt,
+	}, nil
+}
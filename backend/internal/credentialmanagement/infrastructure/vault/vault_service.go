@@ -3,7 +3,11 @@ package vault
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
 	"sync"
 
 	"github.com/bytedance/sonic"
@@ -31,6 +35,11 @@ type RegionalVaultClient struct {
 	region          domain.VaultRegion
 }
 
+var (
+	_ domain.VaultHealthChecker = (*VaultServiceImpl)(nil)
+	_ domain.VaultKeyManager    = (*VaultServiceImpl)(nil)
+)
+
 // VaultServiceImpl implements the domain.VaultService interface using HashiCorp Vault
 type VaultServiceImpl struct {
 	mu             sync.RWMutex
@@ -54,7 +63,7 @@ func NewVaultService(ctx context.Context, config *VaultConfig, defaultRegion dom
 	algorithmMap := map[domain.VaultRegion]domain.VaultAlgorithm{
 		domain.RegionEU: domain.AlgorithmAESGCM,
 		domain.RegionUS: domain.AlgorithmAESGCM,
-		domain.RegionCN: domain.AlgorithmAESGCM, // Will support SM4GCM in the future
+		domain.RegionCN: domain.AlgorithmAESGCM, // Transit keys have no SM4, SM4GCM is provided by the envelope backend
 	}
 
 	keyNamePattern := map[domain.CredentialType]string{
@@ -327,6 +336,109 @@ func (v *VaultServiceImpl) ReWrapData(ctx context.Context, currentMetadata *doma
 	return newMetadata, nil
 }
 
+// GetLatestKeyVersion returns the latest version of the key in the specified region for the given credential type
+func (v *VaultServiceImpl) GetLatestKeyVersion(ctx context.Context, region domain.VaultRegion, credentialType domain.CredentialType) (int, error) {
+	client, err := v.getRegionalClient(region)
+	if err != nil {
+		return 0, err
+	}
+
+	keyName := v.getKeyName(credentialType, region)
+	path := fmt.Sprintf("%s/keys/%s", v.getTransitPath(client, credentialType), keyName)
+
+	secret, err := client.client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read key %s in region %s: %w", keyName, region, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return 0, fmt.Errorf("key %s not found in region %s", keyName, region)
+	}
+
+	version, err := parseKeyVersion(secret.Data["latest_version"])
+	if err != nil {
+		return 0, fmt.Errorf("invalid latest version of key %s in region %s: %w", keyName, region, err)
+	}
+
+	return version, nil
+}
+
+// SetMinDecryptionVersion disables decryption with older versions of the key in the specified region for the given credential type
+func (v *VaultServiceImpl) SetMinDecryptionVersion(ctx context.Context, region domain.VaultRegion, credentialType domain.CredentialType, version int) error {
+	client, err := v.getRegionalClient(region)
+	if err != nil {
+		return err
+	}
+
+	keyName := v.getKeyName(credentialType, region)
+	path := fmt.Sprintf("%s/keys/%s/config", v.getTransitPath(client, credentialType), keyName)
+
+	data := map[string]interface{}{
+		"min_decryption_version": version,
+	}
+
+	_, err = client.client.Logical().WriteWithContext(ctx, path, data)
+	if err != nil {
+		return fmt.Errorf("failed to set min decryption version of key %s in region %s: %w", keyName, region, err)
+	}
+
+	return nil
+}
+
+// parseKeyVersion converts a key version read from Vault, which is decoded as json.Number
+func parseKeyVersion(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case json.Number:
+		version, err := v.Int64()
+		return int(version), err
+	case float64:
+		return int(v), nil
+	case int:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("unexpected type %T", value)
+	}
+}
+
+// Regions returns the configured vault regions
+func (v *VaultServiceImpl) Regions() []domain.VaultRegion {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	regions := make([]domain.VaultRegion, 0, len(v.clients))
+	for region := range v.clients {
+		regions = append(regions, region)
+	}
+	sort.Slice(regions, func(i, j int) bool { return regions[i] < regions[j] })
+	return regions
+}
+
+// CheckTransitKeys checks the transit keys of every credential type exist in the region
+func (v *VaultServiceImpl) CheckTransitKeys(ctx context.Context, region domain.VaultRegion) error {
+	client, err := v.getRegionalClient(region)
+	if err != nil {
+		return err
+	}
+
+	for _, credType := range []domain.CredentialType{domain.CredentialTypeOAuth, domain.CredentialTypeAPIKey} {
+		keyName := v.getKeyName(credType, region)
+		path := fmt.Sprintf("%s/keys/%s", v.getTransitPath(client, credType), keyName)
+
+		secret, err := client.client.Logical().ReadWithContext(ctx, path)
+		if err != nil {
+			var responseErr *api.ResponseError
+			if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusForbidden {
+				return fmt.Errorf("%w: cannot read transit key %s in region %s", domain.ErrVaultPermissionDenied, keyName, region)
+			}
+			return fmt.Errorf("failed to read transit key %s in region %s: %w", keyName, region, err)
+		}
+		if secret == nil || secret.Data == nil {
+			return fmt.Errorf("transit key %s not found in region %s", keyName, region)
+		}
+	}
+
+	return nil
+}
+
 // CheckHealth checks the health of the Vault service for the specified region
 func (v *VaultServiceImpl) CheckHealth(ctx context.Context, region domain.VaultRegion) error {
 	client, err := v.getRegionalClient(region)
@@ -2,7 +2,10 @@ package contract
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/bytedance/sonic"
 	observability "github.com/context-space/cloud-observability"
 	"github.com/context-space/context-space/backend/internal/credentialmanagement/application"
 	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
@@ -10,13 +13,23 @@ import (
 	"go.uber.org/zap"
 )
 
+// secretStoreRegistry registers the secret stores of other modules so that key rotations rewrap their secrets
+type secretStoreRegistry interface {
+	RegisterSecretStore(name string, store contractCredential.SecretStore)
+}
+
 // CredentialContractFacade implements the Contract interface at the Interface layer
 // Responsibility: Calls the Application layer and handles Domain to DTO conversion
 type CredentialContractFacade struct {
 	credentialService *application.CredentialService
 	credentialFactory domain.CredentialFactory
 	tokenRefresh      domain.TokenRefresh
-	obs               *observability.ObservabilityProvider
+	oauthStateService domain.OAuthStateService
+	vaultService      domain.VaultService
+	secretStores      secretStoreRegistry
+	// consentRedirectURL is where users land after an incremental authorization
+	consentRedirectURL string
+	obs                *observability.ObservabilityProvider
 }
 
 // Ensure implementation of contract interface
@@ -27,13 +40,21 @@ func NewCredentialContractFacade(
 	credentialService *application.CredentialService,
 	credentialFactory domain.CredentialFactory,
 	tokenRefresh domain.TokenRefresh,
+	oauthStateService domain.OAuthStateService,
+	vaultService domain.VaultService,
+	secretStores secretStoreRegistry,
+	consentRedirectURL string,
 	obs *observability.ObservabilityProvider,
 ) contractCredential.CredentialManagementContract {
 	return &CredentialContractFacade{
-		credentialService: credentialService,
-		credentialFactory: credentialFactory,
-		tokenRefresh:      tokenRefresh,
-		obs:               obs,
+		credentialService:  credentialService,
+		credentialFactory:  credentialFactory,
+		tokenRefresh:       tokenRefresh,
+		oauthStateService:  oauthStateService,
+		vaultService:       vaultService,
+		secretStores:       secretStores,
+		consentRedirectURL: consentRedirectURL,
+		obs:                obs,
 	}
 }
 
@@ -49,6 +70,9 @@ func (f *CredentialContractFacade) GetCredentialByUserAndProviderContract(ctx co
 	// Call application service
 	credential, err := f.credentialService.GetCredentialByUserAndProvider(ctx, userID, providerIdentifier)
 	if err != nil {
+		if errors.Is(err, application.ErrCredentialNotFound) {
+			return nil, nil
+		}
 		f.obs.Logger.Error(ctx, "Failed to get credential from service",
 			zap.String("user_id", userID),
 			zap.String("provider_identifier", providerIdentifier),
@@ -60,6 +84,26 @@ func (f *CredentialContractFacade) GetCredentialByUserAndProviderContract(ctx co
 	return credential, nil
 }
 
+// GetCredentialByOrganizationAndProviderContract gets the credential an organization shares for a provider
+func (f *CredentialContractFacade) GetCredentialByOrganizationAndProviderContract(ctx context.Context, organizationID, providerIdentifier string) (interface{}, error) {
+	ctx, span := f.obs.Tracer.Start(ctx, "CredentialContractFacade.GetCredentialByOrganizationAndProviderContract")
+	defer span.End()
+
+	credential, err := f.credentialService.GetCredentialByOrganizationAndProvider(ctx, organizationID, providerIdentifier)
+	if err != nil {
+		if errors.Is(err, application.ErrCredentialNotFound) {
+			return nil, nil
+		}
+		f.obs.Logger.Error(ctx, "Failed to get organization credential from service",
+			zap.String("organization_id", organizationID),
+			zap.String("provider_identifier", providerIdentifier),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return credential, nil
+}
+
 // CreateNoneCredentialContract creates a none credential and converts it to contract DTO
 func (f *CredentialContractFacade) CreateNoneCredentialContract(ctx context.Context, userID, providerIdentifier string) (*contractCredential.CredentialDTO, error) {
 	ctx, span := f.obs.Tracer.Start(ctx, "CredentialContractFacade.CreateNoneCredentialContract")
@@ -111,3 +155,111 @@ func (f *CredentialContractFacade) RefreshAccessTokenContract(ctx context.Contex
 
 	return f.tokenRefresh.RefreshAccessTokenIfNeeded(ctx, providerIdentifier, credential)
 }
+
+// CreateIncrementalAuthorizationContract stores an OAuth state for the union of granted and missing permissions
+// and returns the authorization URL the user must visit to grant them. The flow re-authorizes the organization's
+// credential when organizationID is set and the user's own credential otherwise
+func (f *CredentialContractFacade) CreateIncrementalAuthorizationContract(
+	ctx context.Context,
+	userID, organizationID, providerIdentifier string,
+	missingPermissions []string,
+) (*contractCredential.IncrementalAuthorizationDTO, error) {
+	ctx, span := f.obs.Tracer.Start(ctx, "CredentialContractFacade.CreateIncrementalAuthorizationContract")
+	defer span.End()
+
+	if f.consentRedirectURL == "" {
+		f.obs.Logger.Warn(ctx, "Consent redirect URL is not configured, no incremental authorization is offered",
+			zap.String("provider_identifier", providerIdentifier))
+		return nil, contractCredential.ErrConsentRedirectNotConfigured
+	}
+
+	granted, err := f.credentialService.GetGrantedPermissions(ctx, userID, organizationID, providerIdentifier)
+	if err != nil {
+		f.obs.Logger.Error(ctx, "Failed to get granted permissions",
+			zap.String("user_id", userID),
+			zap.String("organization_id", organizationID),
+			zap.String("provider_identifier", providerIdentifier),
+			zap.Error(err))
+		return nil, err
+	}
+
+	permissions := make([]string, 0, len(granted)+len(missingPermissions))
+	seen := make(map[string]bool, len(granted)+len(missingPermissions))
+	for _, permission := range append(granted, missingPermissions...) {
+		if seen[permission] {
+			continue
+		}
+		seen[permission] = true
+		permissions = append(permissions, permission)
+	}
+
+	stateData, err := domain.NewOAuthStateData(userID, providerIdentifier, f.consentRedirectURL, permissions, map[string]interface{}{
+		"source": "incremental_authorization",
+	})
+	if err != nil {
+		return nil, err
+	}
+	stateData.OrganizationID = organizationID
+
+	if err := f.oauthStateService.StoreStateData(ctx, stateData); err != nil {
+		return nil, err
+	}
+
+	authURL, err := f.credentialService.GetOAuthURLForOwner(ctx, userID, organizationID, providerIdentifier, stateData.State, stateData.CodeChallenge, permissions)
+	if err != nil {
+		f.obs.Logger.Error(ctx, "Failed to generate incremental authorization URL",
+			zap.String("user_id", userID),
+			zap.String("provider_identifier", providerIdentifier),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return &contractCredential.IncrementalAuthorizationDTO{
+		AuthURL:      authURL,
+		OAuthStateID: stateData.ID,
+		Permissions:  permissions,
+	}, nil
+}
+
+// EncryptSecretContract encrypts a secret stored by another module with the API key transit key
+func (f *CredentialContractFacade) EncryptSecretContract(ctx context.Context, plaintext string) (string, error) {
+	ctx, span := f.obs.Tracer.Start(ctx, "CredentialContractFacade.EncryptSecretContract")
+	defer span.End()
+
+	metadata, err := f.vaultService.EncryptData(ctx, plaintext, domain.RegionEU, domain.CredentialTypeAPIKey)
+	if err != nil {
+		f.obs.Logger.Error(ctx, "Failed to encrypt secret", zap.Error(err))
+		return "", err
+	}
+
+	metadataJSON, err := sonic.MarshalString(metadata)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal encryption metadata: %w", err)
+	}
+
+	return metadataJSON, nil
+}
+
+// DecryptSecretContract decrypts a secret encrypted with EncryptSecretContract
+func (f *CredentialContractFacade) DecryptSecretContract(ctx context.Context, encryptionMetadata string) (string, error) {
+	ctx, span := f.obs.Tracer.Start(ctx, "CredentialContractFacade.DecryptSecretContract")
+	defer span.End()
+
+	var metadata domain.EncryptionMetadata
+	if err := sonic.UnmarshalString(encryptionMetadata, &metadata); err != nil {
+		return "", fmt.Errorf("failed to unmarshal encryption metadata: %w", err)
+	}
+
+	plaintext, err := f.vaultService.DecryptData(ctx, &metadata)
+	if err != nil {
+		f.obs.Logger.Error(ctx, "Failed to decrypt secret", zap.Error(err))
+		return "", err
+	}
+
+	return plaintext, nil
+}
+
+// RegisterSecretStoreContract registers the store of a module keeping secrets encrypted with EncryptSecretContract
+func (f *CredentialContractFacade) RegisterSecretStoreContract(name string, store contractCredential.SecretStore) {
+	f.secretStores.RegisterSecretStore(name, store)
+}
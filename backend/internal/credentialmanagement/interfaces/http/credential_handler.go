@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 // CredentialHandler handles HTTP requests for credential management
 type CredentialHandler struct {
 	credentialService    *application.CredentialService
+	revocationService    *application.TokenRevocationService
 	oauthStateService    domain.OAuthStateService
 	obs                  *observability.ObservabilityProvider
 	redirectURLValidator *security.RedirectURLValidator
@@ -30,12 +32,14 @@ type CredentialHandler struct {
 // NewCredentialHandler creates a new instance of CredentialHandler
 func NewCredentialHandler(
 	credentialService *application.CredentialService,
+	revocationService *application.TokenRevocationService,
 	oauthStateService domain.OAuthStateService,
 	observabilityProvider *observability.ObservabilityProvider,
 	redirectURLValidator *security.RedirectURLValidator,
 ) *CredentialHandler {
 	return &CredentialHandler{
 		credentialService:    credentialService,
+		revocationService:    revocationService,
 		oauthStateService:    oauthStateService,
 		obs:                  observabilityProvider,
 		redirectURLValidator: redirectURLValidator,
@@ -54,8 +58,10 @@ func (h *CredentialHandler) RegisterRoutes(router *gin.RouterGroup, requireAuth
 		credentialsWithAuth.GET("", h.GetAllCredentialsByUser)
 		credentialsWithAuth.GET("/provider/:provider_identifier", h.GetCredentialByUserAndProvider)
 		credentialsWithAuth.DELETE("/:id", h.DeleteCredential)
+		credentialsWithAuth.GET("/revocations", h.ListTokenRevocations)
 
 		credentialsWithAuth.POST("/auth/apikey/:provider_identifier", h.CreateAPIKeyCredential)
+		credentialsWithAuth.POST("/auth/app-installation/:provider_identifier", h.CreateAppInstallationCredential)
 
 		credentialsWithAuth.POST("/auth/oauth/:provider_identifier/auth-url", h.CreateOAuthURL)
 		credentialsWithAuth.GET("/auth/oauth/state/:oauth_state_id", h.GetOAuthStateData)
@@ -65,27 +71,40 @@ func (h *CredentialHandler) RegisterRoutes(router *gin.RouterGroup, requireAuth
 type CredentialResponse struct {
 	ID                 string   `json:"id"`
 	UserID             string   `json:"user_id"`
+	OrganizationID     string   `json:"organization_id,omitempty"`
 	ProviderIdentifier string   `json:"provider_identifier"`
 	Type               string   `json:"type"`
 	Permissions        []string `json:"permissions,omitempty"`
 	IsValid            bool     `json:"is_valid"`
 	CreatedAt          string   `json:"created_at"`
+	// Result of the last check against the provider, absent until the credential is first verified
+	LastVerifiedAt     *string `json:"last_verified_at,omitempty"`
+	VerificationStatus string  `json:"verification_status,omitempty"`
+	VerificationError  string  `json:"verification_error,omitempty"`
 }
 
 type CredentialResponseList struct {
 	Credentials []CredentialResponse `json:"credentials"`
 }
 
-func (h *CredentialHandler) mapCredentialToResponse(cred *domain.Credential, permissions []string) CredentialResponse {
-	return CredentialResponse{
+func mapCredentialToResponse(cred *domain.Credential, permissions []string) CredentialResponse {
+	response := CredentialResponse{
 		ID:                 cred.ID,
 		UserID:             cred.UserID,
+		OrganizationID:     cred.OrganizationID,
 		ProviderIdentifier: cred.ProviderIdentifier,
 		Type:               string(cred.Type),
 		Permissions:        permissions,
 		IsValid:            cred.IsValid,
 		CreatedAt:          cred.CreatedAt.Format(time.RFC3339),
+		VerificationStatus: string(cred.VerificationStatus),
+		VerificationError:  cred.VerificationError,
 	}
+	if cred.LastVerifiedAt != nil {
+		lastVerifiedAt := cred.LastVerifiedAt.Format(time.RFC3339)
+		response.LastVerifiedAt = &lastVerifiedAt
+	}
+	return response
 }
 
 // GetAllCredentialsByUser godoc
@@ -119,7 +138,7 @@ func (h *CredentialHandler) GetAllCredentialsByUser(c *gin.Context) {
 		Credentials: make([]CredentialResponse, len(creds)),
 	}
 	for i, cred := range creds {
-		credsResponse.Credentials[i] = h.mapCredentialToResponse(cred, nil)
+		credsResponse.Credentials[i] = mapCredentialToResponse(cred, nil)
 	}
 
 	httpapi.OK(c, credsResponse, "Credentials retrieved successfully")
@@ -176,9 +195,11 @@ func (h *CredentialHandler) GetCredentialByUserAndProvider(c *gin.Context) {
 			h.obs.Logger.Error(ctx, "Failed to get permission identifiers from scopes", zap.Error(err))
 			return
 		}
-		credResponse = h.mapCredentialToResponse(cred.Credential, permissions)
+		credResponse = mapCredentialToResponse(cred.Credential, permissions)
 	case *domain.APIKeyCredential:
-		credResponse = h.mapCredentialToResponse(cred.Credential, nil)
+		credResponse = mapCredentialToResponse(cred.Credential, nil)
+	case *domain.AppInstallationCredential:
+		credResponse = mapCredentialToResponse(cred.Credential, nil)
 	default:
 		httpapi.InternalServerError(c, "Invalid credential type")
 		return
@@ -241,12 +262,15 @@ func (h *CredentialHandler) DeleteCredential(c *gin.Context) {
 		cred = credI.Credential
 	case *domain.APIKeyCredential:
 		cred = credI.Credential
+	case *domain.AppInstallationCredential:
+		cred = credI.Credential
 	default:
 		httpapi.InternalServerError(c, "Invalid credential type")
 		return
 	}
 
-	if cred.UserID != user.ID {
+	// Organization credentials are deleted through the organization routes
+	if cred.UserID != user.ID || cred.OrganizationID != "" {
 		httpapi.Forbidden(c, "You are not allowed to delete this credential")
 		return
 	}
@@ -264,6 +288,97 @@ func (h *CredentialHandler) DeleteCredential(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// TokenRevocationResponse represents the upstream revocation of a deleted credential's token
+type TokenRevocationResponse struct {
+	ID                 string  `json:"id"`
+	CredentialID       string  `json:"credential_id"`
+	ProviderIdentifier string  `json:"provider_identifier"`
+	Status             string  `json:"status"`
+	Attempts           int     `json:"attempts"`
+	LastError          string  `json:"last_error,omitempty"`
+	NextAttemptAt      *string `json:"next_attempt_at,omitempty"`
+	RevokedAt          *string `json:"revoked_at,omitempty"`
+	CreatedAt          string  `json:"created_at"`
+}
+
+type TokenRevocationResponseList struct {
+	Revocations []TokenRevocationResponse `json:"revocations"`
+}
+
+func mapTokenRevocationToResponse(revocation *domain.TokenRevocation) TokenRevocationResponse {
+	response := TokenRevocationResponse{
+		ID:                 revocation.ID,
+		CredentialID:       revocation.CredentialID,
+		ProviderIdentifier: revocation.ProviderIdentifier,
+		Status:             string(revocation.Status),
+		Attempts:           revocation.Attempts,
+		LastError:          revocation.LastError,
+		CreatedAt:          revocation.CreatedAt.Format(time.RFC3339),
+	}
+	if revocation.NextAttemptAt != nil {
+		nextAttemptAt := revocation.NextAttemptAt.Format(time.RFC3339)
+		response.NextAttemptAt = &nextAttemptAt
+	}
+	if revocation.RevokedAt != nil {
+		revokedAt := revocation.RevokedAt.Format(time.RFC3339)
+		response.RevokedAt = &revokedAt
+	}
+	return response
+}
+
+// ListTokenRevocations godoc
+// @Summary List token revocations
+// @Description Returns the outcome of revoking the tokens of deleted credentials at their providers
+// @Tags credentials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Limit (default: 20)"
+// @Param offset query int false "Offset (default: 0)"
+// @Success 200 {object} httpapi.Response{data=TokenRevocationResponseList} "Success response with token revocations"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /credentials/revocations [get]
+func (h *CredentialHandler) ListTokenRevocations(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return
+	}
+	user := userI.(*identityDomain.User)
+
+	limit := 20
+	offset := 0
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
+			limit = min(parsedLimit, 100)
+		}
+	}
+	if offsetParam := c.Query("offset"); offsetParam != "" {
+		if parsedOffset, err := strconv.Atoi(offsetParam); err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	revocations, err := h.revocationService.ListByUser(ctx, user.ID, limit, offset)
+	if err != nil {
+		h.obs.Logger.Error(ctx, "Failed to list token revocations", zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to list token revocations")
+		return
+	}
+
+	response := TokenRevocationResponseList{
+		Revocations: make([]TokenRevocationResponse, len(revocations)),
+	}
+	for i, revocation := range revocations {
+		response.Revocations[i] = mapTokenRevocationToResponse(revocation)
+	}
+
+	httpapi.OK(c, response, "Token revocations retrieved successfully")
+}
+
 // CreateAPIKeyCredentialRequest represents the request body for creating an API key credential
 type CreateAPIKeyCredentialRequest struct {
 	APIKey string `json:"api_key" binding:"required"`
@@ -318,10 +433,70 @@ func (h *CredentialHandler) CreateAPIKeyCredential(c *gin.Context) {
 		return
 	}
 
-	credResponse := h.mapCredentialToResponse(apiKeyCred.Credential, nil)
+	credResponse := mapCredentialToResponse(apiKeyCred.Credential, nil)
 	httpapi.Created(c, credResponse, "Credential created successfully")
 }
 
+// CreateAppInstallationCredentialRequest represents the request body for creating an app installation credential
+type CreateAppInstallationCredentialRequest struct {
+	AppID          string `json:"app_id" binding:"required"`
+	InstallationID string `json:"installation_id" binding:"required"`
+	PrivateKey     string `json:"private_key" binding:"required"` // PEM encoded private key of the app
+}
+
+// CreateAppInstallationCredential godoc
+// @Summary Create app installation credential
+// @Description Creates a credential authenticating as an installation of a provider app, such as a GitHub App
+// @Tags credentials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param provider_identifier path string true "Provider Identifier"
+// @Param request body CreateAppInstallationCredentialRequest true "Create app installation credential request"
+// @Success 201 {object} httpapi.Response{data=CredentialResponse} "Success response with created credential data"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /credentials/auth/app-installation/{provider_identifier} [post]
+func (h *CredentialHandler) CreateAppInstallationCredential(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return
+	}
+	user := userI.(*identityDomain.User)
+
+	providerIdentifier := c.Param("provider_identifier")
+	if providerIdentifier == "" {
+		httpapi.BadRequest(c, "Provider identifier is required")
+		return
+	}
+
+	var req CreateAppInstallationCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	installationCred, err := h.credentialService.CreateAppInstallationCredential(
+		ctx,
+		user.ID,
+		providerIdentifier,
+		req.AppID,
+		req.InstallationID,
+		req.PrivateKey,
+	)
+	if err != nil {
+		h.obs.Logger.Error(ctx, "Failed to create app installation credential", zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to create credential")
+		return
+	}
+
+	httpapi.Created(c, mapCredentialToResponse(installationCred.Credential, nil), "Credential created successfully")
+}
+
 type CreateOAuthURLRequest struct {
 	Permissions []string `json:"permissions" binding:"required"`
 	RedirectURL string   `json:"redirect_url" binding:"required"`
@@ -406,8 +581,10 @@ func (h *CredentialHandler) CreateOAuthURL(c *gin.Context) {
 	}
 
 	// Generate OAuth URL
-	oauthURL, err := h.credentialService.GetOAuthURL(
+	oauthURL, err := h.credentialService.GetOAuthURLForOwner(
 		ctx,
+		user.ID,
+		"",
 		providerIdentifier,
 		oAuthStateData.State,
 		oAuthStateData.CodeChallenge,
@@ -431,6 +608,7 @@ type GetOAuthStateDataResponse struct {
 	ID                 string   `json:"id"`
 	Status             string   `json:"status"`
 	UserID             string   `json:"user_id"`
+	OrganizationID     string   `json:"organization_id,omitempty"`
 	ProviderIdentifier string   `json:"provider_identifier"`
 	Permissions        []string `json:"permissions"`
 	CreatedAt          string   `json:"created_at"`
@@ -484,6 +662,7 @@ func (h *CredentialHandler) GetOAuthStateData(c *gin.Context) {
 		ID:                 oauthStateData.ID,
 		Status:             string(oauthStateData.Status),
 		UserID:             oauthStateData.UserID,
+		OrganizationID:     oauthStateData.OrganizationID,
 		ProviderIdentifier: oauthStateData.ProviderIdentifier,
 		Permissions:        oauthStateData.Permissions,
 		CreatedAt:          oauthStateData.CreatedAt.Format(time.RFC3339),
@@ -586,15 +765,27 @@ func (h *CredentialHandler) HandleOAuthCallback(c *gin.Context) {
 		return
 	}
 
-	// Handle OAuth callback
-	_, err = h.credentialService.HandleOAuthCallback(
-		ctx,
-		callbackCode,
-		oauthStateData.ProviderIdentifier,
-		oauthStateData.UserID,
-		oauthStateData.Permissions,
-		oauthStateData.CodeVerifier,
-	)
+	// Handle OAuth callback, storing the credential for the organization the flow was started for
+	if oauthStateData.OrganizationID != "" {
+		_, err = h.credentialService.HandleOrganizationOAuthCallback(
+			ctx,
+			callbackCode,
+			oauthStateData.ProviderIdentifier,
+			oauthStateData.UserID,
+			oauthStateData.OrganizationID,
+			oauthStateData.Permissions,
+			oauthStateData.CodeVerifier,
+		)
+	} else {
+		_, err = h.credentialService.HandleOAuthCallback(
+			ctx,
+			callbackCode,
+			oauthStateData.ProviderIdentifier,
+			oauthStateData.UserID,
+			oauthStateData.Permissions,
+			oauthStateData.CodeVerifier,
+		)
+	}
 	if err != nil {
 		h.obs.Logger.Error(ctx, "Failed to handle OAuth callback", zap.Error(err))
 		// Redirect with error parameter
@@ -0,0 +1,254 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/application"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	identityDomain "github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// KeyRotationHandler handles the admin HTTP requests for vault key rotations
+type KeyRotationHandler struct {
+	keyRotationService *application.KeyRotationService
+	obs                *observability.ObservabilityProvider
+}
+
+// NewKeyRotationHandler creates a new instance of KeyRotationHandler
+func NewKeyRotationHandler(
+	keyRotationService *application.KeyRotationService,
+	observabilityProvider *observability.ObservabilityProvider,
+) *KeyRotationHandler {
+	return &KeyRotationHandler{
+		keyRotationService: keyRotationService,
+		obs:                observabilityProvider,
+	}
+}
+
+// RegisterRoutes registers the key rotation routes with the given router
+func (h *KeyRotationHandler) RegisterRoutes(router *gin.RouterGroup, requireAuth, requireAdmin gin.HandlerFunc) {
+	rotations := router.Group("/admin/key-rotations")
+	rotations.Use(requireAuth, requireAdmin)
+	{
+		rotations.POST("", h.StartKeyRotation)
+		rotations.GET("", h.ListKeyRotations)
+		rotations.GET("/:id", h.GetKeyRotation)
+		rotations.POST("/:id/resume", h.ResumeKeyRotation)
+	}
+}
+
+// StartKeyRotationRequest represents the request body for rotating a vault transit key
+type StartKeyRotationRequest struct {
+	Region         string `json:"region" binding:"required"`
+	CredentialType string `json:"credential_type" binding:"required"`
+}
+
+type KeyRotationResponse struct {
+	ID             string  `json:"id"`
+	Region         string  `json:"region"`
+	CredentialType string  `json:"credential_type"`
+	KeyVersion     int     `json:"key_version"`
+	Status         string  `json:"status"`
+	Source         string  `json:"source"`
+	Total          int     `json:"total"`
+	Rewrapped      int     `json:"rewrapped"`
+	Skipped        int     `json:"skipped"`
+	Failed         int     `json:"failed"`
+	LastError      string  `json:"last_error,omitempty"`
+	TriggeredBy    string  `json:"triggered_by,omitempty"`
+	StartedAt      string  `json:"started_at"`
+	CompletedAt    *string `json:"completed_at,omitempty"`
+	UpdatedAt      string  `json:"updated_at"`
+}
+
+type KeyRotationResponseList struct {
+	Rotations []KeyRotationResponse `json:"rotations"`
+}
+
+func mapKeyRotationToResponse(rotation *domain.KeyRotation) KeyRotationResponse {
+	response := KeyRotationResponse{
+		ID:             rotation.ID,
+		Region:         string(rotation.Region),
+		CredentialType: string(rotation.CredentialType),
+		KeyVersion:     rotation.KeyVersion,
+		Status:         string(rotation.Status),
+		Source:         string(rotation.Source),
+		Total:          rotation.Total,
+		Rewrapped:      rotation.Rewrapped,
+		Skipped:        rotation.Skipped,
+		Failed:         rotation.Failed,
+		LastError:      rotation.LastError,
+		TriggeredBy:    rotation.TriggeredBy,
+		StartedAt:      rotation.StartedAt.Format(time.RFC3339),
+		UpdatedAt:      rotation.UpdatedAt.Format(time.RFC3339),
+	}
+	if rotation.CompletedAt != nil {
+		completedAt := rotation.CompletedAt.Format(time.RFC3339)
+		response.CompletedAt = &completedAt
+	}
+	return response
+}
+
+// StartKeyRotation godoc
+// @Summary Rotate a vault transit key
+// @Description Rotates the transit key of a region and credential type and rewraps the ciphertexts of older key versions in the background
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body StartKeyRotationRequest true "Start key rotation request"
+// @Success 201 {object} httpapi.Response{data=KeyRotationResponse} "Success response with the started rotation"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 409 {object} httpapi.SwaggerErrorResponse "Conflict error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /admin/key-rotations [post]
+func (h *KeyRotationHandler) StartKeyRotation(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return
+	}
+	user := userI.(*identityDomain.User)
+
+	var req StartKeyRotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	rotation, err := h.keyRotationService.StartRotation(ctx, domain.VaultRegion(req.Region), domain.CredentialType(req.CredentialType), user.ID)
+	if err != nil {
+		h.respondWithError(c, err, "Failed to start key rotation")
+		return
+	}
+
+	httpapi.Created(c, mapKeyRotationToResponse(rotation), "Key rotation started successfully")
+}
+
+// ListKeyRotations godoc
+// @Summary List vault key rotations
+// @Description Returns the key rotations and the progress of their rewrap, newest first
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Limit (default: 20)"
+// @Param offset query int false "Offset (default: 0)"
+// @Success 200 {object} httpapi.Response{data=KeyRotationResponseList} "Success response with key rotations"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /admin/key-rotations [get]
+func (h *KeyRotationHandler) ListKeyRotations(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	limit := 20
+	offset := 0
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
+			limit = min(parsedLimit, 100)
+		}
+	}
+	if offsetParam := c.Query("offset"); offsetParam != "" {
+		if parsedOffset, err := strconv.Atoi(offsetParam); err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	rotations, err := h.keyRotationService.ListRotations(ctx, limit, offset)
+	if err != nil {
+		h.obs.Logger.Error(ctx, "Failed to list key rotations", zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to list key rotations")
+		return
+	}
+
+	response := KeyRotationResponseList{
+		Rotations: make([]KeyRotationResponse, len(rotations)),
+	}
+	for i, rotation := range rotations {
+		response.Rotations[i] = mapKeyRotationToResponse(rotation)
+	}
+
+	httpapi.OK(c, response, "Key rotations retrieved successfully")
+}
+
+// GetKeyRotation godoc
+// @Summary Get a vault key rotation
+// @Description Returns a key rotation and the progress of its rewrap
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Key rotation ID"
+// @Success 200 {object} httpapi.Response{data=KeyRotationResponse} "Success response with the key rotation"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /admin/key-rotations/{id} [get]
+func (h *KeyRotationHandler) GetKeyRotation(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	rotation, err := h.keyRotationService.GetRotation(ctx, c.Param("id"))
+	if err != nil {
+		h.respondWithError(c, err, "Failed to get key rotation")
+		return
+	}
+
+	httpapi.OK(c, mapKeyRotationToResponse(rotation), "Key rotation retrieved successfully")
+}
+
+// ResumeKeyRotation godoc
+// @Summary Resume a vault key rotation
+// @Description Restarts the rewrap of a failed key rotation, or resumes a running one that is not being processed
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Key rotation ID"
+// @Success 200 {object} httpapi.Response{data=KeyRotationResponse} "Success response with the resumed rotation"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 409 {object} httpapi.SwaggerErrorResponse "Conflict error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /admin/key-rotations/{id}/resume [post]
+func (h *KeyRotationHandler) ResumeKeyRotation(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	rotation, err := h.keyRotationService.ResumeRotation(ctx, c.Param("id"))
+	if err != nil {
+		h.respondWithError(c, err, "Failed to resume key rotation")
+		return
+	}
+
+	httpapi.OK(c, mapKeyRotationToResponse(rotation), "Key rotation resumed successfully")
+}
+
+// respondWithError maps key rotation errors to HTTP responses
+func (h *KeyRotationHandler) respondWithError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, application.ErrKeyRotationNotFound):
+		httpapi.NotFound(c, "Key rotation not found")
+	case errors.Is(err, application.ErrKeyRotationUnsupportedRegion),
+		errors.Is(err, application.ErrKeyRotationUnsupportedType):
+		httpapi.BadRequest(c, err.Error())
+	case errors.Is(err, application.ErrKeyRotationInProgress),
+		errors.Is(err, application.ErrKeyRotationCompleted):
+		httpapi.RespondWithError(c, http.StatusConflict, err.Error())
+	default:
+		h.obs.Logger.Error(c.Request.Context(), message, zap.Error(err))
+		httpapi.InternalServerError(c, message)
+	}
+}
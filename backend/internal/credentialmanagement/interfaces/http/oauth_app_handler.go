@@ -0,0 +1,369 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/application"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	identityDomain "github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// OAuthAppHandler handles HTTP requests for the OAuth apps users and organizations register with providers
+type OAuthAppHandler struct {
+	oauthAppService    *application.OAuthAppService
+	membershipProvider domain.OrganizationMembershipProvider
+	obs                *observability.ObservabilityProvider
+}
+
+// NewOAuthAppHandler creates a new instance of OAuthAppHandler
+func NewOAuthAppHandler(
+	oauthAppService *application.OAuthAppService,
+	membershipProvider domain.OrganizationMembershipProvider,
+	observabilityProvider *observability.ObservabilityProvider,
+) *OAuthAppHandler {
+	return &OAuthAppHandler{
+		oauthAppService:    oauthAppService,
+		membershipProvider: membershipProvider,
+		obs:                observabilityProvider,
+	}
+}
+
+// RegisterRoutes registers the OAuth app routes with the given router
+func (h *OAuthAppHandler) RegisterRoutes(router *gin.RouterGroup, requireAuth gin.HandlerFunc) {
+	apps := router.Group("/credentials/oauth-apps")
+	apps.Use(requireAuth)
+	{
+		apps.GET("", h.ListOAuthApps)
+		apps.PUT("/:provider_identifier", h.SaveOAuthApp)
+		apps.DELETE("/:provider_identifier", h.DeleteOAuthApp)
+	}
+
+	organizationApps := router.Group("/organizations/:organization_id/credentials/oauth-apps")
+	organizationApps.Use(requireAuth)
+	{
+		organizationApps.GET("", h.ListOrganizationOAuthApps)
+		organizationApps.PUT("/:provider_identifier", h.SaveOrganizationOAuthApp)
+		organizationApps.DELETE("/:provider_identifier", h.DeleteOrganizationOAuthApp)
+	}
+}
+
+// SaveOAuthAppRequest represents the request to register an OAuth app with a provider
+type SaveOAuthAppRequest struct {
+	ClientID string `json:"client_id" binding:"required"`
+	// ClientSecret is required to register an app, it may be omitted to keep the current secret of an app
+	ClientSecret string `json:"client_secret"`
+	// AuthURL and TokenURL replace the OAuth endpoints of the provider, e.g. for a GitHub Enterprise Server instance
+	AuthURL  string `json:"auth_url"`
+	TokenURL string `json:"token_url"`
+	// APIBaseURL replaces the API base URL of the provider, e.g. https://github.example.com/api/v3
+	APIBaseURL string `json:"api_base_url"`
+}
+
+// OAuthAppResponse represents an OAuth app, its client secret is never returned
+type OAuthAppResponse struct {
+	ID                 string `json:"id"`
+	UserID             string `json:"user_id"`
+	OrganizationID     string `json:"organization_id,omitempty"`
+	ProviderIdentifier string `json:"provider_identifier"`
+	ClientID           string `json:"client_id"`
+	AuthURL            string `json:"auth_url,omitempty"`
+	TokenURL           string `json:"token_url,omitempty"`
+	APIBaseURL         string `json:"api_base_url,omitempty"`
+	CreatedAt          string `json:"created_at"`
+	UpdatedAt          string `json:"updated_at"`
+}
+
+// OAuthAppResponseList represents a list of OAuth apps
+type OAuthAppResponseList struct {
+	OAuthApps []OAuthAppResponse `json:"oauth_apps"`
+}
+
+func mapOAuthAppToResponse(app *domain.OAuthApp) OAuthAppResponse {
+	return OAuthAppResponse{
+		ID:                 app.ID,
+		UserID:             app.UserID,
+		OrganizationID:     app.OrganizationID,
+		ProviderIdentifier: app.ProviderIdentifier,
+		ClientID:           app.ClientID,
+		AuthURL:            app.AuthURL,
+		TokenURL:           app.TokenURL,
+		APIBaseURL:         app.APIBaseURL,
+		CreatedAt:          app.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          app.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func mapOAuthAppsToResponse(apps []*domain.OAuthApp) OAuthAppResponseList {
+	response := OAuthAppResponseList{
+		OAuthApps: make([]OAuthAppResponse, len(apps)),
+	}
+	for i, app := range apps {
+		response.OAuthApps[i] = mapOAuthAppToResponse(app)
+	}
+	return response
+}
+
+// ListOAuthApps godoc
+// @Summary List OAuth apps
+// @Description Returns the OAuth apps the current user registered with providers, without their client secrets
+// @Tags credentials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} httpapi.Response{data=OAuthAppResponseList} "Success response with OAuth apps"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /credentials/oauth-apps [get]
+func (h *OAuthAppHandler) ListOAuthApps(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	apps, err := h.oauthAppService.ListByUser(ctx, user.ID)
+	if err != nil {
+		h.obs.Logger.Error(ctx, "Failed to list OAuth apps", zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to list OAuth apps")
+		return
+	}
+
+	httpapi.OK(c, mapOAuthAppsToResponse(apps), "OAuth apps retrieved successfully")
+}
+
+// SaveOAuthApp godoc
+// @Summary Register OAuth app
+// @Description Registers the OAuth app the current user connects a provider with, replacing the previous one.
+// @Description Credentials connected afterwards use its client and endpoints.
+// @Tags credentials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param provider_identifier path string true "Provider Identifier"
+// @Param request body SaveOAuthAppRequest true "Save OAuth app request"
+// @Success 200 {object} httpapi.Response{data=OAuthAppResponse} "Success response with the OAuth app"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /credentials/oauth-apps/{provider_identifier} [put]
+func (h *OAuthAppHandler) SaveOAuthApp(c *gin.Context) {
+	user, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	h.saveOAuthApp(c, user.ID, "")
+}
+
+// DeleteOAuthApp godoc
+// @Summary Delete OAuth app
+// @Description Deletes the OAuth app the current user registered with a provider.
+// @Description Credentials connected through it have to be connected again once their tokens expire.
+// @Tags credentials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param provider_identifier path string true "Provider Identifier"
+// @Success 204 "No content success response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /credentials/oauth-apps/{provider_identifier} [delete]
+func (h *OAuthAppHandler) DeleteOAuthApp(c *gin.Context) {
+	user, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+
+	h.deleteOAuthApp(c, user.ID, "")
+}
+
+// ListOrganizationOAuthApps godoc
+// @Summary List organization OAuth apps
+// @Description Returns the OAuth apps of an organization, without their client secrets, requires the admin or owner role
+// @Tags credentials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {object} httpapi.Response{data=OAuthAppResponseList} "Success response with OAuth apps"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/credentials/oauth-apps [get]
+func (h *OAuthAppHandler) ListOrganizationOAuthApps(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+	organizationID, ok := h.requireCredentialManager(c, user.ID)
+	if !ok {
+		return
+	}
+
+	apps, err := h.oauthAppService.ListByOrganization(ctx, organizationID)
+	if err != nil {
+		h.obs.Logger.Error(ctx, "Failed to list organization OAuth apps", zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to list OAuth apps")
+		return
+	}
+
+	httpapi.OK(c, mapOAuthAppsToResponse(apps), "OAuth apps retrieved successfully")
+}
+
+// SaveOrganizationOAuthApp godoc
+// @Summary Register organization OAuth app
+// @Description Registers the OAuth app an organization connects a provider with, replacing the previous one,
+// @Description requires the admin or owner role. Credentials shared with the organization afterwards use its client and endpoints.
+// @Tags credentials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Param provider_identifier path string true "Provider Identifier"
+// @Param request body SaveOAuthAppRequest true "Save OAuth app request"
+// @Success 200 {object} httpapi.Response{data=OAuthAppResponse} "Success response with the OAuth app"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/credentials/oauth-apps/{provider_identifier} [put]
+func (h *OAuthAppHandler) SaveOrganizationOAuthApp(c *gin.Context) {
+	user, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+	organizationID, ok := h.requireCredentialManager(c, user.ID)
+	if !ok {
+		return
+	}
+
+	h.saveOAuthApp(c, user.ID, organizationID)
+}
+
+// DeleteOrganizationOAuthApp godoc
+// @Summary Delete organization OAuth app
+// @Description Deletes the OAuth app an organization registered with a provider, requires the admin or owner role
+// @Tags credentials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Param provider_identifier path string true "Provider Identifier"
+// @Success 204 "No content success response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/credentials/oauth-apps/{provider_identifier} [delete]
+func (h *OAuthAppHandler) DeleteOrganizationOAuthApp(c *gin.Context) {
+	user, ok := h.requireUser(c)
+	if !ok {
+		return
+	}
+	organizationID, ok := h.requireCredentialManager(c, user.ID)
+	if !ok {
+		return
+	}
+
+	h.deleteOAuthApp(c, user.ID, organizationID)
+}
+
+// saveOAuthApp registers the OAuth app of the owner for the provider of the path
+func (h *OAuthAppHandler) saveOAuthApp(c *gin.Context, userID, organizationID string) {
+	ctx := c.Request.Context()
+
+	providerIdentifier := c.Param("provider_identifier")
+	if providerIdentifier == "" {
+		httpapi.BadRequest(c, "Provider identifier is required")
+		return
+	}
+
+	var req SaveOAuthAppRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	app, err := h.oauthAppService.Save(ctx, userID, organizationID, providerIdentifier, application.SaveOAuthAppInput{
+		ClientID:     req.ClientID,
+		ClientSecret: req.ClientSecret,
+		AuthURL:      req.AuthURL,
+		TokenURL:     req.TokenURL,
+		APIBaseURL:   req.APIBaseURL,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOAuthAppClientIDRequired),
+			errors.Is(err, domain.ErrOAuthAppClientSecretRequired),
+			errors.Is(err, domain.ErrOAuthAppInvalidURL):
+			httpapi.BadRequest(c, err.Error())
+		default:
+			h.obs.Logger.Error(ctx, "Failed to save OAuth app", zap.Error(err))
+			httpapi.InternalServerError(c, "Failed to save OAuth app")
+		}
+		return
+	}
+
+	httpapi.OK(c, mapOAuthAppToResponse(app), "OAuth app saved successfully")
+}
+
+// deleteOAuthApp deletes the OAuth app of the owner for the provider of the path
+func (h *OAuthAppHandler) deleteOAuthApp(c *gin.Context, userID, organizationID string) {
+	ctx := c.Request.Context()
+
+	err := h.oauthAppService.Delete(ctx, userID, organizationID, c.Param("provider_identifier"))
+	if err != nil {
+		if errors.Is(err, application.ErrOAuthAppNotFound) {
+			httpapi.NotFound(c, "OAuth app not found")
+			return
+		}
+		h.obs.Logger.Error(ctx, "Failed to delete OAuth app", zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to delete OAuth app")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// requireUser returns the authenticated user, writing the error response and returning false if there is none
+func (h *OAuthAppHandler) requireUser(c *gin.Context) (*identityDomain.User, bool) {
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return nil, false
+	}
+	return userI.(*identityDomain.User), true
+}
+
+// requireCredentialManager returns the organization of the path, writing the error response and returning false
+// if the user is not a member of it or cannot manage its credentials
+func (h *OAuthAppHandler) requireCredentialManager(c *gin.Context, userID string) (string, bool) {
+	ctx := c.Request.Context()
+
+	membership, err := h.membershipProvider.GetMembership(ctx, c.Param("organization_id"), userID)
+	if err != nil {
+		h.obs.Logger.Error(ctx, "Failed to get organization membership", zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to get organization membership")
+		return "", false
+	}
+	if membership == nil {
+		httpapi.NotFound(c, "Organization not found")
+		return "", false
+	}
+	if !membership.CanManageCredentials {
+		httpapi.Forbidden(c, "Only organization admins can manage OAuth apps")
+		return "", false
+	}
+
+	return membership.OrganizationID, true
+}
@@ -0,0 +1,383 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/application"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	identityDomain "github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	contractIdentity "github.com/context-space/context-space/backend/internal/shared/contract/identityaccess"
+	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+	"github.com/context-space/context-space/backend/internal/shared/security"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// OrganizationCredentialHandler handles HTTP requests for credentials shared with an organization
+type OrganizationCredentialHandler struct {
+	credentialService    *application.CredentialService
+	oauthStateService    domain.OAuthStateService
+	membershipProvider   domain.OrganizationMembershipProvider
+	obs                  *observability.ObservabilityProvider
+	redirectURLValidator *security.RedirectURLValidator
+}
+
+// NewOrganizationCredentialHandler creates a new instance of OrganizationCredentialHandler
+func NewOrganizationCredentialHandler(
+	credentialService *application.CredentialService,
+	oauthStateService domain.OAuthStateService,
+	membershipProvider domain.OrganizationMembershipProvider,
+	observabilityProvider *observability.ObservabilityProvider,
+	redirectURLValidator *security.RedirectURLValidator,
+) *OrganizationCredentialHandler {
+	return &OrganizationCredentialHandler{
+		credentialService:    credentialService,
+		oauthStateService:    oauthStateService,
+		membershipProvider:   membershipProvider,
+		obs:                  observabilityProvider,
+		redirectURLValidator: redirectURLValidator,
+	}
+}
+
+// RegisterRoutes registers the organization credential routes with the given router
+func (h *OrganizationCredentialHandler) RegisterRoutes(router *gin.RouterGroup, requireAuth gin.HandlerFunc) {
+	credentials := router.Group("/organizations/:organization_id/credentials")
+	credentials.Use(requireAuth)
+	{
+		credentials.GET("", h.ListOrganizationCredentials)
+		credentials.DELETE("/:id", h.DeleteOrganizationCredential)
+
+		credentials.POST("/auth/apikey/:provider_identifier", h.CreateOrganizationAPIKeyCredential)
+		credentials.POST("/auth/app-installation/:provider_identifier", h.CreateOrganizationAppInstallationCredential)
+		credentials.POST("/auth/oauth/:provider_identifier/auth-url", h.CreateOrganizationOAuthURL)
+	}
+}
+
+// ListOrganizationCredentials godoc
+// @Summary List organization credentials
+// @Description Returns the credentials shared with an organization, visible to every member
+// @Tags credentials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {object} httpapi.Response{data=CredentialResponseList} "Success response with credentials list"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/credentials [get]
+func (h *OrganizationCredentialHandler) ListOrganizationCredentials(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	membership, ok := h.requireMembership(c, false)
+	if !ok {
+		return
+	}
+
+	creds, err := h.credentialService.GetAllCredentialsByOrganization(ctx, membership.OrganizationID)
+	if err != nil {
+		h.obs.Logger.Error(ctx, "Failed to get credentials by organization", zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to get credentials by organization")
+		return
+	}
+
+	credsResponse := CredentialResponseList{
+		Credentials: make([]CredentialResponse, len(creds)),
+	}
+	for i, cred := range creds {
+		credsResponse.Credentials[i] = mapCredentialToResponse(cred, nil)
+	}
+
+	httpapi.OK(c, credsResponse, "Credentials retrieved successfully")
+}
+
+// DeleteOrganizationCredential godoc
+// @Summary Delete organization credential
+// @Description Deletes a credential shared with an organization, requires the admin or owner role
+// @Tags credentials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Param id path string true "Credential ID"
+// @Success 204 "No content success response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/credentials/{id} [delete]
+func (h *OrganizationCredentialHandler) DeleteOrganizationCredential(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	membership, ok := h.requireMembership(c, true)
+	if !ok {
+		return
+	}
+
+	credI, err := h.credentialService.GetCredential(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, application.ErrCredentialNotFound) {
+			httpapi.NotFound(c, "Credential not found")
+			return
+		}
+		httpapi.InternalServerError(c, "Failed to get credential")
+		return
+	}
+
+	var cred *domain.Credential
+	switch credI := credI.(type) {
+	case *domain.OAuthCredential:
+		cred = credI.Credential
+	case *domain.APIKeyCredential:
+		cred = credI.Credential
+	case *domain.AppInstallationCredential:
+		cred = credI.Credential
+	default:
+		httpapi.InternalServerError(c, "Invalid credential type")
+		return
+	}
+
+	if cred.OrganizationID != membership.OrganizationID {
+		httpapi.NotFound(c, "Credential not found")
+		return
+	}
+
+	if err := h.credentialService.DeleteCredential(ctx, cred.ID); err != nil {
+		if errors.Is(err, application.ErrCredentialNotFound) {
+			httpapi.NotFound(c, "Credential not found")
+			return
+		}
+		httpapi.InternalServerError(c, "Failed to delete credential")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateOrganizationAPIKeyCredential godoc
+// @Summary Create organization API key credential
+// @Description Creates an API key credential shared with an organization, requires the admin or owner role
+// @Tags credentials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Param provider_identifier path string true "Provider Identifier"
+// @Param request body CreateAPIKeyCredentialRequest true "Create API key credential request"
+// @Success 201 {object} httpapi.Response{data=CredentialResponse} "Success response with created credential data"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/credentials/auth/apikey/{provider_identifier} [post]
+func (h *OrganizationCredentialHandler) CreateOrganizationAPIKeyCredential(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	membership, ok := h.requireMembership(c, true)
+	if !ok {
+		return
+	}
+
+	providerIdentifier := c.Param("provider_identifier")
+	if providerIdentifier == "" {
+		httpapi.BadRequest(c, "Provider identifier is required")
+		return
+	}
+
+	var req CreateAPIKeyCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	apiKeyCred, err := h.credentialService.CreateOrganizationAPIKeyCredential(
+		ctx,
+		membership.OrganizationID,
+		membership.UserID,
+		providerIdentifier,
+		req.APIKey,
+	)
+	if err != nil {
+		h.obs.Logger.Error(ctx, "Failed to create organization credential", zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to create credential")
+		return
+	}
+
+	httpapi.Created(c, mapCredentialToResponse(apiKeyCred.Credential, nil), "Credential created successfully")
+}
+
+// CreateOrganizationAppInstallationCredential godoc
+// @Summary Create organization app installation credential
+// @Description Creates an app installation credential shared with an organization, requires the admin or owner role
+// @Tags credentials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Param provider_identifier path string true "Provider Identifier"
+// @Param request body CreateAppInstallationCredentialRequest true "Create app installation credential request"
+// @Success 201 {object} httpapi.Response{data=CredentialResponse} "Success response with created credential data"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/credentials/auth/app-installation/{provider_identifier} [post]
+func (h *OrganizationCredentialHandler) CreateOrganizationAppInstallationCredential(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	membership, ok := h.requireMembership(c, true)
+	if !ok {
+		return
+	}
+
+	providerIdentifier := c.Param("provider_identifier")
+	if providerIdentifier == "" {
+		httpapi.BadRequest(c, "Provider identifier is required")
+		return
+	}
+
+	var req CreateAppInstallationCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	installationCred, err := h.credentialService.CreateOrganizationAppInstallationCredential(
+		ctx,
+		membership.OrganizationID,
+		membership.UserID,
+		providerIdentifier,
+		req.AppID,
+		req.InstallationID,
+		req.PrivateKey,
+	)
+	if err != nil {
+		h.obs.Logger.Error(ctx, "Failed to create organization credential", zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to create credential")
+		return
+	}
+
+	httpapi.Created(c, mapCredentialToResponse(installationCred.Credential, nil), "Credential created successfully")
+}
+
+// CreateOrganizationOAuthURL godoc
+// @Summary Create organization OAuth URL
+// @Description Generates an OAuth authorization URL whose credential is shared with an organization, requires the admin or owner role
+// @Tags credentials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Param provider_identifier path string true "Provider Identifier"
+// @Param request body CreateOAuthURLRequest true "Create OAuth URL request"
+// @Success 200 {object} httpapi.Response{data=CreateOAuthURLResponse} "Success response with OAuth URL data"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/credentials/auth/oauth/{provider_identifier}/auth-url [post]
+func (h *OrganizationCredentialHandler) CreateOrganizationOAuthURL(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	membership, ok := h.requireMembership(c, true)
+	if !ok {
+		return
+	}
+
+	providerIdentifier := c.Param("provider_identifier")
+	if providerIdentifier == "" {
+		httpapi.BadRequest(c, "Provider identifier is required")
+		return
+	}
+
+	var req CreateOAuthURLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, "Invalid request format")
+		return
+	}
+
+	// SECURITY: Validate redirect URL to prevent open redirect attacks
+	if err := h.redirectURLValidator.ValidateRedirectURL(req.RedirectURL); err != nil {
+		h.obs.Logger.Error(ctx, "Invalid redirect URL provided in OAuth URL request",
+			zap.String("redirect_url", req.RedirectURL),
+			zap.Error(err))
+		httpapi.BadRequest(c, fmt.Sprintf("Invalid redirect URL: %s", err.Error()))
+		return
+	}
+
+	oAuthStateData, err := domain.NewOAuthStateData(
+		membership.UserID,
+		providerIdentifier,
+		req.RedirectURL,
+		req.Permissions,
+		map[string]interface{}{
+			"ip_address": c.ClientIP(),
+			"user_agent": c.Request.UserAgent(),
+		},
+	)
+	if err != nil {
+		h.obs.Logger.Error(ctx, fmt.Sprintf("Failed to create OAuth state data for provider %s", providerIdentifier), zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to create OAuth state data")
+		return
+	}
+	oAuthStateData.OrganizationID = membership.OrganizationID
+
+	if err := h.oauthStateService.StoreStateData(ctx, oAuthStateData); err != nil {
+		h.obs.Logger.Error(ctx, fmt.Sprintf("Failed to store OAuth state data for provider %s", providerIdentifier), zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to store OAuth state data")
+		return
+	}
+
+	oauthURL, err := h.credentialService.GetOAuthURLForOwner(
+		ctx,
+		membership.UserID,
+		membership.OrganizationID,
+		providerIdentifier,
+		oAuthStateData.State,
+		oAuthStateData.CodeChallenge,
+		req.Permissions,
+	)
+	if err != nil {
+		h.obs.Logger.Error(ctx, fmt.Sprintf("Failed to generate OAuth URL for provider %s", providerIdentifier), zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to generate OAuth URL")
+		return
+	}
+
+	httpapi.OK(c, CreateOAuthURLResponse{
+		AuthURL:      oauthURL,
+		OAuthStateID: oAuthStateData.ID,
+	}, "OAuth URL created successfully")
+}
+
+// requireMembership resolves the current user's membership in the organization of the path,
+// writing the error response and returning false if the user is not a member or cannot manage its credentials
+func (h *OrganizationCredentialHandler) requireMembership(c *gin.Context, manage bool) (*contractIdentity.MembershipDTO, bool) {
+	ctx := c.Request.Context()
+
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return nil, false
+	}
+	user := userI.(*identityDomain.User)
+
+	membership, err := h.membershipProvider.GetMembership(ctx, c.Param("organization_id"), user.ID)
+	if err != nil {
+		h.obs.Logger.Error(ctx, "Failed to get organization membership", zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to get organization membership")
+		return nil, false
+	}
+	if membership == nil {
+		httpapi.NotFound(c, "Organization not found")
+		return nil, false
+	}
+	if manage && !membership.CanManageCredentials {
+		httpapi.Forbidden(c, "Only organization admins can manage credentials")
+		return nil, false
+	}
+
+	return membership, true
+}
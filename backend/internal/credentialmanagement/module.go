@@ -2,34 +2,61 @@ package credentialmanagement
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	observability "github.com/context-space/cloud-observability"
 	"github.com/context-space/context-space/backend/internal/credentialmanagement/application"
 	"github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
 	"github.com/context-space/context-space/backend/internal/credentialmanagement/infrastructure/acl"
+	"github.com/context-space/context-space/backend/internal/credentialmanagement/infrastructure/envelope"
 	"github.com/context-space/context-space/backend/internal/credentialmanagement/infrastructure/persistence"
 	"github.com/context-space/context-space/backend/internal/credentialmanagement/infrastructure/vault"
 	"github.com/context-space/context-space/backend/internal/credentialmanagement/interfaces/contract"
 	"github.com/context-space/context-space/backend/internal/credentialmanagement/interfaces/http"
 	"github.com/context-space/context-space/backend/internal/shared/config"
 	contractCredential "github.com/context-space/context-space/backend/internal/shared/contract/credentialmanagement"
+	contractIdentity "github.com/context-space/context-space/backend/internal/shared/contract/identityaccess"
 	contractAdapter "github.com/context-space/context-space/backend/internal/shared/contract/provideradapter"
+	"github.com/context-space/context-space/backend/internal/shared/cron"
 	"github.com/context-space/context-space/backend/internal/shared/events"
+	"github.com/context-space/context-space/backend/internal/shared/health"
 	"github.com/context-space/context-space/backend/internal/shared/infrastructure/cache"
 	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"github.com/context-space/context-space/backend/internal/shared/interfaces/http/middleware"
 	"github.com/context-space/context-space/backend/internal/shared/security"
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// userDeletedEventType is published by the identity access module when an account is deleted
+	userDeletedEventType = "user.deleted"
+	// organizationDeletedEventType is published by the identity access module when an organization is deleted
+	organizationDeletedEventType = "organization.deleted"
+	// serviceAccountDeletedEventType is published by the identity access module when a service account is deleted
+	serviceAccountDeletedEventType = "service_account.deleted"
+)
+
 // Module holds all components for the credential management bounded context
 type Module struct {
 	CredentialService        *application.CredentialService
+	TokenRevocationService   *application.TokenRevocationService
 	CredentialHandler        *http.CredentialHandler
+	OrganizationHandler      *http.OrganizationCredentialHandler
+	OAuthAppHandler          *http.OAuthAppHandler
+	KeyRotationService       *application.KeyRotationService
+	KeyRotationHandler       *http.KeyRotationHandler
+	VerificationService      *application.CredentialVerificationService
 	OAuthStateService        domain.OAuthStateService
+	keyRotationConfig        config.KeyRotationConfig
+	verificationConfig       config.CredentialVerificationConfig
+	adminUserIDs             []string
 	tokenRefreshService      domain.TokenRefresh
 	credentialRepository     domain.CredentialRepository
 	credentialFactory        domain.CredentialFactory
 	redisClient              cache.Cache
+	vaultService             domain.VaultService
 	credentialContractFacade contractCredential.CredentialManagementContract
 }
 
@@ -41,6 +68,7 @@ func NewModule(
 	eventBus events.EventBus,
 	observabilityProvider *observability.ObservabilityProvider,
 	providerAdapterContract contractAdapter.ProviderAdapterContract,
+	organizationReader contractIdentity.OrganizationReader,
 	redisClient cache.Cache,
 ) (*Module, error) {
 	unitOfWorkFactory := database.NewDefaultUnitOfWorkFactory(db, observabilityProvider)
@@ -49,31 +77,41 @@ func NewModule(
 	credentialRepo := persistence.NewCredentialRepository(db, observabilityProvider)
 	oauthRepo := persistence.NewOAuthCredentialRepository(db, observabilityProvider)
 	apiKeyRepo := persistence.NewAPIKeyCredentialRepository(db, observabilityProvider)
+	installationRepo := persistence.NewAppInstallationCredentialRepository(db, observabilityProvider)
+	revocationRepo := persistence.NewTokenRevocationRepository(db, observabilityProvider)
+	oauthAppRepo := persistence.NewOAuthAppRepository(db, observabilityProvider)
 
 	// Initialize OAuth state repository
 	oauthStateRepo := persistence.NewRedisOAuthStateRepository(db, redisClient, observabilityProvider, application.DefaultStateExpiration)
 
 	// Initialize vault service
-	vaultService, err := vault.NewVaultService(ctx, &vault.VaultConfig{
-		Regions: convertVaultRegionsMap(config.Vault.Regions),
-	}, domain.VaultRegion(config.Vault.DefaultRegion))
+	vaultService, err := newVaultService(ctx, config, persistence.NewMinDecryptionVersionRepository(db, observabilityProvider))
 	if err != nil {
 		return nil, err
 	}
 
+	// Initialize OAuth app service
+	oauthAppService := application.NewOAuthAppService(oauthAppRepo, vaultService, observabilityProvider)
+
 	// Initialize credential factory
 	credentialFactory := persistence.NewCredentialFactory(
 		credentialRepo,
 		oauthRepo,
 		apiKeyRepo,
+		installationRepo,
 		vaultService,
+		oauthAppService,
 	)
 
 	providerAdapterACL := acl.NewProviderAdapterACL(providerAdapterContract, observabilityProvider)
+	organizationACL := acl.NewOrganizationACL(organizationReader, observabilityProvider)
 
 	// Initialize OAuth state service
 	oauthStateService := application.NewOAuthStateService(oauthStateRepo, observabilityProvider)
 
+	// Initialize credential invalidation service
+	invalidationService := application.NewCredentialInvalidationService(credentialRepo, eventBus, observabilityProvider)
+
 	// Initialize token refresh service
 	tokenRefreshService := persistence.NewTokenRefreshService(
 		redisClient,
@@ -81,6 +119,31 @@ func NewModule(
 		credentialRepo,
 		oauthRepo,
 		vaultService,
+		invalidationService,
+		oauthAppService,
+		observabilityProvider,
+	)
+
+	// Initialize credential verification service
+	verificationService := application.NewCredentialVerificationService(
+		credentialRepo,
+		credentialFactory,
+		providerAdapterACL,
+		tokenRefreshService,
+		invalidationService,
+		redisClient,
+		time.Duration(config.CredentialVerification.IntervalHours)*time.Hour,
+		config.CredentialVerification.BatchSize,
+		observabilityProvider,
+	)
+
+	// Initialize token revocation service
+	revocationService := application.NewTokenRevocationService(
+		revocationRepo,
+		oauthRepo,
+		providerAdapterACL,
+		vaultService,
+		oauthAppService,
 		observabilityProvider,
 	)
 
@@ -95,8 +158,15 @@ func NewModule(
 		config.Provider.OAuthRedirectURL,
 		redisClient,
 		tokenRefreshService,
+		revocationService,
+		oauthAppService,
 	)
 
+	// Revoke the tokens of deleted accounts
+	eventBus.Subscribe(userDeletedEventType, credentialService.HandleUserDeleted)
+	eventBus.Subscribe(organizationDeletedEventType, credentialService.HandleOrganizationDeleted)
+	eventBus.Subscribe(serviceAccountDeletedEventType, credentialService.HandleServiceAccountDeleted)
+
 	// Initialize OAuth redirect URL validator
 	redirectURLValidator := security.NewRedirectURLValidator(
 		config.Security.RedirectURLValidator.AllowedDomains,
@@ -106,27 +176,72 @@ func NewModule(
 	// Initialize credential handler with OAuth state service
 	credentialHandler := http.NewCredentialHandler(
 		credentialService,
+		revocationService,
 		oauthStateService,
 		observabilityProvider,
 		redirectURLValidator,
 	)
 
+	// Initialize organization credential handler
+	organizationHandler := http.NewOrganizationCredentialHandler(
+		credentialService,
+		oauthStateService,
+		organizationACL,
+		observabilityProvider,
+		redirectURLValidator,
+	)
+
+	// Initialize OAuth app handler
+	oauthAppHandler := http.NewOAuthAppHandler(oauthAppService, organizationACL, observabilityProvider)
+
+	// Secrets other modules encrypt through the contract are rewrapped from the secret stores they register
+	ciphertextRepo := persistence.NewCiphertextRepository(db, observabilityProvider)
+
+	// Initialize key rotation service when the vault keys can be rotated
+	var keyRotationService *application.KeyRotationService
+	var keyRotationHandler *http.KeyRotationHandler
+	if keyManager, ok := vaultService.(domain.VaultKeyManager); ok {
+		keyRotationService = application.NewKeyRotationService(
+			persistence.NewKeyRotationRepository(db, observabilityProvider),
+			ciphertextRepo,
+			keyManager,
+			redisClient,
+			config.KeyRotation.BatchSize,
+			observabilityProvider,
+		)
+		keyRotationHandler = http.NewKeyRotationHandler(keyRotationService, observabilityProvider)
+	}
+
 	// Initialize credential contract facade
 	credentialContractFacade := contract.NewCredentialContractFacade(
 		credentialService,
 		credentialFactory,
 		tokenRefreshService,
+		oauthStateService,
+		vaultService,
+		ciphertextRepo,
+		config.Provider.ConsentRedirectURL,
 		observabilityProvider,
 	)
 
 	return &Module{
 		CredentialService:        credentialService,
+		TokenRevocationService:   revocationService,
 		CredentialHandler:        credentialHandler,
+		OrganizationHandler:      organizationHandler,
+		OAuthAppHandler:          oauthAppHandler,
+		KeyRotationService:       keyRotationService,
+		KeyRotationHandler:       keyRotationHandler,
+		VerificationService:      verificationService,
 		OAuthStateService:        oauthStateService,
+		keyRotationConfig:        config.KeyRotation,
+		verificationConfig:       config.CredentialVerification,
+		adminUserIDs:             config.Security.AdminUserIDs,
 		tokenRefreshService:      tokenRefreshService,
 		credentialRepository:     credentialRepo,
 		credentialFactory:        credentialFactory,
 		redisClient:              redisClient,
+		vaultService:             vaultService,
 		credentialContractFacade: credentialContractFacade,
 	}, nil
 }
@@ -134,6 +249,11 @@ func NewModule(
 // RegisterRoutes registers the credential management routes
 func (m *Module) RegisterRoutes(router *gin.RouterGroup, requireAuth gin.HandlerFunc) {
 	m.CredentialHandler.RegisterRoutes(router, requireAuth)
+	m.OrganizationHandler.RegisterRoutes(router, requireAuth)
+	m.OAuthAppHandler.RegisterRoutes(router, requireAuth)
+	if m.KeyRotationHandler != nil {
+		m.KeyRotationHandler.RegisterRoutes(router, requireAuth, middleware.RequireAdmin(m.adminUserIDs))
+	}
 }
 
 func (m *Module) GetCredentialContractFacade() contractCredential.CredentialManagementContract {
@@ -145,6 +265,96 @@ func (m *Module) TokenRefreshService() domain.TokenRefresh {
 	return m.tokenRefreshService
 }
 
+// CronTaskGroups returns the scheduled task groups of the credential management module
+func (m *Module) CronTaskGroups() []*cron.TaskGroup {
+	groups := []*cron.TaskGroup{
+		{
+			Name:     "revoke_tokens",
+			Schedule: "0 */5 * * * *", // Execute every 5 minutes (6-field cron expression)
+			Tasks: []cron.CronTask{
+				{
+					Name:    "revoke_due_tokens",
+					Handler: m.TokenRevocationService.RevokeDue,
+				},
+			},
+		},
+	}
+
+	if m.verificationConfig.Enabled {
+		groups = append(groups, &cron.TaskGroup{
+			Name:     "verify_credentials",
+			Schedule: m.verificationConfig.Schedule,
+			Tasks: []cron.CronTask{
+				{
+					Name:    "verify_due_credentials",
+					Handler: m.VerificationService.VerifyDue,
+				},
+			},
+		})
+	}
+
+	if m.KeyRotationService == nil {
+		return groups
+	}
+
+	groups = append(groups, &cron.TaskGroup{
+		Name:     "resume_key_rotations",
+		Schedule: "0 */5 * * * *", // Execute every 5 minutes (6-field cron expression)
+		Tasks: []cron.CronTask{
+			{
+				Name:    "resume_active_key_rotations",
+				Handler: m.KeyRotationService.ResumeActive,
+			},
+		},
+	})
+	if m.keyRotationConfig.Enabled {
+		groups = append(groups, &cron.TaskGroup{
+			Name:     "rotate_keys",
+			Schedule: m.keyRotationConfig.Schedule,
+			Tasks: []cron.CronTask{
+				{
+					Name:    "rotate_all_keys",
+					Handler: m.KeyRotationService.RotateAll,
+				},
+			},
+		})
+	}
+	return groups
+}
+
+// ReadinessChecks returns a health and a transit key check for every configured vault region
+func (m *Module) ReadinessChecks() []health.Check {
+	checker, ok := m.vaultService.(domain.VaultHealthChecker)
+	if !ok {
+		return nil
+	}
+
+	var checks []health.Check
+	for _, region := range checker.Regions() {
+		region := region
+		checks = append(checks,
+			health.Check{
+				Name: fmt.Sprintf("vault_%s", region),
+				Run: func(ctx context.Context) error {
+					return checker.CheckHealth(ctx, region)
+				},
+			},
+			health.Check{
+				Name: fmt.Sprintf("vault_%s_transit_keys", region),
+				Run: func(ctx context.Context) error {
+					// Locked-down policies grant encrypt and decrypt only, the keys then still work
+					err := checker.CheckTransitKeys(ctx, region)
+					if errors.Is(err, domain.ErrVaultPermissionDenied) {
+						return health.Warn(err)
+					}
+					return err
+				},
+			},
+		)
+	}
+	return checks
+}
+
 // Initialize initializes the credential management module
 func (m *Module) Initialize(ctx context.Context) error {
 	return nil
@@ -158,6 +368,33 @@ func (m *Module) Close() error {
 	return nil
 }
 
+// newVaultService creates the secret backend selected by the configuration
+// minVersionRepo persists the min decryption versions of the envelope backend, Vault keeps its own
+func newVaultService(ctx context.Context, cfg *config.Config, minVersionRepo domain.MinDecryptionVersionRepository) (domain.VaultService, error) {
+	defaultRegion := domain.VaultRegion(cfg.Vault.DefaultRegion)
+
+	switch cfg.Vault.Backend {
+	case "", "vault":
+		return vault.NewVaultService(ctx, &vault.VaultConfig{
+			Regions: convertVaultRegionsMap(cfg.Vault.Regions),
+		}, defaultRegion)
+	case "envelope":
+		loader := envelope.EnvKeyRingLoader(cfg.Vault.Envelope.KeyRingEnvPrefix)
+		if cfg.Vault.Envelope.KeyRingFile != "" {
+			loader = envelope.FileKeyRingLoader(cfg.Vault.Envelope.KeyRingFile)
+		}
+
+		algorithms := make(map[domain.VaultRegion]domain.VaultAlgorithm)
+		for region, algorithm := range cfg.Vault.Envelope.Algorithms {
+			algorithms[domain.VaultRegion(region)] = domain.VaultAlgorithm(algorithm)
+		}
+
+		return envelope.NewEnvelopeService(loader, minVersionRepo, algorithms, defaultRegion)
+	default:
+		return nil, fmt.Errorf("unsupported vault backend: %s", cfg.Vault.Backend)
+	}
+}
+
 // Helper function to convert config.VaultRegionalConfig to vault.VaultRegionalConfig
 func convertVaultRegionsMap(regions map[string]*config.VaultRegionalConfig) map[domain.VaultRegion]vault.VaultRegionalConfig {
 	result := make(map[domain.VaultRegion]vault.VaultRegionalConfig)
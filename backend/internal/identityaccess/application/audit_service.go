@@ -0,0 +1,352 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/shared/apierrors"
+	"github.com/context-space/context-space/backend/internal/shared/audit"
+	"github.com/context-space/context-space/backend/internal/shared/events"
+	"go.uber.org/zap"
+)
+
+const (
+	// auditChainBatchSize is the number of entries read at once when exporting or verifying a chain
+	auditChainBatchSize = 500
+
+	auditResourceCredential     = "credential"
+	auditResourceAPIKey         = "api_key"
+	auditResourceServiceAccount = "service_account"
+	auditResourceUser           = "user"
+	auditResourceProvider       = "provider"
+
+	auditAuthTypeAPIKey = "api_key"
+
+	// apiKeyUsedAuditInterval is how often the use of an API key is recorded at most, the uses in between are
+	// counted in the next entry
+	apiKeyUsedAuditInterval = time.Hour
+)
+
+// AuditChainVerification is the result of verifying the audit chain of a user
+type AuditChainVerification struct {
+	Valid          bool
+	EntriesChecked int64
+	// FirstInvalidSequence is the sequence of the first entry that was altered or does not link to its predecessor
+	FirstInvalidSequence int64
+}
+
+// AuditService records security-relevant actions in the append-only audit log
+type AuditService struct {
+	auditRepo domain.AuditRepository
+	obs       *observability.ObservabilityProvider
+
+	apiKeyUsesMu sync.Mutex
+	apiKeyUses   map[string]*apiKeyUsage
+}
+
+// apiKeyUsage tracks the uses of an API key since its last apikey.used entry
+type apiKeyUsage struct {
+	recordedAt time.Time
+	unrecorded int
+}
+
+// NewAuditService creates a new AuditService
+func NewAuditService(
+	auditRepo domain.AuditRepository,
+	observabilityProvider *observability.ObservabilityProvider,
+) *AuditService {
+	return &AuditService{
+		auditRepo:  auditRepo,
+		obs:        observabilityProvider,
+		apiKeyUses: make(map[string]*apiKeyUsage),
+	}
+}
+
+// RegisterEventHandlers subscribes the audit log to the events of security-relevant actions
+func (s *AuditService) RegisterEventHandlers(eventBus *events.Bus) {
+	eventBus.Subscribe("credential.created", s.handleCredentialEvent(domain.AuditActionCredentialCreated, domain.AuditOutcomeSuccess))
+	eventBus.Subscribe("credential.deleted", s.handleCredentialEvent(domain.AuditActionCredentialDeleted, domain.AuditOutcomeSuccess))
+	eventBus.Subscribe("credential.invalidated", s.handleCredentialEvent(domain.AuditActionCredentialInvalidated, domain.AuditOutcomeSuccess))
+	eventBus.Subscribe("credential.oauth_consent_completed", s.handleCredentialEvent(domain.AuditActionOAuthConsent, domain.AuditOutcomeSuccess))
+	eventBus.Subscribe("credential.oauth_consent_failed", s.handleCredentialEvent(domain.AuditActionOAuthConsent, domain.AuditOutcomeFailure))
+
+	eventBus.Subscribe(string(APIKeyCreatedEvent), s.handleAPIKeyEvent(domain.AuditActionAPIKeyCreated))
+	eventBus.Subscribe(string(APIKeyDeletedEvent), s.handleAPIKeyEvent(domain.AuditActionAPIKeyDeleted))
+	eventBus.Subscribe(string(APIKeyUsedEvent), s.handleAPIKeyUsed)
+	eventBus.Subscribe(string(OrganizationAPIKeyCreatedEvent), s.handleAPIKeyEvent(domain.AuditActionAPIKeyCreated))
+	eventBus.Subscribe(string(OrganizationAPIKeyDeletedEvent), s.handleAPIKeyEvent(domain.AuditActionAPIKeyDeleted))
+
+	eventBus.Subscribe(string(ServiceAccountCreatedEvent), s.handleServiceAccountEvent(domain.AuditActionServiceAccountCreated))
+	eventBus.Subscribe(string(ServiceAccountDeletedEvent), s.handleServiceAccountEvent(domain.AuditActionServiceAccountDeleted))
+
+	eventBus.Subscribe(string(UserDeletedEvent), s.handleUserDeleted)
+
+	eventBus.Subscribe("provider.created", s.handleProviderEvent(domain.AuditActionProviderCreated))
+	eventBus.Subscribe("provider.updated", s.handleProviderEvent(domain.AuditActionProviderUpdated))
+	eventBus.Subscribe("provider.deleted", s.handleProviderEvent(domain.AuditActionProviderDeleted))
+}
+
+// Record appends an entry to the audit log, attributing it to the request stored in ctx
+// Entries of authenticated requests are recorded in the log of the actor, others in the log of the entry's user
+func (s *AuditService) Record(ctx context.Context, entry *domain.AuditEntry) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "AuditService.Record")
+	defer span.End()
+
+	info := audit.RequestInfoFromContext(ctx)
+	if info.ActorID != "" {
+		entry.UserID = info.ActorID
+		entry.ActorID = info.ActorID
+		entry.AuthType = info.AuthType
+		if info.APIKeyID != "" {
+			entry.Metadata["api_key_id"] = info.APIKeyID
+		}
+	}
+	if entry.ActorID == "" {
+		entry.ActorID = entry.UserID
+	}
+	entry.IPAddress = info.IPAddress
+	entry.UserAgent = info.UserAgent
+
+	if err := s.auditRepo.Append(ctx, entry); err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// ListEntries retrieves the audit entries of a user matching the filter, newest first, and the total number of matches
+func (s *AuditService) ListEntries(ctx context.Context, userID string, filter domain.AuditFilter, offset, limit int) ([]*domain.AuditEntry, int64, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "AuditService.ListEntries")
+	defer span.End()
+
+	entries, total, err := s.auditRepo.List(ctx, userID, filter, offset, limit)
+	if err != nil {
+		return nil, 0, apierrors.NewInternalError("", err)
+	}
+
+	return entries, total, nil
+}
+
+// ExportEntries passes the audit entries of a user matching the filter to write in chain order
+func (s *AuditService) ExportEntries(ctx context.Context, userID string, filter domain.AuditFilter, write func(entry *domain.AuditEntry) error) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "AuditService.ExportEntries")
+	defer span.End()
+
+	return s.walkChain(ctx, userID, func(entry *domain.AuditEntry) (bool, error) {
+		if !filter.Matches(entry) {
+			return true, nil
+		}
+		return true, write(entry)
+	})
+}
+
+// VerifyChain recomputes the hash chain of a user to detect altered, removed or reordered entries
+func (s *AuditService) VerifyChain(ctx context.Context, userID string) (*AuditChainVerification, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "AuditService.VerifyChain")
+	defer span.End()
+
+	verification := &AuditChainVerification{Valid: true}
+
+	var previous *domain.AuditEntry
+	err := s.walkChain(ctx, userID, func(entry *domain.AuditEntry) (bool, error) {
+		verification.EntriesChecked++
+		if !entry.Verify(previous) {
+			verification.Valid = false
+			verification.FirstInvalidSequence = entry.Sequence
+			return false, nil
+		}
+		previous = entry
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !verification.Valid {
+		s.obs.Logger.Warn(ctx, "Audit chain verification failed",
+			zap.String("user_id", userID),
+			zap.Int64("sequence", verification.FirstInvalidSequence))
+	}
+
+	return verification, nil
+}
+
+// walkChain passes the entries of a user in chain order to visit until it returns false
+func (s *AuditService) walkChain(ctx context.Context, userID string, visit func(entry *domain.AuditEntry) (bool, error)) error {
+	afterSequence := int64(0)
+	for {
+		entries, err := s.auditRepo.ListChain(ctx, userID, afterSequence, auditChainBatchSize)
+		if err != nil {
+			return apierrors.NewInternalError("", err)
+		}
+
+		for _, entry := range entries {
+			next, err := visit(entry)
+			if err != nil || !next {
+				return err
+			}
+		}
+
+		if len(entries) < auditChainBatchSize {
+			return nil
+		}
+		afterSequence = entries[len(entries)-1].Sequence
+	}
+}
+
+// handleCredentialEvent returns a handler recording credential events
+func (s *AuditService) handleCredentialEvent(action domain.AuditAction, outcome domain.AuditOutcome) events.EventHandler {
+	return func(ctx context.Context, event events.Event) error {
+		payload, _ := event.Payload.(events.Payload)
+
+		entry := domain.NewAuditEntry(event.Metadata.UserID, action, auditResourceCredential, payloadString(payload, "credential_id"), outcome)
+		entry.Metadata["provider_identifier"] = event.Metadata.ProviderIdentifier
+		if credentialType := payloadString(payload, "type"); credentialType != "" {
+			entry.Metadata["type"] = credentialType
+		}
+		if organizationID := payloadString(payload, "organization_id"); organizationID != "" {
+			entry.Metadata["organization_id"] = organizationID
+		}
+		if reason := payloadString(payload, "reason"); reason != "" {
+			entry.Metadata["reason"] = reason
+		}
+
+		s.record(ctx, event, entry)
+		return nil
+	}
+}
+
+// handleAPIKeyEvent returns a handler recording personal and organization API key events
+func (s *AuditService) handleAPIKeyEvent(action domain.AuditAction) events.EventHandler {
+	return func(ctx context.Context, event events.Event) error {
+		s.record(ctx, event, apiKeyEntry(event, action))
+		return nil
+	}
+}
+
+// apiKeyEntry builds the audit entry of an API key event
+func apiKeyEntry(event events.Event, action domain.AuditAction) *domain.AuditEntry {
+	apiKeyID := event.Metadata.Properties["api_key_id"]
+
+	entry := domain.NewAuditEntry(event.Metadata.UserID, action, auditResourceAPIKey, apiKeyID, domain.AuditOutcomeSuccess)
+	if organizationID := event.Metadata.Properties["organization_id"]; organizationID != "" {
+		entry.Metadata["organization_id"] = organizationID
+	}
+	serviceAccountID := event.Metadata.Properties["service_account_id"]
+	if serviceAccountID != "" {
+		entry.Metadata["service_account_id"] = serviceAccountID
+	}
+	if action == domain.AuditActionAPIKeyUsed {
+		// The request is not attributed to its actor until the API key is validated
+		entry.ActorID = event.Metadata.UserID
+		if serviceAccountID != "" {
+			entry.ActorID = serviceAccountID
+		}
+		entry.AuthType = auditAuthTypeAPIKey
+	}
+	return entry
+}
+
+// handleAPIKeyUsed records the use of an API key at most once per apiKeyUsedAuditInterval, off the request path.
+// API keys are validated on every request, appending to the chain each time would serialize the requests of a user
+// on its lock.
+func (s *AuditService) handleAPIKeyUsed(ctx context.Context, event events.Event) error {
+	apiKeyID := event.Metadata.Properties["api_key_id"]
+	unrecorded, ok := s.takeAPIKeyUse(apiKeyID, time.Now())
+	if !ok {
+		return nil
+	}
+
+	entry := apiKeyEntry(event, domain.AuditActionAPIKeyUsed)
+	if unrecorded > 0 {
+		entry.Metadata["unrecorded_uses"] = strconv.Itoa(unrecorded)
+	}
+	go s.record(context.WithoutCancel(ctx), event, entry)
+	return nil
+}
+
+// takeAPIKeyUse counts a use of an API key, returning true with the number of uses counted since the last entry
+// when the use is to be recorded
+func (s *AuditService) takeAPIKeyUse(apiKeyID string, now time.Time) (int, bool) {
+	s.apiKeyUsesMu.Lock()
+	defer s.apiKeyUsesMu.Unlock()
+
+	usage, ok := s.apiKeyUses[apiKeyID]
+	if ok && now.Sub(usage.recordedAt) < apiKeyUsedAuditInterval {
+		usage.unrecorded++
+		return 0, false
+	}
+
+	// Forget the keys that were not used for an interval, their next use is recorded anyway
+	for id, other := range s.apiKeyUses {
+		if now.Sub(other.recordedAt) >= apiKeyUsedAuditInterval && other.unrecorded == 0 {
+			delete(s.apiKeyUses, id)
+		}
+	}
+
+	unrecorded := 0
+	if ok {
+		unrecorded = usage.unrecorded
+	}
+	s.apiKeyUses[apiKeyID] = &apiKeyUsage{recordedAt: now}
+	return unrecorded, true
+}
+
+// handleServiceAccountEvent returns a handler recording service account changes in the log of the acting user
+func (s *AuditService) handleServiceAccountEvent(action domain.AuditAction) events.EventHandler {
+	return func(ctx context.Context, event events.Event) error {
+		entry := domain.NewAuditEntry(event.Metadata.UserID, action, auditResourceServiceAccount, event.Metadata.Properties["service_account_id"], domain.AuditOutcomeSuccess)
+		if organizationID := event.Metadata.Properties["organization_id"]; organizationID != "" {
+			entry.Metadata["organization_id"] = organizationID
+		}
+
+		s.record(ctx, event, entry)
+		return nil
+	}
+}
+
+// handleUserDeleted records the deletion of a user account
+func (s *AuditService) handleUserDeleted(ctx context.Context, event events.Event) error {
+	entry := domain.NewAuditEntry(event.Metadata.UserID, domain.AuditActionAccountDeleted, auditResourceUser, event.Metadata.UserID, domain.AuditOutcomeSuccess)
+
+	s.record(ctx, event, entry)
+	return nil
+}
+
+// handleProviderEvent returns a handler recording provider changes, which are system entries unless made by a user
+func (s *AuditService) handleProviderEvent(action domain.AuditAction) events.EventHandler {
+	return func(ctx context.Context, event events.Event) error {
+		entry := domain.NewAuditEntry("", action, auditResourceProvider, event.Metadata.Properties["provider_identifier"], domain.AuditOutcomeSuccess)
+		if status := event.Metadata.Properties["provider_status"]; status != "" {
+			entry.Metadata["status"] = status
+		}
+
+		s.record(ctx, event, entry)
+		return nil
+	}
+}
+
+// record appends an entry for an event, failures are logged so that the audited action is not rolled back
+func (s *AuditService) record(ctx context.Context, event events.Event, entry *domain.AuditEntry) {
+	if event.Metadata.TraceID != "" {
+		entry.Metadata["trace_id"] = event.Metadata.TraceID
+	}
+
+	if err := s.Record(ctx, entry); err != nil {
+		s.obs.Logger.Error(ctx, "Failed to record audit entry", zap.Error(err),
+			zap.String("event_type", event.Type),
+			zap.String("user_id", entry.UserID),
+		)
+	}
+}
+
+// payloadString returns a string value of an event payload, or an empty string
+func payloadString(payload events.Payload, key string) string {
+	value, _ := payload[key].(string)
+	return value
+}
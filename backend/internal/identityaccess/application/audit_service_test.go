@@ -0,0 +1,136 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+)
+
+// memoryAuditRepository lists the stored entries by sequence like the database does
+type memoryAuditRepository struct {
+	entries   []*domain.AuditEntry
+	listCalls int
+}
+
+func (r *memoryAuditRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	var previous *domain.AuditEntry
+	if len(r.entries) > 0 {
+		previous = r.entries[len(r.entries)-1]
+	}
+	entry.Link(previous)
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *memoryAuditRepository) List(ctx context.Context, userID string, filter domain.AuditFilter, offset, limit int) ([]*domain.AuditEntry, int64, error) {
+	return nil, 0, nil
+}
+
+func (r *memoryAuditRepository) ListChain(ctx context.Context, userID string, afterSequence int64, limit int) ([]*domain.AuditEntry, error) {
+	r.listCalls++
+	sorted := make([]*domain.AuditEntry, len(r.entries))
+	copy(sorted, r.entries)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Sequence < sorted[j].Sequence })
+
+	var entries []*domain.AuditEntry
+	for _, entry := range sorted {
+		if entry.UserID == userID && entry.Sequence > afterSequence && len(entries) < limit {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// newTestAuditRepository returns a repository holding a linked chain of n entries of user-1
+func newTestAuditRepository(t *testing.T, n int) *memoryAuditRepository {
+	repo := &memoryAuditRepository{}
+	for i := 0; i < n; i++ {
+		entry := domain.NewAuditEntry("user-1", domain.AuditActionAPIKeyUsed, auditResourceAPIKey, "key-1", domain.AuditOutcomeSuccess)
+		require.NoError(t, repo.Append(context.Background(), entry))
+	}
+	return repo
+}
+
+func TestAuditServiceVerifyChain(t *testing.T) {
+	chainLength := 2*auditChainBatchSize + 200
+
+	tests := []struct {
+		name                    string
+		tamper                  func(repo *memoryAuditRepository)
+		expectedValid           bool
+		expectedChecked         int64
+		expectedInvalidSequence int64
+	}{
+		{
+			name:            "IntactAcrossBatches",
+			tamper:          func(repo *memoryAuditRepository) {},
+			expectedValid:   true,
+			expectedChecked: int64(chainLength),
+		},
+		{
+			name: "AlteredEntryInSecondBatch",
+			tamper: func(repo *memoryAuditRepository) {
+				repo.entries[auditChainBatchSize+9].ResourceID = "key-2"
+			},
+			expectedChecked:         auditChainBatchSize + 10,
+			expectedInvalidSequence: auditChainBatchSize + 10,
+		},
+		{
+			name: "RemovedLastEntryOfFirstBatch",
+			tamper: func(repo *memoryAuditRepository) {
+				repo.entries = append(repo.entries[:auditChainBatchSize-1], repo.entries[auditChainBatchSize:]...)
+			},
+			expectedChecked:         auditChainBatchSize,
+			expectedInvalidSequence: auditChainBatchSize + 1,
+		},
+		{
+			name: "RemovedFirstEntryOfSecondBatch",
+			tamper: func(repo *memoryAuditRepository) {
+				repo.entries = append(repo.entries[:auditChainBatchSize], repo.entries[auditChainBatchSize+1:]...)
+			},
+			expectedChecked:         auditChainBatchSize + 1,
+			expectedInvalidSequence: auditChainBatchSize + 2,
+		},
+		{
+			name: "ReorderedAcrossBatchBoundary",
+			tamper: func(repo *memoryAuditRepository) {
+				// Swapping the sequences moves the entries to each other's position in the chain
+				first, second := repo.entries[auditChainBatchSize-1], repo.entries[auditChainBatchSize]
+				first.Sequence, second.Sequence = second.Sequence, first.Sequence
+			},
+			expectedChecked:         auditChainBatchSize,
+			expectedInvalidSequence: auditChainBatchSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestAuditRepository(t, chainLength)
+			tt.tamper(repo)
+			service := NewAuditService(repo, newTestObservabilityProvider(t))
+
+			verification, err := service.VerifyChain(context.Background(), "user-1")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedValid, verification.Valid)
+			assert.Equal(t, tt.expectedChecked, verification.EntriesChecked)
+			assert.Equal(t, tt.expectedInvalidSequence, verification.FirstInvalidSequence)
+		})
+	}
+}
+
+func TestAuditServiceVerifyChainReadsInBatches(t *testing.T) {
+	repo := newTestAuditRepository(t, 2*auditChainBatchSize)
+	service := NewAuditService(repo, newTestObservabilityProvider(t))
+
+	verification, err := service.VerifyChain(context.Background(), "user-1")
+	require.NoError(t, err)
+	assert.True(t, verification.Valid)
+	assert.Equal(t, int64(2*auditChainBatchSize), verification.EntriesChecked)
+	// A full last batch is followed by an empty read that ends the walk
+	assert.Equal(t, 3, repo.listCalls)
+}
@@ -6,7 +6,6 @@ import (
 
 	observability "github.com/context-space/cloud-observability"
 	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
-	"github.com/context-space/context-space/backend/internal/identityaccess/infrastructure/supabase"
 	"github.com/context-space/context-space/backend/internal/shared/events"
 	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
 	"go.uber.org/zap"
@@ -16,7 +15,7 @@ import (
 type AuthService struct {
 	userRepo          domain.UserRepository
 	userInfoRepo      domain.UserInfoRepository
-	supabaseService   *supabase.SupabaseAuthService
+	identityProvider  domain.IdentityProvider
 	unitOfWorkFactory database.UnitOfWorkFactory
 	eventBus          *events.Bus
 	obs               *observability.ObservabilityProvider
@@ -26,7 +25,7 @@ type AuthService struct {
 func NewAuthService(
 	userRepo domain.UserRepository,
 	userInfoRepo domain.UserInfoRepository,
-	supabaseService *supabase.SupabaseAuthService,
+	identityProvider domain.IdentityProvider,
 	unitOfWorkFactory database.UnitOfWorkFactory,
 	eventBus *events.Bus,
 	observabilityProvider *observability.ObservabilityProvider,
@@ -34,26 +33,26 @@ func NewAuthService(
 	return &AuthService{
 		userRepo:          userRepo,
 		userInfoRepo:      userInfoRepo,
-		supabaseService:   supabaseService,
+		identityProvider:  identityProvider,
 		unitOfWorkFactory: unitOfWorkFactory,
 		eventBus:          eventBus,
 		obs:               observabilityProvider,
 	}
 }
 
-// ValidateToken validates an access token
-func (s *AuthService) ValidateToken(ctx context.Context, tokenString string) (*supabase.Claims, error) {
-	return s.supabaseService.ValidateToken(ctx, tokenString)
+// ValidateToken validates an access token with the configured identity provider
+func (s *AuthService) ValidateToken(ctx context.Context, tokenString string) (*domain.Identity, error) {
+	return s.identityProvider.ValidateToken(ctx, tokenString)
 }
 
-// FindOrCreateUser finds or creates a user from a supabase claims
-func (s *AuthService) FindOrCreateUser(ctx context.Context, claims *supabase.Claims) (*domain.User, error) {
-	return s.findOrCreateUser(ctx, claims)
+// FindOrCreateUser finds or creates a user from an identity
+func (s *AuthService) FindOrCreateUser(ctx context.Context, identity *domain.Identity) (*domain.User, error) {
+	return s.findOrCreateUser(ctx, identity)
 }
 
-// findOrCreateUser finds or creates a user based on Supabase user info
-func (s *AuthService) findOrCreateUser(ctx context.Context, claims *supabase.Claims) (*domain.User, error) {
-	user, err := s.userRepo.GetBySupID(ctx, claims.Subject)
+// findOrCreateUser finds or creates a user based on the profile returned by the identity provider
+func (s *AuthService) findOrCreateUser(ctx context.Context, identity *domain.Identity) (*domain.User, error) {
+	user, err := s.userRepo.GetBySupID(ctx, identity.Subject)
 	if err != nil {
 		return nil, err
 	}
@@ -64,12 +63,12 @@ func (s *AuthService) findOrCreateUser(ctx context.Context, claims *supabase.Cla
 	}
 
 	// User not found, create new user
-	supUserInfo, err := s.supabaseService.GetUserInfo(ctx, claims.Subject)
+	profile, err := s.identityProvider.GetUserProfile(ctx, identity)
 	if err != nil {
 		return nil, err
 	}
 
-	newUser := domain.NewUser(supUserInfo.ID, supUserInfo.Email, supUserInfo.IsAnonymous)
+	newUser := domain.NewUser(identity.Subject, profile.Email, profile.IsAnonymous)
 
 	// Begin transaction
 	unitOfWork := s.unitOfWorkFactory.Create()
@@ -84,7 +83,7 @@ func (s *AuthService) findOrCreateUser(ctx context.Context, claims *supabase.Cla
 	}
 
 	// Store user info
-	newUserInfo := domain.NewUserInfo(newUser.ID, supUserInfo.InfoMetadata)
+	newUserInfo := domain.NewUserInfo(newUser.ID, profile.Metadata)
 	if err := s.userInfoRepo.Create(ctx, newUserInfo); err != nil {
 		unitOfWork.Rollback(ctx)
 		return nil, err
@@ -0,0 +1,567 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/shared/apierrors"
+	"github.com/context-space/context-space/backend/internal/shared/events"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"go.uber.org/zap"
+)
+
+// OrganizationEventType defines the events related to organizations
+type OrganizationEventType string
+
+const (
+	// OrganizationCreatedEvent is emitted when an organization is created
+	OrganizationCreatedEvent OrganizationEventType = "organization.created"
+	// OrganizationDeletedEvent is emitted when an organization is deleted
+	OrganizationDeletedEvent OrganizationEventType = "organization.deleted"
+	// OrganizationMemberAddedEvent is emitted when a user joins an organization
+	OrganizationMemberAddedEvent OrganizationEventType = "organization.member_added"
+	// OrganizationMemberRemovedEvent is emitted when a user leaves or is removed from an organization
+	OrganizationMemberRemovedEvent OrganizationEventType = "organization.member_removed"
+	// OrganizationAPIKeyCreatedEvent is emitted when an organization API key is created
+	OrganizationAPIKeyCreatedEvent OrganizationEventType = "organization.apikey_created"
+	// OrganizationAPIKeyDeletedEvent is emitted when an organization API key is deleted
+	OrganizationAPIKeyDeletedEvent OrganizationEventType = "organization.apikey_deleted"
+)
+
+const (
+	maxAPIKeysPerOrganization = 10
+)
+
+// OrganizationMember is a membership together with the member's email
+type OrganizationMember struct {
+	*domain.Membership
+	Email string
+}
+
+// OrganizationService provides organization, membership and organization API key services
+type OrganizationService struct {
+	organizationRepo  domain.OrganizationRepository
+	membershipRepo    domain.MembershipRepository
+	userRepo          domain.UserRepository
+	apiKeyRepo        domain.APIKeyRepository
+	unitOfWorkFactory database.UnitOfWorkFactory
+	eventBus          *events.Bus
+	obs               *observability.ObservabilityProvider
+}
+
+// NewOrganizationService creates a new OrganizationService
+func NewOrganizationService(
+	organizationRepo domain.OrganizationRepository,
+	membershipRepo domain.MembershipRepository,
+	userRepo domain.UserRepository,
+	apiKeyRepo domain.APIKeyRepository,
+	unitOfWorkFactory database.UnitOfWorkFactory,
+	eventBus *events.Bus,
+	observabilityProvider *observability.ObservabilityProvider,
+) *OrganizationService {
+	return &OrganizationService{
+		organizationRepo:  organizationRepo,
+		membershipRepo:    membershipRepo,
+		userRepo:          userRepo,
+		apiKeyRepo:        apiKeyRepo,
+		unitOfWorkFactory: unitOfWorkFactory,
+		eventBus:          eventBus,
+		obs:               observabilityProvider,
+	}
+}
+
+// CreateOrganization creates an organization owned by the given user
+func (s *OrganizationService) CreateOrganization(ctx context.Context, userID, name string) (*domain.Organization, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "OrganizationService.CreateOrganization")
+	defer span.End()
+
+	if name == "" {
+		return nil, apierrors.NewValidationError("Organization name is required", nil)
+	}
+
+	organization := domain.NewOrganization(name, userID)
+	membership := domain.NewMembership(organization.ID, userID, domain.OrganizationRoleOwner)
+
+	unitOfWork := s.unitOfWorkFactory.Create()
+	if err := unitOfWork.Begin(ctx); err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	if err := s.organizationRepo.Create(ctx, organization); err != nil {
+		unitOfWork.Rollback(ctx)
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	if err := s.membershipRepo.Create(ctx, membership); err != nil {
+		unitOfWork.Rollback(ctx)
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	if err := unitOfWork.Commit(ctx); err != nil {
+		unitOfWork.Rollback(ctx)
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	s.emitOrganizationEvent(ctx, OrganizationCreatedEvent, organization.ID, userID, nil)
+
+	return organization, nil
+}
+
+// ListOrganizations retrieves the organizations a user is a member of
+func (s *OrganizationService) ListOrganizations(ctx context.Context, userID string) ([]*domain.Organization, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "OrganizationService.ListOrganizations")
+	defer span.End()
+
+	organizations, err := s.organizationRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	return organizations, nil
+}
+
+// GetMembership retrieves an organization and the membership of a user in it
+// Returns a not found error if the organization does not exist or the user is not a member
+func (s *OrganizationService) GetMembership(ctx context.Context, organizationID, userID string) (*domain.Organization, *domain.Membership, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "OrganizationService.GetMembership")
+	defer span.End()
+
+	organization, err := s.organizationRepo.Get(ctx, organizationID)
+	if err != nil {
+		return nil, nil, apierrors.NewInternalError("", err)
+	}
+	if organization == nil {
+		return nil, nil, apierrors.NewNotFoundError("Organization not found", nil)
+	}
+
+	membership, err := s.membershipRepo.Get(ctx, organizationID, userID)
+	if err != nil {
+		return nil, nil, apierrors.NewInternalError("", err)
+	}
+	if membership == nil {
+		// Do not reveal the existence of organizations the user does not belong to
+		return nil, nil, apierrors.NewNotFoundError("Organization not found", nil)
+	}
+
+	return organization, membership, nil
+}
+
+// UpdateOrganization renames an organization and sets the minimum role allowed to use its credentials
+func (s *OrganizationService) UpdateOrganization(
+	ctx context.Context,
+	organizationID, actorID, name string,
+	sharedCredentialRole domain.OrganizationRole,
+) (*domain.Organization, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "OrganizationService.UpdateOrganization")
+	defer span.End()
+
+	organization, actor, err := s.GetMembership(ctx, organizationID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanManage() {
+		return nil, apierrors.NewForbiddenError("Only organization admins can update the organization", nil)
+	}
+
+	if name != "" {
+		organization.Name = name
+	}
+	if sharedCredentialRole != "" {
+		if !sharedCredentialRole.IsValid() {
+			return nil, apierrors.NewValidationError("Invalid shared credential role", nil)
+		}
+		organization.SharedCredentialRole = sharedCredentialRole
+	}
+	organization.UpdatedAt = time.Now()
+
+	if err := s.organizationRepo.Update(ctx, organization); err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	return organization, nil
+}
+
+// DeleteOrganization deletes an organization with its memberships and API keys
+// Organization credentials are deleted by the credential management module on the emitted event
+func (s *OrganizationService) DeleteOrganization(ctx context.Context, organizationID, actorID string) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "OrganizationService.DeleteOrganization")
+	defer span.End()
+
+	_, actor, err := s.GetMembership(ctx, organizationID, actorID)
+	if err != nil {
+		return err
+	}
+	if actor.Role != domain.OrganizationRoleOwner {
+		return apierrors.NewForbiddenError("Only organization owners can delete the organization", nil)
+	}
+
+	apiKeys, err := s.apiKeyRepo.ListByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return apierrors.NewInternalError("", err)
+	}
+
+	unitOfWork := s.unitOfWorkFactory.Create()
+	if err := unitOfWork.Begin(ctx); err != nil {
+		return apierrors.NewInternalError("", err)
+	}
+
+	for _, apiKey := range apiKeys {
+		if err := s.apiKeyRepo.Delete(ctx, apiKey.ID); err != nil {
+			unitOfWork.Rollback(ctx)
+			return apierrors.NewInternalError("", err)
+		}
+	}
+
+	if err := s.membershipRepo.DeleteByOrganizationID(ctx, organizationID); err != nil {
+		unitOfWork.Rollback(ctx)
+		return apierrors.NewInternalError("", err)
+	}
+
+	if err := s.organizationRepo.Delete(ctx, organizationID); err != nil {
+		unitOfWork.Rollback(ctx)
+		return apierrors.NewInternalError("", err)
+	}
+
+	if err := unitOfWork.Commit(ctx); err != nil {
+		unitOfWork.Rollback(ctx)
+		return apierrors.NewInternalError("", err)
+	}
+
+	s.emitOrganizationEvent(ctx, OrganizationDeletedEvent, organizationID, actorID, nil)
+
+	return nil
+}
+
+// ListMembers retrieves the members of an organization the actor belongs to
+func (s *OrganizationService) ListMembers(ctx context.Context, organizationID, actorID string) ([]*OrganizationMember, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "OrganizationService.ListMembers")
+	defer span.End()
+
+	if _, _, err := s.GetMembership(ctx, organizationID, actorID); err != nil {
+		return nil, err
+	}
+
+	memberships, err := s.membershipRepo.ListByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	members := make([]*OrganizationMember, 0, len(memberships))
+	for _, membership := range memberships {
+		member := &OrganizationMember{Membership: membership}
+		user, err := s.userRepo.Get(ctx, membership.UserID)
+		if err != nil {
+			return nil, apierrors.NewInternalError("", err)
+		}
+		if user != nil {
+			member.Email = user.Email
+		}
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+// AddMember adds an existing user, identified by email, to an organization
+func (s *OrganizationService) AddMember(
+	ctx context.Context,
+	organizationID, actorID, email string,
+	role domain.OrganizationRole,
+) (*OrganizationMember, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "OrganizationService.AddMember")
+	defer span.End()
+
+	if !role.IsValid() {
+		return nil, apierrors.NewValidationError("Invalid organization role", nil)
+	}
+
+	_, actor, err := s.GetMembership(ctx, organizationID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCanAssignRole(actor, role); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+	if user == nil {
+		return nil, apierrors.NewNotFoundError("User not found", nil)
+	}
+
+	existing, err := s.membershipRepo.Get(ctx, organizationID, user.ID)
+	if err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+	if existing != nil {
+		return nil, apierrors.NewAPIError(apierrors.ErrConflict, "User is already a member of the organization", nil)
+	}
+
+	membership := domain.NewMembership(organizationID, user.ID, role)
+	if err := s.membershipRepo.Create(ctx, membership); err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	s.emitOrganizationEvent(ctx, OrganizationMemberAddedEvent, organizationID, actorID, map[string]string{
+		"member_id": user.ID,
+		"role":      string(role),
+	})
+
+	return &OrganizationMember{Membership: membership, Email: user.Email}, nil
+}
+
+// UpdateMemberRole changes the role of a member, keeping at least one owner
+func (s *OrganizationService) UpdateMemberRole(
+	ctx context.Context,
+	organizationID, actorID, userID string,
+	role domain.OrganizationRole,
+) (*domain.Membership, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "OrganizationService.UpdateMemberRole")
+	defer span.End()
+
+	if !role.IsValid() {
+		return nil, apierrors.NewValidationError("Invalid organization role", nil)
+	}
+
+	_, actor, err := s.GetMembership(ctx, organizationID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := s.membershipRepo.Get(ctx, organizationID, userID)
+	if err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+	if membership == nil {
+		return nil, apierrors.NewNotFoundError("Member not found", nil)
+	}
+
+	// Both the current and the new role must be within the actor's reach
+	if err := checkCanAssignRole(actor, membership.Role); err != nil {
+		return nil, err
+	}
+	if err := checkCanAssignRole(actor, role); err != nil {
+		return nil, err
+	}
+	if membership.Role == domain.OrganizationRoleOwner && role != domain.OrganizationRoleOwner {
+		if err := s.checkNotLastOwner(ctx, organizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	membership.Role = role
+	membership.UpdatedAt = time.Now()
+	if err := s.membershipRepo.Update(ctx, membership); err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	return membership, nil
+}
+
+// RemoveMember removes a member from an organization, members may always remove themselves
+// The organization API keys created by the member are deleted with the membership
+func (s *OrganizationService) RemoveMember(ctx context.Context, organizationID, actorID, userID string) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "OrganizationService.RemoveMember")
+	defer span.End()
+
+	_, actor, err := s.GetMembership(ctx, organizationID, actorID)
+	if err != nil {
+		return err
+	}
+
+	membership, err := s.membershipRepo.Get(ctx, organizationID, userID)
+	if err != nil {
+		return apierrors.NewInternalError("", err)
+	}
+	if membership == nil {
+		return apierrors.NewNotFoundError("Member not found", nil)
+	}
+
+	if actorID != userID {
+		if err := checkCanAssignRole(actor, membership.Role); err != nil {
+			return err
+		}
+	}
+	if membership.Role == domain.OrganizationRoleOwner {
+		if err := s.checkNotLastOwner(ctx, organizationID); err != nil {
+			return err
+		}
+	}
+
+	apiKeys, err := s.apiKeyRepo.ListByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return apierrors.NewInternalError("", err)
+	}
+
+	unitOfWork := s.unitOfWorkFactory.Create()
+	if err := unitOfWork.Begin(ctx); err != nil {
+		return apierrors.NewInternalError("", err)
+	}
+
+	for _, apiKey := range apiKeys {
+		if apiKey.UserID != userID {
+			continue
+		}
+		if err := s.apiKeyRepo.Delete(ctx, apiKey.ID); err != nil {
+			unitOfWork.Rollback(ctx)
+			return apierrors.NewInternalError("", err)
+		}
+	}
+
+	if err := s.membershipRepo.Delete(ctx, organizationID, userID); err != nil {
+		unitOfWork.Rollback(ctx)
+		return apierrors.NewInternalError("", err)
+	}
+
+	if err := unitOfWork.Commit(ctx); err != nil {
+		unitOfWork.Rollback(ctx)
+		return apierrors.NewInternalError("", err)
+	}
+
+	s.emitOrganizationEvent(ctx, OrganizationMemberRemovedEvent, organizationID, actorID, map[string]string{
+		"member_id": userID,
+	})
+
+	return nil
+}
+
+// CreateAPIKey creates an API key acting on behalf of an organization
+func (s *OrganizationService) CreateAPIKey(ctx context.Context, organizationID, actorID, name, description string) (*domain.APIKey, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "OrganizationService.CreateAPIKey")
+	defer span.End()
+
+	_, actor, err := s.GetMembership(ctx, organizationID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanManage() {
+		return nil, apierrors.NewForbiddenError("Only organization admins can create API keys", nil)
+	}
+
+	apiKeys, err := s.apiKeyRepo.ListByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+	if len(apiKeys) >= maxAPIKeysPerOrganization {
+		return nil, apierrors.NewForbiddenError("Maximum number of API keys reached", nil)
+	}
+
+	apiKey := domain.NewOrganizationAPIKey(actorID, organizationID, name, description)
+	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	s.emitOrganizationEvent(ctx, OrganizationAPIKeyCreatedEvent, organizationID, actorID, map[string]string{
+		"api_key_id": apiKey.ID,
+	})
+
+	return apiKey, nil
+}
+
+// ListAPIKeys retrieves the API keys of an organization
+func (s *OrganizationService) ListAPIKeys(ctx context.Context, organizationID, actorID string) ([]*domain.APIKey, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "OrganizationService.ListAPIKeys")
+	defer span.End()
+
+	_, actor, err := s.GetMembership(ctx, organizationID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanManage() {
+		return nil, apierrors.NewForbiddenError("Only organization admins can list API keys", nil)
+	}
+
+	apiKeys, err := s.apiKeyRepo.ListByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	return apiKeys, nil
+}
+
+// DeleteAPIKey deletes an API key of an organization
+func (s *OrganizationService) DeleteAPIKey(ctx context.Context, organizationID, actorID, keyID string) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "OrganizationService.DeleteAPIKey")
+	defer span.End()
+
+	_, actor, err := s.GetMembership(ctx, organizationID, actorID)
+	if err != nil {
+		return err
+	}
+	if !actor.CanManage() {
+		return apierrors.NewForbiddenError("Only organization admins can delete API keys", nil)
+	}
+
+	apiKey, err := s.apiKeyRepo.Get(ctx, keyID)
+	if err != nil {
+		return apierrors.NewInternalError("", err)
+	}
+	if apiKey == nil || apiKey.OrganizationID != organizationID {
+		return apierrors.NewNotFoundError("API key not found", nil)
+	}
+
+	if err := s.apiKeyRepo.Delete(ctx, keyID); err != nil {
+		return apierrors.NewInternalError("", err)
+	}
+
+	s.emitOrganizationEvent(ctx, OrganizationAPIKeyDeletedEvent, organizationID, actorID, map[string]string{
+		"api_key_id": apiKey.ID,
+	})
+
+	return nil
+}
+
+// checkNotLastOwner rejects changes that would leave an organization without an owner
+func (s *OrganizationService) checkNotLastOwner(ctx context.Context, organizationID string) error {
+	owners, err := s.membershipRepo.CountByRole(ctx, organizationID, domain.OrganizationRoleOwner)
+	if err != nil {
+		return apierrors.NewInternalError("", err)
+	}
+	if owners <= 1 {
+		return apierrors.NewValidationError("An organization must keep at least one owner", nil)
+	}
+	return nil
+}
+
+// checkCanAssignRole rejects role changes outside the actor's reach, only owners can grant or revoke ownership
+func checkCanAssignRole(actor *domain.Membership, role domain.OrganizationRole) error {
+	if !actor.CanManage() {
+		return apierrors.NewForbiddenError("Only organization admins can manage members", nil)
+	}
+	if role == domain.OrganizationRoleOwner && actor.Role != domain.OrganizationRoleOwner {
+		return apierrors.NewForbiddenError("Only organization owners can manage owners", nil)
+	}
+	return nil
+}
+
+// emitOrganizationEvent emits an organization-related event
+func (s *OrganizationService) emitOrganizationEvent(
+	ctx context.Context,
+	eventType OrganizationEventType,
+	organizationID, actorID string,
+	properties map[string]string,
+) {
+	if properties == nil {
+		properties = make(map[string]string)
+	}
+	properties["organization_id"] = organizationID
+
+	metadata := events.Metadata{
+		UserID:     actorID,
+		TraceID:    observability.GetTraceID(ctx),
+		SpanID:     observability.GetSpanID(ctx),
+		Properties: properties,
+	}
+
+	event := events.NewEvent(events.EventType(eventType), events.Payload{"organization_id": organizationID}, metadata)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		s.obs.Logger.Error(ctx, "Failed to publish organization event", zap.Error(err),
+			zap.String("event_type", string(eventType)),
+			zap.String("organization_id", organizationID),
+		)
+	}
+}
@@ -0,0 +1,284 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/shared/apierrors"
+	"github.com/context-space/context-space/backend/internal/shared/events"
+	identityaccess_mocks "github.com/context-space/context-space/backend/internal/shared/testing/mocks/identityaccess"
+	shared_mocks "github.com/context-space/context-space/backend/internal/shared/testing/mocks/shared"
+)
+
+const (
+	testOrganizationID = "org-1"
+	testActorID        = "actor-1"
+	testMemberID       = "member-1"
+)
+
+// organizationTestMocks holds the repositories of an organization service under test
+type organizationTestMocks struct {
+	organizationRepo *identityaccess_mocks.MockOrganizationRepository
+	membershipRepo   *identityaccess_mocks.MockMembershipRepository
+	userRepo         *identityaccess_mocks.MockUserRepository
+	apiKeyRepo       *identityaccess_mocks.MockAPIKeyRepository
+	unitOfWork       *shared_mocks.MockUnitOfWork
+}
+
+// newOrganizationTestService creates an organization service in which the actor has the given role
+func newOrganizationTestService(t *testing.T, actorRole domain.OrganizationRole) (*OrganizationService, *organizationTestMocks) {
+	mocks := &organizationTestMocks{
+		organizationRepo: identityaccess_mocks.NewMockOrganizationRepository(t),
+		membershipRepo:   identityaccess_mocks.NewMockMembershipRepository(t),
+		userRepo:         identityaccess_mocks.NewMockUserRepository(t),
+		apiKeyRepo:       identityaccess_mocks.NewMockAPIKeyRepository(t),
+		unitOfWork:       shared_mocks.NewMockUnitOfWork(t),
+	}
+	unitOfWorkFactory := shared_mocks.NewMockUnitOfWorkFactory(t)
+	unitOfWorkFactory.EXPECT().Create().Return(mocks.unitOfWork).Maybe()
+
+	organization := domain.NewOrganization("Acme", testActorID)
+	organization.ID = testOrganizationID
+	mocks.organizationRepo.EXPECT().Get(mock.Anything, testOrganizationID).Return(organization, nil).Maybe()
+	mocks.membershipRepo.EXPECT().Get(mock.Anything, testOrganizationID, testActorID).
+		Return(domain.NewMembership(testOrganizationID, testActorID, actorRole), nil).Maybe()
+
+	service := NewOrganizationService(mocks.organizationRepo, mocks.membershipRepo, mocks.userRepo, mocks.apiKeyRepo,
+		unitOfWorkFactory, events.NewBus(), newTestObservabilityProvider(t))
+	return service, mocks
+}
+
+// expectMember registers a member of the test organization
+func (m *organizationTestMocks) expectMember(userID string, role domain.OrganizationRole) {
+	m.membershipRepo.EXPECT().Get(mock.Anything, testOrganizationID, userID).
+		Return(domain.NewMembership(testOrganizationID, userID, role), nil)
+}
+
+// expectOwners makes the test organization count the given number of owners
+func (m *organizationTestMocks) expectOwners(owners int64) {
+	m.membershipRepo.EXPECT().CountByRole(mock.Anything, testOrganizationID, domain.OrganizationRoleOwner).Return(owners, nil)
+}
+
+// expectRemoval expects the membership of a user without organization API keys to be deleted
+func (m *organizationTestMocks) expectRemoval(userID string) {
+	m.apiKeyRepo.EXPECT().ListByOrganizationID(mock.Anything, testOrganizationID).Return(nil, nil)
+	m.unitOfWork.EXPECT().Begin(mock.Anything).Return(nil)
+	m.membershipRepo.EXPECT().Delete(mock.Anything, testOrganizationID, userID).Return(nil)
+	m.unitOfWork.EXPECT().Commit(mock.Anything).Return(nil)
+}
+
+func TestOrganizationServiceMemberManagement(t *testing.T) {
+	tests := []struct {
+		name          string
+		actorRole     domain.OrganizationRole
+		setup         func(m *organizationTestMocks)
+		call          func(s *OrganizationService) error
+		expectedError apierrors.ErrorType
+	}{
+		{
+			name:      "MemberCannotAddMembers",
+			actorRole: domain.OrganizationRoleMember,
+			call: func(s *OrganizationService) error {
+				_, err := s.AddMember(context.Background(), testOrganizationID, testActorID, "new@example.com", domain.OrganizationRoleMember)
+				return err
+			},
+			expectedError: apierrors.ErrorTypeForbidden,
+		},
+		{
+			name:      "MemberCannotChangeRoles",
+			actorRole: domain.OrganizationRoleMember,
+			setup:     func(m *organizationTestMocks) { m.expectMember(testMemberID, domain.OrganizationRoleMember) },
+			call: func(s *OrganizationService) error {
+				_, err := s.UpdateMemberRole(context.Background(), testOrganizationID, testActorID, testMemberID, domain.OrganizationRoleAdmin)
+				return err
+			},
+			expectedError: apierrors.ErrorTypeForbidden,
+		},
+		{
+			name:      "MemberCannotPromoteThemselves",
+			actorRole: domain.OrganizationRoleMember,
+			call: func(s *OrganizationService) error {
+				_, err := s.UpdateMemberRole(context.Background(), testOrganizationID, testActorID, testActorID, domain.OrganizationRoleAdmin)
+				return err
+			},
+			expectedError: apierrors.ErrorTypeForbidden,
+		},
+		{
+			name:      "MemberCannotRemoveOthers",
+			actorRole: domain.OrganizationRoleMember,
+			setup:     func(m *organizationTestMocks) { m.expectMember(testMemberID, domain.OrganizationRoleMember) },
+			call: func(s *OrganizationService) error {
+				return s.RemoveMember(context.Background(), testOrganizationID, testActorID, testMemberID)
+			},
+			expectedError: apierrors.ErrorTypeForbidden,
+		},
+		{
+			name:      "MemberCanLeave",
+			actorRole: domain.OrganizationRoleMember,
+			setup:     func(m *organizationTestMocks) { m.expectRemoval(testActorID) },
+			call: func(s *OrganizationService) error {
+				return s.RemoveMember(context.Background(), testOrganizationID, testActorID, testActorID)
+			},
+		},
+		{
+			name:      "MemberCannotUpdateOrganization",
+			actorRole: domain.OrganizationRoleMember,
+			call: func(s *OrganizationService) error {
+				_, err := s.UpdateOrganization(context.Background(), testOrganizationID, testActorID, "Renamed", domain.OrganizationRoleAdmin)
+				return err
+			},
+			expectedError: apierrors.ErrorTypeForbidden,
+		},
+		{
+			name:      "AdminCannotAddOwners",
+			actorRole: domain.OrganizationRoleAdmin,
+			call: func(s *OrganizationService) error {
+				_, err := s.AddMember(context.Background(), testOrganizationID, testActorID, "new@example.com", domain.OrganizationRoleOwner)
+				return err
+			},
+			expectedError: apierrors.ErrorTypeForbidden,
+		},
+		{
+			name:      "AdminCannotDemoteOwners",
+			actorRole: domain.OrganizationRoleAdmin,
+			setup:     func(m *organizationTestMocks) { m.expectMember(testMemberID, domain.OrganizationRoleOwner) },
+			call: func(s *OrganizationService) error {
+				_, err := s.UpdateMemberRole(context.Background(), testOrganizationID, testActorID, testMemberID, domain.OrganizationRoleMember)
+				return err
+			},
+			expectedError: apierrors.ErrorTypeForbidden,
+		},
+		{
+			name:      "AdminCanRemoveMembers",
+			actorRole: domain.OrganizationRoleAdmin,
+			setup: func(m *organizationTestMocks) {
+				m.expectMember(testMemberID, domain.OrganizationRoleMember)
+				m.expectRemoval(testMemberID)
+			},
+			call: func(s *OrganizationService) error {
+				return s.RemoveMember(context.Background(), testOrganizationID, testActorID, testMemberID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mocks := newOrganizationTestService(t, tt.actorRole)
+			if tt.setup != nil {
+				tt.setup(mocks)
+			}
+
+			err := tt.call(service)
+
+			if tt.expectedError != "" {
+				requireAPIErrorType(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestOrganizationServiceLastOwner(t *testing.T) {
+	tests := []struct {
+		name          string
+		setup         func(m *organizationTestMocks)
+		call          func(s *OrganizationService) error
+		expectedError apierrors.ErrorType
+	}{
+		{
+			name:  "LastOwnerCannotStepDown",
+			setup: func(m *organizationTestMocks) { m.expectOwners(1) },
+			call: func(s *OrganizationService) error {
+				_, err := s.UpdateMemberRole(context.Background(), testOrganizationID, testActorID, testActorID, domain.OrganizationRoleAdmin)
+				return err
+			},
+			expectedError: apierrors.ErrorTypeValidation,
+		},
+		{
+			name:  "LastOwnerCannotLeave",
+			setup: func(m *organizationTestMocks) { m.expectOwners(1) },
+			call: func(s *OrganizationService) error {
+				return s.RemoveMember(context.Background(), testOrganizationID, testActorID, testActorID)
+			},
+			expectedError: apierrors.ErrorTypeValidation,
+		},
+		{
+			name: "OwnerCanBeDemotedWhileAnotherRemains",
+			setup: func(m *organizationTestMocks) {
+				m.expectMember(testMemberID, domain.OrganizationRoleOwner)
+				m.expectOwners(2)
+				m.membershipRepo.EXPECT().Update(mock.Anything, mock.MatchedBy(func(membership *domain.Membership) bool {
+					return membership.UserID == testMemberID && membership.Role == domain.OrganizationRoleAdmin
+				})).Return(nil)
+			},
+			call: func(s *OrganizationService) error {
+				_, err := s.UpdateMemberRole(context.Background(), testOrganizationID, testActorID, testMemberID, domain.OrganizationRoleAdmin)
+				return err
+			},
+		},
+		{
+			name: "OwnerCanLeaveWhileAnotherRemains",
+			setup: func(m *organizationTestMocks) {
+				m.expectOwners(2)
+				m.expectRemoval(testActorID)
+			},
+			call: func(s *OrganizationService) error {
+				return s.RemoveMember(context.Background(), testOrganizationID, testActorID, testActorID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mocks := newOrganizationTestService(t, domain.OrganizationRoleOwner)
+			tt.setup(mocks)
+
+			err := tt.call(service)
+
+			if tt.expectedError != "" {
+				requireAPIErrorType(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestOrganizationServiceCreateAPIKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		actorRole     domain.OrganizationRole
+		expectedError apierrors.ErrorType
+	}{
+		{name: "Member", actorRole: domain.OrganizationRoleMember, expectedError: apierrors.ErrorTypeForbidden},
+		{name: "Admin", actorRole: domain.OrganizationRoleAdmin},
+		{name: "Owner", actorRole: domain.OrganizationRoleOwner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mocks := newOrganizationTestService(t, tt.actorRole)
+			if tt.expectedError == "" {
+				mocks.apiKeyRepo.EXPECT().ListByOrganizationID(mock.Anything, testOrganizationID).Return(nil, nil)
+				mocks.apiKeyRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil)
+			}
+
+			apiKey, err := service.CreateAPIKey(context.Background(), testOrganizationID, testActorID, "deploy", "")
+
+			if tt.expectedError != "" {
+				requireAPIErrorType(t, err, tt.expectedError)
+				assert.Nil(t, apiKey)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testOrganizationID, apiKey.OrganizationID)
+			assert.Equal(t, testActorID, apiKey.UserID)
+		})
+	}
+}
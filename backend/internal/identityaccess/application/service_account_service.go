@@ -0,0 +1,392 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/shared/apierrors"
+	"github.com/context-space/context-space/backend/internal/shared/events"
+	"go.uber.org/zap"
+)
+
+// ServiceAccountEventType defines the events related to service accounts
+type ServiceAccountEventType string
+
+const (
+	// ServiceAccountCreatedEvent is emitted when a service account is created
+	ServiceAccountCreatedEvent ServiceAccountEventType = "service_account.created"
+	// ServiceAccountDeletedEvent is emitted when a service account is deleted, its credentials are deleted with it
+	ServiceAccountDeletedEvent ServiceAccountEventType = "service_account.deleted"
+)
+
+const (
+	maxServiceAccountsPerOwner     = 20
+	maxAPIKeysPerServiceAccount    = 5
+	maxServiceAccountAPIKeyTTLDays = 365
+)
+
+// CreateServiceAccountAPIKeyInput holds the fields of a service account API key to create
+type CreateServiceAccountAPIKeyInput struct {
+	Name        string
+	Description string
+	Scopes      []domain.APIKeyScope
+	// ExpiresAt is required so that leaked keys of unattended callers do not stay valid forever
+	ExpiresAt time.Time
+}
+
+// ServiceAccountService provides the service accounts of users and organizations and their API keys
+type ServiceAccountService struct {
+	serviceAccountRepo  domain.ServiceAccountRepository
+	apiKeyRepo          domain.APIKeyRepository
+	organizationService *OrganizationService
+	eventBus            *events.Bus
+	obs                 *observability.ObservabilityProvider
+}
+
+// NewServiceAccountService creates a new ServiceAccountService
+func NewServiceAccountService(
+	serviceAccountRepo domain.ServiceAccountRepository,
+	apiKeyRepo domain.APIKeyRepository,
+	organizationService *OrganizationService,
+	eventBus *events.Bus,
+	observabilityProvider *observability.ObservabilityProvider,
+) *ServiceAccountService {
+	return &ServiceAccountService{
+		serviceAccountRepo:  serviceAccountRepo,
+		apiKeyRepo:          apiKeyRepo,
+		organizationService: organizationService,
+		eventBus:            eventBus,
+		obs:                 observabilityProvider,
+	}
+}
+
+// RegisterEventHandlers deletes the service accounts of deleted users and organizations
+func (s *ServiceAccountService) RegisterEventHandlers(eventBus *events.Bus) {
+	eventBus.Subscribe(string(UserDeletedEvent), s.handleUserDeleted)
+	eventBus.Subscribe(string(OrganizationDeletedEvent), s.handleOrganizationDeleted)
+}
+
+// CreateServiceAccount creates a service account owned by the actor, or by the organization if organizationID is not empty
+func (s *ServiceAccountService) CreateServiceAccount(ctx context.Context, actorID, organizationID, name, description string) (*domain.ServiceAccount, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ServiceAccountService.CreateServiceAccount")
+	defer span.End()
+
+	existing, err := s.listOwned(ctx, actorID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxServiceAccountsPerOwner {
+		return nil, apierrors.NewForbiddenError("Maximum number of service accounts reached", nil)
+	}
+
+	serviceAccount, err := domain.NewServiceAccount(actorID, organizationID, name, description)
+	if err != nil {
+		return nil, apierrors.NewValidationError(err.Error(), err)
+	}
+
+	if err := s.serviceAccountRepo.Create(ctx, serviceAccount); err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	s.emitServiceAccountEvent(ctx, ServiceAccountCreatedEvent, serviceAccount, actorID)
+
+	return serviceAccount, nil
+}
+
+// ListServiceAccounts retrieves the service accounts owned by the actor, or by the organization if organizationID is not empty
+func (s *ServiceAccountService) ListServiceAccounts(ctx context.Context, actorID, organizationID string) ([]*domain.ServiceAccount, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ServiceAccountService.ListServiceAccounts")
+	defer span.End()
+
+	return s.listOwned(ctx, actorID, organizationID)
+}
+
+// GetManagedServiceAccount retrieves a service account the actor can manage and act as
+// Returns a not found error if the service account does not exist or is out of the actor's reach
+func (s *ServiceAccountService) GetManagedServiceAccount(ctx context.Context, actorID, id string) (*domain.ServiceAccount, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ServiceAccountService.GetManagedServiceAccount")
+	defer span.End()
+
+	serviceAccount, err := s.serviceAccountRepo.Get(ctx, id)
+	if err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+	if serviceAccount == nil {
+		return nil, apierrors.NewNotFoundError("Service account not found", nil)
+	}
+
+	if serviceAccount.OrganizationID == "" {
+		if serviceAccount.CreatedBy != actorID {
+			// Do not reveal the existence of service accounts of other users
+			return nil, apierrors.NewNotFoundError("Service account not found", nil)
+		}
+		return serviceAccount, nil
+	}
+
+	_, membership, err := s.organizationService.GetMembership(ctx, serviceAccount.OrganizationID, actorID)
+	if err != nil {
+		var apiErr *apierrors.APIError
+		if errors.As(err, &apiErr) && apiErr.Code == apierrors.ErrNotFound {
+			return nil, apierrors.NewNotFoundError("Service account not found", nil)
+		}
+		return nil, err
+	}
+	if !membership.CanManage() {
+		return nil, apierrors.NewForbiddenError("Only organization admins can manage service accounts", nil)
+	}
+
+	return serviceAccount, nil
+}
+
+// UpdateServiceAccount changes the name and description of a service account
+func (s *ServiceAccountService) UpdateServiceAccount(ctx context.Context, actorID, id, name, description string) (*domain.ServiceAccount, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ServiceAccountService.UpdateServiceAccount")
+	defer span.End()
+
+	serviceAccount, err := s.GetManagedServiceAccount(ctx, actorID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := serviceAccount.Update(name, description); err != nil {
+		return nil, apierrors.NewValidationError(err.Error(), err)
+	}
+
+	if err := s.serviceAccountRepo.Update(ctx, serviceAccount); err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	return serviceAccount, nil
+}
+
+// DeleteServiceAccount deletes a service account and its API keys
+func (s *ServiceAccountService) DeleteServiceAccount(ctx context.Context, actorID, id string) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "ServiceAccountService.DeleteServiceAccount")
+	defer span.End()
+
+	serviceAccount, err := s.GetManagedServiceAccount(ctx, actorID, id)
+	if err != nil {
+		return err
+	}
+
+	return s.delete(ctx, serviceAccount, actorID)
+}
+
+// CreateAPIKey creates an API key authenticating a service account
+func (s *ServiceAccountService) CreateAPIKey(ctx context.Context, actorID, id string, input CreateServiceAccountAPIKeyInput) (*domain.APIKey, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ServiceAccountService.CreateAPIKey")
+	defer span.End()
+
+	serviceAccount, err := s.GetManagedServiceAccount(ctx, actorID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, scope := range input.Scopes {
+		if !scope.IsValid() {
+			return nil, apierrors.NewValidationError("Unknown API key scope: "+string(scope), nil)
+		}
+	}
+
+	now := time.Now()
+	if !input.ExpiresAt.After(now) {
+		return nil, apierrors.NewValidationError("API key expiry must be in the future", nil)
+	}
+	if input.ExpiresAt.After(now.AddDate(0, 0, maxServiceAccountAPIKeyTTLDays)) {
+		return nil, apierrors.NewValidationError("API key expiry must be within a year", nil)
+	}
+
+	apiKeys, err := s.apiKeyRepo.ListByServiceAccountID(ctx, serviceAccount.ID)
+	if err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+	if len(apiKeys) >= maxAPIKeysPerServiceAccount {
+		return nil, apierrors.NewForbiddenError("Maximum number of API keys reached", nil)
+	}
+
+	expiresAt := input.ExpiresAt
+	apiKey := domain.NewServiceAccountAPIKey(actorID, serviceAccount.ID, input.Name, input.Description, input.Scopes, &expiresAt)
+	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	s.emitAPIKeyEvent(ctx, APIKeyCreatedEvent, apiKey)
+
+	return apiKey, nil
+}
+
+// ListAPIKeys retrieves the API keys of a service account
+func (s *ServiceAccountService) ListAPIKeys(ctx context.Context, actorID, id string) ([]*domain.APIKey, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ServiceAccountService.ListAPIKeys")
+	defer span.End()
+
+	serviceAccount, err := s.GetManagedServiceAccount(ctx, actorID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeys, err := s.apiKeyRepo.ListByServiceAccountID(ctx, serviceAccount.ID)
+	if err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	return apiKeys, nil
+}
+
+// DeleteAPIKey deletes an API key of a service account
+func (s *ServiceAccountService) DeleteAPIKey(ctx context.Context, actorID, id, keyID string) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "ServiceAccountService.DeleteAPIKey")
+	defer span.End()
+
+	serviceAccount, err := s.GetManagedServiceAccount(ctx, actorID, id)
+	if err != nil {
+		return err
+	}
+
+	apiKey, err := s.apiKeyRepo.Get(ctx, keyID)
+	if err != nil {
+		return apierrors.NewInternalError("", err)
+	}
+	if apiKey == nil || apiKey.ServiceAccountID != serviceAccount.ID {
+		return apierrors.NewNotFoundError("API key not found", nil)
+	}
+
+	if err := s.apiKeyRepo.Delete(ctx, keyID); err != nil {
+		return apierrors.NewInternalError("", err)
+	}
+
+	s.emitAPIKeyEvent(ctx, APIKeyDeletedEvent, apiKey)
+
+	return nil
+}
+
+// listOwned retrieves the service accounts of the actor, or of an organization the actor can manage
+func (s *ServiceAccountService) listOwned(ctx context.Context, actorID, organizationID string) ([]*domain.ServiceAccount, error) {
+	if organizationID == "" {
+		serviceAccounts, err := s.serviceAccountRepo.ListByUserID(ctx, actorID)
+		if err != nil {
+			return nil, apierrors.NewInternalError("", err)
+		}
+		return serviceAccounts, nil
+	}
+
+	_, membership, err := s.organizationService.GetMembership(ctx, organizationID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !membership.CanManage() {
+		return nil, apierrors.NewForbiddenError("Only organization admins can manage service accounts", nil)
+	}
+
+	serviceAccounts, err := s.serviceAccountRepo.ListByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+	return serviceAccounts, nil
+}
+
+// delete removes a service account together with its API keys
+func (s *ServiceAccountService) delete(ctx context.Context, serviceAccount *domain.ServiceAccount, actorID string) error {
+	if err := s.apiKeyRepo.DeleteByServiceAccountID(ctx, serviceAccount.ID); err != nil {
+		return apierrors.NewInternalError("", err)
+	}
+	if err := s.serviceAccountRepo.Delete(ctx, serviceAccount.ID); err != nil {
+		return apierrors.NewInternalError("", err)
+	}
+
+	s.emitServiceAccountEvent(ctx, ServiceAccountDeletedEvent, serviceAccount, actorID)
+
+	return nil
+}
+
+// handleUserDeleted deletes the personal service accounts of a deleted user
+func (s *ServiceAccountService) handleUserDeleted(ctx context.Context, event events.Event) error {
+	userID := event.Metadata.UserID
+	if userID == "" {
+		return nil
+	}
+
+	serviceAccounts, err := s.serviceAccountRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.deleteAll(ctx, serviceAccounts, userID)
+}
+
+// handleOrganizationDeleted deletes the service accounts of a deleted organization
+func (s *ServiceAccountService) handleOrganizationDeleted(ctx context.Context, event events.Event) error {
+	organizationID := event.Metadata.Properties["organization_id"]
+	if organizationID == "" {
+		return nil
+	}
+
+	serviceAccounts, err := s.serviceAccountRepo.ListByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	return s.deleteAll(ctx, serviceAccounts, event.Metadata.UserID)
+}
+
+// deleteAll deletes service accounts on behalf of the given actor
+func (s *ServiceAccountService) deleteAll(ctx context.Context, serviceAccounts []*domain.ServiceAccount, actorID string) error {
+	for _, serviceAccount := range serviceAccounts {
+		if err := s.delete(ctx, serviceAccount, actorID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// emitServiceAccountEvent emits a service account-related event, attributed to the actor
+func (s *ServiceAccountService) emitServiceAccountEvent(
+	ctx context.Context,
+	eventType ServiceAccountEventType,
+	serviceAccount *domain.ServiceAccount,
+	actorID string,
+) {
+	metadata := events.Metadata{
+		UserID:  actorID,
+		TraceID: observability.GetTraceID(ctx),
+		SpanID:  observability.GetSpanID(ctx),
+		Properties: map[string]string{
+			"service_account_id": serviceAccount.ID,
+		},
+	}
+	if serviceAccount.OrganizationID != "" {
+		metadata.Properties["organization_id"] = serviceAccount.OrganizationID
+	}
+
+	event := events.NewEvent(events.EventType(eventType), serviceAccount, metadata)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		s.obs.Logger.Error(ctx, "Failed to publish service account event", zap.Error(err),
+			zap.String("event_type", string(eventType)),
+			zap.String("service_account_id", serviceAccount.ID),
+		)
+	}
+}
+
+// emitAPIKeyEvent emits an API key-related event for a key of a service account
+func (s *ServiceAccountService) emitAPIKeyEvent(ctx context.Context, eventType UserEventType, apiKey *domain.APIKey) {
+	metadata := events.Metadata{
+		UserID:  apiKey.UserID,
+		TraceID: observability.GetTraceID(ctx),
+		SpanID:  observability.GetSpanID(ctx),
+		Properties: map[string]string{
+			"api_key_id":         apiKey.ID,
+			"service_account_id": apiKey.ServiceAccountID,
+		},
+	}
+
+	event := events.NewEvent(events.EventType(eventType), apiKey, metadata)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		s.obs.Logger.Error(ctx, "Failed to publish API key event", zap.Error(err),
+			zap.String("event_type", string(eventType)),
+			zap.String("api_key_id", apiKey.ID),
+		)
+	}
+}
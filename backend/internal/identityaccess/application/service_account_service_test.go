@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/shared/apierrors"
+	"github.com/context-space/context-space/backend/internal/shared/events"
+	identityaccess_mocks "github.com/context-space/context-space/backend/internal/shared/testing/mocks/identityaccess"
+)
+
+func newTestObservabilityProvider(t *testing.T) *observability.ObservabilityProvider {
+	obs, _, err := observability.InitializeObservabilityProvider(context.Background(), &observability.LogConfig{
+		Level:       observability.ParseLogLevel("error"),
+		Format:      observability.ParseLogFormat("json"),
+		OutputPaths: []string{"stdout"},
+	}, &observability.TracingConfig{Enabled: false}, &observability.MetricsConfig{Enabled: false})
+	require.NoError(t, err)
+	return obs
+}
+
+// requireAPIErrorType asserts that err is an API error of the given type
+func requireAPIErrorType(t *testing.T, err error, expected apierrors.ErrorType) {
+	t.Helper()
+	var apiErr *apierrors.APIError
+	require.True(t, errors.As(err, &apiErr), "expected an API error, got %v", err)
+	assert.Equal(t, expected, apiErr.Type)
+}
+
+func TestServiceAccountServiceCreateAPIKeyExpiry(t *testing.T) {
+	tests := []struct {
+		name          string
+		expiresIn     time.Duration
+		expectedError apierrors.ErrorType
+	}{
+		{name: "WithinAYear", expiresIn: 90 * 24 * time.Hour},
+		{name: "JustUnderAYear", expiresIn: maxServiceAccountAPIKeyTTLDays*24*time.Hour - time.Hour},
+		{name: "Past", expiresIn: -time.Hour, expectedError: apierrors.ErrorTypeValidation},
+		{name: "Now", expiresIn: 0, expectedError: apierrors.ErrorTypeValidation},
+		{name: "BeyondAYear", expiresIn: maxServiceAccountAPIKeyTTLDays*24*time.Hour + time.Hour, expectedError: apierrors.ErrorTypeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			serviceAccountRepo := identityaccess_mocks.NewMockServiceAccountRepository(t)
+			apiKeyRepo := identityaccess_mocks.NewMockAPIKeyRepository(t)
+			service := NewServiceAccountService(serviceAccountRepo, apiKeyRepo, nil, events.NewBus(), newTestObservabilityProvider(t))
+
+			serviceAccount, err := domain.NewServiceAccount("user-1", "", "ci", "")
+			require.NoError(t, err)
+			serviceAccountRepo.EXPECT().Get(mock.Anything, serviceAccount.ID).Return(serviceAccount, nil)
+			if tt.expectedError == "" {
+				apiKeyRepo.EXPECT().ListByServiceAccountID(mock.Anything, serviceAccount.ID).Return(nil, nil)
+				apiKeyRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil)
+			}
+
+			expiresAt := time.Now().Add(tt.expiresIn)
+			apiKey, err := service.CreateAPIKey(ctx, "user-1", serviceAccount.ID, CreateServiceAccountAPIKeyInput{
+				Name:      "deploy",
+				Scopes:    []domain.APIKeyScope{domain.APIKeyScopeInvocations},
+				ExpiresAt: expiresAt,
+			})
+
+			if tt.expectedError != "" {
+				requireAPIErrorType(t, err, tt.expectedError)
+				assert.Nil(t, apiKey)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, apiKey.ExpiresAt)
+			assert.True(t, apiKey.ExpiresAt.Equal(expiresAt))
+			assert.Equal(t, serviceAccount.ID, apiKey.ServiceAccountID)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"time"
 
 	observability "github.com/context-space/cloud-observability"
 	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
@@ -27,6 +28,8 @@ const (
 	APIKeyDeactivatedEvent UserEventType = "apikey.deactivated"
 	// APIKeyDeletedEvent is emitted when an API key is deleted
 	APIKeyDeletedEvent UserEventType = "apikey.deleted"
+	// APIKeyUsedEvent is emitted when a request is authenticated with an API key
+	APIKeyUsedEvent UserEventType = "apikey.used"
 )
 
 const (
@@ -35,12 +38,13 @@ const (
 
 // UserService provides user-related application services
 type UserService struct {
-	userRepo          domain.UserRepository
-	userinfoRepo      domain.UserInfoRepository
-	apiKeyRepo        domain.APIKeyRepository
-	unitOfWorkFactory database.UnitOfWorkFactory
-	eventBus          *events.Bus
-	obs               *observability.ObservabilityProvider
+	userRepo           domain.UserRepository
+	userinfoRepo       domain.UserInfoRepository
+	apiKeyRepo         domain.APIKeyRepository
+	serviceAccountRepo domain.ServiceAccountRepository
+	unitOfWorkFactory  database.UnitOfWorkFactory
+	eventBus           *events.Bus
+	obs                *observability.ObservabilityProvider
 }
 
 // NewUserService creates a new UserService
@@ -48,17 +52,19 @@ func NewUserService(
 	userRepo domain.UserRepository,
 	userInfoRepo domain.UserInfoRepository,
 	apiKeyRepo domain.APIKeyRepository,
+	serviceAccountRepo domain.ServiceAccountRepository,
 	unitOfWorkFactory database.UnitOfWorkFactory,
 	eventBus *events.Bus,
 	observabilityProvider *observability.ObservabilityProvider,
 ) *UserService {
 	return &UserService{
-		userRepo:          userRepo,
-		userinfoRepo:      userInfoRepo,
-		apiKeyRepo:        apiKeyRepo,
-		unitOfWorkFactory: unitOfWorkFactory,
-		eventBus:          eventBus,
-		obs:               observabilityProvider,
+		userRepo:           userRepo,
+		userinfoRepo:       userInfoRepo,
+		apiKeyRepo:         apiKeyRepo,
+		serviceAccountRepo: serviceAccountRepo,
+		unitOfWorkFactory:  unitOfWorkFactory,
+		eventBus:           eventBus,
+		obs:                observabilityProvider,
 	}
 }
 
@@ -240,7 +246,8 @@ func (s *UserService) DeleteAPIKey(ctx context.Context, id string) error {
 	return nil
 }
 
-// ValidateAPIKey validates an API key
+// ValidateAPIKey validates an API key and returns the user it authenticates,
+// which is the principal of the service account for service account keys
 func (s *UserService) ValidateAPIKey(ctx context.Context, keyValue string) (*domain.User, *domain.APIKey, error) {
 	ctx, span := s.obs.Tracer.Start(ctx, "UserService.ValidateAPIKey")
 	defer span.End()
@@ -254,14 +261,14 @@ func (s *UserService) ValidateAPIKey(ctx context.Context, keyValue string) (*dom
 	if apiKey == nil {
 		return nil, nil, apierrors.NewUnauthorizedError("api key is invalid", nil)
 	}
+	if apiKey.IsExpired(time.Now()) {
+		return nil, nil, apierrors.NewUnauthorizedError("api key has expired", nil)
+	}
 
 	// Get associated user
-	user, err := s.userRepo.Get(ctx, apiKey.UserID)
+	user, err := s.apiKeyPrincipal(ctx, apiKey)
 	if err != nil {
-		return nil, nil, apierrors.NewInternalError("", err)
-	}
-	if user == nil {
-		return nil, nil, apierrors.NewNotFoundError("", err)
+		return nil, nil, err
 	}
 
 	// Update last used timestamp
@@ -270,9 +277,34 @@ func (s *UserService) ValidateAPIKey(ctx context.Context, keyValue string) (*dom
 		return nil, nil, apierrors.NewInternalError("", err)
 	}
 
+	s.emitAPIKeyEvent(ctx, APIKeyUsedEvent, apiKey)
+
 	return user, apiKey, nil
 }
 
+// apiKeyPrincipal returns the user an API key authenticates
+func (s *UserService) apiKeyPrincipal(ctx context.Context, apiKey *domain.APIKey) (*domain.User, error) {
+	if apiKey.ServiceAccountID != "" {
+		serviceAccount, err := s.serviceAccountRepo.Get(ctx, apiKey.ServiceAccountID)
+		if err != nil {
+			return nil, apierrors.NewInternalError("", err)
+		}
+		if serviceAccount == nil {
+			return nil, apierrors.NewUnauthorizedError("service account no longer exists", nil)
+		}
+		return serviceAccount.Principal(), nil
+	}
+
+	user, err := s.userRepo.Get(ctx, apiKey.UserID)
+	if err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+	if user == nil {
+		return nil, apierrors.NewNotFoundError("", err)
+	}
+	return user, nil
+}
+
 // ListAPIKeys retrieves API keys for a user with pagination
 func (s *UserService) ListAPIKeys(ctx context.Context, userID string) ([]*domain.APIKey, error) {
 	ctx, span := s.obs.Tracer.Start(ctx, "UserService.ListAPIKeys")
@@ -320,6 +352,12 @@ func (s *UserService) emitAPIKeyEvent(ctx context.Context, eventType UserEventTy
 			"api_key_id": apiKey.ID,
 		},
 	}
+	if apiKey.OrganizationID != "" {
+		metadata.Properties["organization_id"] = apiKey.OrganizationID
+	}
+	if apiKey.ServiceAccountID != "" {
+		metadata.Properties["service_account_id"] = apiKey.ServiceAccountID
+	}
 
 	// Create and publish event
 	event := events.NewEvent(events.EventType(eventType), apiKey, metadata)
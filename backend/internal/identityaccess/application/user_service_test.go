@@ -0,0 +1,59 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/shared/apierrors"
+	"github.com/context-space/context-space/backend/internal/shared/events"
+	identityaccess_mocks "github.com/context-space/context-space/backend/internal/shared/testing/mocks/identityaccess"
+)
+
+func TestUserServiceValidateAPIKeyExpiry(t *testing.T) {
+	tests := []struct {
+		name          string
+		expiresIn     time.Duration
+		expectedError apierrors.ErrorType
+	}{
+		{name: "Valid", expiresIn: time.Hour},
+		{name: "Expired", expiresIn: -time.Second, expectedError: apierrors.ErrorTypeUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			apiKeyRepo := identityaccess_mocks.NewMockAPIKeyRepository(t)
+			serviceAccountRepo := identityaccess_mocks.NewMockServiceAccountRepository(t)
+			service := NewUserService(nil, nil, apiKeyRepo, serviceAccountRepo, nil, events.NewBus(), newTestObservabilityProvider(t))
+
+			serviceAccount, err := domain.NewServiceAccount("user-1", "", "ci", "")
+			require.NoError(t, err)
+			expiresAt := time.Now().Add(tt.expiresIn)
+			apiKey := domain.NewServiceAccountAPIKey("user-1", serviceAccount.ID, "deploy", "", nil, &expiresAt)
+			apiKeyRepo.EXPECT().GetByKeyValue(mock.Anything, apiKey.KeyValue).Return(apiKey, nil)
+			if tt.expectedError == "" {
+				serviceAccountRepo.EXPECT().Get(mock.Anything, serviceAccount.ID).Return(serviceAccount, nil)
+				apiKeyRepo.EXPECT().Update(mock.Anything, apiKey).Return(nil)
+			}
+
+			user, validated, err := service.ValidateAPIKey(ctx, apiKey.KeyValue)
+
+			if tt.expectedError != "" {
+				requireAPIErrorType(t, err, tt.expectedError)
+				assert.Nil(t, user)
+				assert.Nil(t, validated)
+				assert.Nil(t, apiKey.LastUsed, "an expired key must not be marked as used")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, serviceAccount.Principal().ID, user.ID)
+			assert.Equal(t, apiKey.ID, validated.ID)
+		})
+	}
+}
@@ -3,23 +3,54 @@ package domain
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"slices"
 	"time"
 
 	"github.com/context-space/context-space/backend/internal/shared/utils"
 	"github.com/google/uuid"
 )
 
+// APIKeyScope restricts the endpoints an API key can be used with
+type APIKeyScope string
+
+const (
+	// APIKeyScopeMCP allows listing and calling MCP tools
+	APIKeyScopeMCP APIKeyScope = "mcp"
+	// APIKeyScopeInvocations allows invoking operations and reading the invocation history
+	APIKeyScopeInvocations APIKeyScope = "invocations"
+	// APIKeyScopeCredentials allows listing and connecting credentials
+	APIKeyScopeCredentials APIKeyScope = "credentials"
+	// APIKeyScopeWebhooks allows reading and streaming received webhook events
+	APIKeyScopeWebhooks APIKeyScope = "webhooks"
+)
+
+// IsValid returns true if the scope is a known API key scope
+func (s APIKeyScope) IsValid() bool {
+	switch s {
+	case APIKeyScopeMCP, APIKeyScopeInvocations, APIKeyScopeCredentials, APIKeyScopeWebhooks:
+		return true
+	}
+	return false
+}
+
 // APIKey represents an API key for authentication
 type APIKey struct {
-	ID          string
-	UserID      string
-	KeyValue    string
-	Name        string
-	Description string
-	LastUsed    *time.Time
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   *time.Time
+	ID     string
+	UserID string
+	// OrganizationID is set for keys that act on behalf of an organization, empty for personal keys
+	OrganizationID string
+	// ServiceAccountID is set for keys that authenticate a service account, UserID is then the user who created the key
+	ServiceAccountID string
+	KeyValue         string
+	Name             string
+	Description      string
+	// Scopes restricts the key to some endpoints, an empty list allows every endpoint open to API keys
+	Scopes    []APIKeyScope
+	ExpiresAt *time.Time
+	LastUsed  *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
 }
 
 // NewAPIKey creates a new API key with default values
@@ -35,6 +66,32 @@ func NewAPIKey(userID, name, description string) *APIKey {
 	}
 }
 
+// NewOrganizationAPIKey creates a new API key acting on behalf of an organization, created by the given user
+func NewOrganizationAPIKey(userID, organizationID, name, description string) *APIKey {
+	apiKey := NewAPIKey(userID, name, description)
+	apiKey.OrganizationID = organizationID
+	return apiKey
+}
+
+// NewServiceAccountAPIKey creates a new API key authenticating a service account, created by the given user
+func NewServiceAccountAPIKey(userID, serviceAccountID, name, description string, scopes []APIKeyScope, expiresAt *time.Time) *APIKey {
+	apiKey := NewAPIKey(userID, name, description)
+	apiKey.ServiceAccountID = serviceAccountID
+	apiKey.Scopes = scopes
+	apiKey.ExpiresAt = expiresAt
+	return apiKey
+}
+
+// IsExpired returns true if the key has an expiry that has passed
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// HasScope returns true if the key can be used with the endpoints of the given scope
+func (k *APIKey) HasScope(scope APIKeyScope) bool {
+	return len(k.Scopes) == 0 || slices.Contains(k.Scopes, scope)
+}
+
 // UpdateLastUsed updates the last used timestamp
 func (k *APIKey) UpdateLastUsed() {
 	now := time.Now()
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAPIKeyIsExpired(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name      string
+		expiresAt *time.Time
+		expected  bool
+	}{
+		{name: "NoExpiry", expiresAt: nil, expected: false},
+		{name: "ExpiresLater", expiresAt: &future, expected: false},
+		{name: "ExpiresNow", expiresAt: &now, expected: true},
+		{name: "Expired", expiresAt: &past, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKey := NewServiceAccountAPIKey("user-1", "sa-1", "ci", "", nil, tt.expiresAt)
+			if got := apiKey.IsExpired(now); got != tt.expected {
+				t.Errorf("IsExpired() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditAction is a security-relevant action recorded in the audit log
+type AuditAction string
+
+const (
+	// AuditActionCredentialCreated is recorded when a provider credential is stored
+	AuditActionCredentialCreated AuditAction = "credential.created"
+	// AuditActionCredentialDeleted is recorded when a provider credential is deleted
+	AuditActionCredentialDeleted AuditAction = "credential.deleted"
+	// AuditActionCredentialInvalidated is recorded when a provider rejects a credential and it is invalidated
+	AuditActionCredentialInvalidated AuditAction = "credential.invalidated"
+	// AuditActionOAuthConsent is recorded when an OAuth authorization flow completes or fails
+	AuditActionOAuthConsent AuditAction = "oauth.consent"
+	// AuditActionAPIKeyCreated is recorded when an API key is created
+	AuditActionAPIKeyCreated AuditAction = "apikey.created"
+	// AuditActionAPIKeyDeleted is recorded when an API key is deleted
+	AuditActionAPIKeyDeleted AuditAction = "apikey.deleted"
+	// AuditActionAPIKeyUsed is recorded when a request is authenticated with an API key, at most once an hour per key
+	AuditActionAPIKeyUsed AuditAction = "apikey.used"
+	// AuditActionServiceAccountCreated is recorded when a service account is created
+	AuditActionServiceAccountCreated AuditAction = "service_account.created"
+	// AuditActionServiceAccountDeleted is recorded when a service account is deleted
+	AuditActionServiceAccountDeleted AuditAction = "service_account.deleted"
+	// AuditActionAccountDeleted is recorded when a user account is deleted
+	AuditActionAccountDeleted AuditAction = "account.deleted"
+	// AuditActionProviderCreated is recorded when a provider is created
+	AuditActionProviderCreated AuditAction = "provider.created"
+	// AuditActionProviderUpdated is recorded when a provider is updated, activated or deactivated
+	AuditActionProviderUpdated AuditAction = "provider.updated"
+	// AuditActionProviderDeleted is recorded when a provider is deleted
+	AuditActionProviderDeleted AuditAction = "provider.deleted"
+)
+
+// AuditOutcome is the outcome of an audited action
+type AuditOutcome string
+
+const (
+	// AuditOutcomeSuccess marks an action that was performed
+	AuditOutcomeSuccess AuditOutcome = "success"
+	// AuditOutcomeFailure marks an action that was attempted but failed
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+// GenesisAuditHash is the previous hash of the first entry of an audit chain
+const GenesisAuditHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// AuditEntry is an append-only record of a security-relevant action
+// The entries of a user form a hash chain, each entry hashes its content together with the hash of its predecessor
+type AuditEntry struct {
+	ID string
+	// UserID is the user whose audit log the entry belongs to, empty for system entries
+	UserID string
+	// Sequence is the position of the entry in the chain of its user, starting at 1
+	Sequence     int64
+	ActorID      string
+	AuthType     string
+	IPAddress    string
+	UserAgent    string
+	Action       AuditAction
+	ResourceType string
+	ResourceID   string
+	Outcome      AuditOutcome
+	Metadata     map[string]string
+	PrevHash     string
+	Hash         string
+	CreatedAt    time.Time
+}
+
+// NewAuditEntry creates a new audit entry, it is chained when it is appended to the audit log
+func NewAuditEntry(userID string, action AuditAction, resourceType, resourceID string, outcome AuditOutcome) *AuditEntry {
+	return &AuditEntry{
+		ID:           uuid.New().String(),
+		UserID:       userID,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Outcome:      outcome,
+		Metadata:     make(map[string]string),
+		// Postgres stores microseconds, truncate so that the hash can be recomputed from the stored entry
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+// Link appends the entry to the chain ending with previous, nil for the first entry of a chain
+func (e *AuditEntry) Link(previous *AuditEntry) {
+	if previous == nil {
+		e.Sequence = 1
+		e.PrevHash = GenesisAuditHash
+	} else {
+		e.Sequence = previous.Sequence + 1
+		e.PrevHash = previous.Hash
+	}
+	e.Hash = e.ComputeHash()
+}
+
+// Verify returns true if the entry is unchanged and directly follows previous in its chain
+func (e *AuditEntry) Verify(previous *AuditEntry) bool {
+	expectedSequence := int64(1)
+	expectedPrevHash := GenesisAuditHash
+	if previous != nil {
+		expectedSequence = previous.Sequence + 1
+		expectedPrevHash = previous.Hash
+	}
+
+	return e.Sequence == expectedSequence && e.PrevHash == expectedPrevHash && e.Hash == e.ComputeHash()
+}
+
+// ComputeHash returns the hex encoded SHA-256 hash of the entry content and the previous hash
+func (e *AuditEntry) ComputeHash() string {
+	// Struct fields are encoded in declaration order and map keys sorted, so the encoding is canonical
+	content, _ := json.Marshal(struct {
+		ID           string            `json:"id"`
+		UserID       string            `json:"user_id"`
+		Sequence     int64             `json:"sequence"`
+		ActorID      string            `json:"actor_id"`
+		AuthType     string            `json:"auth_type"`
+		IPAddress    string            `json:"ip_address"`
+		UserAgent    string            `json:"user_agent"`
+		Action       string            `json:"action"`
+		ResourceType string            `json:"resource_type"`
+		ResourceID   string            `json:"resource_id"`
+		Outcome      string            `json:"outcome"`
+		Metadata     map[string]string `json:"metadata"`
+		CreatedAt    string            `json:"created_at"`
+		PrevHash     string            `json:"prev_hash"`
+	}{
+		ID:           e.ID,
+		UserID:       e.UserID,
+		Sequence:     e.Sequence,
+		ActorID:      e.ActorID,
+		AuthType:     e.AuthType,
+		IPAddress:    e.IPAddress,
+		UserAgent:    e.UserAgent,
+		Action:       string(e.Action),
+		ResourceType: e.ResourceType,
+		ResourceID:   e.ResourceID,
+		Outcome:      string(e.Outcome),
+		Metadata:     e.Metadata,
+		CreatedAt:    e.CreatedAt.UTC().Format(time.RFC3339Nano),
+		PrevHash:     e.PrevHash,
+	})
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditFilter narrows down the audit entries of a user, zero values match everything and To is exclusive
+type AuditFilter struct {
+	Action       AuditAction
+	ResourceType string
+	ResourceID   string
+	Outcome      AuditOutcome
+	From         *time.Time
+	To           *time.Time
+}
+
+// Matches returns true if the entry matches all criteria of the filter
+func (f AuditFilter) Matches(entry *AuditEntry) bool {
+	if f.Action != "" && entry.Action != f.Action {
+		return false
+	}
+	if f.ResourceType != "" && entry.ResourceType != f.ResourceType {
+		return false
+	}
+	if f.ResourceID != "" && entry.ResourceID != f.ResourceID {
+		return false
+	}
+	if f.Outcome != "" && entry.Outcome != f.Outcome {
+		return false
+	}
+	if f.From != nil && entry.CreatedAt.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && !entry.CreatedAt.Before(*f.To) {
+		return false
+	}
+	return true
+}
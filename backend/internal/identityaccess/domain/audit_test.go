@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestAuditChain returns a linked chain of n entries of one user
+func newTestAuditChain(n int) []*AuditEntry {
+	entries := make([]*AuditEntry, 0, n)
+	var previous *AuditEntry
+	for i := 0; i < n; i++ {
+		entry := NewAuditEntry("user-1", AuditActionAPIKeyCreated, "api_key", "key-1", AuditOutcomeSuccess)
+		entry.ActorID = "user-1"
+		entry.Metadata["name"] = "deploy"
+		entry.Link(previous)
+		entries = append(entries, entry)
+		previous = entry
+	}
+	return entries
+}
+
+// firstInvalid returns the sequence of the first entry failing verification, 0 if the chain is valid
+func firstInvalid(entries []*AuditEntry) int64 {
+	var previous *AuditEntry
+	for _, entry := range entries {
+		if !entry.Verify(previous) {
+			return entry.Sequence
+		}
+		previous = entry
+	}
+	return 0
+}
+
+func TestAuditEntryLink(t *testing.T) {
+	entries := newTestAuditChain(3)
+
+	if entries[0].Sequence != 1 || entries[0].PrevHash != GenesisAuditHash {
+		t.Errorf("first entry = sequence %d, previous hash %s, want sequence 1 after the genesis hash", entries[0].Sequence, entries[0].PrevHash)
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].Sequence != int64(i+1) {
+			t.Errorf("entry %d sequence = %d, want %d", i, entries[i].Sequence, i+1)
+		}
+		if entries[i].PrevHash != entries[i-1].Hash {
+			t.Errorf("entry %d does not link to the hash of its predecessor", i)
+		}
+	}
+	if entries[1].Hash == entries[2].Hash {
+		t.Error("entries with the same content share a hash")
+	}
+	if sequence := firstInvalid(entries); sequence != 0 {
+		t.Errorf("linked chain fails verification at sequence %d", sequence)
+	}
+}
+
+func TestAuditEntryVerifyDetectsTampering(t *testing.T) {
+	tests := []struct {
+		name     string
+		tamper   func(entries []*AuditEntry) []*AuditEntry
+		expected int64
+	}{
+		{
+			name:     "Unchanged",
+			tamper:   func(entries []*AuditEntry) []*AuditEntry { return entries },
+			expected: 0,
+		},
+		{
+			name: "AlteredAction",
+			tamper: func(entries []*AuditEntry) []*AuditEntry {
+				entries[2].Action = AuditActionAPIKeyDeleted
+				return entries
+			},
+			expected: 3,
+		},
+		{
+			name: "AlteredOutcome",
+			tamper: func(entries []*AuditEntry) []*AuditEntry {
+				entries[0].Outcome = AuditOutcomeFailure
+				return entries
+			},
+			expected: 1,
+		},
+		{
+			name: "AlteredMetadata",
+			tamper: func(entries []*AuditEntry) []*AuditEntry {
+				entries[3].Metadata["name"] = "other"
+				return entries
+			},
+			expected: 4,
+		},
+		{
+			name: "AlteredTimestamp",
+			tamper: func(entries []*AuditEntry) []*AuditEntry {
+				entries[1].CreatedAt = entries[1].CreatedAt.Add(-time.Hour)
+				return entries
+			},
+			expected: 2,
+		},
+		{
+			name: "RehashedEntry",
+			tamper: func(entries []*AuditEntry) []*AuditEntry {
+				// Rehashing an altered entry breaks the link of its successor
+				entries[1].ActorID = "attacker"
+				entries[1].Hash = entries[1].ComputeHash()
+				return entries
+			},
+			expected: 3,
+		},
+		{
+			name: "RemovedEntry",
+			tamper: func(entries []*AuditEntry) []*AuditEntry {
+				return append(entries[:2], entries[3:]...)
+			},
+			expected: 4,
+		},
+		{
+			name: "RemovedFirstEntry",
+			tamper: func(entries []*AuditEntry) []*AuditEntry {
+				return entries[1:]
+			},
+			expected: 2,
+		},
+		{
+			name: "ReorderedEntries",
+			tamper: func(entries []*AuditEntry) []*AuditEntry {
+				entries[2], entries[3] = entries[3], entries[2]
+				return entries
+			},
+			expected: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := tt.tamper(newTestAuditChain(5))
+			if sequence := firstInvalid(entries); sequence != tt.expected {
+				t.Errorf("first invalid sequence = %d, want %d", sequence, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "context"
+
+// Identity is a principal authenticated by an external identity provider
+type Identity struct {
+	// Provider is the name of the identity provider that validated the token
+	Provider string
+	// Subject uniquely identifies the principal within the identity provider, stored as the user's SupID
+	Subject     string
+	Email       string
+	IsAnonymous bool
+	// Claims holds all claims of the validated token
+	Claims map[string]interface{}
+}
+
+// UserProfile is the information used to provision a user on first sign in
+type UserProfile struct {
+	Email       string
+	IsAnonymous bool
+	Metadata    map[string]interface{}
+}
+
+// IdentityProvider validates bearer tokens issued by an external identity provider
+type IdentityProvider interface {
+	// Name returns the name of the identity provider
+	Name() string
+
+	// ValidateToken validates a bearer token and returns the identity it was issued for
+	ValidateToken(ctx context.Context, tokenString string) (*Identity, error)
+
+	// GetUserProfile returns the profile used to provision a user for an identity
+	GetUserProfile(ctx context.Context, identity *Identity) (*UserProfile, error)
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationRole is the role of a member within an organization
+type OrganizationRole string
+
+const (
+	// OrganizationRoleOwner can manage members, credentials, API keys and delete the organization
+	OrganizationRoleOwner OrganizationRole = "owner"
+	// OrganizationRoleAdmin can manage members, credentials and API keys
+	OrganizationRoleAdmin OrganizationRole = "admin"
+	// OrganizationRoleMember can invoke operations with the organization's credentials
+	OrganizationRoleMember OrganizationRole = "member"
+)
+
+// organizationRoleRanks orders the roles from least to most privileged
+var organizationRoleRanks = map[OrganizationRole]int{
+	OrganizationRoleMember: 1,
+	OrganizationRoleAdmin:  2,
+	OrganizationRoleOwner:  3,
+}
+
+// IsValid returns true if the role is a known organization role
+func (r OrganizationRole) IsValid() bool {
+	_, ok := organizationRoleRanks[r]
+	return ok
+}
+
+// AtLeast returns true if the role grants at least the privileges of the given role
+func (r OrganizationRole) AtLeast(role OrganizationRole) bool {
+	return organizationRoleRanks[r] >= organizationRoleRanks[role]
+}
+
+// Organization groups users that share provider credentials and API keys
+type Organization struct {
+	ID        string
+	Name      string
+	CreatedBy string
+	// SharedCredentialRole is the minimum role allowed to invoke operations with the organization's credentials
+	SharedCredentialRole OrganizationRole
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	DeletedAt            *time.Time
+}
+
+// NewOrganization creates a new organization whose credentials every member may use
+func NewOrganization(name, createdBy string) *Organization {
+	return &Organization{
+		ID:                   uuid.New().String(),
+		Name:                 name,
+		CreatedBy:            createdBy,
+		SharedCredentialRole: OrganizationRoleMember,
+		CreatedAt:            time.Now(),
+		UpdatedAt:            time.Now(),
+	}
+}
+
+// AllowsSharedCredentials returns true if a member with the given role may use the organization's credentials
+func (o *Organization) AllowsSharedCredentials(role OrganizationRole) bool {
+	return role.AtLeast(o.SharedCredentialRole)
+}
+
+// Membership links a user to an organization with a role
+type Membership struct {
+	ID             string
+	OrganizationID string
+	UserID         string
+	Role           OrganizationRole
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewMembership creates a new membership
+func NewMembership(organizationID, userID string, role OrganizationRole) *Membership {
+	return &Membership{
+		ID:             uuid.New().String(),
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           role,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+}
+
+// CanManage returns true if the member can manage the organization's members, credentials and API keys
+func (m *Membership) CanManage() bool {
+	return m.Role.AtLeast(OrganizationRoleAdmin)
+}
@@ -54,9 +54,18 @@ type APIKeyRepository interface {
 	// GetByKeyValue retrieves an API key by its value
 	GetByKeyValue(ctx context.Context, value string) (*APIKey, error)
 
-	// ListByUserID retrieves API keys for a user with pagination
+	// ListByUserID retrieves the personal API keys of a user
 	ListByUserID(ctx context.Context, userID string) ([]*APIKey, error)
 
+	// ListByOrganizationID retrieves the API keys of an organization
+	ListByOrganizationID(ctx context.Context, organizationID string) ([]*APIKey, error)
+
+	// ListByServiceAccountID retrieves the API keys of a service account
+	ListByServiceAccountID(ctx context.Context, serviceAccountID string) ([]*APIKey, error)
+
+	// DeleteByServiceAccountID soft-deletes all API keys of a service account
+	DeleteByServiceAccountID(ctx context.Context, serviceAccountID string) error
+
 	// Create creates a new API key
 	Create(ctx context.Context, apiKey *APIKey) error
 
@@ -66,3 +75,78 @@ type APIKeyRepository interface {
 	// Delete soft-deletes an API key
 	Delete(ctx context.Context, id string) error
 }
+
+// ServiceAccountRepository defines the interface for service account data access
+type ServiceAccountRepository interface {
+	// Get retrieves a service account by ID
+	Get(ctx context.Context, id string) (*ServiceAccount, error)
+
+	// ListByUserID retrieves the service accounts owned by a user
+	ListByUserID(ctx context.Context, userID string) ([]*ServiceAccount, error)
+
+	// ListByOrganizationID retrieves the service accounts owned by an organization
+	ListByOrganizationID(ctx context.Context, organizationID string) ([]*ServiceAccount, error)
+
+	// Create creates a new service account
+	Create(ctx context.Context, serviceAccount *ServiceAccount) error
+
+	// Update updates an existing service account
+	Update(ctx context.Context, serviceAccount *ServiceAccount) error
+
+	// Delete soft-deletes a service account
+	Delete(ctx context.Context, id string) error
+}
+
+// OrganizationRepository defines the interface for organization data access
+type OrganizationRepository interface {
+	// Get retrieves an organization by ID
+	Get(ctx context.Context, id string) (*Organization, error)
+
+	// ListByUserID retrieves the organizations a user is a member of
+	ListByUserID(ctx context.Context, userID string) ([]*Organization, error)
+
+	// Create creates a new organization
+	Create(ctx context.Context, organization *Organization) error
+
+	// Update updates an existing organization
+	Update(ctx context.Context, organization *Organization) error
+
+	// Delete soft-deletes an organization
+	Delete(ctx context.Context, id string) error
+}
+
+// MembershipRepository defines the interface for organization membership data access
+type MembershipRepository interface {
+	// Get retrieves the membership of a user in an organization
+	Get(ctx context.Context, organizationID, userID string) (*Membership, error)
+
+	// ListByOrganizationID retrieves the memberships of an organization
+	ListByOrganizationID(ctx context.Context, organizationID string) ([]*Membership, error)
+
+	// CountByRole returns the number of members of an organization with the given role
+	CountByRole(ctx context.Context, organizationID string, role OrganizationRole) (int64, error)
+
+	// Create creates a new membership
+	Create(ctx context.Context, membership *Membership) error
+
+	// Update updates the role of a membership
+	Update(ctx context.Context, membership *Membership) error
+
+	// Delete deletes the membership of a user in an organization
+	Delete(ctx context.Context, organizationID, userID string) error
+
+	// DeleteByOrganizationID deletes all memberships of an organization
+	DeleteByOrganizationID(ctx context.Context, organizationID string) error
+}
+
+// AuditRepository defines the interface for the append-only audit log
+type AuditRepository interface {
+	// Append links the entry to the end of the chain of its user and stores it
+	Append(ctx context.Context, entry *AuditEntry) error
+
+	// List retrieves the entries of a user matching the filter, newest first, and the total number of matches
+	List(ctx context.Context, userID string, filter AuditFilter, offset, limit int) ([]*AuditEntry, int64, error)
+
+	// ListChain retrieves the entries of a user in chain order, starting after the given sequence
+	ListChain(ctx context.Context, userID string, afterSequence int64, limit int) ([]*AuditEntry, error)
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned when validating a service account
+var (
+	ErrServiceAccountNameRequired = errors.New("service account name is required")
+	ErrServiceAccountNameTooLong  = errors.New("service account name must be at most 100 characters")
+)
+
+const maxServiceAccountNameLength = 100
+
+// ServiceAccount is a non-human principal, such as a CI pipeline or a backend job, owned by a user or an organization
+// It cannot log in interactively, it authenticates with its own API keys and holds its own credentials,
+// its invocations and quotas are counted separately from the ones of its owner
+type ServiceAccount struct {
+	ID          string
+	Name        string
+	Description string
+	// CreatedBy is the user who created the service account, its owner unless OrganizationID is set
+	CreatedBy string
+	// OrganizationID is set for service accounts owned by an organization and managed by its admins
+	OrganizationID string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      *time.Time
+}
+
+// NewServiceAccount creates a new service account, owned by the organization if organizationID is not empty
+func NewServiceAccount(createdBy, organizationID, name, description string) (*ServiceAccount, error) {
+	if err := validateServiceAccountName(name); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &ServiceAccount{
+		ID:             uuid.New().String(),
+		Name:           name,
+		Description:    description,
+		CreatedBy:      createdBy,
+		OrganizationID: organizationID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// Update changes the name and description of the service account
+func (a *ServiceAccount) Update(name, description string) error {
+	if err := validateServiceAccountName(name); err != nil {
+		return err
+	}
+
+	a.Name = name
+	a.Description = description
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+// Principal returns the user the requests of the service account are made as
+// Its ID is the service account ID so that credentials, invocations and quotas are kept apart from the owner's
+func (a *ServiceAccount) Principal() *User {
+	return &User{
+		ID:               a.ID,
+		IsServiceAccount: true,
+		CreatedAt:        a.CreatedAt,
+		UpdatedAt:        a.UpdatedAt,
+	}
+}
+
+// validateServiceAccountName checks the name of a service account
+func validateServiceAccountName(name string) error {
+	if name == "" {
+		return ErrServiceAccountNameRequired
+	}
+	if len(name) > maxServiceAccountNameLength {
+		return ErrServiceAccountNameTooLong
+	}
+	return nil
+}
@@ -8,13 +8,16 @@ import (
 
 // User represents a user in the system
 type User struct {
-	ID          string
+	ID string
+	// SupID is the subject of the user at the identity provider
 	SupID       string
 	Email       string
 	IsAnonymous bool
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   *time.Time
+	// IsServiceAccount is set for the principal of a service account, whose ID is the service account ID
+	IsServiceAccount bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        *time.Time
 }
 
 func NewUser(supID, email string, isAnonymous bool) *User {
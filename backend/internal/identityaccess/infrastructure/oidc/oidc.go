@@ -0,0 +1,341 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// ProviderName is the name of the generic OpenID Connect identity provider
+const ProviderName = "oidc"
+
+const (
+	// discoveryPath is appended to the issuer URL to fetch the provider metadata
+	discoveryPath = "/.well-known/openid-configuration"
+	// minKeyRefreshInterval throttles JWKS refreshes triggered by tokens signed with an unknown key
+	// and failed refreshes while the provider is unreachable
+	minKeyRefreshInterval = time.Minute
+	// defaultKeyCacheTTL is how long the JWKS is cached when no TTL is configured
+	defaultKeyCacheTTL = time.Hour
+)
+
+// defaultAllowedAlgorithms are the asymmetric signing algorithms accepted when none are configured
+var defaultAllowedAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// ClaimMapping names the token claims identities are built from, nested claims use dot-separated paths
+type ClaimMapping struct {
+	Subject string
+	Email   string
+	// EmailVerified is a boolean claim, the email is ignored when it is present and false
+	EmailVerified string
+	// Anonymous is an optional boolean claim marking anonymous principals
+	Anonymous string
+}
+
+// Config holds the configuration of a generic OpenID Connect identity provider
+type Config struct {
+	IssuerURL string
+	// Audiences are the accepted aud values, at least one is required
+	Audiences         []string
+	AllowedAlgorithms []string
+	KeyCacheTTL       time.Duration
+	ClockSkew         time.Duration
+	Claims            ClaimMapping
+}
+
+// discoveryDocument holds the provider metadata used to validate tokens
+type discoveryDocument struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// Provider validates ID and access tokens of any OpenID Connect compliant identity provider
+type Provider struct {
+	config *Config
+	client *http.Client
+	obs    *observability.ObservabilityProvider
+
+	// fetches shares the metadata and JWKS fetches of concurrent requests
+	fetches singleflight.Group
+
+	mu        sync.Mutex
+	discovery *discoveryDocument
+	keys      map[string]interface{}
+	// keysFetchedAt is the time of the last successful JWKS fetch, keysAttemptedAt of the last attempt
+	keysFetchedAt   time.Time
+	keysAttemptedAt time.Time
+	keysError       error
+}
+
+// Ensure Provider implements the identity provider interface
+var _ domain.IdentityProvider = (*Provider)(nil)
+
+// NewProvider creates a new OpenID Connect identity provider, the metadata is discovered on first use
+func NewProvider(config *Config, observabilityProvider *observability.ObservabilityProvider) (*Provider, error) {
+	if config == nil {
+		return nil, errors.New("oidc config cannot be nil")
+	}
+	if config.IssuerURL == "" {
+		return nil, errors.New("oidc issuer URL is required")
+	}
+	// Without an audience, tokens the identity provider issued to any of its clients would be accepted
+	if len(config.Audiences) == 0 {
+		return nil, errors.New("oidc audiences are required")
+	}
+	if len(config.AllowedAlgorithms) == 0 {
+		config.AllowedAlgorithms = defaultAllowedAlgorithms
+	}
+	if config.KeyCacheTTL <= 0 {
+		config.KeyCacheTTL = defaultKeyCacheTTL
+	}
+	if config.Claims.Subject == "" {
+		config.Claims.Subject = "sub"
+	}
+	if config.Claims.Email == "" {
+		config.Claims.Email = "email"
+	}
+
+	return &Provider{
+		config: config,
+		client: &http.Client{Timeout: 10 * time.Second},
+		obs:    observabilityProvider,
+	}, nil
+}
+
+// Name returns the name of the identity provider
+func (p *Provider) Name() string {
+	return ProviderName
+}
+
+// ValidateToken validates the signature, issuer, audience and lifetime of a token and maps its claims to an identity
+func (p *Provider) ValidateToken(ctx context.Context, tokenString string) (*domain.Identity, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.getKey(ctx, discovery, kid)
+	},
+		jwt.WithValidMethods(p.config.AllowedAlgorithms),
+		jwt.WithIssuer(discovery.Issuer),
+		jwt.WithLeeway(p.config.ClockSkew),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("token validation failed: %w", err)
+	}
+
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	if err := p.checkAudience(claims); err != nil {
+		return nil, err
+	}
+
+	return p.mapIdentity(claims)
+}
+
+// GetUserProfile provisions users from the claims of their token, no user info endpoint is called
+func (p *Provider) GetUserProfile(ctx context.Context, identity *domain.Identity) (*domain.UserProfile, error) {
+	return &domain.UserProfile{
+		Email:       identity.Email,
+		IsAnonymous: identity.IsAnonymous,
+		Metadata:    identity.Claims,
+	}, nil
+}
+
+// checkAudience verifies that the token was issued for one of the configured audiences
+func (p *Provider) checkAudience(claims jwt.MapClaims) error {
+	audiences, err := claims.GetAudience()
+	if err != nil {
+		return fmt.Errorf("invalid audience claim: %w", err)
+	}
+
+	for _, audience := range audiences {
+		for _, allowed := range p.config.Audiences {
+			if audience == allowed {
+				return nil
+			}
+		}
+	}
+
+	return errors.New("token audience is not allowed")
+}
+
+// mapIdentity builds an identity from the claims using the configured claim mapping
+func (p *Provider) mapIdentity(claims jwt.MapClaims) (*domain.Identity, error) {
+	subject, _ := lookupClaim(claims, p.config.Claims.Subject).(string)
+	if subject == "" {
+		return nil, fmt.Errorf("token has no %s claim", p.config.Claims.Subject)
+	}
+
+	email, _ := lookupClaim(claims, p.config.Claims.Email).(string)
+	if p.config.Claims.EmailVerified != "" {
+		if verified, ok := lookupClaim(claims, p.config.Claims.EmailVerified).(bool); ok && !verified {
+			email = ""
+		}
+	}
+
+	isAnonymous := false
+	if p.config.Claims.Anonymous != "" {
+		isAnonymous, _ = lookupClaim(claims, p.config.Claims.Anonymous).(bool)
+	}
+
+	return &domain.Identity{
+		Provider:    ProviderName,
+		Subject:     subject,
+		Email:       email,
+		IsAnonymous: isAnonymous,
+		Claims:      claims,
+	}, nil
+}
+
+// getDiscovery returns the cached provider metadata, fetching it on first use
+// Concurrent callers share one fetch, which runs without holding the lock
+func (p *Provider) getDiscovery(ctx context.Context) (*discoveryDocument, error) {
+	p.mu.Lock()
+	discovery := p.discovery
+	p.mu.Unlock()
+	if discovery != nil {
+		return discovery, nil
+	}
+
+	result, err, _ := p.fetches.Do("discovery", func() (interface{}, error) {
+		return p.fetchDiscovery(context.WithoutCancel(ctx))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*discoveryDocument), nil
+}
+
+// fetchDiscovery fetches and caches the provider metadata
+func (p *Provider) fetchDiscovery(ctx context.Context) (*discoveryDocument, error) {
+	issuerURL := strings.TrimSuffix(p.config.IssuerURL, "/")
+
+	var discovery discoveryDocument
+	if err := getJSON(ctx, p.client, issuerURL+discoveryPath, &discovery); err != nil {
+		return nil, fmt.Errorf("failed to discover OIDC provider metadata: %w", err)
+	}
+
+	// The issuer of the metadata must match the configured one (OpenID Connect Discovery 1.0, section 4.3)
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuerURL {
+		return nil, fmt.Errorf("discovered issuer %q does not match configured issuer %q", discovery.Issuer, p.config.IssuerURL)
+	}
+	if discovery.JWKSURI == "" {
+		return nil, errors.New("OIDC provider metadata has no jwks_uri")
+	}
+
+	p.mu.Lock()
+	p.discovery = &discovery
+	p.mu.Unlock()
+	return &discovery, nil
+}
+
+// getKey returns the signing key with the given ID, refreshing the cached JWKS when it expired
+// or when the key is unknown because the provider rotated its keys
+func (p *Provider) getKey(ctx context.Context, discovery *discoveryDocument, kid string) (interface{}, error) {
+	p.mu.Lock()
+	keys, keysFetchedAt := p.keys, p.keysFetchedAt
+	p.mu.Unlock()
+
+	if keys == nil || time.Since(keysFetchedAt) > p.config.KeyCacheTTL {
+		refreshed, err := p.refreshKeys(ctx, discovery)
+		switch {
+		case err == nil:
+			keys = refreshed
+		case keys == nil:
+			return nil, err
+		default:
+			// Keep serving the stale keys while the provider is unreachable
+			p.obs.Logger.Warn(ctx, "Failed to refresh OIDC signing keys, using cached keys", zap.Error(err))
+		}
+	}
+
+	if key, ok := findKey(keys, kid); ok {
+		return key, nil
+	}
+
+	refreshed, err := p.refreshKeys(ctx, discovery)
+	if err != nil {
+		return nil, err
+	}
+	if key, ok := findKey(refreshed, kid); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("signing key %q not found", kid)
+}
+
+// refreshKeys replaces the cached JWKS and returns it. Concurrent callers share one fetch, which runs without
+// holding the lock, and fetches are attempted at most once per minKeyRefreshInterval whether they succeed or
+// fail: within it the cached keys are returned, or the error of the last attempt when there are none.
+func (p *Provider) refreshKeys(ctx context.Context, discovery *discoveryDocument) (map[string]interface{}, error) {
+	p.mu.Lock()
+	if time.Since(p.keysAttemptedAt) < minKeyRefreshInterval {
+		keys, err := p.keys, p.keysError
+		p.mu.Unlock()
+		if keys != nil {
+			return keys, nil
+		}
+		return nil, err
+	}
+	p.mu.Unlock()
+
+	result, err, _ := p.fetches.Do("jwks", func() (interface{}, error) {
+		keys, err := fetchKeySet(context.WithoutCancel(ctx), p.client, discovery.JWKSURI)
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.keysAttemptedAt = time.Now()
+		p.keysError = err
+		if err != nil {
+			return nil, err
+		}
+		p.keys = keys
+		p.keysFetchedAt = p.keysAttemptedAt
+		return keys, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]interface{}), nil
+}
+
+// findKey looks up a key of a JWKS, tokens without a key ID match a JWKS with a single key
+func findKey(keys map[string]interface{}, kid string) (interface{}, bool) {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	key, ok := keys[kid]
+	return key, ok
+}
+
+// lookupClaim returns the value of a claim, following dot-separated paths into nested objects
+func lookupClaim(claims map[string]interface{}, path string) interface{} {
+	var value interface{} = claims
+	for _, part := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[part]
+	}
+	return value
+}
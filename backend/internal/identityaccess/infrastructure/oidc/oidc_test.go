@@ -0,0 +1,385 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testIdentityProvider serves the discovery document and the JWKS of an OpenID Connect provider
+type testIdentityProvider struct {
+	server     *httptest.Server
+	jwksStatus atomic.Int32
+	jwksHits   atomic.Int32
+
+	mu   sync.Mutex
+	keys []jsonWebKey
+}
+
+func newTestIdentityProvider(t *testing.T) *testIdentityProvider {
+	idp := &testIdentityProvider{}
+	idp.jwksStatus.Store(http.StatusOK)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(discoveryDocument{Issuer: idp.server.URL, JWKSURI: idp.server.URL + "/jwks"})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		idp.jwksHits.Add(1)
+		if status := int(idp.jwksStatus.Load()); status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		idp.mu.Lock()
+		defer idp.mu.Unlock()
+		json.NewEncoder(w).Encode(jsonWebKeySet{Keys: idp.keys})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+// publish adds a public key to the JWKS
+func (idp *testIdentityProvider) publish(kid string, key interface{}) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		idp.keys = append(idp.keys, jsonWebKey{Kty: "RSA", Kid: kid, Use: "sig", N: encode(key.N.Bytes()), E: encode(big.NewInt(int64(key.E)).Bytes())})
+	case *ecdsa.PublicKey:
+		idp.keys = append(idp.keys, jsonWebKey{Kty: "EC", Kid: kid, Use: "sig", Crv: "P-256", X: encode(key.X.FillBytes(make([]byte, 32))), Y: encode(key.Y.FillBytes(make([]byte, 32)))})
+	}
+}
+
+func newTestProvider(t *testing.T, issuerURL string) *Provider {
+	logger, _ := observability.NewLogger(&observability.LogConfig{
+		Level:       observability.DebugLevel,
+		Format:      observability.ConsoleFormat,
+		OutputPaths: []string{"stdout"},
+		Development: true,
+	})
+	obs := &observability.ObservabilityProvider{
+		Logger:  logger,
+		Tracer:  observability.NewTracer("test-tracer"),
+		Metrics: &observability.Metrics{},
+	}
+
+	provider, err := NewProvider(&Config{
+		IssuerURL: issuerURL,
+		Audiences: []string{"context-space"},
+		ClockSkew: 30 * time.Second,
+		Claims: ClaimMapping{
+			Email:         "profile.email",
+			EmailVerified: "email_verified",
+		},
+	}, obs)
+	if err != nil {
+		t.Fatalf("Failed to create provider: %v", err)
+	}
+	return provider
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func TestProviderValidateToken(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	idp := newTestIdentityProvider(t)
+	idp.publish("rsa-1", &rsaKey.PublicKey)
+	idp.publish("ec-1", &ecKey.PublicKey)
+	provider := newTestProvider(t, idp.server.URL)
+
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss":     idp.server.URL,
+			"aud":     "context-space",
+			"sub":     "user-1",
+			"exp":     time.Now().Add(time.Hour).Unix(),
+			"profile": map[string]interface{}{"email": "user@example.com"},
+		}
+		for name, value := range overrides {
+			if value == nil {
+				delete(c, name)
+				continue
+			}
+			c[name] = value
+		}
+		return c
+	}
+
+	tests := []struct {
+		name          string
+		token         string
+		expectedError string
+		expectedEmail string
+	}{
+		{
+			name:          "ValidRSAToken",
+			token:         signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(nil)),
+			expectedEmail: "user@example.com",
+		},
+		{
+			name:          "ValidECToken",
+			token:         signToken(t, jwt.SigningMethodES256, "ec-1", ecKey, claims(nil)),
+			expectedEmail: "user@example.com",
+		},
+		{
+			name:          "OneOfSeveralAudiencesAllowed",
+			token:         signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(jwt.MapClaims{"aud": []string{"other-client", "context-space"}})),
+			expectedEmail: "user@example.com",
+		},
+		{
+			name:  "UnverifiedEmailIgnored",
+			token: signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(jwt.MapClaims{"email_verified": false})),
+		},
+		{
+			name:          "ExpiredWithinClockSkew",
+			token:         signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(jwt.MapClaims{"exp": time.Now().Add(-10 * time.Second).Unix()})),
+			expectedEmail: "user@example.com",
+		},
+		{
+			name:          "OtherClientAudience",
+			token:         signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(jwt.MapClaims{"aud": "other-client"})),
+			expectedError: "audience",
+		},
+		{
+			name:          "MissingAudience",
+			token:         signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(jwt.MapClaims{"aud": nil})),
+			expectedError: "audience",
+		},
+		{
+			name:          "OtherIssuer",
+			token:         signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(jwt.MapClaims{"iss": "https://attacker.example.com"})),
+			expectedError: "issuer",
+		},
+		{
+			name:          "Expired",
+			token:         signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()})),
+			expectedError: "expired",
+		},
+		{
+			name:          "MissingExpiration",
+			token:         signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(jwt.MapClaims{"exp": nil})),
+			expectedError: "exp",
+		},
+		{
+			name:          "MissingSubject",
+			token:         signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(jwt.MapClaims{"sub": nil})),
+			expectedError: "sub",
+		},
+		{
+			name:          "SignedWithOtherKey",
+			token:         signToken(t, jwt.SigningMethodRS256, "rsa-1", otherKey, claims(nil)),
+			expectedError: "signature",
+		},
+		{
+			name:          "SymmetricAlgorithm",
+			token:         signToken(t, jwt.SigningMethodHS256, "rsa-1", []byte("secret"), claims(nil)),
+			expectedError: "signing method",
+		},
+		{
+			name:          "NoneAlgorithm",
+			token:         signToken(t, jwt.SigningMethodNone, "rsa-1", jwt.UnsafeAllowNoneSignatureType, claims(nil)),
+			expectedError: "signing method",
+		},
+		{
+			name:          "MalformedToken",
+			token:         "not-a-token",
+			expectedError: "malformed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := provider.ValidateToken(context.Background(), tt.token)
+			if tt.expectedError != "" {
+				if err == nil {
+					t.Fatalf("Expected an error containing %q, got identity: %+v", tt.expectedError, identity)
+				}
+				if !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("Expected an error containing %q, got: %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to validate token: %v", err)
+			}
+			if identity.Subject != "user-1" {
+				t.Errorf("Expected subject user-1, got: %s", identity.Subject)
+			}
+			if identity.Email != tt.expectedEmail {
+				t.Errorf("Expected email %q, got: %q", tt.expectedEmail, identity.Email)
+			}
+		})
+	}
+
+	// Every token above was validated against the JWKS fetched once
+	if hits := idp.jwksHits.Load(); hits != 1 {
+		t.Errorf("Expected the JWKS to be fetched once, got: %d", hits)
+	}
+}
+
+func TestProviderKeyRefresh(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	rotatedKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	idp := newTestIdentityProvider(t)
+	idp.publish("rsa-1", &rsaKey.PublicKey)
+	provider := newTestProvider(t, idp.server.URL)
+	ctx := context.Background()
+
+	claims := jwt.MapClaims{
+		"iss": idp.server.URL,
+		"aud": "context-space",
+		"sub": "user-1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+	rotatedToken := signToken(t, jwt.SigningMethodRS256, "rsa-2", rotatedKey, claims)
+
+	// allowRefresh lets the next refresh through, as if minKeyRefreshInterval elapsed
+	allowRefresh := func() {
+		provider.mu.Lock()
+		provider.keysAttemptedAt = time.Time{}
+		provider.mu.Unlock()
+	}
+
+	steps := []struct {
+		name             string
+		setup            func()
+		token            string
+		expectedError    bool
+		expectedJWKSHits int32
+	}{
+		{
+			name:             "InitialFetch",
+			token:            token,
+			expectedJWKSHits: 1,
+		},
+		{
+			name:             "UnknownKeyThrottled",
+			setup:            func() { idp.publish("rsa-2", &rotatedKey.PublicKey) },
+			token:            rotatedToken,
+			expectedError:    true,
+			expectedJWKSHits: 1,
+		},
+		{
+			name:             "UnknownKeyRefreshed",
+			setup:            allowRefresh,
+			token:            rotatedToken,
+			expectedJWKSHits: 2,
+		},
+		{
+			name: "StaleKeysServedWhileUnreachable",
+			setup: func() {
+				provider.mu.Lock()
+				provider.keysFetchedAt = time.Now().Add(-2 * provider.config.KeyCacheTTL)
+				provider.mu.Unlock()
+				allowRefresh()
+				idp.jwksStatus.Store(http.StatusInternalServerError)
+			},
+			token:            token,
+			expectedJWKSHits: 3,
+		},
+		{
+			name:             "FailedRefreshThrottled",
+			token:            signToken(t, jwt.SigningMethodRS256, "rsa-3", rotatedKey, claims),
+			expectedError:    true,
+			expectedJWKSHits: 3,
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			if step.setup != nil {
+				step.setup()
+			}
+
+			_, err := provider.ValidateToken(ctx, step.token)
+			if step.expectedError && err == nil {
+				t.Errorf("Expected an error")
+			}
+			if !step.expectedError && err != nil {
+				t.Errorf("Failed to validate token: %v", err)
+			}
+			if hits := idp.jwksHits.Load(); hits != step.expectedJWKSHits {
+				t.Errorf("Expected %d JWKS fetches, got: %d", step.expectedJWKSHits, hits)
+			}
+		})
+	}
+}
+
+func TestProviderDiscovery(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	idp := newTestIdentityProvider(t)
+	idp.publish("rsa-1", &rsaKey.PublicKey)
+
+	// The metadata claims an issuer other than the configured one
+	provider := newTestProvider(t, idp.server.URL+"/tenant")
+	token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, jwt.MapClaims{
+		"iss": idp.server.URL + "/tenant",
+		"aud": "context-space",
+		"sub": "user-1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	_, err := provider.ValidateToken(context.Background(), token)
+	if err == nil {
+		t.Fatalf("Expected an error for a mismatched issuer")
+	}
+	if !strings.Contains(err.Error(), "discover") {
+		t.Errorf("Expected a discovery error, got: %v", err)
+	}
+}
+
+func TestNewProviderConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *Config
+		wantErr bool
+	}{
+		{name: "Valid", config: &Config{IssuerURL: "https://idp.example.com", Audiences: []string{"context-space"}}},
+		{name: "NilConfig", config: nil, wantErr: true},
+		{name: "MissingIssuer", config: &Config{Audiences: []string{"context-space"}}, wantErr: true},
+		{name: "MissingAudiences", config: &Config{IssuerURL: "https://idp.example.com"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewProvider(tt.config, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bytedance/sonic"
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// AuditRepository implements the domain.AuditRepository interface
+type AuditRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewAuditRepository creates a new audit repository
+func NewAuditRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *AuditRepository {
+	return &AuditRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// Append links the entry to the end of the chain of its user and stores it
+func (r *AuditRepository) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "AuditRepository.Append")
+	defer span.End()
+
+	return r.db.Transaction(ctx, func(tx *gorm.DB) error {
+		// Serialize appends to the same chain so that no two entries link to the same predecessor
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "audit_logs:"+entry.UserID).Error; err != nil {
+			return err
+		}
+
+		var last AuditLogModel
+		result := tx.Select("sequence", "hash").
+			Where("user_id = ?", entry.UserID).
+			Order("sequence DESC").
+			Limit(1).
+			Find(&last)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		var previous *domain.AuditEntry
+		if result.RowsAffected > 0 {
+			previous = &domain.AuditEntry{Sequence: last.Sequence, Hash: last.Hash}
+		}
+		entry.Link(previous)
+
+		return tx.Create(r.mapToModel(entry)).Error
+	})
+}
+
+// List retrieves the entries of a user matching the filter, newest first, and the total number of matches
+func (r *AuditRepository) List(ctx context.Context, userID string, filter domain.AuditFilter, offset, limit int) ([]*domain.AuditEntry, int64, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "AuditRepository.List")
+	defer span.End()
+
+	query := r.db.WithContext(ctx).Model(&AuditLogModel{}).Where("user_id = ?", userID)
+	if filter.Action != "" {
+		query = query.Where("action = ?", string(filter.Action))
+	}
+	if filter.ResourceType != "" {
+		query = query.Where("resource_type = ?", filter.ResourceType)
+	}
+	if filter.ResourceID != "" {
+		query = query.Where("resource_id = ?", filter.ResourceID)
+	}
+	if filter.Outcome != "" {
+		query = query.Where("outcome = ?", string(filter.Outcome))
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var models []AuditLogModel
+	if err := query.Order("sequence DESC").Offset(offset).Limit(limit).Find(&models).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entries, err := r.mapToDomainList(models)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+// ListChain retrieves the entries of a user in chain order, starting after the given sequence
+func (r *AuditRepository) ListChain(ctx context.Context, userID string, afterSequence int64, limit int) ([]*domain.AuditEntry, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "AuditRepository.ListChain")
+	defer span.End()
+
+	var models []AuditLogModel
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND sequence > ?", userID, afterSequence).
+		Order("sequence ASC").
+		Limit(limit).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.mapToDomainList(models)
+}
+
+// mapToDomainList maps audit log models to domain audit entries
+func (r *AuditRepository) mapToDomainList(models []AuditLogModel) ([]*domain.AuditEntry, error) {
+	entries := make([]*domain.AuditEntry, len(models))
+	for i := range models {
+		entry, err := r.mapToDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = entry
+	}
+	return entries, nil
+}
+
+// mapToDomain maps an audit log model to a domain audit entry
+func (r *AuditRepository) mapToDomain(model *AuditLogModel) (*domain.AuditEntry, error) {
+	var metadata map[string]string
+	if len(model.Metadata) > 0 {
+		if err := sonic.Unmarshal(model.Metadata, &metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal audit entry metadata: %w", err)
+		}
+	}
+
+	return &domain.AuditEntry{
+		ID:           model.ID,
+		UserID:       model.UserID,
+		Sequence:     model.Sequence,
+		ActorID:      model.ActorID,
+		AuthType:     model.AuthType,
+		IPAddress:    model.IPAddress,
+		UserAgent:    model.UserAgent,
+		Action:       domain.AuditAction(model.Action),
+		ResourceType: model.ResourceType,
+		ResourceID:   model.ResourceID,
+		Outcome:      domain.AuditOutcome(model.Outcome),
+		Metadata:     metadata,
+		PrevHash:     model.PrevHash,
+		Hash:         model.Hash,
+		CreatedAt:    model.CreatedAt,
+	}, nil
+}
+
+// mapToModel maps a domain audit entry to an audit log model
+func (r *AuditRepository) mapToModel(entry *domain.AuditEntry) *AuditLogModel {
+	return &AuditLogModel{
+		ID:           entry.ID,
+		UserID:       entry.UserID,
+		Sequence:     entry.Sequence,
+		ActorID:      entry.ActorID,
+		AuthType:     entry.AuthType,
+		IPAddress:    entry.IPAddress,
+		UserAgent:    entry.UserAgent,
+		Action:       string(entry.Action),
+		ResourceType: entry.ResourceType,
+		ResourceID:   entry.ResourceID,
+		Outcome:      string(entry.Outcome),
+		Metadata:     mustMarshalJSON(entry.Metadata),
+		PrevHash:     entry.PrevHash,
+		Hash:         entry.Hash,
+		CreatedAt:    entry.CreatedAt,
+	}
+}
@@ -12,7 +12,7 @@ import (
 // UserModel represents the users table in the database
 type UserModel struct {
 	ID          string         `gorm:"type:uuid;primaryKey"`
-	SupID       string         `gorm:"type:uuid;not null;uniqueIndex"`
+	SupID       string         `gorm:"type:varchar(255);not null;uniqueIndex"`
 	Email       *string        `gorm:"type:varchar(255);uniqueIndex"`
 	IsAnonymous bool           `gorm:"type:boolean;not null;default:false"`
 	CreatedAt   time.Time      `gorm:"type:timestamp with time zone;not null;default:now()"`
@@ -45,15 +45,19 @@ func (UserInfoModel) TableName() string {
 
 // UserAPIKeyModel represents the api_keys table in the database
 type UserAPIKeyModel struct {
-	ID          string         `gorm:"type:uuid;primaryKey"`
-	UserID      string         `gorm:"type:uuid;not null;index"`
-	KeyValue    string         `gorm:"type:varchar(64);not null;uniqueIndex;column:key_value"`
-	Name        string         `gorm:"type:varchar(100)"`
-	Description string         `gorm:"type:text"`
-	LastUsed    *time.Time     `gorm:"type:timestamp with time zone"`
-	CreatedAt   time.Time      `gorm:"type:timestamp with time zone;not null;default:now()"`
-	UpdatedAt   time.Time      `gorm:"type:timestamp with time zone;not null;default:now()"`
-	DeletedAt   gorm.DeletedAt `gorm:"type:timestamp with time zone;index"`
+	ID               string          `gorm:"type:uuid;primaryKey"`
+	UserID           string          `gorm:"type:uuid;not null;index"`
+	OrganizationID   *string         `gorm:"type:uuid;index"`
+	ServiceAccountID *string         `gorm:"type:uuid;index"`
+	KeyValue         string          `gorm:"type:varchar(64);not null;uniqueIndex;column:key_value"`
+	Name             string          `gorm:"type:varchar(100)"`
+	Description      string          `gorm:"type:text"`
+	Scopes           json.RawMessage `gorm:"type:jsonb;not null;default:'[]'"`
+	ExpiresAt        *time.Time      `gorm:"type:timestamp with time zone"`
+	LastUsed         *time.Time      `gorm:"type:timestamp with time zone"`
+	CreatedAt        time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
+	UpdatedAt        time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
+	DeletedAt        gorm.DeletedAt  `gorm:"type:timestamp with time zone;index"`
 
 	// Relationships
 	User UserModel `gorm:"foreignKey:UserID;references:ID"`
@@ -64,6 +68,78 @@ func (UserAPIKeyModel) TableName() string {
 	return "user_api_keys"
 }
 
+// ServiceAccountModel represents the service_accounts table in the database
+type ServiceAccountModel struct {
+	ID             string         `gorm:"type:uuid;primaryKey"`
+	Name           string         `gorm:"type:varchar(100);not null"`
+	Description    string         `gorm:"type:text;not null;default:''"`
+	CreatedBy      string         `gorm:"type:uuid;not null;index"`
+	OrganizationID *string        `gorm:"type:uuid;index"`
+	CreatedAt      time.Time      `gorm:"type:timestamp with time zone;not null;default:now()"`
+	UpdatedAt      time.Time      `gorm:"type:timestamp with time zone;not null;default:now()"`
+	DeletedAt      gorm.DeletedAt `gorm:"type:timestamp with time zone;index"`
+}
+
+// TableName returns the table name for the ServiceAccount model
+func (ServiceAccountModel) TableName() string {
+	return "service_accounts"
+}
+
+// OrganizationModel represents the organizations table in the database
+type OrganizationModel struct {
+	ID                   string         `gorm:"type:uuid;primaryKey"`
+	Name                 string         `gorm:"type:varchar(100);not null"`
+	CreatedBy            string         `gorm:"type:uuid;not null"`
+	SharedCredentialRole string         `gorm:"type:varchar(20);not null;default:member"`
+	CreatedAt            time.Time      `gorm:"type:timestamp with time zone;not null;default:now()"`
+	UpdatedAt            time.Time      `gorm:"type:timestamp with time zone;not null;default:now()"`
+	DeletedAt            gorm.DeletedAt `gorm:"type:timestamp with time zone;index"`
+}
+
+// TableName returns the table name for the Organization model
+func (OrganizationModel) TableName() string {
+	return "organizations"
+}
+
+// OrganizationMemberModel represents the organization_members table in the database
+type OrganizationMemberModel struct {
+	ID             string    `gorm:"type:uuid;primaryKey"`
+	OrganizationID string    `gorm:"type:uuid;not null;uniqueIndex:idx_organization_members_organization_user"`
+	UserID         string    `gorm:"type:uuid;not null;uniqueIndex:idx_organization_members_organization_user;index"`
+	Role           string    `gorm:"type:varchar(20);not null"`
+	CreatedAt      time.Time `gorm:"type:timestamp with time zone;not null;default:now()"`
+	UpdatedAt      time.Time `gorm:"type:timestamp with time zone;not null;default:now()"`
+}
+
+// TableName returns the table name for the OrganizationMember model
+func (OrganizationMemberModel) TableName() string {
+	return "organization_members"
+}
+
+// AuditLogModel represents the append-only audit_logs table in the database
+type AuditLogModel struct {
+	ID           string          `gorm:"type:uuid;primaryKey"`
+	UserID       string          `gorm:"type:varchar(36);not null;uniqueIndex:idx_audit_logs_user_sequence"`
+	Sequence     int64           `gorm:"type:bigint;not null;uniqueIndex:idx_audit_logs_user_sequence"`
+	ActorID      string          `gorm:"type:varchar(36);not null;default:''"`
+	AuthType     string          `gorm:"type:varchar(20);not null;default:''"`
+	IPAddress    string          `gorm:"type:varchar(45);not null;default:''"`
+	UserAgent    string          `gorm:"type:text;not null;default:''"`
+	Action       string          `gorm:"type:varchar(50);not null"`
+	ResourceType string          `gorm:"type:varchar(50);not null;default:''"`
+	ResourceID   string          `gorm:"type:varchar(255);not null;default:''"`
+	Outcome      string          `gorm:"type:varchar(20);not null"`
+	Metadata     json.RawMessage `gorm:"type:jsonb;not null"`
+	PrevHash     string          `gorm:"type:char(64);not null"`
+	Hash         string          `gorm:"type:char(64);not null"`
+	CreatedAt    time.Time       `gorm:"type:timestamp with time zone;not null"`
+}
+
+// TableName returns the table name for the AuditLog model
+func (AuditLogModel) TableName() string {
+	return "audit_logs"
+}
+
 // BeforeCreate is called before creating a new record
 func (u *UserModel) BeforeCreate(tx *gorm.DB) error {
 	if u.ID == "" {
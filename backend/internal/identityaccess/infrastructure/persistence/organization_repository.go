@@ -0,0 +1,279 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// OrganizationRepository implements the domain.OrganizationRepository interface
+type OrganizationRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewOrganizationRepository creates a new organization repository
+func NewOrganizationRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *OrganizationRepository {
+	return &OrganizationRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// Get retrieves an organization by ID
+func (r *OrganizationRepository) Get(ctx context.Context, id string) (*domain.Organization, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "OrganizationRepository.Get")
+	defer span.End()
+
+	var model OrganizationModel
+	result := r.db.WithContext(ctx).First(&model, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.mapToDomain(&model), nil
+}
+
+// ListByUserID retrieves the organizations a user is a member of
+func (r *OrganizationRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Organization, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "OrganizationRepository.ListByUserID")
+	defer span.End()
+
+	var models []OrganizationModel
+	result := r.db.WithContext(ctx).
+		Joins("JOIN organization_members ON organization_members.organization_id = organizations.id").
+		Where("organization_members.user_id = ?", userID).
+		Order("organizations.created_at ASC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	organizations := make([]*domain.Organization, len(models))
+	for i, model := range models {
+		organizations[i] = r.mapToDomain(&model)
+	}
+
+	return organizations, nil
+}
+
+// Create creates a new organization
+func (r *OrganizationRepository) Create(ctx context.Context, organization *domain.Organization) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "OrganizationRepository.Create")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Create(r.mapToModel(organization)).Error
+}
+
+// Update updates an existing organization
+func (r *OrganizationRepository) Update(ctx context.Context, organization *domain.Organization) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "OrganizationRepository.Update")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Model(&OrganizationModel{}).Where("id = ?", organization.ID).Updates(map[string]interface{}{
+		"name":                   organization.Name,
+		"shared_credential_role": string(organization.SharedCredentialRole),
+		"updated_at":             organization.UpdatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("organization not found with id: %s", organization.ID)
+	}
+
+	return nil
+}
+
+// Delete soft-deletes an organization
+func (r *OrganizationRepository) Delete(ctx context.Context, id string) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "OrganizationRepository.Delete")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&OrganizationModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("organization not found with id: %s", id)
+	}
+
+	return nil
+}
+
+// mapToDomain maps an organization model to a domain organization
+func (r *OrganizationRepository) mapToDomain(model *OrganizationModel) *domain.Organization {
+	return &domain.Organization{
+		ID:                   model.ID,
+		Name:                 model.Name,
+		CreatedBy:            model.CreatedBy,
+		SharedCredentialRole: domain.OrganizationRole(model.SharedCredentialRole),
+		CreatedAt:            model.CreatedAt,
+		UpdatedAt:            model.UpdatedAt,
+		DeletedAt:            parseGormDeletedAt(model.DeletedAt),
+	}
+}
+
+// mapToModel maps a domain organization to an organization model
+func (r *OrganizationRepository) mapToModel(organization *domain.Organization) *OrganizationModel {
+	return &OrganizationModel{
+		ID:                   organization.ID,
+		Name:                 organization.Name,
+		CreatedBy:            organization.CreatedBy,
+		SharedCredentialRole: string(organization.SharedCredentialRole),
+		CreatedAt:            organization.CreatedAt,
+		UpdatedAt:            organization.UpdatedAt,
+		DeletedAt:            parseDomainDeletedAt(organization.DeletedAt),
+	}
+}
+
+// MembershipRepository implements the domain.MembershipRepository interface
+type MembershipRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewMembershipRepository creates a new membership repository
+func NewMembershipRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *MembershipRepository {
+	return &MembershipRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// Get retrieves the membership of a user in an organization
+func (r *MembershipRepository) Get(ctx context.Context, organizationID, userID string) (*domain.Membership, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "MembershipRepository.Get")
+	defer span.End()
+
+	var model OrganizationMemberModel
+	result := r.db.WithContext(ctx).First(&model, "organization_id = ? AND user_id = ?", organizationID, userID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.mapToDomain(&model), nil
+}
+
+// ListByOrganizationID retrieves the memberships of an organization
+func (r *MembershipRepository) ListByOrganizationID(ctx context.Context, organizationID string) ([]*domain.Membership, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "MembershipRepository.ListByOrganizationID")
+	defer span.End()
+
+	var models []OrganizationMemberModel
+	result := r.db.WithContext(ctx).Where("organization_id = ?", organizationID).Order("created_at ASC").Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	memberships := make([]*domain.Membership, len(models))
+	for i, model := range models {
+		memberships[i] = r.mapToDomain(&model)
+	}
+
+	return memberships, nil
+}
+
+// CountByRole returns the number of members of an organization with the given role
+func (r *MembershipRepository) CountByRole(ctx context.Context, organizationID string, role domain.OrganizationRole) (int64, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "MembershipRepository.CountByRole")
+	defer span.End()
+
+	var count int64
+	result := r.db.WithContext(ctx).Model(&OrganizationMemberModel{}).
+		Where("organization_id = ? AND role = ?", organizationID, string(role)).
+		Count(&count)
+	return count, result.Error
+}
+
+// Create creates a new membership
+func (r *MembershipRepository) Create(ctx context.Context, membership *domain.Membership) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "MembershipRepository.Create")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Create(r.mapToModel(membership)).Error
+}
+
+// Update updates the role of a membership
+func (r *MembershipRepository) Update(ctx context.Context, membership *domain.Membership) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "MembershipRepository.Update")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Model(&OrganizationMemberModel{}).Where("id = ?", membership.ID).Updates(map[string]interface{}{
+		"role":       string(membership.Role),
+		"updated_at": membership.UpdatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("membership not found with id: %s", membership.ID)
+	}
+
+	return nil
+}
+
+// Delete deletes the membership of a user in an organization
+func (r *MembershipRepository) Delete(ctx context.Context, organizationID, userID string) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "MembershipRepository.Delete")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Delete(&OrganizationMemberModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("membership not found for user %s in organization %s", userID, organizationID)
+	}
+
+	return nil
+}
+
+// DeleteByOrganizationID deletes all memberships of an organization
+func (r *MembershipRepository) DeleteByOrganizationID(ctx context.Context, organizationID string) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "MembershipRepository.DeleteByOrganizationID")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Where("organization_id = ?", organizationID).Delete(&OrganizationMemberModel{}).Error
+}
+
+// mapToDomain maps an organization member model to a domain membership
+func (r *MembershipRepository) mapToDomain(model *OrganizationMemberModel) *domain.Membership {
+	return &domain.Membership{
+		ID:             model.ID,
+		OrganizationID: model.OrganizationID,
+		UserID:         model.UserID,
+		Role:           domain.OrganizationRole(model.Role),
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+// mapToModel maps a domain membership to an organization member model
+func (r *MembershipRepository) mapToModel(membership *domain.Membership) *OrganizationMemberModel {
+	return &OrganizationMemberModel{
+		ID:             membership.ID,
+		OrganizationID: membership.OrganizationID,
+		UserID:         membership.UserID,
+		Role:           string(membership.Role),
+		CreatedAt:      membership.CreatedAt,
+		UpdatedAt:      membership.UpdatedAt,
+	}
+}
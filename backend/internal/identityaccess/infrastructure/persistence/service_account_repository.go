@@ -0,0 +1,160 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// ServiceAccountRepository implements the domain.ServiceAccountRepository interface
+type ServiceAccountRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewServiceAccountRepository creates a new service account repository
+func NewServiceAccountRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *ServiceAccountRepository {
+	return &ServiceAccountRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// Get retrieves a service account by ID
+func (r *ServiceAccountRepository) Get(ctx context.Context, id string) (*domain.ServiceAccount, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "ServiceAccountRepository.Get")
+	defer span.End()
+
+	var model ServiceAccountModel
+	result := r.db.WithContext(ctx).First(&model, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.mapToDomain(&model), nil
+}
+
+// ListByUserID retrieves the service accounts owned by a user
+func (r *ServiceAccountRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.ServiceAccount, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "ServiceAccountRepository.ListByUserID")
+	defer span.End()
+
+	var models []ServiceAccountModel
+	result := r.db.WithContext(ctx).
+		Where("created_by = ? AND organization_id IS NULL", userID).
+		Order("created_at ASC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.mapToDomainList(models), nil
+}
+
+// ListByOrganizationID retrieves the service accounts owned by an organization
+func (r *ServiceAccountRepository) ListByOrganizationID(ctx context.Context, organizationID string) ([]*domain.ServiceAccount, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "ServiceAccountRepository.ListByOrganizationID")
+	defer span.End()
+
+	var models []ServiceAccountModel
+	result := r.db.WithContext(ctx).
+		Where("organization_id = ?", organizationID).
+		Order("created_at ASC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.mapToDomainList(models), nil
+}
+
+// Create creates a new service account
+func (r *ServiceAccountRepository) Create(ctx context.Context, serviceAccount *domain.ServiceAccount) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "ServiceAccountRepository.Create")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Create(r.mapToModel(serviceAccount)).Error
+}
+
+// Update updates an existing service account
+func (r *ServiceAccountRepository) Update(ctx context.Context, serviceAccount *domain.ServiceAccount) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "ServiceAccountRepository.Update")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Model(&ServiceAccountModel{}).Where("id = ?", serviceAccount.ID).Updates(map[string]interface{}{
+		"name":        serviceAccount.Name,
+		"description": serviceAccount.Description,
+		"updated_at":  serviceAccount.UpdatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("service account not found with id: %s", serviceAccount.ID)
+	}
+
+	return nil
+}
+
+// Delete soft-deletes a service account
+func (r *ServiceAccountRepository) Delete(ctx context.Context, id string) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "ServiceAccountRepository.Delete")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&ServiceAccountModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("service account not found with id: %s", id)
+	}
+
+	return nil
+}
+
+// mapToDomainList maps service account models to domain service accounts
+func (r *ServiceAccountRepository) mapToDomainList(models []ServiceAccountModel) []*domain.ServiceAccount {
+	serviceAccounts := make([]*domain.ServiceAccount, len(models))
+	for i, model := range models {
+		serviceAccounts[i] = r.mapToDomain(&model)
+	}
+	return serviceAccounts
+}
+
+// mapToDomain maps a service account model to a domain service account
+func (r *ServiceAccountRepository) mapToDomain(model *ServiceAccountModel) *domain.ServiceAccount {
+	return &domain.ServiceAccount{
+		ID:             model.ID,
+		Name:           model.Name,
+		Description:    model.Description,
+		CreatedBy:      model.CreatedBy,
+		OrganizationID: parseGormOrganizationID(model.OrganizationID),
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+		DeletedAt:      parseGormDeletedAt(model.DeletedAt),
+	}
+}
+
+// mapToModel maps a domain service account to a service account model
+func (r *ServiceAccountRepository) mapToModel(serviceAccount *domain.ServiceAccount) *ServiceAccountModel {
+	return &ServiceAccountModel{
+		ID:             serviceAccount.ID,
+		Name:           serviceAccount.Name,
+		Description:    serviceAccount.Description,
+		CreatedBy:      serviceAccount.CreatedBy,
+		OrganizationID: parseDomainOrganizationID(serviceAccount.OrganizationID),
+		CreatedAt:      serviceAccount.CreatedAt,
+		UpdatedAt:      serviceAccount.UpdatedAt,
+		DeletedAt:      parseDomainDeletedAt(serviceAccount.DeletedAt),
+	}
+}
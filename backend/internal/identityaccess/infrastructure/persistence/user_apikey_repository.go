@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/bytedance/sonic"
 	observability "github.com/context-space/cloud-observability"
 	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
 	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
@@ -59,13 +60,15 @@ func (r *UserAPIKeyRepository) GetByKeyValue(ctx context.Context, value string)
 	return r.mapToDomain(&model), nil
 }
 
-// ListByUserID retrieves API keys for a user with pagination
+// ListByUserID retrieves the personal API keys of a user
 func (r *UserAPIKeyRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.APIKey, error) {
 	ctx, span := r.obs.Tracer.Start(ctx, "UserAPIKeyRepository.ListByUserID")
 	defer span.End()
 
 	var models []UserAPIKeyModel
-	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&models)
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND organization_id IS NULL AND service_account_id IS NULL", userID).
+		Find(&models)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -82,6 +85,44 @@ func (r *UserAPIKeyRepository) ListByUserID(ctx context.Context, userID string)
 	return apiKeys, nil
 }
 
+// ListByServiceAccountID retrieves the API keys of a service account
+func (r *UserAPIKeyRepository) ListByServiceAccountID(ctx context.Context, serviceAccountID string) ([]*domain.APIKey, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "UserAPIKeyRepository.ListByServiceAccountID")
+	defer span.End()
+
+	var models []UserAPIKeyModel
+	result := r.db.WithContext(ctx).Where("service_account_id = ?", serviceAccountID).Order("created_at ASC").Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	apiKeys := make([]*domain.APIKey, len(models))
+	for i, model := range models {
+		apiKeys[i] = r.mapToDomain(&model)
+	}
+
+	return apiKeys, nil
+}
+
+// ListByOrganizationID retrieves the API keys of an organization
+func (r *UserAPIKeyRepository) ListByOrganizationID(ctx context.Context, organizationID string) ([]*domain.APIKey, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "UserAPIKeyRepository.ListByOrganizationID")
+	defer span.End()
+
+	var models []UserAPIKeyModel
+	result := r.db.WithContext(ctx).Where("organization_id = ?", organizationID).Order("created_at ASC").Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	apiKeys := make([]*domain.APIKey, len(models))
+	for i, model := range models {
+		apiKeys[i] = r.mapToDomain(&model)
+	}
+
+	return apiKeys, nil
+}
+
 // Create creates a new API key
 func (r *UserAPIKeyRepository) Create(ctx context.Context, apiKey *domain.APIKey) error {
 	ctx, span := r.obs.Tracer.Start(ctx, "UserAPIKeyRepository.Create")
@@ -135,32 +176,59 @@ func (r *UserAPIKeyRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// DeleteByServiceAccountID soft-deletes all API keys of a service account
+func (r *UserAPIKeyRepository) DeleteByServiceAccountID(ctx context.Context, serviceAccountID string) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "UserAPIKeyRepository.DeleteByServiceAccountID")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Where("service_account_id = ?", serviceAccountID).Delete(&UserAPIKeyModel{}).Error
+}
+
 // mapToDomain maps an API key model to a domain API key
 func (r *UserAPIKeyRepository) mapToDomain(model *UserAPIKeyModel) *domain.APIKey {
+	var scopes []domain.APIKeyScope
+	if len(model.Scopes) > 0 {
+		// Keys are still usable with the endpoints of their valid scopes if the column holds garbage
+		_ = sonic.Unmarshal(model.Scopes, &scopes)
+	}
+
 	return &domain.APIKey{
-		ID:          model.ID,
-		UserID:      model.UserID,
-		KeyValue:    model.KeyValue,
-		Name:        model.Name,
-		Description: model.Description,
-		LastUsed:    model.LastUsed,
-		CreatedAt:   model.CreatedAt,
-		UpdatedAt:   model.UpdatedAt,
-		DeletedAt:   parseGormDeletedAt(model.DeletedAt),
+		ID:               model.ID,
+		UserID:           model.UserID,
+		OrganizationID:   parseGormOrganizationID(model.OrganizationID),
+		ServiceAccountID: parseGormServiceAccountID(model.ServiceAccountID),
+		KeyValue:         model.KeyValue,
+		Name:             model.Name,
+		Description:      model.Description,
+		Scopes:           scopes,
+		ExpiresAt:        model.ExpiresAt,
+		LastUsed:         model.LastUsed,
+		CreatedAt:        model.CreatedAt,
+		UpdatedAt:        model.UpdatedAt,
+		DeletedAt:        parseGormDeletedAt(model.DeletedAt),
 	}
 }
 
 // mapToModel maps a domain API key to an API key model
 func (r *UserAPIKeyRepository) mapToModel(apiKey *domain.APIKey) *UserAPIKeyModel {
+	scopes := apiKey.Scopes
+	if scopes == nil {
+		scopes = []domain.APIKeyScope{}
+	}
+
 	return &UserAPIKeyModel{
-		ID:          apiKey.ID,
-		UserID:      apiKey.UserID,
-		KeyValue:    apiKey.KeyValue,
-		Name:        apiKey.Name,
-		Description: apiKey.Description,
-		LastUsed:    apiKey.LastUsed,
-		CreatedAt:   apiKey.CreatedAt,
-		UpdatedAt:   apiKey.UpdatedAt,
-		DeletedAt:   parseDomainDeletedAt(apiKey.DeletedAt),
+		ID:               apiKey.ID,
+		UserID:           apiKey.UserID,
+		OrganizationID:   parseDomainOrganizationID(apiKey.OrganizationID),
+		ServiceAccountID: parseDomainServiceAccountID(apiKey.ServiceAccountID),
+		KeyValue:         apiKey.KeyValue,
+		Name:             apiKey.Name,
+		Description:      apiKey.Description,
+		Scopes:           mustMarshalJSON(scopes),
+		ExpiresAt:        apiKey.ExpiresAt,
+		LastUsed:         apiKey.LastUsed,
+		CreatedAt:        apiKey.CreatedAt,
+		UpdatedAt:        apiKey.UpdatedAt,
+		DeletedAt:        parseDomainDeletedAt(apiKey.DeletedAt),
 	}
 }
@@ -52,3 +52,31 @@ func parseDomainEmail(email string) *string {
 	}
 	return result
 }
+
+func parseGormOrganizationID(organizationID *string) string {
+	if organizationID == nil {
+		return ""
+	}
+	return *organizationID
+}
+
+func parseDomainOrganizationID(organizationID string) *string {
+	if organizationID == "" {
+		return nil
+	}
+	return &organizationID
+}
+
+func parseGormServiceAccountID(serviceAccountID *string) string {
+	if serviceAccountID == nil {
+		return ""
+	}
+	return *serviceAccountID
+}
+
+func parseDomainServiceAccountID(serviceAccountID string) *string {
+	if serviceAccountID == "" {
+		return nil
+	}
+	return &serviceAccountID
+}
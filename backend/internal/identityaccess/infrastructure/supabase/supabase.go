@@ -7,6 +7,7 @@ import (
 
 	"github.com/bytedance/sonic"
 	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
@@ -38,6 +39,9 @@ type UserInfo struct {
 	InfoMetadata map[string]interface{} `json:"info_metadata"`
 }
 
+// ProviderName is the name of the Supabase identity provider
+const ProviderName = "supabase"
+
 // SupabaseAuthService provides Supabase authentication capabilities
 type SupabaseAuthService struct {
 	client *Client
@@ -45,6 +49,9 @@ type SupabaseAuthService struct {
 	obs    *observability.ObservabilityProvider
 }
 
+// Ensure SupabaseAuthService implements the identity provider interface
+var _ domain.IdentityProvider = (*SupabaseAuthService)(nil)
+
 // NewSupabaseAuthService creates a new Supabase auth service
 func NewSupabaseAuthService(config *SupabaseConfig, observabilityProvider *observability.ObservabilityProvider) (*SupabaseAuthService, error) {
 	if config == nil {
@@ -60,8 +67,52 @@ func NewSupabaseAuthService(config *SupabaseConfig, observabilityProvider *obser
 	}, nil
 }
 
-// ValidateToken validates a JWT token from Supabase Auth
-func (s *SupabaseAuthService) ValidateToken(ctx context.Context, tokenString string) (*Claims, error) {
+// Name returns the name of the identity provider
+func (s *SupabaseAuthService) Name() string {
+	return ProviderName
+}
+
+// ValidateToken validates a JWT token from Supabase Auth and returns the identity it was issued for
+func (s *SupabaseAuthService) ValidateToken(ctx context.Context, tokenString string) (*domain.Identity, error) {
+	claims, err := s.validateClaims(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := sonic.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	rawClaims := make(map[string]interface{})
+	if err := sonic.Unmarshal(data, &rawClaims); err != nil {
+		return nil, err
+	}
+
+	return &domain.Identity{
+		Provider: ProviderName,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+		Claims:   rawClaims,
+	}, nil
+}
+
+// GetUserProfile fetches the profile of an identity from the Supabase admin API
+func (s *SupabaseAuthService) GetUserProfile(ctx context.Context, identity *domain.Identity) (*domain.UserProfile, error) {
+	userInfo, err := s.GetUserInfo(ctx, identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserProfile{
+		Email:       userInfo.Email,
+		IsAnonymous: userInfo.IsAnonymous,
+		Metadata:    userInfo.InfoMetadata,
+	}, nil
+}
+
+// validateClaims validates a JWT token from Supabase Auth and returns its claims
+func (s *SupabaseAuthService) validateClaims(tokenString string) (*Claims, error) {
 	// Parse the token with claims validation
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		// Verify signing algorithm is HMAC (HS256)
@@ -0,0 +1,52 @@
+package contract
+
+import (
+	"context"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/identityaccess/application"
+	"github.com/context-space/context-space/backend/internal/shared/apierrors"
+	contractIdentity "github.com/context-space/context-space/backend/internal/shared/contract/identityaccess"
+)
+
+// OrganizationContractFacade implements the organization reader contract at the Interface layer
+type OrganizationContractFacade struct {
+	organizationService *application.OrganizationService
+	obs                 *observability.ObservabilityProvider
+}
+
+// Ensure implementation of contract interface
+var _ contractIdentity.OrganizationReader = (*OrganizationContractFacade)(nil)
+
+// NewOrganizationContractFacade creates a new organization contract facade
+func NewOrganizationContractFacade(
+	organizationService *application.OrganizationService,
+	obs *observability.ObservabilityProvider,
+) contractIdentity.OrganizationReader {
+	return &OrganizationContractFacade{
+		organizationService: organizationService,
+		obs:                 obs,
+	}
+}
+
+// GetMembershipContract returns the membership of a user in an organization, or nil if the user is not a member
+func (f *OrganizationContractFacade) GetMembershipContract(ctx context.Context, organizationID, userID string) (*contractIdentity.MembershipDTO, error) {
+	ctx, span := f.obs.Tracer.Start(ctx, "OrganizationContractFacade.GetMembershipContract")
+	defer span.End()
+
+	organization, membership, err := f.organizationService.GetMembership(ctx, organizationID, userID)
+	if err != nil {
+		if apiErr, ok := err.(*apierrors.APIError); ok && apiErr.Code == apierrors.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &contractIdentity.MembershipDTO{
+		OrganizationID:          organization.ID,
+		UserID:                  membership.UserID,
+		Role:                    string(membership.Role),
+		CanManageCredentials:    membership.CanManage(),
+		CanUseSharedCredentials: organization.AllowsSharedCredentials(membership.Role),
+	}, nil
+}
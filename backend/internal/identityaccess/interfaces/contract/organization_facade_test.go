@@ -0,0 +1,61 @@
+package contract
+
+import (
+	"context"
+	"testing"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/context-space/context-space/backend/internal/identityaccess/application"
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/shared/events"
+	identityaccess_mocks "github.com/context-space/context-space/backend/internal/shared/testing/mocks/identityaccess"
+)
+
+func TestOrganizationFacadeCredentialPermissions(t *testing.T) {
+	tests := []struct {
+		name                 string
+		role                 domain.OrganizationRole
+		sharedCredentialRole domain.OrganizationRole
+		expectedManage       bool
+		expectedUse          bool
+	}{
+		{name: "Member", role: domain.OrganizationRoleMember, sharedCredentialRole: domain.OrganizationRoleMember, expectedManage: false, expectedUse: true},
+		{name: "MemberBelowSharedRole", role: domain.OrganizationRoleMember, sharedCredentialRole: domain.OrganizationRoleAdmin, expectedManage: false, expectedUse: false},
+		{name: "Admin", role: domain.OrganizationRoleAdmin, sharedCredentialRole: domain.OrganizationRoleAdmin, expectedManage: true, expectedUse: true},
+		{name: "Owner", role: domain.OrganizationRoleOwner, sharedCredentialRole: domain.OrganizationRoleMember, expectedManage: true, expectedUse: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			obs, _, err := observability.InitializeObservabilityProvider(ctx, &observability.LogConfig{
+				Level:       observability.ParseLogLevel("error"),
+				Format:      observability.ParseLogFormat("json"),
+				OutputPaths: []string{"stdout"},
+			}, &observability.TracingConfig{Enabled: false}, &observability.MetricsConfig{Enabled: false})
+			require.NoError(t, err)
+
+			organizationRepo := identityaccess_mocks.NewMockOrganizationRepository(t)
+			membershipRepo := identityaccess_mocks.NewMockMembershipRepository(t)
+			organization := domain.NewOrganization("Acme", "owner-1")
+			organization.SharedCredentialRole = tt.sharedCredentialRole
+			organizationRepo.EXPECT().Get(mock.Anything, organization.ID).Return(organization, nil)
+			membershipRepo.EXPECT().Get(mock.Anything, organization.ID, "user-1").
+				Return(domain.NewMembership(organization.ID, "user-1", tt.role), nil)
+
+			organizationService := application.NewOrganizationService(organizationRepo, membershipRepo, nil, nil, nil, events.NewBus(), obs)
+			facade := NewOrganizationContractFacade(organizationService, obs)
+
+			membership, err := facade.GetMembershipContract(ctx, organization.ID, "user-1")
+			require.NoError(t, err)
+			require.NotNil(t, membership)
+			assert.Equal(t, string(tt.role), membership.Role)
+			assert.Equal(t, tt.expectedManage, membership.CanManageCredentials)
+			assert.Equal(t, tt.expectedUse, membership.CanUseSharedCredentials)
+		})
+	}
+}
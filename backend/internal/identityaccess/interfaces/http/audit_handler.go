@@ -0,0 +1,345 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/identityaccess/application"
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	auditExportFormatJSONL = "jsonl"
+	auditExportFormatCSV   = "csv"
+)
+
+// auditCSVHeader is the header row of CSV exports, in the order of auditEntryCSVRecord
+var auditCSVHeader = []string{
+	"id", "user_id", "sequence", "actor_id", "auth_type", "ip_address", "user_agent", "action",
+	"resource_type", "resource_id", "outcome", "metadata", "created_at", "prev_hash", "hash",
+}
+
+// AuditHandler handles HTTP requests for the audit log of the current user
+type AuditHandler struct {
+	auditService *application.AuditService
+	obs          *observability.ObservabilityProvider
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(auditService *application.AuditService, observabilityProvider *observability.ObservabilityProvider) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+		obs:          observabilityProvider,
+	}
+}
+
+// RegisterRoutes registers the audit routes
+func (h *AuditHandler) RegisterRoutes(router *gin.RouterGroup, requireAuth gin.HandlerFunc) {
+	users := router.Group("/users")
+	users.Use(requireAuth)
+	{
+		users.GET("/me/audit", h.ListAuditEntries)
+		users.GET("/me/audit/export", h.ExportAuditEntries)
+		users.GET("/me/audit/verify", h.VerifyAuditChain)
+	}
+}
+
+// AuditEntryResponse represents an audit log entry, created_at keeps the precision the hash is computed over
+type AuditEntryResponse struct {
+	ID           string            `json:"id"`
+	UserID       string            `json:"user_id"`
+	Sequence     int64             `json:"sequence"`
+	ActorID      string            `json:"actor_id"`
+	AuthType     string            `json:"auth_type"`
+	IPAddress    string            `json:"ip_address"`
+	UserAgent    string            `json:"user_agent"`
+	Action       string            `json:"action"`
+	ResourceType string            `json:"resource_type"`
+	ResourceID   string            `json:"resource_id"`
+	Outcome      string            `json:"outcome"`
+	Metadata     map[string]string `json:"metadata"`
+	CreatedAt    string            `json:"created_at"`
+	PrevHash     string            `json:"prev_hash"`
+	Hash         string            `json:"hash"`
+}
+
+// AuditEntryListResponse represents a page of audit log entries
+type AuditEntryListResponse struct {
+	Entries []AuditEntryResponse `json:"entries"`
+	Total   int64                `json:"total"`
+	Limit   int                  `json:"limit"`
+	Offset  int                  `json:"offset"`
+}
+
+// AuditChainVerificationResponse represents the result of verifying the audit chain
+type AuditChainVerificationResponse struct {
+	Valid                bool  `json:"valid"`
+	EntriesChecked       int64 `json:"entries_checked"`
+	FirstInvalidSequence int64 `json:"first_invalid_sequence,omitempty"`
+}
+
+// ListAuditEntries godoc
+// @Summary List audit log entries
+// @Description Lists the security-relevant actions of the current user, newest first
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param action query string false "Action, e.g. credential.created"
+// @Param resource_type query string false "Resource type (credential, api_key, user, provider)"
+// @Param resource_id query string false "Resource ID"
+// @Param outcome query string false "Outcome (success, failure)"
+// @Param from query string false "Earliest time, inclusive (RFC 3339)"
+// @Param to query string false "Latest time, exclusive (RFC 3339)"
+// @Param limit query int false "Limit (default: 50, max: 200)"
+// @Param offset query int false "Offset (default: 0)"
+// @Success 200 {object} httpapi.Response{data=AuditEntryListResponse} "Success response with audit log entries"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /users/me/audit [get]
+func (h *AuditHandler) ListAuditEntries(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return
+	}
+	user := userI.(*domain.User)
+
+	filter, ok := parseAuditFilter(c)
+	if !ok {
+		return
+	}
+
+	limit := 50
+	offset := 0
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
+			limit = min(parsedLimit, 200)
+		}
+	}
+	if offsetParam := c.Query("offset"); offsetParam != "" {
+		if parsedOffset, err := strconv.Atoi(offsetParam); err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	entries, total, err := h.auditService.ListEntries(ctx, user.ID, filter, offset, limit)
+	if err != nil {
+		h.obs.Logger.Error(ctx, "Failed to list audit entries", zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to list audit entries")
+		return
+	}
+
+	response := AuditEntryListResponse{
+		Entries: make([]AuditEntryResponse, len(entries)),
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	}
+	for i, entry := range entries {
+		response.Entries[i] = mapAuditEntryToResponse(entry)
+	}
+
+	httpapi.OK(c, response, "Audit entries retrieved successfully")
+}
+
+// ExportAuditEntries godoc
+// @Summary Export audit log entries
+// @Description Exports the security-relevant actions of the current user in chain order as JSON Lines or CSV
+// @Tags users
+// @Produce plain
+// @Security BearerAuth
+// @Param format query string false "Export format (jsonl, csv; default: jsonl)"
+// @Param action query string false "Action, e.g. credential.created"
+// @Param resource_type query string false "Resource type (credential, api_key, user, provider)"
+// @Param resource_id query string false "Resource ID"
+// @Param outcome query string false "Outcome (success, failure)"
+// @Param from query string false "Earliest time, inclusive (RFC 3339)"
+// @Param to query string false "Latest time, exclusive (RFC 3339)"
+// @Success 200 {string} string "Audit log entries, one per line"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Router /users/me/audit/export [get]
+func (h *AuditHandler) ExportAuditEntries(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return
+	}
+	user := userI.(*domain.User)
+
+	format := c.DefaultQuery("format", auditExportFormatJSONL)
+	if format != auditExportFormatJSONL && format != auditExportFormatCSV {
+		httpapi.BadRequest(c, "Invalid format, expected jsonl or csv")
+		return
+	}
+
+	filter, ok := parseAuditFilter(c)
+	if !ok {
+		return
+	}
+
+	filename := fmt.Sprintf("audit-%s.%s", time.Now().UTC().Format("20060102T150405Z"), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	var err error
+	if format == auditExportFormatCSV {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Status(http.StatusOK)
+
+		writer := csv.NewWriter(c.Writer)
+		if err = writer.Write(auditCSVHeader); err == nil {
+			err = h.auditService.ExportEntries(ctx, user.ID, filter, func(entry *domain.AuditEntry) error {
+				return writer.Write(auditEntryCSVRecord(entry))
+			})
+		}
+		writer.Flush()
+		if err == nil {
+			err = writer.Error()
+		}
+	} else {
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+
+		encoder := json.NewEncoder(c.Writer)
+		err = h.auditService.ExportEntries(ctx, user.ID, filter, func(entry *domain.AuditEntry) error {
+			return encoder.Encode(mapAuditEntryToResponse(entry))
+		})
+	}
+
+	// The status is already sent, a failed export is detected by its truncated content
+	if err != nil {
+		h.obs.Logger.Error(ctx, "Failed to export audit entries", zap.Error(err))
+	}
+}
+
+// VerifyAuditChain godoc
+// @Summary Verify audit log
+// @Description Recomputes the hash chain of the current user's audit log to detect altered or removed entries
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} httpapi.Response{data=AuditChainVerificationResponse} "Success response with the verification result"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /users/me/audit/verify [get]
+func (h *AuditHandler) VerifyAuditChain(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return
+	}
+	user := userI.(*domain.User)
+
+	verification, err := h.auditService.VerifyChain(ctx, user.ID)
+	if err != nil {
+		h.obs.Logger.Error(ctx, "Failed to verify audit chain", zap.Error(err))
+		httpapi.InternalServerError(c, "Failed to verify audit chain")
+		return
+	}
+
+	httpapi.OK(c, AuditChainVerificationResponse{
+		Valid:                verification.Valid,
+		EntriesChecked:       verification.EntriesChecked,
+		FirstInvalidSequence: verification.FirstInvalidSequence,
+	}, "Audit chain verified successfully")
+}
+
+// parseAuditFilter parses the audit filter query parameters, responding with a bad request if they are invalid
+func parseAuditFilter(c *gin.Context) (domain.AuditFilter, bool) {
+	filter := domain.AuditFilter{
+		Action:       domain.AuditAction(c.Query("action")),
+		ResourceType: c.Query("resource_type"),
+		ResourceID:   c.Query("resource_id"),
+		Outcome:      domain.AuditOutcome(c.Query("outcome")),
+	}
+
+	if filter.Outcome != "" && filter.Outcome != domain.AuditOutcomeSuccess && filter.Outcome != domain.AuditOutcomeFailure {
+		httpapi.BadRequest(c, "Invalid outcome, expected success or failure")
+		return filter, false
+	}
+
+	if fromParam := c.Query("from"); fromParam != "" {
+		from, err := time.Parse(time.RFC3339, fromParam)
+		if err != nil {
+			httpapi.BadRequest(c, "Invalid from time, expected RFC 3339")
+			return filter, false
+		}
+		filter.From = &from
+	}
+	if toParam := c.Query("to"); toParam != "" {
+		to, err := time.Parse(time.RFC3339, toParam)
+		if err != nil {
+			httpapi.BadRequest(c, "Invalid to time, expected RFC 3339")
+			return filter, false
+		}
+		filter.To = &to
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		httpapi.BadRequest(c, "from must be before to")
+		return filter, false
+	}
+
+	return filter, true
+}
+
+// mapAuditEntryToResponse maps a domain audit entry to its response
+func mapAuditEntryToResponse(entry *domain.AuditEntry) AuditEntryResponse {
+	return AuditEntryResponse{
+		ID:           entry.ID,
+		UserID:       entry.UserID,
+		Sequence:     entry.Sequence,
+		ActorID:      entry.ActorID,
+		AuthType:     entry.AuthType,
+		IPAddress:    entry.IPAddress,
+		UserAgent:    entry.UserAgent,
+		Action:       string(entry.Action),
+		ResourceType: entry.ResourceType,
+		ResourceID:   entry.ResourceID,
+		Outcome:      string(entry.Outcome),
+		Metadata:     entry.Metadata,
+		CreatedAt:    entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		PrevHash:     entry.PrevHash,
+		Hash:         entry.Hash,
+	}
+}
+
+// auditEntryCSVRecord maps a domain audit entry to a CSV record, metadata is encoded as a JSON object
+func auditEntryCSVRecord(entry *domain.AuditEntry) []string {
+	metadata, _ := json.Marshal(entry.Metadata)
+
+	return []string{
+		entry.ID,
+		entry.UserID,
+		strconv.FormatInt(entry.Sequence, 10),
+		entry.ActorID,
+		entry.AuthType,
+		entry.IPAddress,
+		entry.UserAgent,
+		string(entry.Action),
+		entry.ResourceType,
+		entry.ResourceID,
+		string(entry.Outcome),
+		string(metadata),
+		entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		entry.PrevHash,
+		entry.Hash,
+	}
+}
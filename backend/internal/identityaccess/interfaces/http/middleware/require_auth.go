@@ -5,16 +5,30 @@ import (
 
 	observability "github.com/context-space/cloud-observability"
 	"github.com/context-space/context-space/backend/internal/identityaccess/application"
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/shared/audit"
 	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+	"github.com/context-space/context-space/backend/internal/shared/utils"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
+// OrganizationHeader selects the organization a session or personal API key request acts on behalf of
+const OrganizationHeader = "X-Organization-ID"
+
+// ServiceAccountHeader selects a service account of the caller that a session or personal API key request acts as,
+// so that owners can connect the credentials of their service accounts and review their invocations
+const ServiceAccountHeader = "X-Service-Account-ID"
+
 // RequireAuth middleware ensures the user is authenticated and sets domain.User in context
 // This can be used by any module that needs auth with user information
+// Requests made with an organization API key or the organization header also get the organization ID and membership
+// Requests made with a service account API key or the service account header are made as the service account's principal
 func RequireAuth(
 	authService *application.AuthService,
 	userService *application.UserService,
+	organizationService *application.OrganizationService,
+	serviceAccountService *application.ServiceAccountService,
 	obs *observability.ObservabilityProvider,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -39,11 +53,13 @@ func RequireAuth(
 		}
 
 		authString := parts[1]
+		organizationID := c.GetHeader(OrganizationHeader)
+		serviceAccountID := c.GetHeader(ServiceAccountHeader)
+		requestPath := c.Request.URL.Path
 		// Check if it's a API key
 		if strings.HasPrefix(authString, "cs-") {
-			// API key authentication is only allowed for MCP requests
-			requestPath := c.Request.URL.Path
-			if !strings.HasPrefix(requestPath, "/v1/mcp") && !strings.HasPrefix(requestPath, "/v1/invocations") && requestPath != "/v1/credentials" {
+			// API key authentication is only allowed for MCP, invocation and credential requests
+			if _, ok := apiKeyScopeForPath(requestPath, true); !ok {
 				httpapi.Unauthorized(c, "API key authentication is not allowed for this request")
 				c.Abort()
 				return
@@ -56,12 +72,45 @@ func RequireAuth(
 				c.Abort()
 				return
 			}
+
+			scope, ok := apiKeyScopeForPath(requestPath, apiKey.ServiceAccountID != "")
+			if !ok {
+				httpapi.Unauthorized(c, "API key authentication is not allowed for this request")
+				c.Abort()
+				return
+			}
+			if !apiKey.HasScope(scope) {
+				httpapi.Forbidden(c, utils.StringsBuilder("API key is missing the ", string(scope), " scope"))
+				c.Abort()
+				return
+			}
+
 			c.Set("api_key", apiKey)
 			c.Set("user", user)
 			c.Set("auth_type", "api_key")
+
+			// Service accounts act on their own, not on behalf of an organization or another service account
+			if apiKey.ServiceAccountID != "" {
+				if organizationID != "" || (serviceAccountID != "" && serviceAccountID != apiKey.ServiceAccountID) {
+					httpapi.Forbidden(c, "Service account API keys cannot act on behalf of another account")
+					c.Abort()
+					return
+				}
+				serviceAccountID = ""
+			}
+
+			// Organization API keys always act on behalf of their organization
+			if apiKey.OrganizationID != "" {
+				if organizationID != "" && organizationID != apiKey.OrganizationID {
+					httpapi.Forbidden(c, "API key does not belong to this organization")
+					c.Abort()
+					return
+				}
+				organizationID = apiKey.OrganizationID
+			}
 		} else {
-			// Validate token with Supabase
-			claims, err := authService.ValidateToken(ctx, authString)
+			// Validate token with the configured identity provider
+			identity, err := authService.ValidateToken(ctx, authString)
 			if err != nil {
 				obs.Logger.Debug(ctx, "Invalid token", zap.Error(err))
 				httpapi.Unauthorized(c, "Invalid token")
@@ -70,7 +119,7 @@ func RequireAuth(
 			}
 
 			// Get or create user from token
-			user, err := authService.FindOrCreateUser(ctx, claims)
+			user, err := authService.FindOrCreateUser(ctx, identity)
 			if err != nil {
 				obs.Logger.Debug(ctx, "Failed to get or create user from token", zap.Error(err))
 				httpapi.Unauthorized(c, "Failed to authenticate user")
@@ -78,11 +127,97 @@ func RequireAuth(
 				return
 			}
 
-			c.Set("supabase_claims", claims)
+			c.Set("identity", identity)
 			c.Set("user", user)
-			c.Set("auth_type", "supabase")
+			c.Set("auth_type", identity.Provider)
+		}
+
+		// Owners act as one of their service accounts with the service account header
+		if serviceAccountID != "" {
+			if !serviceAccountHeaderAllowed(requestPath) {
+				httpapi.BadRequest(c, "The service account header is not allowed for this request")
+				c.Abort()
+				return
+			}
+			if organizationID != "" {
+				httpapi.BadRequest(c, "The service account and organization headers cannot be combined")
+				c.Abort()
+				return
+			}
+
+			owner := c.MustGet("user").(*domain.User)
+			serviceAccount, err := serviceAccountService.GetManagedServiceAccount(ctx, owner.ID, serviceAccountID)
+			if err != nil {
+				obs.Logger.Debug(ctx, "Service account not manageable by user",
+					zap.String("service_account_id", serviceAccountID),
+					zap.Error(err))
+				httpapi.Forbidden(c, "Cannot act as this service account")
+				c.Abort()
+				return
+			}
+			c.Set("service_account_owner", owner)
+			c.Set("user", serviceAccount.Principal())
+		}
+
+		// Attribute security-relevant actions of the request to the authenticated user
+		user := c.MustGet("user").(*domain.User)
+		requestInfo := audit.RequestInfoFromContext(ctx)
+		requestInfo.ActorID = user.ID
+		if owner, ok := c.Get("service_account_owner"); ok {
+			// The owner acting as a service account remains accountable for the request
+			requestInfo.ActorID = owner.(*domain.User).ID
+		}
+		requestInfo.AuthType = c.GetString("auth_type")
+		if apiKey, ok := c.Get("api_key"); ok {
+			requestInfo.APIKeyID = apiKey.(*domain.APIKey).ID
+		}
+		ctx = audit.WithRequestInfo(ctx, requestInfo)
+		c.Request = c.Request.WithContext(ctx)
+
+		if organizationID != "" {
+			_, membership, err := organizationService.GetMembership(ctx, organizationID, user.ID)
+			if err != nil {
+				obs.Logger.Debug(ctx, "Organization membership not found",
+					zap.String("organization_id", organizationID),
+					zap.Error(err))
+				httpapi.Forbidden(c, "Not a member of this organization")
+				c.Abort()
+				return
+			}
+			c.Set("organization_id", organizationID)
+			c.Set("organization_membership", membership)
 		}
 
 		c.Next()
 	}
 }
+
+// apiKeyScopeForPath returns the scope an API key needs for a request path, false if API keys cannot be used for it
+// Service accounts have no session, so their keys also reach the credential and usage endpoints
+func apiKeyScopeForPath(requestPath string, serviceAccount bool) (domain.APIKeyScope, bool) {
+	switch {
+	case strings.HasPrefix(requestPath, "/v1/mcp"):
+		return domain.APIKeyScopeMCP, true
+	case strings.HasPrefix(requestPath, "/v1/invocations"):
+		return domain.APIKeyScopeInvocations, true
+	case requestPath == "/v1/credentials":
+		return domain.APIKeyScopeCredentials, true
+	case strings.HasPrefix(requestPath, "/v1/webhooks/events"):
+		return domain.APIKeyScopeWebhooks, true
+	case serviceAccount && strings.HasPrefix(requestPath, "/v1/credentials/"):
+		return domain.APIKeyScopeCredentials, true
+	case serviceAccount && requestPath == "/v1/users/me/usage":
+		return domain.APIKeyScopeInvocations, true
+	}
+	return "", false
+}
+
+// serviceAccountHeaderAllowed returns true if owners can act as a service account for a request path,
+// which covers the endpoints of service account keys and the approval of their gated invocations
+func serviceAccountHeaderAllowed(requestPath string) bool {
+	if strings.HasPrefix(requestPath, "/v1/approvals") {
+		return true
+	}
+	_, ok := apiKeyScopeForPath(requestPath, true)
+	return ok
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+)
+
+func TestAPIKeyScopeForPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		serviceAccount bool
+		expectedScope  domain.APIKeyScope
+		expectedOK     bool
+	}{
+		{name: "MCP", path: "/v1/mcp/tools", expectedScope: domain.APIKeyScopeMCP, expectedOK: true},
+		{name: "Invocations", path: "/v1/invocations/github/create_issue", expectedScope: domain.APIKeyScopeInvocations, expectedOK: true},
+		{name: "CredentialList", path: "/v1/credentials", expectedScope: domain.APIKeyScopeCredentials, expectedOK: true},
+		{name: "WebhookEvents", path: "/v1/webhooks/events/stream", expectedScope: domain.APIKeyScopeWebhooks, expectedOK: true},
+		{name: "UserCredentialEndpointRefused", path: "/v1/credentials/github/oauth/url"},
+		{name: "UserUsageRefused", path: "/v1/users/me/usage"},
+		{name: "ServiceAccountCredentialEndpoint", path: "/v1/credentials/github/oauth/url", serviceAccount: true, expectedScope: domain.APIKeyScopeCredentials, expectedOK: true},
+		{name: "ServiceAccountUsage", path: "/v1/users/me/usage", serviceAccount: true, expectedScope: domain.APIKeyScopeInvocations, expectedOK: true},
+		{name: "ServiceAccountUserProfileRefused", path: "/v1/users/me", serviceAccount: true},
+		{name: "APIKeyManagementRefused", path: "/v1/users/me/apikeys"},
+		{name: "ServiceAccountAPIKeyManagementRefused", path: "/v1/service-accounts/sa-1/apikeys", serviceAccount: true},
+		{name: "WebhookSubscriptionsRefused", path: "/v1/webhooks/subscriptions"},
+		{name: "ApprovalsRefused", path: "/v1/approvals", serviceAccount: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope, ok := apiKeyScopeForPath(tt.path, tt.serviceAccount)
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedScope, scope)
+		})
+	}
+}
+
+func TestServiceAccountHeaderAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected bool
+	}{
+		{name: "MCP", path: "/v1/mcp", expected: true},
+		{name: "Invocations", path: "/v1/invocations", expected: true},
+		{name: "CredentialEndpoint", path: "/v1/credentials/github/apikey", expected: true},
+		{name: "Usage", path: "/v1/users/me/usage", expected: true},
+		{name: "Approvals", path: "/v1/approvals/inv-1/approve", expected: true},
+		{name: "UserProfile", path: "/v1/users/me"},
+		{name: "ServiceAccountManagement", path: "/v1/service-accounts"},
+		{name: "Organizations", path: "/v1/organizations/org-1/members"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, serviceAccountHeaderAllowed(tt.path))
+		})
+	}
+}
@@ -0,0 +1,583 @@
+package http
+
+import (
+	"net/http"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/identityaccess/application"
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/shared/apierrors"
+	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+	"github.com/context-space/context-space/backend/internal/shared/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationHandler handles HTTP requests for organizations, their members and API keys
+type OrganizationHandler struct {
+	organizationService *application.OrganizationService
+	obs                 *observability.ObservabilityProvider
+}
+
+// NewOrganizationHandler creates a new organization handler
+func NewOrganizationHandler(organizationService *application.OrganizationService, observabilityProvider *observability.ObservabilityProvider) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationService: organizationService,
+		obs:                 observabilityProvider,
+	}
+}
+
+// RegisterRoutes registers the organization routes
+func (h *OrganizationHandler) RegisterRoutes(router *gin.RouterGroup, requireAuth gin.HandlerFunc) {
+	organizations := router.Group("/organizations")
+	organizations.Use(requireAuth)
+	{
+		organizations.POST("", h.CreateOrganization)
+		organizations.GET("", h.ListOrganizations)
+		organizations.GET("/:organization_id", h.GetOrganization)
+		organizations.PATCH("/:organization_id", h.UpdateOrganization)
+		organizations.DELETE("/:organization_id", h.DeleteOrganization)
+
+		// Member routes
+		organizations.GET("/:organization_id/members", h.ListMembers)
+		organizations.POST("/:organization_id/members", h.AddMember)
+		organizations.PATCH("/:organization_id/members/:user_id", h.UpdateMember)
+		organizations.DELETE("/:organization_id/members/:user_id", h.RemoveMember)
+
+		// API Key routes
+		organizations.POST("/:organization_id/apikeys", h.CreateAPIKey)
+		organizations.GET("/:organization_id/apikeys", h.ListAPIKeys)
+		organizations.DELETE("/:organization_id/apikeys/:keyID", h.DeleteAPIKey)
+	}
+}
+
+// OrganizationResponse represents an organization and the current user's role in it
+type OrganizationResponse struct {
+	ID                   string `json:"id"`
+	Name                 string `json:"name"`
+	SharedCredentialRole string `json:"shared_credential_role"`
+	Role                 string `json:"role,omitempty"`
+	CreatedAt            string `json:"created_at"`
+}
+
+// ListOrganizationsResponse represents the response for listing organizations
+type ListOrganizationsResponse struct {
+	Organizations []OrganizationResponse `json:"organizations"`
+}
+
+// CreateOrganizationRequest represents the request to create an organization
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// UpdateOrganizationRequest represents the request to update an organization
+type UpdateOrganizationRequest struct {
+	Name string `json:"name"`
+	// SharedCredentialRole is the minimum role allowed to invoke operations with the organization's credentials
+	SharedCredentialRole string `json:"shared_credential_role" enums:"owner,admin,member"`
+}
+
+// MemberResponse represents a member of an organization
+type MemberResponse struct {
+	UserID    string `json:"user_id"`
+	Email     string `json:"email,omitempty"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ListMembersResponse represents the response for listing organization members
+type ListMembersResponse struct {
+	Members []MemberResponse `json:"members"`
+}
+
+// AddMemberRequest represents the request to add a user to an organization
+type AddMemberRequest struct {
+	Email string `json:"email" binding:"required"`
+	Role  string `json:"role" binding:"required" enums:"owner,admin,member"`
+}
+
+// UpdateMemberRequest represents the request to change the role of a member
+type UpdateMemberRequest struct {
+	Role string `json:"role" binding:"required" enums:"owner,admin,member"`
+}
+
+// CreateOrganization godoc
+// @Summary Create organization
+// @Description Creates an organization owned by the current user
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateOrganizationRequest true "Create organization request"
+// @Success 201 {object} httpapi.Response{data=OrganizationResponse} "Success response with created organization"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations [post]
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request: ", err.Error()))
+		return
+	}
+
+	organization, err := h.organizationService.CreateOrganization(ctx, user.ID, req.Name)
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to create organization")
+		return
+	}
+
+	httpapi.Created(c, mapOrganizationToResponse(organization, domain.OrganizationRoleOwner), "Organization created successfully")
+}
+
+// ListOrganizations godoc
+// @Summary List organizations
+// @Description Lists the organizations the current user is a member of
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} httpapi.Response{data=ListOrganizationsResponse} "Success response with list of organizations"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations [get]
+func (h *OrganizationHandler) ListOrganizations(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	organizations, err := h.organizationService.ListOrganizations(ctx, user.ID)
+	if err != nil {
+		httpapi.InternalServerError(c, "Failed to list organizations")
+		return
+	}
+
+	response := ListOrganizationsResponse{Organizations: make([]OrganizationResponse, len(organizations))}
+	for i, organization := range organizations {
+		response.Organizations[i] = mapOrganizationToResponse(organization, "")
+	}
+
+	httpapi.OK(c, response, "Organizations retrieved successfully")
+}
+
+// GetOrganization godoc
+// @Summary Get organization
+// @Description Gets an organization the current user is a member of, with the user's role
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {object} httpapi.Response{data=OrganizationResponse} "Success response with organization data"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id} [get]
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	organization, membership, err := h.organizationService.GetMembership(ctx, c.Param("organization_id"), user.ID)
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to get organization")
+		return
+	}
+
+	httpapi.OK(c, mapOrganizationToResponse(organization, membership.Role), "Organization retrieved successfully")
+}
+
+// UpdateOrganization godoc
+// @Summary Update organization
+// @Description Renames an organization or changes the minimum role allowed to use its credentials, admins only
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Param request body UpdateOrganizationRequest true "Update organization request"
+// @Success 200 {object} httpapi.Response{data=OrganizationResponse} "Success response with updated organization"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id} [patch]
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request: ", err.Error()))
+		return
+	}
+
+	organization, err := h.organizationService.UpdateOrganization(
+		ctx,
+		c.Param("organization_id"),
+		user.ID,
+		req.Name,
+		domain.OrganizationRole(req.SharedCredentialRole),
+	)
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to update organization")
+		return
+	}
+
+	httpapi.OK(c, mapOrganizationToResponse(organization, ""), "Organization updated successfully")
+}
+
+// DeleteOrganization godoc
+// @Summary Delete organization
+// @Description Deletes an organization with its members, API keys and credentials, owners only
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Success 204 "No content success response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id} [delete]
+func (h *OrganizationHandler) DeleteOrganization(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.organizationService.DeleteOrganization(ctx, c.Param("organization_id"), user.ID); err != nil {
+		respondWithServiceError(c, err, "Failed to delete organization")
+		return
+	}
+
+	httpapi.NoContent(c)
+}
+
+// ListMembers godoc
+// @Summary List organization members
+// @Description Lists the members of an organization and their roles
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {object} httpapi.Response{data=ListMembersResponse} "Success response with list of members"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/members [get]
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.organizationService.ListMembers(ctx, c.Param("organization_id"), user.ID)
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to list members")
+		return
+	}
+
+	response := ListMembersResponse{Members: make([]MemberResponse, len(members))}
+	for i, member := range members {
+		response.Members[i] = mapMemberToResponse(member.Membership, member.Email)
+	}
+
+	httpapi.OK(c, response, "Members retrieved successfully")
+}
+
+// AddMember godoc
+// @Summary Add organization member
+// @Description Adds an existing user to an organization, admins only, owners only for the owner role
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Param request body AddMemberRequest true "Add member request"
+// @Success 201 {object} httpapi.Response{data=MemberResponse} "Success response with added member"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 409 {object} httpapi.SwaggerErrorResponse "Conflict error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/members [post]
+func (h *OrganizationHandler) AddMember(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request: ", err.Error()))
+		return
+	}
+
+	member, err := h.organizationService.AddMember(ctx, c.Param("organization_id"), user.ID, req.Email, domain.OrganizationRole(req.Role))
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to add member")
+		return
+	}
+
+	httpapi.Created(c, mapMemberToResponse(member.Membership, member.Email), "Member added successfully")
+}
+
+// UpdateMember godoc
+// @Summary Update organization member
+// @Description Changes the role of a member, admins only, owners only for owners
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Param user_id path string true "User ID"
+// @Param request body UpdateMemberRequest true "Update member request"
+// @Success 200 {object} httpapi.Response{data=MemberResponse} "Success response with updated member"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/members/{user_id} [patch]
+func (h *OrganizationHandler) UpdateMember(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request: ", err.Error()))
+		return
+	}
+
+	membership, err := h.organizationService.UpdateMemberRole(ctx, c.Param("organization_id"), user.ID, c.Param("user_id"), domain.OrganizationRole(req.Role))
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to update member")
+		return
+	}
+
+	httpapi.OK(c, mapMemberToResponse(membership, ""), "Member updated successfully")
+}
+
+// RemoveMember godoc
+// @Summary Remove organization member
+// @Description Removes a member from an organization with their organization API keys, members may remove themselves
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Param user_id path string true "User ID"
+// @Success 204 "No content success response"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/members/{user_id} [delete]
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.organizationService.RemoveMember(ctx, c.Param("organization_id"), user.ID, c.Param("user_id")); err != nil {
+		respondWithServiceError(c, err, "Failed to remove member")
+		return
+	}
+
+	httpapi.NoContent(c)
+}
+
+// CreateAPIKey godoc
+// @Summary Create organization API key
+// @Description Creates an API key that invokes operations on behalf of the organization, admins only
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Param request body CreateAPIKeyRequest true "Create API key request"
+// @Success 201 {object} httpapi.Response{data=APIKeyResponse} "Success response with created API key data"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/apikeys [post]
+func (h *OrganizationHandler) CreateAPIKey(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request: ", err.Error()))
+		return
+	}
+
+	apiKey, err := h.organizationService.CreateAPIKey(ctx, c.Param("organization_id"), user.ID, req.Name, req.Description)
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to create API key")
+		return
+	}
+
+	httpapi.Created(c, APIKeyResponse{
+		ID:          apiKey.ID,
+		KeyValue:    apiKey.KeyValue,
+		Name:        apiKey.Name,
+		Description: apiKey.Description,
+		CreatedAt:   apiKey.CreatedAt.Format(http.TimeFormat),
+	}, "API key created successfully")
+}
+
+// ListAPIKeys godoc
+// @Summary List organization API keys
+// @Description Lists the API keys of an organization, admins only
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {object} httpapi.Response{data=ListAPIKeysResponse} "Success response with list of API keys"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/apikeys [get]
+func (h *OrganizationHandler) ListAPIKeys(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	apiKeys, err := h.organizationService.ListAPIKeys(ctx, c.Param("organization_id"), user.ID)
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to list API keys")
+		return
+	}
+
+	apiKeyResponses := make([]APIKeyResponse, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		apiKeyResponses[i] = APIKeyResponse{
+			ID:          apiKey.ID,
+			Name:        apiKey.Name,
+			KeyValue:    apiKey.KeyValue[3:11],
+			Description: apiKey.Description,
+			CreatedAt:   apiKey.CreatedAt.Format(http.TimeFormat),
+		}
+	}
+
+	httpapi.OK(c, ListAPIKeysResponse{
+		APIKeys: apiKeyResponses,
+	}, "API keys retrieved successfully")
+}
+
+// DeleteAPIKey godoc
+// @Summary Delete organization API key
+// @Description Deletes an API key of an organization, admins only
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Param keyID path string true "API Key ID"
+// @Success 204 "No content success response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/apikeys/{keyID} [delete]
+func (h *OrganizationHandler) DeleteAPIKey(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.organizationService.DeleteAPIKey(ctx, c.Param("organization_id"), user.ID, c.Param("keyID")); err != nil {
+		respondWithServiceError(c, err, "Failed to delete API key")
+		return
+	}
+
+	httpapi.NoContent(c)
+}
+
+// currentUser returns the authenticated user, responding with 401 if there is none
+func currentUser(c *gin.Context) (*domain.User, bool) {
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return nil, false
+	}
+	return userI.(*domain.User), true
+}
+
+// respondWithServiceError writes the status of an API error, internal errors use the fallback message
+func respondWithServiceError(c *gin.Context, err error, fallback string) {
+	apiErr, ok := err.(*apierrors.APIError)
+	if !ok || apiErr.HTTPCode == http.StatusInternalServerError || apiErr.Message == "" {
+		httpapi.InternalServerError(c, fallback)
+		return
+	}
+	httpapi.RespondWithAPIError(c, apiErr)
+}
+
+// mapOrganizationToResponse maps an organization to a response, role is omitted if empty
+func mapOrganizationToResponse(organization *domain.Organization, role domain.OrganizationRole) OrganizationResponse {
+	return OrganizationResponse{
+		ID:                   organization.ID,
+		Name:                 organization.Name,
+		SharedCredentialRole: string(organization.SharedCredentialRole),
+		Role:                 string(role),
+		CreatedAt:            organization.CreatedAt.Format(http.TimeFormat),
+	}
+}
+
+// mapMemberToResponse maps a membership to a response
+func mapMemberToResponse(membership *domain.Membership, email string) MemberResponse {
+	return MemberResponse{
+		UserID:    membership.UserID,
+		Email:     email,
+		Role:      string(membership.Role),
+		CreatedAt: membership.CreatedAt.Format(http.TimeFormat),
+	}
+}
@@ -0,0 +1,476 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/identityaccess/application"
+	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+	"github.com/context-space/context-space/backend/internal/shared/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// ServiceAccountHandler handles HTTP requests for the service accounts of users and organizations and their API keys
+type ServiceAccountHandler struct {
+	serviceAccountService *application.ServiceAccountService
+	obs                   *observability.ObservabilityProvider
+}
+
+// NewServiceAccountHandler creates a new service account handler
+func NewServiceAccountHandler(serviceAccountService *application.ServiceAccountService, observabilityProvider *observability.ObservabilityProvider) *ServiceAccountHandler {
+	return &ServiceAccountHandler{
+		serviceAccountService: serviceAccountService,
+		obs:                   observabilityProvider,
+	}
+}
+
+// RegisterRoutes registers the service account routes
+func (h *ServiceAccountHandler) RegisterRoutes(router *gin.RouterGroup, requireAuth gin.HandlerFunc) {
+	serviceAccounts := router.Group("/service-accounts")
+	serviceAccounts.Use(requireAuth)
+	{
+		serviceAccounts.POST("", h.CreateServiceAccount)
+		serviceAccounts.GET("", h.ListServiceAccounts)
+		serviceAccounts.GET("/:service_account_id", h.GetServiceAccount)
+		serviceAccounts.PATCH("/:service_account_id", h.UpdateServiceAccount)
+		serviceAccounts.DELETE("/:service_account_id", h.DeleteServiceAccount)
+
+		// API Key routes
+		serviceAccounts.POST("/:service_account_id/apikeys", h.CreateAPIKey)
+		serviceAccounts.GET("/:service_account_id/apikeys", h.ListAPIKeys)
+		serviceAccounts.DELETE("/:service_account_id/apikeys/:keyID", h.DeleteAPIKey)
+	}
+
+	organizations := router.Group("/organizations")
+	organizations.Use(requireAuth)
+	{
+		organizations.POST("/:organization_id/service-accounts", h.CreateOrganizationServiceAccount)
+		organizations.GET("/:organization_id/service-accounts", h.ListOrganizationServiceAccounts)
+	}
+}
+
+// ServiceAccountResponse represents a service account
+type ServiceAccountResponse struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	CreatedBy      string `json:"created_by"`
+	OrganizationID string `json:"organization_id,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// ListServiceAccountsResponse represents the response for listing service accounts
+type ListServiceAccountsResponse struct {
+	ServiceAccounts []ServiceAccountResponse `json:"service_accounts"`
+}
+
+// ServiceAccountRequest represents the request to create or update a service account
+type ServiceAccountRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// CreateServiceAccountAPIKeyRequest represents the request to create an API key of a service account
+type CreateServiceAccountAPIKeyRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	// Scopes restricts the key to some endpoints, all endpoints open to service accounts are allowed if empty
+	Scopes []string `json:"scopes" enums:"mcp,invocations,credentials,webhooks"`
+	// ExpiresAt is the RFC 3339 expiry of the key, at most a year from now
+	ExpiresAt time.Time `json:"expires_at" binding:"required"`
+}
+
+// ServiceAccountAPIKeyResponse represents an API key of a service account
+type ServiceAccountAPIKeyResponse struct {
+	ID          string   `json:"id"`
+	KeyValue    string   `json:"key_value,omitempty"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Scopes      []string `json:"scopes"`
+	ExpiresAt   string   `json:"expires_at"`
+	LastUsed    string   `json:"last_used,omitempty"`
+	CreatedAt   string   `json:"created_at"`
+}
+
+// ListServiceAccountAPIKeysResponse represents the response for listing the API keys of a service account
+type ListServiceAccountAPIKeysResponse struct {
+	APIKeys []ServiceAccountAPIKeyResponse `json:"api_keys"`
+}
+
+// CreateServiceAccount godoc
+// @Summary Create service account
+// @Description Creates a service account owned by the current user. Service accounts cannot log in, they authenticate with their own API keys and hold their own credentials. Send the X-Service-Account-ID header with a session to connect credentials or review invocations as the service account.
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ServiceAccountRequest true "Create service account request"
+// @Success 201 {object} httpapi.Response{data=ServiceAccountResponse} "Success response with created service account"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /service-accounts [post]
+func (h *ServiceAccountHandler) CreateServiceAccount(c *gin.Context) {
+	h.createServiceAccount(c, "")
+}
+
+// CreateOrganizationServiceAccount godoc
+// @Summary Create organization service account
+// @Description Creates a service account owned by an organization and managed by its admins, admins only
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Param request body ServiceAccountRequest true "Create service account request"
+// @Success 201 {object} httpapi.Response{data=ServiceAccountResponse} "Success response with created service account"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/service-accounts [post]
+func (h *ServiceAccountHandler) CreateOrganizationServiceAccount(c *gin.Context) {
+	h.createServiceAccount(c, c.Param("organization_id"))
+}
+
+// ListServiceAccounts godoc
+// @Summary List service accounts
+// @Description Lists the service accounts owned by the current user
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} httpapi.Response{data=ListServiceAccountsResponse} "Success response with list of service accounts"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /service-accounts [get]
+func (h *ServiceAccountHandler) ListServiceAccounts(c *gin.Context) {
+	h.listServiceAccounts(c, "")
+}
+
+// ListOrganizationServiceAccounts godoc
+// @Summary List organization service accounts
+// @Description Lists the service accounts owned by an organization, admins only
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {object} httpapi.Response{data=ListServiceAccountsResponse} "Success response with list of service accounts"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /organizations/{organization_id}/service-accounts [get]
+func (h *ServiceAccountHandler) ListOrganizationServiceAccounts(c *gin.Context) {
+	h.listServiceAccounts(c, c.Param("organization_id"))
+}
+
+// GetServiceAccount godoc
+// @Summary Get service account
+// @Description Gets a service account the current user owns or manages as an organization admin
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param service_account_id path string true "Service account ID"
+// @Success 200 {object} httpapi.Response{data=ServiceAccountResponse} "Success response with service account"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /service-accounts/{service_account_id} [get]
+func (h *ServiceAccountHandler) GetServiceAccount(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceAccount, err := h.serviceAccountService.GetManagedServiceAccount(ctx, user.ID, c.Param("service_account_id"))
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to get service account")
+		return
+	}
+
+	httpapi.OK(c, mapServiceAccountToResponse(serviceAccount), "Service account retrieved successfully")
+}
+
+// UpdateServiceAccount godoc
+// @Summary Update service account
+// @Description Updates the name and description of a service account
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param service_account_id path string true "Service account ID"
+// @Param request body ServiceAccountRequest true "Update service account request"
+// @Success 200 {object} httpapi.Response{data=ServiceAccountResponse} "Success response with updated service account"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /service-accounts/{service_account_id} [patch]
+func (h *ServiceAccountHandler) UpdateServiceAccount(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req ServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request: ", err.Error()))
+		return
+	}
+
+	serviceAccount, err := h.serviceAccountService.UpdateServiceAccount(ctx, user.ID, c.Param("service_account_id"), req.Name, req.Description)
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to update service account")
+		return
+	}
+
+	httpapi.OK(c, mapServiceAccountToResponse(serviceAccount), "Service account updated successfully")
+}
+
+// DeleteServiceAccount godoc
+// @Summary Delete service account
+// @Description Deletes a service account together with its API keys and credentials
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param service_account_id path string true "Service account ID"
+// @Success 204 "No content success response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /service-accounts/{service_account_id} [delete]
+func (h *ServiceAccountHandler) DeleteServiceAccount(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.serviceAccountService.DeleteServiceAccount(ctx, user.ID, c.Param("service_account_id")); err != nil {
+		respondWithServiceError(c, err, "Failed to delete service account")
+		return
+	}
+
+	httpapi.NoContent(c)
+}
+
+// CreateAPIKey godoc
+// @Summary Create service account API key
+// @Description Creates an API key authenticating a service account, with optional scopes and a mandatory expiry
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param service_account_id path string true "Service account ID"
+// @Param request body CreateServiceAccountAPIKeyRequest true "Create API key request"
+// @Success 201 {object} httpapi.Response{data=ServiceAccountAPIKeyResponse} "Success response with created API key"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /service-accounts/{service_account_id}/apikeys [post]
+func (h *ServiceAccountHandler) CreateAPIKey(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req CreateServiceAccountAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request: ", err.Error()))
+		return
+	}
+
+	scopes := make([]domain.APIKeyScope, len(req.Scopes))
+	for i, scope := range req.Scopes {
+		scopes[i] = domain.APIKeyScope(scope)
+	}
+
+	apiKey, err := h.serviceAccountService.CreateAPIKey(ctx, user.ID, c.Param("service_account_id"), application.CreateServiceAccountAPIKeyInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Scopes:      scopes,
+		ExpiresAt:   req.ExpiresAt,
+	})
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to create API key")
+		return
+	}
+
+	response := mapServiceAccountAPIKeyToResponse(apiKey)
+	response.KeyValue = apiKey.KeyValue
+	httpapi.Created(c, response, "API key created successfully")
+}
+
+// ListAPIKeys godoc
+// @Summary List service account API keys
+// @Description Lists the API keys of a service account
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param service_account_id path string true "Service account ID"
+// @Success 200 {object} httpapi.Response{data=ListServiceAccountAPIKeysResponse} "Success response with list of API keys"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /service-accounts/{service_account_id}/apikeys [get]
+func (h *ServiceAccountHandler) ListAPIKeys(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	apiKeys, err := h.serviceAccountService.ListAPIKeys(ctx, user.ID, c.Param("service_account_id"))
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to list API keys")
+		return
+	}
+
+	apiKeyResponses := make([]ServiceAccountAPIKeyResponse, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		apiKeyResponses[i] = mapServiceAccountAPIKeyToResponse(apiKey)
+		apiKeyResponses[i].KeyValue = apiKey.KeyValue[3:11]
+	}
+
+	httpapi.OK(c, ListServiceAccountAPIKeysResponse{
+		APIKeys: apiKeyResponses,
+	}, "API keys retrieved successfully")
+}
+
+// DeleteAPIKey godoc
+// @Summary Delete service account API key
+// @Description Deletes an API key of a service account
+// @Tags service-accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param service_account_id path string true "Service account ID"
+// @Param keyID path string true "API Key ID"
+// @Success 204 "No content success response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /service-accounts/{service_account_id}/apikeys/{keyID} [delete]
+func (h *ServiceAccountHandler) DeleteAPIKey(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.serviceAccountService.DeleteAPIKey(ctx, user.ID, c.Param("service_account_id"), c.Param("keyID")); err != nil {
+		respondWithServiceError(c, err, "Failed to delete API key")
+		return
+	}
+
+	httpapi.NoContent(c)
+}
+
+// createServiceAccount creates a service account owned by the current user, or by the organization if not empty
+func (h *ServiceAccountHandler) createServiceAccount(c *gin.Context, organizationID string) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req ServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request: ", err.Error()))
+		return
+	}
+
+	serviceAccount, err := h.serviceAccountService.CreateServiceAccount(ctx, user.ID, organizationID, req.Name, req.Description)
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to create service account")
+		return
+	}
+
+	httpapi.Created(c, mapServiceAccountToResponse(serviceAccount), "Service account created successfully")
+}
+
+// listServiceAccounts lists the service accounts of the current user, or of the organization if not empty
+func (h *ServiceAccountHandler) listServiceAccounts(c *gin.Context, organizationID string) {
+	ctx := c.Request.Context()
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	serviceAccounts, err := h.serviceAccountService.ListServiceAccounts(ctx, user.ID, organizationID)
+	if err != nil {
+		respondWithServiceError(c, err, "Failed to list service accounts")
+		return
+	}
+
+	responses := make([]ServiceAccountResponse, len(serviceAccounts))
+	for i, serviceAccount := range serviceAccounts {
+		responses[i] = mapServiceAccountToResponse(serviceAccount)
+	}
+
+	httpapi.OK(c, ListServiceAccountsResponse{
+		ServiceAccounts: responses,
+	}, "Service accounts retrieved successfully")
+}
+
+// mapServiceAccountToResponse maps a service account to a response
+func mapServiceAccountToResponse(serviceAccount *domain.ServiceAccount) ServiceAccountResponse {
+	return ServiceAccountResponse{
+		ID:             serviceAccount.ID,
+		Name:           serviceAccount.Name,
+		Description:    serviceAccount.Description,
+		CreatedBy:      serviceAccount.CreatedBy,
+		OrganizationID: serviceAccount.OrganizationID,
+		CreatedAt:      serviceAccount.CreatedAt.Format(http.TimeFormat),
+	}
+}
+
+// mapServiceAccountAPIKeyToResponse maps an API key of a service account to a response without its value
+func mapServiceAccountAPIKeyToResponse(apiKey *domain.APIKey) ServiceAccountAPIKeyResponse {
+	scopes := make([]string, len(apiKey.Scopes))
+	for i, scope := range apiKey.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	response := ServiceAccountAPIKeyResponse{
+		ID:          apiKey.ID,
+		Name:        apiKey.Name,
+		Description: apiKey.Description,
+		Scopes:      scopes,
+		CreatedAt:   apiKey.CreatedAt.Format(http.TimeFormat),
+	}
+	if apiKey.ExpiresAt != nil {
+		response.ExpiresAt = apiKey.ExpiresAt.Format(http.TimeFormat)
+	}
+	if apiKey.LastUsed != nil {
+		response.LastUsed = apiKey.LastUsed.Format(http.TimeFormat)
+	}
+	return response
+}
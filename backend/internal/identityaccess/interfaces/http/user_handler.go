@@ -235,8 +235,8 @@ func (h *UserHandler) GetAPIKey(c *gin.Context) {
 		return
 	}
 
-	// Verify that the API key belongs to the user
-	if apiKey.UserID != user.ID {
+	// Verify that the API key is a personal key of the user
+	if apiKey.UserID != user.ID || apiKey.OrganizationID != "" || apiKey.ServiceAccountID != "" {
 		httpapi.Forbidden(c, "API key does not belong to this user")
 		return
 	}
@@ -287,7 +287,7 @@ func (h *UserHandler) DeleteAPIKey(c *gin.Context) {
 		return
 	}
 
-	if apiKey.UserID != user.ID {
+	if apiKey.UserID != user.ID || apiKey.OrganizationID != "" || apiKey.ServiceAccountID != "" {
 		httpapi.Forbidden(c, "API key does not belong to this user")
 		return
 	}
@@ -3,8 +3,10 @@ package identityaccess
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/identityaccess/infrastructure/oidc"
 	"github.com/context-space/context-space/backend/internal/identityaccess/infrastructure/supabase"
 	"github.com/context-space/context-space/backend/internal/identityaccess/interfaces/http/middleware"
 
@@ -13,19 +15,28 @@ import (
 	observability "github.com/context-space/cloud-observability"
 	"github.com/context-space/context-space/backend/internal/identityaccess/application"
 	"github.com/context-space/context-space/backend/internal/identityaccess/infrastructure/persistence"
+	iacontract "github.com/context-space/context-space/backend/internal/identityaccess/interfaces/contract"
 	iahttp "github.com/context-space/context-space/backend/internal/identityaccess/interfaces/http"
 	"github.com/context-space/context-space/backend/internal/shared/config"
+	contractIdentity "github.com/context-space/context-space/backend/internal/shared/contract/identityaccess"
 	"github.com/context-space/context-space/backend/internal/shared/events"
 	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
 )
 
 // Module encapsulates all identity and access components
 type Module struct {
-	userService *application.UserService
-	userHandler *iahttp.UserHandler
-	authService *application.AuthService
-	userRepo    domain.UserRepository
-	obs         *observability.ObservabilityProvider
+	userService                *application.UserService
+	userHandler                *iahttp.UserHandler
+	organizationService        *application.OrganizationService
+	organizationHandler        *iahttp.OrganizationHandler
+	organizationContractFacade contractIdentity.OrganizationReader
+	auditService               *application.AuditService
+	auditHandler               *iahttp.AuditHandler
+	serviceAccountService      *application.ServiceAccountService
+	serviceAccountHandler      *iahttp.ServiceAccountHandler
+	authService                *application.AuthService
+	userRepo                   domain.UserRepository
+	obs                        *observability.ObservabilityProvider
 }
 
 // NewModule creates a new identity and access module
@@ -42,24 +53,22 @@ func NewModule(
 	userRepo := persistence.NewUserRepository(db, observabilityProvider)
 	userInfoRepo := persistence.NewUserInfoRepository(db, observabilityProvider)
 	apiKeyRepo := persistence.NewUserAPIKeyRepository(db, observabilityProvider)
+	organizationRepo := persistence.NewOrganizationRepository(db, observabilityProvider)
+	membershipRepo := persistence.NewMembershipRepository(db, observabilityProvider)
+	auditRepo := persistence.NewAuditRepository(db, observabilityProvider)
+	serviceAccountRepo := persistence.NewServiceAccountRepository(db, observabilityProvider)
 
-	// Initialize Supabase auth service
-	supabaseConfig := &supabase.SupabaseConfig{
-		ProjectRef:  cfg.Supabase.ProjectRef,
-		ServiceRole: cfg.Supabase.ServiceRole,
-		JWTSecret:   cfg.Supabase.JWTSecret,
-	}
-
-	supabaseAuthService, err := supabase.NewSupabaseAuthService(supabaseConfig, observabilityProvider)
+	// Initialize the configured identity provider
+	identityProvider, err := newIdentityProvider(cfg, observabilityProvider)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize Supabase auth service: %w", err)
+		return nil, err
 	}
 
 	// Create application services
 	authService := application.NewAuthService(
 		userRepo,
 		userInfoRepo,
-		supabaseAuthService,
+		identityProvider,
 		unitOfWorkFactory,
 		eventBus,
 		observabilityProvider,
@@ -70,20 +79,56 @@ func NewModule(
 		userRepo,
 		userInfoRepo,
 		apiKeyRepo,
+		serviceAccountRepo,
+		unitOfWorkFactory,
+		eventBus,
+		observabilityProvider,
+	)
+
+	// Create the organization service
+	organizationService := application.NewOrganizationService(
+		organizationRepo,
+		membershipRepo,
+		userRepo,
+		apiKeyRepo,
 		unitOfWorkFactory,
 		eventBus,
 		observabilityProvider,
 	)
 
-	// Create HTTP handler
+	// Create the service account service and delete the service accounts of deleted owners
+	serviceAccountService := application.NewServiceAccountService(
+		serviceAccountRepo,
+		apiKeyRepo,
+		organizationService,
+		eventBus,
+		observabilityProvider,
+	)
+	serviceAccountService.RegisterEventHandlers(eventBus)
+
+	// Create the audit service and record security-relevant events of all modules
+	auditService := application.NewAuditService(auditRepo, observabilityProvider)
+	auditService.RegisterEventHandlers(eventBus)
+
+	// Create HTTP handlers
 	userHandler := iahttp.NewUserHandler(userService, observabilityProvider)
+	organizationHandler := iahttp.NewOrganizationHandler(organizationService, observabilityProvider)
+	auditHandler := iahttp.NewAuditHandler(auditService, observabilityProvider)
+	serviceAccountHandler := iahttp.NewServiceAccountHandler(serviceAccountService, observabilityProvider)
 
 	return &Module{
-		userService: userService,
-		userHandler: userHandler,
-		authService: authService,
-		userRepo:    userRepo,
-		obs:         observabilityProvider,
+		userService:                userService,
+		userHandler:                userHandler,
+		organizationService:        organizationService,
+		organizationHandler:        organizationHandler,
+		organizationContractFacade: iacontract.NewOrganizationContractFacade(organizationService, observabilityProvider),
+		auditService:               auditService,
+		auditHandler:               auditHandler,
+		serviceAccountService:      serviceAccountService,
+		serviceAccountHandler:      serviceAccountHandler,
+		authService:                authService,
+		userRepo:                   userRepo,
+		obs:                        observabilityProvider,
 	}, nil
 }
 
@@ -97,10 +142,60 @@ func (m *Module) Initialize(ctx context.Context) error {
 func (m *Module) RegisterRoutes(router *gin.RouterGroup, requireAuth gin.HandlerFunc) {
 	// Register routes with appropriate middleware
 	m.userHandler.RegisterRoutes(router, requireAuth)
+	m.organizationHandler.RegisterRoutes(router, requireAuth)
+	m.auditHandler.RegisterRoutes(router, requireAuth)
+	m.serviceAccountHandler.RegisterRoutes(router, requireAuth)
+}
+
+// GetOrganizationContract returns the organization reader used by other modules to check memberships
+func (m *Module) GetOrganizationContract() contractIdentity.OrganizationReader {
+	return m.organizationContractFacade
 }
 
 // GetRequireAuthMiddleware returns a middleware that authenticates requests and extracts domain.User
 // Other modules can use this to secure their routes and get access to the domain.User object
 func (m *Module) GetRequireAuthMiddleware() gin.HandlerFunc {
-	return middleware.RequireAuth(m.authService, m.userService, m.obs)
+	return middleware.RequireAuth(m.authService, m.userService, m.organizationService, m.serviceAccountService, m.obs)
+}
+
+// newIdentityProvider creates the identity provider selected in the auth configuration
+func newIdentityProvider(cfg *config.Config, observabilityProvider *observability.ObservabilityProvider) (domain.IdentityProvider, error) {
+	switch cfg.Auth.Provider {
+	case "", supabase.ProviderName:
+		supabaseConfig := &supabase.SupabaseConfig{
+			ProjectRef:  cfg.Supabase.ProjectRef,
+			ServiceRole: cfg.Supabase.ServiceRole,
+			JWTSecret:   cfg.Supabase.JWTSecret,
+		}
+
+		supabaseAuthService, err := supabase.NewSupabaseAuthService(supabaseConfig, observabilityProvider)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize Supabase auth service: %w", err)
+		}
+		return supabaseAuthService, nil
+
+	case oidc.ProviderName:
+		oidcConfig := &oidc.Config{
+			IssuerURL:         cfg.Auth.OIDC.IssuerURL,
+			Audiences:         cfg.Auth.OIDC.Audiences,
+			AllowedAlgorithms: cfg.Auth.OIDC.AllowedAlgorithms,
+			KeyCacheTTL:       time.Duration(cfg.Auth.OIDC.JWKSCacheSeconds) * time.Second,
+			ClockSkew:         time.Duration(cfg.Auth.OIDC.ClockSkewSeconds) * time.Second,
+			Claims: oidc.ClaimMapping{
+				Subject:       cfg.Auth.OIDC.Claims.Subject,
+				Email:         cfg.Auth.OIDC.Claims.Email,
+				EmailVerified: cfg.Auth.OIDC.Claims.EmailVerified,
+				Anonymous:     cfg.Auth.OIDC.Claims.Anonymous,
+			},
+		}
+
+		oidcProvider, err := oidc.NewProvider(oidcConfig, observabilityProvider)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize OIDC identity provider: %w", err)
+		}
+		return oidcProvider, nil
+
+	default:
+		return nil, fmt.Errorf("unknown auth provider: %s", cfg.Auth.Provider)
+	}
 }
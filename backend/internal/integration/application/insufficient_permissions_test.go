@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	contractCredential "github.com/context-space/context-space/backend/internal/shared/contract/credentialmanagement"
+	contractIdentity "github.com/context-space/context-space/backend/internal/shared/contract/identityaccess"
+	contractAdapter "github.com/context-space/context-space/backend/internal/shared/contract/provideradapter"
+	integration_mocks "github.com/context-space/context-space/backend/internal/shared/testing/mocks/integration"
+)
+
+// stubOrganizationProvider returns a fixed membership
+type stubOrganizationProvider struct {
+	membership *contractIdentity.MembershipDTO
+}
+
+func (p *stubOrganizationProvider) GetMembership(ctx context.Context, organizationID, userID string) (*contractIdentity.MembershipDTO, error) {
+	return p.membership, nil
+}
+
+func newInsufficientPermissionsTestService(t *testing.T, credProvider *integration_mocks.MockCredentialProvider, membership *contractIdentity.MembershipDTO) *InvocationService {
+	obs, _, err := observability.InitializeObservabilityProvider(context.Background(), &observability.LogConfig{
+		Level:       observability.ParseLogLevel("error"),
+		Format:      observability.ParseLogFormat("json"),
+		OutputPaths: []string{"stdout"},
+	}, &observability.TracingConfig{Enabled: false}, &observability.MetricsConfig{Enabled: false})
+	require.NoError(t, err)
+
+	return &InvocationService{
+		credProvider:         credProvider,
+		organizationProvider: &stubOrganizationProvider{membership: membership},
+		obs:                  obs,
+	}
+}
+
+func TestInsufficientPermissionsError(t *testing.T) {
+	scopeErr := &contractAdapter.InsufficientScopeError{
+		ProviderIdentifier:  "github",
+		OperationIdentifier: "create_issue",
+		MissingPermissions:  []string{"issues_write"},
+	}
+	authorization := &contractCredential.IncrementalAuthorizationDTO{AuthURL: "https://github.com/login/oauth/authorize?state=s", OAuthStateID: "state-1"}
+
+	tests := []struct {
+		name                     string
+		credentialOrganizationID string
+		membership               *contractIdentity.MembershipDTO
+		authorization            *contractCredential.IncrementalAuthorizationDTO
+		authorizationErr         error
+		expectAuthorization      bool
+		expectURL                string
+		expectReason             string
+	}{
+		{
+			name:                "personal credential",
+			authorization:       authorization,
+			expectAuthorization: true,
+			expectURL:           authorization.AuthURL,
+		},
+		{
+			name:                "consent redirect not configured",
+			authorizationErr:    fmt.Errorf("contract: %w", contractCredential.ErrConsentRedirectNotConfigured),
+			expectAuthorization: true,
+			expectReason:        AuthorizationUnavailableNotConfigured,
+		},
+		{
+			name:                "consent flow fails",
+			authorizationErr:    fmt.Errorf("failed to store state"),
+			expectAuthorization: true,
+			expectReason:        AuthorizationUnavailableFailedToStart,
+		},
+		{
+			name:                     "organization credential with a member allowed to manage credentials",
+			credentialOrganizationID: "org-1",
+			membership:               &contractIdentity.MembershipDTO{CanManageCredentials: true, CanUseSharedCredentials: true},
+			authorization:            authorization,
+			expectAuthorization:      true,
+			expectURL:                authorization.AuthURL,
+		},
+		{
+			name:                     "organization credential with a member only allowed to use it",
+			credentialOrganizationID: "org-1",
+			membership:               &contractIdentity.MembershipDTO{CanUseSharedCredentials: true},
+			expectReason:             AuthorizationUnavailableOrganization,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credProvider := &integration_mocks.MockCredentialProvider{}
+			if tt.expectAuthorization {
+				credProvider.On("CreateIncrementalAuthorization", mock.Anything, "user-1", tt.credentialOrganizationID, "github", []string{"issues_write"}).
+					Return(tt.authorization, tt.authorizationErr).Once()
+			}
+			service := newInsufficientPermissionsTestService(t, credProvider, tt.membership)
+
+			permissionsErr := service.insufficientPermissionsError(context.Background(), "user-1", tt.credentialOrganizationID, scopeErr)
+
+			assert.ErrorIs(t, permissionsErr, ErrInsufficientPermissions)
+			assert.Equal(t, []string{"issues_write"}, permissionsErr.MissingPermissions)
+			assert.Equal(t, tt.expectURL, permissionsErr.AuthorizationURL)
+			assert.Equal(t, tt.expectReason, permissionsErr.AuthorizationUnavailableReason)
+			credProvider.AssertExpectations(t)
+		})
+	}
+}
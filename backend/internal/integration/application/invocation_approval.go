@@ -0,0 +1,334 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	contractProvider "github.com/context-space/context-space/backend/internal/shared/contract/providercore"
+	"github.com/context-space/context-space/backend/internal/shared/types"
+)
+
+// expireApprovalsBatchSize bounds how many stale approvals are expired per query
+const expireApprovalsBatchSize = 100
+
+// ApprovalPolicyUpdate holds the fields of an approval policy to change, nil fields are left untouched
+type ApprovalPolicyUpdate struct {
+	Enabled       *bool
+	MinRiskLevel  *types.OperationRiskLevel
+	AlwaysRequire *[]string
+	NeverRequire  *[]string
+	ApprovalTTL   *time.Duration
+}
+
+// GetApprovalPolicy returns the approval policy of a user, falling back to the default policy
+func (s *InvocationService) GetApprovalPolicy(ctx context.Context, userID string) (*domain.ApprovalPolicy, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "InvocationService.GetApprovalPolicy")
+	defer span.End()
+
+	policy, err := s.approvalPolicyRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval policy: %w", err)
+	}
+	if policy == nil {
+		policy = domain.NewApprovalPolicy(userID)
+	}
+
+	return policy, nil
+}
+
+// UpdateApprovalPolicy applies the given changes to the user's approval policy
+func (s *InvocationService) UpdateApprovalPolicy(ctx context.Context, userID string, update ApprovalPolicyUpdate) (*domain.ApprovalPolicy, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "InvocationService.UpdateApprovalPolicy")
+	defer span.End()
+
+	policy, err := s.GetApprovalPolicy(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Enabled != nil {
+		policy.Enabled = *update.Enabled
+	}
+	if update.MinRiskLevel != nil {
+		if !update.MinRiskLevel.IsValid() {
+			return nil, fmt.Errorf("%w: unknown risk level %q", ErrInvalidParameters, *update.MinRiskLevel)
+		}
+		policy.MinRiskLevel = *update.MinRiskLevel
+	}
+	if update.AlwaysRequire != nil {
+		policy.AlwaysRequire = *update.AlwaysRequire
+	}
+	if update.NeverRequire != nil {
+		policy.NeverRequire = *update.NeverRequire
+	}
+	if update.ApprovalTTL != nil {
+		if *update.ApprovalTTL <= 0 {
+			return nil, fmt.Errorf("%w: approval ttl must be positive", ErrInvalidParameters)
+		}
+		policy.ApprovalTTL = *update.ApprovalTTL
+	}
+	policy.UpdatedAt = time.Now()
+
+	if err := s.approvalPolicyRepo.Save(ctx, policy); err != nil {
+		return nil, fmt.Errorf("failed to save approval policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// ListPendingApprovals returns the invocations of a user that are waiting for a decision
+func (s *InvocationService) ListPendingApprovals(ctx context.Context, userID string, limit, offset int) ([]*domain.Invocation, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "InvocationService.ListPendingApprovals")
+	defer span.End()
+
+	invocations, err := s.invocationRepo.ListByUserIDAndStatus(ctx, userID, domain.InvocationStatusAwaitingApproval, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending approvals: %w", err)
+	}
+
+	return invocations, nil
+}
+
+// CountPendingApprovals returns the count of invocations of a user that are waiting for a decision
+func (s *InvocationService) CountPendingApprovals(ctx context.Context, userID string) (int64, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "InvocationService.CountPendingApprovals")
+	defer span.End()
+
+	count, err := s.invocationRepo.CountByUserIDAndStatus(ctx, userID, domain.InvocationStatusAwaitingApproval)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending approvals: %w", err)
+	}
+
+	return count, nil
+}
+
+// ApproveInvocation approves a gated invocation and executes it, optionally with edited parameters
+func (s *InvocationService) ApproveInvocation(
+	ctx context.Context,
+	userID string,
+	invocationID string,
+	params map[string]interface{},
+) (*domain.Invocation, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "InvocationService.ApproveInvocation")
+	defer span.End()
+
+	invocation, err := s.getAwaitingInvocation(ctx, userID, invocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := s.providerProvider.GetProviderByIdentifier(ctx, invocation.ProviderIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotFound, err.Error())
+	}
+
+	// The catalog may have changed while the invocation was waiting, and edited parameters must match the schema too
+	effectiveParams := invocation.Parameters
+	if params != nil {
+		effectiveParams = params
+	}
+	if err := checkInvocationAllowed(provider, invocation.OperationIdentifier, effectiveParams, time.Now()); err != nil {
+		return nil, err
+	}
+
+	providerAdapter, err := s.adapterProvider.GetAdapterByProviderIdentifier(ctx, invocation.ProviderIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrProviderAdapterNotFound, err.Error())
+	}
+
+	// Resolve the credential before recording the decision so a missing credential leaves the approval pending
+	credential, credentialOrganizationID, err := s.resolveCredential(ctx, userID, invocation.OrganizationID, invocation.ProviderIdentifier, providerAdapter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the request moving the invocation out of awaiting approval executes it, concurrent approvals,
+	// denials and expiries lose before any quota is charged
+	awaiting := invocation.Snapshot()
+	invocation.Approve(userID, params)
+	invocation.SetStarted()
+	if err := s.transitionAwaitingInvocation(ctx, invocation); err != nil {
+		return nil, err
+	}
+
+	// The invocation counts against the quotas of the API key that requested it, not of the approving session,
+	// a refused invocation goes back to awaiting approval so it can be approved once the quota resets
+	if err := s.quotaService.Consume(ctx, userID, invocation.APIKeyID, invocation.ProviderIdentifier, invocation.OperationIdentifier); err != nil {
+		if _, revertErr := s.invocationRepo.UpdateIfStatus(ctx, awaiting, domain.InvocationStatusPending); revertErr != nil {
+			s.obs.Logger.Error(ctx, "Failed to revert approval refused by quota",
+				zap.String("invocation_id", invocation.ID),
+				zap.Error(revertErr))
+		}
+		return nil, err
+	}
+
+	s.emitInvocationEvent(ctx, s.eventTypes.Approved, invocation)
+
+	return s.executeInvocation(ctx, providerAdapter, invocation, credential, credentialOrganizationID)
+}
+
+// DenyInvocation rejects a gated invocation
+func (s *InvocationService) DenyInvocation(ctx context.Context, userID, invocationID, reason string) (*domain.Invocation, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "InvocationService.DenyInvocation")
+	defer span.End()
+
+	invocation, err := s.getAwaitingInvocation(ctx, userID, invocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	invocation.Deny(userID, reason)
+	if err := s.transitionAwaitingInvocation(ctx, invocation); err != nil {
+		return nil, err
+	}
+
+	s.emitInvocationEvent(ctx, s.eventTypes.Denied, invocation)
+
+	return invocation, nil
+}
+
+// ExpireStaleApprovals marks invocations whose approval window elapsed as expired
+func (s *InvocationService) ExpireStaleApprovals(ctx context.Context) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "InvocationService.ExpireStaleApprovals")
+	defer span.End()
+
+	now := time.Now()
+	expired := 0
+	for {
+		invocations, err := s.invocationRepo.ListAwaitingApprovalExpiredBefore(ctx, now, expireApprovalsBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list stale approvals: %w", err)
+		}
+
+		for _, invocation := range invocations {
+			invocation.Expire()
+			updated, err := s.invocationRepo.UpdateIfStatus(ctx, invocation, domain.InvocationStatusAwaitingApproval)
+			if err != nil {
+				return fmt.Errorf("failed to expire invocation %s: %w", invocation.ID, err)
+			}
+			// The invocation was approved or denied since it was listed
+			if !updated {
+				continue
+			}
+			s.emitInvocationEvent(ctx, s.eventTypes.Expired, invocation)
+			expired++
+		}
+
+		if len(invocations) < expireApprovalsBatchSize {
+			break
+		}
+	}
+
+	s.obs.Logger.Info(ctx, "Expired stale approvals", zap.Int("count", expired))
+	return nil
+}
+
+// approvalRequirement returns the user's policy and the operation risk level when the invocation must be approved
+func (s *InvocationService) approvalRequirement(
+	ctx context.Context,
+	userID string,
+	provider *contractProvider.ProviderDTO,
+	operationIdentifier string,
+) (*domain.ApprovalPolicy, types.OperationRiskLevel, error) {
+	riskLevel := types.RiskLevelRead
+	for _, operation := range provider.Operations {
+		if operation.Identifier == operationIdentifier {
+			riskLevel = types.OperationRiskLevel(operation.RiskLevel)
+			break
+		}
+	}
+
+	policy, err := s.GetApprovalPolicy(ctx, userID)
+	if err != nil {
+		return nil, riskLevel, err
+	}
+	if !policy.RequiresApproval(provider.Identifier, operationIdentifier, riskLevel) {
+		return nil, riskLevel, nil
+	}
+
+	return policy, riskLevel, nil
+}
+
+// requestApproval records a gated invocation with a snapshot of its parameters and notifies listeners
+func (s *InvocationService) requestApproval(
+	ctx context.Context,
+	userID string,
+	providerIdentifier string,
+	operationIdentifier string,
+	params map[string]interface{},
+	shape *domain.ResponseShape,
+	policy *domain.ApprovalPolicy,
+	riskLevel types.OperationRiskLevel,
+) (*domain.Invocation, error) {
+	invocation := domain.NewInvocation(
+		uuid.New().String(),
+		userID,
+		providerIdentifier,
+		operationIdentifier,
+		params,
+	)
+	invocationCtx := InvocationContextFromContext(ctx)
+	invocation.OrganizationID = invocationCtx.OrganizationID
+	invocation.APIKeyID = invocationCtx.APIKeyID
+	invocation.Paginate = invocationCtx.Paginate
+	invocation.Shape = shape
+	invocation.RequireApproval(string(riskLevel), policy.TTL())
+
+	if err := s.invocationRepo.Create(ctx, invocation); err != nil {
+		s.obs.Logger.Debug(ctx, "Failed to create invocation record", zap.Error(err))
+		return nil, fmt.Errorf("failed to create invocation record: %w", err)
+	}
+
+	s.emitInvocationEvent(ctx, s.eventTypes.ApprovalRequested, invocation)
+
+	s.obs.Logger.Debug(ctx, "Invocation awaiting approval",
+		zap.String("invocation_id", invocation.ID),
+		zap.String("risk_level", string(riskLevel)),
+	)
+
+	return invocation, nil
+}
+
+// getAwaitingInvocation loads an invocation owned by the user that can still be decided
+func (s *InvocationService) getAwaitingInvocation(ctx context.Context, userID, invocationID string) (*domain.Invocation, error) {
+	invocation, err := s.GetInvocationByID(ctx, invocationID)
+	if err != nil {
+		return nil, err
+	}
+	if invocation.UserID != userID {
+		return nil, ErrInvocationNotFound
+	}
+	if !invocation.IsAwaitingApproval() {
+		return nil, ErrNotAwaitingApproval
+	}
+	if invocation.IsApprovalExpired(time.Now()) {
+		invocation.Expire()
+		updated, err := s.invocationRepo.UpdateIfStatus(ctx, invocation, domain.InvocationStatusAwaitingApproval)
+		if err != nil {
+			s.obs.Logger.Error(ctx, "Failed to expire invocation", zap.String("invocation_id", invocation.ID), zap.Error(err))
+		} else if updated {
+			s.emitInvocationEvent(ctx, s.eventTypes.Expired, invocation)
+		}
+		return nil, ErrApprovalExpired
+	}
+
+	return invocation, nil
+}
+
+// transitionAwaitingInvocation stores the decision on an invocation that was awaiting approval,
+// failing with ErrNotAwaitingApproval if another request decided it first
+func (s *InvocationService) transitionAwaitingInvocation(ctx context.Context, invocation *domain.Invocation) error {
+	updated, err := s.invocationRepo.UpdateIfStatus(ctx, invocation, domain.InvocationStatusAwaitingApproval)
+	if err != nil {
+		return fmt.Errorf("failed to update invocation: %w", err)
+	}
+	if !updated {
+		return ErrNotAwaitingApproval
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package application
+
+import (
+	"context"
+
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+)
+
+// invocationContextKeyType is the context key type for the invocation context
+type invocationContextKeyType string
+
+// invocationContextKey is the context key under which the invocation context is stored
+const invocationContextKey invocationContextKeyType = "integration.invocationContext"
+
+// InvocationContext carries request attributes of an invocation that are not part of its parameters
+type InvocationContext struct {
+	// APIKeyID is the ID of the API key that authenticated the request, empty for session requests
+	APIKeyID string
+	// OrganizationID is the organization the request acts for, empty for personal requests
+	OrganizationID string
+	// ServiceAccount is set for requests made as a service account, whose quotas are counted separately
+	ServiceAccount bool
+	// Paginate makes the invocation follow several pages of a paginated operation, nil for a single page
+	Paginate *domain.InvocationPaginate
+	// Shape reduces the response of the invocation before it is returned, nil for the full response
+	Shape *domain.ResponseShape
+}
+
+// WithInvocationContext returns a copy of ctx carrying the invocation context
+func WithInvocationContext(ctx context.Context, invocationCtx InvocationContext) context.Context {
+	return context.WithValue(ctx, invocationContextKey, invocationCtx)
+}
+
+// InvocationContextFromContext returns the invocation context stored in ctx, or an empty one
+func InvocationContextFromContext(ctx context.Context) InvocationContext {
+	if invocationCtx, ok := ctx.Value(invocationContextKey).(InvocationContext); ok {
+		return invocationCtx
+	}
+	return InvocationContext{}
+}
@@ -0,0 +1,264 @@
+package application
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	contractProvider "github.com/context-space/context-space/backend/internal/shared/contract/providercore"
+	"github.com/context-space/context-space/backend/internal/shared/types"
+)
+
+// Catalog gating errors
+var (
+	ErrProviderUnavailable = errors.New("provider unavailable")
+	ErrProviderDeprecated  = errors.New("provider deprecated")
+)
+
+// ProviderUnavailableError reports why the catalog refuses invocations of a provider
+type ProviderUnavailableError struct {
+	ProviderIdentifier string
+	Status             string
+	// Message, StartsAt and EndsAt come from the provider's maintenance window and may be empty
+	Message  string
+	StartsAt *time.Time
+	EndsAt   *time.Time
+}
+
+// Error implements the error interface
+func (e *ProviderUnavailableError) Error() string {
+	return fmt.Sprintf("%s: %s is %s", e.Unwrap(), e.ProviderIdentifier, e.Status)
+}
+
+// Unwrap allows errors.Is to match ErrProviderDeprecated or ErrProviderUnavailable
+func (e *ProviderUnavailableError) Unwrap() error {
+	if e.Status == string(types.ProviderStatusDeprecated) {
+		return ErrProviderDeprecated
+	}
+	return ErrProviderUnavailable
+}
+
+// ParameterError describes why a single parameter was rejected
+type ParameterError struct {
+	Field   string
+	Message string
+}
+
+// InvalidParametersError reports every parameter of an invocation that does not match the operation schema
+type InvalidParametersError struct {
+	ProviderIdentifier  string
+	OperationIdentifier string
+	Fields              []ParameterError
+}
+
+// Error implements the error interface
+func (e *InvalidParametersError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+": "+field.Message)
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidParameters, strings.Join(messages, "; "))
+}
+
+// Unwrap allows errors.Is to match ErrInvalidParameters
+func (e *InvalidParametersError) Unwrap() error {
+	return ErrInvalidParameters
+}
+
+// checkInvocationAllowed validates an invocation against the catalog before any credential is touched
+func checkInvocationAllowed(
+	provider *contractProvider.ProviderDTO,
+	operationIdentifier string,
+	params map[string]interface{},
+	now time.Time,
+) error {
+	if err := checkProviderAvailable(provider, now); err != nil {
+		return err
+	}
+
+	return checkOperationParameters(provider, operationIdentifier, params)
+}
+
+// checkOperationParameters rejects unknown operations and parameters that do not match the operation schema
+func checkOperationParameters(
+	provider *contractProvider.ProviderDTO,
+	operationIdentifier string,
+	params map[string]interface{},
+) error {
+	var operation *contractProvider.OperationDTO
+	for i := range provider.Operations {
+		if provider.Operations[i].Identifier == operationIdentifier {
+			operation = &provider.Operations[i]
+			break
+		}
+	}
+	if operation == nil {
+		return fmt.Errorf("%w: %s.%s", ErrOperationNotFound, provider.Identifier, operationIdentifier)
+	}
+
+	if fields := validateParameters(operation.Parameters, params); len(fields) > 0 {
+		return &InvalidParametersError{
+			ProviderIdentifier:  provider.Identifier,
+			OperationIdentifier: operationIdentifier,
+			Fields:              fields,
+		}
+	}
+
+	return nil
+}
+
+// checkProviderAvailable rejects providers that are not active or are inside a scheduled maintenance window
+func checkProviderAvailable(provider *contractProvider.ProviderDTO, now time.Time) error {
+	status := types.ProviderStatus(provider.Status)
+	inWindow := maintenanceActiveAt(provider.Maintenance, now)
+	if status == types.ProviderStatusActive && !inWindow {
+		return nil
+	}
+
+	unavailableErr := &ProviderUnavailableError{
+		ProviderIdentifier: provider.Identifier,
+		Status:             provider.Status,
+	}
+	if status == types.ProviderStatusActive {
+		unavailableErr.Status = string(types.ProviderStatusMaintenance)
+	}
+	if maintenance := provider.Maintenance; maintenance != nil && (inWindow || status == types.ProviderStatusMaintenance) {
+		unavailableErr.Message = maintenance.Message
+		unavailableErr.StartsAt = unixTimeOrNil(maintenance.StartsAt)
+		unavailableErr.EndsAt = unixTimeOrNil(maintenance.EndsAt)
+	}
+
+	return unavailableErr
+}
+
+// maintenanceActiveAt returns true if the maintenance window covers the given time
+func maintenanceActiveAt(maintenance *contractProvider.MaintenanceDTO, now time.Time) bool {
+	if maintenance == nil || (maintenance.StartsAt == 0 && maintenance.EndsAt == 0) {
+		return false
+	}
+	if maintenance.StartsAt != 0 && now.Unix() < maintenance.StartsAt {
+		return false
+	}
+	if maintenance.EndsAt != 0 && now.Unix() >= maintenance.EndsAt {
+		return false
+	}
+	return true
+}
+
+// unixTimeOrNil converts a unix timestamp to a time, zero meaning unset
+func unixTimeOrNil(timestamp int64) *time.Time {
+	if timestamp == 0 {
+		return nil
+	}
+	t := time.Unix(timestamp, 0).UTC()
+	return &t
+}
+
+// validateParameters checks the parameters against the operation schema, unknown parameters are passed through
+func validateParameters(schema []contractProvider.ParameterDTO, params map[string]interface{}) []ParameterError {
+	var fields []ParameterError
+	for _, parameter := range schema {
+		value, ok := params[parameter.Name]
+		if !ok || value == nil {
+			if parameter.Required && parameter.Default == nil {
+				fields = append(fields, ParameterError{Field: parameter.Name, Message: "is required"})
+			}
+			continue
+		}
+
+		if !matchesParameterType(parameter.Type, value) {
+			fields = append(fields, ParameterError{Field: parameter.Name, Message: fmt.Sprintf("must be of type %s", parameter.Type)})
+			continue
+		}
+
+		if len(parameter.Enum) > 0 && !inEnum(parameter.Enum, value) {
+			fields = append(fields, ParameterError{
+				Field:   parameter.Name,
+				Message: fmt.Sprintf("must be one of %s", strings.Join(parameter.Enum, ", ")),
+			})
+		}
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].Field < fields[j].Field })
+	return fields
+}
+
+// matchesParameterType reports whether a decoded JSON value can be decoded into the declared parameter type.
+// It accepts the conversions of the weakly typed decoding of the adapters, so that the check does not reject
+// what the adapters accept: numbers and booleans for strings, numeric strings and booleans for numbers,
+// strings such as "true" and numbers for booleans, and single values for arrays.
+func matchesParameterType(parameterType string, value interface{}) bool {
+	text, isString := value.(string)
+	_, isBool := value.(bool)
+	_, isNumber := toFloat(value)
+
+	switch parameterType {
+	case "string":
+		return isString || isBool || isNumber
+	case "integer":
+		if isString {
+			_, err := strconv.ParseInt(text, 0, 64)
+			return text == "" || err == nil
+		}
+		return isBool || isNumber
+	case "number":
+		if isString {
+			_, err := strconv.ParseFloat(text, 64)
+			return text == "" || err == nil
+		}
+		return isBool || isNumber
+	case "boolean":
+		if isString {
+			_, err := strconv.ParseBool(text)
+			return text == "" || err == nil
+		}
+		return isBool || isNumber
+	case "object":
+		return reflect.ValueOf(value).Kind() == reflect.Map
+	case "array":
+		kind := reflect.ValueOf(value).Kind()
+		return kind == reflect.Slice || kind == reflect.Array || isString || isBool || isNumber
+	default:
+		// Unknown schema types are not enforced
+		return true
+	}
+}
+
+// toFloat converts any numeric value to a float64
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		number, err := v.Float64()
+		return number, err == nil
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	default:
+		return 0, false
+	}
+}
+
+// inEnum reports whether the value is one of the allowed enum values
+func inEnum(enum []string, value interface{}) bool {
+	candidate := fmt.Sprint(value)
+	for _, allowed := range enum {
+		if allowed == candidate {
+			return true
+		}
+	}
+	return false
+}
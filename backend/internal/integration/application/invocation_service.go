@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/bytedance/sonic"
 	"github.com/google/uuid"
@@ -13,25 +14,35 @@ import (
 
 	observability "github.com/context-space/cloud-observability"
 	"github.com/context-space/context-space/backend/internal/integration/domain"
+	contractCredential "github.com/context-space/context-space/backend/internal/shared/contract/credentialmanagement"
+	contractAdapter "github.com/context-space/context-space/backend/internal/shared/contract/provideradapter"
 	"github.com/context-space/context-space/backend/internal/shared/events"
 	"github.com/context-space/context-space/backend/internal/shared/infrastructure/cache"
 )
 
 // InvocationEventTypes defines the event types for invocation events
 type InvocationEventTypes struct {
-	Started  events.EventType
-	Success  events.EventType
-	Failed   events.EventType
-	Canceled events.EventType
+	Started           events.EventType
+	Success           events.EventType
+	Failed            events.EventType
+	Canceled          events.EventType
+	ApprovalRequested events.EventType
+	Approved          events.EventType
+	Denied            events.EventType
+	Expired           events.EventType
 }
 
 // DefaultInvocationEventTypes returns the default invocation event types
 func DefaultInvocationEventTypes() InvocationEventTypes {
 	return InvocationEventTypes{
-		Started:  events.EventType("invocation.started"),
-		Success:  events.EventType("invocation.success"),
-		Failed:   events.EventType("invocation.failed"),
-		Canceled: events.EventType("invocation.canceled"),
+		Started:           events.EventType("invocation.started"),
+		Success:           events.EventType("invocation.success"),
+		Failed:            events.EventType("invocation.failed"),
+		Canceled:          events.EventType("invocation.canceled"),
+		ApprovalRequested: events.EventType("invocation.approval_requested"),
+		Approved:          events.EventType("invocation.approved"),
+		Denied:            events.EventType("invocation.denied"),
+		Expired:           events.EventType("invocation.expired"),
 	}
 }
 
@@ -45,8 +56,39 @@ var (
 	ErrRateLimitExceeded       = errors.New("rate limit exceeded")
 	ErrAdapterExecuteFailed    = errors.New("adapter execution failed")
 	ErrInvocationNotFound      = errors.New("invocation not found")
+	ErrNotAwaitingApproval     = errors.New("invocation is not awaiting approval")
+	ErrApprovalExpired         = errors.New("approval window expired")
+	ErrInsufficientPermissions = errors.New("insufficient permissions")
 )
 
+// InsufficientPermissionsError reports the permissions an invocation was missing and how to grant them
+type InsufficientPermissionsError struct {
+	ProviderIdentifier  string
+	OperationIdentifier string
+	MissingPermissions  []string
+	// AuthorizationURL is empty when no consent flow could be started, AuthorizationUnavailableReason says why
+	AuthorizationURL               string
+	OAuthStateID                   string
+	AuthorizationUnavailableReason string
+}
+
+// Reasons for not offering a consent flow with an InsufficientPermissionsError
+const (
+	AuthorizationUnavailableNotConfigured = "incremental authorization is not configured"
+	AuthorizationUnavailableOrganization  = "the organization credential is missing the permissions, an organization admin must grant them"
+	AuthorizationUnavailableFailedToStart = "the consent flow could not be started"
+)
+
+// Error implements the error interface
+func (e *InsufficientPermissionsError) Error() string {
+	return fmt.Sprintf("%s: %s.%s requires %v", ErrInsufficientPermissions, e.ProviderIdentifier, e.OperationIdentifier, e.MissingPermissions)
+}
+
+// Unwrap allows errors.Is to match ErrInsufficientPermissions
+func (e *InsufficientPermissionsError) Unwrap() error {
+	return ErrInsufficientPermissions
+}
+
 // Common adapter error codes (duplicated from adapterDomain for safety)
 const (
 	ErrorCodeRateLimitExceeded    = "rate_limited"
@@ -67,6 +109,9 @@ type InvocationService struct {
 	eventTypes           InvocationEventTypes
 	obs                  *observability.ObservabilityProvider
 	redisClient          cache.Cache
+	approvalPolicyRepo   domain.ApprovalPolicyRepository
+	quotaService         *QuotaService
+	organizationProvider domain.OrganizationProvider
 }
 
 // NewInvocationService creates a new invocation service
@@ -79,6 +124,9 @@ func NewInvocationService(
 	observabilityProvider *observability.ObservabilityProvider,
 	redisClient cache.Cache,
 	tokenRefreshProvider domain.TokenRefreshProvider,
+	approvalPolicyRepo domain.ApprovalPolicyRepository,
+	quotaService *QuotaService,
+	organizationProvider domain.OrganizationProvider,
 ) *InvocationService {
 	return &InvocationService{
 		providerProvider:     providerProvider,
@@ -90,6 +138,9 @@ func NewInvocationService(
 		eventTypes:           DefaultInvocationEventTypes(),
 		obs:                  observabilityProvider,
 		redisClient:          redisClient,
+		approvalPolicyRepo:   approvalPolicyRepo,
+		quotaService:         quotaService,
+		organizationProvider: organizationProvider,
 	}
 }
 
@@ -119,20 +170,110 @@ func (s *InvocationService) InvokeOperation(
 		zap.Any("params", params),
 	)
 
-	// Get the provider (not used directly but validates existence)
-	_, err := s.providerProvider.GetProviderByIdentifier(ctx, providerIdentifier)
+	// Get the provider
+	provider, err := s.providerProvider.GetProviderByIdentifier(ctx, providerIdentifier)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrProviderNotFound, err.Error())
 	}
 
+	// Reject unavailable providers, unknown operations and malformed parameters before touching credentials
+	if err := checkInvocationAllowed(provider, operationIdentifier, params, time.Now()); err != nil {
+		return nil, err
+	}
+	invocationCtx := InvocationContextFromContext(ctx)
+	shape, err := resolveResponseShape(provider, operationIdentifier, invocationCtx.Shape)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get the provider adapter
 	providerAdapter, err := s.adapterProvider.GetAdapterByProviderIdentifier(ctx, providerIdentifier)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrProviderAdapterNotFound, err.Error())
 	}
 
+	// Hold the invocation for approval if the user's policy gates this operation
+	policy, riskLevel, err := s.approvalRequirement(ctx, userID, provider, operationIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	if policy != nil {
+		return s.requestApproval(ctx, userID, providerIdentifier, operationIdentifier, params, shape, policy, riskLevel)
+	}
+
 	// Get credential for the provider (if needed)
+	credential, credentialOrganizationID, err := s.resolveCredential(ctx, userID, invocationCtx.OrganizationID, providerIdentifier, providerAdapter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Count the invocation against the user's quotas before anything is executed
+	if err := s.quotaService.Consume(ctx, userID, invocationCtx.APIKeyID, providerIdentifier, operationIdentifier); err != nil {
+		return nil, err
+	}
+
+	// Create a unique ID for this invocation
+	invocationID := uuid.New().String()
+
+	// Create invocation record
+	invocation := domain.NewInvocation(
+		invocationID,
+		userID,
+		providerIdentifier,
+		operationIdentifier,
+		params,
+	)
+	invocation.OrganizationID = invocationCtx.OrganizationID
+	invocation.APIKeyID = invocationCtx.APIKeyID
+	invocation.Paginate = invocationCtx.Paginate
+	invocation.Shape = shape
+
+	// Set the invocation as started
+	invocation.SetStarted()
+
+	// Save initial invocation record
+	// An invocation that could not be recorded is never executed, so it is not charged either
+	if err := s.invocationRepo.Create(ctx, invocation); err != nil {
+		s.obs.Logger.Debug(ctx, "Failed to create invocation record", zap.Error(err))
+		s.quotaService.Release(ctx, userID, invocationCtx.APIKeyID, providerIdentifier, operationIdentifier)
+		return nil, fmt.Errorf("failed to create invocation record: %w", err)
+	}
+
+	return s.executeInvocation(ctx, providerAdapter, invocation, credential, credentialOrganizationID)
+}
+
+// ValidateOperation checks that an operation exists in the catalog and that the parameters match its schema,
+// without checking the availability of the provider, for invocations made later
+func (s *InvocationService) ValidateOperation(
+	ctx context.Context,
+	providerIdentifier string,
+	operationIdentifier string,
+	params map[string]interface{},
+) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "InvocationService.ValidateOperation")
+	defer span.End()
+
+	provider, err := s.providerProvider.GetProviderByIdentifier(ctx, providerIdentifier)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrProviderNotFound, err.Error())
+	}
+
+	return checkOperationParameters(provider, operationIdentifier, params)
+}
+
+// resolveCredential loads and refreshes the user's credential for the provider, falling back to the credential
+// shared by the organization the invocation is made for, or creates a none credential. It also returns the
+// organization owning the credential, empty unless the organization's credential is used
+func (s *InvocationService) resolveCredential(
+	ctx context.Context,
+	userID string,
+	organizationID string,
+	providerIdentifier string,
+	providerAdapter contractAdapter.AdapterContract,
+) (interface{}, string, error) {
 	var credential interface{}
+	var credentialOrganizationID string
+	var err error
 	adapterInfo := providerAdapter.GetAdapterInfoContract()
 	authType := adapterInfo.AuthType
 	s.obs.Logger.Debug(ctx, "Loaded provider adapter",
@@ -143,11 +284,20 @@ func (s *InvocationService) InvokeOperation(
 	if authType != "none" {
 		credential, err = s.credProvider.GetCredentialByUserAndProvider(ctx, userID, providerIdentifier)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get credential: %w", err)
+			return nil, "", fmt.Errorf("failed to get credential: %w", err)
+		}
+		if credential == nil && organizationID != "" {
+			credential, err = s.resolveOrganizationCredential(ctx, userID, organizationID, providerIdentifier)
+			if err != nil {
+				return nil, "", err
+			}
+			if credential != nil {
+				credentialOrganizationID = organizationID
+			}
 		}
 		if credential == nil {
 			s.obs.Logger.Debug(ctx, "Credential not found", zap.String("provider_identifier", providerIdentifier), zap.Error(err))
-			return nil, ErrCredentialNotFound
+			return nil, "", ErrCredentialNotFound
 		}
 
 		credential, err = s.tokenRefreshProvider.RefreshAccessToken(ctx, providerIdentifier, credential)
@@ -156,48 +306,85 @@ func (s *InvocationService) InvokeOperation(
 				zap.String("provider_identifier", providerIdentifier),
 				zap.String("user_id", userID),
 				zap.Error(err))
-			return nil, fmt.Errorf("failed to refresh access token: %w", err)
+			return nil, "", fmt.Errorf("failed to refresh access token: %w", err)
 
 		}
 	} else {
 		credential, err = s.credProvider.CreateNone(ctx, userID, providerIdentifier)
 		if err != nil {
 			s.obs.Logger.Debug(ctx, "Failed to create none credential", zap.Error(err))
-			return nil, fmt.Errorf("failed to create none credential: %w", err)
+			return nil, "", fmt.Errorf("failed to create none credential: %w", err)
 		}
 	}
 
-	// Create a unique ID for this invocation
-	invocationID := uuid.New().String()
+	return credential, credentialOrganizationID, nil
+}
 
-	// Create invocation record
-	invocation := domain.NewInvocation(
-		invocationID,
-		userID,
-		providerIdentifier,
-		operationIdentifier,
-		params,
-	)
+// resolveOrganizationCredential loads the credential an organization shares for the provider,
+// or nil if it has none or the user's role is not allowed to use it
+func (s *InvocationService) resolveOrganizationCredential(
+	ctx context.Context,
+	userID string,
+	organizationID string,
+	providerIdentifier string,
+) (interface{}, error) {
+	if s.organizationProvider == nil {
+		return nil, nil
+	}
 
-	// Set the invocation as started
-	invocation.SetStarted()
+	membership, err := s.organizationProvider.GetMembership(ctx, organizationID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization membership: %w", err)
+	}
+	if membership == nil || !membership.CanUseSharedCredentials {
+		s.obs.Logger.Debug(ctx, "Organization credential not allowed",
+			zap.String("organization_id", organizationID),
+			zap.String("user_id", userID))
+		return nil, nil
+	}
 
-	// Save initial invocation record
-	if err := s.invocationRepo.Create(ctx, invocation); err != nil {
-		s.obs.Logger.Debug(ctx, "Failed to create invocation record", zap.Error(err))
-		return nil, fmt.Errorf("failed to create invocation record: %w", err)
+	credential, err := s.credProvider.GetCredentialByOrganizationAndProvider(ctx, organizationID, providerIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization credential: %w", err)
 	}
 
+	return credential, nil
+}
+
+// executeInvocation runs a persisted invocation against the adapter and records the outcome
+func (s *InvocationService) executeInvocation(
+	ctx context.Context,
+	providerAdapter contractAdapter.AdapterContract,
+	invocation *domain.Invocation,
+	credential interface{},
+	credentialOrganizationID string,
+) (*domain.Invocation, error) {
 	// Emit started event
 	s.emitInvocationEvent(ctx, s.eventTypes.Started, invocation)
 
-	// Execute the operation directly
-	result, execErr := providerAdapter.ExecuteContract(ctx, operationIdentifier, params, credential)
+	// Execute the operation, following its pages when asked to
+	var paginateOptions contractAdapter.PaginateOptionsDTO
+	if invocation.Paginate != nil {
+		paginateOptions = contractAdapter.PaginateOptionsDTO{
+			Cursor:   invocation.Paginate.Cursor,
+			MaxItems: invocation.Paginate.MaxItems,
+			MaxPages: invocation.Paginate.MaxPages,
+		}
+	}
+	pageResult, execErr := providerAdapter.ExecutePagesContract(ctx, invocation.OperationIdentifier, invocation.Parameters, credential, paginateOptions)
 
 	if execErr != nil {
 		errMsg := fmt.Sprintf("Failed to execute operation: %s", execErr.Error())
 		s.obs.Logger.Debug(ctx, errMsg, zap.Error(execErr))
 		s.handleInvocationError(ctx, invocation, errors.New(errMsg))
+
+		var scopeErr *contractAdapter.InsufficientScopeError
+		if errors.As(execErr, &scopeErr) {
+			return invocation, s.insufficientPermissionsError(ctx, invocation.UserID, credentialOrganizationID, scopeErr)
+		}
+		if errors.Is(execErr, contractAdapter.ErrPaginationNotSupported) {
+			return invocation, fmt.Errorf("%w: %s", ErrInvalidParameters, execErr)
+		}
 		return invocation, fmt.Errorf("%w: %s", ErrAdapterExecuteFailed, execErr)
 	}
 
@@ -207,14 +394,25 @@ func (s *InvocationService) InvokeOperation(
 	}
 
 	// Serialize result to JSON
-	resultJSON, err := sonic.Marshal(result)
+	resultJSON, err := sonic.Marshal(pageResult.Result)
 	if err != nil {
 		errMsg := fmt.Sprintf("Failed to marshal result: %s", err.Error())
 		s.handleInvocationError(ctx, invocation, errors.New(errMsg))
 		return invocation, fmt.Errorf("failed to marshal result: %w", err)
 	}
 
+	// Reduce the response as asked before it is stored and returned
+	if invocation.Shape != nil {
+		resultJSON, invocation.Elisions, err = invocation.Shape.Apply(resultJSON)
+		if err != nil {
+			errMsg := fmt.Sprintf("Failed to shape result: %s", err.Error())
+			s.handleInvocationError(ctx, invocation, errors.New(errMsg))
+			return invocation, fmt.Errorf("failed to shape result: %w", err)
+		}
+	}
+
 	// Update invocation record with success
+	invocation.NextCursor = pageResult.NextCursor
 	invocation.SetSuccess(resultJSON) // Duration is calculated internally
 	if err := s.invocationRepo.Update(ctx, invocation); err != nil {
 		s.obs.Logger.Error(ctx, fmt.Sprintf("Failed to update invocation:%+v", invocation), zap.Error(err))
@@ -231,6 +429,50 @@ func (s *InvocationService) InvokeOperation(
 	return invocation, nil
 }
 
+// insufficientPermissionsError builds the error returned for a missing scope, with a consent URL when one can be created.
+// The consent flow re-authorizes the credential the invocation ran with, which for an organization's credential
+// only members allowed to manage its credentials may do
+func (s *InvocationService) insufficientPermissionsError(
+	ctx context.Context,
+	userID string,
+	credentialOrganizationID string,
+	scopeErr *contractAdapter.InsufficientScopeError,
+) *InsufficientPermissionsError {
+	permissionsErr := &InsufficientPermissionsError{
+		ProviderIdentifier:  scopeErr.ProviderIdentifier,
+		OperationIdentifier: scopeErr.OperationIdentifier,
+		MissingPermissions:  scopeErr.MissingPermissions,
+	}
+
+	if credentialOrganizationID != "" {
+		membership, err := s.organizationProvider.GetMembership(ctx, credentialOrganizationID, userID)
+		if err != nil || membership == nil || !membership.CanManageCredentials {
+			if err != nil {
+				s.obs.Logger.Warn(ctx, "Failed to get organization membership", zap.Error(err))
+			}
+			permissionsErr.AuthorizationUnavailableReason = AuthorizationUnavailableOrganization
+			return permissionsErr
+		}
+	}
+
+	authorization, err := s.credProvider.CreateIncrementalAuthorization(ctx, userID, credentialOrganizationID, scopeErr.ProviderIdentifier, scopeErr.MissingPermissions)
+	if err != nil {
+		s.obs.Logger.Warn(ctx, "Failed to create incremental authorization",
+			zap.String("provider_identifier", scopeErr.ProviderIdentifier),
+			zap.Error(err))
+		if errors.Is(err, contractCredential.ErrConsentRedirectNotConfigured) {
+			permissionsErr.AuthorizationUnavailableReason = AuthorizationUnavailableNotConfigured
+		} else {
+			permissionsErr.AuthorizationUnavailableReason = AuthorizationUnavailableFailedToStart
+		}
+		return permissionsErr
+	}
+
+	permissionsErr.AuthorizationURL = authorization.AuthURL
+	permissionsErr.OAuthStateID = authorization.OAuthStateID
+	return permissionsErr
+}
+
 // GetInvocationByID returns an invocation by ID
 func (s *InvocationService) GetInvocationByID(ctx context.Context, id string) (*domain.Invocation, error) {
 	ctx, span := s.obs.Tracer.Start(ctx, "InvocationService.GetInvocationByID")
@@ -313,6 +555,9 @@ func (s *InvocationService) emitInvocationEvent(ctx context.Context, eventType e
 			"status":        string(invocation.Status),
 		},
 	}
+	if invocation.OrganizationID != "" {
+		metadata.Properties["organization_id"] = invocation.OrganizationID
+	}
 
 	// Create event
 	event := events.NewEvent(eventType, invocation, metadata)
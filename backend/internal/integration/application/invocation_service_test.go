@@ -38,6 +38,7 @@ type InvocationServiceTestSuite struct {
 	mockEventBus            *shared_mocks.MockEventBus
 	mockRedisClient         *shared_mocks.MockCache
 	mockTokenRefreshService *integration_mocks.MockTokenRefreshProvider
+	mockApprovalPolicyRepo  *integration_mocks.MockApprovalPolicyRepository
 	mockProviderAdapter     *provideradapter_mocks.MockAdapter
 	mockObs                 *observability.ObservabilityProvider
 
@@ -92,6 +93,7 @@ func (suite *InvocationServiceTestSuite) SetupTest() {
 	suite.mockEventBus = &shared_mocks.MockEventBus{}
 	suite.mockRedisClient = &shared_mocks.MockCache{}
 	suite.mockTokenRefreshService = &integration_mocks.MockTokenRefreshProvider{}
+	suite.mockApprovalPolicyRepo = &integration_mocks.MockApprovalPolicyRepository{}
 	suite.mockProviderAdapter = &provideradapter_mocks.MockAdapter{}
 
 	// Create service instance
@@ -104,6 +106,9 @@ func (suite *InvocationServiceTestSuite) SetupTest() {
 		suite.mockObs,
 		suite.mockRedisClient,
 		suite.mockTokenRefreshService,
+		suite.mockApprovalPolicyRepo,
+		NewQuotaService(nil, nil, domain.QuotaPolicy{}, suite.mockObs),
+		nil,
 	)
 }
 
@@ -735,6 +740,186 @@ func (suite *InvocationServiceTestSuite) TestCountInvocationsByUserID() {
 	}
 }
 
+// awaitingInvocation returns an invocation of the test user waiting for approval, expired if ttl is negative
+func (suite *InvocationServiceTestSuite) awaitingInvocation(id string, ttl time.Duration) *domain.Invocation {
+	invocation := domain.NewInvocation(id, suite.testUserID, suite.testProviderIdentifier, suite.testOperationID, suite.testParams)
+	invocation.RequireApproval("write", ttl)
+	return invocation
+}
+
+// hasStatus matches an invocation moved to the given status
+func hasStatus(status domain.InvocationStatus) interface{} {
+	return mock.MatchedBy(func(invocation *domain.Invocation) bool {
+		return invocation.Status == status
+	})
+}
+
+// TestDenyInvocation tests the transitions out of awaiting approval when an invocation is denied
+func (suite *InvocationServiceTestSuite) TestDenyInvocation() {
+	testCases := []struct {
+		name          string
+		setupMocks    func(*InvocationServiceTestSuite)
+		userID        string
+		expectedError error
+		assertions    func(*InvocationServiceTestSuite, *domain.Invocation, error)
+	}{
+		{
+			name: "successful_denial",
+			setupMocks: func(s *InvocationServiceTestSuite) {
+				s.mockInvocationRepo.On("GetByID", mock.Anything, "test-invocation-id").Return(s.awaitingInvocation("test-invocation-id", time.Hour), nil)
+				s.mockInvocationRepo.On("UpdateIfStatus", mock.Anything, hasStatus(domain.InvocationStatusDenied), domain.InvocationStatusAwaitingApproval).Return(true, nil)
+				s.mockEventBus.On("Publish", mock.Anything, mock.AnythingOfType("events.Event")).Return(nil).Once()
+			},
+			userID:        "test-user-123",
+			expectedError: nil,
+			assertions: func(s *InvocationServiceTestSuite, result *domain.Invocation, err error) {
+				require.NoError(s.T(), err)
+				require.NotNil(s.T(), result)
+				assert.Equal(s.T(), domain.InvocationStatusDenied, result.Status)
+				assert.Equal(s.T(), "too risky", result.Approval.Reason)
+				assert.Equal(s.T(), s.testUserID, result.Approval.DecidedBy)
+				assert.NotNil(s.T(), result.CompletedAt)
+			},
+		},
+		{
+			name: "other_user",
+			setupMocks: func(s *InvocationServiceTestSuite) {
+				s.mockInvocationRepo.On("GetByID", mock.Anything, "test-invocation-id").Return(s.awaitingInvocation("test-invocation-id", time.Hour), nil)
+			},
+			userID:        "other-user",
+			expectedError: ErrInvocationNotFound,
+		},
+		{
+			name: "already_decided",
+			setupMocks: func(s *InvocationServiceTestSuite) {
+				invocation := s.awaitingInvocation("test-invocation-id", time.Hour)
+				invocation.Deny(s.testUserID, "")
+				s.mockInvocationRepo.On("GetByID", mock.Anything, "test-invocation-id").Return(invocation, nil)
+			},
+			userID:        "test-user-123",
+			expectedError: ErrNotAwaitingApproval,
+		},
+		{
+			name: "approval_window_elapsed",
+			setupMocks: func(s *InvocationServiceTestSuite) {
+				s.mockInvocationRepo.On("GetByID", mock.Anything, "test-invocation-id").Return(s.awaitingInvocation("test-invocation-id", -time.Minute), nil)
+				s.mockInvocationRepo.On("UpdateIfStatus", mock.Anything, hasStatus(domain.InvocationStatusExpired), domain.InvocationStatusAwaitingApproval).Return(true, nil)
+				s.mockEventBus.On("Publish", mock.Anything, mock.AnythingOfType("events.Event")).Return(nil).Once()
+			},
+			userID:        "test-user-123",
+			expectedError: ErrApprovalExpired,
+		},
+		{
+			name: "decided_concurrently",
+			setupMocks: func(s *InvocationServiceTestSuite) {
+				s.mockInvocationRepo.On("GetByID", mock.Anything, "test-invocation-id").Return(s.awaitingInvocation("test-invocation-id", time.Hour), nil)
+				s.mockInvocationRepo.On("UpdateIfStatus", mock.Anything, hasStatus(domain.InvocationStatusDenied), domain.InvocationStatusAwaitingApproval).Return(false, nil)
+			},
+			userID:        "test-user-123",
+			expectedError: ErrNotAwaitingApproval,
+		},
+		{
+			name: "repository_error",
+			setupMocks: func(s *InvocationServiceTestSuite) {
+				s.mockInvocationRepo.On("GetByID", mock.Anything, "test-invocation-id").Return(s.awaitingInvocation("test-invocation-id", time.Hour), nil)
+				s.mockInvocationRepo.On("UpdateIfStatus", mock.Anything, mock.Anything, domain.InvocationStatusAwaitingApproval).Return(false, errors.New("database error"))
+			},
+			userID: "test-user-123",
+			assertions: func(s *InvocationServiceTestSuite, result *domain.Invocation, err error) {
+				require.Error(s.T(), err)
+				require.Nil(s.T(), result)
+				assert.Contains(s.T(), err.Error(), "failed to update invocation")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			// Reset mocks to ensure clean state for each test case
+			suite.resetMockState()
+
+			tc.setupMocks(suite)
+			result, err := suite.service.DenyInvocation(suite.testContext, tc.userID, "test-invocation-id", "too risky")
+			if tc.assertions != nil {
+				tc.assertions(suite, result, err)
+			} else {
+				require.ErrorIs(suite.T(), err, tc.expectedError)
+				require.Nil(suite.T(), result)
+			}
+
+			suite.mockInvocationRepo.AssertExpectations(suite.T())
+			suite.mockEventBus.AssertExpectations(suite.T())
+		})
+	}
+}
+
+// TestExpireStaleApprovals tests that only invocations still awaiting approval are expired
+func (suite *InvocationServiceTestSuite) TestExpireStaleApprovals() {
+	testCases := []struct {
+		name           string
+		setupMocks     func(*InvocationServiceTestSuite)
+		expectedEvents int
+		expectedError  bool
+	}{
+		{
+			name: "expires_undecided_invocations",
+			setupMocks: func(s *InvocationServiceTestSuite) {
+				stale := []*domain.Invocation{
+					s.awaitingInvocation("stale-1", -time.Minute),
+					s.awaitingInvocation("stale-2", -time.Minute),
+				}
+				s.mockInvocationRepo.On("ListAwaitingApprovalExpiredBefore", mock.Anything, mock.Anything, expireApprovalsBatchSize).Return(stale, nil)
+				s.mockInvocationRepo.On("UpdateIfStatus", mock.Anything, hasStatus(domain.InvocationStatusExpired), domain.InvocationStatusAwaitingApproval).Return(true, nil).Twice()
+				s.mockEventBus.On("Publish", mock.Anything, mock.AnythingOfType("events.Event")).Return(nil).Twice()
+			},
+			expectedEvents: 2,
+		},
+		{
+			name: "skips_invocations_decided_since_listed",
+			setupMocks: func(s *InvocationServiceTestSuite) {
+				stale := []*domain.Invocation{
+					s.awaitingInvocation("approved-meanwhile", -time.Minute),
+					s.awaitingInvocation("stale", -time.Minute),
+				}
+				s.mockInvocationRepo.On("ListAwaitingApprovalExpiredBefore", mock.Anything, mock.Anything, expireApprovalsBatchSize).Return(stale, nil)
+				s.mockInvocationRepo.On("UpdateIfStatus", mock.Anything, mock.MatchedBy(func(invocation *domain.Invocation) bool {
+					return invocation.ID == "approved-meanwhile"
+				}), domain.InvocationStatusAwaitingApproval).Return(false, nil).Once()
+				s.mockInvocationRepo.On("UpdateIfStatus", mock.Anything, mock.MatchedBy(func(invocation *domain.Invocation) bool {
+					return invocation.ID == "stale"
+				}), domain.InvocationStatusAwaitingApproval).Return(true, nil).Once()
+				s.mockEventBus.On("Publish", mock.Anything, mock.AnythingOfType("events.Event")).Return(nil).Once()
+			},
+			expectedEvents: 1,
+		},
+		{
+			name: "list_error",
+			setupMocks: func(s *InvocationServiceTestSuite) {
+				s.mockInvocationRepo.On("ListAwaitingApprovalExpiredBefore", mock.Anything, mock.Anything, expireApprovalsBatchSize).Return(nil, errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			// Reset mocks to ensure clean state for each test case
+			suite.resetMockState()
+
+			tc.setupMocks(suite)
+			err := suite.service.ExpireStaleApprovals(suite.testContext)
+			if tc.expectedError {
+				require.Error(suite.T(), err)
+			} else {
+				require.NoError(suite.T(), err)
+			}
+
+			suite.mockInvocationRepo.AssertExpectations(suite.T())
+			suite.mockEventBus.AssertNumberOfCalls(suite.T(), "Publish", tc.expectedEvents)
+		})
+	}
+}
+
 // TestInvocationServiceTestSuite runs the invocation service test suite
 func TestInvocationServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(InvocationServiceTestSuite))
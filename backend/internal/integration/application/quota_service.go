@@ -0,0 +1,224 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+)
+
+// QuotaExceededError reports the exhausted limit of a rejected invocation
+type QuotaExceededError struct {
+	Usage domain.QuotaUsage
+}
+
+// Error implements the error interface
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s: %s %s quota of %d reached, resets at %s",
+		ErrRateLimitExceeded, e.Usage.Limit.Scope, e.Usage.Limit.Window, e.Usage.Limit.Limit,
+		e.Usage.ResetAt.Format(time.RFC3339))
+}
+
+// Unwrap allows errors.Is to match ErrRateLimitExceeded
+func (e *QuotaExceededError) Unwrap() error {
+	return ErrRateLimitExceeded
+}
+
+// UserUsage is the usage report of a user
+type UserUsage struct {
+	Quotas []domain.QuotaUsage
+	Daily  []*domain.UsageRecord
+}
+
+// QuotaService enforces invocation quotas and reports usage
+type QuotaService struct {
+	counter   domain.QuotaCounter
+	usageRepo domain.UsageRepository
+	policy    domain.QuotaPolicy
+	obs       *observability.ObservabilityProvider
+}
+
+// NewQuotaService creates a new quota service
+func NewQuotaService(
+	counter domain.QuotaCounter,
+	usageRepo domain.UsageRepository,
+	policy domain.QuotaPolicy,
+	observabilityProvider *observability.ObservabilityProvider,
+) *QuotaService {
+	return &QuotaService{
+		counter:   counter,
+		usageRepo: usageRepo,
+		policy:    policy,
+		obs:       observabilityProvider,
+	}
+}
+
+// Consume counts one invocation against the applicable quotas, it returns a QuotaExceededError when a limit is exhausted
+func (s *QuotaService) Consume(ctx context.Context, userID, apiKeyID, providerIdentifier, operationIdentifier string) error {
+	if !s.policy.Enabled {
+		return nil
+	}
+
+	ctx, span := s.obs.Tracer.Start(ctx, "QuotaService.Consume")
+	defer span.End()
+
+	limits := s.limitsFor(ctx, userID, apiKeyID, providerIdentifier, operationIdentifier)
+	usages, allowed, err := s.counter.Consume(ctx, limits, domain.UsageRecord{
+		UserID:              userID,
+		APIKeyID:            apiKeyID,
+		ProviderIdentifier:  providerIdentifier,
+		OperationIdentifier: operationIdentifier,
+	}, time.Now())
+	if err != nil {
+		// Quotas fail open so an unavailable Redis does not take invocations down with it
+		s.obs.Logger.Error(ctx, "Failed to consume quota", zap.String("user_id", userID), zap.Error(err))
+		return nil
+	}
+
+	if !allowed {
+		for _, usage := range usages {
+			if usage.Used+1 > usage.Limit.Limit {
+				return &QuotaExceededError{Usage: usage}
+			}
+		}
+		return ErrRateLimitExceeded
+	}
+
+	return nil
+}
+
+// Release takes back an invocation counted by Consume that was never executed
+func (s *QuotaService) Release(ctx context.Context, userID, apiKeyID, providerIdentifier, operationIdentifier string) {
+	if !s.policy.Enabled {
+		return
+	}
+
+	ctx, span := s.obs.Tracer.Start(ctx, "QuotaService.Release")
+	defer span.End()
+
+	limits := s.limitsFor(ctx, userID, apiKeyID, providerIdentifier, operationIdentifier)
+	err := s.counter.Release(ctx, limits, domain.UsageRecord{
+		UserID:              userID,
+		APIKeyID:            apiKeyID,
+		ProviderIdentifier:  providerIdentifier,
+		OperationIdentifier: operationIdentifier,
+	}, time.Now())
+	if err != nil {
+		s.obs.Logger.Error(ctx, "Failed to release quota", zap.String("user_id", userID), zap.Error(err))
+	}
+}
+
+// Status returns the most restrictive quota of an invocation, nil if no quota applies
+func (s *QuotaService) Status(ctx context.Context, userID, apiKeyID, providerIdentifier, operationIdentifier string) (*domain.QuotaUsage, error) {
+	if !s.policy.Enabled {
+		return nil, nil
+	}
+
+	ctx, span := s.obs.Tracer.Start(ctx, "QuotaService.Status")
+	defer span.End()
+
+	limits := s.limitsFor(ctx, userID, apiKeyID, providerIdentifier, operationIdentifier)
+	if len(limits) == 0 {
+		return nil, nil
+	}
+
+	usages, err := s.counter.Peek(ctx, limits, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quota status: %w", err)
+	}
+
+	var status *domain.QuotaUsage
+	for i := range usages {
+		if status == nil || usages[i].Remaining() < status.Remaining() {
+			status = &usages[i]
+		}
+	}
+
+	return status, nil
+}
+
+// GetUsage returns the user-level quotas and the daily usage of a user between two days
+func (s *QuotaService) GetUsage(ctx context.Context, userID string, from, to time.Time) (*UserUsage, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "QuotaService.GetUsage")
+	defer span.End()
+
+	usage := &UserUsage{
+		Quotas: []domain.QuotaUsage{},
+		Daily:  []*domain.UsageRecord{},
+	}
+
+	now := time.Now()
+	if s.policy.Enabled {
+		limits := s.limitsFor(ctx, userID, "", "", "")
+		quotas, err := s.counter.Peek(ctx, limits, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get quota usage: %w", err)
+		}
+		usage.Quotas = quotas
+	}
+
+	daily, err := s.usageRepo.ListByUserID(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daily usage: %w", err)
+	}
+
+	// Today's rows lag behind the rollup, replace them with the live counters
+	today, _ := domain.QuotaWindowDay.Bounds(now)
+	if !today.Before(from) && !today.After(to) {
+		live, err := s.counter.UserDailyUsage(ctx, userID, today)
+		if err != nil {
+			s.obs.Logger.Warn(ctx, "Failed to get live daily usage", zap.Error(err))
+		} else {
+			filtered := daily[:0]
+			for _, record := range daily {
+				if !record.Day.Equal(today) {
+					filtered = append(filtered, record)
+				}
+			}
+			daily = append(filtered, live...)
+		}
+	}
+
+	sort.SliceStable(daily, func(i, j int) bool {
+		return daily[i].Day.Before(daily[j].Day)
+	})
+	usage.Daily = daily
+
+	return usage, nil
+}
+
+// limitsFor returns the limits applying to an invocation, with the service account limits for requests made as one
+func (s *QuotaService) limitsFor(ctx context.Context, userID, apiKeyID, providerIdentifier, operationIdentifier string) []domain.QuotaLimit {
+	if InvocationContextFromContext(ctx).ServiceAccount {
+		return s.policy.LimitsForServiceAccount(userID, apiKeyID, providerIdentifier, operationIdentifier)
+	}
+	return s.policy.LimitsFor(userID, apiKeyID, providerIdentifier, operationIdentifier)
+}
+
+// RollupUsage copies the daily usage counters of today and yesterday into the usage table
+func (s *QuotaService) RollupUsage(ctx context.Context) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "QuotaService.RollupUsage")
+	defer span.End()
+
+	now := time.Now()
+	total := 0
+	// Yesterday is included so the last minutes of a day are rolled up after midnight
+	for _, day := range []time.Time{now.AddDate(0, 0, -1), now} {
+		records, err := s.counter.DailyUsage(ctx, day)
+		if err != nil {
+			return fmt.Errorf("failed to get daily usage: %w", err)
+		}
+		if err := s.usageRepo.Upsert(ctx, records); err != nil {
+			return fmt.Errorf("failed to store daily usage: %w", err)
+		}
+		total += len(records)
+	}
+
+	s.obs.Logger.Info(ctx, "Rolled up invocation usage", zap.Int("records", total))
+	return nil
+}
@@ -0,0 +1,194 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	observability "github.com/context-space/cloud-observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	"github.com/context-space/context-space/backend/internal/integration/infrastructure/quota"
+	"github.com/context-space/context-space/backend/internal/shared/config"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/cache"
+)
+
+// memoryUsageRepository keeps the upserted daily usage keyed by day and usage dimensions
+type memoryUsageRepository struct {
+	records map[string]*domain.UsageRecord
+}
+
+func (r *memoryUsageRepository) Upsert(ctx context.Context, records []*domain.UsageRecord) error {
+	for _, record := range records {
+		r.records[usageRecordKey(record)] = record
+	}
+	return nil
+}
+
+func (r *memoryUsageRepository) ListByUserID(ctx context.Context, userID string, from, to time.Time) ([]*domain.UsageRecord, error) {
+	var records []*domain.UsageRecord
+	for _, record := range r.records {
+		if record.UserID == userID && !record.Day.Before(from) && !record.Day.After(to) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func usageRecordKey(record *domain.UsageRecord) string {
+	return record.Day.Format(time.DateOnly) + "|" + record.UserID + "|" + record.APIKeyID + "|" +
+		record.ProviderIdentifier + "|" + record.OperationIdentifier
+}
+
+// newQuotaTestService creates a quota service counting in an in-memory Redis
+func newQuotaTestService(t *testing.T, policy domain.QuotaPolicy) (*QuotaService, *quota.RedisCounter, *memoryUsageRepository) {
+	obs, _, err := observability.InitializeObservabilityProvider(context.Background(), &observability.LogConfig{
+		Level:       observability.ParseLogLevel("error"),
+		Format:      observability.ParseLogFormat("json"),
+		OutputPaths: []string{"stdout"},
+	}, &observability.TracingConfig{Enabled: false}, &observability.MetricsConfig{Enabled: false})
+	require.NoError(t, err)
+
+	server := miniredis.RunT(t)
+	port, err := strconv.Atoi(server.Port())
+	require.NoError(t, err)
+	redisClient, err := cache.NewRedisClient(&config.Config{Redis: config.RedisConfig{Host: server.Host(), Port: port}}, obs)
+	require.NoError(t, err)
+	t.Cleanup(func() { redisClient.Close() })
+
+	counter := quota.NewRedisCounter(redisClient, obs)
+	usageRepo := &memoryUsageRepository{records: map[string]*domain.UsageRecord{}}
+	return NewQuotaService(counter, usageRepo, policy, obs), counter, usageRepo
+}
+
+func TestQuotaServiceConsume(t *testing.T) {
+	policy := domain.QuotaPolicy{
+		Enabled:   true,
+		User:      domain.QuotaLimits{PerDay: 3},
+		APIKey:    domain.QuotaLimits{PerDay: 2},
+		Providers: map[string]domain.QuotaLimits{"github.create_issue": {PerDay: 5}},
+	}
+
+	tests := []struct {
+		name          string
+		apiKeyID      string
+		expectedScope domain.QuotaScope
+	}{
+		{name: "SessionReachesUserLimit", expectedScope: domain.QuotaScopeUser},
+		{name: "APIKeyReachesKeyLimit", apiKeyID: "key-1", expectedScope: domain.QuotaScopeAPIKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			service, _, _ := newQuotaTestService(t, policy)
+
+			allowed := 0
+			var err error
+			for i := 0; err == nil && i < 10; i++ {
+				if err = service.Consume(ctx, "user-1", tt.apiKeyID, "github", "create_issue"); err == nil {
+					allowed++
+				}
+			}
+
+			var exceeded *QuotaExceededError
+			require.ErrorAs(t, err, &exceeded)
+			assert.True(t, errors.Is(err, ErrRateLimitExceeded))
+			assert.Equal(t, tt.expectedScope, exceeded.Usage.Limit.Scope)
+			assert.Equal(t, int(exceeded.Usage.Limit.Limit), allowed)
+
+			// Another user is counted separately
+			assert.NoError(t, service.Consume(ctx, "user-2", "", "github", "create_issue"))
+		})
+	}
+}
+
+func TestQuotaServiceRelease(t *testing.T) {
+	ctx := context.Background()
+	service, counter, _ := newQuotaTestService(t, domain.QuotaPolicy{
+		Enabled: true,
+		User:    domain.QuotaLimits{PerDay: 1},
+	})
+
+	require.NoError(t, service.Consume(ctx, "user-1", "", "github", "create_issue"))
+	require.Error(t, service.Consume(ctx, "user-1", "", "github", "create_issue"))
+
+	service.Release(ctx, "user-1", "", "github", "create_issue")
+
+	status, err := service.Status(ctx, "user-1", "", "github", "create_issue")
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.Equal(t, int64(0), status.Used)
+
+	usage, err := counter.UserDailyUsage(ctx, "user-1", time.Now())
+	require.NoError(t, err)
+	require.Len(t, usage, 1)
+	assert.Equal(t, int64(0), usage[0].Count, "a released invocation must not be reported as usage")
+
+	assert.NoError(t, service.Consume(ctx, "user-1", "", "github", "create_issue"))
+}
+
+func TestQuotaServiceDisabled(t *testing.T) {
+	ctx := context.Background()
+	service, counter, _ := newQuotaTestService(t, domain.QuotaPolicy{
+		User: domain.QuotaLimits{PerDay: 1},
+	})
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, service.Consume(ctx, "user-1", "", "github", "create_issue"))
+	}
+
+	usage, err := counter.UserDailyUsage(ctx, "user-1", time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, usage)
+}
+
+func TestQuotaServiceRollupUsage(t *testing.T) {
+	ctx := context.Background()
+	service, counter, usageRepo := newQuotaTestService(t, domain.QuotaPolicy{Enabled: true})
+
+	now := time.Now()
+	today, _ := domain.QuotaWindowDay.Bounds(now)
+	yesterday := today.AddDate(0, 0, -1)
+
+	// The last invocation of yesterday is counted before midnight and rolled up after it
+	_, _, err := counter.Consume(ctx, nil, domain.UsageRecord{
+		UserID: "user-1", ProviderIdentifier: "github", OperationIdentifier: "create_issue",
+	}, yesterday.Add(23*time.Hour+59*time.Minute))
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, service.Consume(ctx, "user-1", "key-1", "github", "create_issue"))
+	}
+	require.NoError(t, service.Consume(ctx, "user-2", "", "slack", "post_message"))
+
+	require.NoError(t, service.RollupUsage(ctx))
+
+	expected := map[string]int64{
+		yesterday.Format(time.DateOnly) + "|user-1||github|create_issue":  1,
+		today.Format(time.DateOnly) + "|user-1|key-1|github|create_issue": 2,
+		today.Format(time.DateOnly) + "|user-2||slack|post_message":       1,
+	}
+	counts := make(map[string]int64, len(usageRepo.records))
+	for key, record := range usageRepo.records {
+		counts[key] = record.Count
+	}
+	assert.Equal(t, expected, counts)
+
+	// A second rollup replaces the counts instead of adding to them
+	require.NoError(t, service.Consume(ctx, "user-2", "", "slack", "post_message"))
+	require.NoError(t, service.RollupUsage(ctx))
+	assert.Equal(t, int64(1), usageRepo.records[yesterday.Format(time.DateOnly)+"|user-1||github|create_issue"].Count)
+	assert.Equal(t, int64(2), usageRepo.records[today.Format(time.DateOnly)+"|user-2||slack|post_message"].Count)
+
+	usage, err := service.GetUsage(ctx, "user-1", yesterday, today)
+	require.NoError(t, err)
+	require.Len(t, usage.Daily, 2)
+	assert.Equal(t, yesterday, usage.Daily[0].Day)
+	assert.Equal(t, today, usage.Daily[1].Day)
+	assert.Equal(t, int64(2), usage.Daily[1].Count)
+}
@@ -0,0 +1,40 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	contractProvider "github.com/context-space/context-space/backend/internal/shared/contract/providercore"
+)
+
+// resolveResponseShape validates the shape asked for an invocation and fills in the default projection
+// the catalog declares for the operation when a compact response is asked without fields
+func resolveResponseShape(
+	provider *contractProvider.ProviderDTO,
+	operationIdentifier string,
+	shape *domain.ResponseShape,
+) (*domain.ResponseShape, error) {
+	if shape == nil {
+		return nil, nil
+	}
+	if err := shape.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidParameters, err)
+	}
+
+	resolved := *shape
+	if resolved.Compact && len(resolved.Fields) == 0 {
+		for _, operation := range provider.Operations {
+			if operation.Identifier == operationIdentifier {
+				resolved.Fields = operation.CompactFields
+				break
+			}
+		}
+	}
+
+	// Nothing to reduce, the response is returned as is
+	if len(resolved.Fields) == 0 && resolved.MaxArrayItems == 0 && resolved.MaxStringLength == 0 &&
+		resolved.MaxBytes == 0 && resolved.MaxTokens == 0 {
+		return nil, nil
+	}
+	return &resolved, nil
+}
@@ -0,0 +1,413 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	"github.com/context-space/context-space/backend/internal/shared/events"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Schedule event types, failures notify the owner of the schedule
+const (
+	ScheduleRunFailedEvent events.EventType = "schedule.run_failed"
+	SchedulePausedEvent    events.EventType = "schedule.paused"
+)
+
+// scheduleRunConcurrency bounds the schedules run at the same time by a check
+const scheduleRunConcurrency = 5
+
+// ScheduleLimits bounds the schedules of users and their failures
+type ScheduleLimits struct {
+	// BatchSize is the maximum number of due schedules run per check
+	BatchSize int
+	// MaxPerUser is the maximum number of schedules a user can create
+	MaxPerUser int
+	// MaxConsecutiveFailures is the number of failed runs in a row after which a schedule is paused, 0 never pauses
+	MaxConsecutiveFailures int
+}
+
+// ScheduleInput holds the fields of a schedule to create
+type ScheduleInput struct {
+	Name                string
+	ProviderIdentifier  string
+	OperationIdentifier string
+	Parameters          map[string]interface{}
+	CronExpression      string
+	Timezone            string
+}
+
+// ScheduleUpdate holds the fields of a schedule to update, nil fields are left unchanged
+type ScheduleUpdate struct {
+	Name           *string
+	Parameters     *map[string]interface{}
+	CronExpression *string
+	Timezone       *string
+}
+
+// ScheduleService runs provider operations on user-defined schedules
+// Due schedules are checked by a cron task and claimed in the database, so they can be created,
+// changed and paused at any time without restarting the scheduler
+type ScheduleService struct {
+	scheduleRepo      domain.ScheduleRepository
+	runRepo           domain.ScheduleRunRepository
+	invocationService *InvocationService
+	eventBus          *events.Bus
+	limits            ScheduleLimits
+	obs               *observability.ObservabilityProvider
+}
+
+// NewScheduleService creates a new schedule service
+func NewScheduleService(
+	scheduleRepo domain.ScheduleRepository,
+	runRepo domain.ScheduleRunRepository,
+	invocationService *InvocationService,
+	eventBus *events.Bus,
+	limits ScheduleLimits,
+	observabilityProvider *observability.ObservabilityProvider,
+) *ScheduleService {
+	return &ScheduleService{
+		scheduleRepo:      scheduleRepo,
+		runRepo:           runRepo,
+		invocationService: invocationService,
+		eventBus:          eventBus,
+		limits:            limits,
+		obs:               observabilityProvider,
+	}
+}
+
+// RegisterEventHandlers deletes the schedules of deleted users and service accounts
+func (s *ScheduleService) RegisterEventHandlers(eventBus *events.Bus) {
+	eventBus.Subscribe("user.deleted", s.handlePrincipalDeleted)
+	eventBus.Subscribe("service_account.deleted", s.handlePrincipalDeleted)
+}
+
+// CreateSchedule creates an active schedule of a user after validating the operation and its parameters
+func (s *ScheduleService) CreateSchedule(ctx context.Context, userID string, input ScheduleInput) (*domain.Schedule, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ScheduleService.CreateSchedule")
+	defer span.End()
+
+	existing, err := s.scheduleRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %w", err)
+	}
+	if s.limits.MaxPerUser > 0 && len(existing) >= s.limits.MaxPerUser {
+		return nil, fmt.Errorf("%w: maximum number of schedules reached", domain.ErrValidation)
+	}
+
+	if err := s.invocationService.ValidateOperation(ctx, input.ProviderIdentifier, input.OperationIdentifier, input.Parameters); err != nil {
+		return nil, err
+	}
+
+	schedule, err := domain.NewSchedule(
+		userID,
+		input.Name,
+		input.ProviderIdentifier,
+		input.OperationIdentifier,
+		input.Parameters,
+		input.CronExpression,
+		input.Timezone,
+		time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Runs act for the organization and API key of the request creating the schedule
+	invocationCtx := InvocationContextFromContext(ctx)
+	schedule.OrganizationID = invocationCtx.OrganizationID
+	schedule.APIKeyID = invocationCtx.APIKeyID
+	schedule.ServiceAccount = invocationCtx.ServiceAccount
+
+	if err := s.scheduleRepo.Create(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to create schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// ListSchedules returns the schedules of a user
+func (s *ScheduleService) ListSchedules(ctx context.Context, userID string) ([]*domain.Schedule, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ScheduleService.ListSchedules")
+	defer span.End()
+
+	return s.scheduleRepo.ListByUserID(ctx, userID)
+}
+
+// GetSchedule returns a schedule of a user
+func (s *ScheduleService) GetSchedule(ctx context.Context, userID, scheduleID string) (*domain.Schedule, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ScheduleService.GetSchedule")
+	defer span.End()
+
+	schedule, err := s.scheduleRepo.GetByID(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+	if schedule == nil || schedule.UserID != userID {
+		return nil, domain.ErrScheduleNotFound
+	}
+
+	return schedule, nil
+}
+
+// UpdateSchedule updates the name, parameters or timing of a schedule
+func (s *ScheduleService) UpdateSchedule(ctx context.Context, userID, scheduleID string, update ScheduleUpdate) (*domain.Schedule, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ScheduleService.UpdateSchedule")
+	defer span.End()
+
+	schedule, err := s.GetSchedule(ctx, userID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if update.Name != nil {
+		if err := schedule.Rename(*update.Name, now); err != nil {
+			return nil, err
+		}
+	}
+	if update.Parameters != nil {
+		if err := s.invocationService.ValidateOperation(ctx, schedule.ProviderIdentifier, schedule.OperationIdentifier, *update.Parameters); err != nil {
+			return nil, err
+		}
+		schedule.Parameters = *update.Parameters
+		schedule.UpdatedAt = now
+	}
+	if update.CronExpression != nil || update.Timezone != nil {
+		cronExpression := schedule.CronExpression
+		if update.CronExpression != nil {
+			cronExpression = *update.CronExpression
+		}
+		timezone := schedule.Timezone
+		if update.Timezone != nil {
+			timezone = *update.Timezone
+		}
+		if err := schedule.SetTiming(cronExpression, timezone, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.scheduleRepo.Update(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to update schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// PauseSchedule stops a schedule from running until it is resumed
+func (s *ScheduleService) PauseSchedule(ctx context.Context, userID, scheduleID string) (*domain.Schedule, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ScheduleService.PauseSchedule")
+	defer span.End()
+
+	schedule, err := s.GetSchedule(ctx, userID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.Pause(time.Now())
+	if err := s.scheduleRepo.Update(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to pause schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// ResumeSchedule restarts a paused schedule from its next run time, resetting its failure count
+func (s *ScheduleService) ResumeSchedule(ctx context.Context, userID, scheduleID string) (*domain.Schedule, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ScheduleService.ResumeSchedule")
+	defer span.End()
+
+	schedule, err := s.GetSchedule(ctx, userID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := schedule.Resume(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.scheduleRepo.Update(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to resume schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// DeleteSchedule deletes a schedule, its runs and invocations are kept
+func (s *ScheduleService) DeleteSchedule(ctx context.Context, userID, scheduleID string) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "ScheduleService.DeleteSchedule")
+	defer span.End()
+
+	if _, err := s.GetSchedule(ctx, userID, scheduleID); err != nil {
+		return err
+	}
+
+	return s.scheduleRepo.Delete(ctx, scheduleID)
+}
+
+// ListRuns returns the runs of a schedule of a user, newest first
+func (s *ScheduleService) ListRuns(ctx context.Context, userID, scheduleID string, limit, offset int) ([]*domain.ScheduleRun, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ScheduleService.ListRuns")
+	defer span.End()
+
+	if _, err := s.GetSchedule(ctx, userID, scheduleID); err != nil {
+		return nil, err
+	}
+
+	return s.runRepo.ListByScheduleID(ctx, scheduleID, limit, offset)
+}
+
+// RunDueSchedules runs the schedules whose next run time has passed
+// Each schedule is claimed by moving its next run time before it runs, so a run is never made twice
+func (s *ScheduleService) RunDueSchedules(ctx context.Context) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "ScheduleService.RunDueSchedules")
+	defer span.End()
+
+	now := time.Now()
+	schedules, err := s.scheduleRepo.ListDue(ctx, now, s.limits.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list due schedules: %w", err)
+	}
+
+	semaphore := make(chan struct{}, scheduleRunConcurrency)
+	var wg sync.WaitGroup
+	claimed := 0
+	for _, schedule := range schedules {
+		dueAt := *schedule.NextRunAt
+		if err := schedule.Advance(now); err != nil {
+			s.obs.Logger.Error(ctx, "Failed to compute next run of schedule",
+				zap.String("schedule_id", schedule.ID),
+				zap.Error(err))
+			continue
+		}
+
+		ok, err := s.scheduleRepo.ClaimDue(ctx, schedule, dueAt)
+		if err != nil {
+			return fmt.Errorf("failed to claim schedule: %w", err)
+		}
+		if !ok {
+			continue
+		}
+		claimed++
+
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(schedule *domain.Schedule, dueAt time.Time) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			s.runSchedule(ctx, schedule, dueAt)
+		}(schedule, dueAt)
+	}
+	wg.Wait()
+
+	s.obs.Logger.Info(ctx, "Ran due schedules", zap.Int("due", len(schedules)), zap.Int("claimed", claimed))
+	return nil
+}
+
+// runSchedule invokes the operation of a claimed schedule and records the run
+func (s *ScheduleService) runSchedule(ctx context.Context, schedule *domain.Schedule, dueAt time.Time) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ScheduleService.runSchedule")
+	defer span.End()
+
+	// Restore the invocation context the schedule was created with, so the run resolves the credential of the
+	// organization and is metered against the API key
+	invocationCtx := WithInvocationContext(ctx, InvocationContext{
+		APIKeyID:       schedule.APIKeyID,
+		OrganizationID: schedule.OrganizationID,
+		ServiceAccount: schedule.ServiceAccount,
+	})
+
+	startedAt := time.Now()
+	invocation, invokeErr := s.invocationService.InvokeOperation(
+		invocationCtx,
+		schedule.UserID,
+		schedule.ProviderIdentifier,
+		schedule.OperationIdentifier,
+		schedule.Parameters,
+	)
+
+	run := domain.NewScheduleRun(schedule, dueAt, startedAt, invocation, invokeErr)
+	if err := s.runRepo.Create(ctx, run); err != nil {
+		s.obs.Logger.Error(ctx, "Failed to record schedule run",
+			zap.String("schedule_id", schedule.ID),
+			zap.Error(err))
+	}
+
+	paused := schedule.RecordRun(run, s.limits.MaxConsecutiveFailures)
+	if err := s.scheduleRepo.UpdateRunState(ctx, schedule); err != nil {
+		s.obs.Logger.Error(ctx, "Failed to record schedule run state",
+			zap.String("schedule_id", schedule.ID),
+			zap.Error(err))
+	}
+
+	if run.Status == domain.ScheduleRunStatusFailed {
+		s.emitScheduleEvent(ctx, ScheduleRunFailedEvent, schedule, run)
+	}
+	if paused {
+		s.emitScheduleEvent(ctx, SchedulePausedEvent, schedule, run)
+	}
+}
+
+// emitScheduleEvent publishes an event about a run of a schedule for its owner
+func (s *ScheduleService) emitScheduleEvent(ctx context.Context, eventType events.EventType, schedule *domain.Schedule, run *domain.ScheduleRun) {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	traceID := ""
+	spanID := ""
+	if spanCtx.IsValid() {
+		traceID = spanCtx.TraceID().String()
+		spanID = spanCtx.SpanID().String()
+	}
+
+	metadata := events.Metadata{
+		UserID:             schedule.UserID,
+		ProviderIdentifier: schedule.ProviderIdentifier,
+		Operation:          schedule.OperationIdentifier,
+		TraceID:            traceID,
+		SpanID:             spanID,
+		Properties: map[string]string{
+			"schedule_id":          schedule.ID,
+			"schedule_run_id":      run.ID,
+			"consecutive_failures": fmt.Sprintf("%d", schedule.ConsecutiveFailures),
+		},
+	}
+	if run.InvocationID != "" {
+		metadata.Properties["invocation_id"] = run.InvocationID
+	}
+
+	if err := s.eventBus.Publish(ctx, events.NewEvent(eventType, run, metadata)); err != nil {
+		s.obs.Logger.Error(ctx, "Failed to publish schedule event",
+			zap.Error(err),
+			zap.String("event_type", string(eventType)),
+			zap.String("schedule_id", schedule.ID))
+	}
+}
+
+// handlePrincipalDeleted deletes the schedules of a deleted user or service account
+func (s *ScheduleService) handlePrincipalDeleted(ctx context.Context, event events.Event) error {
+	userID := event.Metadata.UserID
+	if event.Type == "service_account.deleted" {
+		userID = event.Metadata.Properties["service_account_id"]
+	}
+	if userID == "" {
+		return nil
+	}
+
+	schedules, err := s.scheduleRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, schedule := range schedules {
+		if err := s.scheduleRepo.Delete(ctx, schedule.ID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,343 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	"github.com/context-space/context-space/backend/internal/shared/events"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// WebhookReceivedEvent is published when a verified delivery is stored, once per delivery
+const WebhookReceivedEvent events.EventType = "webhook.received"
+
+const (
+	// maxWebhookSubscriptionsPerUser bounds the webhook subscriptions a user can create
+	maxWebhookSubscriptionsPerUser = 20
+	// defaultWebhookEventsLimit and maxWebhookEventsLimit bound the events returned by a listing
+	defaultWebhookEventsLimit = 50
+	maxWebhookEventsLimit     = 200
+)
+
+// WebhookIngestResult is the outcome of ingesting a delivery
+type WebhookIngestResult struct {
+	// Challenge is the response to an endpoint validation request, no event is stored for it
+	Challenge interface{}
+	Event     *domain.WebhookEvent
+	// Duplicate is true when the delivery was already ingested, it is acknowledged without being published again
+	Duplicate bool
+}
+
+// WebhookService ingests the deliveries pushed by providers and manages the subscriptions receiving them
+type WebhookService struct {
+	subscriptionRepo domain.WebhookSubscriptionRepository
+	eventRepo        domain.WebhookEventRepository
+	secretProvider   domain.SecretProvider
+	verifiers        map[domain.WebhookScheme]domain.WebhookVerifier
+	eventBus         *events.Bus
+	retention        time.Duration
+	obs              *observability.ObservabilityProvider
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(
+	subscriptionRepo domain.WebhookSubscriptionRepository,
+	eventRepo domain.WebhookEventRepository,
+	secretProvider domain.SecretProvider,
+	verifiers map[domain.WebhookScheme]domain.WebhookVerifier,
+	eventBus *events.Bus,
+	retention time.Duration,
+	observabilityProvider *observability.ObservabilityProvider,
+) *WebhookService {
+	return &WebhookService{
+		subscriptionRepo: subscriptionRepo,
+		eventRepo:        eventRepo,
+		secretProvider:   secretProvider,
+		verifiers:        verifiers,
+		eventBus:         eventBus,
+		retention:        retention,
+		obs:              observabilityProvider,
+	}
+}
+
+// RegisterEventHandlers deletes the webhook subscriptions of deleted users and service accounts
+func (s *WebhookService) RegisterEventHandlers(eventBus *events.Bus) {
+	eventBus.Subscribe("user.deleted", s.handlePrincipalDeleted)
+	eventBus.Subscribe("service_account.deleted", s.handlePrincipalDeleted)
+}
+
+// CreateSubscription creates a webhook subscription of a user for a provider
+// The signing secret is required when it is issued by the provider, and generated when left empty otherwise
+// The returned subscription holds the plaintext secret, it is not returned afterwards
+func (s *WebhookService) CreateSubscription(ctx context.Context, userID, providerIdentifier, description, signingSecret string) (*domain.WebhookSubscription, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "WebhookService.CreateSubscription")
+	defer span.End()
+
+	scheme, ok := domain.WebhookSchemeFor(providerIdentifier)
+	if !ok {
+		return nil, domain.ErrWebhookProviderNotSupported
+	}
+
+	existing, err := s.subscriptionRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	if len(existing) >= maxWebhookSubscriptionsPerUser {
+		return nil, fmt.Errorf("%w: maximum number of webhook subscriptions reached", domain.ErrValidation)
+	}
+
+	if signingSecret == "" {
+		if !scheme.SubscriberChoosesSecret() {
+			return nil, domain.ErrWebhookSigningSecretRequired
+		}
+		if signingSecret, err = domain.GenerateSigningSecret(); err != nil {
+			return nil, fmt.Errorf("failed to generate signing secret: %w", err)
+		}
+	}
+	if err := validateSigningSecret(scheme, signingSecret); err != nil {
+		return nil, err
+	}
+
+	subscription := domain.NewWebhookSubscription(userID, providerIdentifier, description, signingSecret)
+	if subscription.EncryptedSigningSecret, err = s.secretProvider.EncryptSecret(ctx, signingSecret); err != nil {
+		return nil, fmt.Errorf("failed to encrypt signing secret: %w", err)
+	}
+
+	if err := s.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// ListSubscriptions returns the webhook subscriptions of a user
+func (s *WebhookService) ListSubscriptions(ctx context.Context, userID string) ([]*domain.WebhookSubscription, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "WebhookService.ListSubscriptions")
+	defer span.End()
+
+	return s.subscriptionRepo.ListByUserID(ctx, userID)
+}
+
+// GetSubscription returns a webhook subscription of a user
+func (s *WebhookService) GetSubscription(ctx context.Context, userID, subscriptionID string) (*domain.WebhookSubscription, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "WebhookService.GetSubscription")
+	defer span.End()
+
+	subscription, err := s.subscriptionRepo.GetByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	if subscription == nil || subscription.UserID != userID {
+		return nil, domain.ErrWebhookSubscriptionNotFound
+	}
+
+	return subscription, nil
+}
+
+// UpdateSubscription updates the description of a webhook subscription and rotates its signing secret if set
+func (s *WebhookService) UpdateSubscription(ctx context.Context, userID, subscriptionID string, description, signingSecret *string) (*domain.WebhookSubscription, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "WebhookService.UpdateSubscription")
+	defer span.End()
+
+	subscription, err := s.GetSubscription(ctx, userID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if description != nil {
+		subscription.Description = *description
+	}
+	if signingSecret != nil {
+		if err := validateSigningSecret(subscription.Scheme(), *signingSecret); err != nil {
+			return nil, err
+		}
+		if subscription.EncryptedSigningSecret, err = s.secretProvider.EncryptSecret(ctx, *signingSecret); err != nil {
+			return nil, fmt.Errorf("failed to encrypt signing secret: %w", err)
+		}
+	}
+	subscription.UpdatedAt = time.Now()
+
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// DeleteSubscription deletes a webhook subscription, its stored events expire with the retention
+func (s *WebhookService) DeleteSubscription(ctx context.Context, userID, subscriptionID string) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "WebhookService.DeleteSubscription")
+	defer span.End()
+
+	if _, err := s.GetSubscription(ctx, userID, subscriptionID); err != nil {
+		return err
+	}
+
+	return s.subscriptionRepo.Delete(ctx, subscriptionID)
+}
+
+// Ingest verifies a delivery pushed to a subscription endpoint, stores it once and publishes it on the event bus
+func (s *WebhookService) Ingest(ctx context.Context, providerIdentifier, subscriptionID string, delivery *domain.WebhookDelivery) (*WebhookIngestResult, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "WebhookService.Ingest")
+	defer span.End()
+
+	subscription, err := s.subscriptionRepo.GetByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	if subscription == nil || subscription.ProviderIdentifier != providerIdentifier {
+		return nil, domain.ErrWebhookSubscriptionNotFound
+	}
+
+	verifier, ok := s.verifiers[subscription.Scheme()]
+	if !ok {
+		return nil, domain.ErrWebhookProviderNotSupported
+	}
+
+	secret, err := s.secretProvider.DecryptSecret(ctx, subscription.EncryptedSigningSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt signing secret: %w", err)
+	}
+
+	if err := verifier.Verify(secret, delivery); err != nil {
+		s.obs.Logger.Warn(ctx, "Rejected webhook delivery",
+			zap.String("subscription_id", subscription.ID),
+			zap.String("provider_identifier", providerIdentifier),
+			zap.Error(err))
+		return nil, err
+	}
+
+	challenge, err := verifier.Challenge(secret, delivery)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return &WebhookIngestResult{Challenge: challenge}, nil
+	}
+
+	deliveryID, eventType := verifier.Normalize(delivery)
+	event := domain.NewWebhookEvent(subscription, delivery, deliveryID, eventType)
+
+	created, err := s.eventRepo.Create(ctx, event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store webhook event: %w", err)
+	}
+	if !created {
+		s.obs.Logger.Debug(ctx, "Ignored duplicate webhook delivery",
+			zap.String("subscription_id", subscription.ID),
+			zap.String("delivery_id", event.DeliveryID))
+		return &WebhookIngestResult{Event: event, Duplicate: true}, nil
+	}
+
+	if err := s.subscriptionRepo.UpdateLastDeliveryAt(ctx, subscription.ID, delivery.ReceivedAt); err != nil {
+		s.obs.Logger.Warn(ctx, "Failed to record webhook delivery time",
+			zap.String("subscription_id", subscription.ID),
+			zap.Error(err))
+	}
+
+	s.emitWebhookEvent(ctx, event)
+
+	return &WebhookIngestResult{Event: event}, nil
+}
+
+// ListEvents returns the stored events of a user matching the filter
+func (s *WebhookService) ListEvents(ctx context.Context, filter domain.WebhookEventFilter) ([]*domain.WebhookEvent, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "WebhookService.ListEvents")
+	defer span.End()
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultWebhookEventsLimit
+	}
+	if filter.Limit > maxWebhookEventsLimit {
+		filter.Limit = maxWebhookEventsLimit
+	}
+
+	return s.eventRepo.List(ctx, filter)
+}
+
+// PurgeExpiredEvents deletes the events received before the retention period
+func (s *WebhookService) PurgeExpiredEvents(ctx context.Context) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "WebhookService.PurgeExpiredEvents")
+	defer span.End()
+
+	deleted, err := s.eventRepo.DeleteReceivedBefore(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		return fmt.Errorf("failed to purge webhook events: %w", err)
+	}
+
+	s.obs.Logger.Info(ctx, "Purged expired webhook events", zap.Int64("deleted", deleted))
+	return nil
+}
+
+// emitWebhookEvent publishes a stored event for the owner of the subscription
+func (s *WebhookService) emitWebhookEvent(ctx context.Context, event *domain.WebhookEvent) {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	traceID := ""
+	spanID := ""
+	if spanCtx.IsValid() {
+		traceID = spanCtx.TraceID().String()
+		spanID = spanCtx.SpanID().String()
+	}
+
+	metadata := events.Metadata{
+		UserID:             event.UserID,
+		ProviderIdentifier: event.ProviderIdentifier,
+		TraceID:            traceID,
+		SpanID:             spanID,
+		Properties: map[string]string{
+			"webhook_event_id": event.ID,
+			"subscription_id":  event.SubscriptionID,
+			"event_type":       event.EventType,
+		},
+	}
+
+	if err := s.eventBus.Publish(ctx, events.NewEvent(WebhookReceivedEvent, event, metadata)); err != nil {
+		s.obs.Logger.Error(ctx, "Failed to publish webhook event",
+			zap.Error(err),
+			zap.String("webhook_event_id", event.ID))
+	}
+}
+
+// handlePrincipalDeleted deletes the webhook subscriptions of a deleted user or service account
+func (s *WebhookService) handlePrincipalDeleted(ctx context.Context, event events.Event) error {
+	userID := event.Metadata.UserID
+	if event.Type == "service_account.deleted" {
+		userID = event.Metadata.Properties["service_account_id"]
+	}
+	if userID == "" {
+		return nil
+	}
+
+	subscriptions, err := s.subscriptionRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, subscription := range subscriptions {
+		if err := s.subscriptionRepo.Delete(ctx, subscription.ID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateSigningSecret checks the format of the secret expected by a scheme
+func validateSigningSecret(scheme domain.WebhookScheme, signingSecret string) error {
+	if signingSecret == "" {
+		return domain.ErrWebhookSigningSecretRequired
+	}
+	if scheme == domain.WebhookSchemeAirtable {
+		if _, err := base64.StdEncoding.DecodeString(signingSecret); err != nil {
+			return fmt.Errorf("%w: the Airtable MAC secret must be base64 encoded", domain.ErrValidation)
+		}
+	}
+	return nil
+}
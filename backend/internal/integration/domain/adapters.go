@@ -4,6 +4,7 @@ import (
 	"context"
 
 	contractCredential "github.com/context-space/context-space/backend/internal/shared/contract/credentialmanagement"
+	contractIdentity "github.com/context-space/context-space/backend/internal/shared/contract/identityaccess"
 	contractAdapter "github.com/context-space/context-space/backend/internal/shared/contract/provideradapter"
 	contractProvider "github.com/context-space/context-space/backend/internal/shared/contract/providercore"
 )
@@ -24,14 +25,35 @@ type CredentialProvider interface {
 	// GetCredentialByUserAndProvider retrieves a credential by user ID and provider ID
 	GetCredentialByUserAndProvider(ctx context.Context, userID, providerIdentifier string) (interface{}, error)
 
+	// GetCredentialByOrganizationAndProvider retrieves the credential an organization shares for a provider
+	GetCredentialByOrganizationAndProvider(ctx context.Context, organizationID, providerIdentifier string) (interface{}, error)
+
 	// CreateNone creates a new no-auth credential
 	CreateNone(ctx context.Context, userID, providerIdentifier string) (*contractCredential.CredentialDTO, error)
 
 	// UpdateCredentialLastUsedAt updates the last used at time of a credential
 	UpdateCredentialLastUsedAt(ctx context.Context, credential interface{}) error
+
+	// CreateIncrementalAuthorization starts an OAuth flow granting the missing permissions on top of the existing ones,
+	// on the organization's credential when organizationID is set
+	CreateIncrementalAuthorization(ctx context.Context, userID, organizationID, providerIdentifier string, missingPermissions []string) (*contractCredential.IncrementalAuthorizationDTO, error)
+}
+
+// OrganizationProvider defines an interface for resolving organization memberships
+type OrganizationProvider interface {
+	// GetMembership returns the membership of a user in an organization, or nil if the user is not a member
+	GetMembership(ctx context.Context, organizationID, userID string) (*contractIdentity.MembershipDTO, error)
 }
 
 type TokenRefreshProvider interface {
 	// RefreshAccessToken refreshes the access token if needed
 	RefreshAccessToken(ctx context.Context, providerIdentifier string, credential interface{}) (interface{}, error)
 }
+
+// SecretProvider defines an interface for encrypting the secrets stored by the integration module
+type SecretProvider interface {
+	// EncryptSecret encrypts a secret, returning the encryption metadata to store as JSON
+	EncryptSecret(ctx context.Context, plaintext string) (string, error)
+	// DecryptSecret decrypts a secret from its stored encryption metadata
+	DecryptSecret(ctx context.Context, encryptionMetadata string) (string, error)
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/context-space/context-space/backend/internal/shared/types"
+	"github.com/google/uuid"
+)
+
+// DefaultApprovalTTL is how long a gated invocation waits for a decision by default
+const DefaultApprovalTTL = 24 * time.Hour
+
+// ApprovalPolicy decides which invocations of a user must be approved before they run
+type ApprovalPolicy struct {
+	ID      string
+	UserID  string
+	Enabled bool
+	// MinRiskLevel is the lowest operation risk level that requires approval,
+	// read operations are only gated through AlwaysRequire
+	MinRiskLevel types.OperationRiskLevel
+	// AlwaysRequire lists "provider.operation" identifiers that always require approval
+	AlwaysRequire []string
+	// NeverRequire lists "provider.operation" identifiers that never require approval
+	NeverRequire []string
+	ApprovalTTL  time.Duration
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// NewApprovalPolicy creates the default policy for a user. It is disabled so that existing API and MCP clients
+// keep running destructive operations directly, once enabled it gates destructive operations
+func NewApprovalPolicy(userID string) *ApprovalPolicy {
+	return &ApprovalPolicy{
+		ID:           uuid.New().String(),
+		UserID:       userID,
+		Enabled:      false,
+		MinRiskLevel: types.RiskLevelDestructive,
+		ApprovalTTL:  DefaultApprovalTTL,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+}
+
+// RequiresApproval reports whether an invocation of the operation must be approved
+func (p *ApprovalPolicy) RequiresApproval(providerIdentifier, operationIdentifier string, riskLevel types.OperationRiskLevel) bool {
+	if !p.Enabled {
+		return false
+	}
+
+	toolName := providerIdentifier + "." + operationIdentifier
+	for _, name := range p.NeverRequire {
+		if name == toolName {
+			return false
+		}
+	}
+	for _, name := range p.AlwaysRequire {
+		if name == toolName {
+			return true
+		}
+	}
+
+	return riskLevel.Severity() >= p.MinRiskLevel.Severity() && riskLevel.Severity() > types.RiskLevelRead.Severity()
+}
+
+// TTL returns the approval window, falling back to the default
+func (p *ApprovalPolicy) TTL() time.Duration {
+	if p.ApprovalTTL <= 0 {
+		return DefaultApprovalTTL
+	}
+	return p.ApprovalTTL
+}
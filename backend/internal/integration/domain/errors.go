@@ -24,3 +24,33 @@ var (
 	// ErrProviderUnavailable is returned when a provider is unavailable
 	ErrProviderUnavailable = errors.New("provider unavailable")
 )
+
+// Webhook error definitions
+var (
+	// ErrWebhookSubscriptionNotFound is returned when a webhook subscription cannot be found
+	ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+
+	// ErrWebhookProviderNotSupported is returned when the deliveries of a provider cannot be ingested
+	ErrWebhookProviderNotSupported = errors.New("provider does not support webhook ingestion")
+
+	// ErrWebhookSigningSecretRequired is returned when a subscription is created without the secret issued by the provider
+	ErrWebhookSigningSecretRequired = errors.New("signing secret is required")
+
+	// ErrWebhookSignatureInvalid is returned when the signature of a delivery does not match
+	ErrWebhookSignatureInvalid = errors.New("invalid webhook signature")
+)
+
+// Schedule error definitions
+var (
+	// ErrScheduleNotFound is returned when a schedule cannot be found
+	ErrScheduleNotFound = errors.New("schedule not found")
+
+	// ErrInvalidSchedule is returned when the cron expression or timezone of a schedule is invalid
+	ErrInvalidSchedule = errors.New("invalid schedule")
+)
+
+// Response shape error definitions
+var (
+	// ErrInvalidResponseShape is returned when the projection paths or limits of a response shape are invalid
+	ErrInvalidResponseShape = errors.New("invalid response shape")
+)
@@ -15,12 +15,41 @@ const (
 	InvocationStatusSuccess InvocationStatus = "success"
 	// InvocationStatusFailed represents a failed invocation
 	InvocationStatusFailed InvocationStatus = "failed"
+	// InvocationStatusAwaitingApproval represents an invocation held for user approval
+	InvocationStatusAwaitingApproval InvocationStatus = "awaiting_approval"
+	// InvocationStatusDenied represents an invocation rejected by the user
+	InvocationStatusDenied InvocationStatus = "denied"
+	// InvocationStatusExpired represents an invocation whose approval window elapsed
+	InvocationStatusExpired InvocationStatus = "expired"
 )
 
+// InvocationApproval holds the approval state of a gated invocation
+type InvocationApproval struct {
+	RiskLevel          string                 `json:"risk_level"`
+	RequestedAt        time.Time              `json:"requested_at"`
+	ExpiresAt          time.Time              `json:"expires_at"`
+	DecidedAt          *time.Time             `json:"decided_at,omitempty"`
+	DecidedBy          string                 `json:"decided_by,omitempty"`
+	Reason             string                 `json:"reason,omitempty"`
+	OriginalParameters map[string]interface{} `json:"original_parameters,omitempty"`
+}
+
+// InvocationPaginate holds how many pages of a paginated operation an invocation follows
+type InvocationPaginate struct {
+	Cursor   string `json:"cursor,omitempty"`
+	MaxItems int    `json:"max_items,omitempty"`
+	MaxPages int    `json:"max_pages,omitempty"`
+}
+
 // Invocation represents an invocation of an operation on a provider
 type Invocation struct {
-	ID                  string
-	UserID              string
+	ID     string
+	UserID string
+	// OrganizationID is the organization the invocation was made for, empty for personal invocations
+	OrganizationID string
+	// APIKeyID is the API key the invocation was requested with, empty for session requests.
+	// Gated invocations are charged to its quotas once approved.
+	APIKeyID            string
 	ProviderIdentifier  string
 	OperationIdentifier string
 	Status              InvocationStatus
@@ -30,9 +59,18 @@ type Invocation struct {
 	ErrorMessage        string
 	Parameters          map[string]interface{}
 	ResponseData        json.RawMessage
-	CreatedAt           time.Time
-	UpdatedAt           time.Time
-	DeletedAt           *time.Time
+	Approval            *InvocationApproval
+	// Paginate is set when the invocation follows several pages of a paginated operation
+	Paginate *InvocationPaginate
+	// NextCursor resumes a paginated operation after the last fetched page, empty once the last page was fetched
+	NextCursor string
+	// Shape is set when the response was projected, sliced or trimmed to a budget before it was stored
+	Shape *ResponseShape
+	// Elisions records what shaping removed from the response
+	Elisions  []ResponseElision
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
 }
 
 // NewInvocation creates a new invocation
@@ -87,7 +125,12 @@ func (i *Invocation) CalculateDuration() int64 {
 
 // IsCompleted returns true if the invocation is completed
 func (i *Invocation) IsCompleted() bool {
-	return i.Status == InvocationStatusSuccess || i.Status == InvocationStatusFailed
+	switch i.Status {
+	case InvocationStatusSuccess, InvocationStatusFailed, InvocationStatusDenied, InvocationStatusExpired:
+		return true
+	default:
+		return false
+	}
 }
 
 // IsSuccessful returns true if the invocation was successful
@@ -99,3 +142,74 @@ func (i *Invocation) IsSuccessful() bool {
 func (i *Invocation) IsFailed() bool {
 	return i.Status == InvocationStatusFailed
 }
+
+// RequireApproval holds the invocation until the user approves or denies it
+func (i *Invocation) RequireApproval(riskLevel string, ttl time.Duration) {
+	now := time.Now()
+	i.Status = InvocationStatusAwaitingApproval
+	i.Approval = &InvocationApproval{
+		RiskLevel:   riskLevel,
+		RequestedAt: now,
+		ExpiresAt:   now.Add(ttl),
+	}
+	i.UpdatedAt = now
+}
+
+// IsAwaitingApproval returns true if the invocation is waiting for a decision
+func (i *Invocation) IsAwaitingApproval() bool {
+	return i.Status == InvocationStatusAwaitingApproval
+}
+
+// IsApprovalExpired returns true if the approval window has elapsed
+func (i *Invocation) IsApprovalExpired(now time.Time) bool {
+	return i.Approval != nil && now.After(i.Approval.ExpiresAt)
+}
+
+// Snapshot returns a copy of the invocation that later decisions on the invocation do not change
+func (i *Invocation) Snapshot() *Invocation {
+	snapshot := *i
+	if i.Approval != nil {
+		approval := *i.Approval
+		snapshot.Approval = &approval
+	}
+	return &snapshot
+}
+
+// Approve records the approval decision, replacing the parameters if edited ones are given
+func (i *Invocation) Approve(decidedBy string, parameters map[string]interface{}) {
+	now := time.Now()
+	if i.Approval == nil {
+		i.Approval = &InvocationApproval{RequestedAt: now, ExpiresAt: now}
+	}
+	if parameters != nil {
+		i.Approval.OriginalParameters = i.Parameters
+		i.Parameters = parameters
+	}
+	i.Approval.DecidedAt = &now
+	i.Approval.DecidedBy = decidedBy
+	i.Status = InvocationStatusPending
+	i.UpdatedAt = now
+}
+
+// Deny records the rejection of the invocation
+func (i *Invocation) Deny(decidedBy, reason string) {
+	now := time.Now()
+	if i.Approval == nil {
+		i.Approval = &InvocationApproval{RequestedAt: now, ExpiresAt: now}
+	}
+	i.Approval.DecidedAt = &now
+	i.Approval.DecidedBy = decidedBy
+	i.Approval.Reason = reason
+	i.Status = InvocationStatusDenied
+	i.CompletedAt = &now
+	i.UpdatedAt = now
+}
+
+// Expire marks an invocation whose approval window elapsed without a decision
+func (i *Invocation) Expire() {
+	now := time.Now()
+	i.Status = InvocationStatusExpired
+	i.ErrorMessage = "approval window expired"
+	i.CompletedAt = &now
+	i.UpdatedAt = now
+}
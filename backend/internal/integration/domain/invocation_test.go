@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInvocationApprovalTransitions(t *testing.T) {
+	original := map[string]interface{}{"title": "draft"}
+	edited := map[string]interface{}{"title": "final"}
+
+	tests := []struct {
+		name               string
+		decide             func(*Invocation)
+		expectedStatus     InvocationStatus
+		expectedParameters map[string]interface{}
+		expectedOriginal   map[string]interface{}
+		completed          bool
+	}{
+		{
+			name:               "Approve",
+			decide:             func(i *Invocation) { i.Approve("user-1", nil) },
+			expectedStatus:     InvocationStatusPending,
+			expectedParameters: original,
+		},
+		{
+			name:               "ApproveWithEditedParameters",
+			decide:             func(i *Invocation) { i.Approve("user-1", edited) },
+			expectedStatus:     InvocationStatusPending,
+			expectedParameters: edited,
+			expectedOriginal:   original,
+		},
+		{
+			name:               "Deny",
+			decide:             func(i *Invocation) { i.Deny("user-1", "too risky") },
+			expectedStatus:     InvocationStatusDenied,
+			expectedParameters: original,
+			completed:          true,
+		},
+		{
+			name:               "Expire",
+			decide:             func(i *Invocation) { i.Expire() },
+			expectedStatus:     InvocationStatusExpired,
+			expectedParameters: original,
+			completed:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invocation := NewInvocation("invocation-1", "user-1", "github", "create_issue", original)
+			invocation.RequireApproval("write", time.Hour)
+			if !invocation.IsAwaitingApproval() {
+				t.Fatalf("Expected invocation to await approval, got status: %s", invocation.Status)
+			}
+			if invocation.IsApprovalExpired(time.Now()) {
+				t.Fatalf("Expected approval window to be open")
+			}
+			if !invocation.IsApprovalExpired(time.Now().Add(2 * time.Hour)) {
+				t.Fatalf("Expected approval window to elapse after the ttl")
+			}
+
+			tt.decide(invocation)
+
+			if invocation.Status != tt.expectedStatus {
+				t.Errorf("Expected status %s, got: %s", tt.expectedStatus, invocation.Status)
+			}
+			if invocation.IsAwaitingApproval() {
+				t.Errorf("Expected invocation to no longer await approval")
+			}
+			if invocation.IsCompleted() != tt.completed {
+				t.Errorf("Expected completed %v, got: %v", tt.completed, invocation.IsCompleted())
+			}
+			if (invocation.CompletedAt != nil) != tt.completed {
+				t.Errorf("Expected completed at to be set %v, got: %v", tt.completed, invocation.CompletedAt)
+			}
+			if invocation.Parameters["title"] != tt.expectedParameters["title"] {
+				t.Errorf("Expected parameters %v, got: %v", tt.expectedParameters, invocation.Parameters)
+			}
+			if invocation.Approval.OriginalParameters["title"] != tt.expectedOriginal["title"] {
+				t.Errorf("Expected original parameters %v, got: %v", tt.expectedOriginal, invocation.Approval.OriginalParameters)
+			}
+			if tt.expectedStatus != InvocationStatusExpired && invocation.Approval.DecidedBy != "user-1" {
+				t.Errorf("Expected decision by user-1, got: %s", invocation.Approval.DecidedBy)
+			}
+		})
+	}
+}
+
+func TestInvocationSnapshot(t *testing.T) {
+	original := map[string]interface{}{"title": "draft"}
+	invocation := NewInvocation("invocation-1", "user-1", "github", "create_issue", original)
+	invocation.RequireApproval("write", time.Hour)
+
+	snapshot := invocation.Snapshot()
+	invocation.Approve("user-1", map[string]interface{}{"title": "final"})
+	invocation.SetStarted()
+
+	if !snapshot.IsAwaitingApproval() {
+		t.Errorf("Expected snapshot to still await approval, got status: %s", snapshot.Status)
+	}
+	if snapshot.Approval.DecidedAt != nil || snapshot.Approval.DecidedBy != "" {
+		t.Errorf("Expected snapshot to hold no decision, got: %+v", snapshot.Approval)
+	}
+	if snapshot.Parameters["title"] != "draft" || snapshot.StartedAt != nil {
+		t.Errorf("Expected snapshot to keep the awaiting parameters and no start time, got: %v %v", snapshot.Parameters, snapshot.StartedAt)
+	}
+}
+
+func TestNewApprovalPolicyDisabledByDefault(t *testing.T) {
+	policy := NewApprovalPolicy("user-1")
+	if policy.RequiresApproval("github", "delete_repository", "destructive") {
+		t.Error("Expected the default policy not to gate destructive operations until the user enables it")
+	}
+
+	policy.Enabled = true
+	if !policy.RequiresApproval("github", "delete_repository", "destructive") {
+		t.Error("Expected an enabled policy to gate destructive operations")
+	}
+}
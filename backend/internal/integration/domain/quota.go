@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// QuotaWindow represents the time window a quota limit applies to
+type QuotaWindow string
+
+const (
+	QuotaWindowMinute QuotaWindow = "minute"
+	QuotaWindowDay    QuotaWindow = "day"
+	QuotaWindowMonth  QuotaWindow = "month"
+)
+
+// Bounds returns the start and end of the window containing the given time, in UTC
+func (w QuotaWindow) Bounds(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	switch w {
+	case QuotaWindowMinute:
+		start := now.Truncate(time.Minute)
+		return start, start.Add(time.Minute)
+	case QuotaWindowDay:
+		start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 0, 1)
+	default:
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	}
+}
+
+// QuotaScope represents the subject a quota limit is counted against
+type QuotaScope string
+
+const (
+	QuotaScopeUser           QuotaScope = "user"
+	QuotaScopeServiceAccount QuotaScope = "service_account"
+	QuotaScopeAPIKey         QuotaScope = "api_key"
+	QuotaScopeProvider       QuotaScope = "provider"
+	QuotaScopeOperation      QuotaScope = "operation"
+)
+
+// QuotaLimits holds the invocation limits per window, zero means unlimited
+type QuotaLimits struct {
+	PerMinute int64
+	PerDay    int64
+	PerMonth  int64
+}
+
+// QuotaPolicy holds the configured limits for every quota scope
+type QuotaPolicy struct {
+	Enabled bool
+	User    QuotaLimits
+	// ServiceAccount replaces the user limits for invocations made as a service account
+	ServiceAccount QuotaLimits
+	APIKey         QuotaLimits
+	// Providers is keyed by provider identifier or by provider.operation identifier
+	Providers map[string]QuotaLimits
+}
+
+// QuotaLimit is a single limit applied to one subject in one window
+type QuotaLimit struct {
+	Scope   QuotaScope
+	Subject string
+	Window  QuotaWindow
+	Limit   int64
+}
+
+// CounterKey returns the key of the counter tracking this limit in the window containing the given time
+func (l QuotaLimit) CounterKey(now time.Time) string {
+	start, _ := l.Window.Bounds(now)
+	return fmt.Sprintf("quota:%s:%s:%s:%d", l.Scope, l.Subject, l.Window, start.Unix())
+}
+
+// QuotaUsage is the consumption of a quota limit in its current window
+type QuotaUsage struct {
+	Limit   QuotaLimit
+	Used    int64
+	ResetAt time.Time
+}
+
+// Remaining returns how many invocations are left in the current window
+func (u QuotaUsage) Remaining() int64 {
+	if u.Used >= u.Limit.Limit {
+		return 0
+	}
+	return u.Limit.Limit - u.Used
+}
+
+// LimitsFor returns the limits applying to an invocation, the API key ID is empty for session requests
+func (p QuotaPolicy) LimitsFor(userID, apiKeyID, providerIdentifier, operationIdentifier string) []QuotaLimit {
+	return p.limitsFor(QuotaScopeUser, p.User, userID, apiKeyID, providerIdentifier, operationIdentifier)
+}
+
+// LimitsForServiceAccount returns the limits applying to an invocation made as a service account
+func (p QuotaPolicy) LimitsForServiceAccount(serviceAccountID, apiKeyID, providerIdentifier, operationIdentifier string) []QuotaLimit {
+	return p.limitsFor(QuotaScopeServiceAccount, p.ServiceAccount, serviceAccountID, apiKeyID, providerIdentifier, operationIdentifier)
+}
+
+// limitsFor returns the limits applying to an invocation of a principal counted under the given scope
+func (p QuotaPolicy) limitsFor(
+	principalScope QuotaScope,
+	principalLimits QuotaLimits,
+	userID, apiKeyID, providerIdentifier, operationIdentifier string,
+) []QuotaLimit {
+	var limits []QuotaLimit
+	limits = appendQuotaLimits(limits, principalScope, userID, principalLimits)
+	if apiKeyID != "" {
+		limits = appendQuotaLimits(limits, QuotaScopeAPIKey, apiKeyID, p.APIKey)
+	}
+	if providerLimits, ok := p.Providers[providerIdentifier]; ok {
+		limits = appendQuotaLimits(limits, QuotaScopeProvider, userID+":"+providerIdentifier, providerLimits)
+	}
+	operationKey := providerIdentifier + "." + operationIdentifier
+	if operationLimits, ok := p.Providers[operationKey]; ok {
+		limits = appendQuotaLimits(limits, QuotaScopeOperation, userID+":"+operationKey, operationLimits)
+	}
+	return limits
+}
+
+// appendQuotaLimits appends a limit for every window with a non zero value
+func appendQuotaLimits(limits []QuotaLimit, scope QuotaScope, subject string, quotaLimits QuotaLimits) []QuotaLimit {
+	windows := []struct {
+		window QuotaWindow
+		limit  int64
+	}{
+		{QuotaWindowMinute, quotaLimits.PerMinute},
+		{QuotaWindowDay, quotaLimits.PerDay},
+		{QuotaWindowMonth, quotaLimits.PerMonth},
+	}
+	for _, w := range windows {
+		if w.limit > 0 {
+			limits = append(limits, QuotaLimit{Scope: scope, Subject: subject, Window: w.window, Limit: w.limit})
+		}
+	}
+	return limits
+}
+
+// UsageRecord is the number of invocations of an operation by a user and API key on one day
+type UsageRecord struct {
+	Day                 time.Time
+	UserID              string
+	APIKeyID            string
+	ProviderIdentifier  string
+	OperationIdentifier string
+	Count               int64
+}
+
+// QuotaCounter defines the interface for the shared quota counters
+type QuotaCounter interface {
+	// Consume atomically counts one invocation against all limits, nothing is counted if any limit is exhausted
+	Consume(ctx context.Context, limits []QuotaLimit, usage UsageRecord, now time.Time) ([]QuotaUsage, bool, error)
+
+	// Release takes back one invocation counted by Consume from the limits and the daily usage
+	Release(ctx context.Context, limits []QuotaLimit, usage UsageRecord, now time.Time) error
+
+	// Peek returns the current usage of the limits without counting an invocation
+	Peek(ctx context.Context, limits []QuotaLimit, now time.Time) ([]QuotaUsage, error)
+
+	// DailyUsage returns the usage records of all users counted on the given day
+	DailyUsage(ctx context.Context, day time.Time) ([]*UsageRecord, error)
+
+	// UserDailyUsage returns the usage records of one user counted on the given day
+	UserDailyUsage(ctx context.Context, userID string, day time.Time) ([]*UsageRecord, error)
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 )
 
 // InvocationRepository defines the interface for invocation persistence
@@ -12,6 +13,10 @@ type InvocationRepository interface {
 	// Update updates an invocation
 	Update(ctx context.Context, invocation *Invocation) error
 
+	// UpdateIfStatus updates an invocation only if its stored status is still the given one,
+	// returning false if another request changed it first
+	UpdateIfStatus(ctx context.Context, invocation *Invocation, status InvocationStatus) (bool, error)
+
 	// GetByID returns an invocation by ID
 	GetByID(ctx context.Context, id string) (*Invocation, error)
 
@@ -26,4 +31,101 @@ type InvocationRepository interface {
 
 	// CountByOperationIdentifier returns the count of invocations by operation identifier
 	CountByOperationIdentifier(ctx context.Context, providerIdentifier, operationIdentifier string) (int64, error)
+
+	// ListByUserIDAndStatus returns invocations by user ID in the given status
+	ListByUserIDAndStatus(ctx context.Context, userID string, status InvocationStatus, limit, offset int) ([]*Invocation, error)
+
+	// CountByUserIDAndStatus returns the count of invocations by user ID in the given status
+	CountByUserIDAndStatus(ctx context.Context, userID string, status InvocationStatus) (int64, error)
+
+	// ListAwaitingApprovalExpiredBefore returns invocations whose approval window ended before the given time
+	ListAwaitingApprovalExpiredBefore(ctx context.Context, before time.Time, limit int) ([]*Invocation, error)
+}
+
+// ApprovalPolicyRepository defines the interface for approval policy persistence
+type ApprovalPolicyRepository interface {
+	// GetByUserID returns the approval policy of a user, nil if the user has none
+	GetByUserID(ctx context.Context, userID string) (*ApprovalPolicy, error)
+
+	// Save creates or updates an approval policy
+	Save(ctx context.Context, policy *ApprovalPolicy) error
+}
+
+// UsageRepository defines the interface for daily usage persistence
+type UsageRepository interface {
+	// Upsert stores the given daily usage records, replacing the counts of existing ones
+	Upsert(ctx context.Context, records []*UsageRecord) error
+
+	// ListByUserID returns the daily usage records of a user between two days, inclusive
+	ListByUserID(ctx context.Context, userID string, from, to time.Time) ([]*UsageRecord, error)
+}
+
+// WebhookSubscriptionRepository defines the interface for webhook subscription persistence
+type WebhookSubscriptionRepository interface {
+	// GetByID returns a webhook subscription by ID, nil if it does not exist
+	GetByID(ctx context.Context, id string) (*WebhookSubscription, error)
+
+	// ListByUserID returns the webhook subscriptions of a user
+	ListByUserID(ctx context.Context, userID string) ([]*WebhookSubscription, error)
+
+	// Create creates a new webhook subscription
+	Create(ctx context.Context, subscription *WebhookSubscription) error
+
+	// Update updates the description and signing secret of a webhook subscription
+	Update(ctx context.Context, subscription *WebhookSubscription) error
+
+	// UpdateLastDeliveryAt records the time of the last delivery received by a subscription
+	UpdateLastDeliveryAt(ctx context.Context, id string, at time.Time) error
+
+	// Delete soft-deletes a webhook subscription
+	Delete(ctx context.Context, id string) error
+}
+
+// WebhookEventRepository defines the interface for received webhook event persistence
+type WebhookEventRepository interface {
+	// Create stores an event, returning false if the delivery was already stored for the subscription
+	Create(ctx context.Context, event *WebhookEvent) (bool, error)
+
+	// List returns the events matching the filter
+	List(ctx context.Context, filter WebhookEventFilter) ([]*WebhookEvent, error)
+
+	// DeleteReceivedBefore deletes the events received before the given time, returning the number deleted
+	DeleteReceivedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ScheduleRepository defines the interface for schedule persistence
+type ScheduleRepository interface {
+	// GetByID returns a schedule by ID, nil if it does not exist
+	GetByID(ctx context.Context, id string) (*Schedule, error)
+
+	// ListByUserID returns the schedules of a user
+	ListByUserID(ctx context.Context, userID string) ([]*Schedule, error)
+
+	// ListDue returns active schedules whose next run time is at or before the given time, earliest first
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*Schedule, error)
+
+	// Create creates a new schedule
+	Create(ctx context.Context, schedule *Schedule) error
+
+	// Update updates the definition, status and next run time of a schedule
+	Update(ctx context.Context, schedule *Schedule) error
+
+	// ClaimDue moves the next run time of a schedule from dueAt to the one of the schedule,
+	// returning false if another run claimed it or the schedule changed since it was listed
+	ClaimDue(ctx context.Context, schedule *Schedule, dueAt time.Time) (bool, error)
+
+	// UpdateRunState records the outcome of the last run of a schedule, and its status when the run paused it
+	UpdateRunState(ctx context.Context, schedule *Schedule) error
+
+	// Delete soft-deletes a schedule
+	Delete(ctx context.Context, id string) error
+}
+
+// ScheduleRunRepository defines the interface for schedule run persistence
+type ScheduleRunRepository interface {
+	// Create stores a run
+	Create(ctx context.Context, run *ScheduleRun) error
+
+	// ListByScheduleID returns the runs of a schedule, newest first
+	ListByScheduleID(ctx context.Context, scheduleID string, limit, offset int) ([]*ScheduleRun, error)
 }
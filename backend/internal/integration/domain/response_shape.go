@@ -0,0 +1,425 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bytedance/sonic"
+)
+
+// bytesPerToken approximates the number of bytes of JSON text per model token
+const bytesPerToken = 4
+
+// Budget trimming never shortens arrays or strings below these lengths before falling back to cutting the text
+const (
+	minBudgetArrayItems    = 1
+	minBudgetStringLength  = 64
+	maxBudgetTrimmingRound = 16
+)
+
+// ElisionKind describes what was removed from a response
+type ElisionKind string
+
+const (
+	// ElisionKindArrayItems means trailing items of an array were dropped
+	ElisionKindArrayItems ElisionKind = "array_items"
+	// ElisionKindStringChars means the end of a string was cut
+	ElisionKindStringChars ElisionKind = "string_chars"
+	// ElisionKindBudget means the response still exceeded its budget and was returned as cut JSON text
+	ElisionKindBudget ElisionKind = "budget"
+)
+
+// ResponseShape controls how the response of an invocation is reduced before it is returned
+type ResponseShape struct {
+	// Fields are the paths to keep, such as "items[*].name" or "$.user.login", empty to keep everything
+	Fields []string `json:"fields,omitempty"`
+	// Compact uses the default projection of the operation when Fields is empty
+	Compact bool `json:"compact,omitempty"`
+	// MaxArrayItems keeps the first items of every array, 0 for no limit
+	MaxArrayItems int `json:"max_array_items,omitempty"`
+	// MaxStringLength keeps the first characters of every string, 0 for no limit
+	MaxStringLength int `json:"max_string_length,omitempty"`
+	// MaxBytes bounds the size of the shaped JSON, 0 for no limit
+	MaxBytes int `json:"max_bytes,omitempty"`
+	// MaxTokens bounds the estimated number of tokens of the shaped JSON, 0 for no limit
+	MaxTokens int `json:"max_tokens,omitempty"`
+}
+
+// ResponseElision records what was removed at a path of a shaped response
+type ResponseElision struct {
+	// Path uses [*] for the items of arrays, so one elision covers every item
+	Path string      `json:"path"`
+	Kind ElisionKind `json:"kind"`
+	// Omitted is the number of array items, characters or bytes removed
+	Omitted int `json:"omitted"`
+}
+
+// shapePathSegment is one step of a projection path, a key, an array index or a wildcard
+type shapePathSegment struct {
+	key string
+	// index is the array index plus one, 0 for key and wildcard segments
+	index    int
+	wildcard bool
+}
+
+// shapeSelection is the tree of the projection paths, merged on their common prefixes
+type shapeSelection struct {
+	leaf     bool
+	children map[shapePathSegment]*shapeSelection
+	order    []shapePathSegment
+}
+
+// shapeAPI keeps large identifiers intact when responses are decoded into generic values
+var shapeAPI = sonic.Config{UseNumber: true}.Froze()
+
+// Validate checks the projection paths and limits of the shape
+func (s *ResponseShape) Validate() error {
+	if s.MaxArrayItems < 0 || s.MaxStringLength < 0 || s.MaxBytes < 0 || s.MaxTokens < 0 {
+		return fmt.Errorf("%w: limits must not be negative", ErrInvalidResponseShape)
+	}
+	for _, field := range s.Fields {
+		if _, err := parseShapePath(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply projects, slices and truncates a JSON response, then trims it until it fits the budget of the shape
+func (s *ResponseShape) Apply(data json.RawMessage) (json.RawMessage, []ResponseElision, error) {
+	if len(data) == 0 {
+		return data, nil, nil
+	}
+
+	var value interface{}
+	if err := shapeAPI.Unmarshal(data, &value); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if len(s.Fields) > 0 {
+		selection := &shapeSelection{}
+		for _, field := range s.Fields {
+			segments, err := parseShapePath(field)
+			if err != nil {
+				return nil, nil, err
+			}
+			selection.add(segments)
+		}
+		value, _ = selection.project(value)
+	}
+
+	arrayLimit, stringLimit := s.MaxArrayItems, s.MaxStringLength
+	budget := s.budget()
+	for round := 0; ; round++ {
+		elisions := newElisionSet()
+		trimmed := trimValue(value, "$", arrayLimit, stringLimit, elisions)
+		shaped, err := shapeAPI.Marshal(trimmed)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal shaped response: %w", err)
+		}
+		if budget == 0 || len(shaped) <= budget {
+			return shaped, elisions.list(), nil
+		}
+
+		// Halve the longest arrays and strings until the response fits or nothing more can be trimmed
+		nextArrayLimit, nextStringLimit := halveLimit(arrayLimit, longestArray(value), minBudgetArrayItems),
+			halveLimit(stringLimit, longestString(value), minBudgetStringLength)
+		if round >= maxBudgetTrimmingRound || (nextArrayLimit == arrayLimit && nextStringLimit == stringLimit) {
+			return cutToBudget(shaped, budget, elisions)
+		}
+		arrayLimit, stringLimit = nextArrayLimit, nextStringLimit
+	}
+}
+
+// budget returns the byte size the shaped response must fit in, 0 for no limit
+func (s *ResponseShape) budget() int {
+	budget := s.MaxBytes
+	if s.MaxTokens > 0 && (budget == 0 || s.MaxTokens*bytesPerToken < budget) {
+		budget = s.MaxTokens * bytesPerToken
+	}
+	return budget
+}
+
+// parseShapePath parses a projection path such as "$.items[*].name", "items[0]" or "items[].name"
+func parseShapePath(path string) ([]shapePathSegment, error) {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(path), "$"), ".")
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: empty field path %q", ErrInvalidResponseShape, path)
+	}
+
+	var segments []shapePathSegment
+	for _, part := range strings.Split(trimmed, ".") {
+		key := part
+		brackets := ""
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			key, brackets = part[:i], part[i:]
+		}
+		if key == "*" {
+			segments = append(segments, shapePathSegment{wildcard: true})
+		} else if key != "" {
+			segments = append(segments, shapePathSegment{key: key})
+		} else if brackets == "" {
+			return nil, fmt.Errorf("%w: empty segment in field path %q", ErrInvalidResponseShape, path)
+		}
+
+		for brackets != "" {
+			end := strings.IndexByte(brackets, ']')
+			if brackets[0] != '[' || end < 0 {
+				return nil, fmt.Errorf("%w: unbalanced brackets in field path %q", ErrInvalidResponseShape, path)
+			}
+			selector := brackets[1:end]
+			brackets = brackets[end+1:]
+			if selector == "" || selector == "*" {
+				segments = append(segments, shapePathSegment{wildcard: true})
+				continue
+			}
+			index, err := strconv.Atoi(selector)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("%w: invalid index %q in field path %q", ErrInvalidResponseShape, selector, path)
+			}
+			segments = append(segments, shapePathSegment{index: index + 1})
+		}
+	}
+	return segments, nil
+}
+
+// add merges a projection path into the selection
+func (n *shapeSelection) add(segments []shapePathSegment) {
+	node := n
+	for _, segment := range segments {
+		if node.leaf {
+			return
+		}
+		if node.children == nil {
+			node.children = make(map[shapePathSegment]*shapeSelection)
+		}
+		child, ok := node.children[segment]
+		if !ok {
+			child = &shapeSelection{}
+			node.children[segment] = child
+			node.order = append(node.order, segment)
+		}
+		node = child
+	}
+	node.leaf = true
+	node.children = nil
+	node.order = nil
+}
+
+// project keeps the selected parts of a value, keys applied to an array select them in every item
+func (n *shapeSelection) project(value interface{}) (interface{}, bool) {
+	if n.leaf {
+		return value, true
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		projected := n.projectObject(v, false)
+		return projected, len(projected) > 0
+	case []interface{}:
+		projected := make([]interface{}, 0, len(v))
+		for i, item := range v {
+			var merged interface{}
+			selected := false
+			for _, segment := range n.order {
+				if !segment.wildcard && segment.index != i+1 {
+					continue
+				}
+				if itemValue, ok := n.children[segment].project(item); ok {
+					merged = mergeProjections(merged, itemValue)
+					selected = true
+				}
+			}
+			if object, ok := item.(map[string]interface{}); ok {
+				if keys := n.projectObject(object, true); len(keys) > 0 {
+					merged = mergeProjections(merged, keys)
+					selected = true
+				}
+			}
+			if selected {
+				projected = append(projected, merged)
+			}
+		}
+		return projected, len(projected) > 0
+	default:
+		return nil, false
+	}
+}
+
+// projectObject keeps the selected keys of an object, wildcards select every key unless keysOnly is set
+func (n *shapeSelection) projectObject(object map[string]interface{}, keysOnly bool) map[string]interface{} {
+	projected := make(map[string]interface{})
+	for _, segment := range n.order {
+		child := n.children[segment]
+		switch {
+		case segment.wildcard && !keysOnly:
+			for key, item := range object {
+				if selected, ok := child.project(item); ok {
+					projected[key] = mergeProjections(projected[key], selected)
+				}
+			}
+		case segment.key != "":
+			if item, exists := object[segment.key]; exists {
+				if selected, ok := child.project(item); ok {
+					projected[segment.key] = mergeProjections(projected[segment.key], selected)
+				}
+			}
+		}
+	}
+	return projected
+}
+
+// mergeProjections combines two projections of the same value, objects are merged key by key
+func mergeProjections(existing, selected interface{}) interface{} {
+	existingObject, ok := existing.(map[string]interface{})
+	if !ok {
+		return selected
+	}
+	selectedObject, ok := selected.(map[string]interface{})
+	if !ok {
+		return selected
+	}
+	for key, value := range selectedObject {
+		existingObject[key] = mergeProjections(existingObject[key], value)
+	}
+	return existingObject
+}
+
+// elisionSet aggregates the elisions of a response by path and kind
+type elisionSet struct {
+	byKey map[string]*ResponseElision
+	order []string
+}
+
+func newElisionSet() *elisionSet {
+	return &elisionSet{byKey: make(map[string]*ResponseElision)}
+}
+
+// record adds omitted units at a path
+func (e *elisionSet) record(path string, kind ElisionKind, omitted int) {
+	key := string(kind) + " " + path
+	if elision, ok := e.byKey[key]; ok {
+		elision.Omitted += omitted
+		return
+	}
+	e.byKey[key] = &ResponseElision{Path: path, Kind: kind, Omitted: omitted}
+	e.order = append(e.order, key)
+}
+
+// list returns the elisions in the order they were first recorded, nil if nothing was removed
+func (e *elisionSet) list() []ResponseElision {
+	if len(e.order) == 0 {
+		return nil
+	}
+	elisions := make([]ResponseElision, 0, len(e.order))
+	for _, key := range e.order {
+		elisions = append(elisions, *e.byKey[key])
+	}
+	return elisions
+}
+
+// trimValue slices arrays and truncates strings beyond the limits, recording what was removed
+func trimValue(value interface{}, path string, arrayLimit, stringLimit int, elisions *elisionSet) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		trimmed := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			trimmed[key] = trimValue(item, path+"."+key, arrayLimit, stringLimit, elisions)
+		}
+		return trimmed
+	case []interface{}:
+		items := v
+		if arrayLimit > 0 && len(items) > arrayLimit {
+			elisions.record(path, ElisionKindArrayItems, len(items)-arrayLimit)
+			items = items[:arrayLimit]
+		}
+		trimmed := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			trimmed = append(trimmed, trimValue(item, path+"[*]", arrayLimit, stringLimit, elisions))
+		}
+		return trimmed
+	case string:
+		length := utf8.RuneCountInString(v)
+		if stringLimit <= 0 || length <= stringLimit {
+			return v
+		}
+		elisions.record(path, ElisionKindStringChars, length-stringLimit)
+		runes := []rune(v)
+		return string(runes[:stringLimit]) + fmt.Sprintf("… [%d more characters]", length-stringLimit)
+	default:
+		return v
+	}
+}
+
+// halveLimit returns the next limit to try, starting from the longest length found when no limit is set
+func halveLimit(limit, longest, minimum int) int {
+	if limit <= 0 || limit > longest {
+		limit = longest
+	}
+	if limit <= minimum {
+		return limit
+	}
+	next := limit / 2
+	if next < minimum {
+		next = minimum
+	}
+	return next
+}
+
+// longestArray returns the length of the longest array in a value
+func longestArray(value interface{}) int {
+	longest := 0
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, item := range v {
+			longest = max(longest, longestArray(item))
+		}
+	case []interface{}:
+		longest = len(v)
+		for _, item := range v {
+			longest = max(longest, longestArray(item))
+		}
+	}
+	return longest
+}
+
+// longestString returns the number of characters of the longest string in a value
+func longestString(value interface{}) int {
+	longest := 0
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, item := range v {
+			longest = max(longest, longestString(item))
+		}
+	case []interface{}:
+		for _, item := range v {
+			longest = max(longest, longestString(item))
+		}
+	case string:
+		longest = utf8.RuneCountInString(v)
+	}
+	return longest
+}
+
+// cutToBudget returns the start of the JSON text as a string when trimming could not make the response fit
+func cutToBudget(shaped []byte, budget int, elisions *elisionSet) (json.RawMessage, []ResponseElision, error) {
+	cut := min(budget, len(shaped))
+	for {
+		for cut > 0 && cut < len(shaped) && !utf8.RuneStart(shaped[cut]) {
+			cut--
+		}
+		text, err := shapeAPI.Marshal(string(shaped[:cut]))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal cut response: %w", err)
+		}
+		// Quoting and escaping make the text longer than the cut, shorten it by the excess until it fits
+		if len(text) <= budget || cut == 0 {
+			elisions.record("$", ElisionKindBudget, len(shaped)-cut)
+			return text, elisions.list(), nil
+		}
+		cut -= min(cut, len(text)-budget)
+	}
+}
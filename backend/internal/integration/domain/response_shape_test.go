@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestResponseShapeApply(t *testing.T) {
+	items := `{"total":3,"items":[` +
+		`{"id":1,"name":"a","owner":{"login":"x","id":9}},` +
+		`{"id":2,"name":"b","owner":{"login":"y","id":8}},` +
+		`{"id":3,"name":"c"}]}`
+	numbers := make([]string, 100)
+	for i := range numbers {
+		numbers[i] = fmt.Sprint(i)
+	}
+
+	tests := []struct {
+		name             string
+		shape            ResponseShape
+		data             string
+		expected         string
+		expectedElisions []ResponseElision
+	}{
+		{
+			name:     "ProjectionMergesWildcardIndexAndRootPaths",
+			shape:    ResponseShape{Fields: []string{"items[*].name", "$.items[0].owner.login", "total"}},
+			data:     items,
+			expected: `{"total":3,"items":[{"name":"a","owner":{"login":"x"}},{"name":"b"},{"name":"c"}]}`,
+		},
+		{
+			name:     "ProjectionKeyFansOutOverArrayItems",
+			shape:    ResponseShape{Fields: []string{"items.id"}},
+			data:     items,
+			expected: `{"items":[{"id":1},{"id":2},{"id":3}]}`,
+		},
+		{
+			name:     "ProjectionEmptyArraySelection",
+			shape:    ResponseShape{Fields: []string{"items[][]"}},
+			data:     `{"items":[[1,2],[3]]}`,
+			expected: `{"items":[[1,2],[3]]}`,
+		},
+		{
+			name:     "UnprojectablePathsKeepNothing",
+			shape:    ResponseShape{Fields: []string{"missing.deep", "total.sub", "items[5]"}},
+			data:     items,
+			expected: `{}`,
+		},
+		{
+			name:     "StringTruncationCountsCharacters",
+			shape:    ResponseShape{MaxStringLength: 3},
+			data:     `{"text":"héllo wörld"}`,
+			expected: `{"text":"hél… [8 more characters]"}`,
+			expectedElisions: []ResponseElision{
+				{Path: "$.text", Kind: ElisionKindStringChars, Omitted: 8},
+			},
+		},
+		{
+			name:     "ArraySlicingAggregatesElisionsOfItems",
+			shape:    ResponseShape{MaxArrayItems: 1},
+			data:     `{"rows":[{"tags":["a","b"]},{"tags":["c"]}]}`,
+			expected: `{"rows":[{"tags":["a"]}]}`,
+			expectedElisions: []ResponseElision{
+				{Path: "$.rows", Kind: ElisionKindArrayItems, Omitted: 1},
+				{Path: "$.rows[*].tags", Kind: ElisionKindArrayItems, Omitted: 1},
+			},
+		},
+		{
+			name:     "BudgetReachedByHalvingArrays",
+			shape:    ResponseShape{MaxBytes: 40},
+			data:     `{"values":[` + strings.Join(numbers, ",") + `]}`,
+			expected: `{"values":[0,1,2,3,4,5,6,7,8,9,10,11]}`,
+			expectedElisions: []ResponseElision{
+				{Path: "$.values", Kind: ElisionKindArrayItems, Omitted: 88},
+			},
+		},
+		{
+			name:     "LargeIdentifiersKeepTheirDigits",
+			shape:    ResponseShape{Fields: []string{"id"}},
+			data:     `{"id":12345678901234567890,"name":"x"}`,
+			expected: `{"id":12345678901234567890}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shaped, elisions, err := tt.shape.Apply(json.RawMessage(tt.data))
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			assertJSONEqual(t, tt.expected, string(shaped))
+			if !reflect.DeepEqual(elisions, tt.expectedElisions) {
+				t.Errorf("elisions = %+v, want %+v", elisions, tt.expectedElisions)
+			}
+		})
+	}
+}
+
+func TestResponseShapeApplyCutsToBudget(t *testing.T) {
+	quotedKeys := make([]string, 20)
+	for i := range quotedKeys {
+		quotedKeys[i] = fmt.Sprintf(`"k%02d":"\"q\""`, i)
+	}
+
+	tests := []struct {
+		name  string
+		shape ResponseShape
+		data  string
+	}{
+		{
+			// Objects are never trimmed, and escaping the quotes grows the cut text past the budget
+			name:  "EscapingGrowth",
+			shape: ResponseShape{MaxTokens: 10},
+			data:  `{` + strings.Join(quotedKeys, ",") + `}`,
+		},
+		{
+			// Strings are not shortened below the minimum, the cut must not split a multibyte character
+			name:  "MultibyteText",
+			shape: ResponseShape{MaxBytes: 62},
+			data:  `{"text":"` + strings.Repeat("é", minBudgetStringLength) + `"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shaped, elisions, err := tt.shape.Apply(json.RawMessage(tt.data))
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+
+			budget := tt.shape.budget()
+			if len(shaped) > budget {
+				t.Errorf("len(shaped) = %d, want at most %d", len(shaped), budget)
+			}
+			var text string
+			if err := json.Unmarshal(shaped, &text); err != nil {
+				t.Fatalf("shaped response is not a JSON string: %s", shaped)
+			}
+			if !utf8.ValidString(text) || !strings.HasPrefix(text, `{"`) {
+				t.Errorf("cut text = %q, want the valid start of the JSON response", text)
+			}
+			if !strings.Contains(string(shaped), `\"`) {
+				t.Errorf("shaped = %s, want the escaped quotes of the cut text", shaped)
+			}
+			if len(elisions) != 1 || elisions[0].Kind != ElisionKindBudget || elisions[0].Path != "$" || elisions[0].Omitted <= 0 {
+				t.Errorf("elisions = %+v, want a single budget elision", elisions)
+			}
+		})
+	}
+}
+
+func assertJSONEqual(t *testing.T, expected, actual string) {
+	t.Helper()
+
+	decode := func(data string) interface{} {
+		decoder := json.NewDecoder(strings.NewReader(data))
+		decoder.UseNumber()
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			t.Fatalf("invalid JSON %s: %v", data, err)
+		}
+		return value
+	}
+	expectedValue, actualValue := decode(expected), decode(actual)
+	if !reflect.DeepEqual(expectedValue, actualValue) {
+		t.Errorf("JSON = %s, want %s", actual, expected)
+	}
+}
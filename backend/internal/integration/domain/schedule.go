@@ -0,0 +1,250 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+)
+
+// MinScheduleInterval is the shortest time allowed between two runs of a schedule
+const MinScheduleInterval = 5 * time.Minute
+
+// maxScheduleNameLength bounds the name of a schedule
+const maxScheduleNameLength = 100
+
+// ScheduleStatus represents whether a schedule runs
+type ScheduleStatus string
+
+const (
+	// ScheduleStatusActive schedules run at their next run time
+	ScheduleStatusActive ScheduleStatus = "active"
+	// ScheduleStatusPaused schedules do not run until they are resumed
+	ScheduleStatusPaused ScheduleStatus = "paused"
+)
+
+// ScheduleRunStatus represents the outcome of a run of a schedule
+type ScheduleRunStatus string
+
+const (
+	// ScheduleRunStatusSucceeded means the invocation succeeded
+	ScheduleRunStatusSucceeded ScheduleRunStatus = "succeeded"
+	// ScheduleRunStatusFailed means the invocation failed or could not be made
+	ScheduleRunStatusFailed ScheduleRunStatus = "failed"
+	// ScheduleRunStatusAwaitingApproval means the approval policy of the user gated the invocation
+	ScheduleRunStatusAwaitingApproval ScheduleRunStatus = "awaiting_approval"
+)
+
+// scheduleParser parses standard 5-field cron expressions and descriptors such as @daily
+var scheduleParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// Schedule runs a provider operation with fixed parameters under the credentials of its user
+type Schedule struct {
+	ID     string
+	UserID string
+	// OrganizationID is the organization the schedule was created for, whose shared credential its runs use
+	OrganizationID string
+	// APIKeyID is the API key the schedule was created with, its runs count against the quotas of the key
+	APIKeyID string
+	// ServiceAccount is set for schedules of service accounts, whose quotas are counted separately
+	ServiceAccount      bool
+	Name                string
+	ProviderIdentifier  string
+	OperationIdentifier string
+	Parameters          map[string]interface{}
+	// CronExpression is a standard 5-field cron expression evaluated in Timezone
+	CronExpression string
+	Timezone       string
+	Status         ScheduleStatus
+	// NextRunAt is nil while the schedule is paused
+	NextRunAt     *time.Time
+	LastRunAt     *time.Time
+	LastRunStatus ScheduleRunStatus
+	// ConsecutiveFailures counts the failed runs since the last successful one
+	ConsecutiveFailures int
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	DeletedAt           *time.Time
+}
+
+// NewSchedule creates an active schedule, validating its timing
+func NewSchedule(
+	userID, name, providerIdentifier, operationIdentifier string,
+	parameters map[string]interface{},
+	cronExpression, timezone string,
+	now time.Time,
+) (*Schedule, error) {
+	if err := validateScheduleName(name); err != nil {
+		return nil, err
+	}
+
+	schedule := &Schedule{
+		ID:                  uuid.New().String(),
+		UserID:              userID,
+		Name:                name,
+		ProviderIdentifier:  providerIdentifier,
+		OperationIdentifier: operationIdentifier,
+		Parameters:          parameters,
+		Status:              ScheduleStatusActive,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+	if err := schedule.SetTiming(cronExpression, timezone, now); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// Rename changes the name of the schedule
+func (s *Schedule) Rename(name string, now time.Time) error {
+	if err := validateScheduleName(name); err != nil {
+		return err
+	}
+	s.Name = name
+	s.UpdatedAt = now
+	return nil
+}
+
+// SetTiming changes the cron expression and timezone of the schedule, an empty timezone is UTC
+func (s *Schedule) SetTiming(cronExpression, timezone string, now time.Time) error {
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, _, err := parseSchedule(cronExpression, timezone, now); err != nil {
+		return err
+	}
+
+	s.CronExpression = cronExpression
+	s.Timezone = timezone
+	s.UpdatedAt = now
+	if s.Status == ScheduleStatusActive {
+		return s.Advance(now)
+	}
+	return nil
+}
+
+// Advance sets the next run time to the first one after now, runs missed while the schedule was not due are skipped
+func (s *Schedule) Advance(now time.Time) error {
+	schedule, location, err := parseSchedule(s.CronExpression, s.Timezone, now)
+	if err != nil {
+		return err
+	}
+
+	next := schedule.Next(now.In(location)).UTC()
+	s.NextRunAt = &next
+	return nil
+}
+
+// Pause stops the schedule from running
+func (s *Schedule) Pause(now time.Time) {
+	s.Status = ScheduleStatusPaused
+	s.NextRunAt = nil
+	s.UpdatedAt = now
+}
+
+// Resume restarts a paused schedule from its next run time after now
+func (s *Schedule) Resume(now time.Time) error {
+	s.Status = ScheduleStatusActive
+	s.ConsecutiveFailures = 0
+	s.UpdatedAt = now
+	return s.Advance(now)
+}
+
+// RecordRun records the outcome of a run, pausing the schedule once maxConsecutiveFailures runs failed in a row
+// Returns true if the schedule was paused
+func (s *Schedule) RecordRun(run *ScheduleRun, maxConsecutiveFailures int) bool {
+	s.LastRunAt = &run.StartedAt
+	s.LastRunStatus = run.Status
+
+	if run.Status != ScheduleRunStatusFailed {
+		s.ConsecutiveFailures = 0
+		return false
+	}
+
+	s.ConsecutiveFailures++
+	if maxConsecutiveFailures > 0 && s.ConsecutiveFailures >= maxConsecutiveFailures && s.Status == ScheduleStatusActive {
+		s.Pause(run.CompletedAt)
+		return true
+	}
+	return false
+}
+
+// ScheduleRun records a run of a schedule and links it to the invocation it made
+type ScheduleRun struct {
+	ID         string
+	ScheduleID string
+	UserID     string
+	// InvocationID is empty when the run failed before an invocation was recorded, such as a missing credential
+	InvocationID string
+	Status       ScheduleRunStatus
+	Error        string
+	// ScheduledFor is the run time the schedule was due at
+	ScheduledFor time.Time
+	StartedAt    time.Time
+	CompletedAt  time.Time
+}
+
+// NewScheduleRun creates the run of a schedule from the invocation it made and the error it returned
+func NewScheduleRun(schedule *Schedule, scheduledFor, startedAt time.Time, invocation *Invocation, invokeErr error) *ScheduleRun {
+	run := &ScheduleRun{
+		ID:           uuid.New().String(),
+		ScheduleID:   schedule.ID,
+		UserID:       schedule.UserID,
+		Status:       ScheduleRunStatusSucceeded,
+		ScheduledFor: scheduledFor,
+		StartedAt:    startedAt,
+		CompletedAt:  time.Now(),
+	}
+	if invocation != nil {
+		run.InvocationID = invocation.ID
+		if invocation.IsAwaitingApproval() {
+			run.Status = ScheduleRunStatusAwaitingApproval
+		}
+	}
+	if invokeErr != nil {
+		run.Status = ScheduleRunStatusFailed
+		run.Error = invokeErr.Error()
+	}
+	return run
+}
+
+// parseSchedule parses a cron expression in a timezone and rejects schedules running more often than MinScheduleInterval
+func parseSchedule(cronExpression, timezone string, now time.Time) (cron.Schedule, *time.Location, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidSchedule, timezone)
+	}
+
+	schedule, err := scheduleParser.Parse(cronExpression)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidSchedule, err.Error())
+	}
+
+	// Check the gaps between the next runs, expressions such as "0,1 * * * *" only run too often at times
+	previous := schedule.Next(now.In(location))
+	if previous.IsZero() {
+		return nil, nil, fmt.Errorf("%w: the cron expression never runs", ErrInvalidSchedule)
+	}
+	for i := 0; i < 24; i++ {
+		next := schedule.Next(previous)
+		if next.Sub(previous) < MinScheduleInterval {
+			return nil, nil, fmt.Errorf("%w: runs must be at least %s apart", ErrInvalidSchedule, MinScheduleInterval)
+		}
+		previous = next
+	}
+
+	return schedule, location, nil
+}
+
+// validateScheduleName checks the name of a schedule
+func validateScheduleName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrValidation)
+	}
+	if len(name) > maxScheduleNameLength {
+		return fmt.Errorf("%w: name must be at most %d characters", ErrValidation, maxScheduleNameLength)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookScheme is the signature scheme a provider signs its webhook deliveries with
+type WebhookScheme string
+
+const (
+	// WebhookSchemeGitHub signs the body with HMAC-SHA256 in X-Hub-Signature-256
+	WebhookSchemeGitHub WebhookScheme = "github"
+	// WebhookSchemeSlack signs "v0:timestamp:body" with the app signing secret in X-Slack-Signature
+	WebhookSchemeSlack WebhookScheme = "slack"
+	// WebhookSchemeStripe signs "timestamp.body" with the endpoint secret in Stripe-Signature
+	WebhookSchemeStripe WebhookScheme = "stripe"
+	// WebhookSchemeAirtable signs the body with the base64 MAC secret of the webhook in X-Airtable-Content-MAC
+	WebhookSchemeAirtable WebhookScheme = "airtable"
+	// WebhookSchemeZoom signs "v0:timestamp:body" with the secret token of the app in x-zm-signature
+	WebhookSchemeZoom WebhookScheme = "zoom"
+)
+
+// webhookSchemes maps the providers pushing events to the signature scheme of their deliveries
+var webhookSchemes = map[string]WebhookScheme{
+	"github":   WebhookSchemeGitHub,
+	"slack":    WebhookSchemeSlack,
+	"stripe":   WebhookSchemeStripe,
+	"airtable": WebhookSchemeAirtable,
+	"zoom":     WebhookSchemeZoom,
+}
+
+// WebhookSchemeFor returns the signature scheme of a provider, false if its deliveries cannot be ingested
+func WebhookSchemeFor(providerIdentifier string) (WebhookScheme, bool) {
+	scheme, ok := webhookSchemes[providerIdentifier]
+	return scheme, ok
+}
+
+// SubscriberChoosesSecret reports whether the signing secret is chosen by the subscriber when registering
+// the webhook with the provider, rather than issued by the provider
+func (s WebhookScheme) SubscriberChoosesSecret() bool {
+	return s == WebhookSchemeGitHub
+}
+
+// GenerateSigningSecret returns a random signing secret for schemes where the subscriber chooses it
+func GenerateSigningSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// WebhookSubscription is an endpoint receiving the deliveries of a provider on behalf of a user
+type WebhookSubscription struct {
+	ID                 string
+	UserID             string
+	ProviderIdentifier string
+	Description        string
+	// SigningSecret is the plaintext secret, only set when the subscription is created or its secret is verified
+	SigningSecret string
+	// EncryptedSigningSecret is the encryption metadata of the signing secret, as stored
+	EncryptedSigningSecret string
+	LastDeliveryAt         *time.Time
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+	DeletedAt              *time.Time
+}
+
+// NewWebhookSubscription creates a new webhook subscription of a user
+func NewWebhookSubscription(userID, providerIdentifier, description, signingSecret string) *WebhookSubscription {
+	now := time.Now()
+	return &WebhookSubscription{
+		ID:                 uuid.New().String(),
+		UserID:             userID,
+		ProviderIdentifier: providerIdentifier,
+		Description:        description,
+		SigningSecret:      signingSecret,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+}
+
+// Scheme returns the signature scheme of the deliveries of the subscription
+func (s *WebhookSubscription) Scheme() WebhookScheme {
+	return webhookSchemes[s.ProviderIdentifier]
+}
+
+// WebhookDelivery is a request pushed by a provider to a subscription endpoint
+type WebhookDelivery struct {
+	Header     http.Header
+	Body       []byte
+	ReceivedAt time.Time
+}
+
+// FallbackDeliveryID identifies deliveries of providers that do not send a delivery ID by the hash of their body,
+// so that retries of the same delivery are still de-duplicated
+func (d *WebhookDelivery) FallbackDeliveryID() string {
+	sum := sha256.Sum256(d.Body)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// WebhookVerifier verifies and normalizes the deliveries of a signature scheme
+type WebhookVerifier interface {
+	// Verify checks the signature of a delivery against the signing secret of its subscription
+	Verify(secret string, delivery *WebhookDelivery) error
+
+	// Challenge returns the response to an endpoint validation request, nil if the delivery is an event
+	Challenge(secret string, delivery *WebhookDelivery) (interface{}, error)
+
+	// Normalize returns the delivery ID and event type of a verified delivery,
+	// the delivery ID is empty if the provider does not send one
+	Normalize(delivery *WebhookDelivery) (deliveryID, eventType string)
+}
+
+// WebhookEvent is a verified delivery stored for the owner of the subscription
+type WebhookEvent struct {
+	ID                 string
+	SubscriptionID     string
+	UserID             string
+	ProviderIdentifier string
+	// DeliveryID identifies the delivery at the provider, retries of a delivery share it
+	DeliveryID string
+	EventType  string
+	Payload    json.RawMessage
+	ReceivedAt time.Time
+}
+
+// NewWebhookEvent creates the event of a verified delivery
+func NewWebhookEvent(subscription *WebhookSubscription, delivery *WebhookDelivery, deliveryID, eventType string) *WebhookEvent {
+	if deliveryID == "" {
+		deliveryID = delivery.FallbackDeliveryID()
+	}
+
+	// Form-encoded deliveries, such as Slack interactions, are kept as a JSON string
+	payload := json.RawMessage(delivery.Body)
+	if !json.Valid(delivery.Body) {
+		payload, _ = json.Marshal(string(delivery.Body))
+	}
+
+	return &WebhookEvent{
+		ID:                 uuid.New().String(),
+		SubscriptionID:     subscription.ID,
+		UserID:             subscription.UserID,
+		ProviderIdentifier: subscription.ProviderIdentifier,
+		DeliveryID:         deliveryID,
+		EventType:          eventType,
+		Payload:            payload,
+		ReceivedAt:         delivery.ReceivedAt,
+	}
+}
+
+// WebhookEventCursor is the position of an event in the order events are received
+type WebhookEventCursor struct {
+	ReceivedAt time.Time
+	ID         string
+}
+
+// WebhookEventFilter selects the events of a user
+type WebhookEventFilter struct {
+	UserID             string
+	SubscriptionID     string
+	ProviderIdentifier string
+	EventType          string
+	// Since excludes the events received before it, newest events are returned first
+	Since time.Time
+	// After returns the events received after the cursor, oldest first, to follow new events
+	After *WebhookEventCursor
+	Limit int
+}
@@ -20,6 +20,7 @@ type CredentialACL struct {
 // Ensure CredentialACL implements both interfaces
 var _ domain.CredentialProvider = (*CredentialACL)(nil)
 var _ domain.TokenRefreshProvider = (*CredentialACL)(nil)
+var _ domain.SecretProvider = (*CredentialACL)(nil)
 
 // NewCredentialACL creates a new credential ACL that implements both CredentialProvider and TokenRefreshProvider
 func NewCredentialACL(
@@ -58,6 +59,23 @@ func (acl *CredentialACL) GetCredentialByUserAndProvider(ctx context.Context, us
 	return credential, nil
 }
 
+// GetCredentialByOrganizationAndProvider retrieves an organization credential through the contract layer
+func (acl *CredentialACL) GetCredentialByOrganizationAndProvider(ctx context.Context, organizationID, providerIdentifier string) (interface{}, error) {
+	ctx, span := acl.obs.Tracer.Start(ctx, "CredentialACL.GetCredentialByOrganizationAndProvider")
+	defer span.End()
+
+	credential, err := acl.credentialContract.GetCredentialByOrganizationAndProviderContract(ctx, organizationID, providerIdentifier)
+	if err != nil {
+		acl.obs.Logger.Error(ctx, "Failed to get organization credential through contract",
+			zap.String("organization_id", organizationID),
+			zap.String("provider_identifier", providerIdentifier),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return credential, nil
+}
+
 // CreateNone creates a none credential through the contract layer
 func (acl *CredentialACL) CreateNone(ctx context.Context, userID, providerIdentifier string) (*contractCredential.CredentialDTO, error) {
 	ctx, span := acl.obs.Tracer.Start(ctx, "CredentialACL.CreateNone")
@@ -126,3 +144,53 @@ func (acl *CredentialACL) RefreshAccessToken(ctx context.Context, providerIdenti
 
 	return refreshedCredential, nil
 }
+
+// CreateIncrementalAuthorization starts an incremental OAuth flow through the contract layer
+func (acl *CredentialACL) CreateIncrementalAuthorization(
+	ctx context.Context,
+	userID, organizationID, providerIdentifier string,
+	missingPermissions []string,
+) (*contractCredential.IncrementalAuthorizationDTO, error) {
+	ctx, span := acl.obs.Tracer.Start(ctx, "CredentialACL.CreateIncrementalAuthorization")
+	defer span.End()
+
+	authorization, err := acl.credentialContract.CreateIncrementalAuthorizationContract(ctx, userID, organizationID, providerIdentifier, missingPermissions)
+	if err != nil {
+		acl.obs.Logger.Error(ctx, "Failed to create incremental authorization through contract",
+			zap.String("user_id", userID),
+			zap.String("organization_id", organizationID),
+			zap.String("provider_identifier", providerIdentifier),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return authorization, nil
+}
+
+// EncryptSecret encrypts a secret through the contract layer
+func (acl *CredentialACL) EncryptSecret(ctx context.Context, plaintext string) (string, error) {
+	ctx, span := acl.obs.Tracer.Start(ctx, "CredentialACL.EncryptSecret")
+	defer span.End()
+
+	encryptionMetadata, err := acl.credentialContract.EncryptSecretContract(ctx, plaintext)
+	if err != nil {
+		acl.obs.Logger.Error(ctx, "Failed to encrypt secret through contract", zap.Error(err))
+		return "", err
+	}
+
+	return encryptionMetadata, nil
+}
+
+// DecryptSecret decrypts a secret through the contract layer
+func (acl *CredentialACL) DecryptSecret(ctx context.Context, encryptionMetadata string) (string, error) {
+	ctx, span := acl.obs.Tracer.Start(ctx, "CredentialACL.DecryptSecret")
+	defer span.End()
+
+	plaintext, err := acl.credentialContract.DecryptSecretContract(ctx, encryptionMetadata)
+	if err != nil {
+		acl.obs.Logger.Error(ctx, "Failed to decrypt secret through contract", zap.Error(err))
+		return "", err
+	}
+
+	return plaintext, nil
+}
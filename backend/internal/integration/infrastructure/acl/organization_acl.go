@@ -0,0 +1,43 @@
+package acl
+
+import (
+	"context"
+	"fmt"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	contractIdentity "github.com/context-space/context-space/backend/internal/shared/contract/identityaccess"
+)
+
+// OrganizationACL implements integration/domain.OrganizationProvider over the identity access contract
+type OrganizationACL struct {
+	contractReader contractIdentity.OrganizationReader
+	obs            *observability.ObservabilityProvider
+}
+
+// Ensure OrganizationACL implements the domain interface
+var _ domain.OrganizationProvider = (*OrganizationACL)(nil)
+
+// NewOrganizationACL creates a new organization ACL
+func NewOrganizationACL(
+	contractReader contractIdentity.OrganizationReader,
+	obs *observability.ObservabilityProvider,
+) domain.OrganizationProvider {
+	return &OrganizationACL{
+		contractReader: contractReader,
+		obs:            obs,
+	}
+}
+
+// GetMembership returns the membership of a user in an organization, or nil if the user is not a member
+func (a *OrganizationACL) GetMembership(ctx context.Context, organizationID, userID string) (*contractIdentity.MembershipDTO, error) {
+	ctx, span := a.obs.Tracer.Start(ctx, "OrganizationACL.GetMembership")
+	defer span.End()
+
+	membership, err := a.contractReader.GetMembershipContract(ctx, organizationID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization membership: %w", err)
+	}
+
+	return membership, nil
+}
@@ -0,0 +1,112 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bytedance/sonic"
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"github.com/context-space/context-space/backend/internal/shared/types"
+	"gorm.io/gorm"
+)
+
+// ApprovalPolicyRepository implements the domain.ApprovalPolicyRepository interface using GORM
+type ApprovalPolicyRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewApprovalPolicyRepository creates a new approval policy repository
+func NewApprovalPolicyRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *ApprovalPolicyRepository {
+	return &ApprovalPolicyRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// GetByUserID returns the approval policy of a user, nil if the user has none
+func (r *ApprovalPolicyRepository) GetByUserID(ctx context.Context, userID string) (*domain.ApprovalPolicy, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "ApprovalPolicyRepository.GetByUserID")
+	defer span.End()
+
+	var model ApprovalPolicyModel
+	result := r.db.WithContext(ctx).First(&model, "user_id = ?", userID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.mapToDomain(&model)
+}
+
+// Save creates or updates an approval policy
+func (r *ApprovalPolicyRepository) Save(ctx context.Context, policy *domain.ApprovalPolicy) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "ApprovalPolicyRepository.Save")
+	defer span.End()
+
+	model, err := r.mapToModel(policy)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Save(model).Error
+}
+
+// mapToDomain converts an approval policy model to a domain approval policy
+func (r *ApprovalPolicyRepository) mapToDomain(model *ApprovalPolicyModel) (*domain.ApprovalPolicy, error) {
+	var jsonAttributes struct {
+		AlwaysRequire []string `json:"always_require"`
+		NeverRequire  []string `json:"never_require"`
+	}
+
+	if len(model.JSONAttributes) > 0 {
+		if err := sonic.Unmarshal(model.JSONAttributes, &jsonAttributes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal approval policy JSON attributes: %w", err)
+		}
+	}
+
+	return &domain.ApprovalPolicy{
+		ID:            model.ID,
+		UserID:        model.UserID,
+		Enabled:       model.Enabled,
+		MinRiskLevel:  types.OperationRiskLevel(model.MinRiskLevel),
+		AlwaysRequire: jsonAttributes.AlwaysRequire,
+		NeverRequire:  jsonAttributes.NeverRequire,
+		ApprovalTTL:   time.Duration(model.ApprovalTTLSeconds) * time.Second,
+		CreatedAt:     model.CreatedAt,
+		UpdatedAt:     model.UpdatedAt,
+	}, nil
+}
+
+// mapToModel converts a domain approval policy to an approval policy model
+func (r *ApprovalPolicyRepository) mapToModel(policy *domain.ApprovalPolicy) (*ApprovalPolicyModel, error) {
+	jsonAttributes := struct {
+		AlwaysRequire []string `json:"always_require"`
+		NeverRequire  []string `json:"never_require"`
+	}{
+		AlwaysRequire: policy.AlwaysRequire,
+		NeverRequire:  policy.NeverRequire,
+	}
+
+	jsonAttributesBytes, err := sonic.Marshal(jsonAttributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal approval policy JSON attributes: %w", err)
+	}
+
+	return &ApprovalPolicyModel{
+		ID:                 policy.ID,
+		UserID:             policy.UserID,
+		Enabled:            policy.Enabled,
+		MinRiskLevel:       string(policy.MinRiskLevel),
+		ApprovalTTLSeconds: int64(policy.ApprovalTTL / time.Second),
+		JSONAttributes:     jsonAttributesBytes,
+		CreatedAt:          policy.CreatedAt,
+		UpdatedAt:          policy.UpdatedAt,
+	}, nil
+}
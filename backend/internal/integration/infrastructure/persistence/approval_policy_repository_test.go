@@ -0,0 +1,78 @@
+package persistence
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	observability "github.com/context-space/cloud-observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	shared_mocks "github.com/context-space/context-space/backend/internal/shared/testing/mocks/shared"
+)
+
+// recordedArg matches any argument and keeps the value it was given
+type recordedArg struct {
+	value driver.Value
+}
+
+func (a *recordedArg) Match(v driver.Value) bool {
+	a.value = v
+	return true
+}
+
+func TestApprovalPolicyRepositorySavesDisabledPolicy(t *testing.T) {
+	ctx := context.Background()
+	obs, _, err := observability.InitializeObservabilityProvider(ctx, &observability.LogConfig{
+		Level:       observability.ParseLogLevel("error"),
+		Format:      observability.ParseLogFormat("json"),
+		OutputPaths: []string{"stdout"},
+	}, &observability.TracingConfig{Enabled: false}, &observability.MetricsConfig{Enabled: false})
+	require.NoError(t, err)
+
+	sqlDB, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+
+	db := &shared_mocks.MockDatabase{}
+	db.On("WithContext", mock.Anything).Return(gormDB)
+	repo := NewApprovalPolicyRepository(db, obs)
+
+	policy := domain.NewApprovalPolicy("6f1c2a9e-6a4e-4f4c-9d55-0c6f3f0b7a11")
+	require.False(t, policy.Enabled)
+
+	// Save updates the policy and creates it when no row was updated
+	id, userID, enabled := &recordedArg{}, &recordedArg{}, &recordedArg{}
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(`UPDATE "approval_policies"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectCommit()
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectQuery(`INSERT INTO "approval_policies" \("id","user_id","enabled",`).
+		WithArgs(id, userID, enabled, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(policy.CreatedAt, policy.UpdatedAt))
+	sqlMock.ExpectCommit()
+
+	require.NoError(t, repo.Save(ctx, policy))
+	assert.Equal(t, false, enabled.value, "a disabled policy must be inserted disabled")
+
+	sqlMock.ExpectQuery(`SELECT \* FROM "approval_policies"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "enabled", "min_risk_level", "approval_ttl_seconds"}).
+			AddRow(id.value, userID.value, enabled.value, string(policy.MinRiskLevel), int64(policy.ApprovalTTL.Seconds())))
+
+	saved, err := repo.GetByUserID(ctx, policy.UserID)
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.False(t, saved.Enabled)
+	assert.Equal(t, policy.ID, saved.ID)
+	assert.Equal(t, policy.MinRiskLevel, saved.MinRiskLevel)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/bytedance/sonic"
 	observability "github.com/context-space/cloud-observability"
@@ -55,6 +56,29 @@ func (r *InvocationRepository) Update(ctx context.Context, invocation *domain.In
 	return result.Error
 }
 
+// UpdateIfStatus updates an invocation only if its stored status is still the given one,
+// returning false if another request changed it first
+func (r *InvocationRepository) UpdateIfStatus(ctx context.Context, invocation *domain.Invocation, status domain.InvocationStatus) (bool, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "InvocationRepository.UpdateIfStatus")
+	defer span.End()
+
+	model, err := r.mapToModel(invocation)
+	if err != nil {
+		return false, err
+	}
+
+	// Select("*") writes the zero values of the model too, as Save does
+	result := r.db.WithContext(ctx).Model(&InvocationModel{}).
+		Where("id = ? AND status = ?", invocation.ID, string(status)).
+		Select("*").Omit("id", "created_at").
+		Updates(model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
 // GetByID returns an invocation by ID
 func (r *InvocationRepository) GetByID(ctx context.Context, id string) (*domain.Invocation, error) {
 	ctx, span := r.obs.Tracer.Start(ctx, "InvocationRepository.GetByID")
@@ -127,12 +151,77 @@ func (r *InvocationRepository) CountByOperationIdentifier(ctx context.Context, p
 	return count, result.Error
 }
 
+// ListByUserIDAndStatus returns invocations by user ID in the given status
+func (r *InvocationRepository) ListByUserIDAndStatus(ctx context.Context, userID string, status domain.InvocationStatus, limit, offset int) ([]*domain.Invocation, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "InvocationRepository.ListByUserIDAndStatus")
+	defer span.End()
+
+	var models []InvocationModel
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ?", userID, string(status)).
+		Order("created_at DESC").
+		Offset(offset).Limit(limit).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.mapModelsToDomain(models)
+}
+
+// CountByUserIDAndStatus returns the count of invocations by user ID in the given status
+func (r *InvocationRepository) CountByUserIDAndStatus(ctx context.Context, userID string, status domain.InvocationStatus) (int64, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "InvocationRepository.CountByUserIDAndStatus")
+	defer span.End()
+
+	var count int64
+	result := r.db.WithContext(ctx).Model(&InvocationModel{}).Where("user_id = ? AND status = ?", userID, status).Count(&count)
+	return count, result.Error
+}
+
+// ListAwaitingApprovalExpiredBefore returns invocations whose approval window ended before the given time
+func (r *InvocationRepository) ListAwaitingApprovalExpiredBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Invocation, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "InvocationRepository.ListAwaitingApprovalExpiredBefore")
+	defer span.End()
+
+	var models []InvocationModel
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND approval_expires_at < ?", string(domain.InvocationStatusAwaitingApproval), before).
+		Order("approval_expires_at ASC").
+		Limit(limit).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.mapModelsToDomain(models)
+}
+
+// mapModelsToDomain converts a list of invocation models to domain invocations
+func (r *InvocationRepository) mapModelsToDomain(models []InvocationModel) ([]*domain.Invocation, error) {
+	invocations := make([]*domain.Invocation, 0, len(models))
+	for i := range models {
+		invocation, err := r.mapToDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		invocations = append(invocations, invocation)
+	}
+	return invocations, nil
+}
+
 // mapToDomain converts an invocation model to a domain invocation
 func (r *InvocationRepository) mapToDomain(model *InvocationModel) (*domain.Invocation, error) {
 	var jsonAttributes struct {
 		Parameters   string `json:"parameters"`    // Base64 encoded string
 		ResponseData string `json:"response_data"` // Base64 encoded string
 		ErrorMessage string `json:"error_message"` // Base64 encoded string
+		Approval     string `json:"approval"`      // Base64 encoded string
+		Paginate     string `json:"paginate"`      // Base64 encoded string
+		NextCursor   string `json:"next_cursor"`   // Base64 encoded string
+		Shape        string `json:"shape"`         // Base64 encoded string
+		Elisions     string `json:"elisions"`      // Base64 encoded string
+		APIKeyID     string `json:"api_key_id"`
 	}
 
 	if err := sonic.Unmarshal(model.JSONAttributes, &jsonAttributes); err != nil {
@@ -159,9 +248,60 @@ func (r *InvocationRepository) mapToDomain(model *InvocationModel) (*domain.Invo
 		return nil, fmt.Errorf("failed to decode error message: %w", err)
 	}
 
+	var approval *domain.InvocationApproval
+	if jsonAttributes.Approval != "" {
+		approvalData, err := base64.StdEncoding.DecodeString(jsonAttributes.Approval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode approval: %w", err)
+		}
+		if err := sonic.Unmarshal(approvalData, &approval); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal approval: %w", err)
+		}
+	}
+
+	var paginate *domain.InvocationPaginate
+	if jsonAttributes.Paginate != "" {
+		paginateData, err := base64.StdEncoding.DecodeString(jsonAttributes.Paginate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode paginate: %w", err)
+		}
+		if err := sonic.Unmarshal(paginateData, &paginate); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal paginate: %w", err)
+		}
+	}
+
+	nextCursor, err := base64.StdEncoding.DecodeString(jsonAttributes.NextCursor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode next cursor: %w", err)
+	}
+
+	var shape *domain.ResponseShape
+	if jsonAttributes.Shape != "" {
+		shapeData, err := base64.StdEncoding.DecodeString(jsonAttributes.Shape)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode shape: %w", err)
+		}
+		if err := sonic.Unmarshal(shapeData, &shape); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal shape: %w", err)
+		}
+	}
+
+	var elisions []domain.ResponseElision
+	if jsonAttributes.Elisions != "" {
+		elisionsData, err := base64.StdEncoding.DecodeString(jsonAttributes.Elisions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode elisions: %w", err)
+		}
+		if err := sonic.Unmarshal(elisionsData, &elisions); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal elisions: %w", err)
+		}
+	}
+
 	return &domain.Invocation{
 		ID:                  model.ID,
 		UserID:              model.UserID,
+		OrganizationID:      parseGormOrganizationID(model.OrganizationID),
+		APIKeyID:            jsonAttributes.APIKeyID,
 		ProviderIdentifier:  model.ProviderIdentifier,
 		OperationIdentifier: model.OperationIdentifier,
 		Status:              domain.InvocationStatus(model.Status),
@@ -171,6 +311,11 @@ func (r *InvocationRepository) mapToDomain(model *InvocationModel) (*domain.Invo
 		ErrorMessage:        string(errorMessage),
 		Parameters:          parametersMap,
 		ResponseData:        responseData,
+		Approval:            approval,
+		Paginate:            paginate,
+		NextCursor:          string(nextCursor),
+		Shape:               shape,
+		Elisions:            elisions,
 		CreatedAt:           model.CreatedAt,
 		UpdatedAt:           model.UpdatedAt,
 		DeletedAt:           parseGormDeletedAt(model.DeletedAt),
@@ -189,14 +334,64 @@ func (r *InvocationRepository) mapToModel(invocation *domain.Invocation) (*Invoc
 		return nil, fmt.Errorf("failed to marshal response data: %w", err)
 	}
 
+	var approval string
+	var approvalExpiresAt *time.Time
+	if invocation.Approval != nil {
+		approvalData, err := sonic.Marshal(invocation.Approval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal approval: %w", err)
+		}
+		approval = base64.StdEncoding.EncodeToString(approvalData)
+		approvalExpiresAt = &invocation.Approval.ExpiresAt
+	}
+
+	var paginate string
+	if invocation.Paginate != nil {
+		paginateData, err := sonic.Marshal(invocation.Paginate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal paginate: %w", err)
+		}
+		paginate = base64.StdEncoding.EncodeToString(paginateData)
+	}
+
+	var shape string
+	if invocation.Shape != nil {
+		shapeData, err := sonic.Marshal(invocation.Shape)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal shape: %w", err)
+		}
+		shape = base64.StdEncoding.EncodeToString(shapeData)
+	}
+
+	var elisions string
+	if len(invocation.Elisions) > 0 {
+		elisionsData, err := sonic.Marshal(invocation.Elisions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal elisions: %w", err)
+		}
+		elisions = base64.StdEncoding.EncodeToString(elisionsData)
+	}
+
 	jsonAttributes := struct {
-		Parameters   string `json:"parameters"`    // Base64 encoded string
-		ResponseData string `json:"response_data"` // Base64 encoded string
-		ErrorMessage string `json:"error_message"` // Base64 encoded string
+		Parameters   string `json:"parameters"`            // Base64 encoded string
+		ResponseData string `json:"response_data"`         // Base64 encoded string
+		ErrorMessage string `json:"error_message"`         // Base64 encoded string
+		Approval     string `json:"approval,omitempty"`    // Base64 encoded string
+		Paginate     string `json:"paginate,omitempty"`    // Base64 encoded string
+		NextCursor   string `json:"next_cursor,omitempty"` // Base64 encoded string
+		Shape        string `json:"shape,omitempty"`       // Base64 encoded string
+		Elisions     string `json:"elisions,omitempty"`    // Base64 encoded string
+		APIKeyID     string `json:"api_key_id,omitempty"`
 	}{
 		Parameters:   base64.StdEncoding.EncodeToString(parameters),
 		ResponseData: base64.StdEncoding.EncodeToString(responseData),
 		ErrorMessage: base64.StdEncoding.EncodeToString([]byte(invocation.ErrorMessage)),
+		Approval:     approval,
+		Paginate:     paginate,
+		NextCursor:   base64.StdEncoding.EncodeToString([]byte(invocation.NextCursor)),
+		Shape:        shape,
+		Elisions:     elisions,
+		APIKeyID:     invocation.APIKeyID,
 	}
 
 	jsonAttributesBytes, err := sonic.Marshal(jsonAttributes)
@@ -207,12 +402,14 @@ func (r *InvocationRepository) mapToModel(invocation *domain.Invocation) (*Invoc
 	return &InvocationModel{
 		ID:                  invocation.ID,
 		UserID:              invocation.UserID,
+		OrganizationID:      parseDomainOrganizationID(invocation.OrganizationID),
 		ProviderIdentifier:  invocation.ProviderIdentifier,
 		OperationIdentifier: invocation.OperationIdentifier,
 		Status:              string(invocation.Status),
 		Duration:            invocation.Duration,
 		StartedAt:           invocation.StartedAt,
 		CompletedAt:         invocation.CompletedAt,
+		ApprovalExpiresAt:   approvalExpiresAt,
 		JSONAttributes:      jsonAttributesBytes,
 		CreatedAt:           invocation.CreatedAt,
 		UpdatedAt:           invocation.UpdatedAt,
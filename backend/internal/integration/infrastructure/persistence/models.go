@@ -11,12 +11,14 @@ import (
 type InvocationModel struct {
 	ID                  string          `gorm:"type:uuid;primaryKey"`
 	UserID              string          `gorm:"type:uuid;not null;index"`
+	OrganizationID      *string         `gorm:"type:uuid;index"`
 	ProviderIdentifier  string          `gorm:"type:varchar(50);not null;index"`
 	OperationIdentifier string          `gorm:"type:varchar(50);not null;index"`
 	Status              string          `gorm:"type:varchar(20);not null"`
 	Duration            int64           `gorm:"not null"`
 	StartedAt           *time.Time      `gorm:"type:timestamp with time zone"`
 	CompletedAt         *time.Time      `gorm:"type:timestamp with time zone"`
+	ApprovalExpiresAt   *time.Time      `gorm:"type:timestamp with time zone"`
 	JSONAttributes      json.RawMessage `gorm:"type:jsonb"`
 	CreatedAt           time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
 	UpdatedAt           time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
@@ -27,3 +29,116 @@ type InvocationModel struct {
 func (InvocationModel) TableName() string {
 	return "invocations"
 }
+
+// ApprovalPolicyModel is the GORM model for per-user approval policies
+type ApprovalPolicyModel struct {
+	ID                 string          `gorm:"type:uuid;primaryKey"`
+	UserID             string          `gorm:"type:uuid;not null;uniqueIndex"`
+	Enabled            bool            `gorm:"not null"`
+	MinRiskLevel       string          `gorm:"type:varchar(20);not null"`
+	ApprovalTTLSeconds int64           `gorm:"column:approval_ttl_seconds;not null"`
+	JSONAttributes     json.RawMessage `gorm:"type:jsonb"`
+	CreatedAt          time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
+	UpdatedAt          time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
+	DeletedAt          gorm.DeletedAt  `gorm:"type:timestamp with time zone;index"`
+}
+
+// TableName overrides the table name
+func (ApprovalPolicyModel) TableName() string {
+	return "approval_policies"
+}
+
+// UsageModel is the GORM model for daily invocation usage
+type UsageModel struct {
+	Day                 time.Time `gorm:"type:date;primaryKey"`
+	UserID              string    `gorm:"type:uuid;primaryKey"`
+	APIKeyID            string    `gorm:"column:api_key_id;type:varchar(36);primaryKey"`
+	ProviderIdentifier  string    `gorm:"type:varchar(50);primaryKey"`
+	OperationIdentifier string    `gorm:"type:varchar(50);primaryKey"`
+	Count               int64     `gorm:"not null"`
+	CreatedAt           time.Time `gorm:"type:timestamp with time zone;not null;default:now()"`
+	UpdatedAt           time.Time `gorm:"type:timestamp with time zone;not null;default:now()"`
+}
+
+// TableName overrides the table name
+func (UsageModel) TableName() string {
+	return "invocation_usage"
+}
+
+// WebhookSubscriptionModel is the GORM model for webhook subscriptions
+type WebhookSubscriptionModel struct {
+	ID                 string          `gorm:"type:uuid;primaryKey"`
+	UserID             string          `gorm:"type:uuid;not null;index"`
+	ProviderIdentifier string          `gorm:"type:varchar(50);not null"`
+	Description        string          `gorm:"type:text;not null;default:''"`
+	LastDeliveryAt     *time.Time      `gorm:"type:timestamp with time zone"`
+	JSONAttributes     json.RawMessage `gorm:"type:jsonb;not null"`
+	CreatedAt          time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
+	UpdatedAt          time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
+	DeletedAt          gorm.DeletedAt  `gorm:"type:timestamp with time zone;index"`
+}
+
+// TableName overrides the table name
+func (WebhookSubscriptionModel) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// WebhookEventModel is the GORM model for received webhook events
+type WebhookEventModel struct {
+	ID                 string          `gorm:"type:uuid;primaryKey"`
+	SubscriptionID     string          `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_events_delivery"`
+	UserID             string          `gorm:"type:uuid;not null;index"`
+	ProviderIdentifier string          `gorm:"type:varchar(50);not null"`
+	DeliveryID         string          `gorm:"type:varchar(255);not null;uniqueIndex:idx_webhook_events_delivery"`
+	EventType          string          `gorm:"type:varchar(100);not null"`
+	Payload            json.RawMessage `gorm:"type:jsonb;not null"`
+	ReceivedAt         time.Time       `gorm:"type:timestamp with time zone;not null;index"`
+}
+
+// TableName overrides the table name
+func (WebhookEventModel) TableName() string {
+	return "webhook_events"
+}
+
+// ScheduleModel is the GORM model for user-defined schedules
+type ScheduleModel struct {
+	ID                  string          `gorm:"type:uuid;primaryKey"`
+	UserID              string          `gorm:"type:uuid;not null;index"`
+	Name                string          `gorm:"type:varchar(100);not null"`
+	ProviderIdentifier  string          `gorm:"type:varchar(50);not null"`
+	OperationIdentifier string          `gorm:"type:varchar(50);not null"`
+	CronExpression      string          `gorm:"type:varchar(100);not null"`
+	Timezone            string          `gorm:"type:varchar(64);not null"`
+	Status              string          `gorm:"type:varchar(20);not null"`
+	NextRunAt           *time.Time      `gorm:"type:timestamp with time zone;index"`
+	LastRunAt           *time.Time      `gorm:"type:timestamp with time zone"`
+	LastRunStatus       string          `gorm:"type:varchar(20);not null;default:''"`
+	ConsecutiveFailures int             `gorm:"not null;default:0"`
+	JSONAttributes      json.RawMessage `gorm:"type:jsonb"`
+	CreatedAt           time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
+	UpdatedAt           time.Time       `gorm:"type:timestamp with time zone;not null;default:now()"`
+	DeletedAt           gorm.DeletedAt  `gorm:"type:timestamp with time zone;index"`
+}
+
+// TableName overrides the table name
+func (ScheduleModel) TableName() string {
+	return "schedules"
+}
+
+// ScheduleRunModel is the GORM model for schedule runs
+type ScheduleRunModel struct {
+	ID           string    `gorm:"type:uuid;primaryKey"`
+	ScheduleID   string    `gorm:"type:uuid;not null;index"`
+	UserID       string    `gorm:"type:uuid;not null"`
+	InvocationID *string   `gorm:"type:uuid"`
+	Status       string    `gorm:"type:varchar(20);not null"`
+	Error        string    `gorm:"type:text;not null;default:''"`
+	ScheduledFor time.Time `gorm:"type:timestamp with time zone;not null"`
+	StartedAt    time.Time `gorm:"type:timestamp with time zone;not null"`
+	CompletedAt  time.Time `gorm:"type:timestamp with time zone;not null"`
+}
+
+// TableName overrides the table name
+func (ScheduleRunModel) TableName() string {
+	return "schedule_runs"
+}
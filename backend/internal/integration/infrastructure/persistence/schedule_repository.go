@@ -0,0 +1,253 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bytedance/sonic"
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// ScheduleRepository implements the domain.ScheduleRepository interface using GORM
+type ScheduleRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewScheduleRepository creates a new schedule repository
+func NewScheduleRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *ScheduleRepository {
+	return &ScheduleRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// GetByID returns a schedule by ID, nil if it does not exist
+func (r *ScheduleRepository) GetByID(ctx context.Context, id string) (*domain.Schedule, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "ScheduleRepository.GetByID")
+	defer span.End()
+
+	var model ScheduleModel
+	result := r.db.WithContext(ctx).First(&model, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.mapToDomain(&model)
+}
+
+// ListByUserID returns the schedules of a user
+func (r *ScheduleRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Schedule, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "ScheduleRepository.ListByUserID")
+	defer span.End()
+
+	var models []ScheduleModel
+	result := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.mapToDomainList(models)
+}
+
+// ListDue returns active schedules whose next run time is at or before the given time, earliest first
+func (r *ScheduleRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Schedule, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "ScheduleRepository.ListDue")
+	defer span.End()
+
+	var models []ScheduleModel
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND next_run_at <= ?", string(domain.ScheduleStatusActive), now).
+		Order("next_run_at ASC").
+		Limit(limit).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.mapToDomainList(models)
+}
+
+// Create creates a new schedule
+func (r *ScheduleRepository) Create(ctx context.Context, schedule *domain.Schedule) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "ScheduleRepository.Create")
+	defer span.End()
+
+	model, err := r.mapToModel(schedule)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Create(model).Error
+}
+
+// Update updates the definition, status and next run time of a schedule
+func (r *ScheduleRepository) Update(ctx context.Context, schedule *domain.Schedule) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "ScheduleRepository.Update")
+	defer span.End()
+
+	model, err := r.mapToModel(schedule)
+	if err != nil {
+		return err
+	}
+
+	result := r.db.WithContext(ctx).Model(&ScheduleModel{}).Where("id = ?", schedule.ID).Updates(map[string]interface{}{
+		"name":                 model.Name,
+		"cron_expression":      model.CronExpression,
+		"timezone":             model.Timezone,
+		"status":               model.Status,
+		"next_run_at":          model.NextRunAt,
+		"consecutive_failures": model.ConsecutiveFailures,
+		"json_attributes":      model.JSONAttributes,
+		"updated_at":           model.UpdatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("schedule not found with id: %s", schedule.ID)
+	}
+
+	return nil
+}
+
+// ClaimDue moves the next run time of a schedule from dueAt to the one of the schedule,
+// returning false if another run claimed it or the schedule changed since it was listed
+func (r *ScheduleRepository) ClaimDue(ctx context.Context, schedule *domain.Schedule, dueAt time.Time) (bool, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "ScheduleRepository.ClaimDue")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Model(&ScheduleModel{}).
+		Where("id = ? AND status = ? AND next_run_at = ?", schedule.ID, string(domain.ScheduleStatusActive), dueAt).
+		Update("next_run_at", schedule.NextRunAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateRunState records the outcome of the last run of a schedule, and its status when the run paused it
+func (r *ScheduleRepository) UpdateRunState(ctx context.Context, schedule *domain.Schedule) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "ScheduleRepository.UpdateRunState")
+	defer span.End()
+
+	updates := map[string]interface{}{
+		"last_run_at":          schedule.LastRunAt,
+		"last_run_status":      string(schedule.LastRunStatus),
+		"consecutive_failures": schedule.ConsecutiveFailures,
+	}
+	// Only a pause is written, so that a schedule edited while it ran keeps its new timing
+	if schedule.Status == domain.ScheduleStatusPaused {
+		updates["status"] = string(schedule.Status)
+		updates["next_run_at"] = nil
+		updates["updated_at"] = schedule.UpdatedAt
+	}
+
+	return r.db.WithContext(ctx).Model(&ScheduleModel{}).Where("id = ?", schedule.ID).Updates(updates).Error
+}
+
+// Delete soft-deletes a schedule
+func (r *ScheduleRepository) Delete(ctx context.Context, id string) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "ScheduleRepository.Delete")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&ScheduleModel{}).Error
+}
+
+// scheduleAttributes are the JSON attributes of a schedule
+type scheduleAttributes struct {
+	Parameters     map[string]interface{} `json:"parameters"`
+	OrganizationID string                 `json:"organization_id,omitempty"`
+	APIKeyID       string                 `json:"api_key_id,omitempty"`
+	ServiceAccount bool                   `json:"service_account,omitempty"`
+}
+
+// mapToDomainList converts schedule models to domain schedules
+func (r *ScheduleRepository) mapToDomainList(models []ScheduleModel) ([]*domain.Schedule, error) {
+	schedules := make([]*domain.Schedule, 0, len(models))
+	for i := range models {
+		schedule, err := r.mapToDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, nil
+}
+
+// mapToDomain converts a schedule model to a domain schedule
+func (r *ScheduleRepository) mapToDomain(model *ScheduleModel) (*domain.Schedule, error) {
+	var jsonAttributes scheduleAttributes
+	if len(model.JSONAttributes) > 0 {
+		if err := sonic.Unmarshal(model.JSONAttributes, &jsonAttributes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal schedule JSON attributes: %w", err)
+		}
+	}
+
+	return &domain.Schedule{
+		ID:                  model.ID,
+		UserID:              model.UserID,
+		OrganizationID:      jsonAttributes.OrganizationID,
+		APIKeyID:            jsonAttributes.APIKeyID,
+		ServiceAccount:      jsonAttributes.ServiceAccount,
+		Name:                model.Name,
+		ProviderIdentifier:  model.ProviderIdentifier,
+		OperationIdentifier: model.OperationIdentifier,
+		Parameters:          jsonAttributes.Parameters,
+		CronExpression:      model.CronExpression,
+		Timezone:            model.Timezone,
+		Status:              domain.ScheduleStatus(model.Status),
+		NextRunAt:           model.NextRunAt,
+		LastRunAt:           model.LastRunAt,
+		LastRunStatus:       domain.ScheduleRunStatus(model.LastRunStatus),
+		ConsecutiveFailures: model.ConsecutiveFailures,
+		CreatedAt:           model.CreatedAt,
+		UpdatedAt:           model.UpdatedAt,
+		DeletedAt:           parseGormDeletedAt(model.DeletedAt),
+	}, nil
+}
+
+// mapToModel converts a domain schedule to a schedule model
+func (r *ScheduleRepository) mapToModel(schedule *domain.Schedule) (*ScheduleModel, error) {
+	jsonAttributesBytes, err := sonic.Marshal(scheduleAttributes{
+		Parameters:     schedule.Parameters,
+		OrganizationID: schedule.OrganizationID,
+		APIKeyID:       schedule.APIKeyID,
+		ServiceAccount: schedule.ServiceAccount,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schedule JSON attributes: %w", err)
+	}
+
+	return &ScheduleModel{
+		ID:                  schedule.ID,
+		UserID:              schedule.UserID,
+		Name:                schedule.Name,
+		ProviderIdentifier:  schedule.ProviderIdentifier,
+		OperationIdentifier: schedule.OperationIdentifier,
+		CronExpression:      schedule.CronExpression,
+		Timezone:            schedule.Timezone,
+		Status:              string(schedule.Status),
+		NextRunAt:           schedule.NextRunAt,
+		LastRunAt:           schedule.LastRunAt,
+		LastRunStatus:       string(schedule.LastRunStatus),
+		ConsecutiveFailures: schedule.ConsecutiveFailures,
+		JSONAttributes:      jsonAttributesBytes,
+		CreatedAt:           schedule.CreatedAt,
+		UpdatedAt:           schedule.UpdatedAt,
+		DeletedAt:           parseDomainDeletedAt(schedule.DeletedAt),
+	}, nil
+}
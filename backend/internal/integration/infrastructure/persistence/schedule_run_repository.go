@@ -0,0 +1,95 @@
+package persistence
+
+import (
+	"context"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+)
+
+// ScheduleRunRepository implements the domain.ScheduleRunRepository interface using GORM
+type ScheduleRunRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewScheduleRunRepository creates a new schedule run repository
+func NewScheduleRunRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *ScheduleRunRepository {
+	return &ScheduleRunRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// Create stores a run
+func (r *ScheduleRunRepository) Create(ctx context.Context, run *domain.ScheduleRun) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "ScheduleRunRepository.Create")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Create(r.mapToModel(run)).Error
+}
+
+// ListByScheduleID returns the runs of a schedule, newest first
+func (r *ScheduleRunRepository) ListByScheduleID(ctx context.Context, scheduleID string, limit, offset int) ([]*domain.ScheduleRun, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "ScheduleRunRepository.ListByScheduleID")
+	defer span.End()
+
+	var models []ScheduleRunModel
+	result := r.db.WithContext(ctx).
+		Where("schedule_id = ?", scheduleID).
+		Order("started_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	runs := make([]*domain.ScheduleRun, len(models))
+	for i := range models {
+		runs[i] = r.mapToDomain(&models[i])
+	}
+
+	return runs, nil
+}
+
+// mapToDomain converts a schedule run model to a domain schedule run
+func (r *ScheduleRunRepository) mapToDomain(model *ScheduleRunModel) *domain.ScheduleRun {
+	invocationID := ""
+	if model.InvocationID != nil {
+		invocationID = *model.InvocationID
+	}
+
+	return &domain.ScheduleRun{
+		ID:           model.ID,
+		ScheduleID:   model.ScheduleID,
+		UserID:       model.UserID,
+		InvocationID: invocationID,
+		Status:       domain.ScheduleRunStatus(model.Status),
+		Error:        model.Error,
+		ScheduledFor: model.ScheduledFor,
+		StartedAt:    model.StartedAt,
+		CompletedAt:  model.CompletedAt,
+	}
+}
+
+// mapToModel converts a domain schedule run to a schedule run model
+func (r *ScheduleRunRepository) mapToModel(run *domain.ScheduleRun) *ScheduleRunModel {
+	var invocationID *string
+	if run.InvocationID != "" {
+		invocationID = &run.InvocationID
+	}
+
+	return &ScheduleRunModel{
+		ID:           run.ID,
+		ScheduleID:   run.ScheduleID,
+		UserID:       run.UserID,
+		InvocationID: invocationID,
+		Status:       string(run.Status),
+		Error:        run.Error,
+		ScheduledFor: run.ScheduledFor,
+		StartedAt:    run.StartedAt,
+		CompletedAt:  run.CompletedAt,
+	}
+}
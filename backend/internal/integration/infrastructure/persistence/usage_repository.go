@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"gorm.io/gorm/clause"
+)
+
+// UsageRepository implements the domain.UsageRepository interface using GORM
+type UsageRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewUsageRepository creates a new usage repository
+func NewUsageRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *UsageRepository {
+	return &UsageRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// Upsert stores the given daily usage records, replacing the counts of existing ones
+func (r *UsageRepository) Upsert(ctx context.Context, records []*domain.UsageRecord) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "UsageRepository.Upsert")
+	defer span.End()
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	models := make([]*UsageModel, 0, len(records))
+	for _, record := range records {
+		model := r.mapToModel(record)
+		model.CreatedAt = now
+		model.UpdatedAt = now
+		models = append(models, model)
+	}
+
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "day"},
+			{Name: "user_id"},
+			{Name: "api_key_id"},
+			{Name: "provider_identifier"},
+			{Name: "operation_identifier"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"count", "updated_at"}),
+	}).CreateInBatches(models, 500).Error
+}
+
+// ListByUserID returns the daily usage records of a user between two days, inclusive
+func (r *UsageRepository) ListByUserID(ctx context.Context, userID string, from, to time.Time) ([]*domain.UsageRecord, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "UsageRepository.ListByUserID")
+	defer span.End()
+
+	var models []UsageModel
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND day >= ? AND day <= ?", userID, from.Format(time.DateOnly), to.Format(time.DateOnly)).
+		Order("day ASC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	records := make([]*domain.UsageRecord, 0, len(models))
+	for i := range models {
+		records = append(records, r.mapToDomain(&models[i]))
+	}
+
+	return records, nil
+}
+
+// mapToDomain converts a usage model to a domain usage record
+func (r *UsageRepository) mapToDomain(model *UsageModel) *domain.UsageRecord {
+	return &domain.UsageRecord{
+		Day:                 model.Day.UTC(),
+		UserID:              model.UserID,
+		APIKeyID:            model.APIKeyID,
+		ProviderIdentifier:  model.ProviderIdentifier,
+		OperationIdentifier: model.OperationIdentifier,
+		Count:               model.Count,
+	}
+}
+
+// mapToModel converts a domain usage record to a usage model
+func (r *UsageRepository) mapToModel(record *domain.UsageRecord) *UsageModel {
+	return &UsageModel{
+		Day:                 record.Day.UTC(),
+		UserID:              record.UserID,
+		APIKeyID:            record.APIKeyID,
+		ProviderIdentifier:  record.ProviderIdentifier,
+		OperationIdentifier: record.OperationIdentifier,
+		Count:               record.Count,
+	}
+}
@@ -19,3 +19,17 @@ func parseDomainDeletedAt(deletedAt *time.Time) gorm.DeletedAt {
 	}
 	return gorm.DeletedAt{Time: *deletedAt, Valid: true}
 }
+
+func parseGormOrganizationID(organizationID *string) string {
+	if organizationID == nil {
+		return ""
+	}
+	return *organizationID
+}
+
+func parseDomainOrganizationID(organizationID string) *string {
+	if organizationID == "" {
+		return nil
+	}
+	return &organizationID
+}
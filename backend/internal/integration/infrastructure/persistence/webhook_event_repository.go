@@ -0,0 +1,121 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookEventRepository implements the domain.WebhookEventRepository interface using GORM
+type WebhookEventRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewWebhookEventRepository creates a new webhook event repository
+func NewWebhookEventRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *WebhookEventRepository {
+	return &WebhookEventRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// Create stores an event, returning false if the delivery was already stored for the subscription
+func (r *WebhookEventRepository) Create(ctx context.Context, event *domain.WebhookEvent) (bool, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "WebhookEventRepository.Create")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "delivery_id"}},
+		DoNothing: true,
+	}).Create(r.mapToModel(event))
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// List returns the events matching the filter
+func (r *WebhookEventRepository) List(ctx context.Context, filter domain.WebhookEventFilter) ([]*domain.WebhookEvent, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "WebhookEventRepository.List")
+	defer span.End()
+
+	query := r.db.WithContext(ctx).Where("user_id = ?", filter.UserID)
+	if filter.SubscriptionID != "" {
+		query = query.Where("subscription_id = ?", filter.SubscriptionID)
+	}
+	if filter.ProviderIdentifier != "" {
+		query = query.Where("provider_identifier = ?", filter.ProviderIdentifier)
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("received_at >= ?", filter.Since)
+	}
+
+	if filter.After != nil {
+		if filter.After.ID == "" {
+			query = query.Where("received_at > ?", filter.After.ReceivedAt)
+		} else {
+			query = query.Where("(received_at, id) > (?, ?)", filter.After.ReceivedAt, filter.After.ID)
+		}
+		query = query.Order("received_at ASC, id ASC")
+	} else {
+		query = query.Order("received_at DESC, id DESC")
+	}
+
+	var models []WebhookEventModel
+	if err := query.Limit(filter.Limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	events := make([]*domain.WebhookEvent, len(models))
+	for i := range models {
+		events[i] = r.mapToDomain(&models[i])
+	}
+
+	return events, nil
+}
+
+// DeleteReceivedBefore deletes the events received before the given time, returning the number deleted
+func (r *WebhookEventRepository) DeleteReceivedBefore(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "WebhookEventRepository.DeleteReceivedBefore")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Where("received_at < ?", before).Delete(&WebhookEventModel{})
+	return result.RowsAffected, result.Error
+}
+
+// mapToDomain converts a webhook event model to a domain webhook event
+func (r *WebhookEventRepository) mapToDomain(model *WebhookEventModel) *domain.WebhookEvent {
+	return &domain.WebhookEvent{
+		ID:                 model.ID,
+		SubscriptionID:     model.SubscriptionID,
+		UserID:             model.UserID,
+		ProviderIdentifier: model.ProviderIdentifier,
+		DeliveryID:         model.DeliveryID,
+		EventType:          model.EventType,
+		Payload:            model.Payload,
+		ReceivedAt:         model.ReceivedAt,
+	}
+}
+
+// mapToModel converts a domain webhook event to a webhook event model
+func (r *WebhookEventRepository) mapToModel(event *domain.WebhookEvent) *WebhookEventModel {
+	return &WebhookEventModel{
+		ID:                 event.ID,
+		SubscriptionID:     event.SubscriptionID,
+		UserID:             event.UserID,
+		ProviderIdentifier: event.ProviderIdentifier,
+		DeliveryID:         event.DeliveryID,
+		EventType:          event.EventType,
+		Payload:            event.Payload,
+		ReceivedAt:         event.ReceivedAt,
+	}
+}
@@ -0,0 +1,110 @@
+package persistence
+
+import (
+	"context"
+
+	observability "github.com/context-space/cloud-observability"
+	contractCredential "github.com/context-space/context-space/backend/internal/shared/contract/credentialmanagement"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// WebhookSecretStoreName names the signing secrets of webhook subscriptions among the secret stores of the credential contract
+const WebhookSecretStoreName = "webhook_subscriptions"
+
+// storedSecretRow is a subscription ID with the encryption metadata of its signing secret
+type storedSecretRow struct {
+	RowID              string
+	EncryptionMetadata string
+}
+
+// WebhookSecretStore implements the contractCredential.SecretStore interface for the signing secrets of webhook subscriptions,
+// so that they are rewrapped when the transit key is rotated
+type WebhookSecretStore struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// Ensure implementation of the secret store contract
+var _ contractCredential.SecretStore = (*WebhookSecretStore)(nil)
+
+// NewWebhookSecretStore creates a new webhook secret store
+func NewWebhookSecretStore(db database.Database, observabilityProvider *observability.ObservabilityProvider) *WebhookSecretStore {
+	return &WebhookSecretStore{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// CountSecretsBelowVersion counts the signing secrets encrypted in the region with an older key version
+func (s *WebhookSecretStore) CountSecretsBelowVersion(ctx context.Context, region string, keyVersion int) (int, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "WebhookSecretStore.CountSecretsBelowVersion")
+	defer span.End()
+
+	var count int64
+	if err := s.belowVersionQuery(ctx, region, keyVersion).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// ListSecretsBelowVersion lists the signing secrets encrypted in the region with an older key version, ordered by subscription ID
+func (s *WebhookSecretStore) ListSecretsBelowVersion(ctx context.Context, region string, keyVersion int, afterRowID string, limit int) ([]*contractCredential.StoredSecretDTO, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "WebhookSecretStore.ListSecretsBelowVersion")
+	defer span.End()
+
+	query := s.belowVersionQuery(ctx, region, keyVersion)
+	if afterRowID != "" {
+		query = query.Where("id > ?", afterRowID)
+	}
+
+	var rows []storedSecretRow
+	result := query.
+		Select("id AS row_id, json_attributes->>'encryption_metadata' AS encryption_metadata").
+		Order("id ASC").
+		Limit(limit).
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	secrets := make([]*contractCredential.StoredSecretDTO, 0, len(rows))
+	for _, row := range rows {
+		secrets = append(secrets, &contractCredential.StoredSecretDTO{
+			RowID:              row.RowID,
+			EncryptionMetadata: row.EncryptionMetadata,
+		})
+	}
+
+	return secrets, nil
+}
+
+// ReplaceSecret stores the rewrapped signing secret of a subscription if it still holds the original one
+func (s *WebhookSecretStore) ReplaceSecret(ctx context.Context, rowID, originalCiphertext, encryptionMetadata string) (bool, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "WebhookSecretStore.ReplaceSecret")
+	defer span.End()
+
+	// Compare the stored ciphertext so that a secret rotated since it was listed is not overwritten
+	result := s.db.WithContext(ctx).
+		Model(&WebhookSubscriptionModel{}).
+		Where("id = ?", rowID).
+		Where("json_attributes->'encryption_metadata'->>'ciphertext' = ?", originalCiphertext).
+		Updates(map[string]interface{}{
+			"json_attributes": gorm.Expr("jsonb_set(json_attributes, '{encryption_metadata}', ?::jsonb)", encryptionMetadata),
+			"updated_at":      gorm.Expr("NOW()"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// belowVersionQuery selects the subscriptions whose signing secret was encrypted in the region with an older key version
+func (s *WebhookSecretStore) belowVersionQuery(ctx context.Context, region string, keyVersion int) *gorm.DB {
+	return s.db.WithContext(ctx).
+		Model(&WebhookSubscriptionModel{}).
+		Where("json_attributes->'encryption_metadata'->>'region' = ?", region).
+		Where("(json_attributes->'encryption_metadata'->>'key_version')::int < ?", keyVersion)
+}
@@ -0,0 +1,177 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bytedance/sonic"
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// WebhookSubscriptionRepository implements the domain.WebhookSubscriptionRepository interface using GORM
+type WebhookSubscriptionRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewWebhookSubscriptionRepository creates a new webhook subscription repository
+func NewWebhookSubscriptionRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// GetByID returns a webhook subscription by ID, nil if it does not exist
+func (r *WebhookSubscriptionRepository) GetByID(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "WebhookSubscriptionRepository.GetByID")
+	defer span.End()
+
+	var model WebhookSubscriptionModel
+	result := r.db.WithContext(ctx).First(&model, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return r.mapToDomain(&model)
+}
+
+// ListByUserID returns the webhook subscriptions of a user
+func (r *WebhookSubscriptionRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.WebhookSubscription, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "WebhookSubscriptionRepository.ListByUserID")
+	defer span.End()
+
+	var models []WebhookSubscriptionModel
+	result := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	subscriptions := make([]*domain.WebhookSubscription, 0, len(models))
+	for i := range models {
+		subscription, err := r.mapToDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+// Create creates a new webhook subscription
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "WebhookSubscriptionRepository.Create")
+	defer span.End()
+
+	model, err := r.mapToModel(subscription)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Create(model).Error
+}
+
+// Update updates the description and signing secret of a webhook subscription
+func (r *WebhookSubscriptionRepository) Update(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "WebhookSubscriptionRepository.Update")
+	defer span.End()
+
+	model, err := r.mapToModel(subscription)
+	if err != nil {
+		return err
+	}
+
+	result := r.db.WithContext(ctx).Model(&WebhookSubscriptionModel{}).Where("id = ?", subscription.ID).Updates(map[string]interface{}{
+		"description":     model.Description,
+		"json_attributes": model.JSONAttributes,
+		"updated_at":      model.UpdatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook subscription not found with id: %s", subscription.ID)
+	}
+
+	return nil
+}
+
+// UpdateLastDeliveryAt records the time of the last delivery received by a subscription
+func (r *WebhookSubscriptionRepository) UpdateLastDeliveryAt(ctx context.Context, id string, at time.Time) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "WebhookSubscriptionRepository.UpdateLastDeliveryAt")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Model(&WebhookSubscriptionModel{}).Where("id = ?", id).Update("last_delivery_at", at).Error
+}
+
+// Delete soft-deletes a webhook subscription
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "WebhookSubscriptionRepository.Delete")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&WebhookSubscriptionModel{}).Error
+}
+
+// webhookSubscriptionAttributes are the JSON attributes of a webhook subscription,
+// the encryption metadata is rewrapped in place when the transit key is rotated
+type webhookSubscriptionAttributes struct {
+	EncryptionMetadata json.RawMessage `json:"encryption_metadata,omitempty"`
+}
+
+// mapToDomain converts a webhook subscription model to a domain webhook subscription
+func (r *WebhookSubscriptionRepository) mapToDomain(model *WebhookSubscriptionModel) (*domain.WebhookSubscription, error) {
+	var jsonAttributes webhookSubscriptionAttributes
+	if len(model.JSONAttributes) > 0 {
+		if err := sonic.Unmarshal(model.JSONAttributes, &jsonAttributes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal webhook subscription JSON attributes: %w", err)
+		}
+	}
+
+	return &domain.WebhookSubscription{
+		ID:                     model.ID,
+		UserID:                 model.UserID,
+		ProviderIdentifier:     model.ProviderIdentifier,
+		Description:            model.Description,
+		EncryptedSigningSecret: string(jsonAttributes.EncryptionMetadata),
+		LastDeliveryAt:         model.LastDeliveryAt,
+		CreatedAt:              model.CreatedAt,
+		UpdatedAt:              model.UpdatedAt,
+		DeletedAt:              parseGormDeletedAt(model.DeletedAt),
+	}, nil
+}
+
+// mapToModel converts a domain webhook subscription to a webhook subscription model
+func (r *WebhookSubscriptionRepository) mapToModel(subscription *domain.WebhookSubscription) (*WebhookSubscriptionModel, error) {
+	jsonAttributesBytes, err := sonic.Marshal(webhookSubscriptionAttributes{
+		EncryptionMetadata: json.RawMessage(subscription.EncryptedSigningSecret),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook subscription JSON attributes: %w", err)
+	}
+
+	return &WebhookSubscriptionModel{
+		ID:                 subscription.ID,
+		UserID:             subscription.UserID,
+		ProviderIdentifier: subscription.ProviderIdentifier,
+		Description:        subscription.Description,
+		LastDeliveryAt:     subscription.LastDeliveryAt,
+		JSONAttributes:     jsonAttributesBytes,
+		CreatedAt:          subscription.CreatedAt,
+		UpdatedAt:          subscription.UpdatedAt,
+		DeletedAt:          parseDomainDeletedAt(subscription.DeletedAt),
+	}, nil
+}
@@ -0,0 +1,212 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/cache"
+)
+
+const (
+	// usageKeyPrefix prefixes the per-day hash holding usage counts until they are rolled up
+	usageKeyPrefix = "usage:"
+	// userUsageKeyInfix separates the day and the user of the per-user daily hash read by usage reports
+	userUsageKeyInfix = ":user:"
+	// usageTTL keeps daily usage hashes long enough for the rollup to catch up after downtime
+	usageTTL = 72 * time.Hour
+	// windowGrace keeps window counters slightly past the window end to absorb clock skew
+	windowGrace = time.Minute
+	// usageFieldSeparator separates the dimensions of a usage hash field
+	usageFieldSeparator = "|"
+)
+
+// RedisCounter implements the domain.QuotaCounter interface on top of the shared cache
+type RedisCounter struct {
+	cache cache.Cache
+	obs   *observability.ObservabilityProvider
+}
+
+// NewRedisCounter creates a new Redis quota counter
+func NewRedisCounter(cache cache.Cache, observabilityProvider *observability.ObservabilityProvider) *RedisCounter {
+	return &RedisCounter{
+		cache: cache,
+		obs:   observabilityProvider,
+	}
+}
+
+// Consume atomically counts one invocation against all limits and records it in the daily usage
+func (c *RedisCounter) Consume(ctx context.Context, limits []domain.QuotaLimit, usage domain.UsageRecord, now time.Time) ([]domain.QuotaUsage, bool, error) {
+	ctx, span := c.obs.Tracer.Start(ctx, "RedisCounter.Consume")
+	defer span.End()
+
+	values, allowed, err := c.cache.IncrementCounters(ctx, c.consumeCounters(limits, usage, now))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to increment quota counters: %w", err)
+	}
+
+	return c.toUsages(limits, values, now), allowed, nil
+}
+
+// Release takes back one invocation counted by Consume from the limits and the daily usage
+func (c *RedisCounter) Release(ctx context.Context, limits []domain.QuotaLimit, usage domain.UsageRecord, now time.Time) error {
+	ctx, span := c.obs.Tracer.Start(ctx, "RedisCounter.Release")
+	defer span.End()
+
+	if err := c.cache.DecrementCounters(ctx, c.consumeCounters(limits, usage, now)); err != nil {
+		return fmt.Errorf("failed to decrement quota counters: %w", err)
+	}
+
+	return nil
+}
+
+// consumeCounters returns the counters of an invocation, the limit counters followed by its daily usage.
+// The usage is counted in the daily hash of all users, which the rollup reads, and in the daily hash of the user,
+// so that usage reports do not read the counters of every user
+func (c *RedisCounter) consumeCounters(limits []domain.QuotaLimit, usage domain.UsageRecord, now time.Time) []cache.Counter {
+	return append(c.limitCounters(limits, now),
+		cache.Counter{
+			Key:   usageKey(now),
+			Field: usageField(usage),
+			TTL:   usageTTL,
+		},
+		cache.Counter{
+			Key:   userUsageKey(usage.UserID, now),
+			Field: userUsageField(usage),
+			TTL:   usageTTL,
+		},
+	)
+}
+
+// Peek returns the current usage of the limits without counting an invocation
+func (c *RedisCounter) Peek(ctx context.Context, limits []domain.QuotaLimit, now time.Time) ([]domain.QuotaUsage, error) {
+	ctx, span := c.obs.Tracer.Start(ctx, "RedisCounter.Peek")
+	defer span.End()
+
+	values, err := c.cache.GetCounters(ctx, c.limitCounters(limits, now))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quota counters: %w", err)
+	}
+
+	return c.toUsages(limits, values, now), nil
+}
+
+// DailyUsage returns the usage records of all users counted on the given day
+func (c *RedisCounter) DailyUsage(ctx context.Context, day time.Time) ([]*domain.UsageRecord, error) {
+	ctx, span := c.obs.Tracer.Start(ctx, "RedisCounter.DailyUsage")
+	defer span.End()
+
+	fields, err := c.cache.HashGetAll(ctx, usageKey(day))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily usage: %w", err)
+	}
+
+	return parseUsageFields(fields, "", day), nil
+}
+
+// UserDailyUsage returns the usage records of one user counted on the given day
+func (c *RedisCounter) UserDailyUsage(ctx context.Context, userID string, day time.Time) ([]*domain.UsageRecord, error) {
+	ctx, span := c.obs.Tracer.Start(ctx, "RedisCounter.UserDailyUsage")
+	defer span.End()
+
+	fields, err := c.cache.HashGetAll(ctx, userUsageKey(userID, day))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily usage of user: %w", err)
+	}
+
+	return parseUsageFields(fields, userID, day), nil
+}
+
+// parseUsageFields maps the fields of a daily usage hash to usage records,
+// the fields of a per-user hash omit the user, which is then the given one
+func parseUsageFields(fields map[string]string, userID string, day time.Time) []*domain.UsageRecord {
+	dayStart, _ := domain.QuotaWindowDay.Bounds(day)
+	records := make([]*domain.UsageRecord, 0, len(fields))
+	for field, value := range fields {
+		if userID != "" {
+			field = userID + usageFieldSeparator + field
+		}
+		parts := strings.Split(field, usageFieldSeparator)
+		if len(parts) != 4 {
+			continue
+		}
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		records = append(records, &domain.UsageRecord{
+			Day:                 dayStart,
+			UserID:              parts[0],
+			APIKeyID:            parts[1],
+			ProviderIdentifier:  parts[2],
+			OperationIdentifier: parts[3],
+			Count:               count,
+		})
+	}
+	return records
+}
+
+// limitCounters maps quota limits to cache counters for the current windows
+func (c *RedisCounter) limitCounters(limits []domain.QuotaLimit, now time.Time) []cache.Counter {
+	counters := make([]cache.Counter, 0, len(limits)+1)
+	for _, limit := range limits {
+		_, end := limit.Window.Bounds(now)
+		counters = append(counters, cache.Counter{
+			Key:   limit.CounterKey(now),
+			Limit: limit.Limit,
+			TTL:   end.Sub(now) + windowGrace,
+		})
+	}
+	return counters
+}
+
+// toUsages pairs the limits with the counter values read for them
+func (c *RedisCounter) toUsages(limits []domain.QuotaLimit, values []int64, now time.Time) []domain.QuotaUsage {
+	usages := make([]domain.QuotaUsage, 0, len(limits))
+	for i, limit := range limits {
+		_, end := limit.Window.Bounds(now)
+		var used int64
+		if i < len(values) {
+			used = values[i]
+		}
+		usages = append(usages, domain.QuotaUsage{
+			Limit:   limit,
+			Used:    used,
+			ResetAt: end,
+		})
+	}
+	return usages
+}
+
+// usageKey returns the key of the daily usage hash for the given day
+func usageKey(day time.Time) string {
+	return usageKeyPrefix + day.UTC().Format(time.DateOnly)
+}
+
+// usageField returns the usage hash field of a usage record
+func usageField(usage domain.UsageRecord) string {
+	return strings.Join([]string{
+		usage.UserID,
+		usage.APIKeyID,
+		usage.ProviderIdentifier,
+		usage.OperationIdentifier,
+	}, usageFieldSeparator)
+}
+
+// userUsageKey returns the key of the daily usage hash of a user for the given day
+func userUsageKey(userID string, day time.Time) string {
+	return usageKey(day) + userUsageKeyInfix + userID
+}
+
+// userUsageField returns the field of a usage record in the daily usage hash of its user
+func userUsageField(usage domain.UsageRecord) string {
+	return strings.Join([]string{
+		usage.APIKeyID,
+		usage.ProviderIdentifier,
+		usage.OperationIdentifier,
+	}, usageFieldSeparator)
+}
@@ -0,0 +1,293 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+)
+
+// SignatureTolerance is how far the signed timestamp of a delivery may be from the time it is received,
+// older deliveries are rejected to prevent replays
+const SignatureTolerance = 5 * time.Minute
+
+// NewVerifiers returns the verifiers of the supported signature schemes
+func NewVerifiers() map[domain.WebhookScheme]domain.WebhookVerifier {
+	return map[domain.WebhookScheme]domain.WebhookVerifier{
+		domain.WebhookSchemeGitHub:   &GitHubVerifier{},
+		domain.WebhookSchemeSlack:    &SlackVerifier{tolerance: SignatureTolerance},
+		domain.WebhookSchemeStripe:   &StripeVerifier{tolerance: SignatureTolerance},
+		domain.WebhookSchemeAirtable: &AirtableVerifier{},
+		domain.WebhookSchemeZoom:     &ZoomVerifier{tolerance: SignatureTolerance},
+	}
+}
+
+// GitHubVerifier verifies GitHub deliveries, signed with HMAC-SHA256 of the body
+type GitHubVerifier struct{}
+
+// Verify checks the X-Hub-Signature-256 header
+func (v *GitHubVerifier) Verify(secret string, delivery *domain.WebhookDelivery) error {
+	signature, ok := strings.CutPrefix(delivery.Header.Get("X-Hub-Signature-256"), "sha256=")
+	if !ok {
+		return fmt.Errorf("%w: missing X-Hub-Signature-256 header", domain.ErrWebhookSignatureInvalid)
+	}
+	return compareSignature(signature, hmacSHA256([]byte(secret), delivery.Body))
+}
+
+// Challenge returns nil, GitHub pings the endpoint with a regular event
+func (v *GitHubVerifier) Challenge(secret string, delivery *domain.WebhookDelivery) (interface{}, error) {
+	return nil, nil
+}
+
+// Normalize identifies the delivery by the hash of its body and reads the event name, qualified by the action of the
+// event if any. The X-GitHub-Delivery GUID is not covered by the signature and GitHub signs no timestamp, so a captured
+// delivery could otherwise be replayed with a fresh GUID. Replays of a body are only de-duplicated while its event is
+// retained, after the webhook retention period the same signed body is accepted again.
+func (v *GitHubVerifier) Normalize(delivery *domain.WebhookDelivery) (string, string) {
+	eventType := delivery.Header.Get("X-GitHub-Event")
+
+	var body struct {
+		Action string `json:"action"`
+	}
+	if err := sonic.Unmarshal(delivery.Body, &body); err == nil && body.Action != "" {
+		eventType += "." + body.Action
+	}
+
+	return delivery.FallbackDeliveryID(), eventType
+}
+
+// SlackVerifier verifies Slack deliveries, signed with the signing secret of the app
+type SlackVerifier struct {
+	tolerance time.Duration
+}
+
+// Verify checks the X-Slack-Signature header over the version, the request timestamp and the body
+func (v *SlackVerifier) Verify(secret string, delivery *domain.WebhookDelivery) error {
+	timestamp := delivery.Header.Get("X-Slack-Request-Timestamp")
+	if err := checkTimestamp(timestamp, delivery.ReceivedAt, v.tolerance); err != nil {
+		return err
+	}
+
+	signature, ok := strings.CutPrefix(delivery.Header.Get("X-Slack-Signature"), "v0=")
+	if !ok {
+		return fmt.Errorf("%w: missing X-Slack-Signature header", domain.ErrWebhookSignatureInvalid)
+	}
+	return compareSignature(signature, hmacSHA256([]byte(secret), []byte("v0:"+timestamp+":"), delivery.Body))
+}
+
+// Challenge answers the url_verification request sent when the request URL of the app is set
+func (v *SlackVerifier) Challenge(secret string, delivery *domain.WebhookDelivery) (interface{}, error) {
+	var body struct {
+		Type      string `json:"type"`
+		Challenge string `json:"challenge"`
+	}
+	if err := sonic.Unmarshal(delivery.Body, &body); err != nil || body.Type != "url_verification" {
+		return nil, nil
+	}
+	return map[string]string{"challenge": body.Challenge}, nil
+}
+
+// Normalize reads the event ID and type of Events API deliveries,
+// interactions and slash commands are form-encoded and carry no delivery ID
+func (v *SlackVerifier) Normalize(delivery *domain.WebhookDelivery) (string, string) {
+	var body struct {
+		EventID string `json:"event_id"`
+		Type    string `json:"type"`
+		Event   struct {
+			Type string `json:"type"`
+		} `json:"event"`
+	}
+	if err := sonic.Unmarshal(delivery.Body, &body); err == nil {
+		if body.Event.Type != "" {
+			return body.EventID, body.Event.Type
+		}
+		return body.EventID, body.Type
+	}
+
+	form, err := url.ParseQuery(string(delivery.Body))
+	if err != nil {
+		return "", ""
+	}
+	if form.Get("command") != "" {
+		return "", "slash_command"
+	}
+	var payload struct {
+		Type string `json:"type"`
+	}
+	if err := sonic.UnmarshalString(form.Get("payload"), &payload); err == nil {
+		return "", payload.Type
+	}
+	return "", ""
+}
+
+// StripeVerifier verifies Stripe deliveries, signed with the secret of the webhook endpoint
+type StripeVerifier struct {
+	tolerance time.Duration
+}
+
+// Verify checks the v1 signatures of the Stripe-Signature header over the timestamp and the body,
+// several signatures are sent while the endpoint secret is rolled
+func (v *StripeVerifier) Verify(secret string, delivery *domain.WebhookDelivery) error {
+	var timestamp string
+	var signatures []string
+	for _, item := range strings.Split(delivery.Header.Get("Stripe-Signature"), ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(item), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if len(signatures) == 0 {
+		return fmt.Errorf("%w: missing v1 signature in Stripe-Signature header", domain.ErrWebhookSignatureInvalid)
+	}
+	if err := checkTimestamp(timestamp, delivery.ReceivedAt, v.tolerance); err != nil {
+		return err
+	}
+
+	expected := hmacSHA256([]byte(secret), []byte(timestamp+"."), delivery.Body)
+	for _, signature := range signatures {
+		if compareSignature(signature, expected) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: signature mismatch", domain.ErrWebhookSignatureInvalid)
+}
+
+// Challenge returns nil, Stripe does not validate endpoints
+func (v *StripeVerifier) Challenge(secret string, delivery *domain.WebhookDelivery) (interface{}, error) {
+	return nil, nil
+}
+
+// Normalize reads the ID and type of the event
+func (v *StripeVerifier) Normalize(delivery *domain.WebhookDelivery) (string, string) {
+	var body struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	}
+	_ = sonic.Unmarshal(delivery.Body, &body)
+	return body.ID, body.Type
+}
+
+// AirtableVerifier verifies Airtable notifications, signed with the MAC secret returned when the webhook is created
+type AirtableVerifier struct{}
+
+// Verify checks the X-Airtable-Content-MAC header, the secret is the base64 encoded MAC secret
+func (v *AirtableVerifier) Verify(secret string, delivery *domain.WebhookDelivery) error {
+	key, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil {
+		return fmt.Errorf("%w: MAC secret is not base64 encoded", domain.ErrWebhookSignatureInvalid)
+	}
+
+	signature, ok := strings.CutPrefix(delivery.Header.Get("X-Airtable-Content-MAC"), "hmac-sha256=")
+	if !ok {
+		return fmt.Errorf("%w: missing X-Airtable-Content-MAC header", domain.ErrWebhookSignatureInvalid)
+	}
+	return compareSignature(signature, hmacSHA256(key, delivery.Body))
+}
+
+// Challenge returns nil, Airtable does not validate endpoints
+func (v *AirtableVerifier) Challenge(secret string, delivery *domain.WebhookDelivery) (interface{}, error) {
+	return nil, nil
+}
+
+// Normalize identifies a notification by its webhook and timestamp,
+// notifications only announce that new payloads can be listed
+func (v *AirtableVerifier) Normalize(delivery *domain.WebhookDelivery) (string, string) {
+	var body struct {
+		Webhook struct {
+			ID string `json:"id"`
+		} `json:"webhook"`
+		Timestamp string `json:"timestamp"`
+	}
+	if err := sonic.Unmarshal(delivery.Body, &body); err != nil || body.Webhook.ID == "" || body.Timestamp == "" {
+		return "", "notification"
+	}
+	return body.Webhook.ID + ":" + body.Timestamp, "notification"
+}
+
+// ZoomVerifier verifies Zoom deliveries, signed with the secret token of the app
+type ZoomVerifier struct {
+	tolerance time.Duration
+}
+
+// Verify checks the x-zm-signature header over the version, the request timestamp and the body
+func (v *ZoomVerifier) Verify(secret string, delivery *domain.WebhookDelivery) error {
+	timestamp := delivery.Header.Get("x-zm-request-timestamp")
+	if err := checkTimestamp(timestamp, delivery.ReceivedAt, v.tolerance); err != nil {
+		return err
+	}
+
+	signature, ok := strings.CutPrefix(delivery.Header.Get("x-zm-signature"), "v0=")
+	if !ok {
+		return fmt.Errorf("%w: missing x-zm-signature header", domain.ErrWebhookSignatureInvalid)
+	}
+	return compareSignature(signature, hmacSHA256([]byte(secret), []byte("v0:"+timestamp+":"), delivery.Body))
+}
+
+// Challenge answers the endpoint.url_validation request with the plain token hashed with the secret token
+func (v *ZoomVerifier) Challenge(secret string, delivery *domain.WebhookDelivery) (interface{}, error) {
+	var body struct {
+		Event   string `json:"event"`
+		Payload struct {
+			PlainToken string `json:"plainToken"`
+		} `json:"payload"`
+	}
+	if err := sonic.Unmarshal(delivery.Body, &body); err != nil || body.Event != "endpoint.url_validation" {
+		return nil, nil
+	}
+	return map[string]string{
+		"plainToken":     body.Payload.PlainToken,
+		"encryptedToken": hex.EncodeToString(hmacSHA256([]byte(secret), []byte(body.Payload.PlainToken))),
+	}, nil
+}
+
+// Normalize reads the event name, Zoom sends no delivery ID but retries the same body
+func (v *ZoomVerifier) Normalize(delivery *domain.WebhookDelivery) (string, string) {
+	var body struct {
+		Event string `json:"event"`
+	}
+	_ = sonic.Unmarshal(delivery.Body, &body)
+	return "", body.Event
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the concatenated parts
+func hmacSHA256(key []byte, parts ...[]byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	for _, part := range parts {
+		mac.Write(part)
+	}
+	return mac.Sum(nil)
+}
+
+// compareSignature compares a hex encoded signature with the expected MAC in constant time
+func compareSignature(signature string, expected []byte) error {
+	decoded, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(decoded, expected) {
+		return fmt.Errorf("%w: signature mismatch", domain.ErrWebhookSignatureInvalid)
+	}
+	return nil
+}
+
+// checkTimestamp rejects deliveries whose signed unix timestamp is outside the tolerance
+func checkTimestamp(timestamp string, receivedAt time.Time, tolerance time.Duration) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: missing or invalid timestamp", domain.ErrWebhookSignatureInvalid)
+	}
+
+	age := receivedAt.Sub(time.Unix(seconds, 0))
+	if age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: timestamp outside the tolerance", domain.ErrWebhookSignatureInvalid)
+	}
+	return nil
+}
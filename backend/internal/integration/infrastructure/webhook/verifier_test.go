@@ -0,0 +1,325 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+)
+
+// sign returns the hex encoded HMAC-SHA256 of the message
+func sign(secret, message string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newDelivery(body string, receivedAt time.Time, headers map[string]string) *domain.WebhookDelivery {
+	header := http.Header{}
+	for name, value := range headers {
+		header.Set(name, value)
+	}
+	return &domain.WebhookDelivery{Header: header, Body: []byte(body), ReceivedAt: receivedAt}
+}
+
+func TestVerifiers(t *testing.T) {
+	const secret = "whsec_test"
+	const body = `{"id":"evt_1","type":"invoice.paid"}`
+
+	now := time.Unix(1700000000, 0)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	stale := strconv.FormatInt(now.Add(-SignatureTolerance-time.Second).Unix(), 10)
+	future := strconv.FormatInt(now.Add(SignatureTolerance+time.Second).Unix(), 10)
+	withinTolerance := strconv.FormatInt(now.Add(-SignatureTolerance+time.Second).Unix(), 10)
+
+	airtableSecret := base64.StdEncoding.EncodeToString([]byte("airtable-mac-secret"))
+	verifiers := NewVerifiers()
+
+	tests := []struct {
+		name     string
+		scheme   domain.WebhookScheme
+		secret   string
+		delivery *domain.WebhookDelivery
+		wantErr  bool
+	}{
+		// GitHub
+		{
+			name:   "GitHubDocumentedExample",
+			scheme: domain.WebhookSchemeGitHub,
+			secret: "It's a Secret to Everybody",
+			delivery: newDelivery("Hello, World!", now, map[string]string{
+				"X-Hub-Signature-256": "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17",
+			}),
+		},
+		{
+			name:     "GitHubValid",
+			scheme:   domain.WebhookSchemeGitHub,
+			secret:   secret,
+			delivery: newDelivery(body, now, map[string]string{"X-Hub-Signature-256": "sha256=" + sign(secret, body)}),
+		},
+		{
+			name:     "GitHubWrongSecret",
+			scheme:   domain.WebhookSchemeGitHub,
+			secret:   secret,
+			delivery: newDelivery(body, now, map[string]string{"X-Hub-Signature-256": "sha256=" + sign("other", body)}),
+			wantErr:  true,
+		},
+		{
+			name:     "GitHubTamperedBody",
+			scheme:   domain.WebhookSchemeGitHub,
+			secret:   secret,
+			delivery: newDelivery(body+" ", now, map[string]string{"X-Hub-Signature-256": "sha256=" + sign(secret, body)}),
+			wantErr:  true,
+		},
+		{
+			name:     "GitHubSHA1SignatureOnly",
+			scheme:   domain.WebhookSchemeGitHub,
+			secret:   secret,
+			delivery: newDelivery(body, now, map[string]string{"X-Hub-Signature": "sha1=" + sign(secret, body)}),
+			wantErr:  true,
+		},
+		{
+			name:     "GitHubNonHexSignature",
+			scheme:   domain.WebhookSchemeGitHub,
+			secret:   secret,
+			delivery: newDelivery(body, now, map[string]string{"X-Hub-Signature-256": "sha256=not-hex"}),
+			wantErr:  true,
+		},
+
+		// Slack
+		{
+			name:   "SlackValid",
+			scheme: domain.WebhookSchemeSlack,
+			secret: secret,
+			delivery: newDelivery(body, now, map[string]string{
+				"X-Slack-Request-Timestamp": ts,
+				"X-Slack-Signature":         "v0=" + sign(secret, "v0:"+ts+":"+body),
+			}),
+		},
+		{
+			name:   "SlackWithinTolerance",
+			scheme: domain.WebhookSchemeSlack,
+			secret: secret,
+			delivery: newDelivery(body, now, map[string]string{
+				"X-Slack-Request-Timestamp": withinTolerance,
+				"X-Slack-Signature":         "v0=" + sign(secret, "v0:"+withinTolerance+":"+body),
+			}),
+		},
+		{
+			name:   "SlackReplayedStaleTimestamp",
+			scheme: domain.WebhookSchemeSlack,
+			secret: secret,
+			delivery: newDelivery(body, now, map[string]string{
+				"X-Slack-Request-Timestamp": stale,
+				"X-Slack-Signature":         "v0=" + sign(secret, "v0:"+stale+":"+body),
+			}),
+			wantErr: true,
+		},
+		{
+			name:   "SlackFutureTimestamp",
+			scheme: domain.WebhookSchemeSlack,
+			secret: secret,
+			delivery: newDelivery(body, now, map[string]string{
+				"X-Slack-Request-Timestamp": future,
+				"X-Slack-Signature":         "v0=" + sign(secret, "v0:"+future+":"+body),
+			}),
+			wantErr: true,
+		},
+		{
+			name:   "SlackTimestampNotSigned",
+			scheme: domain.WebhookSchemeSlack,
+			secret: secret,
+			delivery: newDelivery(body, now, map[string]string{
+				"X-Slack-Request-Timestamp": ts,
+				"X-Slack-Signature":         "v0=" + sign(secret, "v0:"+stale+":"+body),
+			}),
+			wantErr: true,
+		},
+		{
+			name:   "SlackMissingTimestamp",
+			scheme: domain.WebhookSchemeSlack,
+			secret: secret,
+			delivery: newDelivery(body, now, map[string]string{
+				"X-Slack-Signature": "v0=" + sign(secret, "v0::"+body),
+			}),
+			wantErr: true,
+		},
+
+		// Stripe
+		{
+			name:   "StripeValid",
+			scheme: domain.WebhookSchemeStripe,
+			secret: secret,
+			delivery: newDelivery(body, now, map[string]string{
+				"Stripe-Signature": "t=" + ts + ",v1=" + sign(secret, ts+"."+body),
+			}),
+		},
+		{
+			name:   "StripeRolledSecret",
+			scheme: domain.WebhookSchemeStripe,
+			secret: secret,
+			delivery: newDelivery(body, now, map[string]string{
+				"Stripe-Signature": "t=" + ts + ",v1=" + sign("whsec_old", ts+"."+body) + ",v1=" + sign(secret, ts+"."+body),
+			}),
+		},
+		{
+			name:   "StripeV0SignatureOnly",
+			scheme: domain.WebhookSchemeStripe,
+			secret: secret,
+			delivery: newDelivery(body, now, map[string]string{
+				"Stripe-Signature": "t=" + ts + ",v0=" + sign(secret, ts+"."+body),
+			}),
+			wantErr: true,
+		},
+		{
+			name:   "StripeReplayedStaleTimestamp",
+			scheme: domain.WebhookSchemeStripe,
+			secret: secret,
+			delivery: newDelivery(body, now, map[string]string{
+				"Stripe-Signature": "t=" + stale + ",v1=" + sign(secret, stale+"."+body),
+			}),
+			wantErr: true,
+		},
+		{
+			name:   "StripeMissingTimestamp",
+			scheme: domain.WebhookSchemeStripe,
+			secret: secret,
+			delivery: newDelivery(body, now, map[string]string{
+				"Stripe-Signature": "v1=" + sign(secret, "."+body),
+			}),
+			wantErr: true,
+		},
+
+		// Airtable
+		{
+			name:   "AirtableValid",
+			scheme: domain.WebhookSchemeAirtable,
+			secret: airtableSecret,
+			delivery: newDelivery(body, now, map[string]string{
+				"X-Airtable-Content-MAC": "hmac-sha256=" + sign("airtable-mac-secret", body),
+			}),
+		},
+		{
+			name:   "AirtableSecretNotDecoded",
+			scheme: domain.WebhookSchemeAirtable,
+			secret: airtableSecret,
+			delivery: newDelivery(body, now, map[string]string{
+				"X-Airtable-Content-MAC": "hmac-sha256=" + sign(airtableSecret, body),
+			}),
+			wantErr: true,
+		},
+		{
+			name:   "AirtableSecretNotBase64",
+			scheme: domain.WebhookSchemeAirtable,
+			secret: "not base64!",
+			delivery: newDelivery(body, now, map[string]string{
+				"X-Airtable-Content-MAC": "hmac-sha256=" + sign("not base64!", body),
+			}),
+			wantErr: true,
+		},
+
+		// Zoom
+		{
+			name:   "ZoomValid",
+			scheme: domain.WebhookSchemeZoom,
+			secret: secret,
+			delivery: newDelivery(body, now, map[string]string{
+				"x-zm-request-timestamp": ts,
+				"x-zm-signature":         "v0=" + sign(secret, "v0:"+ts+":"+body),
+			}),
+		},
+		{
+			name:   "ZoomReplayedStaleTimestamp",
+			scheme: domain.WebhookSchemeZoom,
+			secret: secret,
+			delivery: newDelivery(body, now, map[string]string{
+				"x-zm-request-timestamp": stale,
+				"x-zm-signature":         "v0=" + sign(secret, "v0:"+stale+":"+body),
+			}),
+			wantErr: true,
+		},
+		{
+			name:   "ZoomMissingSignature",
+			scheme: domain.WebhookSchemeZoom,
+			secret: secret,
+			delivery: newDelivery(body, now, map[string]string{
+				"x-zm-request-timestamp": ts,
+			}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifiers[tt.scheme].Verify(tt.secret, tt.delivery)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("Expected the delivery to verify, got: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, domain.ErrWebhookSignatureInvalid) {
+				t.Errorf("Expected ErrWebhookSignatureInvalid, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestGitHubNormalize(t *testing.T) {
+	const body = `{"action":"opened","number":1}`
+	verifier := &GitHubVerifier{}
+
+	first := newDelivery(body, time.Now(), map[string]string{"X-GitHub-Event": "issues", "X-GitHub-Delivery": "guid-1"})
+	deliveryID, eventType := verifier.Normalize(first)
+	if eventType != "issues.opened" {
+		t.Errorf("Expected event type issues.opened, got %s", eventType)
+	}
+	if deliveryID != first.FallbackDeliveryID() {
+		t.Errorf("Expected the delivery to be identified by its body hash, got %s", deliveryID)
+	}
+
+	// The GUID is not signed, a replay of the same body with a fresh GUID must be de-duplicated
+	replayed := newDelivery(body, time.Now(), map[string]string{"X-GitHub-Event": "issues", "X-GitHub-Delivery": "guid-2"})
+	if replayedID, _ := verifier.Normalize(replayed); replayedID != deliveryID {
+		t.Errorf("Expected a replay with a fresh GUID to share the delivery ID %s, got %s", deliveryID, replayedID)
+	}
+
+	other := newDelivery(`{"action":"closed","number":1}`, time.Now(), map[string]string{"X-GitHub-Event": "issues", "X-GitHub-Delivery": "guid-1"})
+	if otherID, _ := verifier.Normalize(other); otherID == deliveryID {
+		t.Error("Expected deliveries with different bodies to have different delivery IDs")
+	}
+}
+
+func TestZoomChallenge(t *testing.T) {
+	verifier := &ZoomVerifier{tolerance: SignatureTolerance}
+	delivery := newDelivery(`{"event":"endpoint.url_validation","payload":{"plainToken":"qgg8vlvZRS6UYooatFL8Aw"}}`, time.Now(), nil)
+
+	response, err := verifier.Challenge("secret-token", delivery)
+	if err != nil {
+		t.Fatalf("Failed to answer challenge: %v", err)
+	}
+
+	answer, ok := response.(map[string]string)
+	if !ok {
+		t.Fatalf("Expected a challenge answer, got: %v", response)
+	}
+	if answer["plainToken"] != "qgg8vlvZRS6UYooatFL8Aw" {
+		t.Errorf("Expected the plain token to be echoed, got: %s", answer["plainToken"])
+	}
+	if expected := sign("secret-token", "qgg8vlvZRS6UYooatFL8Aw"); answer["encryptedToken"] != expected {
+		t.Errorf("Expected encrypted token %s, got: %s", expected, answer["encryptedToken"])
+	}
+
+	// Regular events are not challenges
+	response, err = verifier.Challenge("secret-token", newDelivery(`{"event":"meeting.started"}`, time.Now(), nil))
+	if err != nil || response != nil {
+		t.Errorf("Expected no challenge answer, got: %v, %v", response, err)
+	}
+}
@@ -0,0 +1,373 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	identityDomain "github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/integration/application"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+	"github.com/context-space/context-space/backend/internal/shared/types"
+	"github.com/context-space/context-space/backend/internal/shared/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// ApprovalHandler handles HTTP requests for invocation approvals
+type ApprovalHandler struct {
+	invocationService *application.InvocationService
+	obs               *observability.ObservabilityProvider
+}
+
+// NewApprovalHandler creates a new approval handler
+func NewApprovalHandler(
+	invocationService *application.InvocationService,
+	observabilityProvider *observability.ObservabilityProvider,
+) *ApprovalHandler {
+	return &ApprovalHandler{
+		invocationService: invocationService,
+		obs:               observabilityProvider,
+	}
+}
+
+// RegisterRoutes registers the routes for this handler
+func (h *ApprovalHandler) RegisterRoutes(router *gin.RouterGroup, requireAuth gin.HandlerFunc) {
+	// Approvals are not reachable with API keys, so an agent cannot approve its own calls
+	approvals := router.Group("/approvals")
+	approvals.Use(requireAuth)
+	{
+		approvals.GET("", h.ListPendingApprovals)
+		approvals.GET("/policy", h.GetApprovalPolicy)
+		approvals.PUT("/policy", h.UpdateApprovalPolicy)
+		approvals.POST("/:invocation_id/approve", h.ApproveInvocation)
+		approvals.POST("/:invocation_id/deny", h.DenyInvocation)
+	}
+}
+
+// ApproveRequest represents the request body for approving an invocation
+type ApproveRequest struct {
+	Parameters map[string]interface{} `json:"parameters,omitempty"` // Optional edited parameters replacing the snapshot
+}
+
+// DenyRequest represents the request body for denying an invocation
+type DenyRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// ApprovalPolicyResponse represents an approval policy in responses
+type ApprovalPolicyResponse struct {
+	Enabled            bool     `json:"enabled"`
+	MinRiskLevel       string   `json:"min_risk_level"`
+	AlwaysRequire      []string `json:"always_require"`
+	NeverRequire       []string `json:"never_require"`
+	ApprovalTTLSeconds int64    `json:"approval_ttl_seconds"`
+}
+
+// UpdateApprovalPolicyRequest represents the request body for updating an approval policy
+type UpdateApprovalPolicyRequest struct {
+	Enabled            *bool     `json:"enabled,omitempty"`
+	MinRiskLevel       *string   `json:"min_risk_level,omitempty" enums:"read,write,destructive"`
+	AlwaysRequire      *[]string `json:"always_require,omitempty"`
+	NeverRequire       *[]string `json:"never_require,omitempty"`
+	ApprovalTTLSeconds *int64    `json:"approval_ttl_seconds,omitempty"`
+}
+
+// mapApprovalPolicyToResponse maps a domain approval policy to a response
+func mapApprovalPolicyToResponse(policy *domain.ApprovalPolicy) ApprovalPolicyResponse {
+	alwaysRequire := policy.AlwaysRequire
+	if alwaysRequire == nil {
+		alwaysRequire = []string{}
+	}
+	neverRequire := policy.NeverRequire
+	if neverRequire == nil {
+		neverRequire = []string{}
+	}
+
+	return ApprovalPolicyResponse{
+		Enabled:            policy.Enabled,
+		MinRiskLevel:       string(policy.MinRiskLevel),
+		AlwaysRequire:      alwaysRequire,
+		NeverRequire:       neverRequire,
+		ApprovalTTLSeconds: int64(policy.TTL() / time.Second),
+	}
+}
+
+// ListPendingApprovals godoc
+// @Summary List pending approvals
+// @Description Lists invocations of the authenticated user that are awaiting approval
+// @Tags approval
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Limit (default: 20)"
+// @Param offset query int false "Offset (default: 0)"
+// @Success 200 {object} httpapi.Response{data=ListInvocationsResponse} "Success response with pending invocations"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /approvals [get]
+func (h *ApprovalHandler) ListPendingApprovals(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return
+	}
+	user := userI.(*identityDomain.User)
+
+	limit := 20
+	offset := 0
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
+			limit = min(parsedLimit, 100)
+		}
+	}
+	if offsetParam := c.Query("offset"); offsetParam != "" {
+		if parsedOffset, err := strconv.Atoi(offsetParam); err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	invocations, err := h.invocationService.ListPendingApprovals(ctx, user.ID, limit, offset)
+	if err != nil {
+		httpapi.InternalServerError(c, "Failed to list pending approvals")
+		return
+	}
+
+	total, err := h.invocationService.CountPendingApprovals(ctx, user.ID)
+	if err != nil {
+		httpapi.InternalServerError(c, "Failed to count pending approvals")
+		return
+	}
+
+	invocationResponses := make([]InvocationResponse, 0, len(invocations))
+	for _, invocation := range invocations {
+		response, err := mapInvocationToResponse(invocation, false)
+		if err != nil {
+			continue
+		}
+		invocationResponses = append(invocationResponses, response)
+	}
+
+	httpapi.OK(c, ListInvocationsResponse{
+		Invocations: invocationResponses,
+		Total:       total,
+	}, "Pending approvals retrieved successfully")
+}
+
+// ApproveInvocation godoc
+// @Summary Approve invocation
+// @Description Approves an invocation awaiting approval and executes it, optionally with edited parameters
+// @Tags approval
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param invocation_id path string true "Invocation ID"
+// @Param request body ApproveRequest false "Edited parameters"
+// @Success 200 {object} httpapi.Response{data=InvocationResponse} "Success response with invocation result"
+// @Failure 400 {object} httpapi.Response{data=InvalidParametersResponse} "Edited parameters do not match the operation schema"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 409 {object} httpapi.SwaggerErrorResponse "Invocation is not awaiting approval"
+// @Failure 410 {object} httpapi.SwaggerErrorResponse "Approval window expired or provider deprecated"
+// @Failure 429 {object} httpapi.SwaggerErrorResponse "Quota exceeded"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Failure 503 {object} httpapi.Response{data=ProviderUnavailableResponse} "Provider is inactive or under maintenance"
+// @Router /approvals/{invocation_id}/approve [post]
+func (h *ApprovalHandler) ApproveInvocation(c *gin.Context) {
+	ctx := invocationContext(c)
+
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return
+	}
+	user := userI.(*identityDomain.User)
+
+	var req ApproveRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request format: ", err.Error()))
+		return
+	}
+
+	invocation, err := h.invocationService.ApproveInvocation(ctx, user.ID, c.Param("invocation_id"), req.Parameters)
+	if err != nil {
+		h.respondWithDecisionError(c, err)
+		return
+	}
+
+	response, err := mapInvocationToResponse(invocation, true)
+	if err != nil {
+		httpapi.InternalServerError(c, "Failed to format response")
+		return
+	}
+
+	httpapi.OK(c, response, "Invocation approved successfully")
+}
+
+// DenyInvocation godoc
+// @Summary Deny invocation
+// @Description Denies an invocation awaiting approval
+// @Tags approval
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param invocation_id path string true "Invocation ID"
+// @Param request body DenyRequest false "Reason for the denial"
+// @Success 200 {object} httpapi.Response{data=InvocationResponse} "Success response with denied invocation"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 409 {object} httpapi.SwaggerErrorResponse "Invocation is not awaiting approval"
+// @Failure 410 {object} httpapi.SwaggerErrorResponse "Approval window expired"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /approvals/{invocation_id}/deny [post]
+func (h *ApprovalHandler) DenyInvocation(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return
+	}
+	user := userI.(*identityDomain.User)
+
+	var req DenyRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request format: ", err.Error()))
+		return
+	}
+
+	invocation, err := h.invocationService.DenyInvocation(ctx, user.ID, c.Param("invocation_id"), req.Reason)
+	if err != nil {
+		h.respondWithDecisionError(c, err)
+		return
+	}
+
+	response, err := mapInvocationToResponse(invocation, false)
+	if err != nil {
+		httpapi.InternalServerError(c, "Failed to format response")
+		return
+	}
+
+	httpapi.OK(c, response, "Invocation denied successfully")
+}
+
+// GetApprovalPolicy godoc
+// @Summary Get approval policy
+// @Description Gets the approval policy of the authenticated user
+// @Tags approval
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} httpapi.Response{data=ApprovalPolicyResponse} "Success response with approval policy"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /approvals/policy [get]
+func (h *ApprovalHandler) GetApprovalPolicy(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return
+	}
+	user := userI.(*identityDomain.User)
+
+	policy, err := h.invocationService.GetApprovalPolicy(ctx, user.ID)
+	if err != nil {
+		httpapi.InternalServerError(c, "Failed to get approval policy")
+		return
+	}
+
+	httpapi.OK(c, mapApprovalPolicyToResponse(policy), "Approval policy retrieved successfully")
+}
+
+// UpdateApprovalPolicy godoc
+// @Summary Update approval policy
+// @Description Updates the approval policy of the authenticated user
+// @Tags approval
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdateApprovalPolicyRequest true "Approval policy changes"
+// @Success 200 {object} httpapi.Response{data=ApprovalPolicyResponse} "Success response with updated approval policy"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /approvals/policy [put]
+func (h *ApprovalHandler) UpdateApprovalPolicy(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return
+	}
+	user := userI.(*identityDomain.User)
+
+	var req UpdateApprovalPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request format: ", err.Error()))
+		return
+	}
+
+	update := application.ApprovalPolicyUpdate{
+		Enabled:       req.Enabled,
+		AlwaysRequire: req.AlwaysRequire,
+		NeverRequire:  req.NeverRequire,
+	}
+	if req.MinRiskLevel != nil {
+		riskLevel := types.OperationRiskLevel(*req.MinRiskLevel)
+		update.MinRiskLevel = &riskLevel
+	}
+	if req.ApprovalTTLSeconds != nil {
+		ttl := time.Duration(*req.ApprovalTTLSeconds) * time.Second
+		update.ApprovalTTL = &ttl
+	}
+
+	policy, err := h.invocationService.UpdateApprovalPolicy(ctx, user.ID, update)
+	if err != nil {
+		if errors.Is(err, application.ErrInvalidParameters) {
+			httpapi.BadRequest(c, err.Error())
+			return
+		}
+		httpapi.InternalServerError(c, "Failed to update approval policy")
+		return
+	}
+
+	httpapi.OK(c, mapApprovalPolicyToResponse(policy), "Approval policy updated successfully")
+}
+
+// respondWithDecisionError maps errors from approve/deny to responses
+func (h *ApprovalHandler) respondWithDecisionError(c *gin.Context, err error) {
+	if respondWithCatalogRejection(c, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, application.ErrInvocationNotFound):
+		httpapi.NotFound(c, "Invocation not found")
+	case errors.Is(err, application.ErrNotAwaitingApproval):
+		httpapi.RespondWithError(c, http.StatusConflict, "Invocation is not awaiting approval")
+	case errors.Is(err, application.ErrApprovalExpired):
+		httpapi.RespondWithError(c, http.StatusGone, "Approval window expired")
+	case errors.Is(err, application.ErrProviderNotFound):
+		httpapi.NotFound(c, "Provider not found")
+	case errors.Is(err, application.ErrOperationNotFound):
+		httpapi.NotFound(c, "Operation not found")
+	case errors.Is(err, application.ErrProviderAdapterNotFound):
+		httpapi.NotFound(c, "Provider adapter not found")
+	case errors.Is(err, application.ErrCredentialNotFound):
+		httpapi.Unauthorized(c, "Provider authentication required")
+	case errors.Is(err, application.ErrRateLimitExceeded):
+		httpapi.TooManyRequests(c, err.Error())
+	case errors.Is(err, application.ErrInsufficientPermissions):
+		respondWithInsufficientPermissions(c, err)
+	default:
+		httpapi.InternalServerError(c, utils.StringsBuilder("Failed to process approval: ", err.Error()))
+	}
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/context-space/context-space/backend/internal/integration/application"
+	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+	"github.com/context-space/context-space/backend/internal/shared/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// InsufficientPermissionsResponse describes the permissions an operation is missing and where to grant them
+type InsufficientPermissionsResponse struct {
+	ProviderIdentifier  string   `json:"provider_identifier"`
+	OperationIdentifier string   `json:"operation_identifier"`
+	MissingPermissions  []string `json:"missing_permissions"`
+	AuthorizationURL    string   `json:"authorization_url,omitempty"`
+	OAuthStateID        string   `json:"oauth_state_id,omitempty"`
+	// AuthorizationUnavailableReason explains why no authorization URL is returned
+	AuthorizationUnavailableReason string `json:"authorization_unavailable_reason,omitempty"`
+}
+
+// respondWithInsufficientPermissions writes a 403 with the missing permissions when err reports them
+func respondWithInsufficientPermissions(c *gin.Context, err error) bool {
+	var permissionsErr *application.InsufficientPermissionsError
+	if !errors.As(err, &permissionsErr) {
+		return false
+	}
+
+	httpapi.RespondWithErrorData(c, http.StatusForbidden,
+		utils.StringsBuilder("Missing required permissions for ", permissionsErr.ProviderIdentifier),
+		InsufficientPermissionsResponse{
+			ProviderIdentifier:             permissionsErr.ProviderIdentifier,
+			OperationIdentifier:            permissionsErr.OperationIdentifier,
+			MissingPermissions:             permissionsErr.MissingPermissions,
+			AuthorizationURL:               permissionsErr.AuthorizationURL,
+			OAuthStateID:                   permissionsErr.OAuthStateID,
+			AuthorizationUnavailableReason: permissionsErr.AuthorizationUnavailableReason,
+		},
+	)
+	return true
+}
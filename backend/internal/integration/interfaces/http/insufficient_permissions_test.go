@@ -0,0 +1,53 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/context-space/context-space/backend/internal/integration/application"
+)
+
+func TestRespondWithInsufficientPermissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("maps a wrapped permissions error to 403", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		err := fmt.Errorf("invoke: %w", &application.InsufficientPermissionsError{
+			ProviderIdentifier:             "github",
+			OperationIdentifier:            "create_issue",
+			MissingPermissions:             []string{"issues_write"},
+			AuthorizationUnavailableReason: application.AuthorizationUnavailableNotConfigured,
+		})
+
+		require.True(t, respondWithInsufficientPermissions(c, err))
+
+		var body struct {
+			Code int                             `json:"code"`
+			Data InsufficientPermissionsResponse `json:"data"`
+		}
+		require.NoError(t, sonic.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, http.StatusForbidden, body.Code)
+		assert.Equal(t, "github", body.Data.ProviderIdentifier)
+		assert.Equal(t, "create_issue", body.Data.OperationIdentifier)
+		assert.Equal(t, []string{"issues_write"}, body.Data.MissingPermissions)
+		assert.Empty(t, body.Data.AuthorizationURL)
+		assert.Equal(t, application.AuthorizationUnavailableNotConfigured, body.Data.AuthorizationUnavailableReason)
+	})
+
+	t.Run("leaves other errors to the caller", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+
+		assert.False(t, respondWithInsufficientPermissions(c, errors.New("adapter execution failed")))
+		assert.Zero(t, recorder.Body.Len())
+	})
+}
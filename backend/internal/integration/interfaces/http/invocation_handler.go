@@ -3,6 +3,7 @@ package http
 import (
 	"encoding/json"
 	"errors"
+	"net/http"
 	"strconv"
 	"time"
 
@@ -18,16 +19,19 @@ import (
 // InvocationHandler handles HTTP requests for invocations
 type InvocationHandler struct {
 	invocationService *application.InvocationService
+	quotaService      *application.QuotaService
 	obs               *observability.ObservabilityProvider
 }
 
 // NewInvocationHandler creates a new invocation handler
 func NewInvocationHandler(
 	invocationService *application.InvocationService,
+	quotaService *application.QuotaService,
 	observabilityProvider *observability.ObservabilityProvider,
 ) *InvocationHandler {
 	return &InvocationHandler{
 		invocationService: invocationService,
+		quotaService:      quotaService,
 		obs:               observabilityProvider,
 	}
 }
@@ -54,10 +58,30 @@ type InvocationResponse struct {
 	Parameters          map[string]interface{} `json:"parameters"`
 	ResponseData        json.RawMessage        `json:"response_data,omitempty"`
 	ErrorMessage        string                 `json:"error_message,omitempty"`
+	NextCursor          string                 `json:"next_cursor"`
+	Elided              []ElisionResponse      `json:"elided,omitempty"`
 	Duration            int64                  `json:"duration_ms"`
 	StartedAt           string                 `json:"started_at,omitempty"`
 	CompletedAt         string                 `json:"completed_at,omitempty"`
 	CreatedAt           string                 `json:"created_at"`
+	Approval            *ApprovalResponse      `json:"approval,omitempty"`
+}
+
+// ElisionResponse represents what response shaping removed at a path of the response data
+type ElisionResponse struct {
+	Path    string `json:"path"`
+	Kind    string `json:"kind"`
+	Omitted int    `json:"omitted"`
+}
+
+// ApprovalResponse represents the approval state of a gated invocation
+type ApprovalResponse struct {
+	RiskLevel          string                 `json:"risk_level"`
+	RequestedAt        string                 `json:"requested_at"`
+	ExpiresAt          string                 `json:"expires_at"`
+	DecidedAt          string                 `json:"decided_at,omitempty"`
+	Reason             string                 `json:"reason,omitempty"`
+	OriginalParameters map[string]interface{} `json:"original_parameters,omitempty"`
 }
 
 // ListInvocationsResponse represents the response for listing invocations
@@ -79,6 +103,31 @@ func mapInvocationToResponse(invocation *domain.Invocation, withResponseData boo
 	}
 	createdAt := invocation.CreatedAt.Format(time.RFC3339)
 
+	var approval *ApprovalResponse
+	if invocation.Approval != nil {
+		decidedAt := ""
+		if invocation.Approval.DecidedAt != nil {
+			decidedAt = invocation.Approval.DecidedAt.Format(time.RFC3339)
+		}
+		approval = &ApprovalResponse{
+			RiskLevel:          invocation.Approval.RiskLevel,
+			RequestedAt:        invocation.Approval.RequestedAt.Format(time.RFC3339),
+			ExpiresAt:          invocation.Approval.ExpiresAt.Format(time.RFC3339),
+			DecidedAt:          decidedAt,
+			Reason:             invocation.Approval.Reason,
+			OriginalParameters: invocation.Approval.OriginalParameters,
+		}
+	}
+
+	elided := make([]ElisionResponse, 0, len(invocation.Elisions))
+	for _, elision := range invocation.Elisions {
+		elided = append(elided, ElisionResponse{
+			Path:    elision.Path,
+			Kind:    string(elision.Kind),
+			Omitted: elision.Omitted,
+		})
+	}
+
 	responseData := json.RawMessage{}
 	if withResponseData {
 		responseData = invocation.ResponseData
@@ -93,10 +142,13 @@ func mapInvocationToResponse(invocation *domain.Invocation, withResponseData boo
 		Parameters:          invocation.Parameters,
 		ResponseData:        responseData,
 		ErrorMessage:        invocation.ErrorMessage,
+		NextCursor:          invocation.NextCursor,
+		Elided:              elided,
 		Duration:            invocation.Duration,
 		StartedAt:           startedAt,
 		CompletedAt:         completedAt,
 		CreatedAt:           createdAt,
+		Approval:            approval,
 	}, nil
 }
 
@@ -225,11 +277,40 @@ func (h *InvocationHandler) ListInvocationsByUser(c *gin.Context) {
 // InvokeRequest represents the request body for invoking an operation
 type InvokeRequest struct {
 	Parameters map[string]interface{} `json:"parameters"`
+	// Paginate follows several pages of a paginated operation and returns their merged items
+	Paginate *PaginateRequest `json:"paginate,omitempty"`
+	// Shape reduces the response data, what was removed is listed in elided
+	Shape *ShapeRequest `json:"shape,omitempty"`
+}
+
+// ShapeRequest represents how the response data of an invocation is reduced
+type ShapeRequest struct {
+	// Fields are the paths to keep, such as "items[*].name" or "$.user.login"
+	Fields []string `json:"fields,omitempty"`
+	// Compact keeps the default projection of the operation when fields is empty
+	Compact         bool `json:"compact,omitempty"`
+	MaxArrayItems   int  `json:"max_array_items,omitempty" binding:"omitempty,gte=1"`
+	MaxStringLength int  `json:"max_string_length,omitempty" binding:"omitempty,gte=1"`
+	MaxBytes        int  `json:"max_bytes,omitempty" binding:"omitempty,gte=1"`
+	// MaxTokens is estimated at 4 bytes of JSON per token
+	MaxTokens int `json:"max_tokens,omitempty" binding:"omitempty,gte=1"`
+}
+
+// PaginateRequest represents how many pages of a paginated operation an invocation follows
+type PaginateRequest struct {
+	// Cursor is the next_cursor of a previous invocation to continue from
+	Cursor string `json:"cursor,omitempty"`
+	// MaxItems stops fetching pages once this many items are collected, the last page is returned whole
+	// so that cursor resumes after every returned item
+	MaxItems int `json:"max_items,omitempty" binding:"omitempty,gte=1"`
+	MaxPages int `json:"max_pages,omitempty" binding:"omitempty,gte=1,lte=20"`
 }
 
 // InvokeOperation godoc
 // @Summary Invoke provider operation
-// @Description Executes an operation on a provider
+// @Description Executes an operation on a provider. Paginated operations report the cursor of their next page in next_cursor,
+// @Description and follow up to paginate.max_pages pages, merging their items, when paginate is given.
+// @Description The response data is projected, sliced, truncated and trimmed to a byte or token budget when shape is given
 // @Tags invocation
 // @Accept json
 // @Produce json
@@ -238,14 +319,18 @@ type InvokeRequest struct {
 // @Param operation_identifier path string true "Operation Identifier"
 // @Param request body InvokeRequest true "Invocation parameters"
 // @Success 200 {object} httpapi.Response{data=InvocationResponse} "Success response with invocation result"
-// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Success 202 {object} httpapi.Response{data=InvocationResponse} "Invocation is awaiting user approval"
+// @Failure 400 {object} httpapi.Response{data=InvalidParametersResponse} "Parameters do not match the operation schema"
 // @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 403 {object} httpapi.Response{data=InsufficientPermissionsResponse} "Missing permissions with an authorization URL to grant them"
 // @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 410 {object} httpapi.Response{data=ProviderUnavailableResponse} "Provider is deprecated"
 // @Failure 429 {object} httpapi.SwaggerErrorResponse "Rate limit exceeded error response"
 // @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Failure 503 {object} httpapi.Response{data=ProviderUnavailableResponse} "Provider is inactive or under maintenance"
 // @Router /invocations/{provider_identifier}/{operation_identifier} [post]
 func (h *InvocationHandler) InvokeOperation(c *gin.Context) {
-	ctx := c.Request.Context()
+	ctx := invocationContext(c)
 
 	userI, exists := c.Get("user")
 	if !exists {
@@ -264,6 +349,27 @@ func (h *InvocationHandler) InvokeOperation(c *gin.Context) {
 		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request format: ", err.Error()))
 		return
 	}
+	if req.Paginate != nil {
+		invocationCtx := application.InvocationContextFromContext(ctx)
+		invocationCtx.Paginate = &domain.InvocationPaginate{
+			Cursor:   req.Paginate.Cursor,
+			MaxItems: req.Paginate.MaxItems,
+			MaxPages: req.Paginate.MaxPages,
+		}
+		ctx = application.WithInvocationContext(ctx, invocationCtx)
+	}
+	if req.Shape != nil {
+		invocationCtx := application.InvocationContextFromContext(ctx)
+		invocationCtx.Shape = &domain.ResponseShape{
+			Fields:          req.Shape.Fields,
+			Compact:         req.Shape.Compact,
+			MaxArrayItems:   req.Shape.MaxArrayItems,
+			MaxStringLength: req.Shape.MaxStringLength,
+			MaxBytes:        req.Shape.MaxBytes,
+			MaxTokens:       req.Shape.MaxTokens,
+		}
+		ctx = application.WithInvocationContext(ctx, invocationCtx)
+	}
 
 	// Invoke the operation
 	invocation, err := h.invocationService.InvokeOperation(
@@ -273,8 +379,13 @@ func (h *InvocationHandler) InvokeOperation(c *gin.Context) {
 		operationIdentifier,
 		req.Parameters,
 	)
+	setRateLimitHeaders(c, ctx, h.quotaService, user.ID, providerIdentifier, operationIdentifier, err)
 
 	if err != nil {
+		if respondWithCatalogRejection(c, err) {
+			return
+		}
+
 		// Handle different error types
 		switch {
 		case errors.Is(err, application.ErrProviderNotFound):
@@ -288,7 +399,9 @@ func (h *InvocationHandler) InvokeOperation(c *gin.Context) {
 		case errors.Is(err, application.ErrCredentialNotFound):
 			httpapi.Unauthorized(c, "Provider authentication required")
 		case errors.Is(err, application.ErrRateLimitExceeded):
-			httpapi.TooManyRequests(c, "Rate limit exceeded")
+			httpapi.TooManyRequests(c, err.Error())
+		case errors.Is(err, application.ErrInsufficientPermissions):
+			respondWithInsufficientPermissions(c, err)
 		case errors.Is(err, application.ErrAdapterExecuteFailed):
 			httpapi.InternalServerError(c, utils.StringsBuilder("Failed to invoke operation: ", err.Error()))
 		default:
@@ -304,5 +417,10 @@ func (h *InvocationHandler) InvokeOperation(c *gin.Context) {
 		return
 	}
 
+	if invocation.IsAwaitingApproval() {
+		httpapi.RespondWithSuccess(c, http.StatusAccepted, response, "Operation is awaiting approval")
+		return
+	}
+
 	httpapi.OK(c, response, "Operation invoked successfully")
 }
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/context-space/context-space/backend/internal/integration/application"
+	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+	"github.com/context-space/context-space/backend/internal/shared/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// ProviderUnavailableResponse describes why a provider cannot be invoked and when it is expected back
+type ProviderUnavailableResponse struct {
+	ProviderIdentifier  string `json:"provider_identifier"`
+	Status              string `json:"status"`
+	MaintenanceMessage  string `json:"maintenance_message,omitempty"`
+	MaintenanceStartsAt int64  `json:"maintenance_starts_at,omitempty"`
+	MaintenanceEndsAt   int64  `json:"maintenance_ends_at,omitempty"`
+}
+
+// ParameterErrorResponse describes why a single parameter was rejected
+type ParameterErrorResponse struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// InvalidParametersResponse lists every parameter that does not match the operation schema
+type InvalidParametersResponse struct {
+	ProviderIdentifier  string                   `json:"provider_identifier"`
+	OperationIdentifier string                   `json:"operation_identifier"`
+	Errors              []ParameterErrorResponse `json:"errors"`
+}
+
+// respondWithCatalogRejection writes a 410, 503 or 400 with details when err comes from catalog gating
+func respondWithCatalogRejection(c *gin.Context, err error) bool {
+	var unavailableErr *application.ProviderUnavailableError
+	if errors.As(err, &unavailableErr) {
+		response := ProviderUnavailableResponse{
+			ProviderIdentifier: unavailableErr.ProviderIdentifier,
+			Status:             unavailableErr.Status,
+			MaintenanceMessage: unavailableErr.Message,
+		}
+		if unavailableErr.StartsAt != nil {
+			response.MaintenanceStartsAt = unavailableErr.StartsAt.Unix()
+		}
+		if unavailableErr.EndsAt != nil {
+			response.MaintenanceEndsAt = unavailableErr.EndsAt.Unix()
+			if wait := time.Until(*unavailableErr.EndsAt); wait > 0 {
+				c.Header("Retry-After", strconv.FormatInt(int64(math.Ceil(wait.Seconds())), 10))
+			}
+		}
+
+		if errors.Is(err, application.ErrProviderDeprecated) {
+			httpapi.RespondWithErrorData(c, http.StatusGone,
+				utils.StringsBuilder("Provider ", unavailableErr.ProviderIdentifier, " is deprecated"), response)
+			return true
+		}
+		message := utils.StringsBuilder("Provider ", unavailableErr.ProviderIdentifier, " is unavailable")
+		if unavailableErr.Message != "" {
+			message = utils.StringsBuilder(message, ": ", unavailableErr.Message)
+		}
+		httpapi.RespondWithErrorData(c, http.StatusServiceUnavailable, message, response)
+		return true
+	}
+
+	var parametersErr *application.InvalidParametersError
+	if errors.As(err, &parametersErr) {
+		fields := make([]ParameterErrorResponse, 0, len(parametersErr.Fields))
+		for _, field := range parametersErr.Fields {
+			fields = append(fields, ParameterErrorResponse{Field: field.Field, Message: field.Message})
+		}
+		httpapi.RespondWithErrorData(c, http.StatusBadRequest,
+			utils.StringsBuilder("Invalid parameters for ", parametersErr.ProviderIdentifier, ".", parametersErr.OperationIdentifier),
+			InvalidParametersResponse{
+				ProviderIdentifier:  parametersErr.ProviderIdentifier,
+				OperationIdentifier: parametersErr.OperationIdentifier,
+				Errors:              fields,
+			},
+		)
+		return true
+	}
+
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"net/http"
 	"strings"
 
 	observability "github.com/context-space/cloud-observability"
@@ -28,8 +29,23 @@ type CallToolRequest struct {
 
 // CallToolResponse defines the response structure for the call_tool endpoint.
 type CallToolResponse struct {
-	ToolResult json.RawMessage `json:"tool_result,omitempty"`
-	Error      string          `json:"error,omitempty"` // Error message if the tool call failed
+	ToolResult   json.RawMessage `json:"tool_result,omitempty"`
+	Error        string          `json:"error,omitempty"`         // Error message if the tool call failed
+	InvocationID string          `json:"invocation_id,omitempty"` // Set when the call is held for approval
+	Status       string          `json:"status,omitempty"`        // Set when the call is held for approval
+	NextCursor   string          `json:"next_cursor,omitempty"`   // Set when a paginated tool has more pages
+	Elided       []ElisionResponse `json:"elided,omitempty"`      // What response shaping removed from the tool result
+}
+
+// CallToolShapeQuery defines the query parameters reducing the result of a tool call.
+// Tool results use the compact projection of the operation unless fields are given or full is set.
+type CallToolShapeQuery struct {
+	Fields          string `form:"fields"` // Comma-separated paths to keep, such as "items[*].name"
+	Full            bool   `form:"full"`
+	MaxArrayItems   int    `form:"max_array_items" binding:"omitempty,gte=1"`
+	MaxStringLength int    `form:"max_string_length" binding:"omitempty,gte=1"`
+	MaxBytes        int    `form:"max_bytes" binding:"omitempty,gte=1"`
+	MaxTokens       int    `form:"max_tokens" binding:"omitempty,gte=1"`
 }
 
 // ListToolsRequest defines the request body for the list_tools endpoint.
@@ -88,6 +104,7 @@ type NewListToolsResponse struct {
 // McpHandler handles HTTP requests for MCP (Meta Call Protocol) endpoints.
 type McpHandler struct {
 	invocationService *application.InvocationService
+	quotaService      *application.QuotaService
 	providerService   *providercoreApp.ProviderService
 	obs               *observability.ObservabilityProvider
 }
@@ -95,11 +112,13 @@ type McpHandler struct {
 // NewMcpHandler creates a new McpHandler.
 func NewMcpHandler(
 	invocationService *application.InvocationService,
+	quotaService *application.QuotaService,
 	providerService *providercoreApp.ProviderService,
 	observabilityProvider *observability.ObservabilityProvider,
 ) *McpHandler {
 	return &McpHandler{
 		invocationService: invocationService,
+		quotaService:      quotaService,
 		providerService:   providerService,
 		obs:               observabilityProvider,
 	}
@@ -128,15 +147,25 @@ func (h *McpHandler) RegisterRoutes(router *gin.RouterGroup, requireAuth gin.Han
 // @Param provider_identifier path string true "Identifier of the provider (e.g., 'gmail')"
 // @Param operation_identifier path string true "Identifier of the operation (e.g., 'sendEmail')"
 // @Param request_body body map[string]interface{} true "Input parameters for the tool method"
+// @Param fields query string false "Comma-separated paths of the tool result to keep, replacing the compact projection of the operation"
+// @Param full query bool false "Return the full tool result instead of the compact projection of the operation"
+// @Param max_array_items query int false "Keep the first items of every array"
+// @Param max_string_length query int false "Keep the first characters of every string"
+// @Param max_bytes query int false "Byte budget of the tool result"
+// @Param max_tokens query int false "Token budget of the tool result, estimated at 4 bytes per token"
 // @Success 200 {object} httpapi.Response{data=CallToolResponse} "Success response with tool execution result"
-// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request if input is invalid"
+// @Success 202 {object} httpapi.Response{data=CallToolResponse} "Tool call is awaiting user approval"
+// @Failure 400 {object} httpapi.Response{data=InvalidParametersResponse} "Bad request if input does not match the tool schema"
 // @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized if JWT is missing or invalid"
-// @Failure 403 {object} httpapi.SwaggerErrorResponse "Forbidden if user does not have credential or permission"
+// @Failure 403 {object} httpapi.Response{data=InsufficientPermissionsResponse} "Forbidden if user does not have credential or permission"
 // @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found if provider or operation does not exist"
+// @Failure 410 {object} httpapi.Response{data=ProviderUnavailableResponse} "Provider is deprecated"
+// @Failure 429 {object} httpapi.SwaggerErrorResponse "Quota exceeded"
 // @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error or tool execution error"
+// @Failure 503 {object} httpapi.Response{data=ProviderUnavailableResponse} "Provider is inactive or under maintenance"
 // @Router /mcp/call_tool/{provider_identifier}/{operation_identifier} [post]
 func (h *McpHandler) HandleMcpCallTool(c *gin.Context) {
-	ctx := c.Request.Context()
+	ctx := invocationContext(c)
 	logger := h.obs.Logger.With(zap.String("handler", "McpHandler"), zap.String("method", "HandleMcpCallTool"))
 
 	// 1. Get User from context (set by require_auth middleware)
@@ -188,10 +217,20 @@ func (h *McpHandler) HandleMcpCallTool(c *gin.Context) {
 	}
 	logger.Info(ctx, "Received CallTool request", zap.Any("input_keys", keys(params)))
 
+	var shapeQuery CallToolShapeQuery
+	if err := c.ShouldBindQuery(&shapeQuery); err != nil {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid response shaping parameters: ", err.Error()))
+		return
+	}
+	invocationCtx := application.InvocationContextFromContext(ctx)
+	invocationCtx.Shape = shapeQuery.toResponseShape()
+	ctx = application.WithInvocationContext(ctx, invocationCtx)
+
 	// 4. Call InvocationService
 	// Note: domain.Invocation might be returned even if err is not nil, e.g. if adapter execution fails
 	// but the invocation record itself was created.
 	invocation, err := h.invocationService.InvokeOperation(ctx, userID, providerIdentifier, operationIdentifier, params) // Use params directly
+	setRateLimitHeaders(c, ctx, h.quotaService, userID, providerIdentifier, operationIdentifier, err)
 
 	// Handle errors from InvokeOperation or failed invocation status
 	if err != nil {
@@ -207,6 +246,19 @@ func (h *McpHandler) HandleMcpCallTool(c *gin.Context) {
 			httpapi.Forbidden(c, utils.StringsBuilder("Access denied: missing or invalid credentials for ", providerIdentifier))
 			return
 		}
+		if respondWithCatalogRejection(c, err) {
+			logger.Info(ctx, "Tool call rejected by the provider catalog", zap.Error(err))
+			return
+		}
+		if respondWithInsufficientPermissions(c, err) {
+			logger.Info(ctx, "Tool call rejected for missing permissions", zap.Error(err))
+			return
+		}
+		if errors.Is(err, application.ErrRateLimitExceeded) {
+			logger.Info(ctx, "Tool call rejected by quota", zap.Error(err))
+			httpapi.TooManyRequests(c, err.Error())
+			return
+		}
 		if errors.Is(err, application.ErrInvalidParameters) {
 			logger.Warn(ctx, "Invalid parameters reported by InvocationService", zap.Error(err))
 			httpapi.BadRequest(c, utils.StringsBuilder("Invalid parameters for tool: ", err.Error()))
@@ -254,15 +306,50 @@ func (h *McpHandler) HandleMcpCallTool(c *gin.Context) {
 		return
 	}
 
+	// The call was held by the user's approval policy, the agent can poll the invocation
+	if invocation.IsAwaitingApproval() {
+		logger.Info(ctx, "Tool call is awaiting approval", zap.String("invocationID", invocation.ID))
+		httpapi.RespondWithSuccess(c, http.StatusAccepted, CallToolResponse{
+			InvocationID: invocation.ID,
+			Status:       string(invocation.Status),
+		}, "Tool call is awaiting user approval")
+		return
+	}
+
 	// 5. Prepare response if successful
 	response := CallToolResponse{
 		ToolResult: invocation.ResponseData, // This is json.RawMessage
+		NextCursor: invocation.NextCursor,
+	}
+	for _, elision := range invocation.Elisions {
+		response.Elided = append(response.Elided, ElisionResponse{
+			Path:    elision.Path,
+			Kind:    string(elision.Kind),
+			Omitted: elision.Omitted,
+		})
 	}
 
 	logger.Info(ctx, "Successfully called tool", zap.String("invocationID", invocation.ID))
 	httpapi.OK(c, response, "Tool called successfully")
 }
 
+// toResponseShape converts the query into the shape of the tool result
+func (q CallToolShapeQuery) toResponseShape() *integrationDomain.ResponseShape {
+	shape := &integrationDomain.ResponseShape{
+		Compact:         !q.Full,
+		MaxArrayItems:   q.MaxArrayItems,
+		MaxStringLength: q.MaxStringLength,
+		MaxBytes:        q.MaxBytes,
+		MaxTokens:       q.MaxTokens,
+	}
+	for _, field := range strings.Split(q.Fields, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			shape.Fields = append(shape.Fields, field)
+		}
+	}
+	return shape
+}
+
 // HandleMcpListTools godoc
 // @Summary List available tools (Provider Operations)
 // @Description Retrieves a list of tools that the MCP client can call.
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	identityDomain "github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/integration/application"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// Rate limit response headers
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+	headerRetryAfter         = "Retry-After"
+)
+
+// invocationContext returns the request context carrying the invocation context of the authenticated request
+func invocationContext(c *gin.Context) context.Context {
+	invocationCtx := application.InvocationContext{}
+	if apiKeyI, exists := c.Get("api_key"); exists {
+		if apiKey, ok := apiKeyI.(*identityDomain.APIKey); ok && apiKey != nil {
+			invocationCtx.APIKeyID = apiKey.ID
+		}
+	}
+	invocationCtx.OrganizationID = c.GetString("organization_id")
+	if userI, exists := c.Get("user"); exists {
+		if user, ok := userI.(*identityDomain.User); ok && user != nil {
+			invocationCtx.ServiceAccount = user.IsServiceAccount
+		}
+	}
+	return application.WithInvocationContext(c.Request.Context(), invocationCtx)
+}
+
+// setRateLimitHeaders reports the most restrictive quota of an invocation in the response headers
+func setRateLimitHeaders(
+	c *gin.Context,
+	ctx context.Context,
+	quotaService *application.QuotaService,
+	userID string,
+	providerIdentifier string,
+	operationIdentifier string,
+	invokeErr error,
+) {
+	var quotaErr *application.QuotaExceededError
+	if errors.As(invokeErr, &quotaErr) {
+		writeRateLimitHeaders(c, &quotaErr.Usage)
+		retryAfter := int64(time.Until(quotaErr.Usage.ResetAt).Seconds()) + 1
+		c.Header(headerRetryAfter, strconv.FormatInt(retryAfter, 10))
+		return
+	}
+
+	apiKeyID := application.InvocationContextFromContext(ctx).APIKeyID
+	status, err := quotaService.Status(ctx, userID, apiKeyID, providerIdentifier, operationIdentifier)
+	if err != nil || status == nil {
+		return
+	}
+	writeRateLimitHeaders(c, status)
+}
+
+// writeRateLimitHeaders writes the limit, remaining count and reset time of a quota
+func writeRateLimitHeaders(c *gin.Context, usage *domain.QuotaUsage) {
+	c.Header(headerRateLimitLimit, strconv.FormatInt(usage.Limit.Limit, 10))
+	c.Header(headerRateLimitRemaining, strconv.FormatInt(usage.Remaining(), 10))
+	c.Header(headerRateLimitReset, strconv.FormatInt(usage.ResetAt.Unix(), 10))
+}
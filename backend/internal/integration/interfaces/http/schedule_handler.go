@@ -0,0 +1,474 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	identityDomain "github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/integration/application"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+	"github.com/context-space/context-space/backend/internal/shared/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ScheduleHandler handles HTTP requests for scheduled invocations
+type ScheduleHandler struct {
+	scheduleService *application.ScheduleService
+	obs             *observability.ObservabilityProvider
+}
+
+// NewScheduleHandler creates a new schedule handler
+func NewScheduleHandler(
+	scheduleService *application.ScheduleService,
+	observabilityProvider *observability.ObservabilityProvider,
+) *ScheduleHandler {
+	return &ScheduleHandler{
+		scheduleService: scheduleService,
+		obs:             observabilityProvider,
+	}
+}
+
+// RegisterRoutes registers the routes for this handler
+func (h *ScheduleHandler) RegisterRoutes(router *gin.RouterGroup, requireAuth gin.HandlerFunc) {
+	schedules := router.Group("/schedules")
+	schedules.Use(requireAuth)
+	{
+		schedules.GET("", h.ListSchedules)
+		schedules.POST("", h.CreateSchedule)
+		schedules.GET("/:schedule_id", h.GetSchedule)
+		schedules.PATCH("/:schedule_id", h.UpdateSchedule)
+		schedules.DELETE("/:schedule_id", h.DeleteSchedule)
+		schedules.POST("/:schedule_id/pause", h.PauseSchedule)
+		schedules.POST("/:schedule_id/resume", h.ResumeSchedule)
+		schedules.GET("/:schedule_id/runs", h.ListRuns)
+	}
+}
+
+// CreateScheduleRequest represents the request body for creating a schedule
+type CreateScheduleRequest struct {
+	Name                string                 `json:"name" binding:"required"`
+	ProviderIdentifier  string                 `json:"provider_identifier" binding:"required"`
+	OperationIdentifier string                 `json:"operation_identifier" binding:"required"`
+	Parameters          map[string]interface{} `json:"parameters"`
+	// CronExpression is a standard 5-field cron expression or a descriptor such as @daily
+	CronExpression string `json:"cron_expression" binding:"required" example:"0 9 * * 1-5"`
+	// Timezone is an IANA timezone, UTC when empty
+	Timezone string `json:"timezone,omitempty" example:"Europe/Berlin"`
+}
+
+// UpdateScheduleRequest represents the request body for updating a schedule
+type UpdateScheduleRequest struct {
+	Name           *string                 `json:"name,omitempty"`
+	Parameters     *map[string]interface{} `json:"parameters,omitempty"`
+	CronExpression *string                 `json:"cron_expression,omitempty"`
+	Timezone       *string                 `json:"timezone,omitempty"`
+}
+
+// ScheduleResponse represents a schedule in responses
+type ScheduleResponse struct {
+	ID                  string                 `json:"id"`
+	OrganizationID      string                 `json:"organization_id,omitempty"`
+	Name                string                 `json:"name"`
+	ProviderIdentifier  string                 `json:"provider_identifier"`
+	OperationIdentifier string                 `json:"operation_identifier"`
+	Parameters          map[string]interface{} `json:"parameters"`
+	CronExpression      string                 `json:"cron_expression"`
+	Timezone            string                 `json:"timezone"`
+	Status              string                 `json:"status" enums:"active,paused"`
+	NextRunAt           string                 `json:"next_run_at,omitempty"`
+	LastRunAt           string                 `json:"last_run_at,omitempty"`
+	LastRunStatus       string                 `json:"last_run_status,omitempty" enums:"succeeded,failed,awaiting_approval"`
+	ConsecutiveFailures int                    `json:"consecutive_failures"`
+	CreatedAt           string                 `json:"created_at"`
+	UpdatedAt           string                 `json:"updated_at"`
+}
+
+// ListSchedulesResponse represents the response for listing schedules
+type ListSchedulesResponse struct {
+	Schedules []ScheduleResponse `json:"schedules"`
+}
+
+// ScheduleRunResponse represents a run of a schedule in responses
+type ScheduleRunResponse struct {
+	ID         string `json:"id"`
+	ScheduleID string `json:"schedule_id"`
+	// InvocationID links the run to its invocation, empty when the run failed before invoking
+	InvocationID string `json:"invocation_id,omitempty"`
+	Status       string `json:"status" enums:"succeeded,failed,awaiting_approval"`
+	Error        string `json:"error,omitempty"`
+	ScheduledFor string `json:"scheduled_for"`
+	StartedAt    string `json:"started_at"`
+	CompletedAt  string `json:"completed_at"`
+}
+
+// ListScheduleRunsResponse represents the response for listing the runs of a schedule
+type ListScheduleRunsResponse struct {
+	Runs []ScheduleRunResponse `json:"runs"`
+}
+
+// mapScheduleToResponse maps a domain schedule to a response
+func mapScheduleToResponse(schedule *domain.Schedule) ScheduleResponse {
+	response := ScheduleResponse{
+		ID:                  schedule.ID,
+		OrganizationID:      schedule.OrganizationID,
+		Name:                schedule.Name,
+		ProviderIdentifier:  schedule.ProviderIdentifier,
+		OperationIdentifier: schedule.OperationIdentifier,
+		Parameters:          schedule.Parameters,
+		CronExpression:      schedule.CronExpression,
+		Timezone:            schedule.Timezone,
+		Status:              string(schedule.Status),
+		LastRunStatus:       string(schedule.LastRunStatus),
+		ConsecutiveFailures: schedule.ConsecutiveFailures,
+		CreatedAt:           schedule.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:           schedule.UpdatedAt.Format(time.RFC3339),
+	}
+	if schedule.NextRunAt != nil {
+		response.NextRunAt = schedule.NextRunAt.Format(time.RFC3339)
+	}
+	if schedule.LastRunAt != nil {
+		response.LastRunAt = schedule.LastRunAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+// mapScheduleRunToResponse maps a domain schedule run to a response
+func mapScheduleRunToResponse(run *domain.ScheduleRun) ScheduleRunResponse {
+	return ScheduleRunResponse{
+		ID:           run.ID,
+		ScheduleID:   run.ScheduleID,
+		InvocationID: run.InvocationID,
+		Status:       string(run.Status),
+		Error:        run.Error,
+		ScheduledFor: run.ScheduledFor.Format(time.RFC3339),
+		StartedAt:    run.StartedAt.Format(time.RFC3339),
+		CompletedAt:  run.CompletedAt.Format(time.RFC3339),
+	}
+}
+
+// CreateSchedule godoc
+// @Summary Create schedule
+// @Description Creates a schedule running a provider operation with fixed parameters under the credentials of the authenticated user. Runs must be at least 5 minutes apart
+// @Tags schedule
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateScheduleRequest true "Schedule"
+// @Success 201 {object} httpapi.Response{data=ScheduleResponse} "Success response with the schedule"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Provider or operation not found"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /schedules [post]
+func (h *ScheduleHandler) CreateSchedule(c *gin.Context) {
+	// The schedule keeps the organization and API key of the request for its runs
+	ctx := invocationContext(c)
+
+	user, ok := currentScheduleUser(c)
+	if !ok {
+		return
+	}
+
+	var req CreateScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request format: ", err.Error()))
+		return
+	}
+
+	schedule, err := h.scheduleService.CreateSchedule(ctx, user.ID, application.ScheduleInput{
+		Name:                req.Name,
+		ProviderIdentifier:  req.ProviderIdentifier,
+		OperationIdentifier: req.OperationIdentifier,
+		Parameters:          req.Parameters,
+		CronExpression:      req.CronExpression,
+		Timezone:            req.Timezone,
+	})
+	if err != nil {
+		h.respondWithScheduleError(c, err, "Failed to create schedule")
+		return
+	}
+
+	httpapi.Created(c, mapScheduleToResponse(schedule), "Schedule created successfully")
+}
+
+// ListSchedules godoc
+// @Summary List schedules
+// @Description Lists the schedules of the authenticated user
+// @Tags schedule
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} httpapi.Response{data=ListSchedulesResponse} "Success response with schedules"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /schedules [get]
+func (h *ScheduleHandler) ListSchedules(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentScheduleUser(c)
+	if !ok {
+		return
+	}
+
+	schedules, err := h.scheduleService.ListSchedules(ctx, user.ID)
+	if err != nil {
+		httpapi.InternalServerError(c, "Failed to list schedules")
+		return
+	}
+
+	responses := make([]ScheduleResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		responses = append(responses, mapScheduleToResponse(schedule))
+	}
+
+	httpapi.OK(c, ListSchedulesResponse{Schedules: responses}, "Schedules retrieved successfully")
+}
+
+// GetSchedule godoc
+// @Summary Get schedule
+// @Description Gets a schedule of the authenticated user
+// @Tags schedule
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param schedule_id path string true "Schedule ID"
+// @Success 200 {object} httpapi.Response{data=ScheduleResponse} "Success response with the schedule"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /schedules/{schedule_id} [get]
+func (h *ScheduleHandler) GetSchedule(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentScheduleUser(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.scheduleService.GetSchedule(ctx, user.ID, c.Param("schedule_id"))
+	if err != nil {
+		h.respondWithScheduleError(c, err, "Failed to get schedule")
+		return
+	}
+
+	httpapi.OK(c, mapScheduleToResponse(schedule), "Schedule retrieved successfully")
+}
+
+// UpdateSchedule godoc
+// @Summary Update schedule
+// @Description Updates the name, parameters, cron expression or timezone of a schedule. A new timing takes effect from the next run
+// @Tags schedule
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param schedule_id path string true "Schedule ID"
+// @Param request body UpdateScheduleRequest true "Fields to update"
+// @Success 200 {object} httpapi.Response{data=ScheduleResponse} "Success response with the updated schedule"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /schedules/{schedule_id} [patch]
+func (h *ScheduleHandler) UpdateSchedule(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentScheduleUser(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request format: ", err.Error()))
+		return
+	}
+
+	schedule, err := h.scheduleService.UpdateSchedule(ctx, user.ID, c.Param("schedule_id"), application.ScheduleUpdate{
+		Name:           req.Name,
+		Parameters:     req.Parameters,
+		CronExpression: req.CronExpression,
+		Timezone:       req.Timezone,
+	})
+	if err != nil {
+		h.respondWithScheduleError(c, err, "Failed to update schedule")
+		return
+	}
+
+	httpapi.OK(c, mapScheduleToResponse(schedule), "Schedule updated successfully")
+}
+
+// DeleteSchedule godoc
+// @Summary Delete schedule
+// @Description Deletes a schedule, the invocations of its past runs are kept
+// @Tags schedule
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param schedule_id path string true "Schedule ID"
+// @Success 204 "No content"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /schedules/{schedule_id} [delete]
+func (h *ScheduleHandler) DeleteSchedule(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentScheduleUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.scheduleService.DeleteSchedule(ctx, user.ID, c.Param("schedule_id")); err != nil {
+		h.respondWithScheduleError(c, err, "Failed to delete schedule")
+		return
+	}
+
+	httpapi.NoContent(c)
+}
+
+// PauseSchedule godoc
+// @Summary Pause schedule
+// @Description Stops a schedule from running until it is resumed
+// @Tags schedule
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param schedule_id path string true "Schedule ID"
+// @Success 200 {object} httpapi.Response{data=ScheduleResponse} "Success response with the paused schedule"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /schedules/{schedule_id}/pause [post]
+func (h *ScheduleHandler) PauseSchedule(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentScheduleUser(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.scheduleService.PauseSchedule(ctx, user.ID, c.Param("schedule_id"))
+	if err != nil {
+		h.respondWithScheduleError(c, err, "Failed to pause schedule")
+		return
+	}
+
+	httpapi.OK(c, mapScheduleToResponse(schedule), "Schedule paused successfully")
+}
+
+// ResumeSchedule godoc
+// @Summary Resume schedule
+// @Description Resumes a paused schedule from its next run time and resets its failure count. Runs missed while it was paused are skipped
+// @Tags schedule
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param schedule_id path string true "Schedule ID"
+// @Success 200 {object} httpapi.Response{data=ScheduleResponse} "Success response with the resumed schedule"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /schedules/{schedule_id}/resume [post]
+func (h *ScheduleHandler) ResumeSchedule(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentScheduleUser(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.scheduleService.ResumeSchedule(ctx, user.ID, c.Param("schedule_id"))
+	if err != nil {
+		h.respondWithScheduleError(c, err, "Failed to resume schedule")
+		return
+	}
+
+	httpapi.OK(c, mapScheduleToResponse(schedule), "Schedule resumed successfully")
+}
+
+// ListRuns godoc
+// @Summary List schedule runs
+// @Description Lists the runs of a schedule of the authenticated user, newest first, with the invocation each run made
+// @Tags schedule
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param schedule_id path string true "Schedule ID"
+// @Param limit query int false "Limit (default: 10)"
+// @Param offset query int false "Offset (default: 0)"
+// @Success 200 {object} httpapi.Response{data=ListScheduleRunsResponse} "Success response with schedule runs"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /schedules/{schedule_id}/runs [get]
+func (h *ScheduleHandler) ListRuns(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentScheduleUser(c)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	offset := 0
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+	if offsetParam := c.Query("offset"); offsetParam != "" {
+		if parsedOffset, err := strconv.Atoi(offsetParam); err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	runs, err := h.scheduleService.ListRuns(ctx, user.ID, c.Param("schedule_id"), limit, offset)
+	if err != nil {
+		h.respondWithScheduleError(c, err, "Failed to list schedule runs")
+		return
+	}
+
+	responses := make([]ScheduleRunResponse, 0, len(runs))
+	for _, run := range runs {
+		responses = append(responses, mapScheduleRunToResponse(run))
+	}
+
+	httpapi.OK(c, ListScheduleRunsResponse{Runs: responses}, "Schedule runs retrieved successfully")
+}
+
+// currentScheduleUser returns the authenticated user, responding with 401 when there is none
+func currentScheduleUser(c *gin.Context) (*identityDomain.User, bool) {
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return nil, false
+	}
+	return userI.(*identityDomain.User), true
+}
+
+// respondWithScheduleError maps schedule errors to HTTP responses
+func (h *ScheduleHandler) respondWithScheduleError(c *gin.Context, err error, fallback string) {
+	if respondWithCatalogRejection(c, err) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrScheduleNotFound):
+		httpapi.NotFound(c, "Schedule not found")
+	case errors.Is(err, application.ErrProviderNotFound):
+		httpapi.NotFound(c, "Provider not found")
+	case errors.Is(err, application.ErrOperationNotFound):
+		httpapi.NotFound(c, "Operation not found")
+	case errors.Is(err, domain.ErrInvalidSchedule),
+		errors.Is(err, domain.ErrValidation),
+		errors.Is(err, application.ErrInvalidParameters):
+		httpapi.BadRequest(c, err.Error())
+	default:
+		h.obs.Logger.Error(c.Request.Context(), fallback, zap.Error(err))
+		httpapi.InternalServerError(c, fallback)
+	}
+}
@@ -0,0 +1,162 @@
+package http
+
+import (
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	identityDomain "github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/integration/application"
+	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// defaultUsageDays is the number of days reported when no range is given
+	defaultUsageDays = 30
+	// maxUsageDays bounds the range of a usage report
+	maxUsageDays = 366
+)
+
+// UsageHandler handles HTTP requests for invocation usage
+type UsageHandler struct {
+	quotaService *application.QuotaService
+	obs          *observability.ObservabilityProvider
+}
+
+// NewUsageHandler creates a new usage handler
+func NewUsageHandler(
+	quotaService *application.QuotaService,
+	observabilityProvider *observability.ObservabilityProvider,
+) *UsageHandler {
+	return &UsageHandler{
+		quotaService: quotaService,
+		obs:          observabilityProvider,
+	}
+}
+
+// RegisterRoutes registers the routes for this handler
+func (h *UsageHandler) RegisterRoutes(router *gin.RouterGroup, requireAuth gin.HandlerFunc) {
+	users := router.Group("/users")
+	users.Use(requireAuth)
+	{
+		users.GET("/me/usage", h.GetUsage)
+	}
+}
+
+// QuotaResponse represents the state of a quota in its current window
+type QuotaResponse struct {
+	Scope     string `json:"scope"`
+	Window    string `json:"window"`
+	Limit     int64  `json:"limit"`
+	Used      int64  `json:"used"`
+	Remaining int64  `json:"remaining"`
+	ResetAt   string `json:"reset_at"`
+}
+
+// DailyUsageResponse represents the invocation count of an operation on one day
+type DailyUsageResponse struct {
+	Day                 string `json:"day"`
+	APIKeyID            string `json:"api_key_id,omitempty"`
+	ProviderIdentifier  string `json:"provider_identifier"`
+	OperationIdentifier string `json:"operation_identifier"`
+	Count               int64  `json:"count"`
+}
+
+// UsageResponse represents the usage report of a user
+type UsageResponse struct {
+	From   string               `json:"from"`
+	To     string               `json:"to"`
+	Quotas []QuotaResponse      `json:"quotas"`
+	Daily  []DailyUsageResponse `json:"daily"`
+}
+
+// GetUsage godoc
+// @Summary Get usage
+// @Description Gets the current quotas and the daily invocation usage of the authenticated user
+// @Tags usage
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day of the report (YYYY-MM-DD, default: 30 days ago)"
+// @Param to query string false "Last day of the report (YYYY-MM-DD, default: today)"
+// @Success 200 {object} httpapi.Response{data=UsageResponse} "Success response with usage"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /users/me/usage [get]
+func (h *UsageHandler) GetUsage(c *gin.Context) {
+	ctx := invocationContext(c)
+
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return
+	}
+	user := userI.(*identityDomain.User)
+
+	to := time.Now().UTC()
+	if toParam := c.Query("to"); toParam != "" {
+		parsed, err := time.Parse(time.DateOnly, toParam)
+		if err != nil {
+			httpapi.BadRequest(c, "Invalid to date, expected YYYY-MM-DD")
+			return
+		}
+		to = parsed
+	}
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	from := to.AddDate(0, 0, -(defaultUsageDays - 1))
+	if fromParam := c.Query("from"); fromParam != "" {
+		parsed, err := time.Parse(time.DateOnly, fromParam)
+		if err != nil {
+			httpapi.BadRequest(c, "Invalid from date, expected YYYY-MM-DD")
+			return
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		httpapi.BadRequest(c, "from must not be after to")
+		return
+	}
+	if to.Sub(from) > maxUsageDays*24*time.Hour {
+		httpapi.BadRequest(c, "Usage range must not exceed 366 days")
+		return
+	}
+
+	usage, err := h.quotaService.GetUsage(ctx, user.ID, from, to)
+	if err != nil {
+		httpapi.InternalServerError(c, "Failed to get usage")
+		return
+	}
+
+	quotas := make([]QuotaResponse, 0, len(usage.Quotas))
+	for _, quota := range usage.Quotas {
+		quotas = append(quotas, QuotaResponse{
+			Scope:     string(quota.Limit.Scope),
+			Window:    string(quota.Limit.Window),
+			Limit:     quota.Limit.Limit,
+			Used:      quota.Used,
+			Remaining: quota.Remaining(),
+			ResetAt:   quota.ResetAt.Format(time.RFC3339),
+		})
+	}
+
+	daily := make([]DailyUsageResponse, 0, len(usage.Daily))
+	for _, record := range usage.Daily {
+		daily = append(daily, DailyUsageResponse{
+			Day:                 record.Day.Format(time.DateOnly),
+			APIKeyID:            record.APIKeyID,
+			ProviderIdentifier:  record.ProviderIdentifier,
+			OperationIdentifier: record.OperationIdentifier,
+			Count:               record.Count,
+		})
+	}
+
+	httpapi.OK(c, UsageResponse{
+		From:   from.Format(time.DateOnly),
+		To:     to.Format(time.DateOnly),
+		Quotas: quotas,
+		Daily:  daily,
+	}, "Usage retrieved successfully")
+}
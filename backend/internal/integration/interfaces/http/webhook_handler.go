@@ -0,0 +1,553 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bytedance/sonic"
+	observability "github.com/context-space/cloud-observability"
+	identityDomain "github.com/context-space/context-space/backend/internal/identityaccess/domain"
+	"github.com/context-space/context-space/backend/internal/integration/application"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
+	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+	"github.com/context-space/context-space/backend/internal/shared/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// webhookStreamPollInterval is how often the event stream checks for new events,
+	// events are read from the database so that deliveries received by any instance are streamed
+	webhookStreamPollInterval = 2 * time.Second
+	// webhookStreamHeartbeatInterval keeps idle streams open through proxies
+	webhookStreamHeartbeatInterval = 30 * time.Second
+)
+
+// WebhookHandler handles HTTP requests for provider webhooks
+type WebhookHandler struct {
+	webhookService *application.WebhookService
+	maxBodyBytes   int64
+	obs            *observability.ObservabilityProvider
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(
+	webhookService *application.WebhookService,
+	maxBodyBytes int64,
+	observabilityProvider *observability.ObservabilityProvider,
+) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		maxBodyBytes:   maxBodyBytes,
+		obs:            observabilityProvider,
+	}
+}
+
+// RegisterRoutes registers the routes for this handler
+func (h *WebhookHandler) RegisterRoutes(router *gin.RouterGroup, requireAuth gin.HandlerFunc) {
+	webhooks := router.Group("/webhooks")
+	{
+		// Deliveries are authenticated by the signature of the provider, not by the user
+		webhooks.POST("/:provider_identifier/:subscription_id", h.ReceiveWebhook)
+
+		subscriptions := webhooks.Group("/subscriptions")
+		subscriptions.Use(requireAuth)
+		{
+			subscriptions.GET("", h.ListSubscriptions)
+			subscriptions.POST("", h.CreateSubscription)
+			subscriptions.GET("/:subscription_id", h.GetSubscription)
+			subscriptions.PATCH("/:subscription_id", h.UpdateSubscription)
+			subscriptions.DELETE("/:subscription_id", h.DeleteSubscription)
+		}
+
+		events := webhooks.Group("/events")
+		events.Use(requireAuth)
+		{
+			events.GET("", h.ListEvents)
+			events.GET("/stream", h.StreamEvents)
+		}
+	}
+}
+
+// CreateWebhookSubscriptionRequest represents the request body for creating a webhook subscription
+type CreateWebhookSubscriptionRequest struct {
+	ProviderIdentifier string `json:"provider_identifier" binding:"required" enums:"github,slack,stripe,airtable,zoom"`
+	Description        string `json:"description,omitempty"`
+	// SigningSecret is the secret issued by the provider, it is generated for GitHub when empty
+	SigningSecret string `json:"signing_secret,omitempty"`
+}
+
+// UpdateWebhookSubscriptionRequest represents the request body for updating a webhook subscription
+type UpdateWebhookSubscriptionRequest struct {
+	Description   *string `json:"description,omitempty"`
+	SigningSecret *string `json:"signing_secret,omitempty"`
+}
+
+// WebhookSubscriptionResponse represents a webhook subscription in responses
+type WebhookSubscriptionResponse struct {
+	ID                 string `json:"id"`
+	ProviderIdentifier string `json:"provider_identifier"`
+	Description        string `json:"description"`
+	// EndpointPath is the path to register as the webhook URL at the provider
+	EndpointPath string `json:"endpoint_path"`
+	// SigningSecret is only returned when the subscription is created
+	SigningSecret  string `json:"signing_secret,omitempty"`
+	LastDeliveryAt string `json:"last_delivery_at,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// ListWebhookSubscriptionsResponse represents the response for listing webhook subscriptions
+type ListWebhookSubscriptionsResponse struct {
+	Subscriptions []WebhookSubscriptionResponse `json:"subscriptions"`
+}
+
+// WebhookEventResponse represents a received webhook event in responses
+type WebhookEventResponse struct {
+	ID                 string          `json:"id"`
+	SubscriptionID     string          `json:"subscription_id"`
+	ProviderIdentifier string          `json:"provider_identifier"`
+	DeliveryID         string          `json:"delivery_id"`
+	EventType          string          `json:"event_type"`
+	Payload            json.RawMessage `json:"payload" swaggertype:"object"`
+	ReceivedAt         string          `json:"received_at"`
+}
+
+// ListWebhookEventsResponse represents the response for listing webhook events
+type ListWebhookEventsResponse struct {
+	Events []WebhookEventResponse `json:"events"`
+}
+
+// mapWebhookSubscriptionToResponse maps a domain webhook subscription to a response
+func mapWebhookSubscriptionToResponse(subscription *domain.WebhookSubscription, withSecret bool) WebhookSubscriptionResponse {
+	response := WebhookSubscriptionResponse{
+		ID:                 subscription.ID,
+		ProviderIdentifier: subscription.ProviderIdentifier,
+		Description:        subscription.Description,
+		EndpointPath:       fmt.Sprintf("/v1/webhooks/%s/%s", subscription.ProviderIdentifier, subscription.ID),
+		CreatedAt:          subscription.CreatedAt.Format(time.RFC3339),
+	}
+	if withSecret {
+		response.SigningSecret = subscription.SigningSecret
+	}
+	if subscription.LastDeliveryAt != nil {
+		response.LastDeliveryAt = subscription.LastDeliveryAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+// mapWebhookEventToResponse maps a domain webhook event to a response
+func mapWebhookEventToResponse(event *domain.WebhookEvent) WebhookEventResponse {
+	return WebhookEventResponse{
+		ID:                 event.ID,
+		SubscriptionID:     event.SubscriptionID,
+		ProviderIdentifier: event.ProviderIdentifier,
+		DeliveryID:         event.DeliveryID,
+		EventType:          event.EventType,
+		Payload:            event.Payload,
+		ReceivedAt:         event.ReceivedAt.Format(time.RFC3339Nano),
+	}
+}
+
+// ReceiveWebhook godoc
+// @Summary Receive webhook delivery
+// @Description Receives an event pushed by a provider, verifies its signature and stores it once for the owner of the subscription. Endpoint validation requests of Slack and Zoom are answered with their challenge
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param provider_identifier path string true "Provider identifier" Enums(github,slack,stripe,airtable,zoom)
+// @Param subscription_id path string true "Webhook subscription ID"
+// @Success 200 {object} httpapi.Response "Delivery accepted, or challenge response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Invalid signature"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Subscription not found"
+// @Failure 413 {object} httpapi.SwaggerErrorResponse "Delivery too large"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /webhooks/{provider_identifier}/{subscription_id} [post]
+func (h *WebhookHandler) ReceiveWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+	receivedAt := time.Now()
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			httpapi.RespondWithError(c, http.StatusRequestEntityTooLarge, "Delivery too large")
+			return
+		}
+		httpapi.BadRequest(c, "Failed to read delivery")
+		return
+	}
+
+	result, err := h.webhookService.Ingest(ctx, c.Param("provider_identifier"), c.Param("subscription_id"), &domain.WebhookDelivery{
+		Header:     c.Request.Header,
+		Body:       body,
+		ReceivedAt: receivedAt,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrWebhookSubscriptionNotFound):
+			httpapi.NotFound(c, "Webhook subscription not found")
+		case errors.Is(err, domain.ErrWebhookSignatureInvalid):
+			httpapi.Unauthorized(c, "Invalid webhook signature")
+		default:
+			h.obs.Logger.Error(ctx, "Failed to ingest webhook delivery",
+				zap.String("subscription_id", c.Param("subscription_id")),
+				zap.Error(err))
+			httpapi.InternalServerError(c, "Failed to ingest webhook delivery")
+		}
+		return
+	}
+
+	// Providers expect the bare challenge, not the response envelope
+	if result.Challenge != nil {
+		c.JSON(http.StatusOK, result.Challenge)
+		return
+	}
+
+	httpapi.OK(c, gin.H{
+		"event_id":  result.Event.ID,
+		"duplicate": result.Duplicate,
+	}, "Webhook delivery accepted")
+}
+
+// CreateSubscription godoc
+// @Summary Create webhook subscription
+// @Description Creates an endpoint receiving the webhook deliveries of a provider. The signing secret issued by the provider is required, except for GitHub where one is generated when omitted; it is only returned in this response
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateWebhookSubscriptionRequest true "Webhook subscription"
+// @Success 201 {object} httpapi.Response{data=WebhookSubscriptionResponse} "Success response with the subscription and its signing secret"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /webhooks/subscriptions [post]
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentWebhookUser(c)
+	if !ok {
+		return
+	}
+
+	var req CreateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request format: ", err.Error()))
+		return
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(ctx, user.ID, req.ProviderIdentifier, req.Description, req.SigningSecret)
+	if err != nil {
+		h.respondWithSubscriptionError(c, err, "Failed to create webhook subscription")
+		return
+	}
+
+	httpapi.Created(c, mapWebhookSubscriptionToResponse(subscription, true), "Webhook subscription created successfully")
+}
+
+// ListSubscriptions godoc
+// @Summary List webhook subscriptions
+// @Description Lists the webhook subscriptions of the authenticated user
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} httpapi.Response{data=ListWebhookSubscriptionsResponse} "Success response with webhook subscriptions"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /webhooks/subscriptions [get]
+func (h *WebhookHandler) ListSubscriptions(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentWebhookUser(c)
+	if !ok {
+		return
+	}
+
+	subscriptions, err := h.webhookService.ListSubscriptions(ctx, user.ID)
+	if err != nil {
+		httpapi.InternalServerError(c, "Failed to list webhook subscriptions")
+		return
+	}
+
+	responses := make([]WebhookSubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		responses = append(responses, mapWebhookSubscriptionToResponse(subscription, false))
+	}
+
+	httpapi.OK(c, ListWebhookSubscriptionsResponse{Subscriptions: responses}, "Webhook subscriptions retrieved successfully")
+}
+
+// GetSubscription godoc
+// @Summary Get webhook subscription
+// @Description Gets a webhook subscription of the authenticated user
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param subscription_id path string true "Webhook subscription ID"
+// @Success 200 {object} httpapi.Response{data=WebhookSubscriptionResponse} "Success response with the webhook subscription"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /webhooks/subscriptions/{subscription_id} [get]
+func (h *WebhookHandler) GetSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentWebhookUser(c)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.webhookService.GetSubscription(ctx, user.ID, c.Param("subscription_id"))
+	if err != nil {
+		h.respondWithSubscriptionError(c, err, "Failed to get webhook subscription")
+		return
+	}
+
+	httpapi.OK(c, mapWebhookSubscriptionToResponse(subscription, false), "Webhook subscription retrieved successfully")
+}
+
+// UpdateSubscription godoc
+// @Summary Update webhook subscription
+// @Description Updates the description of a webhook subscription, or replaces its signing secret after it is rolled at the provider
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param subscription_id path string true "Webhook subscription ID"
+// @Param request body UpdateWebhookSubscriptionRequest true "Fields to update"
+// @Success 200 {object} httpapi.Response{data=WebhookSubscriptionResponse} "Success response with the updated webhook subscription"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /webhooks/subscriptions/{subscription_id} [patch]
+func (h *WebhookHandler) UpdateSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentWebhookUser(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpapi.BadRequest(c, utils.StringsBuilder("Invalid request format: ", err.Error()))
+		return
+	}
+
+	subscription, err := h.webhookService.UpdateSubscription(ctx, user.ID, c.Param("subscription_id"), req.Description, req.SigningSecret)
+	if err != nil {
+		h.respondWithSubscriptionError(c, err, "Failed to update webhook subscription")
+		return
+	}
+
+	httpapi.OK(c, mapWebhookSubscriptionToResponse(subscription, false), "Webhook subscription updated successfully")
+}
+
+// DeleteSubscription godoc
+// @Summary Delete webhook subscription
+// @Description Deletes a webhook subscription, further deliveries to its endpoint are rejected
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param subscription_id path string true "Webhook subscription ID"
+// @Success 204 "No content"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /webhooks/subscriptions/{subscription_id} [delete]
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentWebhookUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(ctx, user.ID, c.Param("subscription_id")); err != nil {
+		h.respondWithSubscriptionError(c, err, "Failed to delete webhook subscription")
+		return
+	}
+
+	httpapi.NoContent(c)
+}
+
+// ListEvents godoc
+// @Summary List webhook events
+// @Description Lists the webhook events received for the authenticated user, newest first
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param provider_identifier query string false "Filter by provider identifier"
+// @Param subscription_id query string false "Filter by webhook subscription ID"
+// @Param event_type query string false "Filter by event type"
+// @Param since query string false "Only events received at or after this RFC 3339 time"
+// @Param limit query int false "Limit (default: 50, max: 200)"
+// @Success 200 {object} httpapi.Response{data=ListWebhookEventsResponse} "Success response with webhook events"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /webhooks/events [get]
+func (h *WebhookHandler) ListEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentWebhookUser(c)
+	if !ok {
+		return
+	}
+
+	filter, ok := webhookEventFilter(c, user.ID)
+	if !ok {
+		return
+	}
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
+			filter.Limit = parsedLimit
+		}
+	}
+
+	events, err := h.webhookService.ListEvents(ctx, filter)
+	if err != nil {
+		httpapi.InternalServerError(c, "Failed to list webhook events")
+		return
+	}
+
+	responses := make([]WebhookEventResponse, 0, len(events))
+	for _, event := range events {
+		responses = append(responses, mapWebhookEventToResponse(event))
+	}
+
+	httpapi.OK(c, ListWebhookEventsResponse{Events: responses}, "Webhook events retrieved successfully")
+}
+
+// StreamEvents godoc
+// @Summary Stream webhook events
+// @Description Streams the webhook events received for the authenticated user as server-sent events, starting after the since time or from now
+// @Tags webhook
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param provider_identifier query string false "Filter by provider identifier"
+// @Param subscription_id query string false "Filter by webhook subscription ID"
+// @Param event_type query string false "Filter by event type"
+// @Param since query string false "Replay the events received after this RFC 3339 time"
+// @Success 200 {object} WebhookEventResponse "Stream of webhook events"
+// @Failure 400 {object} httpapi.SwaggerErrorResponse "Bad request error response"
+// @Failure 401 {object} httpapi.SwaggerErrorResponse "Unauthorized error response"
+// @Router /webhooks/events/stream [get]
+func (h *WebhookHandler) StreamEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, ok := currentWebhookUser(c)
+	if !ok {
+		return
+	}
+
+	filter, ok := webhookEventFilter(c, user.ID)
+	if !ok {
+		return
+	}
+	cursor := &domain.WebhookEventCursor{ReceivedAt: time.Now()}
+	if !filter.Since.IsZero() {
+		cursor.ReceivedAt = filter.Since
+	}
+	filter.Since = time.Time{}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	poll := time.NewTicker(webhookStreamPollInterval)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(webhookStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case <-poll.C:
+			filter.After = cursor
+			events, err := h.webhookService.ListEvents(ctx, filter)
+			if err != nil {
+				h.obs.Logger.Error(ctx, "Failed to poll webhook events", zap.Error(err))
+				continue
+			}
+
+			for _, event := range events {
+				data, err := sonic.Marshal(mapWebhookEventToResponse(event))
+				if err != nil {
+					continue
+				}
+				if _, err := fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, application.WebhookReceivedEvent, data); err != nil {
+					return
+				}
+				cursor = &domain.WebhookEventCursor{ReceivedAt: event.ReceivedAt, ID: event.ID}
+			}
+			if len(events) > 0 {
+				c.Writer.Flush()
+			}
+		}
+	}
+}
+
+// webhookEventFilter reads the event filter of the request, responding with a bad request if it is invalid
+func webhookEventFilter(c *gin.Context, userID string) (domain.WebhookEventFilter, bool) {
+	filter := domain.WebhookEventFilter{
+		UserID:             userID,
+		SubscriptionID:     c.Query("subscription_id"),
+		ProviderIdentifier: c.Query("provider_identifier"),
+		EventType:          c.Query("event_type"),
+	}
+
+	if sinceParam := c.Query("since"); sinceParam != "" {
+		since, err := time.Parse(time.RFC3339Nano, sinceParam)
+		if err != nil {
+			httpapi.BadRequest(c, "Invalid since, expected an RFC 3339 time")
+			return filter, false
+		}
+		filter.Since = since
+	}
+
+	return filter, true
+}
+
+// currentWebhookUser returns the authenticated user, responding with unauthorized if there is none
+func currentWebhookUser(c *gin.Context) (*identityDomain.User, bool) {
+	userI, exists := c.Get("user")
+	if !exists {
+		httpapi.Unauthorized(c, "Authentication required")
+		return nil, false
+	}
+	return userI.(*identityDomain.User), true
+}
+
+// respondWithSubscriptionError maps webhook subscription errors to HTTP responses
+func (h *WebhookHandler) respondWithSubscriptionError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrWebhookSubscriptionNotFound):
+		httpapi.NotFound(c, "Webhook subscription not found")
+	case errors.Is(err, domain.ErrWebhookProviderNotSupported),
+		errors.Is(err, domain.ErrWebhookSigningSecretRequired),
+		errors.Is(err, domain.ErrValidation):
+		httpapi.BadRequest(c, err.Error())
+	default:
+		h.obs.Logger.Error(c.Request.Context(), fallback, zap.Error(err))
+		httpapi.InternalServerError(c, fallback)
+	}
+}
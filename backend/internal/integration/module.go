@@ -2,18 +2,25 @@ package integration
 
 import (
 	"context"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	observability "github.com/context-space/cloud-observability"
 	"github.com/context-space/context-space/backend/internal/integration/application"
+	"github.com/context-space/context-space/backend/internal/integration/domain"
 	"github.com/context-space/context-space/backend/internal/integration/infrastructure/acl"
 	"github.com/context-space/context-space/backend/internal/integration/infrastructure/persistence"
+	"github.com/context-space/context-space/backend/internal/integration/infrastructure/quota"
+	"github.com/context-space/context-space/backend/internal/integration/infrastructure/webhook"
 	"github.com/context-space/context-space/backend/internal/integration/interfaces/http"
 	providercoreApp "github.com/context-space/context-space/backend/internal/providercore/application"
+	"github.com/context-space/context-space/backend/internal/shared/config"
 	contractCredential "github.com/context-space/context-space/backend/internal/shared/contract/credentialmanagement"
+	contractIdentity "github.com/context-space/context-space/backend/internal/shared/contract/identityaccess"
 	contractAdapter "github.com/context-space/context-space/backend/internal/shared/contract/provideradapter"
 	contractProvider "github.com/context-space/context-space/backend/internal/shared/contract/providercore"
+	"github.com/context-space/context-space/backend/internal/shared/cron"
 	"github.com/context-space/context-space/backend/internal/shared/events"
 	"github.com/context-space/context-space/backend/internal/shared/infrastructure/cache"
 	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
@@ -22,8 +29,16 @@ import (
 // Module encapsulates all integration components
 type Module struct {
 	InvocationService *application.InvocationService
+	QuotaService      *application.QuotaService
 	InvocationHandler *http.InvocationHandler
+	ApprovalHandler   *http.ApprovalHandler
+	UsageHandler      *http.UsageHandler
 	McpHandler        *http.McpHandler
+	WebhookService    *application.WebhookService
+	WebhookHandler    *http.WebhookHandler
+	ScheduleService   *application.ScheduleService
+	ScheduleHandler   *http.ScheduleHandler
+	scheduleConfig    config.ScheduledInvocationConfig
 	obs               *observability.ObservabilityProvider
 }
 
@@ -35,11 +50,19 @@ func NewModule(
 	providerContract contractProvider.ProviderCoreReader,
 	adapterContract contractAdapter.ProviderAdapterContract,
 	credentialContract contractCredential.CredentialManagementContract,
+	organizationReader contractIdentity.OrganizationReader,
 	providerService *providercoreApp.ProviderService,
 	redisClient cache.Cache,
+	cfg *config.Config,
 ) (*Module, error) {
 	// Create repositories
 	invocationRepo := persistence.NewInvocationRepository(db, observabilityProvider)
+	approvalPolicyRepo := persistence.NewApprovalPolicyRepository(db, observabilityProvider)
+	usageRepo := persistence.NewUsageRepository(db, observabilityProvider)
+	webhookSubscriptionRepo := persistence.NewWebhookSubscriptionRepository(db, observabilityProvider)
+	webhookEventRepo := persistence.NewWebhookEventRepository(db, observabilityProvider)
+	scheduleRepo := persistence.NewScheduleRepository(db, observabilityProvider)
+	scheduleRunRepo := persistence.NewScheduleRunRepository(db, observabilityProvider)
 
 	// Create ACL for provider operations
 	providerProvider := acl.NewProviderACL(providerContract, observabilityProvider)
@@ -56,6 +79,13 @@ func NewModule(
 		observabilityProvider,
 	)
 
+	// Create ACL for organization memberships
+	organizationProvider := acl.NewOrganizationACL(organizationReader, observabilityProvider)
+
+	// Create quota service backed by the shared Redis counters
+	quotaCounter := quota.NewRedisCounter(redisClient, observabilityProvider)
+	quotaService := application.NewQuotaService(quotaCounter, usageRepo, quotaPolicyFromConfig(cfg.Quota), observabilityProvider)
+
 	// Create application service
 	invocationService := application.NewInvocationService(
 		providerProvider,
@@ -66,16 +96,61 @@ func NewModule(
 		observabilityProvider,
 		redisClient,
 		credProvider, // Same ACL instance implements both interfaces
+		approvalPolicyRepo,
+		quotaService,
+		organizationProvider,
+	)
+
+	// Signing secrets are encrypted through the credential contract, which rewraps them from the store on key rotation
+	credentialContract.RegisterSecretStoreContract(persistence.WebhookSecretStoreName, persistence.NewWebhookSecretStore(db, observabilityProvider))
+
+	// Create webhook service, signing secrets are encrypted through the credential ACL
+	webhookService := application.NewWebhookService(
+		webhookSubscriptionRepo,
+		webhookEventRepo,
+		credProvider,
+		webhook.NewVerifiers(),
+		eventBus,
+		time.Duration(cfg.Webhook.RetentionHours)*time.Hour,
+		observabilityProvider,
 	)
+	webhookService.RegisterEventHandlers(eventBus)
+
+	// Create schedule service, scheduled runs are invoked through the invocation service
+	scheduleService := application.NewScheduleService(
+		scheduleRepo,
+		scheduleRunRepo,
+		invocationService,
+		eventBus,
+		application.ScheduleLimits{
+			BatchSize:              cfg.ScheduledInvocation.BatchSize,
+			MaxPerUser:             cfg.ScheduledInvocation.MaxPerUser,
+			MaxConsecutiveFailures: cfg.ScheduledInvocation.MaxConsecutiveFailures,
+		},
+		observabilityProvider,
+	)
+	scheduleService.RegisterEventHandlers(eventBus)
 
 	// Create HTTP handler
-	invocationHandler := http.NewInvocationHandler(invocationService, observabilityProvider)
-	mcpHandler := http.NewMcpHandler(invocationService, providerService, observabilityProvider)
+	invocationHandler := http.NewInvocationHandler(invocationService, quotaService, observabilityProvider)
+	approvalHandler := http.NewApprovalHandler(invocationService, observabilityProvider)
+	usageHandler := http.NewUsageHandler(quotaService, observabilityProvider)
+	mcpHandler := http.NewMcpHandler(invocationService, quotaService, providerService, observabilityProvider)
+	webhookHandler := http.NewWebhookHandler(webhookService, cfg.Webhook.MaxBodyBytes, observabilityProvider)
+	scheduleHandler := http.NewScheduleHandler(scheduleService, observabilityProvider)
 
 	return &Module{
 		InvocationService: invocationService,
+		QuotaService:      quotaService,
 		InvocationHandler: invocationHandler,
+		ApprovalHandler:   approvalHandler,
+		UsageHandler:      usageHandler,
 		McpHandler:        mcpHandler,
+		WebhookService:    webhookService,
+		WebhookHandler:    webhookHandler,
+		ScheduleService:   scheduleService,
+		ScheduleHandler:   scheduleHandler,
+		scheduleConfig:    cfg.ScheduledInvocation,
 		obs:               observabilityProvider,
 	}, nil
 }
@@ -90,10 +165,81 @@ func (m *Module) Initialize(ctx context.Context) error {
 // RegisterRoutes registers all integration HTTP routes
 func (m *Module) RegisterRoutes(router *gin.RouterGroup, requireAuth gin.HandlerFunc) {
 	m.InvocationHandler.RegisterRoutes(router, requireAuth)
+	m.ApprovalHandler.RegisterRoutes(router, requireAuth)
+	m.UsageHandler.RegisterRoutes(router, requireAuth)
 	m.McpHandler.RegisterRoutes(router, requireAuth)
+	m.WebhookHandler.RegisterRoutes(router, requireAuth)
+	m.ScheduleHandler.RegisterRoutes(router, requireAuth)
+}
+
+// CronTaskGroups returns the scheduled task groups of the integration module
+func (m *Module) CronTaskGroups() []*cron.TaskGroup {
+	groups := []*cron.TaskGroup{
+		{
+			Name:     "expire_stale_approvals",
+			Schedule: "0 */5 * * * *", // Execute every 5 minutes (6-field cron expression)
+			Tasks: []cron.CronTask{
+				{
+					Name:    "expire_stale_approvals",
+					Handler: m.InvocationService.ExpireStaleApprovals,
+				},
+			},
+		},
+		{
+			Name:     "rollup_usage",
+			Schedule: "0 */10 * * * *", // Execute every 10 minutes (6-field cron expression)
+			Tasks: []cron.CronTask{
+				{
+					Name:    "rollup_usage",
+					Handler: m.QuotaService.RollupUsage,
+				},
+			},
+		},
+		{
+			Name:     "purge_webhook_events",
+			Schedule: "0 30 * * * *", // Execute every hour (6-field cron expression)
+			Tasks: []cron.CronTask{
+				{
+					Name:    "purge_webhook_events",
+					Handler: m.WebhookService.PurgeExpiredEvents,
+				},
+			},
+		},
+	}
+
+	if m.scheduleConfig.Enabled {
+		groups = append(groups, &cron.TaskGroup{
+			Name:     "run_due_schedules",
+			Schedule: m.scheduleConfig.Schedule,
+			Tasks: []cron.CronTask{
+				{
+					Name:    "run_due_schedules",
+					Handler: m.ScheduleService.RunDueSchedules,
+				},
+			},
+		})
+	}
+
+	return groups
 }
 
 // GetInvocationService returns the invocation service
 func (m *Module) GetInvocationService() *application.InvocationService {
 	return m.InvocationService
 }
+
+// quotaPolicyFromConfig maps the quota configuration to the domain quota policy
+func quotaPolicyFromConfig(cfg config.QuotaConfig) domain.QuotaPolicy {
+	providers := make(map[string]domain.QuotaLimits, len(cfg.Providers))
+	for identifier, limits := range cfg.Providers {
+		providers[identifier] = domain.QuotaLimits(limits)
+	}
+
+	return domain.QuotaPolicy{
+		Enabled:        cfg.Enabled,
+		User:           domain.QuotaLimits(cfg.User),
+		ServiceAccount: domain.QuotaLimits(cfg.ServiceAccount),
+		APIKey:         domain.QuotaLimits(cfg.APIKey),
+		Providers:      providers,
+	}
+}
@@ -0,0 +1,292 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
+	"github.com/context-space/context-space/backend/internal/shared/apierrors"
+	"github.com/context-space/context-space/backend/internal/shared/types"
+)
+
+const (
+	// probeConcurrency bounds how many providers are probed at the same time
+	probeConcurrency = 4
+	// DefaultProbeHistoryLimit is the number of probe results returned with a provider status
+	DefaultProbeHistoryLimit = 50
+)
+
+// ProbeConfig holds the synthetic probe settings
+type ProbeConfig struct {
+	Timeout   time.Duration
+	Retention time.Duration
+	// Targets is keyed by provider identifier, providers without a target are only probed if they implement domain.HealthChecker.
+	// Only providers whose auth type is none are probed.
+	Targets map[string]domain.ProbeTarget
+}
+
+// ProviderHealth summarizes the probe history of a provider
+type ProviderHealth struct {
+	ProviderIdentifier string
+	Status             domain.ProbeStatus
+	LastCheckedAt      *time.Time
+	LastError          string
+	LatencyMs          int64
+	// Uptime is the share of successful probes in the returned history, from 0 to 1
+	Uptime  float64
+	History []*domain.ProbeResult
+}
+
+// ProbeService runs synthetic probes against providers and reports their health
+type ProbeService struct {
+	adapterFactory *AdapterFactory
+	probeRepo      domain.ProbeResultRepository
+	config         ProbeConfig
+	obs            *observability.ObservabilityProvider
+}
+
+// NewProbeService creates a new probe service
+func NewProbeService(
+	adapterFactory *AdapterFactory,
+	probeRepo domain.ProbeResultRepository,
+	config ProbeConfig,
+	obs *observability.ObservabilityProvider,
+) *ProbeService {
+	return &ProbeService{
+		adapterFactory: adapterFactory,
+		probeRepo:      probeRepo,
+		config:         config,
+		obs:            obs,
+	}
+}
+
+// RunProbes probes every registered provider that has a target or a health check and records the results
+func (s *ProbeService) RunProbes(ctx context.Context) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "ProbeService.RunProbes")
+	defer span.End()
+
+	identifiers := s.adapterFactory.ListRegisteredProviders()
+	sort.Strings(identifiers)
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		probed    int
+		down      int
+		semaphore = make(chan struct{}, probeConcurrency)
+	)
+	for _, identifier := range identifiers {
+		adapter, err := s.adapterFactory.GetAdapter(identifier)
+		if err != nil {
+			continue
+		}
+
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(identifier string, adapter domain.Adapter) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			result, ok := s.probe(ctx, identifier, adapter)
+			if !ok {
+				return
+			}
+			if err := s.probeRepo.Create(ctx, result); err != nil {
+				s.obs.Logger.Error(ctx, "Failed to record probe result", zap.String("provider_identifier", identifier), zap.Error(err))
+				return
+			}
+
+			mu.Lock()
+			probed++
+			if result.Status == domain.ProbeStatusDown {
+				down++
+			}
+			mu.Unlock()
+		}(identifier, adapter)
+	}
+	wg.Wait()
+
+	s.obs.Logger.Info(ctx, "Provider probes completed", zap.Int("probed", probed), zap.Int("down", down))
+	return nil
+}
+
+// PruneHistory deletes probe results older than the retention period
+func (s *ProbeService) PruneHistory(ctx context.Context) error {
+	ctx, span := s.obs.Tracer.Start(ctx, "ProbeService.PruneHistory")
+	defer span.End()
+
+	if s.config.Retention <= 0 {
+		return nil
+	}
+
+	deleted, err := s.probeRepo.DeleteBefore(ctx, time.Now().Add(-s.config.Retention))
+	if err != nil {
+		return fmt.Errorf("failed to prune probe history: %w", err)
+	}
+
+	s.obs.Logger.Info(ctx, "Pruned provider probe history", zap.Int64("deleted", deleted))
+	return nil
+}
+
+// GetProviderHealth returns the current status and recent probe history of a provider
+func (s *ProbeService) GetProviderHealth(ctx context.Context, identifier string, limit int) (*ProviderHealth, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ProbeService.GetProviderHealth")
+	defer span.End()
+
+	if !s.adapterFactory.IsAdapterRegistered(identifier) {
+		return nil, apierrors.NewNotFoundError("", fmt.Errorf("provider %s is not registered", identifier))
+	}
+	if limit <= 0 {
+		limit = DefaultProbeHistoryLimit
+	}
+
+	history, err := s.probeRepo.ListByProvider(ctx, identifier, limit)
+	if err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+
+	health := &ProviderHealth{ProviderIdentifier: identifier, Status: domain.ProbeStatusUnknown, History: history}
+	if len(history) == 0 {
+		return health, nil
+	}
+
+	applyLatestProbe(health, history[0])
+	up := 0
+	for _, result := range history {
+		if result.Status == domain.ProbeStatusUp {
+			up++
+		}
+	}
+	health.Uptime = float64(up) / float64(len(history))
+
+	return health, nil
+}
+
+// ListProviderHealth returns the latest status of every registered provider, unprobed providers are unknown
+func (s *ProbeService) ListProviderHealth(ctx context.Context) ([]*ProviderHealth, error) {
+	ctx, span := s.obs.Tracer.Start(ctx, "ProbeService.ListProviderHealth")
+	defer span.End()
+
+	latest, err := s.probeRepo.ListLatest(ctx)
+	if err != nil {
+		return nil, apierrors.NewInternalError("", err)
+	}
+	latestByProvider := make(map[string]*domain.ProbeResult, len(latest))
+	for _, result := range latest {
+		latestByProvider[result.ProviderIdentifier] = result
+	}
+
+	identifiers := s.adapterFactory.ListRegisteredProviders()
+	sort.Strings(identifiers)
+
+	healths := make([]*ProviderHealth, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		health := &ProviderHealth{ProviderIdentifier: identifier, Status: domain.ProbeStatusUnknown}
+		if result, ok := latestByProvider[identifier]; ok {
+			applyLatestProbe(health, result)
+		}
+		healths = append(healths, health)
+	}
+
+	return healths, nil
+}
+
+// RequiredCommands returns the distinct executables the registered adapters need to spawn
+func (s *ProbeService) RequiredCommands() []string {
+	seen := make(map[string]bool)
+	var commands []string
+	for _, identifier := range s.adapterFactory.ListRegisteredProviders() {
+		adapter, err := s.adapterFactory.GetAdapter(identifier)
+		if err != nil {
+			continue
+		}
+		dependent, ok := adapter.(domain.CommandDependent)
+		if !ok {
+			continue
+		}
+		command := dependent.RequiredCommand()
+		if command != "" && !seen[command] {
+			seen[command] = true
+			commands = append(commands, command)
+		}
+	}
+
+	sort.Strings(commands)
+	return commands
+}
+
+// probe runs the probe of one provider, returning false if the provider has nothing to probe
+// Probes run without credentials, so only providers declaring no auth are probed: the others would be reported
+// down, and their MCP servers spawned for nothing
+func (s *ProbeService) probe(ctx context.Context, identifier string, adapter domain.Adapter) (result *domain.ProbeResult, ok bool) {
+	if info := adapter.GetProviderAdapterInfo(); info == nil || info.AuthType != types.AuthTypeNone {
+		if _, hasTarget := s.config.Targets[identifier]; hasTarget {
+			s.obs.Logger.Warn(ctx, "Skipping probe target of a provider that needs credentials", zap.String("provider_identifier", identifier))
+		}
+		return nil, false
+	}
+
+	target, hasTarget := s.config.Targets[identifier]
+	checker, isChecker := adapter.(domain.HealthChecker)
+	if !hasTarget && !isChecker {
+		return nil, false
+	}
+
+	operation := target.Operation
+	if !hasTarget {
+		operation = domain.ProbeKindToolsList
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	result = &domain.ProbeResult{
+		ID:                 uuid.New().String(),
+		ProviderIdentifier: identifier,
+		Operation:          operation,
+		Status:             domain.ProbeStatusUp,
+		CheckedAt:          start,
+	}
+
+	// A panicking adapter counts as a failed probe rather than taking the scheduler down
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result.Status = domain.ProbeStatusDown
+			result.Error = fmt.Sprintf("probe panicked: %v", recovered)
+			result.LatencyMs = time.Since(start).Milliseconds()
+			ok = true
+		}
+	}()
+
+	var err error
+	if hasTarget {
+		_, err = adapter.Execute(ctx, target.Operation, target.Parameters, nil)
+	} else {
+		err = checker.Health(ctx)
+	}
+
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Status = domain.ProbeStatusDown
+		result.Error = err.Error()
+	}
+
+	return result, true
+}
+
+// applyLatestProbe copies the most recent probe result into the health summary
+func applyLatestProbe(health *ProviderHealth, result *domain.ProbeResult) {
+	checkedAt := result.CheckedAt
+	health.Status = result.Status
+	health.LastCheckedAt = &checkedAt
+	health.LastError = result.Error
+	health.LatencyMs = result.LatencyMs
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "context"
+
+// CredentialVerifier is implemented by adapters that can check a credential against the provider with a cheap call
+type CredentialVerifier interface {
+	// VerifyCredential returns an AdapterError with ErrCredentialError when the provider rejects the credential,
+	// any other error means the verification was inconclusive
+	VerifyCredential(ctx context.Context, credential interface{}) error
+}
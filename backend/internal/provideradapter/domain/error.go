@@ -2,12 +2,14 @@ package domain
 
 import (
 	"fmt"
+	"net/http"
 )
 
 // Constants for standard adapter error codes (now string type).
 const (
 	// Credential related errors
-	ErrCredentialError string = "CREDENTIAL_ERROR" // Error related to credentials (invalid, missing, expired)
+	ErrCredentialError   string = "CREDENTIAL_ERROR"   // Error related to credentials (invalid, missing, expired)
+	ErrInsufficientScope string = "INSUFFICIENT_SCOPE" // The credential was not granted the permissions the operation requires
 
 	// Provider interaction errors
 	ErrProviderAPIError string = "PROVIDER_API_ERROR" // General error during interaction with the provider API
@@ -36,6 +38,7 @@ type AdapterError struct {
 	ErrorCode           string
 	ErrorMessage        string
 	StatusCode          int
+	MissingPermissions  []string // Set for ErrInsufficientScope errors
 	Raw                 interface{}
 }
 
@@ -61,3 +64,19 @@ func NewAdapterError(
 		StatusCode:          statusCode,
 	}
 }
+
+// NewInsufficientScopeError creates an adapter error for a credential missing the permissions of an operation
+func NewInsufficientScopeError(
+	providerIdentifier string,
+	operationIdentifier string,
+	missingPermissions []string,
+) *AdapterError {
+	return &AdapterError{
+		ProviderIdentifier:  providerIdentifier,
+		OperationIdentifier: operationIdentifier,
+		ErrorCode:           ErrInsufficientScope,
+		ErrorMessage:        fmt.Sprintf("missing required permissions: %v", missingPermissions),
+		StatusCode:          http.StatusForbidden,
+		MissingPermissions:  missingPermissions,
+	}
+}
@@ -32,3 +32,9 @@ type OAuthAdapter interface {
 	// GetPermissionIdentifiersFromScopes gets the permission identifiers from the scopes
 	GetPermissionIdentifiersFromScopes(scopes []string) ([]string, error)
 }
+
+// OAuthTokenRevoker is implemented by OAuth adapters whose provider can revoke issued tokens
+type OAuthTokenRevoker interface {
+	// RevokeOAuthToken invalidates the token at the provider, revoking an already invalid token is not an error
+	RevokeOAuthToken(ctx context.Context, token *oauth2.Token) error
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // OAuthConfig defines the configuration information required for OAuth authentication
 type OAuthConfig struct {
@@ -35,3 +38,34 @@ func (c *OAuthConfig) Clone() *OAuthConfig {
 
 	return clone
 }
+
+// OAuthAppOverride replaces the OAuth client and the endpoints of the provider for a user or an organization
+// Empty URLs fall back to the endpoints of the provider
+type OAuthAppOverride struct {
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	APIBaseURL   string
+}
+
+// HasEndpoints reports whether the override replaces any endpoint of the provider
+func (o *OAuthAppOverride) HasEndpoints() bool {
+	return o.AuthURL != "" || o.TokenURL != "" || o.APIBaseURL != ""
+}
+
+type oauthAppOverrideContextKey struct{}
+
+// WithOAuthAppOverride returns a context whose OAuth flows and API calls use the given override
+func WithOAuthAppOverride(ctx context.Context, override *OAuthAppOverride) context.Context {
+	if override == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, oauthAppOverrideContextKey{}, override)
+}
+
+// OAuthAppOverrideFromContext returns the override of the context, or nil if the provider manifest is used
+func OAuthAppOverrideFromContext(ctx context.Context) *OAuthAppOverride {
+	override, _ := ctx.Value(oauthAppOverrideContextKey{}).(*OAuthAppOverride)
+	return override
+}
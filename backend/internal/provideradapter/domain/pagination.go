@@ -0,0 +1,270 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bytedance/sonic"
+)
+
+// MaxPaginatePages bounds the pages followed by a single paginated execution
+const MaxPaginatePages = 20
+
+// PaginationStyle describes how an operation selects its page
+type PaginationStyle string
+
+const (
+	// PaginationStylePage operations take a page number starting at 1
+	PaginationStylePage PaginationStyle = "page"
+	// PaginationStyleCursor operations take an opaque cursor returned by the previous page
+	PaginationStyleCursor PaginationStyle = "cursor"
+)
+
+// Pagination declares where the cursor of a paginated operation goes and how the next one is found in its result
+// Paths are dot-separated keys into the JSON form of the result
+type Pagination struct {
+	Style PaginationStyle
+	// CursorParam is the parameter the page number or cursor is passed in
+	CursorParam string
+	// ItemsPath is the path of the list of items, empty when the result is the list itself
+	ItemsPath string
+	// NextCursorPath is the path of the cursor of the next page, for cursor style operations
+	NextCursorPath string
+	// TotalPagesPath is the path of the number of pages, for page style operations that report it
+	TotalPagesPath string
+	// PageSizeParam is the parameter holding the page size of page style operations without TotalPagesPath,
+	// a page shorter than the page size is the last one
+	PageSizeParam string
+	// DefaultPageSize is the page size used by the operation when PageSizeParam is not given
+	DefaultPageSize int
+}
+
+// Paginator is implemented by adapters with paginated operations
+type Paginator interface {
+	// Pagination returns how an operation paginates, nil if it does not
+	Pagination(operationID string) *Pagination
+}
+
+// PaginateOptions controls how many pages a paginated execution follows
+type PaginateOptions struct {
+	// Cursor is the next cursor of a previous execution, empty to start from the page given in the parameters
+	Cursor string
+	// MaxItems stops fetching pages once this many items are collected, 0 for no limit. The last page is kept
+	// whole so that next_cursor resumes after every returned item, the result may hold up to a page more.
+	MaxItems int
+	// MaxPages is the number of pages to fetch at most, 0 for a single page
+	MaxPages int
+}
+
+// PageResult is the merged result of the pages fetched by a paginated execution
+type PageResult struct {
+	Result interface{}
+	// NextCursor resumes after the last fetched page, empty once the last page was fetched
+	NextCursor string
+	Pages      int
+	Items      int
+}
+
+// PageExecutor executes one page of an operation with the given parameters
+type PageExecutor func(ctx context.Context, params map[string]interface{}) (interface{}, error)
+
+// numberPreservingAPI keeps large identifiers intact when results are decoded into generic values
+var numberPreservingAPI = sonic.Config{UseNumber: true}.Froze()
+
+// Collect fetches pages of the operation until the options or the last page stop it, merging their items
+// The result keeps the shape of the last page with its items replaced by the items of every fetched page,
+// pages are never cut at MaxItems as the next cursor could not resume in the middle of one
+func (p *Pagination) Collect(ctx context.Context, execute PageExecutor, params map[string]interface{}, options PaginateOptions) (*PageResult, error) {
+	maxPages := options.MaxPages
+	if maxPages <= 0 {
+		maxPages = 1
+	}
+	if maxPages > MaxPaginatePages {
+		maxPages = MaxPaginatePages
+	}
+
+	pageParams := make(map[string]interface{}, len(params)+1)
+	for key, value := range params {
+		pageParams[key] = value
+	}
+	if options.Cursor != "" {
+		pageParams[p.CursorParam] = options.Cursor
+	}
+
+	result := &PageResult{}
+	var merged []interface{}
+	var last interface{}
+	for result.Pages < maxPages {
+		raw, err := execute(ctx, pageParams)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := normalizeResult(raw)
+		if err != nil {
+			return nil, err
+		}
+		items, err := lookupItems(page, p.ItemsPath)
+		if err != nil {
+			return nil, err
+		}
+
+		last = page
+		result.Pages++
+		merged = append(merged, items...)
+		result.NextCursor = p.nextCursor(page, pageParams, len(items))
+
+		if result.NextCursor == "" || (options.MaxItems > 0 && len(merged) >= options.MaxItems) {
+			break
+		}
+		pageParams[p.CursorParam] = result.NextCursor
+	}
+
+	if merged == nil {
+		merged = []interface{}{}
+	}
+	result.Items = len(merged)
+	result.Result = replaceItems(last, p.ItemsPath, merged)
+	return result, nil
+}
+
+// nextCursor returns the cursor of the page after the one fetched with params, empty if it was the last one
+func (p *Pagination) nextCursor(page interface{}, params map[string]interface{}, itemCount int) string {
+	if p.Style == PaginationStyleCursor {
+		cursor, _ := lookupPath(page, p.NextCursorPath)
+		return scalarString(cursor)
+	}
+
+	current := 1
+	if value, ok := intValue(params[p.CursorParam]); ok && value > 0 {
+		current = value
+	}
+
+	switch {
+	case p.TotalPagesPath != "":
+		value, _ := lookupPath(page, p.TotalPagesPath)
+		totalPages, ok := intValue(value)
+		if !ok || current >= totalPages {
+			return ""
+		}
+	case p.PageSizeParam != "":
+		pageSize := p.DefaultPageSize
+		if value, ok := intValue(params[p.PageSizeParam]); ok && value > 0 {
+			pageSize = value
+		}
+		if itemCount == 0 || (pageSize > 0 && itemCount < pageSize) {
+			return ""
+		}
+	default:
+		if itemCount == 0 {
+			return ""
+		}
+	}
+
+	return strconv.Itoa(current + 1)
+}
+
+// normalizeResult converts a typed result into its generic JSON form
+func normalizeResult(raw interface{}) (interface{}, error) {
+	data, err := numberPreservingAPI.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal page: %w", err)
+	}
+
+	var page interface{}
+	if err := numberPreservingAPI.Unmarshal(data, &page); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal page: %w", err)
+	}
+	return page, nil
+}
+
+// lookupItems returns the list at path, a missing or null list has no items
+func lookupItems(page interface{}, path string) ([]interface{}, error) {
+	value, ok := lookupPath(page, path)
+	if !ok || value == nil {
+		return nil, nil
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("pagination items at %q are not a list", path)
+	}
+	return items, nil
+}
+
+// lookupPath returns the value at a dot-separated path, an empty path is the value itself
+func lookupPath(value interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return value, true
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// replaceItems sets the list at path, the items replace the page when the path is empty
+func replaceItems(page interface{}, path string, items []interface{}) interface{} {
+	if path == "" {
+		return items
+	}
+
+	keys := strings.Split(path, ".")
+	object, ok := page.(map[string]interface{})
+	if !ok {
+		return page
+	}
+	parent := object
+	for _, key := range keys[:len(keys)-1] {
+		child, ok := parent[key].(map[string]interface{})
+		if !ok {
+			return page
+		}
+		parent = child
+	}
+	parent[keys[len(keys)-1]] = items
+	return object
+}
+
+// scalarString formats a cursor value, null and empty values end the pagination
+func scalarString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// intValue reads an integer parameter or result value given as a number or a string
+func intValue(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	case fmt.Stringer:
+		parsed, err := strconv.Atoi(v.String())
+		return parsed, err == nil
+	case string:
+		parsed, err := strconv.Atoi(v)
+		return parsed, err == nil
+	default:
+		return 0, false
+	}
+}
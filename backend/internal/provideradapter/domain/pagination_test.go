@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// pageServer serves fixed pages by the value of the cursor parameter and records the cursors it was asked for
+type pageServer struct {
+	cursorParam string
+	pages       map[string]interface{}
+	requested   []string
+}
+
+func (s *pageServer) execute(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+	cursor := ""
+	if value, ok := params[s.cursorParam]; ok {
+		cursor = fmt.Sprint(value)
+	}
+	s.requested = append(s.requested, cursor)
+
+	page, ok := s.pages[cursor]
+	if !ok {
+		return nil, fmt.Errorf("unexpected cursor %q", cursor)
+	}
+	return page, nil
+}
+
+func TestPaginationCollect(t *testing.T) {
+	tests := []struct {
+		name              string
+		pagination        Pagination
+		pages             map[string]interface{}
+		params            map[string]interface{}
+		options           PaginateOptions
+		expectedRequested []string
+		expectedItems     []interface{}
+		expectedCursor    string
+	}{
+		{
+			name:       "PageStyleStopsAtTotalPages",
+			pagination: Pagination{Style: PaginationStylePage, CursorParam: "page", ItemsPath: "data.items", TotalPagesPath: "data.total_pages"},
+			pages: map[string]interface{}{
+				"":  map[string]interface{}{"data": map[string]interface{}{"items": []interface{}{"a", "b"}, "total_pages": 3}},
+				"2": map[string]interface{}{"data": map[string]interface{}{"items": []interface{}{"c", "d"}, "total_pages": 3}},
+				"3": map[string]interface{}{"data": map[string]interface{}{"items": []interface{}{"e"}, "total_pages": 3}},
+			},
+			options:           PaginateOptions{MaxPages: 5},
+			expectedRequested: []string{"", "2", "3"},
+			expectedItems:     []interface{}{"a", "b", "c", "d", "e"},
+		},
+		{
+			name:       "PageStyleReturnsTheNextPageWhenMaxPagesIsReached",
+			pagination: Pagination{Style: PaginationStylePage, CursorParam: "page", ItemsPath: "items", TotalPagesPath: "total_pages"},
+			pages: map[string]interface{}{
+				"":  map[string]interface{}{"items": []interface{}{"a"}, "total_pages": "3"},
+				"2": map[string]interface{}{"items": []interface{}{"b"}, "total_pages": "3"},
+			},
+			options:           PaginateOptions{MaxPages: 2},
+			expectedRequested: []string{"", "2"},
+			expectedItems:     []interface{}{"a", "b"},
+			expectedCursor:    "3",
+		},
+		{
+			name:       "PageStyleStopsAtShortPage",
+			pagination: Pagination{Style: PaginationStylePage, CursorParam: "page", ItemsPath: "items", PageSizeParam: "per_page", DefaultPageSize: 30},
+			pages: map[string]interface{}{
+				"":  map[string]interface{}{"items": []interface{}{"a", "b"}},
+				"2": map[string]interface{}{"items": []interface{}{"c"}},
+			},
+			params:            map[string]interface{}{"per_page": 2},
+			options:           PaginateOptions{MaxPages: 5},
+			expectedRequested: []string{"", "2"},
+			expectedItems:     []interface{}{"a", "b", "c"},
+		},
+		{
+			name:       "PageStyleStopsAtEmptyPage",
+			pagination: Pagination{Style: PaginationStylePage, CursorParam: "page"},
+			pages: map[string]interface{}{
+				"":  []interface{}{"a", "b"},
+				"2": []interface{}{},
+			},
+			options:           PaginateOptions{MaxPages: 5},
+			expectedRequested: []string{"", "2"},
+			expectedItems:     []interface{}{"a", "b"},
+		},
+		{
+			name:       "CursorStyleStopsAtNullCursor",
+			pagination: Pagination{Style: PaginationStyleCursor, CursorParam: "start_cursor", ItemsPath: "results", NextCursorPath: "next_cursor"},
+			pages: map[string]interface{}{
+				"":   map[string]interface{}{"results": []interface{}{"a"}, "next_cursor": "c2"},
+				"c2": map[string]interface{}{"results": []interface{}{"b"}, "next_cursor": nil},
+			},
+			options:           PaginateOptions{MaxPages: 5},
+			expectedRequested: []string{"", "c2"},
+			expectedItems:     []interface{}{"a", "b"},
+		},
+		{
+			name:       "CursorStyleResumesFromOptionsCursor",
+			pagination: Pagination{Style: PaginationStyleCursor, CursorParam: "start_cursor", ItemsPath: "results", NextCursorPath: "next_cursor"},
+			pages: map[string]interface{}{
+				"c2": map[string]interface{}{"results": []interface{}{"b"}, "next_cursor": "c3"},
+			},
+			options:           PaginateOptions{Cursor: "c2"},
+			expectedRequested: []string{"c2"},
+			expectedItems:     []interface{}{"b"},
+			expectedCursor:    "c3",
+		},
+		{
+			name:       "MaxItemsKeepsTheLastPageWhole",
+			pagination: Pagination{Style: PaginationStyleCursor, CursorParam: "start_cursor", ItemsPath: "results", NextCursorPath: "meta.next"},
+			pages: map[string]interface{}{
+				"":   map[string]interface{}{"results": []interface{}{"a", "b", "c"}, "meta": map[string]interface{}{"next": "c2"}},
+				"c2": map[string]interface{}{"results": []interface{}{"d", "e", "f"}, "meta": map[string]interface{}{"next": "c3"}},
+			},
+			options:           PaginateOptions{MaxPages: 5, MaxItems: 4},
+			expectedRequested: []string{"", "c2"},
+			expectedItems:     []interface{}{"a", "b", "c", "d", "e", "f"},
+			expectedCursor:    "c3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &pageServer{cursorParam: tt.pagination.CursorParam, pages: tt.pages}
+
+			result, err := tt.pagination.Collect(context.Background(), server.execute, tt.params, tt.options)
+			if err != nil {
+				t.Fatalf("Collect() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(server.requested, tt.expectedRequested) {
+				t.Errorf("requested cursors = %v, want %v", server.requested, tt.expectedRequested)
+			}
+			items, err := lookupItems(result.Result, tt.pagination.ItemsPath)
+			if err != nil {
+				t.Fatalf("lookupItems() error = %v", err)
+			}
+			if fmt.Sprint(items) != fmt.Sprint(tt.expectedItems) {
+				t.Errorf("items = %v, want %v", items, tt.expectedItems)
+			}
+			if result.Items != len(tt.expectedItems) || result.Pages != len(tt.expectedRequested) {
+				t.Errorf("counts = %d items in %d pages, want %d in %d", result.Items, result.Pages, len(tt.expectedItems), len(tt.expectedRequested))
+			}
+			if result.NextCursor != tt.expectedCursor {
+				t.Errorf("NextCursor = %q, want %q", result.NextCursor, tt.expectedCursor)
+			}
+		})
+	}
+}
+
+func TestPaginationCollectCapsPages(t *testing.T) {
+	pagination := Pagination{Style: PaginationStylePage, CursorParam: "page"}
+	calls := 0
+	execute := func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+		calls++
+		return []interface{}{calls}, nil
+	}
+
+	result, err := pagination.Collect(context.Background(), execute, nil, PaginateOptions{MaxPages: MaxPaginatePages * 5})
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if calls != MaxPaginatePages || result.Pages != MaxPaginatePages {
+		t.Errorf("fetched %d pages, want %d", calls, MaxPaginatePages)
+	}
+	if expected := fmt.Sprint(MaxPaginatePages + 1); result.NextCursor != expected {
+		t.Errorf("NextCursor = %q, want %q", result.NextCursor, expected)
+	}
+}
+
+func TestPaginationCollectSinglePageByDefault(t *testing.T) {
+	pagination := Pagination{Style: PaginationStyleCursor, CursorParam: "cursor", NextCursorPath: "next", ItemsPath: "items"}
+	server := &pageServer{cursorParam: "cursor", pages: map[string]interface{}{
+		"": map[string]interface{}{"items": nil, "next": "c2"},
+	}}
+
+	result, err := pagination.Collect(context.Background(), server.execute, nil, PaginateOptions{})
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if result.Pages != 1 || result.Items != 0 || result.NextCursor != "c2" {
+		t.Errorf("result = %d pages, %d items, cursor %q, want 1 page, 0 items, cursor c2", result.Pages, result.Items, result.NextCursor)
+	}
+	if items, _ := lookupPath(result.Result, "items"); !reflect.DeepEqual(items, []interface{}{}) {
+		t.Errorf("items = %#v, want an empty list", items)
+	}
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ProbeStatus is the outcome of a synthetic provider probe
+type ProbeStatus string
+
+const (
+	ProbeStatusUp      ProbeStatus = "up"
+	ProbeStatusDown    ProbeStatus = "down"
+	ProbeStatusUnknown ProbeStatus = "unknown"
+)
+
+// ProbeKindToolsList identifies probes that list the tools of an MCP server instead of running an operation
+const ProbeKindToolsList = "tools/list"
+
+// ProbeResult records one synthetic probe of a provider
+type ProbeResult struct {
+	ID                 string
+	ProviderIdentifier string
+	// Operation is the probed operation identifier, or ProbeKindToolsList for MCP servers
+	Operation string
+	Status    ProbeStatus
+	LatencyMs int64
+	Error     string
+	CheckedAt time.Time
+}
+
+// ProbeTarget is the cheap, credential-free operation used to probe a provider
+type ProbeTarget struct {
+	Operation  string
+	Parameters map[string]interface{}
+}
+
+// HealthChecker is implemented by adapters that can check their upstream without credentials
+type HealthChecker interface {
+	Health(ctx context.Context) error
+}
+
+// CommandDependent is implemented by adapters that spawn a local executable, such as npx or uvx for MCP servers
+type CommandDependent interface {
+	RequiredCommand() string
+}
+
+// ProbeResultRepository defines the interface for probe history persistence
+type ProbeResultRepository interface {
+	// Create records a probe result
+	Create(ctx context.Context, result *ProbeResult) error
+
+	// ListByProvider returns the most recent probe results of a provider, newest first
+	ListByProvider(ctx context.Context, providerIdentifier string, limit int) ([]*ProbeResult, error)
+
+	// ListLatest returns the most recent probe result of every probed provider
+	ListLatest(ctx context.Context) ([]*ProbeResult, error)
+
+	// DeleteBefore deletes probe results checked before the given time
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
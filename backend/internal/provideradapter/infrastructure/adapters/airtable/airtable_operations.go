@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	"github.com/bytedance/sonic"
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
 )
 
 // Define constants for API paths used by handlers.
@@ -229,6 +230,28 @@ type OperationDefinition struct {
 // Operations maps operation IDs to their definitions.
 type Operations map[string]OperationDefinition
 
+// offsetPagination returns how an Airtable list operation returning its items at itemsPath paginates,
+// the offset is an opaque cursor left out after the last page
+func offsetPagination(itemsPath string) *domain.Pagination {
+	return &domain.Pagination{
+		Style:          domain.PaginationStyleCursor,
+		CursorParam:    "offset",
+		ItemsPath:      itemsPath,
+		NextCursorPath: "offset",
+	}
+}
+
+// operationPaginations maps the paginated operation IDs to their pagination
+var operationPaginations = map[string]*domain.Pagination{
+	operationIDListBases:   offsetPagination("bases"),
+	operationIDListRecords: offsetPagination("records"),
+}
+
+// Pagination returns how an operation paginates, nil if it does not
+func (a *AirtableAdapter) Pagination(operationID string) *domain.Pagination {
+	return operationPaginations[operationID]
+}
+
 // RegisterOperation registers the parameter schema and handler.
 func (a *AirtableAdapter) RegisterOperation(operationID string, schema interface{}, handler OperationHandler, requiredPerms []string) {
 	a.BaseAdapter.RegisterOperation(operationID, schema) // Register schema for validation
@@ -22,6 +22,7 @@ const (
 func init() {
 	// Type assertion to ensure the adapter implements the necessary interfaces
 	var _ domain.OAuthAdapter = (*AirtableAdapter)(nil)
+	var _ domain.Paginator = (*AirtableAdapter)(nil)
 
 	template := &AirtableTemplate{}
 	registry.RegisterAdapterTemplate(identifier, template)
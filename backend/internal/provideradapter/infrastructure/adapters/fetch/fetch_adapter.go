@@ -2,21 +2,25 @@ package fetch
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 
 	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
 	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/base"
 	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/rest"
+	"github.com/context-space/context-space/backend/internal/shared/security"
 )
 
 // FetchAdapter is an adapter for web content fetching operations
 type FetchAdapter struct {
 	*base.BaseAdapter
-	restAdapter domain.Adapter     // Uses RESTAdapter for actual execution
-	operations  Operations         // Map operation ID to OperationDefinition
-	defaults    *OperationDefaults // Operation default values
-	apiKey      string             // API key for authentication
+	restAdapter   domain.Adapter     // Uses RESTAdapter for actual execution
+	nativeFetcher *NativeFetcher     // Fetches URLs itself when set, instead of the Serper Scraping API
+	operations    Operations         // Map operation ID to OperationDefinition
+	defaults      *OperationDefaults // Operation default values
+	apiKey        string             // API key for authentication
 }
 
 // NewFetchAdapter creates a new FetchAdapter
@@ -24,6 +28,7 @@ func NewFetchAdapter(
 	providerInfo *domain.ProviderAdapterInfo,
 	config *domain.AdapterConfig,
 	restAdapter *rest.RESTAdapter,
+	nativeFetcher *NativeFetcher,
 	defaults *OperationDefaults,
 	apiKey string,
 ) *FetchAdapter {
@@ -31,11 +36,12 @@ func NewFetchAdapter(
 	// Create FetchAdapter instance
 	baseAdapter := base.NewBaseAdapter(providerInfo, config)
 	adapter := &FetchAdapter{
-		BaseAdapter: baseAdapter,
-		restAdapter: restAdapter,
-		operations:  make(Operations),
-		defaults:    defaults,
-		apiKey:      apiKey,
+		BaseAdapter:   baseAdapter,
+		restAdapter:   restAdapter,
+		nativeFetcher: nativeFetcher,
+		operations:    make(Operations),
+		defaults:      defaults,
+		apiKey:        apiKey,
 	}
 
 	// Register specific fetch operations
@@ -45,7 +51,8 @@ func NewFetchAdapter(
 }
 
 // Execute finds the appropriate handler for the operationID, prepares parameters
-// including the API key, and delegates execution to the underlying RESTAdapter.
+// including the API key, and delegates execution to the underlying RESTAdapter,
+// or fetches the URL itself when the native backend is configured.
 func (a *FetchAdapter) Execute(
 	ctx context.Context,
 	operationID string,
@@ -54,7 +61,7 @@ func (a *FetchAdapter) Execute(
 ) (interface{}, error) {
 
 	apiKey := a.apiKey
-	if apiKey == "" {
+	if apiKey == "" && a.nativeFetcher == nil {
 		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrCredentialError, "invalid or missing API key credential", http.StatusUnauthorized)
 	}
 
@@ -70,6 +77,10 @@ func (a *FetchAdapter) Execute(
 		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, "PARAMETER_ERROR", fmt.Sprintf("parameter validation failed: %s", err.Error()), http.StatusBadRequest)
 	}
 
+	if a.nativeFetcher != nil {
+		return a.executeNative(ctx, operationID, processedParams)
+	}
+
 	restParams, err := opDef.Handler(ctx, processedParams)
 	if err != nil {
 		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, "HANDLER_ERROR", fmt.Sprintf("handler execution failed: %s", err.Error()), http.StatusInternalServerError)
@@ -93,3 +104,39 @@ func (a *FetchAdapter) Execute(
 
 	return result, nil
 }
+
+// executeNative fetches the URL of the parameters with the native fetcher
+func (a *FetchAdapter) executeNative(ctx context.Context, operationID string, processedParams interface{}) (interface{}, error) {
+	params, ok := processedParams.(*FetchParams)
+	if !ok {
+		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, "HANDLER_ERROR", fmt.Sprintf("internal error: unexpected parameter type for %s", operationID), http.StatusInternalServerError)
+	}
+
+	result, err := a.nativeFetcher.Fetch(ctx, params.URL)
+	if err != nil {
+		return nil, a.nativeFetchError(operationID, err)
+	}
+	return result, nil
+}
+
+// nativeFetchError converts an error of the native fetcher to an adapter error
+func (a *FetchAdapter) nativeFetchError(operationID string, err error) error {
+	providerIdentifier := a.GetProviderAdapterInfo().Identifier
+
+	var netErr net.Error
+	var siteErr *statusError
+	switch {
+	case errors.Is(err, security.ErrBlockedAddress), errors.Is(err, errUnsupportedURL):
+		return domain.NewAdapterError(providerIdentifier, operationID, domain.ErrInvalidParameters, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, errDisallowedByRobots):
+		return domain.NewAdapterError(providerIdentifier, operationID, domain.ErrProviderAPIError, err.Error(), http.StatusForbidden)
+	case errors.Is(err, errUnsupportedContentType):
+		return domain.NewAdapterError(providerIdentifier, operationID, domain.ErrProviderAPIError, err.Error(), http.StatusUnsupportedMediaType)
+	case errors.As(err, &siteErr):
+		return domain.NewAdapterError(providerIdentifier, operationID, domain.ErrProviderAPIError, err.Error(), http.StatusBadGateway)
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return domain.NewAdapterError(providerIdentifier, operationID, domain.ErrTimeout, fmt.Sprintf("fetch timed out: %v", err), http.StatusGatewayTimeout)
+	default:
+		return domain.NewAdapterError(providerIdentifier, operationID, domain.ErrProviderAPIError, fmt.Sprintf("fetch failed: %v", err), http.StatusBadGateway)
+	}
+}
@@ -0,0 +1,510 @@
+package fetch
+
+import (
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// skippedElements never hold readable content
+var skippedElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true, atom.Svg: true,
+	atom.Iframe: true, atom.Object: true, atom.Embed: true, atom.Canvas: true, atom.Form: true,
+	atom.Button: true, atom.Input: true, atom.Select: true, atom.Textarea: true, atom.Head: true,
+}
+
+// boilerplateElements hold navigation and page chrome rather than the main content
+var boilerplateElements = map[atom.Atom]bool{
+	atom.Nav: true, atom.Header: true, atom.Footer: true, atom.Aside: true, atom.Dialog: true,
+}
+
+// blockElements are rendered as separate markdown blocks, other elements are rendered inline
+var blockElements = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true, atom.Center: true,
+	atom.Details: true, atom.Dialog: true, atom.Dd: true, atom.Div: true, atom.Dl: true, atom.Dt: true,
+	atom.Fieldset: true, atom.Figcaption: true, atom.Figure: true, atom.Footer: true, atom.H1: true,
+	atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true, atom.Header: true,
+	atom.Hgroup: true, atom.Hr: true, atom.Li: true, atom.Main: true, atom.Nav: true, atom.Ol: true,
+	atom.P: true, atom.Pre: true, atom.Section: true, atom.Summary: true, atom.Table: true, atom.Ul: true,
+}
+
+var (
+	// boilerplatePattern matches the class or id of elements such as sidebars, menus or cookie banners
+	boilerplatePattern = regexp.MustCompile(`(?i)(^|[\s_-])(sidebar|menu|navbar|nav|breadcrumbs?|footer|comments?|share|social|advert|ads|cookie|banner|popup|modal|newsletter|related)($|[\s_-])`)
+
+	// whitespacePattern matches runs of whitespace collapsed in rendered text
+	whitespacePattern = regexp.MustCompile(`\s+`)
+
+	// blankLinesPattern matches runs of blank lines collapsed in rendered markdown
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// FetchedLink is a link found in the main content of a fetched page
+type FetchedLink struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+// htmlDocument is the readable content extracted from an HTML page
+type htmlDocument struct {
+	Title       string
+	Description string
+	Markdown    string
+	Links       []FetchedLink
+}
+
+// htmlToMarkdown extracts the title, description and main content of an HTML page, rendering the content as
+// markdown with links resolved against the URL of the page
+func htmlToMarkdown(r io.Reader, pageURL *url.URL, maxLinks int) (*htmlDocument, error) {
+	root, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	converter := &markdownConverter{base: pageURL, maxLinks: maxLinks, seenLinks: make(map[string]bool)}
+	if base := findElement(root, atom.Base); base != nil {
+		if href, err := pageURL.Parse(attr(base, "href")); err == nil {
+			converter.base = href
+		}
+	}
+
+	document := &htmlDocument{}
+	document.Title, document.Description = pageMetadata(root)
+
+	content := mainContent(root)
+	markdown := strings.Join(converter.blocks(content), "\n\n")
+	document.Markdown = strings.TrimSpace(blankLinesPattern.ReplaceAllString(markdown, "\n\n"))
+	document.Links = converter.links
+	return document, nil
+}
+
+// pageMetadata returns the title and description of a page from its head, preferring Open Graph values
+func pageMetadata(root *html.Node) (string, string) {
+	var title, description string
+	if element := findElement(root, atom.Title); element != nil {
+		title = collapseWhitespace(textContent(element))
+	}
+	walk(root, func(n *html.Node) bool {
+		if n.DataAtom != atom.Meta {
+			return true
+		}
+		name := strings.ToLower(attr(n, "name") + attr(n, "property"))
+		content := collapseWhitespace(attr(n, "content"))
+		switch {
+		case name == "og:title" && content != "":
+			title = content
+		case name == "og:description" && content != "":
+			description = content
+		case name == "description" && description == "":
+			description = content
+		}
+		return true
+	})
+	return title, description
+}
+
+// mainContent returns the element holding the main content of a page: the largest article or main element,
+// else the container the most paragraph text is directly under, else the body
+func mainContent(root *html.Node) *html.Node {
+	var best *html.Node
+	bestLength := 0
+	walk(root, func(n *html.Node) bool {
+		if n.DataAtom == atom.Article || n.DataAtom == atom.Main || attr(n, "role") == "main" {
+			if length := len(collapseWhitespace(textContent(n))); length > bestLength {
+				best, bestLength = n, length
+			}
+		}
+		return true
+	})
+	if best != nil {
+		return best
+	}
+
+	// Score containers by the text of the paragraphs they hold, half of it for grandparents
+	scores := make(map[*html.Node]int)
+	walk(root, func(n *html.Node) bool {
+		if skippedElements[n.DataAtom] || boilerplateElements[n.DataAtom] || isBoilerplate(n) {
+			return false
+		}
+		if n.DataAtom == atom.P || n.DataAtom == atom.Pre {
+			length := len(collapseWhitespace(textContent(n)))
+			if parent := n.Parent; parent != nil {
+				scores[parent] += length
+				if grandparent := parent.Parent; grandparent != nil {
+					scores[grandparent] += length / 2
+				}
+			}
+		}
+		return true
+	})
+	for n, score := range scores {
+		if score > bestLength {
+			best, bestLength = n, score
+		}
+	}
+	if best != nil && best.DataAtom != atom.Html {
+		return best
+	}
+	if body := findElement(root, atom.Body); body != nil {
+		return body
+	}
+	return root
+}
+
+// markdownConverter renders HTML elements as markdown, collecting the links it renders
+type markdownConverter struct {
+	base      *url.URL
+	maxLinks  int
+	links     []FetchedLink
+	seenLinks map[string]bool
+}
+
+// blocks renders the children of an element as markdown blocks, grouping runs of inline content
+// into paragraphs
+func (c *markdownConverter) blocks(n *html.Node) []string {
+	var blocks []string
+	var paragraph strings.Builder
+	flush := func() {
+		if text := trimLines(paragraph.String()); text != "" {
+			blocks = append(blocks, text)
+		}
+		paragraph.Reset()
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if c.skip(child) {
+			continue
+		}
+		if child.Type == html.ElementNode && blockElements[child.DataAtom] {
+			flush()
+			blocks = append(blocks, c.block(child)...)
+			continue
+		}
+		paragraph.WriteString(c.inline(child))
+	}
+	flush()
+	return blocks
+}
+
+// block renders a block element as markdown blocks
+func (c *markdownConverter) block(n *html.Node) []string {
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		text := strings.ReplaceAll(trimLines(c.inlineChildren(n)), "\n", " ")
+		if text == "" {
+			return nil
+		}
+		level, _ := strconv.Atoi(n.Data[1:])
+		return []string{strings.Repeat("#", level) + " " + text}
+	case atom.Pre:
+		code := strings.Trim(textContent(n), "\n")
+		if strings.TrimSpace(code) == "" {
+			return nil
+		}
+		return []string{"```" + codeLanguage(n) + "\n" + code + "\n```"}
+	case atom.Blockquote:
+		inner := strings.Join(c.blocks(n), "\n\n")
+		if inner == "" {
+			return nil
+		}
+		return []string{prefixLines(inner, "> ", "> ")}
+	case atom.Ul, atom.Ol:
+		if list := c.list(n); list != "" {
+			return []string{list}
+		}
+		return nil
+	case atom.Table:
+		if table := c.table(n); table != "" {
+			return []string{table}
+		}
+		return nil
+	case atom.Hr:
+		return []string{"---"}
+	default:
+		return c.blocks(n)
+	}
+}
+
+// list renders the items of a list, nesting the blocks of each item under its marker
+func (c *markdownConverter) list(n *html.Node) string {
+	ordered := n.DataAtom == atom.Ol
+	number := 1
+	if start, err := strconv.Atoi(attr(n, "start")); err == nil && ordered {
+		number = start
+	}
+
+	var items []string
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.DataAtom != atom.Li || c.skip(child) {
+			continue
+		}
+		marker := "- "
+		if ordered {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+		content := strings.Join(c.blocks(child), "\n")
+		if content == "" {
+			continue
+		}
+		items = append(items, prefixLines(content, marker, strings.Repeat(" ", len(marker))))
+	}
+	return strings.Join(items, "\n")
+}
+
+// table renders the rows of a table, using the first row as the header
+func (c *markdownConverter) table(n *html.Node) string {
+	var rows [][]string
+	columns := 0
+	var collect func(*html.Node)
+	collect = func(parent *html.Node) {
+		for child := parent.FirstChild; child != nil; child = child.NextSibling {
+			switch child.DataAtom {
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				collect(child)
+			case atom.Tr:
+				var cells []string
+				for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.DataAtom != atom.Th && cell.DataAtom != atom.Td {
+						continue
+					}
+					text := strings.ReplaceAll(trimLines(c.inlineChildren(cell)), "\n", " ")
+					cells = append(cells, strings.ReplaceAll(text, "|", "\\|"))
+				}
+				if len(cells) > 0 {
+					rows = append(rows, cells)
+					columns = max(columns, len(cells))
+				}
+			}
+		}
+	}
+	collect(n)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	lines := make([]string, 0, len(rows)+1)
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// inline renders a node as inline markdown
+func (c *markdownConverter) inline(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return whitespacePattern.ReplaceAllString(n.Data, " ")
+	}
+	if n.Type != html.ElementNode {
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		return "\n"
+	case atom.A:
+		text := strings.TrimSpace(whitespacePattern.ReplaceAllString(c.inlineChildren(n), " "))
+		href := c.resolve(attr(n, "href"))
+		if href == "" || text == "" {
+			return text
+		}
+		c.addLink(text, href)
+		return "[" + text + "](" + href + ")"
+	case atom.Img:
+		src := c.resolve(attr(n, "src"))
+		if src == "" {
+			return ""
+		}
+		return "![" + collapseWhitespace(attr(n, "alt")) + "](" + src + ")"
+	case atom.Strong, atom.B:
+		return wrapInline(c.inlineChildren(n), "**")
+	case atom.Em, atom.I:
+		return wrapInline(c.inlineChildren(n), "*")
+	case atom.Del, atom.S, atom.Strike:
+		return wrapInline(c.inlineChildren(n), "~~")
+	case atom.Code, atom.Kbd, atom.Samp:
+		return wrapInline(whitespacePattern.ReplaceAllString(textContent(n), " "), "`")
+	default:
+		return c.inlineChildren(n)
+	}
+}
+
+// inlineChildren renders the children of an element as inline markdown
+func (c *markdownConverter) inlineChildren(n *html.Node) string {
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if !c.skip(child) {
+			sb.WriteString(c.inline(child))
+		}
+	}
+	return sb.String()
+}
+
+// skip reports whether a node holds no readable content
+func (c *markdownConverter) skip(n *html.Node) bool {
+	switch n.Type {
+	case html.TextNode:
+		return false
+	case html.ElementNode:
+		if skippedElements[n.DataAtom] || boilerplateElements[n.DataAtom] || isBoilerplate(n) {
+			return true
+		}
+		_, hidden := attrValue(n, "hidden")
+		return hidden || attr(n, "aria-hidden") == "true"
+	default:
+		return true
+	}
+}
+
+// resolve returns the absolute http(s) URL of a reference, or an empty string for other schemes
+func (c *markdownConverter) resolve(reference string) string {
+	reference = strings.TrimSpace(reference)
+	if reference == "" || strings.HasPrefix(reference, "#") {
+		return ""
+	}
+	resolved, err := c.base.Parse(reference)
+	if err != nil || (resolved.Scheme != "http" && resolved.Scheme != "https") {
+		return ""
+	}
+	return resolved.String()
+}
+
+// addLink records a link of the content, once per URL and up to the configured number of links
+func (c *markdownConverter) addLink(text, href string) {
+	if c.seenLinks[href] || len(c.links) >= c.maxLinks {
+		return
+	}
+	c.seenLinks[href] = true
+	c.links = append(c.links, FetchedLink{Text: text, URL: href})
+}
+
+// isBoilerplate reports whether the class or id of an element marks it as page chrome
+func isBoilerplate(n *html.Node) bool {
+	if n.Type != html.ElementNode || n.DataAtom == atom.Html || n.DataAtom == atom.Body ||
+		n.DataAtom == atom.Article || n.DataAtom == atom.Main {
+		return false
+	}
+	return boilerplatePattern.MatchString(attr(n, "class")) || boilerplatePattern.MatchString(attr(n, "id"))
+}
+
+// codeLanguage returns the language of a code block from a "language-" or "lang-" class
+func codeLanguage(pre *html.Node) string {
+	classes := attr(pre, "class")
+	if code := findElement(pre, atom.Code); code != nil {
+		classes += " " + attr(code, "class")
+	}
+	for _, class := range strings.Fields(classes) {
+		for _, prefix := range []string{"language-", "lang-"} {
+			if strings.HasPrefix(class, prefix) {
+				return strings.TrimPrefix(class, prefix)
+			}
+		}
+	}
+	return ""
+}
+
+// walk visits the element nodes under a node depth first, skipping the children of nodes for which visit
+// returns false
+func walk(n *html.Node, visit func(*html.Node) bool) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && !visit(child) {
+			continue
+		}
+		walk(child, visit)
+	}
+}
+
+// findElement returns the first element of a type under a node
+func findElement(n *html.Node, element atom.Atom) *html.Node {
+	var found *html.Node
+	walk(n, func(child *html.Node) bool {
+		if found == nil && child.DataAtom == element {
+			found = child
+		}
+		return found == nil
+	})
+	return found
+}
+
+// textContent concatenates the text under a node
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && skippedElements[child.DataAtom] {
+			continue
+		}
+		sb.WriteString(textContent(child))
+	}
+	return sb.String()
+}
+
+// attrValue returns the value of an attribute of an element and whether the element has it
+func attrValue(n *html.Node, key string) (string, bool) {
+	for _, attribute := range n.Attr {
+		if attribute.Namespace == "" && attribute.Key == key {
+			return attribute.Val, true
+		}
+	}
+	return "", false
+}
+
+// attr returns the value of an attribute of an element, or an empty string
+func attr(n *html.Node, key string) string {
+	value, _ := attrValue(n, key)
+	return value
+}
+
+// collapseWhitespace collapses runs of whitespace into single spaces and trims the text
+func collapseWhitespace(text string) string {
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(text, " "))
+}
+
+// trimLines trims each line of rendered inline content, dropping leading and trailing empty lines
+func trimLines(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// wrapInline surrounds text with an emphasis marker, keeping surrounding whitespace outside of it
+func wrapInline(text, marker string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	start := strings.Index(text, trimmed)
+	return text[:start] + marker + trimmed + marker + text[start+len(trimmed):]
+}
+
+// prefixLines prefixes the first line of text and indents the following ones
+func prefixLines(text, first, rest string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		switch {
+		case i == 0:
+			lines[i] = first + line
+		case line == "":
+			// Blank lines separating nested blocks carry no trailing whitespace
+			lines[i] = strings.TrimRight(rest, " ")
+		default:
+			lines[i] = rest + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
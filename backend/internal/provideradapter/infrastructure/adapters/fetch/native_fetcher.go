@@ -0,0 +1,280 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/html/charset"
+
+	"github.com/context-space/context-space/backend/internal/shared/security"
+)
+
+// Default limits of the native fetcher
+const (
+	defaultFetchTimeout = 20 * time.Second
+	defaultMaxBytes     = 5 * 1024 * 1024
+	defaultMaxRedirects = 5
+	defaultMaxLinks     = 200
+	defaultFetchUA      = "ContextSpaceFetch/1.0"
+	robotsCacheTTL      = time.Hour
+	robotsCacheMaxHosts = 1024
+)
+
+var (
+	// errUnsupportedURL is returned for URLs that are not absolute http(s) URLs
+	errUnsupportedURL = errors.New("only absolute http and https URLs can be fetched")
+
+	// errTooManyRedirects is returned when a URL redirects more times than allowed
+	errTooManyRedirects = errors.New("too many redirects")
+
+	// errUnsupportedContentType is returned for responses that cannot be rendered as text
+	errUnsupportedContentType = errors.New("unsupported content type")
+)
+
+// statusError is returned when the fetched URL responds with an error status
+type statusError struct {
+	StatusCode int
+	Status     string
+}
+
+// Error returns the error message
+func (e *statusError) Error() string {
+	return fmt.Sprintf("the site responded with %s", e.Status)
+}
+
+// NativeFetchConfig configures the limits and politeness of the native fetcher
+type NativeFetchConfig struct {
+	Timeout          time.Duration // Time limit of a fetch, including redirects and reading the body
+	MaxBytes         int64         // Bytes of the body read, longer bodies are truncated
+	MaxRedirects     int           // Redirects followed
+	MaxLinks         int           // Links of the main content returned
+	RespectRobotsTxt bool          // Whether robots.txt of the site is checked before fetching
+	UserAgent        string        // User agent sent, its product token is used to match robots.txt groups
+}
+
+// FetchResult is the content fetched from a URL
+type FetchResult struct {
+	URL         string        `json:"url"` // Final URL, after redirects
+	StatusCode  int           `json:"status_code"`
+	ContentType string        `json:"content_type"`
+	Title       string        `json:"title,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Markdown    string        `json:"markdown"`
+	Links       []FetchedLink `json:"links,omitempty"`
+	Truncated   bool          `json:"truncated"` // Whether the body exceeded the size limit
+}
+
+// NativeFetcher fetches URLs itself, refusing to connect to internal addresses
+type NativeFetcher struct {
+	config NativeFetchConfig
+	client *http.Client
+
+	robotsMu    sync.Mutex
+	robotsCache map[string]robotsCacheEntry
+}
+
+// robotsCacheEntry is the robots.txt rules of a site, cached until they expire
+type robotsCacheEntry struct {
+	rules     *robotsRules
+	expiresAt time.Time
+}
+
+// NewNativeFetcher creates a new NativeFetcher, applying defaults to unset limits
+func NewNativeFetcher(config NativeFetchConfig) *NativeFetcher {
+	if config.Timeout <= 0 {
+		config.Timeout = defaultFetchTimeout
+	}
+	if config.MaxBytes <= 0 {
+		config.MaxBytes = defaultMaxBytes
+	}
+	if config.MaxRedirects <= 0 {
+		config.MaxRedirects = defaultMaxRedirects
+	}
+	if config.MaxLinks <= 0 {
+		config.MaxLinks = defaultMaxLinks
+	}
+	if config.UserAgent == "" {
+		config.UserAgent = defaultFetchUA
+	}
+
+	transport := security.NewRestrictedTransport()
+	transport.ResponseHeaderTimeout = config.Timeout
+	maxRedirects := config.MaxRedirects
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   config.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return errTooManyRedirects
+			}
+			if err := checkURL(req.URL); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+
+	return &NativeFetcher{
+		config:      config,
+		client:      client,
+		robotsCache: make(map[string]robotsCacheEntry),
+	}
+}
+
+// Fetch fetches a URL and extracts its main content as markdown
+func (f *NativeFetcher) Fetch(ctx context.Context, rawURL string) (*FetchResult, error) {
+	target, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUnsupportedURL, err)
+	}
+	if err := checkURL(target); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, f.config.Timeout)
+	defer cancel()
+
+	if f.config.RespectRobotsTxt {
+		rules, err := f.robots(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		if !rules.allowed(target.RequestURI()) {
+			return nil, errDisallowedByRobots
+		}
+	}
+
+	resp, err := f.get(ctx, target.String(), "text/html,application/xhtml+xml,text/plain;q=0.9,*/*;q=0.5")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, &statusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	// Read one byte past the limit to tell whether the body was truncated
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.config.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	result := &FetchResult{
+		URL:         resp.Request.URL.String(),
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	if int64(len(body)) > f.config.MaxBytes {
+		body = body[:f.config.MaxBytes]
+		result.Truncated = true
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(result.ContentType)
+	if mediaType == "" {
+		mediaType = http.DetectContentType(body)
+		mediaType, _, _ = mime.ParseMediaType(mediaType)
+	}
+	decoded, err := charset.NewReader(bytes.NewReader(body), result.ContentType)
+	if err != nil {
+		decoded = bytes.NewReader(body)
+	}
+
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		document, err := htmlToMarkdown(decoded, resp.Request.URL, f.config.MaxLinks)
+		if err != nil {
+			return nil, err
+		}
+		result.Title = document.Title
+		result.Description = document.Description
+		result.Markdown = document.Markdown
+		result.Links = document.Links
+	case strings.HasPrefix(mediaType, "text/") || mediaType == "application/json" ||
+		strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml") || mediaType == "application/xml":
+		text, err := io.ReadAll(decoded)
+		if err != nil {
+			return nil, err
+		}
+		result.Markdown = string(text)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedContentType, mediaType)
+	}
+
+	return result, nil
+}
+
+// robots returns the robots.txt rules of the site of a URL, fetching them unless cached
+func (f *NativeFetcher) robots(ctx context.Context, target *url.URL) (*robotsRules, error) {
+	site := target.Scheme + "://" + target.Host
+
+	f.robotsMu.Lock()
+	entry, ok := f.robotsCache[site]
+	f.robotsMu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.rules, nil
+	}
+
+	resp, err := f.get(ctx, site+"/robots.txt", "text/plain")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var rules *robotsRules
+	switch {
+	case resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices:
+		productToken, _, _ := strings.Cut(f.config.UserAgent, "/")
+		rules = parseRobots(resp.Body, productToken)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		// An unreachable robots.txt disallows the whole site, as specified by RFC 9309
+		rules = &robotsRules{rules: []robotsRule{{allow: false, pattern: "/", regex: robotsPattern("/")}}}
+	default:
+		// A missing robots.txt allows everything
+		rules = &robotsRules{}
+	}
+
+	f.robotsMu.Lock()
+	if len(f.robotsCache) >= robotsCacheMaxHosts {
+		f.robotsCache = make(map[string]robotsCacheEntry)
+	}
+	f.robotsCache[site] = robotsCacheEntry{rules: rules, expiresAt: time.Now().Add(robotsCacheTTL)}
+	f.robotsMu.Unlock()
+
+	return rules, nil
+}
+
+// get sends a GET request with the fetcher's user agent
+func (f *NativeFetcher) get(ctx context.Context, rawURL, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", f.config.UserAgent)
+	req.Header.Set("Accept", accept)
+	return f.client.Do(req)
+}
+
+// checkURL rejects URLs that are not absolute http(s) URLs, and literal IP hosts that are blocked. Hostnames
+// are checked once resolved, when connecting.
+func checkURL(target *url.URL) error {
+	if (target.Scheme != "http" && target.Scheme != "https") || target.Hostname() == "" {
+		return errUnsupportedURL
+	}
+	host := target.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if err := security.DialControl("tcp", net.JoinHostPort(host, "0"), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package fetch
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// errDisallowedByRobots is returned when robots.txt of a site disallows fetching a path
+var errDisallowedByRobots = errors.New("fetching this URL is disallowed by robots.txt")
+
+// maxRobotsBytes caps the size of robots.txt files read, as recommended by RFC 9309
+const maxRobotsBytes = 500 * 1024
+
+// robotsRule is an allow or disallow rule of a robots.txt group
+type robotsRule struct {
+	allow   bool
+	pattern string
+	regex   *regexp.Regexp
+}
+
+// robotsRules are the rules of robots.txt that apply to the fetcher's user agent
+type robotsRules struct {
+	rules []robotsRule
+}
+
+// parseRobots parses robots.txt, keeping the rules of the groups naming the product token of the user agent,
+// or of the "*" groups when no group names it
+func parseRobots(r io.Reader, productToken string) *robotsRules {
+	productToken = strings.ToLower(productToken)
+	var matched, wildcard []robotsRule
+	var agents []string
+	inRules := false
+
+	scanner := bufio.NewScanner(io.LimitReader(r, maxRobotsBytes))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// A user-agent line after rules starts a new group
+			if inRules {
+				agents = nil
+				inRules = false
+			}
+			agents = append(agents, strings.ToLower(value))
+		case "allow", "disallow":
+			inRules = true
+			// An empty disallow allows everything, which needs no rule
+			if value == "" {
+				continue
+			}
+			rule := robotsRule{allow: key == "allow", pattern: value, regex: robotsPattern(value)}
+			for _, agent := range agents {
+				if agent == "*" {
+					wildcard = append(wildcard, rule)
+				} else if agent != "" && strings.HasPrefix(productToken, agent) {
+					matched = append(matched, rule)
+				}
+			}
+		}
+	}
+
+	if matched != nil {
+		return &robotsRules{rules: matched}
+	}
+	return &robotsRules{rules: wildcard}
+}
+
+// robotsPattern compiles a robots.txt path pattern, where "*" matches any characters and a trailing "$"
+// anchors the end of the path
+func robotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
+
+// allowed reports whether a path, including its query, may be fetched. The most specific matching rule
+// wins, and allow wins over disallow between rules as specific.
+func (r *robotsRules) allowed(path string) bool {
+	if path == "" {
+		path = "/"
+	}
+	allowed := true
+	longest := -1
+	for _, rule := range r.rules {
+		if !rule.regex.MatchString(path) {
+			continue
+		}
+		if len(rule.pattern) > longest || (len(rule.pattern) == longest && rule.allow) {
+			longest = len(rule.pattern)
+			allowed = rule.allow
+		}
+	}
+	return allowed
+}
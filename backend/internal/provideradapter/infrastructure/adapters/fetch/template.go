@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/bytedance/sonic"
+
 	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
 	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/registry"
 	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/rest"
@@ -17,6 +19,46 @@ const (
 	apikeyParamName = "X-API-KEY"
 )
 
+// Backends fetch_content can run on
+const (
+	backendNative = "native" // Fetches URLs from the server itself
+	backendSerper = "serper" // Proxies to the Serper Scraping API, which needs an API key
+)
+
+// FetchConfig is the fetch_config section of the provider configuration
+type FetchConfig struct {
+	Backend          string `json:"backend"`            // Backend of fetch_content, native when unset
+	TimeoutSeconds   int    `json:"timeout_seconds"`    // Time limit of a native fetch
+	MaxBytes         int64  `json:"max_bytes"`          // Bytes of a page read by native fetches
+	MaxRedirects     int    `json:"max_redirects"`      // Redirects followed by native fetches
+	RespectRobotsTxt bool   `json:"respect_robots_txt"` // Whether native fetches honor robots.txt
+	UserAgent        string `json:"user_agent"`         // User agent of native fetches
+}
+
+// parseFetchConfig reads the fetch_config section of the provider configuration, defaulting to the native backend
+func parseFetchConfig(provider *domain.ProviderAdapterConfig) (*FetchConfig, error) {
+	jsonBytes, err := sonic.Marshal(provider.CustomConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal provider: %w", err)
+	}
+
+	var jsonAttributes struct {
+		FetchConfig *FetchConfig `json:"fetch_config"`
+	}
+	if err := sonic.Unmarshal(jsonBytes, &jsonAttributes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal provider: %w", err)
+	}
+
+	config := jsonAttributes.FetchConfig
+	if config == nil {
+		config = &FetchConfig{}
+	}
+	if config.Backend == "" {
+		config.Backend = backendNative
+	}
+	return config, nil
+}
+
 // Register the Fetch adapter template during package initialization
 func init() {
 	// Type assertion to ensure the adapter implements the necessary interfaces
@@ -45,16 +87,34 @@ func (t *FetchTemplate) CreateAdapter(provider *domain.ProviderAdapterConfig) (d
 		RetryBackoff: 1 * time.Second,
 	}
 
+	fetchConfig, err := parseFetchConfig(provider)
+	if err != nil {
+		return nil, err
+	}
+
 	restConfig := &rest.RESTConfig{
 		BaseURL: baseURL,
 	}
 	restAdapterInstance := rest.NewRESTAdapter(providerInfo, adapterConfig, restConfig)
 
-	apiKey := provider.CustomConfig["api_key"].(string)
+	// The native fetcher is only created when selected, the Serper backend needs it nil
+	var nativeFetcher *NativeFetcher
+	if fetchConfig.Backend == backendNative {
+		nativeFetcher = NewNativeFetcher(NativeFetchConfig{
+			Timeout:          time.Duration(fetchConfig.TimeoutSeconds) * time.Second,
+			MaxBytes:         fetchConfig.MaxBytes,
+			MaxRedirects:     fetchConfig.MaxRedirects,
+			RespectRobotsTxt: fetchConfig.RespectRobotsTxt,
+			UserAgent:        fetchConfig.UserAgent,
+		})
+	}
+
+	apiKey, _ := provider.CustomConfig["api_key"].(string)
 	adapter := NewFetchAdapter(
 		providerInfo,
 		adapterConfig,
 		restAdapterInstance,
+		nativeFetcher,
 		&opDefaults,
 		apiKey,
 	)
@@ -76,13 +136,23 @@ func (t *FetchTemplate) ValidateConfig(provider *domain.ProviderAdapterConfig) e
 		return fmt.Errorf("invalid or missing auth_type, must be 'apikey'")
 	}
 
-	apiKey, ok := provider.CustomConfig["api_key"]
-	if !ok {
-		return fmt.Errorf("api_key is required")
+	fetchConfig, err := parseFetchConfig(provider)
+	if err != nil {
+		return err
 	}
 
-	if apiKey.(string) == "" {
-		return fmt.Errorf("api_key is required")
+	switch fetchConfig.Backend {
+	case backendNative:
+		if fetchConfig.TimeoutSeconds < 0 || fetchConfig.MaxBytes < 0 || fetchConfig.MaxRedirects < 0 {
+			return fmt.Errorf("fetch_config limits must not be negative")
+		}
+	case backendSerper:
+		apiKey, _ := provider.CustomConfig["api_key"].(string)
+		if apiKey == "" {
+			return fmt.Errorf("api_key is required for the serper backend")
+		}
+	default:
+		return fmt.Errorf("invalid fetch_config backend '%s', must be '%s' or '%s'", fetchConfig.Backend, backendNative, backendSerper)
 	}
 
 	return nil
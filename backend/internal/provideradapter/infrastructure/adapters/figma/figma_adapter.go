@@ -71,21 +71,21 @@ func NewFigmaAdapter(
 	return adapter
 }
 
-// createOAuth2Config creates the oauth2.Config object.
-func (a *FigmaAdapter) createOAuth2Config(redirectURL string, scopes []string) *oauth2.Config {
+// createOAuth2Config creates the oauth2.Config object, using the OAuth app override of the context if any.
+func (a *FigmaAdapter) createOAuth2Config(ctx context.Context, redirectURL string, scopes []string) *oauth2.Config {
 	endpoint := oauth2.Endpoint{
 		AuthURL:   authURL,
 		TokenURL:  tokenURL,
 		AuthStyle: oauth2.AuthStyleInHeader,
 	}
 
-	return &oauth2.Config{
+	return base.ApplyOAuthAppOverride(ctx, &oauth2.Config{
 		ClientID:     a.oauthConfig.ClientID,
 		ClientSecret: a.oauthConfig.ClientSecret,
 		RedirectURL:  redirectURL,
 		Scopes:       scopes,
 		Endpoint:     endpoint,
-	}
+	})
 }
 
 // ShouldRefreshToken checks if the token should be refreshed
@@ -98,7 +98,7 @@ func (a *FigmaAdapter) ShouldRefreshToken(oldToken *oauth2.Token) bool {
 
 // RefreshOAuthToken refreshes an OAuth token.
 func (a *FigmaAdapter) RefreshOAuthToken(ctx context.Context, oldToken *oauth2.Token) (*oauth2.Token, error) {
-	oauth2Config := a.createOAuth2Config("", nil)
+	oauth2Config := a.createOAuth2Config(ctx, "", nil)
 	tokenSource := oauth2Config.TokenSource(ctx, oldToken)
 	newToken, err := tokenSource.Token()
 	if err != nil {
@@ -118,7 +118,7 @@ func (a *FigmaAdapter) GenerateOAuthURL(ctx context.Context,
 	redirectURL, state, codeChallenge string,
 	scopes []string,
 ) (string, error) {
-	oauth2Config := a.createOAuth2Config(redirectURL, scopes)
+	oauth2Config := a.createOAuth2Config(ctx, redirectURL, scopes)
 	return oauth2Config.AuthCodeURL(state,
 		oauth2.SetAuthURLParam("code_challenge", codeChallenge),
 		/*, oauth2.AccessTypeOffline*/), nil
@@ -126,7 +126,7 @@ func (a *FigmaAdapter) GenerateOAuthURL(ctx context.Context,
 
 // ExchangeCodeForTokens exchanges an authorization code for tokens.
 func (a *FigmaAdapter) ExchangeCodeForTokens(ctx context.Context, code, redirectURL, codeVerifier string) (*oauth2.Token, error) {
-	oauth2Config := a.createOAuth2Config(redirectURL, nil) // Scopes not needed for exchange usually
+	oauth2Config := a.createOAuth2Config(ctx, redirectURL, nil) // Scopes not needed for exchange usually
 	token, err := oauth2Config.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", codeVerifier))
 	if err != nil {
 		return nil, domain.NewAdapterError(
@@ -185,7 +185,7 @@ func (a *FigmaAdapter) Execute(
 		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrInternal, fmt.Sprintf("error checking permissions: %v", err), http.StatusInternalServerError)
 	}
 	if !allScopesPresent {
-		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrCredentialError, fmt.Sprintf("missing required permissions: %v", missingIDs), http.StatusForbidden)
+		return nil, domain.NewInsufficientScopeError(a.GetProviderAdapterInfo().Identifier, operationID, missingIDs)
 	}
 
 	processedParams, err := a.ProcessParams(operationID, params)
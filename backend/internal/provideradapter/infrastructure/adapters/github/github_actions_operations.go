@@ -0,0 +1,373 @@
+package github
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	goGithub "github.com/google/go-github/v71/github"
+
+	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/vcr"
+)
+
+const (
+	// jobLogsDownloadTimeout bounds the download of the logs of a job, which are streamed in full to find their tail
+	jobLogsDownloadTimeout = 2 * time.Minute
+	// jobLogsMaxRedirects is how many redirects GitHub may answer before handing out the download URL of job logs
+	jobLogsMaxRedirects = 2
+	// maxJobLogLineBytes cuts log lines longer than this, such as minified output or base64 dumps
+	maxJobLogLineBytes = 4 * 1024
+	// maxJobLogBytes caps the size of the returned log, the oldest lines of the tail are dropped beyond it
+	maxJobLogBytes = 64 * 1024
+)
+
+// ListWorkflowRunsParams defines parameters for listing workflow runs.
+type ListWorkflowRunsParams struct {
+	Owner      string `mapstructure:"owner" validate:"required"`
+	Repo       string `mapstructure:"repo" validate:"required"`
+	WorkflowID string `mapstructure:"workflow_id" validate:"omitempty"` // Workflow ID or file name (e.g. ci.yml). Default: runs of all workflows
+	Branch     string `mapstructure:"branch" validate:"omitempty"`
+	Event      string `mapstructure:"event" validate:"omitempty"` // Event that triggered the run, e.g. push or pull_request
+	Status     string `mapstructure:"status" validate:"omitempty,oneof=completed action_required cancelled failure neutral skipped stale success timed_out in_progress queued requested waiting pending"`
+	HeadSHA    string `mapstructure:"head_sha" validate:"omitempty"`
+	Page       int    `mapstructure:"page" validate:"omitempty,gte=1" default:"1"`
+	PerPage    int    `mapstructure:"per_page" validate:"omitempty,gte=1,lte=100" default:"30"`
+}
+
+// GetWorkflowRunParams defines parameters for getting a workflow run.
+type GetWorkflowRunParams struct {
+	Owner string `mapstructure:"owner" validate:"required"`
+	Repo  string `mapstructure:"repo" validate:"required"`
+	RunID int64  `mapstructure:"run_id" validate:"required,gt=0"`
+}
+
+// ListWorkflowRunJobsParams defines parameters for listing the jobs of a workflow run.
+type ListWorkflowRunJobsParams struct {
+	Owner   string `mapstructure:"owner" validate:"required"`
+	Repo    string `mapstructure:"repo" validate:"required"`
+	RunID   int64  `mapstructure:"run_id" validate:"required,gt=0"`
+	Filter  string `mapstructure:"filter" validate:"omitempty,oneof=latest all" default:"latest"` // latest: jobs of the most recent attempt, all: jobs of every attempt
+	Page    int    `mapstructure:"page" validate:"omitempty,gte=1" default:"1"`
+	PerPage int    `mapstructure:"per_page" validate:"omitempty,gte=1,lte=100" default:"30"`
+}
+
+// GetJobLogsParams defines parameters for getting the logs of a workflow job.
+type GetJobLogsParams struct {
+	Owner     string `mapstructure:"owner" validate:"required"`
+	Repo      string `mapstructure:"repo" validate:"required"`
+	JobID     int64  `mapstructure:"job_id" validate:"required,gt=0"`
+	TailLines int    `mapstructure:"tail_lines" validate:"omitempty,gte=1,lte=5000" default:"200"` // Number of lines to return from the end of the log
+}
+
+// RerunFailedJobsParams defines parameters for re-running the failed jobs of a workflow run.
+type RerunFailedJobsParams struct {
+	Owner string `mapstructure:"owner" validate:"required"`
+	Repo  string `mapstructure:"repo" validate:"required"`
+	RunID int64  `mapstructure:"run_id" validate:"required,gt=0"`
+}
+
+// ListCheckRunsParams defines parameters for listing the check runs of a ref.
+type ListCheckRunsParams struct {
+	Owner     string `mapstructure:"owner" validate:"required"`
+	Repo      string `mapstructure:"repo" validate:"required"`
+	Ref       string `mapstructure:"ref" validate:"required"` // SHA, branch or tag name
+	CheckName string `mapstructure:"check_name" validate:"omitempty"`
+	Status    string `mapstructure:"status" validate:"omitempty,oneof=queued in_progress completed"`
+	Filter    string `mapstructure:"filter" validate:"omitempty,oneof=latest all" default:"latest"`
+	Page      int    `mapstructure:"page" validate:"omitempty,gte=1" default:"1"`
+	PerPage   int    `mapstructure:"per_page" validate:"omitempty,gte=1,lte=100" default:"30"`
+}
+
+// ListCheckSuitesParams defines parameters for listing the check suites of a ref.
+type ListCheckSuitesParams struct {
+	Owner     string `mapstructure:"owner" validate:"required"`
+	Repo      string `mapstructure:"repo" validate:"required"`
+	Ref       string `mapstructure:"ref" validate:"required"` // SHA, branch or tag name
+	CheckName string `mapstructure:"check_name" validate:"omitempty"`
+	Page      int    `mapstructure:"page" validate:"omitempty,gte=1" default:"1"`
+	PerPage   int    `mapstructure:"per_page" validate:"omitempty,gte=1,lte=100" default:"30"`
+}
+
+// DispatchWorkflowParams defines parameters for triggering a workflow_dispatch event.
+type DispatchWorkflowParams struct {
+	Owner      string                 `mapstructure:"owner" validate:"required"`
+	Repo       string                 `mapstructure:"repo" validate:"required"`
+	WorkflowID string                 `mapstructure:"workflow_id" validate:"required"` // Workflow ID or file name (e.g. deploy.yml)
+	Ref        string                 `mapstructure:"ref" validate:"required"`         // Branch or tag to run the workflow on
+	Inputs     map[string]interface{} `mapstructure:"inputs" validate:"omitempty"`     // Input keys and values configured in the workflow file
+}
+
+// SearchCodeParams defines parameters for searching code.
+type SearchCodeParams struct {
+	Query   string `mapstructure:"query" validate:"required"` // Search query with qualifiers, e.g. "addClass in:file language:js repo:jquery/jquery"
+	Page    int    `mapstructure:"page" validate:"omitempty,gte=1" default:"1"`
+	PerPage int    `mapstructure:"per_page" validate:"omitempty,gte=1,lte=100" default:"30"`
+}
+
+// JobLogs is the tail of the logs of a workflow job
+type JobLogs struct {
+	JobID      int64  `json:"job_id"`
+	Log        string `json:"log"`         // Last lines of the log, joined by newlines
+	Lines      int    `json:"lines"`       // Number of lines in log
+	TotalLines int    `json:"total_lines"` // Number of lines in the full log
+	Truncated  bool   `json:"truncated"`   // Whether lines were left out or cut
+}
+
+func handleListWorkflowRuns(ctx context.Context, client *goGithub.Client, params interface{}) (interface{}, error) {
+	p := params.(*ListWorkflowRunsParams)
+	opts := &goGithub.ListWorkflowRunsOptions{
+		Branch:  p.Branch,
+		Event:   p.Event,
+		Status:  p.Status,
+		HeadSHA: p.HeadSHA,
+		ListOptions: goGithub.ListOptions{
+			Page:    p.Page,
+			PerPage: p.PerPage,
+		},
+	}
+
+	var runs *goGithub.WorkflowRuns
+	var resp *goGithub.Response
+	var err error
+	switch workflowID, isID := parseWorkflowID(p.WorkflowID); {
+	case p.WorkflowID == "":
+		runs, resp, err = client.Actions.ListRepositoryWorkflowRuns(ctx, p.Owner, p.Repo, opts)
+	case isID:
+		runs, resp, err = client.Actions.ListWorkflowRunsByID(ctx, p.Owner, p.Repo, workflowID, opts)
+	default:
+		runs, resp, err = client.Actions.ListWorkflowRunsByFileName(ctx, p.Owner, p.Repo, p.WorkflowID, opts)
+	}
+	if err := handleGitHubResponse(resp, err, "list workflow runs"); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+func handleGetWorkflowRun(ctx context.Context, client *goGithub.Client, params interface{}) (interface{}, error) {
+	p := params.(*GetWorkflowRunParams)
+	run, resp, err := client.Actions.GetWorkflowRunByID(ctx, p.Owner, p.Repo, p.RunID)
+	if err := handleGitHubResponse(resp, err, "get workflow run"); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func handleListWorkflowRunJobs(ctx context.Context, client *goGithub.Client, params interface{}) (interface{}, error) {
+	p := params.(*ListWorkflowRunJobsParams)
+	opts := &goGithub.ListWorkflowJobsOptions{
+		Filter: p.Filter,
+		ListOptions: goGithub.ListOptions{
+			Page:    p.Page,
+			PerPage: p.PerPage,
+		},
+	}
+
+	jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, p.Owner, p.Repo, p.RunID, opts)
+	if err := handleGitHubResponse(resp, err, "list workflow run jobs"); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func handleGetJobLogs(ctx context.Context, client *goGithub.Client, params interface{}) (interface{}, error) {
+	p := params.(*GetJobLogsParams)
+
+	// GitHub redirects to a short-lived download URL, which must be fetched without the GitHub authorization header.
+	// The redirect is the successful response, so it is not checked by handleGitHubResponse
+	logsURL, resp, err := client.Actions.GetWorkflowJobLogs(ctx, p.Owner, p.Repo, p.JobID, jobLogsMaxRedirects)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job logs: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logsURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job logs request: %w", err)
+	}
+	logsResp, err := vcr.NewHTTPClient(jobLogsDownloadTimeout).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download job logs: %w", err)
+	}
+	defer func() { _ = logsResp.Body.Close() }()
+
+	if logsResp.StatusCode < http.StatusOK || logsResp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("failed to download job logs: %s", logsResp.Status)
+	}
+
+	logs, err := tailJobLogs(logsResp.Body, p.TailLines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job logs: %w", err)
+	}
+	logs.JobID = p.JobID
+	return logs, nil
+}
+
+func handleRerunFailedJobs(ctx context.Context, client *goGithub.Client, params interface{}) (interface{}, error) {
+	p := params.(*RerunFailedJobsParams)
+	resp, err := client.Actions.RerunFailedJobsByID(ctx, p.Owner, p.Repo, p.RunID)
+	if err := handleGitHubResponse(resp, err, "re-run failed jobs"); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"success": true, "status": resp.Status}, nil
+}
+
+func handleListCheckRuns(ctx context.Context, client *goGithub.Client, params interface{}) (interface{}, error) {
+	p := params.(*ListCheckRunsParams)
+	opts := &goGithub.ListCheckRunsOptions{
+		ListOptions: goGithub.ListOptions{
+			Page:    p.Page,
+			PerPage: p.PerPage,
+		},
+	}
+	if p.CheckName != "" {
+		opts.CheckName = &p.CheckName
+	}
+	if p.Status != "" {
+		opts.Status = &p.Status
+	}
+	if p.Filter != "" {
+		opts.Filter = &p.Filter
+	}
+
+	checkRuns, resp, err := client.Checks.ListCheckRunsForRef(ctx, p.Owner, p.Repo, p.Ref, opts)
+	if err := handleGitHubResponse(resp, err, "list check runs"); err != nil {
+		return nil, err
+	}
+	return checkRuns, nil
+}
+
+func handleListCheckSuites(ctx context.Context, client *goGithub.Client, params interface{}) (interface{}, error) {
+	p := params.(*ListCheckSuitesParams)
+	opts := &goGithub.ListCheckSuiteOptions{
+		ListOptions: goGithub.ListOptions{
+			Page:    p.Page,
+			PerPage: p.PerPage,
+		},
+	}
+	if p.CheckName != "" {
+		opts.CheckName = &p.CheckName
+	}
+
+	checkSuites, resp, err := client.Checks.ListCheckSuitesForRef(ctx, p.Owner, p.Repo, p.Ref, opts)
+	if err := handleGitHubResponse(resp, err, "list check suites"); err != nil {
+		return nil, err
+	}
+	return checkSuites, nil
+}
+
+func handleDispatchWorkflow(ctx context.Context, client *goGithub.Client, params interface{}) (interface{}, error) {
+	p := params.(*DispatchWorkflowParams)
+	event := goGithub.CreateWorkflowDispatchEventRequest{
+		Ref:    p.Ref,
+		Inputs: p.Inputs,
+	}
+
+	var resp *goGithub.Response
+	var err error
+	if workflowID, isID := parseWorkflowID(p.WorkflowID); isID {
+		resp, err = client.Actions.CreateWorkflowDispatchEventByID(ctx, p.Owner, p.Repo, workflowID, event)
+	} else {
+		resp, err = client.Actions.CreateWorkflowDispatchEventByFileName(ctx, p.Owner, p.Repo, p.WorkflowID, event)
+	}
+	if err := handleGitHubResponse(resp, err, "dispatch workflow"); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"success": true, "status": resp.Status}, nil
+}
+
+func handleSearchCode(ctx context.Context, client *goGithub.Client, params interface{}) (interface{}, error) {
+	p := params.(*SearchCodeParams)
+	opts := &goGithub.SearchOptions{
+		ListOptions: goGithub.ListOptions{
+			Page:    p.Page,
+			PerPage: p.PerPage,
+		},
+	}
+
+	results, resp, err := client.Search.Code(ctx, p.Query, opts)
+	if err := handleGitHubResponse(resp, err, "search code"); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// parseWorkflowID tells a numeric workflow ID from a workflow file name
+func parseWorkflowID(workflow string) (int64, bool) {
+	workflowID, err := strconv.ParseInt(workflow, 10, 64)
+	return workflowID, err == nil
+}
+
+// tailJobLogs streams the logs of a job, keeping their last tailLines lines within maxJobLogBytes
+func tailJobLogs(r io.Reader, tailLines int) (*JobLogs, error) {
+	reader := bufio.NewReaderSize(r, maxJobLogLineBytes)
+	ring := make([]string, tailLines)
+	totalLines := 0
+	truncated := false
+
+	for {
+		line, cut, err := readJobLogLine(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		ring[totalLines%tailLines] = line
+		totalLines++
+		truncated = truncated || cut
+	}
+
+	count := min(totalLines, tailLines)
+	lines := make([]string, 0, count)
+	size := 0
+	// Walk back from the last line so that the newest lines are kept within the byte budget
+	for i := totalLines - 1; i >= totalLines-count; i-- {
+		line := ring[i%tailLines]
+		if size+len(line)+1 > maxJobLogBytes {
+			break
+		}
+		size += len(line) + 1
+		lines = append(lines, line)
+	}
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+
+	return &JobLogs{
+		Log:        strings.Join(lines, "\n"),
+		Lines:      len(lines),
+		TotalLines: totalLines,
+		Truncated:  truncated || len(lines) < totalLines,
+	}, nil
+}
+
+// readJobLogLine reads the next log line without its line ending, cutting it at maxJobLogLineBytes,
+// io.EOF is only returned once no line is left
+func readJobLogLine(reader *bufio.Reader) (string, bool, error) {
+	fragment, err := reader.ReadSlice('\n')
+	line := string(fragment)
+	cut := false
+	// Skip the rest of a line longer than the buffer
+	for errors.Is(err, bufio.ErrBufferFull) {
+		cut = true
+		_, err = reader.ReadSlice('\n')
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", false, err
+	}
+	if errors.Is(err, io.EOF) && line == "" {
+		return "", false, io.EOF
+	}
+
+	line = strings.TrimRight(line, "\r\n")
+	if cut {
+		line = strings.ToValidUTF8(line, "")
+	}
+	return line, cut, nil
+}
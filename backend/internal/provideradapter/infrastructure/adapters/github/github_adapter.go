@@ -1,10 +1,14 @@
 package github
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 	"time"
 
+	"github.com/bytedance/sonic"
 	credDomain "github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
 	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/base"
 	"golang.org/x/oauth2"
@@ -16,9 +20,10 @@ import (
 // GitHubAdapter is an adapter for GitHub
 type GitHubAdapter struct {
 	*base.BaseAdapter
-	oauthConfig   *domain.OAuthConfig
-	operations    Operations
-	permissionSet domain.PermissionSet
+	oauthConfig        *domain.OAuthConfig
+	operations         Operations
+	permissionSet      domain.PermissionSet
+	installationTokens *installationTokenCache // Installation tokens of GitHub App installation credentials
 }
 
 // NewGitHubAdapter creates a new GitHub adapter
@@ -31,10 +36,11 @@ func NewGitHubAdapter(
 	baseAdapter := base.NewBaseAdapter(providerAdapterInfo, config)
 
 	adapter := &GitHubAdapter{
-		BaseAdapter:   baseAdapter,
-		oauthConfig:   oauthConfig,
-		operations:    make(Operations),
-		permissionSet: permissions,
+		BaseAdapter:        baseAdapter,
+		oauthConfig:        oauthConfig,
+		operations:         make(Operations),
+		permissionSet:      permissions,
+		installationTokens: newInstallationTokenCache(),
 	}
 
 	adapter.registerOperations()
@@ -56,20 +62,69 @@ func (a *GitHubAdapter) RefreshOAuthToken(
 	oldToken *oauth2.Token,
 ) (*oauth2.Token, error) {
 	// GitHub doesn't support refresh tokens for OAuth apps, the following is a sample implementation for other third-party providers
-	newToken, err := a.createOAuth2Config("", nil).TokenSource(ctx, oldToken).Token()
+	newToken, err := a.createOAuth2Config(ctx, "", nil).TokenSource(ctx, oldToken).Token()
 	if err != nil {
 		return nil, fmt.Errorf("failed to refresh token: %w", err)
 	}
 	return newToken, nil
 }
 
+// RevokeOAuthToken deletes the user's grant of the OAuth app, revoking every token issued through it
+func (a *GitHubAdapter) RevokeOAuthToken(ctx context.Context, token *oauth2.Token) error {
+	body, err := sonic.Marshal(map[string]string{"access_token": token.AccessToken})
+	if err != nil {
+		return fmt.Errorf("failed to encode revocation request: %w", err)
+	}
+
+	oauthConfig := base.OAuthClientConfig(ctx, a.oauthConfig)
+	endpoint := base.APIBaseURL(ctx, apiBaseURL) + fmt.Sprintf(revokeGrantPath, url.PathEscape(oauthConfig.ClientID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create revocation request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.SetBasicAuth(oauthConfig.ClientID, oauthConfig.ClientSecret)
+
+	// GitHub answers 404 when the grant no longer exists
+	_, err = base.DoTokenRevocation(a.GetProviderAdapterInfo().Identifier, req, http.StatusNotFound)
+	return err
+}
+
+// VerifyCredential checks the token by fetching the authenticated user, as get_me does,
+// a GitHub App installation is checked by minting a new installation token
+func (a *GitHubAdapter) VerifyCredential(ctx context.Context, credential interface{}) error {
+	if installationCred, ok := credential.(*credDomain.AppInstallationCredential); ok {
+		token, err := a.mintInstallationToken(ctx, installationCred)
+		if err != nil {
+			return err
+		}
+		a.installationTokens.put(installationCred.ID, token, time.Now())
+		return nil
+	}
+
+	oauthCred, ok := credential.(*credDomain.OAuthCredential)
+	if !ok || oauthCred == nil || oauthCred.Token == nil {
+		return fmt.Errorf("invalid credential type for GitHub")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base.APIBaseURL(ctx, apiBaseURL)+userPath, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create verification request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+oauthCred.Token.AccessToken)
+
+	_, err = base.DoCredentialVerification(a.GetProviderAdapterInfo().Identifier, req)
+	return err
+}
+
 // GenerateOAuthURL generates an OAuth authorization URL
 func (a *GitHubAdapter) GenerateOAuthURL(
 	ctx context.Context,
 	redirectURL, state, codeChallenge string,
 	scopes []string,
 ) (string, error) {
-	oauth2Config := a.createOAuth2Config(redirectURL, scopes)
+	oauth2Config := a.createOAuth2Config(ctx, redirectURL, scopes)
 
 	// Generate the authorization URL
 	return oauth2Config.AuthCodeURL(state,
@@ -80,7 +135,7 @@ func (a *GitHubAdapter) GenerateOAuthURL(
 
 // ExchangeCodeForTokens exchanges an authorization code for tokens
 func (a *GitHubAdapter) ExchangeCodeForTokens(ctx context.Context, code, redirectURL, codeVerifier string) (*oauth2.Token, error) {
-	oauth2Config := a.createOAuth2Config(redirectURL, nil)
+	oauth2Config := a.createOAuth2Config(ctx, redirectURL, nil)
 
 	opts := []oauth2.AuthCodeOption{}
 	if codeVerifier != "" {
@@ -121,13 +176,21 @@ func (a *GitHubAdapter) Execute(
 	}
 
 	// Create GitHub client
-	client, err := a.createGitHubClient(credential)
+	client, err := a.createGitHubClient(ctx, credential)
 	if err != nil {
 		return nil, err
 	}
 
 	// Execute the operation
-	return opDef.Handler(ctx, client, parsedParams)
+	if _, ok := credential.(*credDomain.AppInstallationCredential); ok {
+		ctx = withInstallation(ctx)
+	}
+	result, err := opDef.Handler(ctx, client, parsedParams)
+	if err != nil {
+		a.invalidateRejectedInstallationToken(credential, err)
+		return nil, err
+	}
+	return result, nil
 }
 
 // CheckMissingPermissions checks if the required permissions are present in the authorized scopes
@@ -151,26 +214,48 @@ func (a *GitHubAdapter) GetPermissionIdentifiersFromScopes(scopes []string) ([]s
 	return a.permissionSet.GetPermissionIdentifiersFromScopes(scopes), nil
 }
 
-// ValidateOperationOAuthScopes validates the scopes of an OAuth token for an operation
+// ValidateOperationOAuthScopes validates the scopes of an OAuth token for an operation,
+// a GitHub App installation is checked for the app permissions the operation needs
 func (a *GitHubAdapter) ValidateOperationOAuthScopes(ctx context.Context, operationID string, credential interface{}) error {
-	oauthCredential, ok := credential.(*credDomain.OAuthCredential)
-	if !ok {
-		return fmt.Errorf("credential is not an OAuthCredential")
+	var scopes []string
+	switch cred := credential.(type) {
+	case *credDomain.OAuthCredential:
+		scopes = cred.Scopes
+	case *credDomain.AppInstallationCredential:
+		if userOperations[operationID] {
+			return domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrInsufficientScope,
+				"operation acts as the authenticated user and is not available to GitHub App installations", http.StatusForbidden)
+		}
+		if _, exists := a.operations[operationID]; !exists {
+			return fmt.Errorf("unknown operation ID: %s", operationID)
+		}
+		token, err := a.installationToken(ctx, cred)
+		if err != nil {
+			return err
+		}
+		if missing := token.missingPermissions(operationID); len(missing) > 0 {
+			return domain.NewInsufficientScopeError(a.GetProviderAdapterInfo().Identifier, operationID, missing)
+		}
+		return nil
+	default:
+		return fmt.Errorf("credential is not an OAuthCredential or AppInstallationCredential")
 	}
 
-	allScopesPresent, missingPermissionsIdentifiers, err := a.CheckMissingPermissions(operationID, oauthCredential.Scopes)
+	allScopesPresent, missingPermissionsIdentifiers, err := a.CheckMissingPermissions(operationID, scopes)
 	if err != nil {
 		return err
 	}
 
 	if !allScopesPresent {
-		return fmt.Errorf("missing required scopes: %v", missingPermissionsIdentifiers)
+		return domain.NewInsufficientScopeError(a.GetProviderAdapterInfo().Identifier, operationID, missingPermissionsIdentifiers)
 	}
 
 	return nil
 }
 
-func (a *GitHubAdapter) createOAuth2Config(redirectURL string, scopes []string) *oauth2.Config {
+// createOAuth2Config creates the oauth2.Config object, using the OAuth app override of the context if any,
+// a GitHub Enterprise Server instance is reached through the endpoints of the override
+func (a *GitHubAdapter) createOAuth2Config(ctx context.Context, redirectURL string, scopes []string) *oauth2.Config {
 	oauth2Config := &oauth2.Config{
 		ClientID:     a.oauthConfig.ClientID,
 		ClientSecret: a.oauthConfig.ClientSecret,
@@ -181,5 +266,5 @@ func (a *GitHubAdapter) createOAuth2Config(redirectURL string, scopes []string)
 	if redirectURL != "" {
 		oauth2Config.RedirectURL = redirectURL
 	}
-	return oauth2Config
+	return base.ApplyOAuthAppOverride(ctx, oauth2Config)
 }
@@ -10,6 +10,9 @@ import (
 	goGithub "github.com/google/go-github/v71/github"
 
 	credDomain "github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
+	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/base"
+	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/vcr"
 )
 
 // Define constants for operation IDs used by handlers.
@@ -44,6 +47,15 @@ const (
 	operationIDCreateRepositoryFromTemplate = "create_repository_from_template"
 	operationIDStarRepository               = "star_repository"
 	operationIDUnstarRepository             = "unstar_repository"
+	operationIDListWorkflowRuns             = "list_workflow_runs"
+	operationIDGetWorkflowRun               = "get_workflow_run"
+	operationIDListWorkflowRunJobs          = "list_workflow_run_jobs"
+	operationIDGetJobLogs                   = "get_job_logs"
+	operationIDRerunFailedJobs              = "rerun_failed_jobs"
+	operationIDListCheckRuns                = "list_check_runs"
+	operationIDListCheckSuites              = "list_check_suites"
+	operationIDDispatchWorkflow             = "dispatch_workflow"
+	operationIDSearchCode                   = "search_code"
 )
 
 // Parameter schema structs for GitHub operations
@@ -353,15 +365,51 @@ type OperationDefinition struct {
 // Operations maps operation IDs to their definitions
 type Operations map[string]OperationDefinition
 
-// RegisterOperation registers both the parameter schema and handler for an operation
-func (a *GitHubAdapter) RegisterOperation(operationID string, schema interface{}, handler OperationHandler) {
+// pagePagination is how the list operations of GitHub paginate, a page shorter than per_page is the last one
+var pagePagination = &domain.Pagination{
+	Style:           domain.PaginationStylePage,
+	CursorParam:     "page",
+	PageSizeParam:   "per_page",
+	DefaultPageSize: 30,
+}
+
+// wrappedPagePagination is the pagination of list operations whose items are wrapped in an object with their total count
+func wrappedPagePagination(itemsPath string) *domain.Pagination {
+	pagination := *pagePagination
+	pagination.ItemsPath = itemsPath
+	return &pagination
+}
+
+// operationPaginations maps the paginated operation IDs to their pagination
+var operationPaginations = map[string]*domain.Pagination{
+	operationIDGetIssueComments:       pagePagination,
+	operationIDListRepositories:       pagePagination,
+	operationIDListCommits:            pagePagination,
+	operationIDListPullRequests:       pagePagination,
+	operationIDListRepositoryIssues:   pagePagination,
+	operationIDListPullRequestReviews: pagePagination,
+	operationIDListWorkflowRuns:       wrappedPagePagination("workflow_runs"),
+	operationIDListWorkflowRunJobs:    wrappedPagePagination("jobs"),
+	operationIDListCheckRuns:          wrappedPagePagination("check_runs"),
+	operationIDListCheckSuites:        wrappedPagePagination("check_suites"),
+	operationIDSearchCode:             wrappedPagePagination("items"),
+}
+
+// Pagination returns how an operation paginates, nil if it does not
+func (a *GitHubAdapter) Pagination(operationID string) *domain.Pagination {
+	return operationPaginations[operationID]
+}
+
+// RegisterOperation registers the parameter schema, handler and required permissions of an operation
+func (a *GitHubAdapter) RegisterOperation(operationID string, schema interface{}, handler OperationHandler, requiredPerms []string) {
 	// Register the parameter schema with the base adapter
 	a.BaseAdapter.RegisterOperation(operationID, schema)
 
-	// Store both schema and handler in our operations map
+	// Store the schema, handler and permission requirements in our operations map
 	a.operations[operationID] = OperationDefinition{
-		Schema:  schema,
-		Handler: handler,
+		Schema:                schema,
+		Handler:               handler,
+		PermissionIdentifiers: requiredPerms,
 	}
 }
 
@@ -370,180 +418,273 @@ func (a *GitHubAdapter) registerOperations() {
 		operationIDGetMe,
 		&struct{}{},
 		handleGetMe,
+		[]string{"read_user"},
 	)
 
 	a.RegisterOperation(
 		operationIDGetIssue,
 		&GetIssueParams{},
 		handleGetIssue,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDGetIssueComments,
 		&GetIssueCommentsParams{},
 		handleGetIssueComments,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDUpdateIssue,
 		&UpdateIssueParams{},
 		handleUpdateIssue,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDCreateIssue,
 		&CreateIssueParams{},
 		handleCreateIssue,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDListRepositories,
 		&ListRepositoriesParams{},
 		handleListRepositories,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDGetRepository,
 		&GetRepositoryParams{},
 		handleGetRepository,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDCreateFile,
 		&CreateFileParams{},
 		handleCreateFile,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDUpdateFile,
 		&UpdateFileParams{},
 		handleUpdateFile,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDGetRepositoryContent,
 		&GetRepositoryContentParams{},
 		handleGetRepositoryContent,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDListCommits,
 		&ListCommitsParams{},
 		handleListCommits,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDDeleteFile,
 		&DeleteFileParams{},
 		handleDeleteFile,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDGetCommit,
 		&GetCommitParams{},
 		handleGetCommit,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDListPullRequests,
 		&ListPullRequestsParams{},
 		handleListPullRequests,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDGetPullRequest,
 		&GetPullRequestParams{},
 		handleGetPullRequest,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDCreatePullRequest,
 		&CreatePullRequestParams{},
 		handleCreatePullRequest,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDListRepositoryIssues,
 		&ListRepositoryIssuesParams{},
 		handleListRepositoryIssues,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDUpdatePullRequest,
 		&UpdatePullRequestParams{},
 		handleUpdatePullRequest,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDDeleteRef,
 		&DeleteRefParams{},
 		handleDeleteRef,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDGetRef,
 		&GetRefParams{},
 		handleGetRef,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDCreateRef,
 		&CreateRefParams{},
 		handleCreateRef,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDMergePullRequest,
 		&MergePullRequestParams{},
 		handleMergePullRequest,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDCreatePullRequestReview,
 		&CreatePullRequestReviewParams{},
 		handleCreatePullRequestReview,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDListPullRequestReviews,
 		&ListPullRequestReviewsParams{},
 		handleListPullRequestReviews,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDGetGitTree,
 		&GetGitTreeParams{},
 		handleGetGitTree,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDGetBlob,
 		&GetBlobParams{},
 		handleGetBlob,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDCreateBlob,
 		&CreateBlobParams{},
 		handleCreateBlob,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDCreateRepositoryFromTemplate,
 		&CreateRepositoryFromTemplateParams{},
 		handleCreateRepositoryFromTemplate,
+		[]string{"repo_access"},
 	)
 
 	a.RegisterOperation(
 		operationIDStarRepository,
 		&StarRepositoryParams{},
 		handleStarRepository,
+		[]string{},
 	)
 
 	a.RegisterOperation(
 		operationIDUnstarRepository,
 		&UnstarRepositoryParams{},
 		handleUnstarRepository,
+		[]string{},
+	)
+
+	a.RegisterOperation(
+		operationIDListWorkflowRuns,
+		&ListWorkflowRunsParams{},
+		handleListWorkflowRuns,
+		[]string{"actions_access"},
+	)
+
+	a.RegisterOperation(
+		operationIDGetWorkflowRun,
+		&GetWorkflowRunParams{},
+		handleGetWorkflowRun,
+		[]string{"actions_access"},
+	)
+
+	a.RegisterOperation(
+		operationIDListWorkflowRunJobs,
+		&ListWorkflowRunJobsParams{},
+		handleListWorkflowRunJobs,
+		[]string{"actions_access"},
+	)
+
+	a.RegisterOperation(
+		operationIDGetJobLogs,
+		&GetJobLogsParams{},
+		handleGetJobLogs,
+		[]string{"actions_access"},
+	)
+
+	a.RegisterOperation(
+		operationIDRerunFailedJobs,
+		&RerunFailedJobsParams{},
+		handleRerunFailedJobs,
+		[]string{"actions_access"},
+	)
+
+	a.RegisterOperation(
+		operationIDListCheckRuns,
+		&ListCheckRunsParams{},
+		handleListCheckRuns,
+		[]string{"actions_access"},
+	)
+
+	a.RegisterOperation(
+		operationIDListCheckSuites,
+		&ListCheckSuitesParams{},
+		handleListCheckSuites,
+		[]string{"actions_access"},
+	)
+
+	a.RegisterOperation(
+		operationIDDispatchWorkflow,
+		&DispatchWorkflowParams{},
+		handleDispatchWorkflow,
+		[]string{"actions_access"},
+	)
+
+	a.RegisterOperation(
+		operationIDSearchCode,
+		&SearchCodeParams{},
+		handleSearchCode,
+		[]string{"repo_access"},
 	)
 }
 
@@ -647,18 +788,54 @@ func handleCreateIssue(ctx context.Context, client *goGithub.Client, params inte
 	return createdIssue, nil
 }
 
-// createGitHubClient creates a new GitHub client from the given credentials
-func (a *GitHubAdapter) createGitHubClient(credential interface{}) (*goGithub.Client, error) {
-	oauthCred, ok := credential.(*credDomain.OAuthCredential)
-	if !ok {
+// createGitHubClient creates a new GitHub client from the given credentials,
+// a GitHub App installation credential authenticates with its cached installation token
+func (a *GitHubAdapter) createGitHubClient(ctx context.Context, credential interface{}) (*goGithub.Client, error) {
+	switch cred := credential.(type) {
+	case *credDomain.OAuthCredential:
+		return newGitHubClient(ctx, cred.Token.AccessToken)
+	case *credDomain.AppInstallationCredential:
+		token, err := a.installationToken(ctx, cred)
+		if err != nil {
+			return nil, err
+		}
+		return newGitHubClient(ctx, token.token)
+	default:
 		return nil, fmt.Errorf("invalid credential type for GitHub")
 	}
+}
+
+// newGitHubClient creates a new GitHub client authenticating with the given token,
+// pointed at the GitHub Enterprise Server instance of the OAuth app override of the context if any
+func newGitHubClient(ctx context.Context, token string) (*goGithub.Client, error) {
+	client := goGithub.NewClient(vcr.NewHTTPClient(0)).WithAuthToken(token)
+
+	enterpriseURL := base.APIBaseURL(ctx, "")
+	if enterpriseURL == "" {
+		return client, nil
+	}
 
-	return goGithub.NewClient(nil).WithAuthToken(oauthCred.Token.AccessToken), nil
+	// WithEnterpriseURLs appends the /api/v3/ and /api/uploads/ paths to the URL of the instance
+	instanceURL := strings.TrimSuffix(strings.TrimSuffix(enterpriseURL, "/"), "/api/v3")
+	client, err := client.WithEnterpriseURLs(instanceURL, instanceURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub Enterprise URL: %w", err)
+	}
+	return client, nil
 }
 
 func handleListRepositories(ctx context.Context, client *goGithub.Client, params interface{}) (interface{}, error) {
 	p := params.(*ListRepositoriesParams)
+
+	// An installation lists the repositories it was granted, the filters of user repositories do not apply
+	if isInstallation(ctx) {
+		installationRepos, resp, err := client.Apps.ListRepos(ctx, &goGithub.ListOptions{Page: p.Page, PerPage: p.PerPage})
+		if err := handleGitHubResponse(resp, err, "list installation repositories"); err != nil {
+			return nil, err
+		}
+		return installationRepos.Repositories, nil
+	}
+
 	// Use the non-deprecated ListByAuthenticatedUser method
 	opts := &goGithub.RepositoryListByAuthenticatedUserOptions{
 		Visibility:  p.Visibility,
@@ -0,0 +1,267 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/golang-jwt/jwt/v5"
+	goGithub "github.com/google/go-github/v71/github"
+
+	credDomain "github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
+)
+
+const (
+	// installationTokenOperation names the minting of installation tokens in adapter errors
+	installationTokenOperation = "create_installation_token"
+	// installationTokenLeeway is how long before its expiry a cached installation token is replaced
+	installationTokenLeeway = 5 * time.Minute
+	// appJWTLifetime stays below the 10 minutes GitHub accepts for app JWTs
+	appJWTLifetime = 9 * time.Minute
+	// appJWTClockSkew backdates the issue time of app JWTs against clock drift with GitHub
+	appJWTClockSkew = 60 * time.Second
+)
+
+// installationOperationPermissions maps operations to the GitHub App permissions and access levels an installation
+// needs for them, metadata is read by every installation and needs no entry
+var installationOperationPermissions = map[string]map[string]string{
+	operationIDGetIssue:                     {"issues": "read"},
+	operationIDGetIssueComments:             {"issues": "read"},
+	operationIDUpdateIssue:                  {"issues": "write"},
+	operationIDCreateIssue:                  {"issues": "write"},
+	operationIDListRepositoryIssues:         {"issues": "read"},
+	operationIDCreateFile:                   {"contents": "write"},
+	operationIDUpdateFile:                   {"contents": "write"},
+	operationIDDeleteFile:                   {"contents": "write"},
+	operationIDGetRepositoryContent:         {"contents": "read"},
+	operationIDListCommits:                  {"contents": "read"},
+	operationIDGetCommit:                    {"contents": "read"},
+	operationIDDeleteRef:                    {"contents": "write"},
+	operationIDGetRef:                       {"contents": "read"},
+	operationIDCreateRef:                    {"contents": "write"},
+	operationIDGetGitTree:                   {"contents": "read"},
+	operationIDGetBlob:                      {"contents": "read"},
+	operationIDCreateBlob:                   {"contents": "write"},
+	operationIDSearchCode:                   {"contents": "read"},
+	operationIDListPullRequests:             {"pull_requests": "read"},
+	operationIDGetPullRequest:               {"pull_requests": "read"},
+	operationIDCreatePullRequest:            {"pull_requests": "write"},
+	operationIDUpdatePullRequest:            {"pull_requests": "write"},
+	operationIDMergePullRequest:             {"pull_requests": "write", "contents": "write"},
+	operationIDCreatePullRequestReview:      {"pull_requests": "write"},
+	operationIDListPullRequestReviews:       {"pull_requests": "read"},
+	operationIDCreateRepositoryFromTemplate: {"administration": "write"},
+	operationIDListWorkflowRuns:             {"actions": "read"},
+	operationIDGetWorkflowRun:               {"actions": "read"},
+	operationIDListWorkflowRunJobs:          {"actions": "read"},
+	operationIDGetJobLogs:                   {"actions": "read"},
+	operationIDRerunFailedJobs:              {"actions": "write"},
+	operationIDDispatchWorkflow:             {"actions": "write"},
+	operationIDListCheckRuns:                {"checks": "read"},
+	operationIDListCheckSuites:              {"checks": "read"},
+}
+
+// appPermissionLevels orders the access levels of GitHub App permissions, a level grants the ones below it
+var appPermissionLevels = map[string]int{
+	"read":  1,
+	"write": 2,
+	"admin": 3,
+}
+
+// userOperations act as the authenticated user, GitHub App installations have no user to act as
+var userOperations = map[string]bool{
+	operationIDGetMe:            true,
+	operationIDStarRepository:   true,
+	operationIDUnstarRepository: true,
+}
+
+// installationContextKey marks the context of operations executed with an installation token
+type installationContextKey struct{}
+
+// withInstallation marks the context as executing with an installation token
+func withInstallation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, installationContextKey{}, true)
+}
+
+// isInstallation reports whether the context executes with an installation token
+func isInstallation(ctx context.Context) bool {
+	installation, _ := ctx.Value(installationContextKey{}).(bool)
+	return installation
+}
+
+// installationToken is an installation access token with the permissions granted to the installation
+type installationToken struct {
+	token       string
+	expiresAt   time.Time
+	permissions map[string]string // GitHub App permission to access level, read, write or admin
+}
+
+// missingPermissions returns the GitHub App permissions an operation needs that the installation was not granted,
+// as permission:level sorted by permission
+func (t *installationToken) missingPermissions(operationID string) []string {
+	var missing []string
+	for appPermission, level := range installationOperationPermissions[operationID] {
+		if appPermissionLevels[t.permissions[appPermission]] < appPermissionLevels[level] {
+			missing = append(missing, appPermission+":"+level)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// installationTokenCache caches installation tokens per credential until shortly before they expire
+type installationTokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]*installationToken
+}
+
+// newInstallationTokenCache creates an empty installation token cache
+func newInstallationTokenCache() *installationTokenCache {
+	return &installationTokenCache{
+		tokens: make(map[string]*installationToken),
+	}
+}
+
+// get returns the cached token of a credential, nil if there is none or it is about to expire
+func (c *installationTokenCache) get(credentialID string, now time.Time) *installationToken {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	token, ok := c.tokens[credentialID]
+	if !ok || now.Add(installationTokenLeeway).After(token.expiresAt) {
+		return nil
+	}
+	return token
+}
+
+// put caches the token of a credential, dropping the expired tokens of other credentials
+func (c *installationTokenCache) put(credentialID string, token *installationToken, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, cached := range c.tokens {
+		if now.After(cached.expiresAt) {
+			delete(c.tokens, id)
+		}
+	}
+	c.tokens[credentialID] = token
+}
+
+// remove drops the cached token of a credential
+func (c *installationTokenCache) remove(credentialID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.tokens, credentialID)
+}
+
+// installationToken returns the cached installation token of a credential, minting a new one if it is about to expire
+func (a *GitHubAdapter) installationToken(ctx context.Context, cred *credDomain.AppInstallationCredential) (*installationToken, error) {
+	if token := a.installationTokens.get(cred.ID, time.Now()); token != nil {
+		return token, nil
+	}
+
+	token, err := a.mintInstallationToken(ctx, cred)
+	if err != nil {
+		return nil, err
+	}
+	a.installationTokens.put(cred.ID, token, time.Now())
+	return token, nil
+}
+
+// mintInstallationToken signs in as the app with a JWT and creates an access token of the installation
+func (a *GitHubAdapter) mintInstallationToken(ctx context.Context, cred *credDomain.AppInstallationCredential) (*installationToken, error) {
+	providerIdentifier := a.GetProviderAdapterInfo().Identifier
+
+	installationID, err := strconv.ParseInt(cred.InstallationID, 10, 64)
+	if err != nil {
+		return nil, domain.NewAdapterError(providerIdentifier, installationTokenOperation, domain.ErrCredentialError,
+			fmt.Sprintf("invalid installation ID %q", cred.InstallationID), http.StatusUnauthorized)
+	}
+
+	appJWT, err := signAppJWT(cred.AppID, cred.PrivateKey, time.Now())
+	if err != nil {
+		return nil, domain.NewAdapterError(providerIdentifier, installationTokenOperation, domain.ErrCredentialError,
+			err.Error(), http.StatusUnauthorized)
+	}
+
+	client, err := newGitHubClient(ctx, appJWT)
+	if err != nil {
+		return nil, err
+	}
+
+	// A rejected JWT answers 401, an installation unknown to the app or suspended answers 404 or 403
+	token, resp, err := client.Apps.CreateInstallationToken(ctx, installationID, nil)
+	if err != nil {
+		if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+			return nil, domain.NewAdapterError(providerIdentifier, installationTokenOperation, domain.ErrCredentialError,
+				fmt.Sprintf("installation token rejected: %v", err), resp.StatusCode)
+		}
+		return nil, domain.NewAdapterError(providerIdentifier, installationTokenOperation, domain.ErrProviderAPIError,
+			fmt.Sprintf("failed to create installation token: %v", err), http.StatusBadGateway)
+	}
+
+	permissions, err := installationPermissions(token.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &installationToken{
+		token:       token.GetToken(),
+		expiresAt:   token.GetExpiresAt().Time,
+		permissions: permissions,
+	}, nil
+}
+
+// invalidateRejectedInstallationToken drops the cached token of an installation credential whose token was rejected,
+// so that the next operation mints a new one
+func (a *GitHubAdapter) invalidateRejectedInstallationToken(credential interface{}, err error) {
+	cred, ok := credential.(*credDomain.AppInstallationCredential)
+	if !ok {
+		return
+	}
+
+	var errorResponse *goGithub.ErrorResponse
+	if errors.As(err, &errorResponse) && errorResponse.Response != nil && errorResponse.Response.StatusCode == http.StatusUnauthorized {
+		a.installationTokens.remove(cred.ID)
+	}
+}
+
+// signAppJWT signs the JWT authenticating as a GitHub App, the app ID or client ID is its issuer
+func signAppJWT(appID, privateKeyPEM string, now time.Time) (string, error) {
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(privateKeyPEM))
+	if err != nil {
+		return "", fmt.Errorf("invalid app private key: %w", err)
+	}
+
+	claims := jwt.RegisteredClaims{
+		Issuer:    appID,
+		IssuedAt:  jwt.NewNumericDate(now.Add(-appJWTClockSkew)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(appJWTLifetime)),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(privateKey)
+}
+
+// installationPermissions converts the permissions of an installation token to a map of permission to access level
+func installationPermissions(permissions *goGithub.InstallationPermissions) (map[string]string, error) {
+	result := make(map[string]string)
+	if permissions == nil {
+		return result, nil
+	}
+
+	permissionsJSON, err := sonic.Marshal(permissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal installation permissions: %w", err)
+	}
+	if err := sonic.Unmarshal(permissionsJSON, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal installation permissions: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,133 @@
+package github
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
+)
+
+func TestSignAppJWT(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(rsaKey)
+	ecDER, _ := x509.MarshalECPrivateKey(ecKey)
+	pkcs1PEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}))
+	pkcs8PEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+	ecPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}))
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		appID      string
+		privateKey string
+		wantErr    bool
+	}{
+		// GitHub generates PKCS#1 keys, PKCS#8 is what most tools convert them to
+		{name: "PKCS1Key", appID: "123456", privateKey: pkcs1PEM},
+		{name: "PKCS8Key", appID: "123456", privateKey: pkcs8PEM},
+		{name: "ClientIDIssuer", appID: "Iv23liExample", privateKey: pkcs1PEM},
+		{name: "ECKey", appID: "123456", privateKey: ecPEM, wantErr: true},
+		{name: "NotPEM", appID: "123456", privateKey: "not a key", wantErr: true},
+		{name: "EmptyKey", appID: "123456", privateKey: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signed, err := signAppJWT(tt.appID, tt.privateKey, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got token: %s", signed)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to sign app JWT: %v", err)
+			}
+
+			claims := &jwt.RegisteredClaims{}
+			token, err := jwt.ParseWithClaims(signed, claims, func(token *jwt.Token) (interface{}, error) {
+				return &rsaKey.PublicKey, nil
+			}, jwt.WithValidMethods([]string{"RS256"}), jwt.WithTimeFunc(func() time.Time { return now }))
+			if err != nil {
+				t.Fatalf("Failed to verify app JWT: %v", err)
+			}
+			if !token.Valid {
+				t.Fatalf("Expected a valid token")
+			}
+
+			if claims.Issuer != tt.appID {
+				t.Errorf("Expected issuer %s, got: %s", tt.appID, claims.Issuer)
+			}
+			if !claims.IssuedAt.Time.Equal(now.Add(-appJWTClockSkew)) {
+				t.Errorf("Expected issued at %v, got: %v", now.Add(-appJWTClockSkew), claims.IssuedAt.Time)
+			}
+			if !claims.ExpiresAt.Time.Equal(now.Add(appJWTLifetime)) {
+				t.Errorf("Expected expires at %v, got: %v", now.Add(appJWTLifetime), claims.ExpiresAt.Time)
+			}
+			// GitHub rejects app JWTs valid for more than 10 minutes
+			if lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time); lifetime > 10*time.Minute {
+				t.Errorf("Expected a lifetime of at most 10 minutes, got: %v", lifetime)
+			}
+
+			// Expired once the lifetime elapsed
+			_, err = jwt.ParseWithClaims(signed, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
+				return &rsaKey.PublicKey, nil
+			}, jwt.WithTimeFunc(func() time.Time { return now.Add(appJWTLifetime + time.Second) }))
+			if err == nil {
+				t.Errorf("Expected the app JWT to expire after %v", appJWTLifetime)
+			}
+		})
+	}
+}
+
+func TestInstallationTokenMissingPermissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions map[string]string
+		operationID string
+		expected    []string
+	}{
+		// Issues do not grant access to the contents of the repository
+		{name: "IssuesOnlyReadsIssues", permissions: map[string]string{"issues": "write"}, operationID: operationIDGetIssue},
+		{name: "IssuesOnlyLacksContents", permissions: map[string]string{"issues": "write"}, operationID: operationIDGetRepositoryContent, expected: []string{"contents:read"}},
+		{name: "ReadLacksWrite", permissions: map[string]string{"contents": "read"}, operationID: operationIDCreateFile, expected: []string{"contents:write"}},
+		{name: "AdminGrantsWrite", permissions: map[string]string{"administration": "admin"}, operationID: operationIDCreateRepositoryFromTemplate},
+		{name: "AllMissingAreReported", permissions: map[string]string{"pull_requests": "read"}, operationID: operationIDMergePullRequest, expected: []string{"contents:write", "pull_requests:write"}},
+		{name: "ChecksAreNotActions", permissions: map[string]string{"checks": "read"}, operationID: operationIDListWorkflowRuns, expected: []string{"actions:read"}},
+		// Every installation reads metadata
+		{name: "MetadataOnly", permissions: map[string]string{"metadata": "read"}, operationID: operationIDGetRepository},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := &installationToken{permissions: tt.permissions}
+			if missing := token.missingPermissions(tt.operationID); !reflect.DeepEqual(missing, tt.expected) {
+				t.Errorf("Expected missing permissions %v, got: %v", tt.expected, missing)
+			}
+		})
+	}
+}
+
+func TestInstallationOperationPermissionsCoverOperations(t *testing.T) {
+	adapter := NewGitHubAdapter(&domain.ProviderAdapterInfo{}, &domain.AdapterConfig{}, &domain.OAuthConfig{}, domain.PermissionSet{})
+
+	// An operation without app permissions would pass the check and fail upstream with a plain 403
+	for operationID := range adapter.operations {
+		_, mapped := installationOperationPermissions[operationID]
+		if !mapped && !userOperations[operationID] && operationID != operationIDListRepositories && operationID != operationIDGetRepository {
+			t.Errorf("Expected the app permissions of %s to be mapped", operationID)
+		}
+	}
+}
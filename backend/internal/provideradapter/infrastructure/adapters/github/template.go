@@ -12,12 +12,19 @@ import (
 
 const (
 	identifier = "github"
+
+	apiBaseURL      = "https://api.github.com"
+	revokeGrantPath = "/applications/%s/grant"
+	userPath        = "/user"
 )
 
 // Register the GitHub adapter template
 func init() {
 
 	var _ domain.OAuthAdapter = (*GitHubAdapter)(nil)
+	var _ domain.OAuthTokenRevoker = (*GitHubAdapter)(nil)
+	var _ domain.CredentialVerifier = (*GitHubAdapter)(nil)
+	var _ domain.Paginator = (*GitHubAdapter)(nil)
 
 	template := &GitHubTemplate{}
 
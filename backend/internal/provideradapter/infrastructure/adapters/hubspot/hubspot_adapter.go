@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -51,8 +52,8 @@ func NewHubspotAdapter(
 	return adapter
 }
 
-// createOAuth2Config creates the oauth2.Config object.
-func (a *HubspotAdapter) createOAuth2Config(redirectURL string, scopes []string) *oauth2.Config {
+// createOAuth2Config creates the oauth2.Config object, using the OAuth app override of the context if any.
+func (a *HubspotAdapter) createOAuth2Config(ctx context.Context, redirectURL string, scopes []string) *oauth2.Config {
 	endpoint := oauth2.Endpoint{
 		AuthURL:  authURL,
 		TokenURL: tokenURL,
@@ -61,13 +62,13 @@ func (a *HubspotAdapter) createOAuth2Config(redirectURL string, scopes []string)
 
 	effectiveRedirectURL := redirectURL
 
-	return &oauth2.Config{
+	return base.ApplyOAuthAppOverride(ctx, &oauth2.Config{
 		ClientID:     a.oauthConfig.ClientID,
 		ClientSecret: a.oauthConfig.ClientSecret,
 		RedirectURL:  effectiveRedirectURL,
 		Scopes:       scopes,
 		Endpoint:     endpoint,
-	}
+	})
 }
 
 // ShouldRefreshToken checks if the token should be refreshed
@@ -80,7 +81,7 @@ func (a *HubspotAdapter) ShouldRefreshToken(oldToken *oauth2.Token) bool {
 
 // RefreshOAuthToken refreshes an OAuth token.
 func (a *HubspotAdapter) RefreshOAuthToken(ctx context.Context, oldToken *oauth2.Token) (*oauth2.Token, error) {
-	oauth2Config := a.createOAuth2Config("", nil)
+	oauth2Config := a.createOAuth2Config(ctx, "", nil)
 	tokenSource := oauth2Config.TokenSource(ctx, oldToken)
 	newToken, err := tokenSource.Token()
 	if err != nil {
@@ -95,18 +96,33 @@ func (a *HubspotAdapter) RefreshOAuthToken(ctx context.Context, oldToken *oauth2
 	return newToken, nil
 }
 
+// RevokeOAuthToken deletes the refresh token, access tokens expire on their own shortly after
+func (a *HubspotAdapter) RevokeOAuthToken(ctx context.Context, token *oauth2.Token) error {
+	if token.RefreshToken == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, revokeURL+url.PathEscape(token.RefreshToken), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create revocation request: %w", err)
+	}
+
+	_, err = base.DoTokenRevocation(a.GetProviderAdapterInfo().Identifier, req, http.StatusNotFound)
+	return err
+}
+
 // GenerateOAuthURL generates an OAuth authorization URL.
 func (a *HubspotAdapter) GenerateOAuthURL(ctx context.Context,
 	redirectURL, state, codeChallenge string,
 	scopes []string,
 ) (string, error) {
-	oauth2Config := a.createOAuth2Config(redirectURL, scopes)
+	oauth2Config := a.createOAuth2Config(ctx, redirectURL, scopes)
 	return oauth2Config.AuthCodeURL(state /*, oauth2.AccessTypeOffline*/), nil
 }
 
 // ExchangeCodeForTokens exchanges an authorization code for tokens.
 func (a *HubspotAdapter) ExchangeCodeForTokens(ctx context.Context, code, redirectURL, codeVerifier string) (*oauth2.Token, error) {
-	oauth2Config := a.createOAuth2Config(redirectURL, nil)
+	oauth2Config := a.createOAuth2Config(ctx, redirectURL, nil)
 	token, err := oauth2Config.Exchange(ctx, code)
 	if err != nil {
 		return nil, domain.NewAdapterError(
@@ -165,7 +181,7 @@ func (a *HubspotAdapter) Execute(
 		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrInternal, fmt.Sprintf("error checking permissions: %v", err), http.StatusInternalServerError)
 	}
 	if !allScopesPresent {
-		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrCredentialError, fmt.Sprintf("missing required permissions: %v", missingIDs), http.StatusForbidden)
+		return nil, domain.NewInsufficientScopeError(a.GetProviderAdapterInfo().Identifier, operationID, missingIDs)
 	}
 
 	processedParams, err := a.ProcessParams(operationID, params)
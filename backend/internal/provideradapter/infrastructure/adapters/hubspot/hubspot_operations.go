@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
 )
 
 // Define constants for API paths used by handlers.
@@ -155,6 +157,25 @@ type OperationDefinition struct {
 // Operations maps operation IDs to their definitions.
 type Operations map[string]OperationDefinition
 
+// searchPagination is how the CRM search operations paginate, the paging object is left out after the last page
+var searchPagination = &domain.Pagination{
+	Style:          domain.PaginationStyleCursor,
+	CursorParam:    "after",
+	ItemsPath:      "results",
+	NextCursorPath: "paging.next.after",
+}
+
+// operationPaginations maps the paginated operation IDs to their pagination
+var operationPaginations = map[string]*domain.Pagination{
+	operationIDSearchContacts:  searchPagination,
+	operationIDSearchCompanies: searchPagination,
+}
+
+// Pagination returns how an operation paginates, nil if it does not
+func (a *HubspotAdapter) Pagination(operationID string) *domain.Pagination {
+	return operationPaginations[operationID]
+}
+
 // RegisterOperation registers the parameter schema and handler.
 func (a *HubspotAdapter) RegisterOperation(operationID string, schema interface{}, handler OperationHandler, requiredPerms []string) {
 	a.BaseAdapter.RegisterOperation(operationID, schema) // Register schema for validation
@@ -14,14 +14,17 @@ const (
 	identifier = "hubspot"
 	baseURL    = "https://api.hubapi.com"
 
-	authURL  = "https://app.hubspot.com/oauth/authorize"
-	tokenURL = "https://api.hubapi.com/oauth/v1/token"
+	authURL   = "https://app.hubspot.com/oauth/authorize"
+	tokenURL  = "https://api.hubapi.com/oauth/v1/token"
+	revokeURL = "https://api.hubapi.com/oauth/v1/refresh-tokens/"
 )
 
 // Register the HubSpot adapter template during package initialization.
 func init() {
 	// Type assertion to ensure the adapter implements the necessary interfaces
 	var _ domain.OAuthAdapter = (*HubspotAdapter)(nil)
+	var _ domain.OAuthTokenRevoker = (*HubspotAdapter)(nil)
+	var _ domain.Paginator = (*HubspotAdapter)(nil)
 
 	template := &HubspotAdapterTemplate{}
 	registry.RegisterAdapterTemplate(identifier, template)
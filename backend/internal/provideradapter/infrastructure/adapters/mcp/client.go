@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/vcr"
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -60,14 +61,15 @@ func (c *MCPClient) connect(ctx context.Context) error {
 
 	stdioTransport := transport.NewStdio(c.config.Command, envs, c.config.Args...)
 
-	c.client = client.NewClient(stdioTransport)
+	// Record or replay the server traffic when enabled, replay never starts the server process
+	c.client = client.NewClient(vcr.WrapStdio(ctx, stdioTransport))
 
 	if err := c.client.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start MCP server: %w", err)
 	}
 
-	// Set up logging for stderr if available
-	if stderr, ok := client.GetStderr(c.client); ok {
+	// Set up logging for stderr if the server process is running
+	if stderr := stdioTransport.Stderr(); stderr != nil {
 		go func() {
 			buf := make([]byte, 4096)
 			for {
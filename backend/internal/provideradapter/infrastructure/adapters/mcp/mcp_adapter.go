@@ -333,15 +333,25 @@ func (a *MCPAdapter) GetOperationSchema(operationID string) (interface{}, error)
 	return nil, fmt.Errorf("operation %s not found", operationID)
 }
 
-// Health checks the health of the MCP server
+// Health checks the health of the MCP server by starting it and listing its tools without credentials,
+// the probes only call it for servers that need none
 func (a *MCPAdapter) Health(ctx context.Context) error {
-	// Try to get tools as a health check
 	if a.initError != nil {
 		return fmt.Errorf("MCP server initialization failed: %w", a.initError)
 	}
+
+	clientConfig := a.buildMCPClientConfig(nil, nil)
+	if _, err := GetMCPServerTools(ctx, clientConfig, a.GetProviderAdapterInfo().Identifier); err != nil {
+		return fmt.Errorf("failed to list MCP server tools: %w", err)
+	}
 	return nil
 }
 
+// RequiredCommand returns the executable used to start the MCP server
+func (a *MCPAdapter) RequiredCommand() string {
+	return a.config.Command
+}
+
 // GetProviderAdapterInfo returns provider information (implements domain.Adapter interface)
 func (a *MCPAdapter) GetProviderAdapterInfo() *domain.ProviderAdapterInfo {
 	return a.BaseAdapter.GetProviderAdapterInfo()
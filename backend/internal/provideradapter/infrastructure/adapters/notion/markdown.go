@@ -0,0 +1,641 @@
+package notion
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits of the Notion API on the blocks and rich text of a single request
+const (
+	maxRichTextContentLength = 2000 // characters of a single text object
+	maxRichTextItems         = 100  // text objects of a single rich text array
+	maxChildrenPerRequest    = 100  // blocks of a single children array
+	maxBlocksPerRequest      = 1000 // blocks of a request, nested children included
+	maxNestingDepth          = 2    // levels of children below the blocks of a request
+)
+
+// defaultCodeLanguage is used for fenced code blocks without a language Notion knows
+const defaultCodeLanguage = "plain text"
+
+// markdownSpecialChars are the characters a backslash escapes in inline markdown
+const markdownSpecialChars = "\\`*_~[]()#!|>"
+
+// codeLanguages are the languages accepted for code blocks
+var codeLanguages = map[string]bool{
+	"abap": true, "agda": true, "arduino": true, "assembly": true, "bash": true, "basic": true, "bnf": true,
+	"c": true, "c#": true, "c++": true, "clojure": true, "coffeescript": true, "coq": true, "css": true,
+	"dart": true, "dhall": true, "diff": true, "docker": true, "ebnf": true, "elixir": true, "elm": true,
+	"erlang": true, "f#": true, "flow": true, "fortran": true, "gherkin": true, "glsl": true, "go": true,
+	"graphql": true, "groovy": true, "haskell": true, "html": true, "idris": true, "java": true,
+	"javascript": true, "json": true, "julia": true, "kotlin": true, "latex": true, "less": true, "lisp": true,
+	"livescript": true, "llvm ir": true, "lua": true, "makefile": true, "markdown": true, "markup": true,
+	"matlab": true, "mathematica": true, "mermaid": true, "nix": true, "notion formula": true,
+	"objective-c": true, "ocaml": true, "pascal": true, "perl": true, "php": true, "plain text": true,
+	"powershell": true, "prolog": true, "protobuf": true, "purescript": true, "python": true, "r": true,
+	"racket": true, "reason": true, "ruby": true, "rust": true, "sass": true, "scala": true, "scheme": true,
+	"scss": true, "shell": true, "smalltalk": true, "solidity": true, "sql": true, "swift": true, "toml": true,
+	"typescript": true, "vb.net": true, "verilog": true, "vhdl": true, "visual basic": true,
+	"webassembly": true, "xml": true, "yaml": true,
+}
+
+// codeLanguageAliases maps common fence info strings to the languages of Notion
+var codeLanguageAliases = map[string]string{
+	"js": "javascript", "jsx": "javascript", "ts": "typescript", "tsx": "typescript", "py": "python",
+	"golang": "go", "rb": "ruby", "rs": "rust", "sh": "shell", "zsh": "shell", "console": "shell",
+	"yml": "yaml", "cpp": "c++", "cs": "c#", "csharp": "c#", "kt": "kotlin", "md": "markdown",
+	"dockerfile": "docker", "ps1": "powershell", "tex": "latex", "proto": "protobuf", "text": "plain text",
+	"txt": "plain text", "plaintext": "plain text",
+}
+
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	todoPattern        = regexp.MustCompile(`^[-*+]\s+\[([ xX])\]\s*(.*)$`)
+	bulletPattern      = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	numberedPattern    = regexp.MustCompile(`^\d{1,9}[.)]\s+(.*)$`)
+	dividerPattern     = regexp.MustCompile(`^(?:-\s*){3,}$|^(?:\*\s*){3,}$|^(?:_\s*){3,}$`)
+	imagePattern       = regexp.MustCompile(`^!\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)$`)
+	tableSeparatorCell = regexp.MustCompile(`^\s*:?-+:?\s*$`)
+	fenceOpenPattern   = regexp.MustCompile("^(`{3,}|~{3,})\\s*([^`\\s]*)")
+)
+
+// markdownBlock is a Notion block in the generic form sent to the API
+type markdownBlock = map[string]interface{}
+
+// listFrame is an open list item that more indented lines are nested in
+type listFrame struct {
+	indent int
+	block  markdownBlock
+}
+
+// markdownToBlocks converts markdown into Notion blocks, supporting headings, bulleted, numbered and to-do lists,
+// fenced code, quotes, tables, dividers, images and inline bold, italic, strikethrough, code and links
+func markdownToBlocks(markdown string) []markdownBlock {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	var blocks []markdownBlock
+	var listStack []listFrame
+	var paragraph []string
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, textBlocks("paragraph", strings.Join(paragraph, "\n"), nil)...)
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		indent := indentWidth(line)
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" {
+			flushParagraph()
+			continue
+		}
+
+		// Fenced code keeps its lines verbatim until the closing fence
+		if match := fenceOpenPattern.FindStringSubmatch(trimmed); match != nil {
+			flushParagraph()
+			listStack = nil
+			fence := match[1]
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+					break
+				}
+				code = append(code, strings.TrimPrefix(lines[i], strings.Repeat(" ", indent)))
+			}
+			blocks = append(blocks, textBlocks("code", strings.Join(code, "\n"), map[string]interface{}{
+				"language": codeLanguage(match[2]),
+			})...)
+			continue
+		}
+
+		// List items nest under the open item they are indented beneath
+		if blockType, text, extra, ok := listItem(trimmed); ok {
+			flushParagraph()
+			for len(listStack) > 0 && listStack[len(listStack)-1].indent >= indent {
+				listStack = listStack[:len(listStack)-1]
+			}
+			for len(listStack) > maxNestingDepth {
+				listStack = listStack[:len(listStack)-1]
+			}
+			items := textBlocks(blockType, text, extra)
+			if len(listStack) == 0 {
+				blocks = append(blocks, items...)
+			} else {
+				appendChildren(listStack[len(listStack)-1].block, items...)
+			}
+			listStack = append(listStack, listFrame{indent: indent, block: items[len(items)-1]})
+			continue
+		}
+
+		// Indented text below a list item continues it as a nested paragraph
+		if indent > 0 && len(listStack) > 0 && len(paragraph) == 0 {
+			for len(listStack) > 1 && listStack[len(listStack)-1].indent >= indent {
+				listStack = listStack[:len(listStack)-1]
+			}
+			appendChildren(listStack[len(listStack)-1].block, textBlocks("paragraph", trimmed, nil)...)
+			continue
+		}
+		listStack = nil
+
+		switch {
+		case headingPattern.MatchString(trimmed):
+			flushParagraph()
+			match := headingPattern.FindStringSubmatch(trimmed)
+			level := min(len(match[1]), 3)
+			blocks = append(blocks, textBlocks("heading_"+strconv.Itoa(level), match[2], nil)...)
+		case dividerPattern.MatchString(trimmed):
+			flushParagraph()
+			blocks = append(blocks, markdownBlock{"object": "block", "type": "divider", "divider": map[string]interface{}{}})
+		case strings.HasPrefix(trimmed, ">"):
+			flushParagraph()
+			var quote []string
+			for ; i < len(lines); i++ {
+				quoteLine := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(quoteLine, ">") {
+					i--
+					break
+				}
+				quote = append(quote, strings.TrimPrefix(strings.TrimPrefix(quoteLine, ">"), " "))
+			}
+			blocks = append(blocks, textBlocks("quote", strings.Join(quote, "\n"), nil)...)
+		case strings.HasPrefix(trimmed, "|") && i+1 < len(lines) && isTableSeparator(lines[i+1]):
+			flushParagraph()
+			header := splitTableRow(trimmed)
+			rows := [][]string{header}
+			for i += 2; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				rows = append(rows, splitTableRow(strings.TrimSpace(lines[i])))
+			}
+			i--
+			blocks = append(blocks, tableBlock(rows))
+		case imagePattern.MatchString(trimmed):
+			flushParagraph()
+			match := imagePattern.FindStringSubmatch(trimmed)
+			image := map[string]interface{}{"type": "external", "external": map[string]interface{}{"url": match[2]}}
+			if match[1] != "" {
+				image["caption"] = richText(match[1])
+			}
+			blocks = append(blocks, markdownBlock{"object": "block", "type": "image", "image": image})
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flushParagraph()
+
+	return blocks
+}
+
+// listItem recognizes to-do, bulleted and numbered list items
+func listItem(line string) (blockType string, text string, extra map[string]interface{}, ok bool) {
+	if match := todoPattern.FindStringSubmatch(line); match != nil {
+		return "to_do", match[2], map[string]interface{}{"checked": match[1] != " "}, true
+	}
+	if dividerPattern.MatchString(line) {
+		return "", "", nil, false
+	}
+	if match := bulletPattern.FindStringSubmatch(line); match != nil {
+		return "bulleted_list_item", match[1], nil, true
+	}
+	if match := numberedPattern.FindStringSubmatch(line); match != nil {
+		return "numbered_list_item", match[1], nil, true
+	}
+	return "", "", nil, false
+}
+
+// textBlocks builds blocks of a type holding rich text, split into several blocks beyond the rich text limits
+func textBlocks(blockType, text string, extra map[string]interface{}) []markdownBlock {
+	var segments []map[string]interface{}
+	if blockType == "code" {
+		segments = plainRichText(text)
+	} else {
+		segments = richText(text)
+	}
+
+	var blocks []markdownBlock
+	for start := 0; start == 0 || start < len(segments); start += maxRichTextItems {
+		end := min(start+maxRichTextItems, len(segments))
+		content := map[string]interface{}{"rich_text": segments[start:end]}
+		for key, value := range extra {
+			content[key] = value
+		}
+		blocks = append(blocks, markdownBlock{"object": "block", "type": blockType, blockType: content})
+		if end == len(segments) {
+			break
+		}
+	}
+	return blocks
+}
+
+// appendChildren nests blocks under a block
+func appendChildren(parent markdownBlock, children ...markdownBlock) {
+	blockType, _ := parent["type"].(string)
+	content, ok := parent[blockType].(map[string]interface{})
+	if !ok {
+		return
+	}
+	existing, _ := content["children"].([]markdownBlock)
+	content["children"] = append(existing, children...)
+}
+
+// tableBlock builds a table whose first row is the column header
+func tableBlock(rows [][]string) markdownBlock {
+	width := len(rows[0])
+	children := make([]markdownBlock, 0, len(rows))
+	for _, row := range rows {
+		cells := make([]interface{}, width)
+		for i := range cells {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			cells[i] = richText(cell)
+		}
+		children = append(children, markdownBlock{
+			"object":    "block",
+			"type":      "table_row",
+			"table_row": map[string]interface{}{"cells": cells},
+		})
+	}
+
+	return markdownBlock{
+		"object": "block",
+		"type":   "table",
+		"table": map[string]interface{}{
+			"table_width":       width,
+			"has_column_header": true,
+			"has_row_header":    false,
+			"children":          children,
+		},
+	}
+}
+
+// isTableSeparator recognizes the line below the header of a table, such as |---|:--:|
+func isTableSeparator(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if !strings.Contains(trimmed, "-") {
+		return false
+	}
+	for _, cell := range splitTableRow(trimmed) {
+		if !tableSeparatorCell.MatchString(cell) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitTableRow splits a table row on the pipes that are not escaped
+func splitTableRow(line string) []string {
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, "\\|") {
+		line = strings.TrimSuffix(line, "|")
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// indentWidth returns the indentation of a line, counting a tab as four spaces
+func indentWidth(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// codeLanguage maps the info string of a code fence to a language of Notion
+func codeLanguage(info string) string {
+	language := strings.ToLower(info)
+	if alias, ok := codeLanguageAliases[language]; ok {
+		return alias
+	}
+	if codeLanguages[language] {
+		return language
+	}
+	return defaultCodeLanguage
+}
+
+// inlineStyle is the formatting of a run of inline text
+type inlineStyle struct {
+	bold          bool
+	italic        bool
+	strikethrough bool
+	code          bool
+	link          string
+}
+
+// inlineRun is a run of text sharing one style
+type inlineRun struct {
+	text  string
+	style inlineStyle
+}
+
+// richText parses inline markdown into Notion rich text, split at the length limit of text objects
+func richText(text string) []map[string]interface{} {
+	var runs []inlineRun
+	parseInline(text, inlineStyle{}, &runs)
+	return runsToRichText(runs)
+}
+
+// plainRichText builds unformatted rich text, split at the length limit of text objects
+func plainRichText(text string) []map[string]interface{} {
+	return runsToRichText([]inlineRun{{text: text}})
+}
+
+// runsToRichText converts styled runs into text objects
+func runsToRichText(runs []inlineRun) []map[string]interface{} {
+	segments := make([]map[string]interface{}, 0, len(runs))
+	for _, run := range runs {
+		for _, chunk := range splitRunes(run.text, maxRichTextContentLength) {
+			content := map[string]interface{}{"content": chunk}
+			if run.style.link != "" {
+				content["link"] = map[string]interface{}{"url": run.style.link}
+			}
+			segment := map[string]interface{}{"type": "text", "text": content}
+			if run.style != (inlineStyle{link: run.style.link}) {
+				segment["annotations"] = map[string]interface{}{
+					"bold":          run.style.bold,
+					"italic":        run.style.italic,
+					"strikethrough": run.style.strikethrough,
+					"code":          run.style.code,
+				}
+			}
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// splitRunes splits text into chunks of at most size characters, an empty text is a single empty chunk
+func splitRunes(text string, size int) []string {
+	if utf8.RuneCountInString(text) <= size {
+		return []string{text}
+	}
+	var chunks []string
+	runes := []rune(text)
+	for start := 0; start < len(runes); start += size {
+		chunks = append(chunks, string(runes[start:min(start+size, len(runes))]))
+	}
+	return chunks
+}
+
+// parseInline appends the styled runs of inline markdown, markers without a closing marker are kept as text
+func parseInline(text string, style inlineStyle, runs *[]inlineRun) {
+	var literal strings.Builder
+	emit := func(value string, runStyle inlineStyle) {
+		if value == "" {
+			return
+		}
+		if n := len(*runs); n > 0 && (*runs)[n-1].style == runStyle {
+			(*runs)[n-1].text += value
+			return
+		}
+		*runs = append(*runs, inlineRun{text: value, style: runStyle})
+	}
+	flush := func() {
+		emit(literal.String(), style)
+		literal.Reset()
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.IndexByte(markdownSpecialChars, rest[1]) >= 0:
+			literal.WriteByte(rest[1])
+			i += 2
+			continue
+		case rest[0] == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end > 0 {
+				flush()
+				codeStyle := style
+				codeStyle.code = true
+				emit(rest[1:end+1], codeStyle)
+				i += end + 2
+				continue
+			}
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if end := strings.Index(rest[2:], rest[:2]); end > 0 && opensEmphasis(text, i, rest[:1]) {
+				flush()
+				innerStyle := style
+				innerStyle.bold = true
+				parseInline(rest[2:end+2], innerStyle, runs)
+				i += end + 4
+				continue
+			}
+		case strings.HasPrefix(rest, "~~"):
+			if end := strings.Index(rest[2:], "~~"); end > 0 {
+				flush()
+				innerStyle := style
+				innerStyle.strikethrough = true
+				parseInline(rest[2:end+2], innerStyle, runs)
+				i += end + 4
+				continue
+			}
+		case rest[0] == '*' || rest[0] == '_':
+			if end := closingEmphasis(rest[1:], rest[0]); end > 0 && opensEmphasis(text, i, rest[:1]) {
+				flush()
+				innerStyle := style
+				innerStyle.italic = true
+				parseInline(rest[1:end+1], innerStyle, runs)
+				i += end + 2
+				continue
+			}
+		case rest[0] == '[' || strings.HasPrefix(rest, "!["):
+			offset := 0
+			if rest[0] == '!' {
+				offset = 1
+			}
+			if label, url, length, ok := inlineLink(rest[offset:]); ok {
+				flush()
+				linkStyle := style
+				linkStyle.link = url
+				parseInline(label, linkStyle, runs)
+				i += offset + length
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(rest)
+		literal.WriteRune(r)
+		i += size
+	}
+	flush()
+}
+
+// opensEmphasis rejects underscores inside words, so snake_case identifiers are kept as text
+func opensEmphasis(text string, at int, marker string) bool {
+	if marker != "_" || at == 0 {
+		return true
+	}
+	previous, _ := utf8.DecodeLastRuneInString(text[:at])
+	return !unicode.IsLetter(previous) && !unicode.IsDigit(previous)
+}
+
+// closingEmphasis returns the index of the single marker closing an emphasis, ignoring doubled markers
+func closingEmphasis(text string, marker byte) int {
+	for i := 0; i < len(text); i++ {
+		if text[i] != marker {
+			continue
+		}
+		if i+1 < len(text) && text[i+1] == marker {
+			i++
+			continue
+		}
+		if i > 0 && text[i-1] != ' ' {
+			return i
+		}
+	}
+	return -1
+}
+
+// inlineLink parses [label](url) at the start of text, returning the length it spans
+func inlineLink(text string) (label, url string, length int, ok bool) {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				if i+1 >= len(text) || text[i+1] != '(' {
+					return "", "", 0, false
+				}
+				end := strings.IndexByte(text[i+2:], ')')
+				if end < 0 {
+					return "", "", 0, false
+				}
+				url = strings.TrimSpace(text[i+2 : i+2+end])
+				if space := strings.IndexByte(url, ' '); space >= 0 {
+					url = url[:space] // drop an optional "title"
+				}
+				if url == "" {
+					return "", "", 0, false
+				}
+				return text[1:i], url, i + 3 + end, true
+			}
+		}
+	}
+	return "", "", 0, false
+}
+
+// splitBlocksForRequests groups top-level blocks into batches within the limits of a single request,
+// deeper nesting than a request allows is flattened into the deepest allowed level
+func splitBlocksForRequests(blocks []markdownBlock) [][]markdownBlock {
+	var batches [][]markdownBlock
+	var batch []markdownBlock
+	batchSize := 0
+	for _, block := range blocks {
+		limitNesting(block, 0)
+		size := countBlocks(block)
+		if len(batch) > 0 && (len(batch) >= maxChildrenPerRequest || batchSize+size > maxBlocksPerRequest) {
+			batches = append(batches, batch)
+			batch, batchSize = nil, 0
+		}
+		batch = append(batch, block)
+		batchSize += size
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// blockChildren returns the children nested in the content of a block
+func blockChildren(block markdownBlock) []markdownBlock {
+	blockType, _ := block["type"].(string)
+	content, ok := block[blockType].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	switch children := content["children"].(type) {
+	case []markdownBlock:
+		return children
+	case []interface{}:
+		converted := make([]markdownBlock, 0, len(children))
+		for _, child := range children {
+			if childBlock, ok := child.(map[string]interface{}); ok {
+				converted = append(converted, childBlock)
+			}
+		}
+		return converted
+	}
+	return nil
+}
+
+// setBlockChildren replaces the children nested in the content of a block, removing them when there are none
+func setBlockChildren(block markdownBlock, children []markdownBlock) {
+	blockType, _ := block["type"].(string)
+	content, ok := block[blockType].(map[string]interface{})
+	if !ok {
+		return
+	}
+	if len(children) == 0 {
+		delete(content, "children")
+		return
+	}
+	content["children"] = children
+}
+
+// limitNesting moves children nested deeper than a request allows up to the deepest allowed level
+func limitNesting(block markdownBlock, depth int) {
+	children := blockChildren(block)
+	if len(children) == 0 {
+		return
+	}
+	if depth < maxNestingDepth-1 {
+		for _, child := range children {
+			limitNesting(child, depth+1)
+		}
+		return
+	}
+
+	// Children at the deepest level cannot have children of their own, they follow them as siblings
+	var flattened []markdownBlock
+	var flatten func(blocks []markdownBlock)
+	flatten = func(blocks []markdownBlock) {
+		for _, child := range blocks {
+			flattened = append(flattened, child)
+			if nested := blockChildren(child); len(nested) > 0 {
+				setBlockChildren(child, nil)
+				flatten(nested)
+			}
+		}
+	}
+	flatten(children)
+	setBlockChildren(block, flattened)
+}
+
+// countBlocks returns the number of blocks of a block and its nested children
+func countBlocks(block markdownBlock) int {
+	count := 1
+	for _, child := range blockChildren(block) {
+		count += countBlocks(child)
+	}
+	return count
+}
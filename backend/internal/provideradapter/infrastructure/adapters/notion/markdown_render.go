@@ -0,0 +1,253 @@
+package notion
+
+import (
+	"strconv"
+	"strings"
+)
+
+// markdownIndent is the indentation of each level of nested blocks in rendered markdown
+const markdownIndent = "    "
+
+// listBlockTypes are the block types rendered as list items, consecutive items of a list are not separated by blank lines
+var listBlockTypes = map[string]bool{
+	"bulleted_list_item": true,
+	"numbered_list_item": true,
+	"to_do":              true,
+	"toggle":             true,
+}
+
+// blocksToMarkdown renders blocks fetched from the API as markdown, the children of a block are expected
+// under its "children" key
+func blocksToMarkdown(blocks []map[string]interface{}) string {
+	return strings.Join(renderBlocks(blocks, 0), "\n")
+}
+
+// renderBlocks renders blocks at a nesting depth into lines, top-level blocks are separated by blank lines
+func renderBlocks(blocks []map[string]interface{}, depth int) []string {
+	var lines []string
+	indent := strings.Repeat(markdownIndent, depth)
+	number := 0
+	previousType := ""
+
+	for _, block := range blocks {
+		blockType, _ := block["type"].(string)
+		content, _ := block[blockType].(map[string]interface{})
+		children, _ := block["children"].([]map[string]interface{})
+
+		if blockType == "numbered_list_item" {
+			number++
+		} else {
+			number = 0
+		}
+
+		var blockLines []string
+		nested := true
+		switch blockType {
+		case "paragraph":
+			blockLines = prefixLines(indent, indent, renderRichText(content["rich_text"]))
+		case "heading_1", "heading_2", "heading_3":
+			level := strings.Repeat("#", int(blockType[len(blockType)-1]-'0'))
+			blockLines = []string{indent + level + " " + strings.ReplaceAll(renderRichText(content["rich_text"]), "\n", " ")}
+		case "bulleted_list_item", "toggle":
+			blockLines = prefixLines(indent+"- ", indent+"  ", renderRichText(content["rich_text"]))
+		case "numbered_list_item":
+			marker := strconv.Itoa(number) + ". "
+			blockLines = prefixLines(indent+marker, indent+strings.Repeat(" ", len(marker)), renderRichText(content["rich_text"]))
+		case "to_do":
+			checkbox := "- [ ] "
+			if checked, _ := content["checked"].(bool); checked {
+				checkbox = "- [x] "
+			}
+			blockLines = prefixLines(indent+checkbox, indent+"      ", renderRichText(content["rich_text"]))
+		case "quote":
+			blockLines = prefixLines(indent+"> ", indent+"> ", renderRichText(content["rich_text"]))
+		case "callout":
+			text := renderRichText(content["rich_text"])
+			if icon, ok := content["icon"].(map[string]interface{}); ok {
+				if emoji, _ := icon["emoji"].(string); emoji != "" {
+					text = emoji + " " + text
+				}
+			}
+			blockLines = prefixLines(indent+"> ", indent+"> ", text)
+		case "code":
+			language, _ := content["language"].(string)
+			if language == defaultCodeLanguage {
+				language = ""
+			}
+			blockLines = append([]string{indent + "```" + language}, prefixLines(indent, indent, plainText(content["rich_text"]))...)
+			blockLines = append(blockLines, indent+"```")
+		case "equation":
+			expression, _ := content["expression"].(string)
+			blockLines = []string{indent + "$$" + expression + "$$"}
+		case "divider":
+			blockLines = []string{indent + "---"}
+		case "image":
+			blockLines = []string{indent + "!" + renderFileLink(content, "image")}
+		case "video", "file", "pdf", "audio":
+			blockLines = []string{indent + renderFileLink(content, blockType)}
+		case "bookmark", "embed", "link_preview":
+			url, _ := content["url"].(string)
+			label := renderRichText(content["caption"])
+			if label == "" {
+				label = url
+			}
+			blockLines = []string{indent + "[" + label + "](" + url + ")"}
+		case "child_page", "child_database":
+			title, _ := content["title"].(string)
+			id, _ := block["id"].(string)
+			blockLines = []string{indent + "[" + title + "](" + notionPageURL(id) + ")"}
+		case "link_to_page":
+			id, _ := content["page_id"].(string)
+			if id == "" {
+				id, _ = content["database_id"].(string)
+			}
+			blockLines = []string{indent + "[" + id + "](" + notionPageURL(id) + ")"}
+		case "table":
+			blockLines = renderTable(children, indent)
+			nested = false
+		case "column_list", "column", "synced_block", "template":
+			// Layout blocks only group their children, which are rendered in their place
+			blockLines = renderBlocks(children, depth)
+			nested = false
+		default:
+			// Blocks without a markdown equivalent, such as breadcrumbs or tables of contents, are skipped
+			continue
+		}
+
+		if nested && len(children) > 0 {
+			blockLines = append(blockLines, renderBlocks(children, depth+1)...)
+		}
+		if depth == 0 && len(lines) > 0 && !(listBlockTypes[blockType] && blockType == previousType) {
+			lines = append(lines, "")
+		}
+		lines = append(lines, blockLines...)
+		previousType = blockType
+	}
+	return lines
+}
+
+// renderTable renders the rows of a table, the first row is used as the header
+func renderTable(rows []map[string]interface{}, indent string) []string {
+	var lines []string
+	for i, row := range rows {
+		content, _ := row["table_row"].(map[string]interface{})
+		cells, _ := content["cells"].([]interface{})
+		rendered := make([]string, 0, len(cells))
+		for _, cell := range cells {
+			text := strings.ReplaceAll(renderRichText(cell), "|", "\\|")
+			rendered = append(rendered, strings.ReplaceAll(text, "\n", " "))
+		}
+		lines = append(lines, indent+"| "+strings.Join(rendered, " | ")+" |")
+		if i == 0 {
+			separators := make([]string, len(rendered))
+			for j := range separators {
+				separators[j] = "---"
+			}
+			lines = append(lines, indent+"| "+strings.Join(separators, " | ")+" |")
+		}
+	}
+	return lines
+}
+
+// renderFileLink renders a file block as a link to its hosted or external URL
+func renderFileLink(content map[string]interface{}, fallbackLabel string) string {
+	url := ""
+	for _, key := range []string{"external", "file"} {
+		if source, ok := content[key].(map[string]interface{}); ok {
+			url, _ = source["url"].(string)
+		}
+	}
+	label := renderRichText(content["caption"])
+	if label == "" {
+		if name, _ := content["name"].(string); name != "" {
+			label = name
+		} else {
+			label = fallbackLabel
+		}
+	}
+	return "[" + label + "](" + url + ")"
+}
+
+// renderRichText renders rich text with its annotations and links as inline markdown
+func renderRichText(value interface{}) string {
+	items, _ := value.([]interface{})
+	var sb strings.Builder
+	for _, item := range items {
+		segment, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		text, _ := segment["plain_text"].(string)
+		if segment["type"] == "equation" {
+			if equation, ok := segment["equation"].(map[string]interface{}); ok {
+				expression, _ := equation["expression"].(string)
+				sb.WriteString("$" + expression + "$")
+				continue
+			}
+		}
+		if text == "" {
+			continue
+		}
+
+		annotations, _ := segment["annotations"].(map[string]interface{})
+		if code, _ := annotations["code"].(bool); code {
+			text = "`" + text + "`"
+		}
+		for _, style := range []struct{ key, marker string }{
+			{"strikethrough", "~~"},
+			{"italic", "*"},
+			{"bold", "**"},
+		} {
+			if enabled, _ := annotations[style.key].(bool); enabled {
+				text = wrapInline(text, style.marker)
+			}
+		}
+		if href, _ := segment["href"].(string); href != "" {
+			text = "[" + text + "](" + href + ")"
+		}
+		sb.WriteString(text)
+	}
+	return sb.String()
+}
+
+// plainText concatenates the text of rich text without formatting
+func plainText(value interface{}) string {
+	items, _ := value.([]interface{})
+	var sb strings.Builder
+	for _, item := range items {
+		if segment, ok := item.(map[string]interface{}); ok {
+			text, _ := segment["plain_text"].(string)
+			sb.WriteString(text)
+		}
+	}
+	return sb.String()
+}
+
+// wrapInline surrounds text with an emphasis marker, keeping surrounding whitespace outside of it
+func wrapInline(text, marker string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	start := strings.Index(text, trimmed)
+	return text[:start] + marker + trimmed + marker + text[start+len(trimmed):]
+}
+
+// prefixLines splits text into lines, prefixing the first and the following ones
+func prefixLines(first, rest, text string) []string {
+	lines := strings.Split(text, "\n")
+	for i := range lines {
+		if i == 0 {
+			lines[i] = first + lines[i]
+		} else {
+			lines[i] = rest + lines[i]
+		}
+	}
+	return lines
+}
+
+// notionPageURL returns the URL of a page or database from its ID
+func notionPageURL(id string) string {
+	return "https://www.notion.so/" + strings.ReplaceAll(id, "-", "")
+}
@@ -0,0 +1,247 @@
+package notion
+
+import (
+	"strings"
+	"testing"
+)
+
+// asFetched converts blocks built for a request into the form the API returns them in, with plain text and
+// links on the rich text and the children of a block under its "children" key
+func asFetched(blocks []markdownBlock) []map[string]interface{} {
+	fetched := make([]map[string]interface{}, 0, len(blocks))
+	for _, block := range blocks {
+		blockType, _ := block["type"].(string)
+		content := map[string]interface{}{}
+		if source, ok := block[blockType].(map[string]interface{}); ok {
+			for key, value := range source {
+				switch key {
+				case "children":
+				case "rich_text", "caption":
+					content[key] = fetchedRichText(value.([]map[string]interface{}))
+				case "cells":
+					cells := make([]interface{}, 0, len(value.([]interface{})))
+					for _, cell := range value.([]interface{}) {
+						cells = append(cells, fetchedRichText(cell.([]map[string]interface{})))
+					}
+					content[key] = cells
+				default:
+					content[key] = value
+				}
+			}
+		}
+
+		fetchedBlock := map[string]interface{}{"object": "block", "type": blockType, blockType: content}
+		if children := blockChildren(block); len(children) > 0 {
+			fetchedBlock["children"] = asFetched(children)
+		}
+		fetched = append(fetched, fetchedBlock)
+	}
+	return fetched
+}
+
+func fetchedRichText(segments []map[string]interface{}) []interface{} {
+	fetched := make([]interface{}, 0, len(segments))
+	for _, segment := range segments {
+		text := segment["text"].(map[string]interface{})
+		item := map[string]interface{}{"type": "text", "plain_text": text["content"]}
+		if link, ok := text["link"].(map[string]interface{}); ok {
+			item["href"] = link["url"]
+		}
+		if annotations, ok := segment["annotations"]; ok {
+			item["annotations"] = annotations
+		}
+		fetched = append(fetched, item)
+	}
+	return fetched
+}
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+	}{
+		{name: "Headings", markdown: "# Title\n\n## Section\n\n### Subsection"},
+		{name: "Paragraph", markdown: "First line\nsecond line"},
+		{name: "InlineMarks", markdown: "Some **bold**, *italic*, ~~struck~~ and `code` text"},
+		{name: "Links", markdown: "See [the docs](https://example.com/docs) and [**bold link**](https://example.com)"},
+		{name: "SnakeCaseIsNotItalic", markdown: "call snake_case_name here"},
+		{name: "BulletedList", markdown: "- one\n- two\n    - nested\n        - deeper"},
+		{name: "NumberedList", markdown: "1. first\n2. second\n3. third"},
+		{name: "ToDos", markdown: "- [ ] open\n- [x] done"},
+		{name: "Code", markdown: "```go\nfmt.Println(\"**not bold**\")\n\nreturn\n```"},
+		{name: "CodeWithoutLanguage", markdown: "```\nplain\n```"},
+		{name: "Quote", markdown: "> quoted\n> **lines**"},
+		{name: "Table", markdown: "| Name | Value |\n| --- | --- |\n| a \\| b | *1* |\n| c |  |"},
+		{name: "Divider", markdown: "Above\n\n---\n\nBelow"},
+		{name: "Image", markdown: "![A diagram](https://example.com/diagram.png)"},
+		{
+			name:     "Document",
+			markdown: "# Plan\n\nIntro with [a link](https://example.com).\n\n- [ ] ship\n- [x] test\n\n> note\n\n```python\nprint(1)\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered := blocksToMarkdown(asFetched(markdownToBlocks(tt.markdown)))
+			if rendered != tt.markdown {
+				t.Errorf("round trip = %q, want %q", rendered, tt.markdown)
+			}
+		})
+	}
+}
+
+func TestMarkdownToBlocksNormalizes(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{name: "DeepHeadingsBecomeLevelThree", markdown: "##### Deep", expected: "### Deep"},
+		{name: "ClosingHashesAreDropped", markdown: "## Title ##", expected: "## Title"},
+		{name: "AlternativeMarkers", markdown: "* one\n+ two\n__bold__ _italic_", expected: "- one\n- two\n\n**bold** *italic*"},
+		{name: "EscapedMarkers", markdown: `\*not italic\*`, expected: "*not italic*"},
+		{name: "UnclosedMarkersStayText", markdown: "**open and `tick", expected: "**open and `tick"},
+		{name: "CodeLanguageAlias", markdown: "```js\nx\n```", expected: "```javascript\nx\n```"},
+		{name: "UnknownCodeLanguage", markdown: "```brainfuck\nx\n```", expected: "```\nx\n```"},
+		{name: "IndentedTextContinuesListItem", markdown: "- item\n    more", expected: "- item\n    more"},
+		{name: "NumberingIsRecomputed", markdown: "3. a\n7. b", expected: "1. a\n2. b"},
+		// Notion stores nested marks as runs with combined annotations, each rendered on its own
+		{name: "NestedMarksSplitIntoRuns", markdown: "**bold with *italic* inside**", expected: "**bold with** ***italic*** **inside**"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered := blocksToMarkdown(asFetched(markdownToBlocks(tt.markdown)))
+			if rendered != tt.expected {
+				t.Errorf("rendered = %q, want %q", rendered, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRichTextSplitsAtContentLimit(t *testing.T) {
+	tests := []struct {
+		name           string
+		text           string
+		expectedChunks []int
+	}{
+		{name: "AtLimit", text: strings.Repeat("a", maxRichTextContentLength), expectedChunks: []int{maxRichTextContentLength}},
+		{name: "OverLimit", text: strings.Repeat("a", maxRichTextContentLength+1), expectedChunks: []int{maxRichTextContentLength, 1}},
+		// The limit counts characters, not bytes
+		{name: "MultibyteAtLimit", text: strings.Repeat("é", maxRichTextContentLength), expectedChunks: []int{maxRichTextContentLength}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments := richText(tt.text)
+			if len(segments) != len(tt.expectedChunks) {
+				t.Fatalf("got %d segments, want %d", len(segments), len(tt.expectedChunks))
+			}
+			for i, segment := range segments {
+				content := segment["text"].(map[string]interface{})["content"].(string)
+				if length := len([]rune(content)); length != tt.expectedChunks[i] {
+					t.Errorf("segment %d has %d characters, want %d", i, length, tt.expectedChunks[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTextBlocksSplitAtRichTextItemLimit(t *testing.T) {
+	tests := []struct {
+		name           string
+		text           string
+		expectedBlocks []int
+	}{
+		{name: "AtLimit", text: strings.Repeat("a", maxRichTextItems*maxRichTextContentLength), expectedBlocks: []int{maxRichTextItems}},
+		{name: "OverLimit", text: strings.Repeat("a", maxRichTextItems*maxRichTextContentLength+1), expectedBlocks: []int{maxRichTextItems, 1}},
+		// Every styled run is a text object of its own, 51 plain and bold pairs and the trailing space make 103
+		{name: "StyledRuns", text: strings.Repeat("a **b** ", maxRichTextItems/2+1), expectedBlocks: []int{maxRichTextItems, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks := textBlocks("paragraph", tt.text, nil)
+			if len(blocks) != len(tt.expectedBlocks) {
+				t.Fatalf("got %d blocks, want %d", len(blocks), len(tt.expectedBlocks))
+			}
+			for i, block := range blocks {
+				segments := block["paragraph"].(map[string]interface{})["rich_text"].([]map[string]interface{})
+				if len(segments) != tt.expectedBlocks[i] {
+					t.Errorf("block %d has %d text objects, want %d", i, len(segments), tt.expectedBlocks[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSplitBlocksForRequests(t *testing.T) {
+	paragraphs := func(count int) []markdownBlock {
+		blocks := make([]markdownBlock, 0, count)
+		for i := 0; i < count; i++ {
+			blocks = append(blocks, textBlocks("paragraph", "text", nil)...)
+		}
+		return blocks
+	}
+	listWithChildren := func(count, children int) []markdownBlock {
+		blocks := make([]markdownBlock, 0, count)
+		for i := 0; i < count; i++ {
+			item := textBlocks("bulleted_list_item", "item", nil)[0]
+			appendChildren(item, paragraphs(children)...)
+			blocks = append(blocks, item)
+		}
+		return blocks
+	}
+
+	tests := []struct {
+		name            string
+		blocks          []markdownBlock
+		expectedBatches []int
+	}{
+		{name: "ChildrenLimit", blocks: paragraphs(maxChildrenPerRequest), expectedBatches: []int{maxChildrenPerRequest}},
+		{name: "OverChildrenLimit", blocks: paragraphs(maxChildrenPerRequest + 1), expectedBatches: []int{maxChildrenPerRequest, 1}},
+		// Ten items with 99 children each are exactly 1000 blocks
+		{name: "BlockLimit", blocks: listWithChildren(10, 99), expectedBatches: []int{10}},
+		{name: "OverBlockLimit", blocks: listWithChildren(11, 99), expectedBatches: []int{10, 1}},
+		{name: "Empty", blocks: nil, expectedBatches: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batches := splitBlocksForRequests(tt.blocks)
+			if len(batches) != len(tt.expectedBatches) {
+				t.Fatalf("got %d batches, want %d", len(batches), len(tt.expectedBatches))
+			}
+			for i, batch := range batches {
+				if len(batch) != tt.expectedBatches[i] {
+					t.Errorf("batch %d has %d blocks, want %d", i, len(batch), tt.expectedBatches[i])
+				}
+				size := 0
+				for _, block := range batch {
+					size += countBlocks(block)
+				}
+				if size > maxBlocksPerRequest {
+					t.Errorf("batch %d holds %d blocks, want at most %d", i, size, maxBlocksPerRequest)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitBlocksForRequestsFlattensDeepNesting(t *testing.T) {
+	item := func(text string) markdownBlock {
+		return textBlocks("bulleted_list_item", text, nil)[0]
+	}
+	root, child, grandchild, greatGrandchild := item("root"), item("child"), item("grandchild"), item("great-grandchild")
+	appendChildren(grandchild, greatGrandchild)
+	appendChildren(child, grandchild)
+	appendChildren(root, child)
+
+	batches := splitBlocksForRequests([]markdownBlock{root})
+
+	rendered := blocksToMarkdown(asFetched(batches[0]))
+	expected := "- root\n    - child\n        - grandchild\n        - great-grandchild"
+	if rendered != expected {
+		t.Errorf("flattened = %q, want %q", rendered, expected)
+	}
+}
@@ -1,10 +1,13 @@
 package notion
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
+	"github.com/bytedance/sonic"
 	"golang.org/x/oauth2"
 
 	credDomain "github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
@@ -97,21 +100,21 @@ func NewNotionAdapter(
 	return adapter
 }
 
-// createOAuth2Config creates the oauth2.Config object.
-func (a *NotionAdapter) createOAuth2Config(redirectURL string, scopes []string) *oauth2.Config {
+// createOAuth2Config creates the oauth2.Config object, using the OAuth app override of the context if any.
+func (a *NotionAdapter) createOAuth2Config(ctx context.Context, redirectURL string, scopes []string) *oauth2.Config {
 	endpoint := oauth2.Endpoint{
 		AuthURL:  authURL,
 		TokenURL: tokenURL,
 	}
 	//
 
-	return &oauth2.Config{
+	return base.ApplyOAuthAppOverride(ctx, &oauth2.Config{
 		ClientID:     a.oauthConfig.ClientID,
 		ClientSecret: a.oauthConfig.ClientSecret,
 		RedirectURL:  redirectURL,
 		Scopes:       scopes,
 		Endpoint:     endpoint,
-	}
+	})
 }
 
 // ShouldRefreshToken checks if the token should be refreshed
@@ -134,12 +137,33 @@ func (a *NotionAdapter) RefreshOAuthToken(ctx context.Context, oldToken *oauth2.
 	//
 }
 
+// RevokeOAuthToken revokes the access token, which also removes the integration from the workspace
+func (a *NotionAdapter) RevokeOAuthToken(ctx context.Context, token *oauth2.Token) error {
+	body, err := sonic.Marshal(map[string]string{"token": token.AccessToken})
+	if err != nil {
+		return fmt.Errorf("failed to encode revocation request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, revokeURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create revocation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Notion-Version", a.notionVersion)
+	oauthConfig := base.OAuthClientConfig(ctx, a.oauthConfig)
+	req.SetBasicAuth(oauthConfig.ClientID, oauthConfig.ClientSecret)
+
+	// Notion answers 401 for tokens that were already revoked
+	_, err = base.DoTokenRevocation(a.GetProviderAdapterInfo().Identifier, req, http.StatusUnauthorized)
+	return err
+}
+
 // GenerateOAuthURL generates an OAuth authorization URL.
 func (a *NotionAdapter) GenerateOAuthURL(ctx context.Context,
 	redirectURL, state, codeChallenge string,
 	scopes []string,
 ) (string, error) {
-	oauth2Config := a.createOAuth2Config(redirectURL, scopes)
+	oauth2Config := a.createOAuth2Config(ctx, redirectURL, scopes)
 	// Consider AccessTypeOffline if refresh tokens are needed and supported
 	// TODO: Make AccessType configurable?
 	return oauth2Config.AuthCodeURL(state,
@@ -150,7 +174,7 @@ func (a *NotionAdapter) GenerateOAuthURL(ctx context.Context,
 
 // ExchangeCodeForTokens exchanges an authorization code for tokens.
 func (a *NotionAdapter) ExchangeCodeForTokens(ctx context.Context, code, redirectURL, codeVerifier string) (*oauth2.Token, error) {
-	oauth2Config := a.createOAuth2Config(redirectURL, nil) // Scopes not needed for exchange usually
+	oauth2Config := a.createOAuth2Config(ctx, redirectURL, nil) // Scopes not needed for exchange usually
 	token, err := oauth2Config.Exchange(ctx, code)
 	if err != nil {
 		// Wrap error into AdapterError
@@ -214,7 +238,7 @@ func (a *NotionAdapter) Execute(
 		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrInternal, fmt.Sprintf("error checking permissions: %v", err), http.StatusInternalServerError)
 	}
 	if !allScopesPresent {
-		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrCredentialError, fmt.Sprintf("missing required permissions: %v", missingIDs), http.StatusForbidden)
+		return nil, domain.NewInsufficientScopeError(a.GetProviderAdapterInfo().Identifier, operationID, missingIDs)
 	}
 
 	// 4. Process User Parameters (Validation based on registered schema)
@@ -223,7 +247,23 @@ func (a *NotionAdapter) Execute(
 		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrInvalidParameters, fmt.Sprintf("parameter validation failed: %v", err), http.StatusBadRequest)
 	}
 
-	// 5. Call the Operation Handler to get REST parameters
+	// 5. Run operations needing several requests, each request is authenticated like single ones
+	call := func(ctx context.Context, restParams map[string]interface{}) (interface{}, error) {
+		return a.executeREST(ctx, operationID, restParams, oauthCred.Token.AccessToken)
+	}
+	if opDef.Runner != nil {
+		result, err := opDef.Runner(ctx, processedParams, call)
+		if err != nil {
+			var adapterErr *domain.AdapterError
+			if errors.As(err, &adapterErr) {
+				return nil, err
+			}
+			return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrInternal, fmt.Sprintf("operation handler failed: %v", err), http.StatusInternalServerError)
+		}
+		return result, nil
+	}
+
+	// 6. Call the Operation Handler to get REST parameters
 	handler := opDef.Handler
 	restParams, err := handler(ctx, processedParams)
 	if err != nil {
@@ -231,27 +271,22 @@ func (a *NotionAdapter) Execute(
 		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrInternal, fmt.Sprintf("operation handler failed: %v", err), http.StatusInternalServerError)
 	}
 
-	// 6. Inject Authentication and Notion Headers
+	// 7. Execute via REST Adapter
+	return call(ctx, restParams)
+}
+
+// executeREST executes one request described by REST parameters, injecting authentication and Notion headers.
+func (a *NotionAdapter) executeREST(ctx context.Context, operationID string, restParams map[string]interface{}, accessToken string) (interface{}, error) {
 	headers, _ := restParams["headers"].(map[string]string)
 	if headers == nil {
 		headers = make(map[string]string)
 	}
-	headers["Authorization"] = utils.StringsBuilder("Bearer ", oauthCred.Token.AccessToken)
+	headers["Authorization"] = utils.StringsBuilder("Bearer ", accessToken)
 	headers["Notion-Version"] = a.notionVersion // Use stored version
 	// Content-Type will be handled by restAdapter if body exists
 	restParams["headers"] = headers
 
-	// 7. Execute via REST Adapter
 	// Pass nil credential to REST adapter as auth is handled here
-	rawResult, err := a.restAdapter.Execute(ctx, operationID, restParams, nil)
-	if err != nil {
-		// Error should already be wrapped by restAdapter
-		return nil, err
-	}
-
-	// The operationResponseTypes map is now primarily for documentation,
-	// testing, or potential future use by callers, not for automatic decoding here.
-
-	// Return the raw result directly from the restAdapter
-	return rawResult, nil
+	// Error should already be wrapped by restAdapter
+	return a.restAdapter.Execute(ctx, operationID, restParams, nil)
 }
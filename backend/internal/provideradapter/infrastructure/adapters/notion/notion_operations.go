@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/bytedance/sonic"
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
 )
 
 // Constants define API paths relative to the BaseURL (https://api.notion.com/v1)
@@ -17,6 +19,7 @@ const (
 
 	// Blocks
 	endpointAppendBlockChildren = "blocks/{block_id}/children"
+	endpointListBlockChildren   = "blocks/{block_id}/children"
 
 	// Databases
 	endpointQueryDatabase = "databases/{database_id}/query"
@@ -44,6 +47,13 @@ const (
 	opListUsers            = "list_users"
 	opGetDatabase          = "get_database"
 	opListDatabases        = "list_databases"
+	opGetPageMarkdown      = "get_page_markdown"
+)
+
+// Limits of get_page_markdown, pages beyond them are rendered partially and marked as truncated
+const (
+	maxMarkdownBlocks = 2000
+	maxMarkdownDepth  = 8
 )
 
 // Create_pageParams defines parameters for the Create Page operation.
@@ -61,6 +71,9 @@ type Create_pageParams struct {
 	// Optional. A JSON string representing an array of Notion block objects to add as content. See Notion API docs for block structure.
 	Content_blocks_json string `mapstructure:"content_blocks_json" validate:"omitempty"` // Required: false in json
 
+	// Optional. Markdown converted to Notion blocks to add as content, instead of content_blocks_json.
+	Content_markdown string `mapstructure:"content_markdown" validate:"omitempty"` // Required: false in json
+
 	// Optional. A JSON string representing the page properties when creating an entry in a database. Keys must match database schema. Title property is handled separately via the 'title' parameter. See Notion API docs.
 	Database_properties_json string `mapstructure:"database_properties_json" validate:"omitempty"` // Required: false in json
 
@@ -81,6 +94,12 @@ type SearchParams struct {
 	// Sort direction.
 	Sort_direction string `mapstructure:"sort_direction" validate:"omitempty,oneof=ascending descending ''"` // Required: false in json
 
+	// Optional. The next_cursor of a previous response, to fetch the following page.
+	Start_cursor string `mapstructure:"start_cursor" validate:"omitempty"` // Required: false in json
+
+	// Optional. Number of items per page, at most 100.
+	Page_size int `mapstructure:"page_size" validate:"omitempty,gte=1,lte=100"` // Required: false in json
+
 }
 
 // Query_databaseParams defines parameters for the Query Database operation.
@@ -95,6 +114,12 @@ type Query_databaseParams struct {
 	// Optional. A JSON string representing the Notion sorts array (list of sort objects). See Notion API documentation for structure.
 	Sorts_json string `mapstructure:"sorts_json" validate:"omitempty"` // Required: false in json
 
+	// Optional. The next_cursor of a previous response, to fetch the following page.
+	Start_cursor string `mapstructure:"start_cursor" validate:"omitempty"` // Required: false in json
+
+	// Optional. Number of items per page, at most 100.
+	Page_size int `mapstructure:"page_size" validate:"omitempty,gte=1,lte=100"` // Required: false in json
+
 }
 
 // Append_to_blockParams defines parameters for the Append Content operation.
@@ -104,7 +129,10 @@ type Append_to_blockParams struct {
 	Block_id string `mapstructure:"block_id" validate:"required"` // Required: true in json
 
 	// A JSON string representing an array of Notion block objects to append. See Notion API docs for block structure.
-	Content_blocks_json string `mapstructure:"content_blocks_json" validate:"required"` // Required: true in json
+	Content_blocks_json string `mapstructure:"content_blocks_json" validate:"required_without=Content_markdown"` // Required: false in json
+
+	// Markdown converted to Notion blocks to append, instead of content_blocks_json.
+	Content_markdown string `mapstructure:"content_markdown" validate:"required_without=Content_blocks_json"` // Required: false in json
 
 }
 
@@ -116,6 +144,14 @@ type Get_pageParams struct {
 
 }
 
+// Get_page_markdownParams defines parameters for the Get Page as Markdown operation.
+type Get_page_markdownParams struct {
+
+	// The ID of the page to render.
+	Page_id string `mapstructure:"page_id" validate:"required"` // Required: true in json
+
+}
+
 // Update_page_propertiesParams defines parameters for the Update Page Properties operation.
 type Update_page_propertiesParams struct {
 
@@ -132,6 +168,13 @@ type Update_page_propertiesParams struct {
 
 // List_usersParams defines parameters for the List Users operation.
 type List_usersParams struct {
+
+	// Optional. The next_cursor of a previous response, to fetch the following page.
+	Start_cursor string `mapstructure:"start_cursor" validate:"omitempty"` // Required: false in json
+
+	// Optional. Number of items per page, at most 100.
+	Page_size int `mapstructure:"page_size" validate:"omitempty,gte=1,lte=100"` // Required: false in json
+
 }
 
 // Get_databaseParams defines parameters for the Get Database Info operation.
@@ -144,22 +187,58 @@ type Get_databaseParams struct {
 
 // List_databasesParams defines parameters for the List Databases operation.
 type List_databasesParams struct {
+
+	// Optional. The next_cursor of a previous response, to fetch the following page.
+	Start_cursor string `mapstructure:"start_cursor" validate:"omitempty"` // Required: false in json
+
+	// Optional. Number of items per page, at most 100.
+	Page_size int `mapstructure:"page_size" validate:"omitempty,gte=1,lte=100"` // Required: false in json
+
 }
 
 // OperationHandler defines the function signature for handling a specific API operation.
 // It now receives context and processed parameters, and returns parameters for the REST adapter.
 type OperationHandler func(ctx context.Context, params interface{}) (map[string]interface{}, error)
 
+// RESTCaller executes one request described by REST parameters against the Notion API.
+type RESTCaller func(ctx context.Context, restParams map[string]interface{}) (interface{}, error)
+
+// OperationRunner defines the function signature for operations needing several requests.
+// It receives processed parameters and returns the result of the operation.
+type OperationRunner func(ctx context.Context, params interface{}, call RESTCaller) (interface{}, error)
+
 // OperationDefinition combines parameter schema and handler. (Response Type removed)
 type OperationDefinition struct {
 	Schema                interface{}      // Parameter schema (struct pointer)
 	Handler               OperationHandler // Operation handler function
+	Runner                OperationRunner  // Operation runner function, used instead of Handler when set
 	PermissionIdentifiers []string         // List of internal permission identifiers required
 }
 
 // Operations maps operation IDs to their definitions.
 type Operations map[string]OperationDefinition
 
+// cursorPagination is how the list operations of Notion paginate, next_cursor is null after the last page
+var cursorPagination = &domain.Pagination{
+	Style:          domain.PaginationStyleCursor,
+	CursorParam:    "start_cursor",
+	ItemsPath:      "results",
+	NextCursorPath: "next_cursor",
+}
+
+// operationPaginations maps the paginated operation IDs to their pagination
+var operationPaginations = map[string]*domain.Pagination{
+	opSearch:        cursorPagination,
+	opQueryDatabase: cursorPagination,
+	opListUsers:     cursorPagination,
+	opListDatabases: cursorPagination,
+}
+
+// Pagination returns how an operation paginates, nil if it does not
+func (a *NotionAdapter) Pagination(operationID string) *domain.Pagination {
+	return operationPaginations[operationID]
+}
+
 // RegisterOperation registers the parameter schema and handler.
 // Method and Path are no longer passed here. ResponseType is also removed.
 func (a *NotionAdapter) RegisterOperation(operationID string, schema interface{}, handler OperationHandler, requiredPerms []string) {
@@ -175,13 +254,21 @@ func (a *NotionAdapter) RegisterOperation(operationID string, schema interface{}
 	}
 }
 
+// RegisterRunnerOperation registers the parameter schema and runner of an operation needing several requests.
+func (a *NotionAdapter) RegisterRunnerOperation(operationID string, schema interface{}, runner OperationRunner, requiredPerms []string) {
+	a.RegisterOperation(operationID, schema, nil, requiredPerms)
+	definition := a.operations[operationID]
+	definition.Runner = runner
+	a.operations[operationID] = definition
+}
+
 // registerOperations is called by the adapter constructor to register all supported operations.
 // Method and path are now handled within each operation handler.
 func (a *NotionAdapter) registerOperations() {
-	a.RegisterOperation(
+	a.RegisterRunnerOperation(
 		opCreatePage,
 		&Create_pageParams{},
-		handleCreate_page,
+		runCreate_page,
 		[]string{"access_notion"},
 	)
 
@@ -199,10 +286,10 @@ func (a *NotionAdapter) registerOperations() {
 		[]string{"access_notion"},
 	)
 
-	a.RegisterOperation(
+	a.RegisterRunnerOperation(
 		opAppendToBlock,
 		&Append_to_blockParams{},
-		handleAppend_to_block,
+		runAppend_to_block,
 		[]string{"access_notion"},
 	)
 
@@ -240,10 +327,17 @@ func (a *NotionAdapter) registerOperations() {
 		handleList_databases,
 		[]string{"access_notion"},
 	)
+
+	a.RegisterRunnerOperation(
+		opGetPageMarkdown,
+		&Get_page_markdownParams{},
+		runGet_page_markdown,
+		[]string{"access_notion"},
+	)
 }
 
-// handleCreate_page constructs parameters for the REST adapter to create a page.
-func handleCreate_page(ctx context.Context, params interface{}) (map[string]interface{}, error) {
+// runCreate_page creates a page, appending the content blocks that do not fit in the creation request.
+func runCreate_page(ctx context.Context, params interface{}, call RESTCaller) (interface{}, error) {
 	// 1. Cast params
 	p, ok := params.(*Create_pageParams)
 	if !ok {
@@ -297,14 +391,14 @@ func handleCreate_page(ctx context.Context, params interface{}) (map[string]inte
 		// This requires knowing the title property key from the JSON, which is complex. Skip for now.
 	}
 
-	// Handle optional Content Blocks
-	if p.Content_blocks_json != "" {
-		var contentBlocks []map[string]interface{} // Keep as map for block creation flexibility
-		if err := sonic.Unmarshal([]byte(p.Content_blocks_json), &contentBlocks); err == nil {
-			reqBody.Children = &contentBlocks
-		} else {
-			return nil, fmt.Errorf("failed to parse content_blocks_json: %w", err)
-		}
+	// Handle optional Content Blocks, the first batch is created with the page
+	contentBlocks, err := parseContentBlocks(p.Content_blocks_json, p.Content_markdown)
+	if err != nil {
+		return nil, err
+	}
+	batches := splitBlocksForRequests(contentBlocks)
+	if len(batches) > 0 {
+		reqBody.Children = &batches[0]
 	}
 
 	// 3. Prepare parameters for REST Adapter
@@ -314,7 +408,70 @@ func handleCreate_page(ctx context.Context, params interface{}) (map[string]inte
 		"body":   reqBody,            // Use the structured request body
 	}
 
-	return restParams, nil
+	page, err := call(ctx, restParams)
+	if err != nil || len(batches) <= 1 {
+		return page, err
+	}
+
+	// 4. Append the remaining batches to the new page
+	pageObject, _ := page.(map[string]interface{})
+	pageID, _ := pageObject["id"].(string)
+	if pageID == "" {
+		return nil, fmt.Errorf("created page has no id to append the remaining content to")
+	}
+	if _, err := appendBlockBatches(ctx, call, pageID, batches[1:]); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// parseContentBlocks returns the blocks given as JSON or converted from markdown, only one of them may be set.
+func parseContentBlocks(blocksJSON, markdown string) ([]map[string]interface{}, error) {
+	if blocksJSON != "" && markdown != "" {
+		return nil, fmt.Errorf("provide either content_blocks_json or content_markdown, not both")
+	}
+	if markdown != "" {
+		return markdownToBlocks(markdown), nil
+	}
+	if blocksJSON == "" {
+		return nil, nil
+	}
+
+	var contentBlocks []map[string]interface{} // Keep as map for block creation flexibility
+	if err := sonic.Unmarshal([]byte(blocksJSON), &contentBlocks); err != nil {
+		return nil, fmt.Errorf("failed to parse content_blocks_json: %w", err)
+	}
+	return contentBlocks, nil
+}
+
+// appendBlockBatches appends batches of blocks to a block one request at a time, returning the appended blocks.
+func appendBlockBatches(ctx context.Context, call RESTCaller, blockID string, batches [][]map[string]interface{}) (map[string]interface{}, error) {
+	var response map[string]interface{}
+	var results []interface{}
+	for _, batch := range batches {
+		restParams := map[string]interface{}{
+			"method":      http.MethodPatch,
+			"path":        endpointAppendBlockChildren, // Use constant template
+			"path_params": map[string]string{"block_id": blockID},
+			"body":        &AppendBlockChildrenRequest{Children: batch},
+		}
+		result, err := call(ctx, restParams)
+		if err != nil {
+			return nil, err
+		}
+
+		response, _ = result.(map[string]interface{})
+		if batchResults, ok := response["results"].([]interface{}); ok {
+			results = append(results, batchResults...)
+		}
+	}
+
+	if response == nil {
+		response = map[string]interface{}{"object": "list"}
+	}
+	response["results"] = results
+	return response, nil
 }
 
 // handleSearch constructs parameters for the REST adapter to search pages/databases.
@@ -372,6 +529,13 @@ func handleSearch(ctx context.Context, params interface{}) (map[string]interface
 		}
 	}
 
+	if p.Start_cursor != "" {
+		body.StartCursor = &p.Start_cursor
+	}
+	if p.Page_size > 0 {
+		body.PageSize = &p.Page_size
+	}
+
 	restParams := map[string]interface{}{
 		"method": http.MethodPost,
 		"path":   endpointSearch,
@@ -407,9 +571,12 @@ func handleQuery_database(ctx context.Context, params interface{}) (map[string]i
 		body.Sorts = sorts
 	}
 
-	// TODO: Add pagination (StartCursor, PageSize)
-	// Example: if pageSize > 0 { body.PageSize = &pageSize }
-	// Example: if startCursor != "" { body.StartCursor = &startCursor }
+	if p.Start_cursor != "" {
+		body.StartCursor = &p.Start_cursor
+	}
+	if p.Page_size > 0 {
+		body.PageSize = &p.Page_size
+	}
 
 	restParams := map[string]interface{}{
 		"method":      http.MethodPost,
@@ -421,30 +588,22 @@ func handleQuery_database(ctx context.Context, params interface{}) (map[string]i
 	return restParams, nil
 }
 
-// handleAppend_to_block constructs parameters for the REST adapter to append content.
-func handleAppend_to_block(ctx context.Context, params interface{}) (map[string]interface{}, error) {
+// runAppend_to_block appends content, split into as many requests as the block limits of the API require.
+func runAppend_to_block(ctx context.Context, params interface{}, call RESTCaller) (interface{}, error) {
 	p, ok := params.(*Append_to_blockParams)
 	if !ok {
 		return nil, fmt.Errorf("internal error: unexpected parameter type %T for operation append_to_block", params)
 	}
 
-	// Construct request body using AppendBlockChildrenRequest struct
-	body := &AppendBlockChildrenRequest{}
-
-	var contentBlocks []map[string]interface{} // Keep as map for block creation flexibility
-	if err := sonic.Unmarshal([]byte(p.Content_blocks_json), &contentBlocks); err != nil {
-		return nil, fmt.Errorf("failed to parse content_blocks_json: %w", err)
+	contentBlocks, err := parseContentBlocks(p.Content_blocks_json, p.Content_markdown)
+	if err != nil {
+		return nil, err
 	}
-	body.Children = contentBlocks
-
-	restParams := map[string]interface{}{
-		"method":      http.MethodPatch,
-		"path":        endpointAppendBlockChildren, // Use constant template
-		"path_params": map[string]string{"block_id": p.Block_id},
-		"body":        body,
+	if len(contentBlocks) == 0 {
+		return nil, fmt.Errorf("no content blocks to append")
 	}
 
-	return restParams, nil
+	return appendBlockBatches(ctx, call, p.Block_id, splitBlocksForRequests(contentBlocks))
 }
 
 // handleGet_page constructs parameters for the REST adapter to get page info.
@@ -504,12 +663,18 @@ func handleUpdate_page_properties(ctx context.Context, params interface{}) (map[
 
 // handleList_users constructs parameters for the REST adapter to list users.
 func handleList_users(ctx context.Context, params interface{}) (map[string]interface{}, error) {
-	_, ok := params.(*List_usersParams)
+	p, ok := params.(*List_usersParams)
 	if !ok {
 		return nil, fmt.Errorf("internal error: unexpected parameter type %T for operation list_users", params)
 	}
 
 	queryParams := make(map[string]string)
+	if p.Start_cursor != "" {
+		queryParams["start_cursor"] = p.Start_cursor
+	}
+	if p.Page_size > 0 {
+		queryParams["page_size"] = strconv.Itoa(p.Page_size)
+	}
 
 	restParams := map[string]interface{}{
 		"method": http.MethodGet,
@@ -540,7 +705,7 @@ func handleGet_database(ctx context.Context, params interface{}) (map[string]int
 
 // handleList_databases constructs parameters for the REST adapter to list databases using search.
 func handleList_databases(ctx context.Context, params interface{}) (map[string]interface{}, error) {
-	_, ok := params.(*List_databasesParams)
+	p, ok := params.(*List_databasesParams)
 	if !ok {
 		return nil, fmt.Errorf("internal error: unexpected parameter type %T for operation list_databases", params)
 	}
@@ -552,6 +717,12 @@ func handleList_databases(ctx context.Context, params interface{}) (map[string]i
 			Value:    "database",
 		},
 	}
+	if p.Start_cursor != "" {
+		body.StartCursor = &p.Start_cursor
+	}
+	if p.Page_size > 0 {
+		body.PageSize = &p.Page_size
+	}
 
 	restParams := map[string]interface{}{
 		"method": http.MethodPost,
@@ -561,3 +732,119 @@ func handleList_databases(ctx context.Context, params interface{}) (map[string]i
 
 	return restParams, nil
 }
+
+// runGet_page_markdown renders a page and its nested blocks as markdown, without descending into child pages.
+func runGet_page_markdown(ctx context.Context, params interface{}, call RESTCaller) (interface{}, error) {
+	p, ok := params.(*Get_page_markdownParams)
+	if !ok {
+		return nil, fmt.Errorf("internal error: unexpected parameter type %T for operation get_page_markdown", params)
+	}
+
+	page, err := call(ctx, map[string]interface{}{
+		"method":      http.MethodGet,
+		"path":        endpointGetPage,
+		"path_params": map[string]string{"page_id": p.Page_id},
+	})
+	if err != nil {
+		return nil, err
+	}
+	pageObject, _ := page.(map[string]interface{})
+	title := pageTitle(pageObject)
+
+	fetcher := &blockTreeFetcher{call: call}
+	blocks, err := fetcher.children(ctx, p.Page_id, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	markdown := blocksToMarkdown(blocks)
+	if title != "" {
+		markdown = "# " + title + "\n\n" + markdown
+	}
+
+	return map[string]interface{}{
+		"id":        pageObject["id"],
+		"url":       pageObject["url"],
+		"title":     title,
+		"markdown":  markdown,
+		"truncated": fetcher.truncated,
+	}, nil
+}
+
+// pageTitle returns the plain text of the title property of a page.
+func pageTitle(page map[string]interface{}) string {
+	properties, _ := page["properties"].(map[string]interface{})
+	for _, property := range properties {
+		propertyObject, ok := property.(map[string]interface{})
+		if !ok || propertyObject["type"] != "title" {
+			continue
+		}
+		return plainText(propertyObject["title"])
+	}
+	return ""
+}
+
+// blockTreeFetcher lists the nested children of a block, within the limits of get_page_markdown.
+type blockTreeFetcher struct {
+	call      RESTCaller
+	fetched   int
+	truncated bool
+}
+
+// children lists the children of a block page by page, attaching their own children under "children".
+func (f *blockTreeFetcher) children(ctx context.Context, blockID string, depth int) ([]map[string]interface{}, error) {
+	var blocks []map[string]interface{}
+	cursor := ""
+	for {
+		queryParams := map[string]string{"page_size": "100"}
+		if cursor != "" {
+			queryParams["start_cursor"] = cursor
+		}
+		result, err := f.call(ctx, map[string]interface{}{
+			"method":       http.MethodGet,
+			"path":         endpointListBlockChildren,
+			"path_params":  map[string]string{"block_id": blockID},
+			"query_params": queryParams,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		list, _ := result.(map[string]interface{})
+		results, _ := list["results"].([]interface{})
+		for _, item := range results {
+			block, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if f.fetched >= maxMarkdownBlocks {
+				f.truncated = true
+				return blocks, nil
+			}
+			f.fetched++
+			blocks = append(blocks, block)
+
+			// Child pages and databases are linked, not inlined
+			hasChildren, _ := block["has_children"].(bool)
+			if !hasChildren || block["type"] == "child_page" || block["type"] == "child_database" {
+				continue
+			}
+			if depth >= maxMarkdownDepth {
+				f.truncated = true
+				continue
+			}
+			id, _ := block["id"].(string)
+			children, err := f.children(ctx, id, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			block["children"] = children
+		}
+
+		hasMore, _ := list["has_more"].(bool)
+		cursor, _ = list["next_cursor"].(string)
+		if !hasMore || cursor == "" {
+			return blocks, nil
+		}
+	}
+}
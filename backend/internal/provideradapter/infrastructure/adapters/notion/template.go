@@ -15,8 +15,9 @@ const (
 	baseURL       = "https://api.notion.com/v1"
 	notionVersion = "2022-06-28"
 
-	authURL  = "https://api.notion.com/v1/oauth/authorize"
-	tokenURL = "https://api.notion.com/v1/oauth/token"
+	authURL   = "https://api.notion.com/v1/oauth/authorize"
+	tokenURL  = "https://api.notion.com/v1/oauth/token"
+	revokeURL = "https://api.notion.com/v1/oauth/revoke"
 )
 
 var opDefaults = OperationDefaults{
@@ -42,6 +43,8 @@ var opDefaults = OperationDefaults{
 func init() {
 
 	var _ domain.OAuthAdapter = (*NotionAdapter)(nil)
+	var _ domain.OAuthTokenRevoker = (*NotionAdapter)(nil)
+	var _ domain.Paginator = (*NotionAdapter)(nil)
 
 	template := &NotionAdapterTemplate{}
 	registry.RegisterAdapterTemplate("notion", template)
@@ -0,0 +1,123 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
+)
+
+const (
+	braveBaseURL        = "https://api.search.brave.com/res/v1"
+	braveEndpointSearch = "web/search"
+	braveAPIKeyHeader   = "X-Subscription-Token"
+	braveMaxCount       = 20
+)
+
+// braveDataRanges maps data ranges to Brave freshness values, which have no hour range
+var braveDataRanges = map[string]string{
+	"hour":  "pd",
+	"day":   "pd",
+	"week":  "pw",
+	"month": "pm",
+	"year":  "py",
+}
+
+// braveResponse is the part of a Brave web search response that is normalized
+type braveResponse struct {
+	Web struct {
+		Results []struct {
+			Title       string `json:"title"`
+			URL         string `json:"url"`
+			Description string `json:"description"`
+			PageAge     string `json:"page_age"`
+			Age         string `json:"age"`
+			Profile     struct {
+				Name string `json:"name"`
+			} `json:"profile"`
+			MetaURL struct {
+				Hostname string `json:"hostname"`
+			} `json:"meta_url"`
+		} `json:"results"`
+	} `json:"web"`
+}
+
+// BraveBackend searches through the Brave Search API
+type BraveBackend struct {
+	restAdapter domain.Adapter
+	apiKey      string
+}
+
+// NewBraveBackend creates a new BraveBackend
+func NewBraveBackend(restAdapter domain.Adapter, apiKey string) *BraveBackend {
+	return &BraveBackend{
+		restAdapter: restAdapter,
+		apiKey:      apiKey,
+	}
+}
+
+// Name returns the name of the backend
+func (b *BraveBackend) Name() string {
+	return backendBrave
+}
+
+// Search runs a query against the Brave Search API
+func (b *BraveBackend) Search(ctx context.Context, query *SearchQuery) (*SearchResponse, error) {
+	// Brave pages by offset in pages of count results, with at most 20 results per page
+	count := min(query.Num, braveMaxCount)
+	queryParams := map[string]string{
+		"q":      query.Query,
+		"count":  strconv.Itoa(count),
+		"offset": strconv.Itoa(query.Page - 1),
+	}
+	if query.Country != "" {
+		queryParams["country"] = strings.ToUpper(query.Country)
+	}
+	if query.Language != "" {
+		queryParams["search_lang"] = query.Language
+	}
+	if freshness, ok := braveDataRanges[query.DataRange]; ok {
+		queryParams["freshness"] = freshness
+	}
+
+	restParams := map[string]interface{}{
+		"method":       http.MethodGet,
+		"path":         braveEndpointSearch,
+		"query_params": queryParams,
+		"headers": map[string]string{
+			"Accept":          "application/json",
+			braveAPIKeyHeader: b.apiKey,
+		},
+	}
+	result, err := b.restAdapter.Execute(ctx, operationIDSearch, restParams, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response braveResponse
+	if err := decodeResponse(result, &response); err != nil {
+		return nil, fmt.Errorf("brave: %w", err)
+	}
+
+	normalized := &SearchResponse{Backend: backendBrave, Query: query.Query, Results: []SearchResult{}}
+	for _, item := range response.Web.Results {
+		result := SearchResult{
+			Title:         item.Title,
+			URL:           item.URL,
+			Snippet:       item.Description,
+			PublishedDate: item.PageAge,
+			Source:        item.Profile.Name,
+		}
+		if result.PublishedDate == "" {
+			result.PublishedDate = item.Age
+		}
+		if result.Source == "" {
+			result.Source = strings.TrimPrefix(item.MetaURL.Hostname, "www.")
+		}
+		normalized.Results = append(normalized.Results, result)
+	}
+	return normalized, nil
+}
@@ -2,6 +2,7 @@ package search
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -9,50 +10,48 @@ import (
 	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/base"
 )
 
-// SearchAdapter is the adapter for Serper API
+// SearchAdapter is the adapter for web search, running on one of the configured search backends
 type SearchAdapter struct {
 	*base.BaseAdapter
-	restAdapter domain.Adapter // Use RESTAdapter for actual execution
-	operations  Operations     // Operation ID mapping to OperationDefinition
-	apiKey      string
+	backends       map[string]SearchBackend // Configured backends by name
+	defaultBackend string                   // Backend of requests not asking for one
+	operations     Operations               // Operation ID mapping to OperationDefinition
 }
 
 // NewSearchAdapter creates a new SearchAdapter
 func NewSearchAdapter(
 	providerInfo *domain.ProviderAdapterInfo,
 	config *domain.AdapterConfig,
-	restAdapter domain.Adapter,
-	apiKey string,
+	backends []SearchBackend,
+	defaultBackend string,
 ) *SearchAdapter {
 	// 1. Create SearchAdapter instance
 	baseAdapter := base.NewBaseAdapter(providerInfo, config)
 	adapter := &SearchAdapter{
-		BaseAdapter: baseAdapter,
-		restAdapter: restAdapter,
-		operations:  make(Operations),
-		apiKey:      apiKey,
+		BaseAdapter:    baseAdapter,
+		backends:       make(map[string]SearchBackend, len(backends)),
+		defaultBackend: defaultBackend,
+		operations:     make(Operations),
+	}
+	for _, backend := range backends {
+		adapter.backends[backend.Name()] = backend
 	}
 
-	// Register specific Serper API operations
+	// Register specific search operations
 	adapter.registerOperations()
 
 	return adapter
 }
 
-// Execute finds the appropriate handler for the operation, prepares parameters including API key,
-// and delegates execution to the underlying RESTAdapter.
+// Execute finds the appropriate handler for the operation, builds the search query,
+// and runs it on the backend asked for or the default one.
 func (a *SearchAdapter) Execute(
 	ctx context.Context,
 	operationID string,
 	params map[string]interface{}, // Original user parameters
-	credential interface{}, // This parameter is now possibly no longer needed, or used for other purposes
+	credential interface{}, // This parameter is not used, backends authenticate with their configured keys
 ) (interface{}, error) {
 
-	apiKey := a.apiKey
-	if apiKey == "" {
-		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrCredentialError, "invalid or missing API key credential", http.StatusUnauthorized)
-	}
-
 	// 1. Find the operation handler definition
 	opDef, exists := a.operations[operationID]
 	if !exists {
@@ -67,23 +66,28 @@ func (a *SearchAdapter) Execute(
 
 	// 3. Call handler with processed parameters
 	handler := opDef.Handler
-	restParams, err := handler(ctx, processedParams)
+	query, backendName, err := handler(ctx, processedParams)
 	if err != nil {
 		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, "HANDLER_ERROR", fmt.Sprintf("Handler execution failed: %s", err.Error()), http.StatusInternalServerError)
 	}
 
-	// 4. Inject API Key into restParams
-	headers, _ := restParams["headers"].(map[string]string)
-	if headers == nil {
-		headers = make(map[string]string)
+	// 4. Pick the backend
+	if backendName == "" {
+		backendName = a.defaultBackend
+	}
+	backend, ok := a.backends[backendName]
+	if !ok {
+		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrInvalidParameters, fmt.Sprintf("search backend '%s' is not configured", backendName), http.StatusBadRequest)
 	}
-	headers[apikeyParamName] = apiKey
-	restParams["headers"] = headers
 
-	// 5. Delegate execution to REST adapter
-	result, err := a.restAdapter.Execute(ctx, operationID, restParams, nil)
+	// 5. Run the search, backend errors from the REST adapter are already adapter errors
+	result, err := backend.Search(ctx, query)
 	if err != nil {
-		return nil, err
+		var adapterErr *domain.AdapterError
+		if errors.As(err, &adapterErr) {
+			return nil, err
+		}
+		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrProviderAPIError, err.Error(), http.StatusBadGateway)
 	}
 
 	return result, nil
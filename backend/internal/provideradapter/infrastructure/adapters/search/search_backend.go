@@ -0,0 +1,76 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/bytedance/sonic"
+)
+
+// Names of the search backends, used in configuration and in the backend parameter of requests
+const (
+	backendSerper  = "serper"
+	backendSearXNG = "searxng"
+	backendBrave   = "brave"
+)
+
+// backendOrder is the order in which configured backends are picked as default when none is configured
+var backendOrder = []string{backendSerper, backendBrave, backendSearXNG}
+
+// SearchBackend runs searches against one search engine, normalizing its results
+type SearchBackend interface {
+	// Name returns the name of the backend
+	Name() string
+
+	// Search runs a query and returns its normalized results
+	Search(ctx context.Context, query *SearchQuery) (*SearchResponse, error)
+}
+
+// SearchQuery is a search request, independent of the backend running it
+type SearchQuery struct {
+	Query     string // Query string
+	Country   string // Two letter country code, lower case
+	Language  string // Two letter language code, lower case
+	DataRange string // Time range looking back from now: hour, day, week, month or year, empty for any time
+	Num       int    // Number of results
+	Page      int    // Page number, starting at 1
+}
+
+// SearchResult is a search result normalized across backends
+type SearchResult struct {
+	Title         string `json:"title"`
+	URL           string `json:"url"`
+	Snippet       string `json:"snippet"`
+	PublishedDate string `json:"published_date,omitempty"` // As reported by the backend, not every backend uses ISO 8601
+	Source        string `json:"source"`                   // Name or host of the site of the result
+}
+
+// SearchResponse is the normalized response of a search
+type SearchResponse struct {
+	Backend string         `json:"backend"`
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+}
+
+// decodeResponse converts the JSON decoded by the REST adapter into the response type of a backend
+func decodeResponse(result interface{}, target interface{}) error {
+	jsonBytes, err := sonic.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search response: %w", err)
+	}
+	if err := sonic.Unmarshal(jsonBytes, target); err != nil {
+		return fmt.Errorf("failed to decode search response: %w", err)
+	}
+	return nil
+}
+
+// sourceFromURL returns the host of a result URL, without its www. prefix, for backends not naming the source
+func sourceFromURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(parsed.Hostname(), "www.")
+}
@@ -3,12 +3,7 @@ package search
 import (
 	"context"
 	"fmt"
-	"net/http"
-)
-
-// Define constants for API paths used by handlers.
-const (
-	endpointSearch = "search"
+	"strings"
 )
 
 // Define constants for operation IDs used by handlers.
@@ -18,39 +13,38 @@ const (
 
 var operationDefaults = OperationDefaults{
 	Search: SearchDefaults{
-		Type:        "search",
-		Country:     "us",
-		Language:    "en",
-		AutoCorrect: true,
-		Num:         10,
-		Page:        1,
+		Country:  "us",
+		Language: "en",
+		Num:      10,
+		Page:     1,
 	},
 }
 
-// SearchDefaults holds default values for 'serper.search' operation
+// SearchDefaults holds default values for 'search.search' operation
 type SearchDefaults struct {
-	Type        string // Search type (search, images, news, videos)
-	Country     string // Country code
-	Language    string // Language code
-	AutoCorrect bool   // Whether to auto-correct
-	Num         int    // Number of results
-	Page        int    // Page number
+	Country  string // Country code
+	Language string // Language code
+	Num      int    // Number of results
+	Page     int    // Page number
 }
 
-// OperationDefaults holds default parameter values for various Serper operations
+// OperationDefaults holds default parameter values for various search operations
 // These are typically configured once when the adapter is created
 type OperationDefaults struct {
 	Search SearchDefaults // Default values for search operation
 }
 
-// SearchParams defines user parameters for the serper.search operation
+// SearchParams defines user parameters for the search.search operation
 type SearchParams struct {
 	Query     string `mapstructure:"query" validate:"required"`
+	Country   string `mapstructure:"country" validate:"omitempty,len=2"`
 	DataRange string `mapstructure:"data_range" validate:"omitempty,oneof=hour day week month year"`
+	Backend   string `mapstructure:"backend" validate:"omitempty,oneof=serper searxng brave"` // Backend of the deployment when empty
 }
 
-// OperationHandler receives processed parameters and returns REST parameters
-type OperationHandler func(ctx context.Context, processedParams interface{}) (map[string]interface{}, error)
+// OperationHandler receives processed parameters and returns the query to run on a search backend,
+// with the name of the backend asked for, empty for the default one
+type OperationHandler func(ctx context.Context, processedParams interface{}) (*SearchQuery, string, error)
 
 // OperationDefinition combines parameter structure and handler
 type OperationDefinition struct {
@@ -83,34 +77,23 @@ func (a *SearchAdapter) registerOperations() {
 	)
 }
 
-func handleSearch(ctx context.Context, processedParams interface{}) (map[string]interface{}, error) {
+func handleSearch(ctx context.Context, processedParams interface{}) (*SearchQuery, string, error) {
 	params, ok := processedParams.(*SearchParams)
 	if !ok {
-		return nil, fmt.Errorf("internal error: unexpected parameter type for %s", operationIDSearch)
+		return nil, "", fmt.Errorf("internal error: unexpected parameter type for %s", operationIDSearch)
 	}
 
-	body := map[string]interface{}{
-		"q":           params.Query,
-		"type":        operationDefaults.Search.Type,
-		"gl":          operationDefaults.Search.Country,
-		"hl":          operationDefaults.Search.Language,
-		"autocorrect": operationDefaults.Search.AutoCorrect,
-		"num":         operationDefaults.Search.Num,
-		"page":        operationDefaults.Search.Page,
+	query := &SearchQuery{
+		Query:     params.Query,
+		Country:   operationDefaults.Search.Country,
+		Language:  operationDefaults.Search.Language,
+		DataRange: params.DataRange,
+		Num:       operationDefaults.Search.Num,
+		Page:      operationDefaults.Search.Page,
 	}
-
-	if params.DataRange != "" {
-		body["tbs"] = fmt.Sprintf("qdr:%s", params.DataRange[0:1])
-	}
-
-	restParams := map[string]interface{}{
-		"method": http.MethodPost,
-		"path":   endpointSearch,
-		"body":   body,
-		"headers": map[string]string{
-			"Content-Type": "application/json",
-		},
+	if params.Country != "" {
+		query.Country = strings.ToLower(params.Country)
 	}
 
-	return restParams, nil
+	return query, params.Backend, nil
 }
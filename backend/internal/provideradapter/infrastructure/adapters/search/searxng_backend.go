@@ -0,0 +1,105 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
+)
+
+const searxngEndpointSearch = "search"
+
+// searxngDataRanges maps data ranges to SearXNG time ranges, which have no hour range
+var searxngDataRanges = map[string]string{
+	"hour":  "day",
+	"day":   "day",
+	"week":  "week",
+	"month": "month",
+	"year":  "year",
+}
+
+// searxngResponse is the part of a SearXNG JSON response that is normalized
+type searxngResponse struct {
+	Results []struct {
+		Title         string  `json:"title"`
+		URL           string  `json:"url"`
+		Content       string  `json:"content"`
+		PublishedDate *string `json:"publishedDate"`
+	} `json:"results"`
+}
+
+// SearXNGBackend searches through a SearXNG instance, which must have the JSON output format enabled
+type SearXNGBackend struct {
+	restAdapter domain.Adapter
+}
+
+// NewSearXNGBackend creates a new SearXNGBackend, the REST adapter is expected to target the instance URL
+func NewSearXNGBackend(restAdapter domain.Adapter) *SearXNGBackend {
+	return &SearXNGBackend{
+		restAdapter: restAdapter,
+	}
+}
+
+// Name returns the name of the backend
+func (b *SearXNGBackend) Name() string {
+	return backendSearXNG
+}
+
+// Search runs a query against the SearXNG instance
+func (b *SearXNGBackend) Search(ctx context.Context, query *SearchQuery) (*SearchResponse, error) {
+	queryParams := map[string]string{
+		"q":      query.Query,
+		"format": "json",
+		"pageno": strconv.Itoa(query.Page),
+	}
+	if query.Language != "" {
+		language := query.Language
+		if query.Country != "" {
+			language += "-" + strings.ToUpper(query.Country)
+		}
+		queryParams["language"] = language
+	}
+	if timeRange, ok := searxngDataRanges[query.DataRange]; ok {
+		queryParams["time_range"] = timeRange
+	}
+
+	restParams := map[string]interface{}{
+		"method":       http.MethodGet,
+		"path":         searxngEndpointSearch,
+		"query_params": queryParams,
+		"headers": map[string]string{
+			"Accept": "application/json",
+		},
+	}
+	result, err := b.restAdapter.Execute(ctx, operationIDSearch, restParams, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response searxngResponse
+	if err := decodeResponse(result, &response); err != nil {
+		return nil, fmt.Errorf("searxng: %w", err)
+	}
+
+	// SearXNG pages have a fixed size, only the number of results asked for is kept
+	normalized := &SearchResponse{Backend: backendSearXNG, Query: query.Query, Results: []SearchResult{}}
+	for _, item := range response.Results {
+		if len(normalized.Results) >= query.Num {
+			break
+		}
+		result := SearchResult{
+			Title:   item.Title,
+			URL:     item.URL,
+			Snippet: item.Content,
+			Source:  sourceFromURL(item.URL),
+		}
+		if item.PublishedDate != nil {
+			result.PublishedDate = *item.PublishedDate
+		}
+		normalized.Results = append(normalized.Results, result)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,100 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
+)
+
+const (
+	serperBaseURL        = "https://google.serper.dev"
+	serperEndpointSearch = "search"
+	serperAPIKeyHeader   = "X-API-KEY"
+)
+
+// serperDataRanges maps data ranges to the qdr values of Google's tbs parameter
+var serperDataRanges = map[string]string{
+	"hour":  "qdr:h",
+	"day":   "qdr:d",
+	"week":  "qdr:w",
+	"month": "qdr:m",
+	"year":  "qdr:y",
+}
+
+// serperResponse is the part of a Serper search response that is normalized
+type serperResponse struct {
+	Organic []struct {
+		Title   string `json:"title"`
+		Link    string `json:"link"`
+		Snippet string `json:"snippet"`
+		Date    string `json:"date"`
+	} `json:"organic"`
+}
+
+// SerperBackend searches Google through the Serper API
+type SerperBackend struct {
+	restAdapter domain.Adapter
+	apiKey      string
+}
+
+// NewSerperBackend creates a new SerperBackend
+func NewSerperBackend(restAdapter domain.Adapter, apiKey string) *SerperBackend {
+	return &SerperBackend{
+		restAdapter: restAdapter,
+		apiKey:      apiKey,
+	}
+}
+
+// Name returns the name of the backend
+func (b *SerperBackend) Name() string {
+	return backendSerper
+}
+
+// Search runs a query against the Serper API
+func (b *SerperBackend) Search(ctx context.Context, query *SearchQuery) (*SearchResponse, error) {
+	body := map[string]interface{}{
+		"q":           query.Query,
+		"type":        "search",
+		"gl":          query.Country,
+		"hl":          query.Language,
+		"autocorrect": true,
+		"num":         query.Num,
+		"page":        query.Page,
+	}
+	if tbs, ok := serperDataRanges[query.DataRange]; ok {
+		body["tbs"] = tbs
+	}
+
+	restParams := map[string]interface{}{
+		"method": http.MethodPost,
+		"path":   serperEndpointSearch,
+		"body":   body,
+		"headers": map[string]string{
+			"Content-Type":     "application/json",
+			serperAPIKeyHeader: b.apiKey,
+		},
+	}
+	result, err := b.restAdapter.Execute(ctx, operationIDSearch, restParams, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response serperResponse
+	if err := decodeResponse(result, &response); err != nil {
+		return nil, fmt.Errorf("serper: %w", err)
+	}
+
+	normalized := &SearchResponse{Backend: backendSerper, Query: query.Query, Results: []SearchResult{}}
+	for _, item := range response.Organic {
+		normalized.Results = append(normalized.Results, SearchResult{
+			Title:         item.Title,
+			URL:           item.Link,
+			Snippet:       item.Snippet,
+			PublishedDate: item.Date,
+			Source:        sourceFromURL(item.Link),
+		})
+	}
+	return normalized, nil
+}
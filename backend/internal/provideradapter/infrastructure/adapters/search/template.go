@@ -2,8 +2,11 @@ package search
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/bytedance/sonic"
+
 	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
 	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/rest"
 	"github.com/context-space/context-space/backend/internal/shared/types"
@@ -13,11 +16,62 @@ import (
 
 const (
 	identifier = "search"
-	baseURL    = "https://google.serper.dev"
-
-	apikeyParamName = "X-API-KEY"
 )
 
+// SearchConfig is the search_config section of the provider configuration. Serper is configured by the
+// api_key of the provider, the other backends by their own section.
+type SearchConfig struct {
+	DefaultBackend string         `json:"default_backend"` // Backend of requests not asking for one
+	SearXNG        *SearXNGConfig `json:"searxng"`
+	Brave          *BraveConfig   `json:"brave"`
+}
+
+// SearXNGConfig configures the SearXNG backend
+type SearXNGConfig struct {
+	BaseURL string `json:"base_url"` // URL of the instance, e.g. https://searx.example.com
+}
+
+// BraveConfig configures the Brave Search backend
+type BraveConfig struct {
+	APIKey string `json:"api_key"`
+}
+
+// parseSearchConfig reads the api_key and search_config sections of the provider configuration
+func parseSearchConfig(provider *domain.ProviderAdapterConfig) (string, *SearchConfig, error) {
+	jsonBytes, err := sonic.Marshal(provider.CustomConfig)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal provider: %w", err)
+	}
+
+	var jsonAttributes struct {
+		APIKey       string        `json:"api_key"`
+		SearchConfig *SearchConfig `json:"search_config"`
+	}
+	if err := sonic.Unmarshal(jsonBytes, &jsonAttributes); err != nil {
+		return "", nil, fmt.Errorf("failed to unmarshal provider: %w", err)
+	}
+
+	config := jsonAttributes.SearchConfig
+	if config == nil {
+		config = &SearchConfig{}
+	}
+	return jsonAttributes.APIKey, config, nil
+}
+
+// configuredBackends returns the names of the backends that have the settings they need, in default order
+func configuredBackends(apiKey string, config *SearchConfig) []string {
+	var names []string
+	for _, name := range backendOrder {
+		switch {
+		case name == backendSerper && apiKey != "",
+			name == backendBrave && config.Brave != nil && config.Brave.APIKey != "",
+			name == backendSearXNG && config.SearXNG != nil && config.SearXNG.BaseURL != "":
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // init registers SearchTemplate when the package is imported
 func init() {
 	var _ domain.APIKeyAdapter = (*SearchAdapter)(nil)
@@ -49,17 +103,38 @@ func (t *SearchTemplate) CreateAdapter(provider *domain.ProviderAdapterConfig) (
 		RetryBackoff: 1 * time.Second,
 	}
 
-	restConfig := &rest.RESTConfig{
-		BaseURL: baseURL,
+	apiKey, searchConfig, err := parseSearchConfig(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each configured backend gets a REST adapter targeting its own API
+	names := configuredBackends(apiKey, searchConfig)
+	backends := make([]SearchBackend, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case backendSerper:
+			restAdapter := rest.NewRESTAdapter(providerAdapterInfo, adapterConfig, &rest.RESTConfig{BaseURL: serperBaseURL})
+			backends = append(backends, NewSerperBackend(restAdapter, apiKey))
+		case backendBrave:
+			restAdapter := rest.NewRESTAdapter(providerAdapterInfo, adapterConfig, &rest.RESTConfig{BaseURL: braveBaseURL})
+			backends = append(backends, NewBraveBackend(restAdapter, searchConfig.Brave.APIKey))
+		case backendSearXNG:
+			restAdapter := rest.NewRESTAdapter(providerAdapterInfo, adapterConfig, &rest.RESTConfig{BaseURL: searchConfig.SearXNG.BaseURL})
+			backends = append(backends, NewSearXNGBackend(restAdapter))
+		}
+	}
+
+	defaultBackend := searchConfig.DefaultBackend
+	if defaultBackend == "" && len(names) > 0 {
+		defaultBackend = names[0]
 	}
-	restAdapter := rest.NewRESTAdapter(providerAdapterInfo, adapterConfig, restConfig)
 
-	apiKey := provider.CustomConfig["api_key"].(string)
 	adapter := NewSearchAdapter(
 		providerAdapterInfo,
 		adapterConfig,
-		restAdapter,
-		apiKey,
+		backends,
+		defaultBackend,
 	)
 	return adapter, nil
 }
@@ -80,13 +155,18 @@ func (t *SearchTemplate) ValidateConfig(provider *domain.ProviderAdapterConfig)
 		return fmt.Errorf("invalid auth_type, must be 'none'")
 	}
 
-	apiKey, ok := provider.CustomConfig["api_key"]
-	if !ok {
-		return fmt.Errorf("api_key is required")
+	apiKey, searchConfig, err := parseSearchConfig(provider)
+	if err != nil {
+		return err
+	}
+
+	names := configuredBackends(apiKey, searchConfig)
+	if len(names) == 0 {
+		return fmt.Errorf("at least one search backend is required: api_key for serper, search_config brave api_key or searxng base_url")
 	}
 
-	if apiKey.(string) == "" {
-		return fmt.Errorf("api_key is required")
+	if searchConfig.DefaultBackend != "" && !slices.Contains(names, searchConfig.DefaultBackend) {
+		return fmt.Errorf("default_backend '%s' is not a configured search backend", searchConfig.DefaultBackend)
 	}
 
 	return nil
@@ -0,0 +1,405 @@
+package slack
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits of Block Kit on the blocks of a single message
+const (
+	maxMessageBlocks      = 50   // blocks of a message
+	maxSectionTextLength  = 3000 // characters of the text of a section
+	maxHeaderTextLength   = 150  // characters of the text of a header
+	maxSectionFields      = 10   // fields of a section
+	maxButtonTextLength   = 75   // characters of the text of a button
+	maxActionElements     = 25   // elements of an actions block
+	maxFallbackTextLength = 3000 // characters of the notification text derived from the blocks
+)
+
+// simplifiedBlockTypes are the block types of the simplified schema that have no Block Kit counterpart
+var simplifiedBlockTypes = map[string]bool{
+	"markdown": true,
+	"buttons":  true,
+}
+
+var (
+	headingPattern = regexp.MustCompile(`^#{1,6}\s+(.*?)(?:\s+#+)?\s*$`)
+	bulletPattern  = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	quotePattern   = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	dividerPattern = regexp.MustCompile(`^(?:-\s*){3,}$|^(?:\*\s*){3,}$|^(?:_\s*){3,}$`)
+	imagePattern   = regexp.MustCompile(`^!\[([^\]]*)\]\(([^)\s]+)(?:\s+"([^"]*)")?\)$`)
+	linkPattern    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	italicPattern  = regexp.MustCompile(`(^|[^*\w])\*([^*\s](?:[^*]*[^*\s])?)\*`)
+	boldPattern    = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	strikePattern  = regexp.MustCompile(`~~(.+?)~~`)
+)
+
+// mrkdwnEscaper escapes the characters Slack reserves for its own markup
+var mrkdwnEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// plainMarkers strips the inline markdown markers from text shown as plain text
+var plainMarkers = strings.NewReplacer("**", "", "__", "", "~~", "", "`", "")
+
+// slackBlock is a Block Kit block as sent to chat.postMessage
+type slackBlock = map[string]interface{}
+
+// buildBlocks builds the Block Kit blocks of a message from markdown and blocks of the simplified schema,
+// the blocks of the markdown come first. Blocks already in Block Kit form are passed through unchanged
+func buildBlocks(markdown string, blocks []interface{}) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(blocks))
+	for _, block := range markdownToBlocks(markdown) {
+		result = append(result, block)
+	}
+
+	for i, raw := range blocks {
+		block, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: block %d is not an object", errInvalidParameters, i)
+		}
+		converted, err := convertBlock(block)
+		if err != nil {
+			return nil, fmt.Errorf("%w: block %d: %v", errInvalidParameters, i, err)
+		}
+		result = append(result, converted...)
+	}
+
+	if len(result) > maxMessageBlocks {
+		return nil, fmt.Errorf("%w: the message has %d blocks, Slack accepts at most %d", errInvalidParameters, len(result), maxMessageBlocks)
+	}
+	return result, nil
+}
+
+// convertBlock converts a block of the simplified schema to Block Kit, a markdown block may become several blocks
+func convertBlock(block map[string]interface{}) ([]interface{}, error) {
+	blockType, _ := block["type"].(string)
+	if blockType == "" {
+		return nil, fmt.Errorf("missing type")
+	}
+	text, textIsString := block["text"].(string)
+	if _, textIsObject := block["text"].(map[string]interface{}); textIsObject && !simplifiedBlockTypes[blockType] {
+		return []interface{}{block}, nil
+	}
+
+	switch blockType {
+	case "markdown":
+		if !textIsString {
+			return nil, fmt.Errorf("markdown blocks need a text")
+		}
+		converted := make([]interface{}, 0)
+		for _, block := range markdownToBlocks(text) {
+			converted = append(converted, block)
+		}
+		return converted, nil
+	case "header":
+		if !textIsString || strings.TrimSpace(text) == "" {
+			return nil, fmt.Errorf("header blocks need a text")
+		}
+		return []interface{}{headerBlock(text)}, nil
+	case "section":
+		return sectionWithFields(block, text)
+	case "context":
+		if _, native := block["elements"]; native {
+			return []interface{}{block}, nil
+		}
+		if !textIsString || strings.TrimSpace(text) == "" {
+			return nil, fmt.Errorf("context blocks need a text")
+		}
+		return []interface{}{slackBlock{
+			"type":     "context",
+			"elements": []interface{}{mrkdwnText(mrkdwn(text))},
+		}}, nil
+	case "image":
+		imageURL, _ := block["image_url"].(string)
+		if imageURL == "" {
+			return nil, fmt.Errorf("image blocks need an image_url")
+		}
+		altText, _ := block["alt_text"].(string)
+		title, _ := block["title"].(string)
+		return []interface{}{imageBlock(imageURL, altText, title)}, nil
+	case "buttons":
+		return buttonsBlock(block["buttons"])
+	default:
+		return []interface{}{block}, nil
+	}
+}
+
+// sectionWithFields builds a section of the simplified schema, a section with fields of objects is Block Kit already
+func sectionWithFields(block map[string]interface{}, text string) ([]interface{}, error) {
+	fields, _ := block["fields"].([]interface{})
+	if len(fields) > maxSectionFields {
+		return nil, fmt.Errorf("sections have at most %d fields", maxSectionFields)
+	}
+
+	fieldTexts := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		fieldText, ok := field.(string)
+		if !ok {
+			return []interface{}{block}, nil
+		}
+		fieldTexts = append(fieldTexts, mrkdwnText(mrkdwn(fieldText)))
+	}
+
+	if strings.TrimSpace(text) == "" && len(fieldTexts) == 0 {
+		return nil, fmt.Errorf("section blocks need a text or fields")
+	}
+	if len(fieldTexts) == 0 {
+		converted := make([]interface{}, 0)
+		for _, block := range sectionBlocks(mrkdwn(text)) {
+			converted = append(converted, block)
+		}
+		return converted, nil
+	}
+
+	section := slackBlock{"type": "section", "fields": fieldTexts}
+	if strings.TrimSpace(text) != "" {
+		section["text"] = mrkdwnText(truncateRunes(mrkdwn(text), maxSectionTextLength))
+	}
+	return []interface{}{section}, nil
+}
+
+// buttonsBlock builds an actions block of link or value buttons
+func buttonsBlock(rawButtons interface{}) ([]interface{}, error) {
+	buttons, _ := rawButtons.([]interface{})
+	if len(buttons) == 0 {
+		return nil, fmt.Errorf("buttons blocks need buttons")
+	}
+	if len(buttons) > maxActionElements {
+		return nil, fmt.Errorf("buttons blocks have at most %d buttons", maxActionElements)
+	}
+
+	elements := make([]interface{}, 0, len(buttons))
+	for i, raw := range buttons {
+		button, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("button %d is not an object", i)
+		}
+		text, _ := button["text"].(string)
+		if text == "" {
+			return nil, fmt.Errorf("button %d needs a text", i)
+		}
+
+		element := slackBlock{
+			"type":      "button",
+			"text":      plainText(truncateRunes(text, maxButtonTextLength)),
+			"action_id": fmt.Sprintf("button_%d", i),
+		}
+		for _, key := range []string{"url", "value", "action_id"} {
+			if value, _ := button[key].(string); value != "" {
+				element[key] = value
+			}
+		}
+		if style, _ := button["style"].(string); style == "primary" || style == "danger" {
+			element["style"] = style
+		}
+		elements = append(elements, element)
+	}
+	return []interface{}{slackBlock{"type": "actions", "elements": elements}}, nil
+}
+
+// markdownToBlocks converts markdown to Block Kit: headings become headers, rules dividers, images on their own line
+// image blocks, fenced code and paragraphs sections of mrkdwn
+func markdownToBlocks(markdown string) []slackBlock {
+	blocks := make([]slackBlock, 0)
+	paragraph := make([]string, 0)
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, sectionBlocks(strings.Join(paragraph, "\n"))...)
+			paragraph = paragraph[:0]
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+			code := make([]string, 0)
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, mrkdwnEscaper.Replace(lines[i]))
+			}
+			blocks = append(blocks, codeBlocks(code)...)
+		case trimmed == "":
+			flush()
+		case dividerPattern.MatchString(trimmed):
+			flush()
+			blocks = append(blocks, slackBlock{"type": "divider"})
+		case headingPattern.MatchString(trimmed):
+			flush()
+			blocks = append(blocks, headerBlock(headingPattern.FindStringSubmatch(trimmed)[1]))
+		case imagePattern.MatchString(trimmed):
+			flush()
+			match := imagePattern.FindStringSubmatch(trimmed)
+			blocks = append(blocks, imageBlock(match[2], match[1], match[3]))
+		default:
+			paragraph = append(paragraph, mrkdwnLine(lines[i]))
+		}
+	}
+	flush()
+	return blocks
+}
+
+// mrkdwn converts markdown text of a single block to Slack mrkdwn line by line
+func mrkdwn(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = mrkdwnLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// mrkdwnLine converts a markdown line to mrkdwn, list bullets become • and quotes keep their marker
+func mrkdwnLine(line string) string {
+	if match := quotePattern.FindStringSubmatch(line); match != nil {
+		return "> " + mrkdwnInline(match[1])
+	}
+	if match := bulletPattern.FindStringSubmatch(line); match != nil {
+		return match[1] + "• " + mrkdwnInline(match[2])
+	}
+	return mrkdwnInline(line)
+}
+
+// mrkdwnInline converts the inline markdown of text to mrkdwn, leaving code spans as they are
+func mrkdwnInline(text string) string {
+	parts := strings.Split(text, "`")
+	var builder strings.Builder
+	for i, part := range parts {
+		if i > 0 {
+			builder.WriteByte('`')
+		}
+		// An unmatched backtick leaves the rest of the line as text
+		if i%2 == 1 && i < len(parts)-1 {
+			builder.WriteString(mrkdwnEscaper.Replace(part))
+			continue
+		}
+		builder.WriteString(formatInline(mrkdwnEscaper.Replace(part)))
+	}
+	return builder.String()
+}
+
+// formatInline rewrites links and emphasis, single asterisks are italic in markdown but bold in mrkdwn
+func formatInline(text string) string {
+	text = linkPattern.ReplaceAllString(text, "<$2|$1>")
+	text = italicPattern.ReplaceAllString(text, "${1}_${2}_")
+	text = boldPattern.ReplaceAllString(text, "*${1}${2}*")
+	return strikePattern.ReplaceAllString(text, "~$1~")
+}
+
+// sectionBlocks splits mrkdwn text into sections within the text length of Block Kit, between lines where possible
+func sectionBlocks(text string) []slackBlock {
+	blocks := make([]slackBlock, 0, 1)
+	for _, chunk := range splitLines(text, maxSectionTextLength) {
+		blocks = append(blocks, slackBlock{"type": "section", "text": mrkdwnText(chunk)})
+	}
+	return blocks
+}
+
+// codeBlocks wraps code lines in fenced mrkdwn sections within the text length of Block Kit
+func codeBlocks(code []string) []slackBlock {
+	const fences = len("```\n\n```")
+	blocks := make([]slackBlock, 0, 1)
+	for _, chunk := range splitLines(strings.Join(code, "\n"), maxSectionTextLength-fences) {
+		blocks = append(blocks, slackBlock{"type": "section", "text": mrkdwnText("```\n" + chunk + "\n```")})
+	}
+	return blocks
+}
+
+// splitLines splits text into chunks of at most limit characters, cutting lines longer than limit
+func splitLines(text string, limit int) []string {
+	chunks := make([]string, 0, 1)
+	var current strings.Builder
+	currentLength := 0
+	for _, line := range strings.Split(text, "\n") {
+		for utf8.RuneCountInString(line) > limit {
+			head := truncateRunes(line, limit)
+			if currentLength > 0 {
+				chunks = append(chunks, current.String())
+				current.Reset()
+				currentLength = 0
+			}
+			chunks = append(chunks, head)
+			line = line[len(head):]
+		}
+
+		lineLength := utf8.RuneCountInString(line)
+		if currentLength > 0 && currentLength+1+lineLength > limit {
+			chunks = append(chunks, current.String())
+			current.Reset()
+			currentLength = 0
+		}
+		if currentLength > 0 {
+			current.WriteByte('\n')
+			currentLength++
+		}
+		current.WriteString(line)
+		currentLength += lineLength
+	}
+	if currentLength > 0 || len(chunks) == 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
+
+// headerBlock builds a header, whose plain text drops the inline markdown of the heading
+func headerBlock(text string) slackBlock {
+	text = plainMarkers.Replace(linkPattern.ReplaceAllString(text, "$1"))
+	return slackBlock{"type": "header", "text": plainText(truncateRunes(text, maxHeaderTextLength))}
+}
+
+// imageBlock builds an image, Slack requires an alt text
+func imageBlock(imageURL, altText, title string) slackBlock {
+	if altText == "" {
+		altText = "image"
+	}
+	block := slackBlock{"type": "image", "image_url": imageURL, "alt_text": altText}
+	if title != "" {
+		block["title"] = plainText(title)
+	}
+	return block
+}
+
+// mrkdwnText builds a text object of mrkdwn
+func mrkdwnText(text string) map[string]interface{} {
+	return map[string]interface{}{"type": "mrkdwn", "text": text}
+}
+
+// plainText builds a text object of plain text
+func plainText(text string) map[string]interface{} {
+	return map[string]interface{}{"type": "plain_text", "text": text, "emoji": true}
+}
+
+// fallbackText derives the notification text of a message from the text of its header, section and context blocks
+func fallbackText(blocks []interface{}) string {
+	texts := make([]string, 0, len(blocks))
+	for _, raw := range blocks {
+		block, _ := raw.(map[string]interface{})
+		switch block["type"] {
+		case "header", "section":
+			if text, ok := block["text"].(map[string]interface{}); ok {
+				if value, _ := text["text"].(string); value != "" {
+					texts = append(texts, value)
+				}
+			}
+		case "context":
+			elements, _ := block["elements"].([]interface{})
+			for _, rawElement := range elements {
+				element, _ := rawElement.(map[string]interface{})
+				if value, _ := element["text"].(string); value != "" {
+					texts = append(texts, value)
+				}
+			}
+		}
+	}
+	return truncateRunes(strings.Join(texts, "\n"), maxFallbackTextLength)
+}
+
+// truncateRunes returns the first limit characters of text
+func truncateRunes(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:limit])
+}
@@ -0,0 +1,216 @@
+package slack
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMarkdownToBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected []slackBlock
+	}{
+		{
+			name:     "HeadingDropsInlineMarkdown",
+			markdown: "## Release **v2** of [the app](https://example.com) ##",
+			expected: []slackBlock{headerBlock("Release v2 of the app")},
+		},
+		{
+			name:     "InlineFormatting",
+			markdown: "Some **bold**, *italic*, ~~struck~~, `a **b**` and [a link](https://example.com)",
+			expected: []slackBlock{{"type": "section", "text": mrkdwnText("Some *bold*, _italic_, ~struck~, `a **b**` and <https://example.com|a link>")}},
+		},
+		{
+			name:     "ReservedCharactersAreEscaped",
+			markdown: "a < b && c > d",
+			expected: []slackBlock{{"type": "section", "text": mrkdwnText("a &lt; b &amp;&amp; c &gt; d")}},
+		},
+		{
+			name:     "ListsAndQuotes",
+			markdown: "- one\n  * two\n> quoted *text*",
+			expected: []slackBlock{{"type": "section", "text": mrkdwnText("• one\n  • two\n> quoted _text_")}},
+		},
+		{
+			name:     "ParagraphsDividersAndImages",
+			markdown: "First\n\n---\n\n![Chart](https://example.com/chart.png \"Weekly\")\nLast",
+			expected: []slackBlock{
+				{"type": "section", "text": mrkdwnText("First")},
+				{"type": "divider"},
+				imageBlock("https://example.com/chart.png", "Chart", "Weekly"),
+				{"type": "section", "text": mrkdwnText("Last")},
+			},
+		},
+		{
+			name:     "CodeIsFencedAndNotFormatted",
+			markdown: "```go\nif a < b && **c** {\n```",
+			expected: []slackBlock{{"type": "section", "text": mrkdwnText("```\nif a &lt; b &amp;&amp; **c** {\n```")}},
+		},
+		{
+			name:     "Empty",
+			markdown: "",
+			expected: []slackBlock{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks := markdownToBlocks(tt.markdown)
+			if !reflect.DeepEqual(blocks, tt.expected) {
+				t.Errorf("blocks = %v, want %v", blocks, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMarkdownToBlocksSplitsLongText(t *testing.T) {
+	line := strings.Repeat("é", 1000)
+	paragraph := strings.Join([]string{line, line, line, line}, "\n")
+
+	tests := []struct {
+		name           string
+		markdown       string
+		expectedLength []int
+	}{
+		// Three lines and their separators exceed the limit, the section is split between lines
+		{name: "SplitsBetweenLines", markdown: paragraph, expectedLength: []int{2001, 2001}},
+		{name: "CutsLongLines", markdown: strings.Repeat("a", maxSectionTextLength+1), expectedLength: []int{maxSectionTextLength, 1}},
+		{name: "CodeKeepsRoomForFences", markdown: "```\n" + strings.Repeat("a", maxSectionTextLength) + "\n```", expectedLength: []int{maxSectionTextLength, 16}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks := markdownToBlocks(tt.markdown)
+			if len(blocks) != len(tt.expectedLength) {
+				t.Fatalf("got %d blocks, want %d", len(blocks), len(tt.expectedLength))
+			}
+			for i, block := range blocks {
+				text := block["text"].(map[string]interface{})["text"].(string)
+				if length := utf8.RuneCountInString(text); length != tt.expectedLength[i] {
+					t.Errorf("block %d has %d characters, want %d", i, length, tt.expectedLength[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuildBlocks(t *testing.T) {
+	nativeSection := map[string]interface{}{"type": "section", "text": map[string]interface{}{"type": "mrkdwn", "text": "*native*"}}
+
+	tests := []struct {
+		name     string
+		markdown string
+		blocks   []interface{}
+		expected []interface{}
+	}{
+		{
+			name:     "MarkdownComesFirst",
+			markdown: "# Title",
+			blocks:   []interface{}{map[string]interface{}{"type": "divider"}},
+			expected: []interface{}{headerBlock("Title"), map[string]interface{}{"type": "divider"}},
+		},
+		{
+			name:     "NativeBlocksPassThrough",
+			blocks:   []interface{}{nativeSection},
+			expected: []interface{}{nativeSection},
+		},
+		{
+			name: "SimplifiedBlocks",
+			blocks: []interface{}{
+				map[string]interface{}{"type": "header", "text": "**Deploy**"},
+				map[string]interface{}{"type": "section", "text": "Ready *now*", "fields": []interface{}{"**Env**", "prod"}},
+				map[string]interface{}{"type": "context", "text": "by [ci](https://ci.example.com)"},
+				map[string]interface{}{"type": "image", "image_url": "https://example.com/a.png"},
+				map[string]interface{}{"type": "markdown", "text": "one\n\ntwo"},
+			},
+			expected: []interface{}{
+				headerBlock("Deploy"),
+				slackBlock{"type": "section", "text": mrkdwnText("Ready _now_"), "fields": []interface{}{mrkdwnText("*Env*"), mrkdwnText("prod")}},
+				slackBlock{"type": "context", "elements": []interface{}{mrkdwnText("by <https://ci.example.com|ci>")}},
+				imageBlock("https://example.com/a.png", "image", ""),
+				slackBlock{"type": "section", "text": mrkdwnText("one")},
+				slackBlock{"type": "section", "text": mrkdwnText("two")},
+			},
+		},
+		{
+			name: "Buttons",
+			blocks: []interface{}{map[string]interface{}{"type": "buttons", "buttons": []interface{}{
+				map[string]interface{}{"text": "Open", "url": "https://example.com", "style": "primary"},
+				map[string]interface{}{"text": strings.Repeat("x", maxButtonTextLength+5), "value": "v", "action_id": "custom", "style": "loud"},
+			}}},
+			expected: []interface{}{slackBlock{"type": "actions", "elements": []interface{}{
+				slackBlock{"type": "button", "text": plainText("Open"), "action_id": "button_0", "url": "https://example.com", "style": "primary"},
+				slackBlock{"type": "button", "text": plainText(strings.Repeat("x", maxButtonTextLength)), "action_id": "custom", "value": "v"},
+			}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks, err := buildBlocks(tt.markdown, tt.blocks)
+			if err != nil {
+				t.Fatalf("buildBlocks() error = %v", err)
+			}
+			if !reflect.DeepEqual(blocks, tt.expected) {
+				t.Errorf("blocks = %v, want %v", blocks, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildBlocksRejectsInvalidBlocks(t *testing.T) {
+	manyFields := make([]interface{}, maxSectionFields+1)
+	for i := range manyFields {
+		manyFields[i] = "field"
+	}
+	manyButtons := make([]interface{}, maxActionElements+1)
+	for i := range manyButtons {
+		manyButtons[i] = map[string]interface{}{"text": "button"}
+	}
+
+	tests := []struct {
+		name     string
+		markdown string
+		blocks   []interface{}
+	}{
+		{name: "NotAnObject", blocks: []interface{}{"divider"}},
+		{name: "MissingType", blocks: []interface{}{map[string]interface{}{"text": "x"}}},
+		{name: "EmptyHeader", blocks: []interface{}{map[string]interface{}{"type": "header", "text": " "}}},
+		{name: "EmptySection", blocks: []interface{}{map[string]interface{}{"type": "section"}}},
+		{name: "TooManyFields", blocks: []interface{}{map[string]interface{}{"type": "section", "fields": manyFields}}},
+		{name: "ImageWithoutURL", blocks: []interface{}{map[string]interface{}{"type": "image"}}},
+		{name: "ButtonWithoutText", blocks: []interface{}{map[string]interface{}{"type": "buttons", "buttons": []interface{}{map[string]interface{}{"url": "https://example.com"}}}}},
+		{name: "TooManyButtons", blocks: []interface{}{map[string]interface{}{"type": "buttons", "buttons": manyButtons}}},
+		{name: "TooManyBlocks", markdown: strings.Repeat("---\n", maxMessageBlocks+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildBlocks(tt.markdown, tt.blocks)
+			if !errors.Is(err, errInvalidParameters) {
+				t.Errorf("buildBlocks() error = %v, want %v", err, errInvalidParameters)
+			}
+		})
+	}
+}
+
+func TestFallbackText(t *testing.T) {
+	blocks := []interface{}{
+		headerBlock("Title"),
+		slackBlock{"type": "divider"},
+		slackBlock{"type": "section", "text": mrkdwnText("Body")},
+		slackBlock{"type": "context", "elements": []interface{}{mrkdwnText("Footer"), map[string]interface{}{"type": "image"}}},
+	}
+
+	if text := fallbackText(blocks); text != "Title\nBody\nFooter" {
+		t.Errorf("fallbackText() = %q, want %q", text, "Title\nBody\nFooter")
+	}
+
+	long := []interface{}{slackBlock{"type": "section", "text": mrkdwnText(strings.Repeat("é", maxFallbackTextLength+1))}}
+	if length := utf8.RuneCountInString(fallbackText(long)); length != maxFallbackTextLength {
+		t.Errorf("fallback text has %d characters, want %d", length, maxFallbackTextLength)
+	}
+}
@@ -0,0 +1,219 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bytedance/sonic"
+
+	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/vcr"
+	"github.com/context-space/context-space/backend/internal/shared/utils"
+)
+
+const (
+	// maxUploadFileBytes caps the content of uploaded files, which is passed inline in the parameters
+	maxUploadFileBytes = 10 * 1024 * 1024
+	// maxDownloadFileBytes caps the files download_file returns inline
+	maxDownloadFileBytes = 5 * 1024 * 1024
+	// fileTransferTimeout bounds uploads to and downloads from the file storage of Slack
+	fileTransferTimeout = 2 * time.Minute
+	// slackFilesHost is the domain of the private file URLs of Slack, the only host the access token is sent to
+	slackFilesHost = "slack.com"
+)
+
+// fileTransfer moves file content to and from the file storage of Slack, outside the Web API
+type fileTransfer struct {
+	client      *http.Client
+	accessToken string
+}
+
+// newFileTransfer creates a file transfer authenticating downloads with the access token
+func newFileTransfer(accessToken string) *fileTransfer {
+	return &fileTransfer{
+		client:      vcr.NewHTTPClient(fileTransferTimeout),
+		accessToken: accessToken,
+	}
+}
+
+// upload sends content to the upload URL handed out by files.getUploadURLExternal
+func (t *fileTransfer) upload(ctx context.Context, uploadURL string, content []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("failed to upload file: %s", resp.Status)
+	}
+	return nil
+}
+
+// download fetches a private file URL of Slack, which requires the access token
+func (t *fileTransfer) download(ctx context.Context, fileURL string) ([]byte, error) {
+	parsed, err := url.Parse(fileURL)
+	if err != nil || parsed.Scheme != "https" || (parsed.Hostname() != slackFilesHost && !strings.HasSuffix(parsed.Hostname(), "."+slackFilesHost)) {
+		return nil, fmt.Errorf("refusing to download file from %q, which is not a Slack file URL", fileURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create download request: %w", err)
+	}
+	req.Header.Set("Authorization", utils.StringsBuilder("Bearer ", t.accessToken))
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("failed to download file: %s", resp.Status)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadFileBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if len(content) > maxDownloadFileBytes {
+		return nil, fmt.Errorf("%w: the file is larger than the %d bytes download_file returns", errInvalidParameters, maxDownloadFileBytes)
+	}
+	return content, nil
+}
+
+// runUploadFile uploads a file through the external upload flow of Slack: files.getUploadURLExternal hands out an
+// upload URL, the content is sent there and files.completeUploadExternal shares the file
+func runUploadFile(ctx context.Context, params interface{}, call RESTCaller, files *fileTransfer) (interface{}, error) {
+	p, ok := params.(*UploadFileParams)
+	if !ok || p == nil {
+		return nil, fmt.Errorf("invalid or missing parameters for upload_file")
+	}
+
+	content := []byte(p.Content)
+	if p.ContentBase64 != "" {
+		decoded, err := base64.StdEncoding.DecodeString(p.ContentBase64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: content_base64 is not valid base64: %v", errInvalidParameters, err)
+		}
+		content = decoded
+	}
+	if len(content) == 0 {
+		return nil, fmt.Errorf("%w: the file is empty", errInvalidParameters)
+	}
+	if len(content) > maxUploadFileBytes {
+		return nil, fmt.Errorf("%w: the file is larger than the %d bytes upload_file accepts", errInvalidParameters, maxUploadFileBytes)
+	}
+
+	uploadForm := url.Values{
+		"filename": {p.Filename},
+		"length":   {strconv.Itoa(len(content))},
+	}
+	upload, err := call(ctx, map[string]interface{}{
+		"method": http.MethodPost,
+		"path":   endpointGetUploadURLExternal,
+		"body":   uploadForm.Encode(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	uploadURL, _ := upload["upload_url"].(string)
+	fileID, _ := upload["file_id"].(string)
+	if uploadURL == "" || fileID == "" {
+		return nil, fmt.Errorf("files.getUploadURLExternal returned no upload URL")
+	}
+
+	if err := files.upload(ctx, uploadURL, content); err != nil {
+		return nil, err
+	}
+
+	title := p.Title
+	if title == "" {
+		title = p.Filename
+	}
+	filesJSON, err := sonic.MarshalString([]map[string]string{{"id": fileID, "title": title}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal uploaded files: %w", err)
+	}
+	completeForm := url.Values{"files": {filesJSON}}
+	if p.Channel != "" {
+		completeForm.Set("channel_id", p.Channel)
+	}
+	if p.InitialComment != "" {
+		completeForm.Set("initial_comment", p.InitialComment)
+	}
+	if p.ThreadTs != "" {
+		completeForm.Set("thread_ts", p.ThreadTs)
+	}
+	return call(ctx, map[string]interface{}{
+		"method": http.MethodPost,
+		"path":   endpointCompleteUploadExternal,
+		"body":   completeForm.Encode(),
+	})
+}
+
+// runDownloadFile looks up a file with files.info and downloads its content, text is returned as is and
+// binary content base64 encoded
+func runDownloadFile(ctx context.Context, params interface{}, call RESTCaller, files *fileTransfer) (interface{}, error) {
+	p, ok := params.(*DownloadFileParams)
+	if !ok || p == nil {
+		return nil, fmt.Errorf("invalid or missing parameters for download_file")
+	}
+
+	info, err := call(ctx, map[string]interface{}{
+		"method":       http.MethodGet,
+		"path":         endpointFileInfo,
+		"query_params": map[string]string{"file": p.File},
+	})
+	if err != nil {
+		return nil, err
+	}
+	file, _ := info["file"].(map[string]interface{})
+	if file == nil {
+		return nil, fmt.Errorf("files.info returned no file")
+	}
+
+	if size, ok := file["size"].(float64); ok && size > maxDownloadFileBytes {
+		return nil, fmt.Errorf("%w: the file has %.0f bytes, download_file returns at most %d", errInvalidParameters, size, maxDownloadFileBytes)
+	}
+	downloadURL, _ := file["url_private_download"].(string)
+	if downloadURL == "" {
+		downloadURL, _ = file["url_private"].(string)
+	}
+	if downloadURL == "" {
+		return nil, fmt.Errorf("%w: the file has no downloadable content", errInvalidParameters)
+	}
+
+	content, err := files.download(ctx, downloadURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{"size": len(content)}
+	for _, key := range []string{"id", "name", "title", "mimetype", "filetype", "permalink"} {
+		if value, ok := file[key]; ok {
+			result[key] = value
+		}
+	}
+	if utf8.Valid(content) && !bytes.ContainsRune(content, 0) {
+		result["content"] = string(content)
+	} else {
+		result["content_base64"] = base64.StdEncoding.EncodeToString(content)
+	}
+	return result, nil
+}
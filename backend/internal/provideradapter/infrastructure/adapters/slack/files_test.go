@@ -0,0 +1,154 @@
+package slack
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// recordingTransport answers every request with a fixed body and records the requests it was sent
+type recordingTransport struct {
+	requests []*http.Request
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Body:       io.NopCloser(strings.NewReader("file content")),
+		Header:     http.Header{},
+		Request:    req,
+	}, nil
+}
+
+func newTestFileTransfer() (*fileTransfer, *recordingTransport) {
+	transport := &recordingTransport{}
+	return &fileTransfer{client: &http.Client{Transport: transport}, accessToken: "xoxb-secret"}, transport
+}
+
+func TestFileTransferDownloadHosts(t *testing.T) {
+	tests := []struct {
+		name    string
+		fileURL string
+		allowed bool
+	}{
+		{name: "FilesHost", fileURL: "https://files.slack.com/files-pri/T1-F1/report.txt", allowed: true},
+		{name: "SlackHost", fileURL: "https://slack.com/files-pri/T1-F1/report.txt", allowed: true},
+		{name: "PlainHTTP", fileURL: "http://files.slack.com/files-pri/T1-F1/report.txt"},
+		{name: "OtherHost", fileURL: "https://attacker.example/files-pri/T1-F1/report.txt"},
+		{name: "SlackAsSubdomainOfOtherHost", fileURL: "https://files.slack.com.attacker.example/report.txt"},
+		{name: "HostEndingInSlack", fileURL: "https://notslack.com/report.txt"},
+		{name: "CredentialsInURL", fileURL: "https://files.slack.com@attacker.example/report.txt"},
+		{name: "NotAURL", fileURL: "://files.slack.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, transport := newTestFileTransfer()
+
+			content, err := files.download(context.Background(), tt.fileURL)
+
+			if !tt.allowed {
+				if err == nil {
+					t.Fatalf("download(%q) succeeded, want the host refused", tt.fileURL)
+				}
+				if len(transport.requests) != 0 {
+					t.Errorf("sent %d requests, want none so the access token does not leave Slack", len(transport.requests))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("download(%q) error = %v", tt.fileURL, err)
+			}
+			if string(content) != "file content" {
+				t.Errorf("content = %q, want %q", content, "file content")
+			}
+			if len(transport.requests) != 1 || transport.requests[0].Header.Get("Authorization") != "Bearer xoxb-secret" {
+				t.Errorf("requests = %v, want one request authenticated with the access token", transport.requests)
+			}
+		})
+	}
+}
+
+func TestRunDownloadFileRefusesForeignURLPrivate(t *testing.T) {
+	files, transport := newTestFileTransfer()
+	call := func(ctx context.Context, restParams map[string]interface{}) (map[string]interface{}, error) {
+		return map[string]interface{}{"file": map[string]interface{}{
+			"id":          "F1",
+			"name":        "report.txt",
+			"size":        float64(12),
+			"url_private": "https://files.attacker.example/report.txt",
+		}}, nil
+	}
+
+	_, err := runDownloadFile(context.Background(), &DownloadFileParams{File: "F1"}, call, files)
+
+	if err == nil || !strings.Contains(err.Error(), "not a Slack file URL") {
+		t.Errorf("runDownloadFile() error = %v, want the file URL refused", err)
+	}
+	if len(transport.requests) != 0 {
+		t.Errorf("sent %d requests, want none", len(transport.requests))
+	}
+}
+
+func TestRunDownloadFileContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "PrefersDownloadURL",
+			file:     map[string]interface{}{"id": "F1", "url_private_download": "https://files.slack.com/files-pri/T1-F1/download/report.txt", "url_private": "https://attacker.example/report.txt"},
+			expected: "https://files.slack.com/files-pri/T1-F1/download/report.txt",
+		},
+		{
+			name:     "FallsBackToPrivateURL",
+			file:     map[string]interface{}{"id": "F1", "url_private": "https://files.slack.com/files-pri/T1-F1/report.txt"},
+			expected: "https://files.slack.com/files-pri/T1-F1/report.txt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, transport := newTestFileTransfer()
+			call := func(ctx context.Context, restParams map[string]interface{}) (map[string]interface{}, error) {
+				return map[string]interface{}{"file": tt.file}, nil
+			}
+
+			result, err := runDownloadFile(context.Background(), &DownloadFileParams{File: "F1"}, call, files)
+			if err != nil {
+				t.Fatalf("runDownloadFile() error = %v", err)
+			}
+
+			if len(transport.requests) != 1 || transport.requests[0].URL.String() != tt.expected {
+				t.Errorf("requests = %v, want one to %s", transport.requests, tt.expected)
+			}
+			content := result.(map[string]interface{})
+			if content["content"] != "file content" || content["id"] != "F1" {
+				t.Errorf("result = %v, want the text content of F1", content)
+			}
+		})
+	}
+}
+
+func TestRunDownloadFileRefusesLargeFiles(t *testing.T) {
+	files, transport := newTestFileTransfer()
+	call := func(ctx context.Context, restParams map[string]interface{}) (map[string]interface{}, error) {
+		return map[string]interface{}{"file": map[string]interface{}{
+			"id":          "F1",
+			"size":        float64(maxDownloadFileBytes + 1),
+			"url_private": "https://files.slack.com/files-pri/T1-F1/large.bin",
+		}}, nil
+	}
+
+	if _, err := runDownloadFile(context.Background(), &DownloadFileParams{File: "F1"}, call, files); err == nil {
+		t.Error("runDownloadFile() succeeded, want the file refused for its size")
+	}
+	if len(transport.requests) != 0 {
+		t.Errorf("sent %d requests, want none", len(transport.requests))
+	}
+}
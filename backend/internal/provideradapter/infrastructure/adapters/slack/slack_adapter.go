@@ -2,10 +2,12 @@ package slack
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/bytedance/sonic"
 	"golang.org/x/oauth2"
 
 	credDomain "github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
@@ -62,21 +64,21 @@ func NewSlackAdapter(
 	return adapter
 }
 
-// createOAuth2Config creates the oauth2.Config object.
-func (a *SlackAdapter) createOAuth2Config(redirectURL string, scopes []string) *oauth2.Config {
+// createOAuth2Config creates the oauth2.Config object, using the OAuth app override of the context if any.
+func (a *SlackAdapter) createOAuth2Config(ctx context.Context, redirectURL string, scopes []string) *oauth2.Config {
 	endpoint := oauth2.Endpoint{
 		AuthURL:   authURL,
 		TokenURL:  tokenURL,
 		AuthStyle: oauth2.AuthStyleInParams,
 	}
 
-	return &oauth2.Config{
+	return base.ApplyOAuthAppOverride(ctx, &oauth2.Config{
 		ClientID:     a.oauthConfig.ClientID,
 		ClientSecret: a.oauthConfig.ClientSecret,
 		RedirectURL:  redirectURL,
 		Scopes:       scopes,
 		Endpoint:     endpoint,
-	}
+	})
 }
 
 // ShouldRefreshToken checks if the token should be refreshed
@@ -89,7 +91,7 @@ func (a *SlackAdapter) ShouldRefreshToken(oldToken *oauth2.Token) bool {
 
 // RefreshOAuthToken refreshes an OAuth token.
 func (a *SlackAdapter) RefreshOAuthToken(ctx context.Context, oldToken *oauth2.Token) (*oauth2.Token, error) {
-	oauth2Config := a.createOAuth2Config("", nil) // Scopes might not be needed for refresh
+	oauth2Config := a.createOAuth2Config(ctx, "", nil) // Scopes might not be needed for refresh
 	tokenSource := oauth2Config.TokenSource(ctx, oldToken)
 	newToken, err := tokenSource.Token()
 	if err != nil {
@@ -104,18 +106,97 @@ func (a *SlackAdapter) RefreshOAuthToken(ctx context.Context, oldToken *oauth2.T
 	return newToken, nil
 }
 
+// RevokeOAuthToken revokes the token through auth.revoke, tokens Slack no longer accepts count as revoked
+func (a *SlackAdapter) RevokeOAuthToken(ctx context.Context, token *oauth2.Token) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, revokeURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create revocation request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	body, err := base.DoTokenRevocation(a.GetProviderAdapterInfo().Identifier, req)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := sonic.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to decode revocation response: %w", err)
+	}
+	if !result.OK && result.Error != "invalid_auth" && result.Error != "token_revoked" && result.Error != "account_inactive" {
+		return domain.NewAdapterError(
+			a.GetProviderAdapterInfo().Identifier,
+			"oauth_revoke",
+			domain.ErrCredentialError,
+			fmt.Sprintf("failed to revoke token: %s", result.Error),
+			http.StatusBadGateway,
+		)
+	}
+	return nil
+}
+
+// VerifyCredential checks the token through auth.test, Slack reports rejected tokens in the body with status 200
+func (a *SlackAdapter) VerifyCredential(ctx context.Context, credential interface{}) error {
+	oauthCred, ok := credential.(*credDomain.OAuthCredential)
+	if !ok || oauthCred == nil || oauthCred.Token == nil {
+		return fmt.Errorf("invalid or missing OAuth credential")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, base.APIBaseURL(ctx, baseURL)+verifyPath, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create verification request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+oauthCred.Token.AccessToken)
+
+	body, err := base.DoCredentialVerification(a.GetProviderAdapterInfo().Identifier, req)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := sonic.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to decode verification response: %w", err)
+	}
+	if result.OK {
+		return nil
+	}
+
+	return domain.NewAdapterError(
+		a.GetProviderAdapterInfo().Identifier,
+		"verify_credential",
+		slackErrorCode(result.Error),
+		fmt.Sprintf("auth.test failed: %s", result.Error),
+		http.StatusUnauthorized,
+	)
+}
+
+// slackErrorCode maps the error of a Web API response to an adapter error code
+func slackErrorCode(slackError string) string {
+	switch slackError {
+	case "invalid_auth", "not_authed", "token_revoked", "token_expired", "account_inactive":
+		return domain.ErrCredentialError
+	}
+	return domain.ErrProviderAPIError
+}
+
 // GenerateOAuthURL generates an OAuth authorization URL.
 func (a *SlackAdapter) GenerateOAuthURL(ctx context.Context,
 	redirectURL, state, codeChallenge string,
 	scopes []string,
 ) (string, error) {
-	oauth2Config := a.createOAuth2Config(redirectURL, scopes)
+	oauth2Config := a.createOAuth2Config(ctx, redirectURL, scopes)
 	return oauth2Config.AuthCodeURL(state /*, oauth2.AccessTypeOffline*/), nil
 }
 
 // ExchangeCodeForTokens exchanges an authorization code for tokens.
 func (a *SlackAdapter) ExchangeCodeForTokens(ctx context.Context, code string, redirectURL string, codeVerifier string) (*oauth2.Token, error) {
-	oauth2Config := a.createOAuth2Config(redirectURL, nil) // Scopes not needed for exchange usually
+	oauth2Config := a.createOAuth2Config(ctx, redirectURL, nil) // Scopes not needed for exchange usually
 	token, err := oauth2Config.Exchange(ctx, code)
 	if err != nil {
 		return nil, domain.NewAdapterError(
@@ -173,7 +254,7 @@ func (a *SlackAdapter) Execute(
 		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrInternal, fmt.Sprintf("error checking permissions: %v", err), http.StatusInternalServerError)
 	}
 	if !allScopesPresent {
-		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrCredentialError, fmt.Sprintf("missing required permissions: %v", missingIDs), http.StatusForbidden)
+		return nil, domain.NewInsufficientScopeError(a.GetProviderAdapterInfo().Identifier, operationID, missingIDs)
 	}
 
 	processedParams, err := a.ProcessParams(operationID, params)
@@ -181,17 +262,34 @@ func (a *SlackAdapter) Execute(
 		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrInvalidParameters, fmt.Sprintf("parameter validation failed: %v", err), http.StatusBadRequest)
 	}
 
+	// Run operations needing several requests, each Web API method of which must answer ok
+	if opDef.Runner != nil {
+		call := func(ctx context.Context, restParams map[string]interface{}) (map[string]interface{}, error) {
+			return a.callMethod(ctx, operationID, restParams, oauthCred.Token.AccessToken)
+		}
+		result, err := opDef.Runner(ctx, processedParams, call, newFileTransfer(oauthCred.Token.AccessToken))
+		if err != nil {
+			return nil, a.operationError(operationID, err)
+		}
+		return result, nil
+	}
+
 	handler := opDef.Handler
 	restParams, err := handler(ctx, processedParams)
 	if err != nil {
-		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrInternal, fmt.Sprintf("operation handler failed: %v", err), http.StatusInternalServerError)
+		return nil, a.operationError(operationID, err)
 	}
 
+	return a.executeREST(ctx, operationID, restParams, oauthCred.Token.AccessToken)
+}
+
+// executeREST executes one request described by REST parameters, injecting authentication.
+func (a *SlackAdapter) executeREST(ctx context.Context, operationID string, restParams map[string]interface{}, accessToken string) (interface{}, error) {
 	headers, _ := restParams["headers"].(map[string]string)
 	if headers == nil {
 		headers = make(map[string]string)
 	}
-	headers["Authorization"] = utils.StringsBuilder("Bearer ", oauthCred.Token.AccessToken)
+	headers["Authorization"] = utils.StringsBuilder("Bearer ", accessToken)
 	restParams["headers"] = headers
 
 	rawResult, err := a.restAdapter.Execute(ctx, operationID, restParams, nil)
@@ -201,3 +299,36 @@ func (a *SlackAdapter) Execute(
 
 	return rawResult, nil
 }
+
+// callMethod executes one Web API method for an operation runner, Slack reports failed methods in the body with status 200
+func (a *SlackAdapter) callMethod(ctx context.Context, operationID string, restParams map[string]interface{}, accessToken string) (map[string]interface{}, error) {
+	rawResult, err := a.executeREST(ctx, operationID, restParams, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	result, _ := rawResult.(map[string]interface{})
+	if ok, _ := result["ok"].(bool); !ok {
+		slackError, _ := result["error"].(string)
+		return nil, domain.NewAdapterError(
+			a.GetProviderAdapterInfo().Identifier,
+			operationID,
+			slackErrorCode(slackError),
+			fmt.Sprintf("%s failed: %s", restParams["path"], slackError),
+			http.StatusBadGateway,
+		)
+	}
+	return result, nil
+}
+
+// operationError wraps the errors of handlers and runners, adapter errors are returned as they are
+func (a *SlackAdapter) operationError(operationID string, err error) error {
+	var adapterErr *domain.AdapterError
+	if errors.As(err, &adapterErr) {
+		return err
+	}
+	if errors.Is(err, errInvalidParameters) {
+		return domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrInvalidParameters, err.Error(), http.StatusBadRequest)
+	}
+	return domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrInternal, fmt.Sprintf("operation handler failed: %v", err), http.StatusInternalServerError)
+}
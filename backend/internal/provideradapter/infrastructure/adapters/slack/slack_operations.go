@@ -2,8 +2,13 @@ package slack
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
 )
 
 // Define constants for API paths used by handlers.
@@ -17,6 +22,19 @@ const (
 	endpointListChannelMembers  = "/conversations.members"
 	endpointListChannelMessages = "/conversations.history"
 	endpointSendDirectMessage   = "/chat.postMessage"
+
+	endpointSearchMessages         = "/search.messages"
+	endpointGetUploadURLExternal   = "/files.getUploadURLExternal"
+	endpointCompleteUploadExternal = "/files.completeUploadExternal"
+	endpointFileInfo               = "/files.info"
+	endpointAddReaction            = "/reactions.add"
+	endpointRemoveReaction         = "/reactions.remove"
+	endpointLookupUserByEmail      = "/users.lookupByEmail"
+	endpointGetUserInfo            = "/users.info"
+	endpointScheduleMessage        = "/chat.scheduleMessage"
+	endpointListScheduledMessages  = "/chat.scheduledMessages.list"
+	endpointDeleteScheduledMessage = "/chat.deleteScheduledMessage"
+	endpointPostRichMessage        = "/chat.postMessage"
 )
 
 // Define constants for operation IDs used by handlers.
@@ -30,8 +48,23 @@ const (
 	operationIDListChannelMembers  = "list_channel_members"
 	operationIDListChannelMessages = "list_channel_messages"
 	operationIDSendDirectMessage   = "send_direct_message"
+
+	operationIDSearchMessages         = "search_messages"
+	operationIDUploadFile             = "upload_file"
+	operationIDDownloadFile           = "download_file"
+	operationIDAddReaction            = "add_reaction"
+	operationIDRemoveReaction         = "remove_reaction"
+	operationIDLookupUserByEmail      = "lookup_user_by_email"
+	operationIDGetUserInfo            = "get_user_info"
+	operationIDScheduleMessage        = "schedule_message"
+	operationIDListScheduledMessages  = "list_scheduled_messages"
+	operationIDDeleteScheduledMessage = "delete_scheduled_message"
+	operationIDPostRichMessage        = "post_rich_message"
 )
 
+// errInvalidParameters marks handler errors caused by the parameters rather than by the adapter
+var errInvalidParameters = errors.New("invalid parameters")
+
 // PostMessageParams defines parameters for the Post Message operation.
 type PostMessageParams struct {
 	// Define fields based on post_message parameters in config
@@ -148,19 +181,185 @@ type SendDirectMessageParams struct {
 
 }
 
+// SearchMessagesParams defines parameters for the Search Messages operation.
+type SearchMessagesParams struct {
+	Query string `mapstructure:"query" validate:"required"` // Search query, supports modifiers such as in:#channel or from:@user
+
+	Sort string `mapstructure:"sort" validate:"omitempty,oneof=score timestamp" default:"score"` // Sort by relevance or time
+
+	SortDir string `mapstructure:"sort_dir" validate:"omitempty,oneof=asc desc" default:"desc"` // Sort direction
+
+	Count int `mapstructure:"count" validate:"omitempty,gte=1,lte=100" default:"20"` // Number of matches per page
+
+	Page int `mapstructure:"page" validate:"omitempty,gte=1,lte=100" default:"1"` // Page number of the results
+
+	Highlight bool `mapstructure:"highlight" validate:"omitempty"` // Whether to mark the matching terms
+
+}
+
+// UploadFileParams defines parameters for the Upload File operation.
+type UploadFileParams struct {
+	Filename string `mapstructure:"filename" validate:"required"` // Name of the file, including its extension
+
+	Content string `mapstructure:"content" validate:"required_without=ContentBase64"` // Text content of the file
+
+	ContentBase64 string `mapstructure:"content_base64" validate:"required_without=Content"` // Base64 encoded content of a binary file
+
+	Title string `mapstructure:"title" validate:"omitempty"` // Title of the file, defaults to the file name
+
+	Channel string `mapstructure:"channel" validate:"omitempty"` // Conversation ID to share the file in, the file stays private when empty
+
+	InitialComment string `mapstructure:"initial_comment" validate:"omitempty"` // Message text introducing the file
+
+	ThreadTs string `mapstructure:"thread_ts" validate:"omitempty"` // Timestamp of the parent message to share the file in its thread
+
+}
+
+// DownloadFileParams defines parameters for the Download File operation.
+type DownloadFileParams struct {
+	File string `mapstructure:"file" validate:"required"` // File ID
+
+}
+
+// AddReactionParams defines parameters for the Add Reaction operation.
+type AddReactionParams struct {
+	Channel string `mapstructure:"channel" validate:"required"` // Conversation ID that contains the message
+
+	Timestamp string `mapstructure:"timestamp" validate:"required"` // Timestamp of the message
+
+	Name string `mapstructure:"name" validate:"required"` // Emoji name, e.g. thumbsup
+
+}
+
+// RemoveReactionParams defines parameters for the Remove Reaction operation.
+type RemoveReactionParams struct {
+	Channel string `mapstructure:"channel" validate:"required"` // Conversation ID that contains the message
+
+	Timestamp string `mapstructure:"timestamp" validate:"required"` // Timestamp of the message
+
+	Name string `mapstructure:"name" validate:"required"` // Emoji name, e.g. thumbsup
+
+}
+
+// LookupUserByEmailParams defines parameters for the Lookup User By Email operation.
+type LookupUserByEmailParams struct {
+	Email string `mapstructure:"email" validate:"required,email"` // Email address of the user
+
+}
+
+// GetUserInfoParams defines parameters for the Get User Info operation.
+type GetUserInfoParams struct {
+	User string `mapstructure:"user" validate:"required"` // Slack User ID
+
+	IncludeLocale bool `mapstructure:"include_locale" validate:"omitempty"` // Whether to include the locale of the user
+
+}
+
+// ScheduleMessageParams defines parameters for the Schedule Message operation.
+type ScheduleMessageParams struct {
+	Channel string `mapstructure:"channel" validate:"required"` // Conversation ID to post the message in
+
+	Text string `mapstructure:"text" validate:"required"` // Plain-text message content
+
+	PostAt time.Time `mapstructure:"post_at" validate:"required"` // When to post the message (RFC 3339), at most 120 days ahead
+
+	ThreadTs string `mapstructure:"thread_ts" validate:"omitempty"` // Timestamp of the parent message to reply to
+
+	Blocks []interface{} `mapstructure:"blocks" validate:"omitempty"` // Blocks of the simplified schema or Block Kit
+
+}
+
+// ListScheduledMessagesParams defines parameters for the List Scheduled Messages operation.
+type ListScheduledMessagesParams struct {
+	Channel string `mapstructure:"channel" validate:"omitempty"` // Only list the messages scheduled in this conversation
+
+	Cursor string `mapstructure:"cursor" validate:"omitempty"` // Cursor for pagination
+
+	Limit int `mapstructure:"limit" validate:"omitempty"` // Maximum number of messages to return
+
+	Oldest string `mapstructure:"oldest" validate:"omitempty"` // Only include messages scheduled after this Unix timestamp
+
+	Latest string `mapstructure:"latest" validate:"omitempty"` // Only include messages scheduled before this Unix timestamp
+
+}
+
+// DeleteScheduledMessageParams defines parameters for the Delete Scheduled Message operation.
+type DeleteScheduledMessageParams struct {
+	Channel string `mapstructure:"channel" validate:"required"` // Conversation ID the message is scheduled in
+
+	ScheduledMessageId string `mapstructure:"scheduled_message_id" validate:"required"` // ID returned when the message was scheduled
+
+}
+
+// PostRichMessageParams defines parameters for the Post Rich Message operation.
+type PostRichMessageParams struct {
+	Channel string `mapstructure:"channel" validate:"required"` // Conversation ID (channel, group or DM)
+
+	Markdown string `mapstructure:"markdown" validate:"required_without=Blocks"` // Markdown converted to Block Kit
+
+	Blocks []interface{} `mapstructure:"blocks" validate:"required_without=Markdown"` // Blocks of the simplified schema or Block Kit, after the markdown
+
+	Text string `mapstructure:"text" validate:"omitempty"` // Notification text, derived from the blocks when empty
+
+	ThreadTs string `mapstructure:"thread_ts" validate:"omitempty"` // Timestamp of the parent message to reply to
+
+}
+
 // OperationHandler defines the function signature for handling a specific API operation.
 type OperationHandler func(ctx context.Context, params interface{}) (map[string]interface{}, error)
 
+// RESTCaller executes one Web API method described by REST parameters, failing on responses that are not ok.
+type RESTCaller func(ctx context.Context, restParams map[string]interface{}) (map[string]interface{}, error)
+
+// OperationRunner defines the function signature for operations needing several requests or file transfers.
+// It receives processed parameters and returns the result of the operation.
+type OperationRunner func(ctx context.Context, params interface{}, call RESTCaller, files *fileTransfer) (interface{}, error)
+
 // OperationDefinition combines parameter schema and handler.
 type OperationDefinition struct {
 	Schema                interface{}      // Parameter schema (struct pointer)
 	Handler               OperationHandler // Operation handler function
+	Runner                OperationRunner  // Operation runner function, used instead of Handler when set
 	PermissionIdentifiers []string         // List of internal permission identifiers required
 }
 
 // Operations maps operation IDs to their definitions.
 type Operations map[string]OperationDefinition
 
+// cursorPagination returns how a Slack list operation returning its items at itemsPath paginates
+func cursorPagination(itemsPath string) *domain.Pagination {
+	return &domain.Pagination{
+		Style:          domain.PaginationStyleCursor,
+		CursorParam:    "cursor",
+		ItemsPath:      itemsPath,
+		NextCursorPath: "response_metadata.next_cursor",
+	}
+}
+
+// searchPagination is how search.messages paginates, by page numbers up to the number of pages it reports
+var searchPagination = &domain.Pagination{
+	Style:          domain.PaginationStylePage,
+	CursorParam:    "page",
+	ItemsPath:      "messages.matches",
+	TotalPagesPath: "messages.paging.pages",
+}
+
+// operationPaginations maps the paginated operation IDs to their pagination
+var operationPaginations = map[string]*domain.Pagination{
+	operationIDListThreadMessages:    cursorPagination("messages"),
+	operationIDListChannels:          cursorPagination("channels"),
+	operationIDListDirectMessages:    cursorPagination("channels"),
+	operationIDListChannelMembers:    cursorPagination("members"),
+	operationIDListChannelMessages:   cursorPagination("messages"),
+	operationIDListScheduledMessages: cursorPagination("scheduled_messages"),
+	operationIDSearchMessages:        searchPagination,
+}
+
+// Pagination returns how an operation paginates, nil if it does not
+func (a *SlackAdapter) Pagination(operationID string) *domain.Pagination {
+	return operationPaginations[operationID]
+}
+
 // RegisterOperation registers the parameter schema and handler.
 func (a *SlackAdapter) RegisterOperation(operationID string, schema interface{}, handler OperationHandler, requiredPerms []string) {
 	a.BaseAdapter.RegisterOperation(operationID, schema) // Register schema for validation
@@ -174,6 +373,14 @@ func (a *SlackAdapter) RegisterOperation(operationID string, schema interface{},
 	}
 }
 
+// RegisterRunnerOperation registers the parameter schema and runner of an operation needing several requests.
+func (a *SlackAdapter) RegisterRunnerOperation(operationID string, schema interface{}, runner OperationRunner, requiredPerms []string) {
+	a.RegisterOperation(operationID, schema, nil, requiredPerms)
+	definition := a.operations[operationID]
+	definition.Runner = runner
+	a.operations[operationID] = definition
+}
+
 // registerOperations is called by the adapter constructor to register all supported operations.
 func (a *SlackAdapter) registerOperations() {
 
@@ -240,6 +447,83 @@ func (a *SlackAdapter) registerOperations() {
 		[]string{"write_im", "send_message"},
 	)
 
+	a.RegisterOperation(
+		operationIDSearchMessages,
+		&SearchMessagesParams{},
+		handleSearchMessages,
+		[]string{"search_messages"},
+	)
+
+	a.RegisterRunnerOperation(
+		operationIDUploadFile,
+		&UploadFileParams{},
+		runUploadFile,
+		[]string{"write_files"},
+	)
+
+	a.RegisterRunnerOperation(
+		operationIDDownloadFile,
+		&DownloadFileParams{},
+		runDownloadFile,
+		[]string{"read_files"},
+	)
+
+	a.RegisterOperation(
+		operationIDAddReaction,
+		&AddReactionParams{},
+		handleAddReaction,
+		[]string{"write_reactions"},
+	)
+
+	a.RegisterOperation(
+		operationIDRemoveReaction,
+		&RemoveReactionParams{},
+		handleRemoveReaction,
+		[]string{"write_reactions"},
+	)
+
+	a.RegisterOperation(
+		operationIDLookupUserByEmail,
+		&LookupUserByEmailParams{},
+		handleLookupUserByEmail,
+		[]string{"read_users", "read_user_emails"},
+	)
+
+	a.RegisterOperation(
+		operationIDGetUserInfo,
+		&GetUserInfoParams{},
+		handleGetUserInfo,
+		[]string{"read_users"},
+	)
+
+	a.RegisterOperation(
+		operationIDScheduleMessage,
+		&ScheduleMessageParams{},
+		handleScheduleMessage,
+		[]string{"send_message"},
+	)
+
+	a.RegisterOperation(
+		operationIDListScheduledMessages,
+		&ListScheduledMessagesParams{},
+		handleListScheduledMessages,
+		[]string{"send_message"},
+	)
+
+	a.RegisterOperation(
+		operationIDDeleteScheduledMessage,
+		&DeleteScheduledMessageParams{},
+		handleDeleteScheduledMessage,
+		[]string{"send_message"},
+	)
+
+	a.RegisterOperation(
+		operationIDPostRichMessage,
+		&PostRichMessageParams{},
+		handlePostRichMessage,
+		[]string{"send_message"},
+	)
+
 }
 
 // handlePostMessage constructs parameters for the REST adapter for the Post Message operation.
@@ -456,3 +740,220 @@ func handleSendDirectMessage(ctx context.Context, params interface{}) (map[strin
 	}
 	return restParams, nil
 }
+
+// handleSearchMessages constructs parameters for the REST adapter for the Search Messages operation.
+func handleSearchMessages(ctx context.Context, params interface{}) (map[string]interface{}, error) {
+	p, ok := params.(*SearchMessagesParams)
+	if !ok || p == nil {
+		return nil, fmt.Errorf("invalid or missing parameters for search_messages")
+	}
+	queryParams := map[string]string{
+		"query": p.Query,
+	}
+	if p.Sort != "" {
+		queryParams["sort"] = p.Sort
+	}
+	if p.SortDir != "" {
+		queryParams["sort_dir"] = p.SortDir
+	}
+	if p.Count > 0 {
+		queryParams["count"] = fmt.Sprintf("%d", p.Count)
+	}
+	if p.Page > 0 {
+		queryParams["page"] = fmt.Sprintf("%d", p.Page)
+	}
+	if p.Highlight {
+		queryParams["highlight"] = "true"
+	}
+	restParams := map[string]interface{}{
+		"method":       http.MethodGet,
+		"path":         endpointSearchMessages,
+		"query_params": queryParams,
+	}
+	return restParams, nil
+}
+
+// handleAddReaction constructs parameters for the REST adapter for the Add Reaction operation.
+func handleAddReaction(ctx context.Context, params interface{}) (map[string]interface{}, error) {
+	p, ok := params.(*AddReactionParams)
+	if !ok || p == nil {
+		return nil, fmt.Errorf("invalid or missing parameters for add_reaction")
+	}
+	restParams := map[string]interface{}{
+		"method": http.MethodPost,
+		"path":   endpointAddReaction,
+		"body":   reactionBody(p.Channel, p.Timestamp, p.Name),
+	}
+	return restParams, nil
+}
+
+// handleRemoveReaction constructs parameters for the REST adapter for the Remove Reaction operation.
+func handleRemoveReaction(ctx context.Context, params interface{}) (map[string]interface{}, error) {
+	p, ok := params.(*RemoveReactionParams)
+	if !ok || p == nil {
+		return nil, fmt.Errorf("invalid or missing parameters for remove_reaction")
+	}
+	restParams := map[string]interface{}{
+		"method": http.MethodPost,
+		"path":   endpointRemoveReaction,
+		"body":   reactionBody(p.Channel, p.Timestamp, p.Name),
+	}
+	return restParams, nil
+}
+
+// reactionBody builds the request body of reactions.add and reactions.remove, emoji names are accepted with their colons
+func reactionBody(channel, timestamp, name string) map[string]interface{} {
+	return map[string]interface{}{
+		"channel":   channel,
+		"timestamp": timestamp,
+		"name":      strings.Trim(name, ":"),
+	}
+}
+
+// handleLookupUserByEmail constructs parameters for the REST adapter for the Lookup User By Email operation.
+func handleLookupUserByEmail(ctx context.Context, params interface{}) (map[string]interface{}, error) {
+	p, ok := params.(*LookupUserByEmailParams)
+	if !ok || p == nil {
+		return nil, fmt.Errorf("invalid or missing parameters for lookup_user_by_email")
+	}
+	restParams := map[string]interface{}{
+		"method": http.MethodGet,
+		"path":   endpointLookupUserByEmail,
+		"query_params": map[string]string{
+			"email": p.Email,
+		},
+	}
+	return restParams, nil
+}
+
+// handleGetUserInfo constructs parameters for the REST adapter for the Get User Info operation.
+func handleGetUserInfo(ctx context.Context, params interface{}) (map[string]interface{}, error) {
+	p, ok := params.(*GetUserInfoParams)
+	if !ok || p == nil {
+		return nil, fmt.Errorf("invalid or missing parameters for get_user_info")
+	}
+	queryParams := map[string]string{
+		"user": p.User,
+	}
+	if p.IncludeLocale {
+		queryParams["include_locale"] = "true"
+	}
+	restParams := map[string]interface{}{
+		"method":       http.MethodGet,
+		"path":         endpointGetUserInfo,
+		"query_params": queryParams,
+	}
+	return restParams, nil
+}
+
+// handleScheduleMessage constructs parameters for the REST adapter for the Schedule Message operation.
+func handleScheduleMessage(ctx context.Context, params interface{}) (map[string]interface{}, error) {
+	p, ok := params.(*ScheduleMessageParams)
+	if !ok || p == nil {
+		return nil, fmt.Errorf("invalid or missing parameters for schedule_message")
+	}
+	if !p.PostAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: post_at must be in the future", errInvalidParameters)
+	}
+	requestBody := map[string]interface{}{
+		"channel": p.Channel,
+		"text":    p.Text,
+		"post_at": p.PostAt.Unix(),
+	}
+	if p.ThreadTs != "" {
+		requestBody["thread_ts"] = p.ThreadTs
+	}
+	if len(p.Blocks) > 0 {
+		blocks, err := buildBlocks("", p.Blocks)
+		if err != nil {
+			return nil, err
+		}
+		requestBody["blocks"] = blocks
+	}
+	restParams := map[string]interface{}{
+		"method": http.MethodPost,
+		"path":   endpointScheduleMessage,
+		"body":   requestBody,
+	}
+	return restParams, nil
+}
+
+// handleListScheduledMessages constructs parameters for the REST adapter for the List Scheduled Messages operation.
+func handleListScheduledMessages(ctx context.Context, params interface{}) (map[string]interface{}, error) {
+	p, ok := params.(*ListScheduledMessagesParams)
+	if !ok || p == nil {
+		return nil, fmt.Errorf("invalid or missing parameters for list_scheduled_messages")
+	}
+	queryParams := map[string]string{}
+	if p.Channel != "" {
+		queryParams["channel"] = p.Channel
+	}
+	if p.Cursor != "" {
+		queryParams["cursor"] = p.Cursor
+	}
+	if p.Limit > 0 {
+		queryParams["limit"] = fmt.Sprintf("%d", p.Limit)
+	}
+	if p.Oldest != "" {
+		queryParams["oldest"] = p.Oldest
+	}
+	if p.Latest != "" {
+		queryParams["latest"] = p.Latest
+	}
+	restParams := map[string]interface{}{
+		"method":       http.MethodGet,
+		"path":         endpointListScheduledMessages,
+		"query_params": queryParams,
+	}
+	return restParams, nil
+}
+
+// handleDeleteScheduledMessage constructs parameters for the REST adapter for the Delete Scheduled Message operation.
+func handleDeleteScheduledMessage(ctx context.Context, params interface{}) (map[string]interface{}, error) {
+	p, ok := params.(*DeleteScheduledMessageParams)
+	if !ok || p == nil {
+		return nil, fmt.Errorf("invalid or missing parameters for delete_scheduled_message")
+	}
+	restParams := map[string]interface{}{
+		"method": http.MethodPost,
+		"path":   endpointDeleteScheduledMessage,
+		"body": map[string]interface{}{
+			"channel":              p.Channel,
+			"scheduled_message_id": p.ScheduledMessageId,
+		},
+	}
+	return restParams, nil
+}
+
+// handlePostRichMessage constructs parameters for the REST adapter for the Post Rich Message operation.
+func handlePostRichMessage(ctx context.Context, params interface{}) (map[string]interface{}, error) {
+	p, ok := params.(*PostRichMessageParams)
+	if !ok || p == nil {
+		return nil, fmt.Errorf("invalid or missing parameters for post_rich_message")
+	}
+	blocks, err := buildBlocks(p.Markdown, p.Blocks)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("%w: the message has no blocks", errInvalidParameters)
+	}
+	text := p.Text
+	if text == "" {
+		text = fallbackText(blocks)
+	}
+	requestBody := map[string]interface{}{
+		"channel": p.Channel,
+		"text":    text,
+		"blocks":  blocks,
+	}
+	if p.ThreadTs != "" {
+		requestBody["thread_ts"] = p.ThreadTs
+	}
+	restParams := map[string]interface{}{
+		"method": http.MethodPost,
+		"path":   endpointPostRichMessage,
+		"body":   requestBody,
+	}
+	return restParams, nil
+}
@@ -15,8 +15,11 @@ const (
 	identifier = "slack"
 	baseURL    = "https://slack.com/api"
 
-	authURL  = "https://slack.com/oauth/v2/authorize"
-	tokenURL = "https://slack.com/api/oauth.v2.access"
+	authURL   = "https://slack.com/oauth/v2/authorize"
+	tokenURL  = "https://slack.com/api/oauth.v2.access"
+	revokeURL = "https://slack.com/api/auth.revoke"
+
+	verifyPath = "/auth.test"
 )
 
 var opDefaults = OperationDefaults{
@@ -32,6 +35,9 @@ var opDefaults = OperationDefaults{
 func init() {
 	// Type assertion to ensure the adapter implements the necessary interfaces
 	var _ domain.OAuthAdapter = (*SlackAdapter)(nil)
+	var _ domain.OAuthTokenRevoker = (*SlackAdapter)(nil)
+	var _ domain.CredentialVerifier = (*SlackAdapter)(nil)
+	var _ domain.Paginator = (*SlackAdapter)(nil)
 
 	template := &SlackTemplate{}
 	registry.RegisterAdapterTemplate("slack", template)
@@ -53,21 +53,21 @@ func NewSpotifyAdapter(
 	return adapter
 }
 
-// createOAuth2Config creates the oauth2.Config object.
-func (a *SpotifyAdapter) createOAuth2Config(redirectURL string, scopes []string) *oauth2.Config {
+// createOAuth2Config creates the oauth2.Config object, using the OAuth app override of the context if any.
+func (a *SpotifyAdapter) createOAuth2Config(ctx context.Context, redirectURL string, scopes []string) *oauth2.Config {
 	endpoint := oauth2.Endpoint{
 		AuthURL:   authURL,
 		TokenURL:  tokenURL,
 		AuthStyle: oauth2.AuthStyleInHeader,
 	}
 
-	return &oauth2.Config{
+	return base.ApplyOAuthAppOverride(ctx, &oauth2.Config{
 		ClientID:     a.oauthConfig.ClientID,
 		ClientSecret: a.oauthConfig.ClientSecret,
 		RedirectURL:  redirectURL,
 		Scopes:       scopes,
 		Endpoint:     endpoint,
-	}
+	})
 }
 
 // ShouldRefreshToken checks if the token should be refreshed
@@ -80,7 +80,7 @@ func (a *SpotifyAdapter) ShouldRefreshToken(oldToken *oauth2.Token) bool {
 
 // RefreshOAuthToken refreshes an OAuth token.
 func (a *SpotifyAdapter) RefreshOAuthToken(ctx context.Context, oldToken *oauth2.Token) (*oauth2.Token, error) {
-	oauth2Config := a.createOAuth2Config("", nil)
+	oauth2Config := a.createOAuth2Config(ctx, "", nil)
 	tokenSource := oauth2Config.TokenSource(ctx, oldToken)
 	newToken, err := tokenSource.Token()
 	if err != nil {
@@ -103,13 +103,13 @@ func (a *SpotifyAdapter) GenerateOAuthURL(ctx context.Context,
 	redirectURL, state, codeChallenge string,
 	scopes []string,
 ) (string, error) {
-	oauth2Config := a.createOAuth2Config(redirectURL, scopes)
+	oauth2Config := a.createOAuth2Config(ctx, redirectURL, scopes)
 	return oauth2Config.AuthCodeURL(state /*, oauth2.AccessTypeOffline*/), nil
 }
 
 // ExchangeCodeForTokens exchanges an authorization code for tokens.
 func (a *SpotifyAdapter) ExchangeCodeForTokens(ctx context.Context, code, redirectURL, codeVerifier string) (*oauth2.Token, error) {
-	oauth2Config := a.createOAuth2Config(redirectURL, nil)
+	oauth2Config := a.createOAuth2Config(ctx, redirectURL, nil)
 	token, err := oauth2Config.Exchange(ctx, code)
 	if err != nil {
 		return nil, domain.NewAdapterError(
@@ -168,7 +168,7 @@ func (a *SpotifyAdapter) Execute(
 		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrInternal, fmt.Sprintf("error checking permissions: %v", err), http.StatusInternalServerError)
 	}
 	if !allScopesPresent {
-		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrCredentialError, fmt.Sprintf("missing required permissions: %v", missingIDs), http.StatusForbidden)
+		return nil, domain.NewInsufficientScopeError(a.GetProviderAdapterInfo().Identifier, operationID, missingIDs)
 	}
 
 	processedParams, err := a.ProcessParams(operationID, params)
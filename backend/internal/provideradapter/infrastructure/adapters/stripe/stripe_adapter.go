@@ -94,3 +94,20 @@ func (a *StripeAdapter) Execute(
 
 	return rawResult, nil
 }
+
+// VerifyCredential checks the API key by retrieving the balance, Stripe answers 401 for unknown or rolled keys
+func (a *StripeAdapter) VerifyCredential(ctx context.Context, credential interface{}) error {
+	apiKeyCred, ok := credential.(*credDomain.APIKeyCredential)
+	if !ok || apiKeyCred == nil || apiKeyCred.APIKey == "" {
+		return fmt.Errorf("invalid or missing API key credential")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+verifyPath, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create verification request: %w", err)
+	}
+	req.SetBasicAuth(apiKeyCred.APIKey, "")
+
+	_, err = base.DoCredentialVerification(a.GetProviderAdapterInfo().Identifier, req)
+	return err
+}
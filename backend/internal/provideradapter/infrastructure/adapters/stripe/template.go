@@ -15,6 +15,7 @@ const (
 	baseURL    = "https://api.stripe.com/v1"
 
 	apikeyParamName = "Authorization"
+	verifyPath      = "/balance"
 )
 
 // Register the Stripe adapter template during package initialization.
@@ -23,6 +24,7 @@ func init() {
 	// StripeAdapter now uses APIKey, so it should implement APIKeyAdapter if such an interface exists and is intended.
 	// The linter error indicates `domain.APIKeyAdapter` exists and GetAPIKeyConfig() *domain.APIKeyConfig is expected.
 	var _ domain.APIKeyAdapter = (*StripeAdapter)(nil)
+	var _ domain.CredentialVerifier = (*StripeAdapter)(nil)
 
 	template := &StripeTemplate{}
 	registry.RegisterAdapterTemplate(identifier, template)
@@ -21,6 +21,7 @@ const (
 func init() {
 	// Type assertion to ensure the adapter implements the necessary interfaces
 	var _ domain.APIKeyAdapter = (*TmdbAdapter)(nil)
+	var _ domain.Paginator = (*TmdbAdapter)(nil)
 
 	template := &TmdbTemplate{}
 	registry.RegisterAdapterTemplate(identifier, template)
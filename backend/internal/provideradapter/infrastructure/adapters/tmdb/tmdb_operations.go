@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
 )
 
 // Define operation IDs as constants
@@ -159,6 +161,37 @@ type OperationDefinition struct {
 // Operations maps operation ID to its definition
 type Operations map[string]OperationDefinition
 
+// pagePagination is how the list operations of TMDB paginate, each page reports the number of pages
+var pagePagination = &domain.Pagination{
+	Style:          domain.PaginationStylePage,
+	CursorParam:    "page",
+	ItemsPath:      "results",
+	TotalPagesPath: "total_pages",
+}
+
+// operationPaginations maps the paginated operation IDs to their pagination
+var operationPaginations = map[string]*domain.Pagination{
+	operationIDMoviePopular:    pagePagination,
+	operationIDMovieNowPlaying: pagePagination,
+	operationIDMovieTopRated:   pagePagination,
+	operationIDMovieUpcoming:   pagePagination,
+	operationIDTVPopular:       pagePagination,
+	operationIDTVOnTheAir:      pagePagination,
+	operationIDTVTopRated:      pagePagination,
+	operationIDTVAiringToday:   pagePagination,
+	operationIDDiscoverMovie:   pagePagination,
+	operationIDDiscoverTV:      pagePagination,
+	operationIDSearchMovie:     pagePagination,
+	operationIDSearchTV:        pagePagination,
+	operationIDSearchPerson:    pagePagination,
+	operationIDSearchMulti:     pagePagination,
+}
+
+// Pagination returns how an operation paginates, nil if it does not
+func (a *TmdbAdapter) Pagination(operationID string) *domain.Pagination {
+	return operationPaginations[operationID]
+}
+
 // RegisterOperation registers an operation
 func (a *TmdbAdapter) RegisterOperation(operationID string, schema interface{}, handler OperationHandler) {
 	// Register the parameter schema with the base adapter for validation/decoding
@@ -14,14 +14,17 @@ const (
 	identifier = "zoom"
 	baseURL    = "https://api.zoom.us/v2"
 
-	authURL  = "https://zoom.us/oauth/authorize"
-	tokenURL = "https://zoom.us/oauth/token"
+	authURL   = "https://zoom.us/oauth/authorize"
+	tokenURL  = "https://zoom.us/oauth/token"
+	revokeURL = "https://zoom.us/oauth/revoke"
 )
 
 // Register the Zoom adapter template during package initialization.
 func init() {
 	// Type assertion to ensure the adapter implements the necessary interfaces
 	var _ domain.OAuthAdapter = (*ZoomAdapter)(nil)
+	var _ domain.OAuthTokenRevoker = (*ZoomAdapter)(nil)
+	var _ domain.CredentialVerifier = (*ZoomAdapter)(nil)
 
 	template := &ZoomAdapterTemplate{}
 	registry.RegisterAdapterTemplate(identifier, template)
@@ -50,21 +50,21 @@ func NewZoomAdapter(
 	return adapter
 }
 
-// createOAuth2Config creates the oauth2.Config object.
-func (a *ZoomAdapter) createOAuth2Config(redirectURL string, scopes []string) *oauth2.Config {
+// createOAuth2Config creates the oauth2.Config object, using the OAuth app override of the context if any.
+func (a *ZoomAdapter) createOAuth2Config(ctx context.Context, redirectURL string, scopes []string) *oauth2.Config {
 	endpoint := oauth2.Endpoint{
 		AuthURL:   authURL,
 		TokenURL:  tokenURL,
 		AuthStyle: oauth2.AuthStyleInHeader,
 	}
 
-	return &oauth2.Config{
+	return base.ApplyOAuthAppOverride(ctx, &oauth2.Config{
 		ClientID:     a.oauthConfig.ClientID,
 		ClientSecret: a.oauthConfig.ClientSecret,
 		RedirectURL:  redirectURL,
 		Scopes:       scopes,
 		Endpoint:     endpoint,
-	}
+	})
 }
 
 // ShouldRefreshToken checks if the token should be refreshed
@@ -77,7 +77,7 @@ func (a *ZoomAdapter) ShouldRefreshToken(oldToken *oauth2.Token) bool {
 
 // RefreshOAuthToken refreshes an OAuth token.
 func (a *ZoomAdapter) RefreshOAuthToken(ctx context.Context, oldToken *oauth2.Token) (*oauth2.Token, error) {
-	oauth2Config := a.createOAuth2Config("", nil)
+	oauth2Config := a.createOAuth2Config(ctx, "", nil)
 	tokenSource := oauth2Config.TokenSource(ctx, oldToken)
 	newToken, err := tokenSource.Token()
 	if err != nil {
@@ -92,18 +92,40 @@ func (a *ZoomAdapter) RefreshOAuthToken(ctx context.Context, oldToken *oauth2.To
 	return newToken, nil
 }
 
+// RevokeOAuthToken revokes the access token at the Zoom revoke endpoint
+func (a *ZoomAdapter) RevokeOAuthToken(ctx context.Context, token *oauth2.Token) error {
+	return base.RevokeTokenRFC7009(ctx, a.GetProviderAdapterInfo().Identifier, revokeURL, token.AccessToken, "", a.oauthConfig)
+}
+
+// VerifyCredential checks the token by fetching the user it belongs to, as get_user_info does
+func (a *ZoomAdapter) VerifyCredential(ctx context.Context, credential interface{}) error {
+	oauthCred, ok := credential.(*credDomain.OAuthCredential)
+	if !ok || oauthCred == nil || oauthCred.Token == nil {
+		return fmt.Errorf("invalid or missing OAuth credential")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base.APIBaseURL(ctx, baseURL)+endpointGetUserInfo, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create verification request: %w", err)
+	}
+	req.Header.Set("Authorization", utils.StringsBuilder("Bearer ", oauthCred.Token.AccessToken))
+
+	_, err = base.DoCredentialVerification(a.GetProviderAdapterInfo().Identifier, req)
+	return err
+}
+
 // GenerateOAuthURL generates an OAuth authorization URL.
 func (a *ZoomAdapter) GenerateOAuthURL(ctx context.Context,
 	redirectURL, state, codeChallenge string,
 	scopes []string,
 ) (string, error) {
-	oauth2Config := a.createOAuth2Config(redirectURL, scopes)
+	oauth2Config := a.createOAuth2Config(ctx, redirectURL, scopes)
 	return oauth2Config.AuthCodeURL(state /*, oauth2.AccessTypeOffline*/), nil
 }
 
 // ExchangeCodeForTokens exchanges an authorization code for tokens.
 func (a *ZoomAdapter) ExchangeCodeForTokens(ctx context.Context, code, redirectURL, codeVerifier string) (*oauth2.Token, error) {
-	oauth2Config := a.createOAuth2Config(redirectURL, nil) // Scopes not needed for exchange usually
+	oauth2Config := a.createOAuth2Config(ctx, redirectURL, nil) // Scopes not needed for exchange usually
 	token, err := oauth2Config.Exchange(ctx, code)
 	if err != nil {
 		return nil, domain.NewAdapterError(
@@ -162,7 +184,7 @@ func (a *ZoomAdapter) Execute(
 		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrInternal, fmt.Sprintf("error checking permissions: %v", err), http.StatusInternalServerError)
 	}
 	if !allScopesPresent {
-		return nil, domain.NewAdapterError(a.GetProviderAdapterInfo().Identifier, operationID, domain.ErrCredentialError, fmt.Sprintf("missing required permissions: %v", missingIDs), http.StatusForbidden)
+		return nil, domain.NewInsufficientScopeError(a.GetProviderAdapterInfo().Identifier, operationID, missingIDs)
 	}
 
 	processedParams, err := a.ProcessParams(operationID, params)
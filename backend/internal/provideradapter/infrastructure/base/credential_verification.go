@@ -0,0 +1,45 @@
+package base
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
+	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/vcr"
+)
+
+// credentialVerificationTimeout bounds a single call verifying a credential
+const credentialVerificationTimeout = 15 * time.Second
+
+// credentialVerificationClient is shared by all adapters verifying credentials
+var credentialVerificationClient = vcr.NewHTTPClient(credentialVerificationTimeout)
+
+// DoCredentialVerification sends a verification request and returns the response body
+// Only 401 means the provider rejected the credential, 403 is left out as providers also answer it for rate limits
+// and missing permissions of a valid credential, other failures are reported as provider API errors
+func DoCredentialVerification(providerIdentifier string, req *http.Request) ([]byte, error) {
+	resp, err := credentialVerificationClient.Do(req)
+	if err != nil {
+		return nil, domain.NewAdapterError(providerIdentifier, "verify_credential", domain.ErrProviderAPIError,
+			fmt.Sprintf("verification request failed: %v", err), http.StatusBadGateway)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read verification response: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return body, domain.NewAdapterError(providerIdentifier, "verify_credential", domain.ErrCredentialError,
+			fmt.Sprintf("credential rejected with status %d: %s", resp.StatusCode, string(body)), resp.StatusCode)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return body, domain.NewAdapterError(providerIdentifier, "verify_credential", domain.ErrProviderAPIError,
+			fmt.Sprintf("verification failed with status %d: %s", resp.StatusCode, string(body)), resp.StatusCode)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,47 @@
+package base
+
+import (
+	"context"
+
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
+	"golang.org/x/oauth2"
+)
+
+// ApplyOAuthAppOverride replaces the client and the endpoints of an OAuth config with the override of the context, if any
+func ApplyOAuthAppOverride(ctx context.Context, oauth2Config *oauth2.Config) *oauth2.Config {
+	override := domain.OAuthAppOverrideFromContext(ctx)
+	if override == nil {
+		return oauth2Config
+	}
+
+	oauth2Config.ClientID = override.ClientID
+	oauth2Config.ClientSecret = override.ClientSecret
+	if override.AuthURL != "" {
+		oauth2Config.Endpoint.AuthURL = override.AuthURL
+	}
+	if override.TokenURL != "" {
+		oauth2Config.Endpoint.TokenURL = override.TokenURL
+	}
+	return oauth2Config
+}
+
+// OAuthClientConfig returns the OAuth client of the override of the context, or the client of the provider manifest
+func OAuthClientConfig(ctx context.Context, oauthConfig *domain.OAuthConfig) *domain.OAuthConfig {
+	override := domain.OAuthAppOverrideFromContext(ctx)
+	if override == nil {
+		return oauthConfig
+	}
+
+	return &domain.OAuthConfig{
+		ClientID:     override.ClientID,
+		ClientSecret: override.ClientSecret,
+	}
+}
+
+// APIBaseURL returns the API base URL of the override of the context, or the given default
+func APIBaseURL(ctx context.Context, defaultURL string) string {
+	if override := domain.OAuthAppOverrideFromContext(ctx); override != nil && override.APIBaseURL != "" {
+		return override.APIBaseURL
+	}
+	return defaultURL
+}
@@ -0,0 +1,72 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
+	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/vcr"
+)
+
+// tokenRevocationTimeout bounds a single call to a provider revocation endpoint
+const tokenRevocationTimeout = 15 * time.Second
+
+// tokenRevocationClient is shared by all adapters revoking tokens
+var tokenRevocationClient = vcr.NewHTTPClient(tokenRevocationTimeout)
+
+// RevokeTokenRFC7009 revokes a token at an RFC 7009 endpoint, authenticating the client with HTTP basic auth
+// The client of the OAuth app override of the context is used instead of the given one, if any
+func RevokeTokenRFC7009(
+	ctx context.Context,
+	providerIdentifier, endpoint, token, tokenTypeHint string,
+	oauthConfig *domain.OAuthConfig,
+) error {
+	oauthConfig = OAuthClientConfig(ctx, oauthConfig)
+
+	form := url.Values{"token": {token}}
+	if tokenTypeHint != "" {
+		form.Set("token_type_hint", tokenTypeHint)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create revocation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(oauthConfig.ClientID, oauthConfig.ClientSecret)
+
+	_, err = DoTokenRevocation(providerIdentifier, req)
+	return err
+}
+
+// DoTokenRevocation sends a revocation request and returns the response body
+// Any 2xx status is a success, as are the given statuses providers answer for tokens that are already invalid
+func DoTokenRevocation(providerIdentifier string, req *http.Request, alreadyRevokedStatuses ...int) ([]byte, error) {
+	resp, err := tokenRevocationClient.Do(req)
+	if err != nil {
+		return nil, domain.NewAdapterError(providerIdentifier, "oauth_revoke", domain.ErrProviderAPIError,
+			fmt.Sprintf("revocation request failed: %v", err), http.StatusBadGateway)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read revocation response: %w", err)
+	}
+
+	if slices.Contains(alreadyRevokedStatuses, resp.StatusCode) {
+		return body, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return body, domain.NewAdapterError(providerIdentifier, "oauth_revoke", domain.ErrCredentialError,
+			fmt.Sprintf("revocation failed with status %d: %s", resp.StatusCode, string(body)), resp.StatusCode)
+	}
+
+	return body, nil
+}
@@ -22,3 +22,19 @@ type ProviderAdapterModel struct {
 func (ProviderAdapterModel) TableName() string {
 	return "provider_adapters"
 }
+
+// ProbeResultModel represents a synthetic provider probe result in the database
+type ProbeResultModel struct {
+	ID                 string    `gorm:"type:uuid;primaryKey"`
+	ProviderIdentifier string    `gorm:"type:varchar(50);not null;index"`
+	Operation          string    `gorm:"type:varchar(100);not null"`
+	Status             string    `gorm:"type:varchar(20);not null"`
+	LatencyMs          int64     `gorm:"not null;default:0"`
+	Error              string    `gorm:"type:text"`
+	CheckedAt          time.Time `gorm:"type:timestamp with time zone;not null;default:now()"`
+}
+
+// TableName specifies the table name for ProbeResultModel
+func (ProbeResultModel) TableName() string {
+	return "provider_probe_results"
+}
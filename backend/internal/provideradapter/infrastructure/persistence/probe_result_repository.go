@@ -0,0 +1,111 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	observability "github.com/context-space/cloud-observability"
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
+	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+)
+
+// ProbeResultRepository implements the domain.ProbeResultRepository interface using GORM
+type ProbeResultRepository struct {
+	db  database.Database
+	obs *observability.ObservabilityProvider
+}
+
+// NewProbeResultRepository creates a new probe result repository
+func NewProbeResultRepository(db database.Database, observabilityProvider *observability.ObservabilityProvider) *ProbeResultRepository {
+	return &ProbeResultRepository{
+		db:  db,
+		obs: observabilityProvider,
+	}
+}
+
+// Create records a probe result
+func (r *ProbeResultRepository) Create(ctx context.Context, result *domain.ProbeResult) error {
+	ctx, span := r.obs.Tracer.Start(ctx, "ProbeResultRepository.Create")
+	defer span.End()
+
+	return r.db.WithContext(ctx).Create(r.mapToModel(result)).Error
+}
+
+// ListByProvider returns the most recent probe results of a provider, newest first
+func (r *ProbeResultRepository) ListByProvider(ctx context.Context, providerIdentifier string, limit int) ([]*domain.ProbeResult, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "ProbeResultRepository.ListByProvider")
+	defer span.End()
+
+	var models []ProbeResultModel
+	result := r.db.WithContext(ctx).
+		Where("provider_identifier = ?", providerIdentifier).
+		Order("checked_at DESC").
+		Limit(limit).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.mapToDomainList(models), nil
+}
+
+// ListLatest returns the most recent probe result of every probed provider
+func (r *ProbeResultRepository) ListLatest(ctx context.Context) ([]*domain.ProbeResult, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "ProbeResultRepository.ListLatest")
+	defer span.End()
+
+	var models []ProbeResultModel
+	result := r.db.WithContext(ctx).
+		Raw(`SELECT DISTINCT ON (provider_identifier) * FROM provider_probe_results
+			ORDER BY provider_identifier, checked_at DESC`).
+		Scan(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.mapToDomainList(models), nil
+}
+
+// DeleteBefore deletes probe results checked before the given time
+func (r *ProbeResultRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := r.obs.Tracer.Start(ctx, "ProbeResultRepository.DeleteBefore")
+	defer span.End()
+
+	result := r.db.WithContext(ctx).Where("checked_at < ?", before).Delete(&ProbeResultModel{})
+	return result.RowsAffected, result.Error
+}
+
+// mapToDomainList converts probe result models to domain probe results
+func (r *ProbeResultRepository) mapToDomainList(models []ProbeResultModel) []*domain.ProbeResult {
+	results := make([]*domain.ProbeResult, 0, len(models))
+	for i := range models {
+		results = append(results, r.mapToDomain(&models[i]))
+	}
+	return results
+}
+
+// mapToDomain converts a probe result model to a domain probe result
+func (r *ProbeResultRepository) mapToDomain(model *ProbeResultModel) *domain.ProbeResult {
+	return &domain.ProbeResult{
+		ID:                 model.ID,
+		ProviderIdentifier: model.ProviderIdentifier,
+		Operation:          model.Operation,
+		Status:             domain.ProbeStatus(model.Status),
+		LatencyMs:          model.LatencyMs,
+		Error:              model.Error,
+		CheckedAt:          model.CheckedAt,
+	}
+}
+
+// mapToModel converts a domain probe result to a probe result model
+func (r *ProbeResultRepository) mapToModel(result *domain.ProbeResult) *ProbeResultModel {
+	return &ProbeResultModel{
+		ID:                 result.ID,
+		ProviderIdentifier: result.ProviderIdentifier,
+		Operation:          result.Operation,
+		Status:             string(result.Status),
+		LatencyMs:          result.LatencyMs,
+		Error:              result.Error,
+		CheckedAt:          result.CheckedAt,
+	}
+}
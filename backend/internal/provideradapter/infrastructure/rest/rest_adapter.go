@@ -12,6 +12,7 @@ import (
 	"github.com/bytedance/sonic"
 	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
 	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/base"
+	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/vcr"
 	"github.com/context-space/context-space/backend/internal/shared/utils"
 )
 
@@ -45,7 +46,9 @@ func NewRESTAdapter(
 		RestConfig:  restConfig,
 		httpClient: &http.Client{
 			Timeout: config.Timeout,
+			// Record/replay transport, a pass-through unless enabled in config or per request
 			// TODO: Add transport for retries, circuit breaker, etc. later
+			Transport: vcr.NewTransport(nil),
 		},
 	}
 }
@@ -81,10 +84,11 @@ func (a *RESTAdapter) Execute(
 		}
 	}
 
-	// 2. Construct the full URL
-	baseURL, err := url.Parse(a.RestConfig.BaseURL)
+	// 2. Construct the full URL, self-hosted instances are reached through the OAuth app override of the context
+	rawBaseURL := base.APIBaseURL(ctx, a.RestConfig.BaseURL)
+	baseURL, err := url.Parse(rawBaseURL)
 	if err != nil {
-		return nil, fmt.Errorf("[%s] invalid base URL '%s': %w", a.GetProviderAdapterInfo().Identifier, a.RestConfig.BaseURL, err)
+		return nil, fmt.Errorf("[%s] invalid base URL '%s': %w", a.GetProviderAdapterInfo().Identifier, rawBaseURL, err)
 	}
 	// Use the finalPath after substitution
 	fullURL := baseURL.JoinPath(finalPath) // Handles slashes correctly
@@ -0,0 +1,203 @@
+package vcr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// redacted replaces sensitive values in cassettes
+	redacted = "REDACTED"
+	// defaultProvider and defaultOperation name the cassette of untagged requests
+	defaultProvider  = "_unknown"
+	defaultOperation = "_unknown"
+	// defaultSubject holds the cassettes of traffic without a user, such as provider probes
+	defaultSubject = "_anonymous"
+)
+
+// sensitiveName matches header, query and body field names whose values must not be stored
+var sensitiveName = regexp.MustCompile(`(?i)(authorization|cookie|token|secret|password|api[-_]?key|apikey|signature|^key$|^code$|code_verifier|session)`)
+
+// pathSafe replaces characters that are not safe in cassette file names
+var pathSafe = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// Cassette holds the recorded HTTP interactions of one provider operation
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is one sanitized request and the response the provider sent for it
+type Interaction struct {
+	Key      string           `json:"key"`
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the sanitized form of an outgoing request
+type RecordedRequest struct {
+	Method  string              `json:"method"`
+	URL     string              `json:"url"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	Body    string              `json:"body,omitempty"`
+}
+
+// RecordedResponse is the sanitized form of a provider response
+type RecordedResponse struct {
+	StatusCode int                 `json:"status_code"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       string              `json:"body,omitempty"`
+}
+
+// find returns the interaction recorded for a request key
+func (c *Cassette) find(key string) *Interaction {
+	for _, interaction := range c.Interactions {
+		if interaction.Key == key {
+			return interaction
+		}
+	}
+	return nil
+}
+
+// put stores an interaction, replacing an earlier recording of the same request
+func (c *Cassette) put(interaction *Interaction) {
+	for i, existing := range c.Interactions {
+		if existing.Key == interaction.Key {
+			c.Interactions[i] = interaction
+			return
+		}
+	}
+	c.Interactions = append(c.Interactions, interaction)
+}
+
+// cassettePath returns the file storing the cassette of a provider operation for one subject
+func cassettePath(dir string, ref operationRef, suffix string) string {
+	return filepath.Join(dir,
+		pathSafe.ReplaceAllString(ref.subject, "_"),
+		pathSafe.ReplaceAllString(ref.provider, "_"),
+		pathSafe.ReplaceAllString(ref.operation, "_")+suffix)
+}
+
+// loadJSON reads a cassette file into v, a missing file leaves v untouched
+func loadJSON(path string, v interface{}) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// saveJSON writes v as an indented cassette file, creating parent directories
+func saveJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// requestKey identifies a request by its method, sanitized URL and sanitized body
+func requestKey(method, sanitizedURL, sanitizedBody string) string {
+	hash := sha256.Sum256([]byte(method + " " + sanitizedURL + "\n" + sanitizedBody))
+	return hex.EncodeToString(hash[:])
+}
+
+// sanitizeHeaders copies headers, redacting sensitive values
+func sanitizeHeaders(headers http.Header) map[string][]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	sanitized := make(map[string][]string, len(headers))
+	for name, values := range headers {
+		if sensitiveName.MatchString(name) {
+			sanitized[name] = []string{redacted}
+			continue
+		}
+		sanitized[name] = append([]string(nil), values...)
+	}
+	return sanitized
+}
+
+// sanitizeURL redacts sensitive query parameters and sorts the query for stable matching
+func sanitizeURL(u *url.URL) string {
+	copied := *u
+	copied.User = nil
+	query := copied.Query()
+	for name := range query {
+		if sensitiveName.MatchString(name) {
+			query[name] = []string{redacted}
+		}
+	}
+	copied.RawQuery = query.Encode()
+	return copied.String()
+}
+
+// sanitizeBody redacts sensitive fields of JSON and form encoded bodies
+func sanitizeBody(body []byte, contentType string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var parsed interface{}
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		sanitized, err := json.Marshal(sanitizeValue(parsed))
+		if err == nil {
+			return string(sanitized)
+		}
+	}
+
+	if strings.Contains(contentType, "application/x-www-form-urlencoded") {
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			for name := range values {
+				if sensitiveName.MatchString(name) {
+					values[name] = []string{redacted}
+				}
+			}
+			return values.Encode()
+		}
+	}
+
+	return string(body)
+}
+
+// sanitizeValue redacts sensitive keys of a decoded JSON value recursively
+func sanitizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if sensitiveName.MatchString(key) {
+				if _, isString := v[key].(string); isString {
+					v[key] = redacted
+					continue
+				}
+			}
+			v[key] = sanitizeValue(v[key])
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = sanitizeValue(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,113 @@
+package vcr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/context-space/context-space/backend/internal/shared/audit"
+)
+
+// Mode selects how provider traffic is recorded or replayed
+type Mode string
+
+const (
+	// ModeOff sends traffic to the provider untouched
+	ModeOff Mode = "off"
+	// ModeRecord sends traffic to the provider and stores it in cassettes
+	ModeRecord Mode = "record"
+	// ModeReplay serves traffic from cassettes without reaching the provider
+	ModeReplay Mode = "replay"
+)
+
+// ModeHeader is the request header selecting the mode of a single request
+const ModeHeader = "X-VCR-Mode"
+
+// ParseMode parses a mode name, an empty name means off
+func ParseMode(value string) (Mode, error) {
+	switch Mode(strings.ToLower(strings.TrimSpace(value))) {
+	case "", ModeOff:
+		return ModeOff, nil
+	case ModeRecord:
+		return ModeRecord, nil
+	case ModeReplay:
+		return ModeReplay, nil
+	default:
+		return ModeOff, fmt.Errorf("unknown vcr mode: %s", value)
+	}
+}
+
+// Context keys for record/replay state
+type modeKeyType string
+type requestedModeKeyType string
+type operationKeyType string
+type subjectKeyType string
+
+const (
+	modeKey          modeKeyType          = "vcr.mode"
+	requestedModeKey requestedModeKeyType = "vcr.requestedMode"
+	operationKey     operationKeyType     = "vcr.operation"
+	subjectKey       subjectKeyType       = "vcr.subject"
+)
+
+// operationRef identifies the cassette a request belongs to
+type operationRef struct {
+	subject   string
+	provider  string
+	operation string
+}
+
+// WithMode returns a copy of ctx overriding the configured mode
+func WithMode(ctx context.Context, mode Mode) context.Context {
+	return context.WithValue(ctx, modeKey, mode)
+}
+
+// modeFromContext returns the mode set on ctx, if any
+func modeFromContext(ctx context.Context) (Mode, bool) {
+	mode, ok := ctx.Value(modeKey).(Mode)
+	return mode, ok
+}
+
+// WithRequestedMode returns a copy of ctx carrying the mode a caller asked for with ModeHeader
+// Unlike WithMode it is only honoured once the recorder has checked the caller, see Recorder.ModeFor
+func WithRequestedMode(ctx context.Context, mode Mode) context.Context {
+	return context.WithValue(ctx, requestedModeKey, mode)
+}
+
+// requestedModeFromContext returns the mode a caller asked for, if any
+func requestedModeFromContext(ctx context.Context) (Mode, bool) {
+	mode, ok := ctx.Value(requestedModeKey).(Mode)
+	return mode, ok
+}
+
+// WithSubject returns a copy of ctx tagged with the user or organization whose traffic is recorded,
+// so that the cassettes of one subject are never replayed to another
+func WithSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, subjectKey, subject)
+}
+
+// WithOperation returns a copy of ctx tagged with the provider operation being executed
+func WithOperation(ctx context.Context, providerIdentifier, operationIdentifier string) context.Context {
+	return context.WithValue(ctx, operationKey, operationRef{provider: providerIdentifier, operation: operationIdentifier})
+}
+
+// operationFromContext returns the provider operation ctx is tagged with, falling back to a shared cassette,
+// and the subject of the traffic, falling back to the authenticated actor of the request
+func operationFromContext(ctx context.Context) operationRef {
+	ref, ok := ctx.Value(operationKey).(operationRef)
+	if !ok || ref.provider == "" {
+		ref = operationRef{provider: defaultProvider, operation: defaultOperation}
+	}
+	if ref.operation == "" {
+		ref.operation = defaultOperation
+	}
+
+	ref.subject, _ = ctx.Value(subjectKey).(string)
+	if ref.subject == "" {
+		ref.subject = audit.RequestInfoFromContext(ctx).ActorID
+	}
+	if ref.subject == "" {
+		ref.subject = defaultSubject
+	}
+	return ref
+}
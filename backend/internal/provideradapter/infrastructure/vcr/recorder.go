@@ -0,0 +1,137 @@
+package vcr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/context-space/context-space/backend/internal/shared/audit"
+)
+
+// ErrInteractionNotFound is returned in replay mode when a request has no recording
+var ErrInteractionNotFound = errors.New("vcr: no recorded interaction for request")
+
+// Config configures the recorder
+type Config struct {
+	// Mode is applied to every request without a per-request override
+	Mode Mode
+	// CassetteDir is the root directory of the cassettes
+	CassetteDir string
+	// AllowHeader enables selecting the mode per request through ModeHeader
+	AllowHeader bool
+	// Production restricts per-request selection to HeaderPrincipals and never honours record mode per request
+	Production bool
+	// HeaderPrincipals are the dev/CI users allowed to select the mode per request in production
+	HeaderPrincipals []string
+}
+
+// Recorder stores and serves cassettes for provider operations
+type Recorder struct {
+	config Config
+	mu     sync.Mutex
+}
+
+// NewRecorder creates a new recorder
+func NewRecorder(config Config) *Recorder {
+	if config.Mode == "" {
+		config.Mode = ModeOff
+	}
+	return &Recorder{config: config}
+}
+
+var (
+	defaultRecorder   = NewRecorder(Config{Mode: ModeOff})
+	defaultRecorderMu sync.RWMutex
+)
+
+// SetDefault replaces the recorder used by the transports created in this package
+func SetDefault(recorder *Recorder) {
+	defaultRecorderMu.Lock()
+	defer defaultRecorderMu.Unlock()
+	defaultRecorder = recorder
+}
+
+// Default returns the recorder used by the transports created in this package
+func Default() *Recorder {
+	defaultRecorderMu.RLock()
+	defer defaultRecorderMu.RUnlock()
+	return defaultRecorder
+}
+
+// AllowHeader reports whether the mode may be selected per request
+func (r *Recorder) AllowHeader() bool {
+	return r.config.AllowHeader
+}
+
+// ModeFor returns the mode applying to a request, a per-request override wins over the configured mode
+// The mode requested with ModeHeader is only honoured for callers allowed to select it
+func (r *Recorder) ModeFor(ctx context.Context) Mode {
+	if mode, ok := modeFromContext(ctx); ok {
+		return mode
+	}
+	if mode, ok := requestedModeFromContext(ctx); ok && r.honoursRequestedMode(ctx, mode) {
+		return mode
+	}
+	return r.config.Mode
+}
+
+// AllowsRequestedMode reports whether a mode may ever be selected per request, regardless of the caller
+func (r *Recorder) AllowsRequestedMode(mode Mode) bool {
+	if !r.config.AllowHeader {
+		return false
+	}
+	return !(r.config.Production && mode == ModeRecord)
+}
+
+// honoursRequestedMode reports whether the authenticated actor of ctx may select a mode per request
+// Outside production any authenticated caller may, in production only the configured dev/CI principals
+func (r *Recorder) honoursRequestedMode(ctx context.Context, mode Mode) bool {
+	if !r.AllowsRequestedMode(mode) {
+		return false
+	}
+	actorID := audit.RequestInfoFromContext(ctx).ActorID
+	if actorID == "" {
+		return false
+	}
+	return !r.config.Production || slices.Contains(r.config.HeaderPrincipals, actorID)
+}
+
+// loadCassette reads the HTTP cassette of an operation, an absent cassette is empty
+func (r *Recorder) loadCassette(ref operationRef) (*Cassette, error) {
+	cassette := &Cassette{}
+	if _, err := loadJSON(cassettePath(r.config.CassetteDir, ref, ".json"), cassette); err != nil {
+		return nil, fmt.Errorf("failed to load cassette for %s.%s: %w", ref.provider, ref.operation, err)
+	}
+	return cassette, nil
+}
+
+// lookup returns the recorded interaction of a request
+func (r *Recorder) lookup(ref operationRef, key string) (*Interaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cassette, err := r.loadCassette(ref)
+	if err != nil {
+		return nil, err
+	}
+	interaction := cassette.find(key)
+	if interaction == nil {
+		return nil, fmt.Errorf("%w: %s.%s", ErrInteractionNotFound, ref.provider, ref.operation)
+	}
+	return interaction, nil
+}
+
+// record stores an interaction in the cassette of its operation
+func (r *Recorder) record(ref operationRef, interaction *Interaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cassette, err := r.loadCassette(ref)
+	if err != nil {
+		return err
+	}
+	cassette.put(interaction)
+	return saveJSON(cassettePath(r.config.CassetteDir, ref, ".json"), cassette)
+}
@@ -0,0 +1,150 @@
+package vcr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// StdioCassette holds the recorded JSON-RPC exchanges of one MCP operation
+type StdioCassette struct {
+	Exchanges []*StdioExchange `json:"exchanges"`
+}
+
+// StdioExchange is one sanitized JSON-RPC request and the response of the MCP server
+type StdioExchange struct {
+	Key      string                     `json:"key"`
+	Method   string                     `json:"method"`
+	Params   json.RawMessage            `json:"params,omitempty"`
+	Response *transport.JSONRPCResponse `json:"response"`
+}
+
+// StdioTransport records or replays the JSON-RPC traffic of an MCP server
+// In replay mode the server process is never started
+type StdioTransport struct {
+	inner    transport.Interface
+	recorder *Recorder
+	mode     Mode
+	ref      operationRef
+	cassette *StdioCassette
+}
+
+// WrapStdio wraps an MCP transport according to the mode applying to ctx
+// It returns the inner transport unchanged when recording and replay are off
+func WrapStdio(ctx context.Context, inner transport.Interface) transport.Interface {
+	recorder := Default()
+	mode := recorder.ModeFor(ctx)
+	if mode == ModeOff {
+		return inner
+	}
+	return &StdioTransport{
+		inner:    inner,
+		recorder: recorder,
+		mode:     mode,
+		ref:      operationFromContext(ctx),
+	}
+}
+
+// Start starts the inner transport unless replaying
+func (t *StdioTransport) Start(ctx context.Context) error {
+	cassette := &StdioCassette{}
+	if _, err := loadJSON(t.path(), cassette); err != nil {
+		return fmt.Errorf("failed to load MCP cassette for %s.%s: %w", t.ref.provider, t.ref.operation, err)
+	}
+	t.cassette = cassette
+
+	if t.mode == ModeReplay {
+		return nil
+	}
+	return t.inner.Start(ctx)
+}
+
+// SendRequest records the exchange or serves it from the cassette
+func (t *StdioTransport) SendRequest(ctx context.Context, request transport.JSONRPCRequest) (*transport.JSONRPCResponse, error) {
+	params, err := json.Marshal(request.Params)
+	if err != nil {
+		return nil, fmt.Errorf("vcr: failed to encode MCP params: %w", err)
+	}
+	sanitizedParams := sanitizeBody(params, "application/json")
+	key := requestKey(request.Method, "", sanitizedParams)
+
+	if t.mode == ModeReplay {
+		for _, exchange := range t.cassette.Exchanges {
+			if exchange.Key == key {
+				response := *exchange.Response
+				response.ID = request.ID
+				return &response, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: %s.%s %s", ErrInteractionNotFound, t.ref.provider, t.ref.operation, request.Method)
+	}
+
+	response, err := t.inner.SendRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := *response
+	if len(response.Result) > 0 {
+		recorded.Result = json.RawMessage(sanitizeBody(response.Result, "application/json"))
+	}
+	exchange := &StdioExchange{
+		Key:      key,
+		Method:   request.Method,
+		Params:   json.RawMessage(sanitizedParams),
+		Response: &recorded,
+	}
+	replaced := false
+	for i, existing := range t.cassette.Exchanges {
+		if existing.Key == key {
+			t.cassette.Exchanges[i] = exchange
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		t.cassette.Exchanges = append(t.cassette.Exchanges, exchange)
+	}
+
+	t.recorder.mu.Lock()
+	defer t.recorder.mu.Unlock()
+	if err := saveJSON(t.path(), t.cassette); err != nil {
+		return nil, fmt.Errorf("vcr: failed to record MCP exchange: %w", err)
+	}
+
+	return response, nil
+}
+
+// SendNotification forwards notifications to the server unless replaying
+func (t *StdioTransport) SendNotification(ctx context.Context, notification mcp.JSONRPCNotification) error {
+	if t.mode == ModeReplay {
+		return nil
+	}
+	return t.inner.SendNotification(ctx, notification)
+}
+
+// SetNotificationHandler sets the notification handler of the inner transport
+func (t *StdioTransport) SetNotificationHandler(handler func(notification mcp.JSONRPCNotification)) {
+	t.inner.SetNotificationHandler(handler)
+}
+
+// Close closes the inner transport unless replaying
+func (t *StdioTransport) Close() error {
+	if t.mode == ModeReplay {
+		return nil
+	}
+	return t.inner.Close()
+}
+
+// GetSessionId returns the session ID of the inner transport
+func (t *StdioTransport) GetSessionId() string {
+	return t.inner.GetSessionId()
+}
+
+// path returns the cassette file of the MCP operation
+func (t *StdioTransport) path() string {
+	return cassettePath(t.recorder.config.CassetteDir, t.ref, ".mcp.json")
+}
@@ -0,0 +1,128 @@
+package vcr
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/context-space/context-space/backend/internal/shared/security"
+)
+
+// Transport is an http.RoundTripper recording or replaying requests according to the default recorder
+type Transport struct {
+	// Base sends requests to the provider, when nil a security.NetworkTransport on http.DefaultTransport,
+	// which keeps the requests of restricted contexts away from internal addresses
+	Base http.RoundTripper
+}
+
+// defaultBase sends requests of transports without a base
+var defaultBase http.RoundTripper = &security.NetworkTransport{}
+
+// NewTransport creates a record/replay transport on top of base
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{Base: base}
+}
+
+// NewHTTPClient creates an HTTP client whose traffic goes through a record/replay transport
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: NewTransport(nil),
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder := Default()
+	mode := recorder.ModeFor(req.Context())
+	if mode == ModeOff {
+		return t.base().RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("vcr: failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	recordedRequest := RecordedRequest{
+		Method:  req.Method,
+		URL:     sanitizeURL(req.URL),
+		Headers: sanitizeHeaders(req.Header),
+		Body:    sanitizeBody(body, req.Header.Get("Content-Type")),
+	}
+	key := requestKey(recordedRequest.Method, recordedRequest.URL, recordedRequest.Body)
+	ref := operationFromContext(req.Context())
+
+	if mode == ModeReplay {
+		interaction, err := recorder.lookup(ref, key)
+		if err != nil {
+			return nil, err
+		}
+		return replayResponse(req, interaction), nil
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("vcr: failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &Interaction{
+		Key:     key,
+		Request: recordedRequest,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    sanitizeHeaders(resp.Header),
+			Body:       sanitizeBody(respBody, resp.Header.Get("Content-Type")),
+		},
+	}
+	if err := recorder.record(ref, interaction); err != nil {
+		return nil, fmt.Errorf("vcr: failed to record interaction: %w", err)
+	}
+
+	return resp, nil
+}
+
+// base returns the transport sending requests to the provider
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return defaultBase
+}
+
+// replayResponse builds the response of a recorded interaction
+func replayResponse(req *http.Request, interaction *Interaction) *http.Response {
+	header := make(http.Header, len(interaction.Response.Headers))
+	for name, values := range interaction.Response.Headers {
+		header[name] = append([]string(nil), values...)
+	}
+	header.Del("Content-Length")
+	header.Del("Content-Encoding")
+
+	body := []byte(interaction.Response.Body)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,145 @@
+package vcr
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/context-space/context-space/backend/internal/shared/audit"
+)
+
+func TestTransportRecordReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"login":"octocat","access_token":"gho_secret"}`))
+	}))
+
+	dir := t.TempDir()
+	SetDefault(NewRecorder(Config{Mode: ModeOff, CassetteDir: dir}))
+	defer SetDefault(NewRecorder(Config{Mode: ModeOff}))
+
+	client := NewHTTPClient(0)
+	ctx := WithSubject(WithOperation(context.Background(), "github", "get_me"), "user-1")
+
+	send := func(ctx context.Context) (int, string, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/user?api_key=abc", nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer gho_secret")
+		resp, err := client.Do(req)
+		if err != nil {
+			return 0, "", err
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body), nil
+	}
+
+	t.Run("Record", func(t *testing.T) {
+		status, body, err := send(WithMode(ctx, ModeRecord))
+		if err != nil {
+			t.Fatalf("record request failed: %v", err)
+		}
+		if status != http.StatusOK || !strings.Contains(body, "gho_secret") {
+			t.Errorf("Expected the live response to be returned, got %d %s", status, body)
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, "user-1", "github", "get_me.json"))
+		if err != nil {
+			t.Fatalf("Expected cassette to be written: %v", err)
+		}
+		if strings.Contains(string(data), "gho_secret") || strings.Contains(string(data), "abc") {
+			t.Errorf("Expected secrets to be redacted from the cassette, got: %s", data)
+		}
+	})
+
+	server.Close()
+
+	t.Run("Replay", func(t *testing.T) {
+		status, body, err := send(WithMode(ctx, ModeReplay))
+		if err != nil {
+			t.Fatalf("replay request failed: %v", err)
+		}
+		if status != http.StatusOK || !strings.Contains(body, "octocat") {
+			t.Errorf("Expected the recorded response, got %d %s", status, body)
+		}
+	})
+
+	t.Run("ReplayOtherSubject", func(t *testing.T) {
+		_, _, err := send(WithMode(WithSubject(ctx, "user-2"), ModeReplay))
+		if err == nil {
+			t.Error("Expected the recordings of one subject not to be replayed to another")
+		}
+	})
+
+	t.Run("ReplayMissingOperation", func(t *testing.T) {
+		_, _, err := send(WithMode(WithOperation(context.Background(), "github", "list_repositories"), ModeReplay))
+		if err == nil {
+			t.Error("Expected replay of an unrecorded request to fail")
+		}
+	})
+}
+
+func TestRecorderModeFor(t *testing.T) {
+	actor := func(actorID string) context.Context {
+		return audit.WithRequestInfo(context.Background(), audit.RequestInfo{ActorID: actorID})
+	}
+
+	tests := []struct {
+		name     string
+		config   Config
+		ctx      context.Context
+		expected Mode
+	}{
+		{
+			name:     "HeaderDisabled",
+			config:   Config{Mode: ModeOff},
+			ctx:      WithRequestedMode(actor("user-1"), ModeReplay),
+			expected: ModeOff,
+		},
+		{
+			name:     "NonProductionAnyUser",
+			config:   Config{Mode: ModeOff, AllowHeader: true},
+			ctx:      WithRequestedMode(actor("user-1"), ModeRecord),
+			expected: ModeRecord,
+		},
+		{
+			name:     "Unauthenticated",
+			config:   Config{Mode: ModeOff, AllowHeader: true},
+			ctx:      WithRequestedMode(context.Background(), ModeReplay),
+			expected: ModeOff,
+		},
+		{
+			name:     "ProductionOtherUser",
+			config:   Config{Mode: ModeOff, AllowHeader: true, Production: true, HeaderPrincipals: []string{"ci"}},
+			ctx:      WithRequestedMode(actor("user-1"), ModeReplay),
+			expected: ModeOff,
+		},
+		{
+			name:     "ProductionPrincipalReplay",
+			config:   Config{Mode: ModeOff, AllowHeader: true, Production: true, HeaderPrincipals: []string{"ci"}},
+			ctx:      WithRequestedMode(actor("ci"), ModeReplay),
+			expected: ModeReplay,
+		},
+		{
+			name:     "ProductionPrincipalRecord",
+			config:   Config{Mode: ModeOff, AllowHeader: true, Production: true, HeaderPrincipals: []string{"ci"}},
+			ctx:      WithRequestedMode(actor("ci"), ModeRecord),
+			expected: ModeOff,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if mode := NewRecorder(tt.config).ModeFor(tt.ctx); mode != tt.expected {
+				t.Errorf("Expected mode %s, got %s", tt.expected, mode)
+			}
+		})
+	}
+}
@@ -2,12 +2,18 @@ package contract
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 
 	observability "github.com/context-space/cloud-observability"
+	credDomain "github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
 	"github.com/context-space/context-space/backend/internal/provideradapter/application"
 	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
+	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/vcr"
 	contractAdapter "github.com/context-space/context-space/backend/internal/shared/contract/provideradapter"
+	"github.com/context-space/context-space/backend/internal/shared/security"
 	"go.uber.org/zap"
 	"golang.org/x/oauth2"
 )
@@ -46,7 +52,108 @@ func (w *DomainAdapterWrapper) ExecuteContract(
 	params map[string]interface{},
 	credential interface{},
 ) (interface{}, error) {
-	return w.domainAdapter.Execute(ctx, operationID, params, credential)
+	ctx = w.executionContext(ctx, operationID, credential)
+	result, err := w.domainAdapter.Execute(ctx, operationID, params, credential)
+	if err != nil {
+		return nil, toContractError(err)
+	}
+	return result, nil
+}
+
+// ExecutePagesContract executes an operation following its pages through the pagination the adapter declares
+func (w *DomainAdapterWrapper) ExecutePagesContract(
+	ctx context.Context,
+	operationID string,
+	params map[string]interface{},
+	credential interface{},
+	options contractAdapter.PaginateOptionsDTO,
+) (*contractAdapter.PageResultDTO, error) {
+	var pagination *domain.Pagination
+	if paginator, ok := w.domainAdapter.(domain.Paginator); ok {
+		pagination = paginator.Pagination(operationID)
+	}
+	if pagination == nil {
+		if !options.IsZero() {
+			return nil, fmt.Errorf("%w: %s", contractAdapter.ErrPaginationNotSupported, operationID)
+		}
+		result, err := w.ExecuteContract(ctx, operationID, params, credential)
+		if err != nil {
+			return nil, err
+		}
+		return &contractAdapter.PageResultDTO{Result: result, Pages: 1}, nil
+	}
+
+	ctx = w.executionContext(ctx, operationID, credential)
+	pages, err := pagination.Collect(ctx, func(ctx context.Context, pageParams map[string]interface{}) (interface{}, error) {
+		return w.domainAdapter.Execute(ctx, operationID, pageParams, credential)
+	}, params, domain.PaginateOptions{
+		Cursor:   options.Cursor,
+		MaxItems: options.MaxItems,
+		MaxPages: options.MaxPages,
+	})
+	if err != nil {
+		return nil, toContractError(err)
+	}
+
+	return &contractAdapter.PageResultDTO{
+		Result:     pages.Result,
+		NextCursor: pages.NextCursor,
+		Pages:      pages.Pages,
+	}, nil
+}
+
+// executionContext tags the context so recorded traffic lands in the cassette of the operation and credential owner,
+// and reaches the instance of the provider the credential was issued by
+func (w *DomainAdapterWrapper) executionContext(ctx context.Context, operationID string, credential interface{}) context.Context {
+	ctx = vcr.WithOperation(ctx, w.domainAdapter.GetProviderAdapterInfo().Identifier, operationID)
+	if ownerID := credentialOwnerID(credential); ownerID != "" {
+		ctx = vcr.WithSubject(ctx, ownerID)
+	}
+	return withCredentialOAuthApp(ctx, credential)
+}
+
+// credentialOwnerID returns the user or organization owning a credential, empty for an unknown credential
+func credentialOwnerID(credential interface{}) string {
+	var base *credDomain.Credential
+	switch c := credential.(type) {
+	case *credDomain.OAuthCredential:
+		if c != nil {
+			base = c.Credential
+		}
+	case *credDomain.APIKeyCredential:
+		if c != nil {
+			base = c.Credential
+		}
+	case *credDomain.AppInstallationCredential:
+		if c != nil {
+			base = c.Credential
+		}
+	case *credDomain.BasicAuthCredential:
+		if c != nil {
+			base = c.Credential
+		}
+	case *credDomain.NoneCredential:
+		if c != nil {
+			base = c.Credential
+		}
+	}
+	if base == nil {
+		return ""
+	}
+	return base.OwnerID()
+}
+
+// toContractError exposes missing permissions as a contract error so callers can ask the user for consent
+func toContractError(err error) error {
+	var adapterErr *domain.AdapterError
+	if errors.As(err, &adapterErr) && adapterErr.ErrorCode == domain.ErrInsufficientScope {
+		return &contractAdapter.InsufficientScopeError{
+			ProviderIdentifier:  adapterErr.ProviderIdentifier,
+			OperationIdentifier: adapterErr.OperationIdentifier,
+			MissingPermissions:  adapterErr.MissingPermissions,
+		}
+	}
+	return err
 }
 
 // GetAdapterInfo converts domain adapter info to contract DTO
@@ -96,7 +203,7 @@ func (f *AdapterContractFacade) GenerateOAuthURLContract(ctx context.Context, pr
 		return "", fmt.Errorf("failed to get OAuth adapter: %w", err)
 	}
 
-	return adapter.GenerateOAuthURL(ctx, redirectURL, state, codeChallenge, scopes)
+	return adapter.GenerateOAuthURL(withOAuthAppOverride(ctx), redirectURL, state, codeChallenge, scopes)
 }
 
 func (f *AdapterContractFacade) ExchangeCodeForTokenContract(ctx context.Context, providerIdentifier, code, redirectURL, codeVerifier string) (*oauth2.Token, error) {
@@ -105,7 +212,7 @@ func (f *AdapterContractFacade) ExchangeCodeForTokenContract(ctx context.Context
 		return nil, fmt.Errorf("failed to get OAuth adapter: %w", err)
 	}
 
-	return adapter.ExchangeCodeForTokens(ctx, code, redirectURL, codeVerifier)
+	return adapter.ExchangeCodeForTokens(withOAuthAppOverride(ctx), code, redirectURL, codeVerifier)
 }
 
 func (f *AdapterContractFacade) ShouldRefreshTokenContract(providerIdentifier string, token *oauth2.Token) (bool, error) {
@@ -123,7 +230,21 @@ func (f *AdapterContractFacade) RefreshTokenContract(ctx context.Context, provid
 		return nil, fmt.Errorf("failed to get OAuth adapter: %w", err)
 	}
 
-	return adapter.RefreshOAuthToken(ctx, oldToken)
+	token, err := adapter.RefreshOAuthToken(withOAuthAppOverride(ctx), oldToken)
+	if err != nil && isInvalidGrant(err) {
+		return nil, fmt.Errorf("%w: %v", contractAdapter.ErrCredentialRejected, err)
+	}
+	return token, err
+}
+
+// isInvalidGrant reports whether the provider refused to refresh because the grant was revoked or expired,
+// most adapters flatten the oauth2 error into the message of their AdapterError so the message is checked as well
+func isInvalidGrant(err error) bool {
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		return retrieveErr.ErrorCode == "invalid_grant"
+	}
+	return strings.Contains(err.Error(), `oauth2: "invalid_grant"`)
 }
 
 func (f *AdapterContractFacade) GetScopesFromPermissionsContract(ctx context.Context, providerIdentifier string, permissions []string) ([]string, error) {
@@ -143,3 +264,84 @@ func (f *AdapterContractFacade) GetPermissionIdentifiersFromScopesContract(ctx c
 
 	return adapter.GetPermissionIdentifiersFromScopes(scopes)
 }
+
+func (f *AdapterContractFacade) RevokeTokenContract(ctx context.Context, providerIdentifier string, token *oauth2.Token) error {
+	adapter, err := f.adapterFactory.GetOAuthAdapter(providerIdentifier)
+	if err != nil {
+		return fmt.Errorf("failed to get OAuth adapter: %w", err)
+	}
+
+	revoker, ok := adapter.(domain.OAuthTokenRevoker)
+	if !ok {
+		return contractAdapter.ErrTokenRevocationNotSupported
+	}
+
+	return revoker.RevokeOAuthToken(withOAuthAppOverride(ctx), token)
+}
+
+func (f *AdapterContractFacade) VerifyCredentialContract(ctx context.Context, providerIdentifier string, credential interface{}) error {
+	adapter, err := f.adapterFactory.GetAdapter(providerIdentifier)
+	if err != nil {
+		return fmt.Errorf("failed to get adapter: %w", err)
+	}
+
+	verifier, ok := adapter.(domain.CredentialVerifier)
+	if !ok {
+		return contractAdapter.ErrCredentialVerificationNotSupported
+	}
+
+	err = verifier.VerifyCredential(withCredentialOAuthApp(ctx, credential), credential)
+	var adapterErr *domain.AdapterError
+	if errors.As(err, &adapterErr) && adapterErr.ErrorCode == domain.ErrCredentialError {
+		return fmt.Errorf("%w: %s", contractAdapter.ErrCredentialRejected, adapterErr.ErrorMessage)
+	}
+	return err
+}
+
+// withOAuthAppOverride converts the OAuth app of the context, if any, into the override used by the adapters
+func withOAuthAppOverride(ctx context.Context) context.Context {
+	app := contractAdapter.OAuthAppFromContext(ctx)
+	if app == nil {
+		return ctx
+	}
+
+	return withOverride(ctx, &domain.OAuthAppOverride{
+		ClientID:     app.ClientID,
+		ClientSecret: app.ClientSecret,
+		AuthURL:      app.AuthURL,
+		TokenURL:     app.TokenURL,
+		APIBaseURL:   app.APIBaseURL,
+	})
+}
+
+// withCredentialOAuthApp sets the override of the OAuth app an OAuth credential was issued through, if any,
+// so that its API calls reach the same instance of the provider
+func withCredentialOAuthApp(ctx context.Context, credential interface{}) context.Context {
+	oauthCred, ok := credential.(*credDomain.OAuthCredential)
+	if !ok || oauthCred == nil || oauthCred.OAuthApp == nil {
+		return ctx
+	}
+
+	app := oauthCred.OAuthApp
+	return withOverride(ctx, &domain.OAuthAppOverride{
+		ClientID:     app.ClientID,
+		ClientSecret: app.ClientSecret,
+		AuthURL:      app.AuthURL,
+		TokenURL:     app.TokenURL,
+		APIBaseURL:   app.APIBaseURL,
+	})
+}
+
+// overrideHTTPClient sends the token requests of OAuth apps replacing the endpoints of the provider
+var overrideHTTPClient = &http.Client{Transport: &security.NetworkTransport{}}
+
+// withOverride sets an override in the context. Endpoints replaced by users may not reach internal addresses,
+// so the requests of the context are restricted, including the token requests of the oauth2 package.
+func withOverride(ctx context.Context, override *domain.OAuthAppOverride) context.Context {
+	ctx = domain.WithOAuthAppOverride(ctx, override)
+	if !override.HasEndpoints() {
+		return ctx
+	}
+	ctx = security.WithRestrictedNetwork(ctx)
+	return context.WithValue(ctx, oauth2.HTTPClient, overrideHTTPClient)
+}
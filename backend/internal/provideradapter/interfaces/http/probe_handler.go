@@ -0,0 +1,153 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/context-space/context-space/backend/internal/provideradapter/application"
+	"github.com/context-space/context-space/backend/internal/provideradapter/domain"
+	"github.com/context-space/context-space/backend/internal/shared/apierrors"
+	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+)
+
+// ProbeHandler handles HTTP requests for provider health status
+type ProbeHandler struct {
+	probeService *application.ProbeService
+}
+
+// NewProbeHandler creates a new probe handler
+func NewProbeHandler(probeService *application.ProbeService) *ProbeHandler {
+	return &ProbeHandler{
+		probeService: probeService,
+	}
+}
+
+// RegisterRoutes registers the routes for this handler
+func (h *ProbeHandler) RegisterRoutes(router *gin.RouterGroup) {
+	providers := router.Group("/providers")
+	{
+		providers.GET("/status", h.ListProviderStatuses)
+		providers.GET("/:identifier/status", h.GetProviderStatus)
+	}
+}
+
+// ProbeResultResponse represents one synthetic probe of a provider
+type ProbeResultResponse struct {
+	Operation string `json:"operation"`
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+	CheckedAt int64  `json:"checked_at"`
+}
+
+// ProviderStatusResponse represents the health of a provider derived from its probes
+type ProviderStatusResponse struct {
+	ProviderIdentifier string                `json:"provider_identifier"`
+	Status             string                `json:"status"`
+	LastCheckedAt      int64                 `json:"last_checked_at,omitempty"`
+	LastError          string                `json:"last_error,omitempty"`
+	LatencyMs          int64                 `json:"latency_ms"`
+	Uptime             *float64              `json:"uptime,omitempty"`
+	History            []ProbeResultResponse `json:"history,omitempty"`
+}
+
+// ProviderStatusListResponse represents the aggregated health of all providers
+type ProviderStatusListResponse struct {
+	Status    string                   `json:"status"` // up if no probed provider is down, degraded otherwise
+	Up        int                      `json:"up"`
+	Down      int                      `json:"down"`
+	Unknown   int                      `json:"unknown"`
+	Providers []ProviderStatusResponse `json:"providers"`
+}
+
+// GetProviderStatus godoc
+// @Summary Get provider status
+// @Description Returns the current status of a provider and its recent synthetic probe history
+// @Tags providers
+// @Produce json
+// @Param identifier path string true "Provider Identifier"
+// @Param limit query int false "Number of probe results to return" default(50)
+// @Success 200 {object} httpapi.Response{data=ProviderStatusResponse} "Success response with provider status"
+// @Failure 404 {object} httpapi.SwaggerErrorResponse "Not found error response"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /providers/{identifier}/status [get]
+func (h *ProbeHandler) GetProviderStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	identifier := c.Param("identifier")
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(application.DefaultProbeHistoryLimit)))
+
+	health, err := h.probeService.GetProviderHealth(ctx, identifier, limit)
+	if err != nil {
+		if apiErr, ok := err.(*apierrors.APIError); ok && apiErr.Code == apierrors.ErrNotFound {
+			httpapi.NotFound(c, "Provider not found")
+		} else {
+			httpapi.InternalServerError(c, "Failed to get provider status")
+		}
+		return
+	}
+
+	response := mapProviderHealthToResponse(health)
+	uptime := health.Uptime
+	response.Uptime = &uptime
+	for _, result := range health.History {
+		response.History = append(response.History, ProbeResultResponse{
+			Operation: result.Operation,
+			Status:    string(result.Status),
+			LatencyMs: result.LatencyMs,
+			Error:     result.Error,
+			CheckedAt: result.CheckedAt.Unix(),
+		})
+	}
+
+	httpapi.OK(c, response, "Provider status retrieved successfully")
+}
+
+// ListProviderStatuses godoc
+// @Summary List provider statuses
+// @Description Returns the latest synthetic probe status of every provider, so callers can skip degraded tools
+// @Tags providers
+// @Produce json
+// @Success 200 {object} httpapi.Response{data=ProviderStatusListResponse} "Success response with provider statuses"
+// @Failure 500 {object} httpapi.SwaggerErrorResponse "Internal server error response"
+// @Router /providers/status [get]
+func (h *ProbeHandler) ListProviderStatuses(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	healths, err := h.probeService.ListProviderHealth(ctx)
+	if err != nil {
+		httpapi.InternalServerError(c, "Failed to list provider statuses")
+		return
+	}
+
+	response := ProviderStatusListResponse{Status: string(domain.ProbeStatusUp), Providers: make([]ProviderStatusResponse, 0, len(healths))}
+	for _, health := range healths {
+		switch health.Status {
+		case domain.ProbeStatusUp:
+			response.Up++
+		case domain.ProbeStatusDown:
+			response.Down++
+			response.Status = "degraded"
+		default:
+			response.Unknown++
+		}
+		response.Providers = append(response.Providers, mapProviderHealthToResponse(health))
+	}
+
+	httpapi.OK(c, response, "Provider statuses retrieved successfully")
+}
+
+// mapProviderHealthToResponse maps a provider health summary to a response without history
+func mapProviderHealthToResponse(health *application.ProviderHealth) ProviderStatusResponse {
+	response := ProviderStatusResponse{
+		ProviderIdentifier: health.ProviderIdentifier,
+		Status:             string(health.Status),
+		LastError:          health.LastError,
+		LatencyMs:          health.LatencyMs,
+	}
+	if health.LastCheckedAt != nil {
+		response.LastCheckedAt = health.LastCheckedAt.Unix()
+	}
+	return response
+}
@@ -0,0 +1,35 @@
+package http
+
+import (
+	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/vcr"
+	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+	"github.com/gin-gonic/gin"
+)
+
+// RecordReplayMiddleware lets a request ask for the record/replay mode of its provider traffic
+// The header is ignored unless the recorder allows per-request selection, and the requested mode is only
+// applied once the recorder has checked the authenticated caller
+func RecordReplayMiddleware(recorder *vcr.Recorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		headerValue := c.GetHeader(vcr.ModeHeader)
+		if headerValue == "" || !recorder.AllowHeader() {
+			c.Next()
+			return
+		}
+
+		mode, err := vcr.ParseMode(headerValue)
+		if err != nil {
+			httpapi.BadRequest(c, err.Error())
+			c.Abort()
+			return
+		}
+		if !recorder.AllowsRequestedMode(mode) {
+			httpapi.Forbidden(c, "This record/replay mode cannot be selected per request")
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(vcr.WithRequestedMode(c.Request.Context(), mode))
+		c.Next()
+	}
+}
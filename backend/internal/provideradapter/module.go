@@ -3,6 +3,9 @@ package provideradapter
 import (
 	"context"
 	"fmt"
+	"os/exec"
+	"path/filepath"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -15,11 +18,16 @@ import (
 	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/persistence"
 	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/registry"
 	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/templates"
+	"github.com/context-space/context-space/backend/internal/provideradapter/infrastructure/vcr"
 	"github.com/context-space/context-space/backend/internal/provideradapter/interfaces/contract"
 	"github.com/context-space/context-space/backend/internal/provideradapter/interfaces/http"
 	providercore "github.com/context-space/context-space/backend/internal/providercore/application"
+	"github.com/context-space/context-space/backend/internal/shared/config"
 	contractAdapter "github.com/context-space/context-space/backend/internal/shared/contract/provideradapter"
+	"github.com/context-space/context-space/backend/internal/shared/cron"
+	"github.com/context-space/context-space/backend/internal/shared/health"
 	"github.com/context-space/context-space/backend/internal/shared/infrastructure/database"
+	"github.com/context-space/context-space/backend/internal/shared/utils"
 	translation "github.com/context-space/context-space/backend/internal/translation/application"
 )
 
@@ -29,7 +37,11 @@ type Module struct {
 	providerLoaderService  *application.ProviderLoaderService
 	providerAdapterService *application.ProviderAdapterService
 	adapterHandler         *http.AdapterHandler
+	probeService           *application.ProbeService
+	probeHandler           *http.ProbeHandler
+	probeSchedule          string
 	adapterContractFacade  contractAdapter.ProviderAdapterContract
+	recorder               *vcr.Recorder
 	obs                    *observability.ObservabilityProvider
 }
 
@@ -39,7 +51,22 @@ func NewModule(
 	observabilityProvider *observability.ObservabilityProvider,
 	providerCoreService *providercore.ProviderService,
 	providerTranslationService *translation.ProviderTranslationService,
+	cfg *config.Config,
 ) (*Module, error) {
+	// Configure record/replay of provider traffic, used by the adapters' HTTP clients and MCP transports
+	vcrMode, err := vcr.ParseMode(cfg.VCR.Mode)
+	if err != nil {
+		return nil, err
+	}
+	recorder := vcr.NewRecorder(vcr.Config{
+		Mode:             vcrMode,
+		CassetteDir:      cfg.VCR.CassetteDir,
+		AllowHeader:      cfg.VCR.AllowHeader,
+		Production:       cfg.Environment == "production",
+		HeaderPrincipals: cfg.VCR.HeaderPrincipals,
+	})
+	vcr.SetDefault(recorder)
+
 	// Initialize adapter factory
 	adapterFactory := application.NewAdapterFactory()
 
@@ -80,6 +107,26 @@ func NewModule(
 	// via their init() functions
 	templates.Init()
 
+	// Initialize synthetic provider probes
+	probeTargets := make(map[string]domain.ProbeTarget, len(cfg.HealthProbe.Providers))
+	for identifier, target := range cfg.HealthProbe.Providers {
+		probeTargets[identifier] = domain.ProbeTarget{Operation: target.Operation, Parameters: target.Parameters}
+	}
+	probeService := application.NewProbeService(
+		adapterFactory,
+		persistence.NewProbeResultRepository(db, observabilityProvider),
+		application.ProbeConfig{
+			Timeout:   time.Duration(cfg.HealthProbe.TimeoutSeconds) * time.Second,
+			Retention: time.Duration(cfg.HealthProbe.RetentionHours) * time.Hour,
+			Targets:   probeTargets,
+		},
+		observabilityProvider,
+	)
+	probeSchedule := ""
+	if cfg.HealthProbe.Enabled {
+		probeSchedule = cfg.HealthProbe.Schedule
+	}
+
 	// Initialize HTTP handlers
 	adapterHandler := http.NewAdapterHandler(
 		adapterFactory,
@@ -92,7 +139,11 @@ func NewModule(
 		providerLoaderService:  providerLoaderService,
 		providerAdapterService: providerAdapterService,
 		adapterHandler:         adapterHandler,
+		probeService:           probeService,
+		probeHandler:           http.NewProbeHandler(probeService),
+		probeSchedule:          probeSchedule,
 		adapterContractFacade:  adapterContractFacade,
+		recorder:               recorder,
 		obs:                    observabilityProvider,
 	}, nil
 }
@@ -101,6 +152,60 @@ func NewModule(
 func (m *Module) RegisterRoutes(router *gin.RouterGroup, requireAuth gin.HandlerFunc) {
 	// Now we can directly register with the router group
 	m.adapterHandler.RegisterRoutes(router, requireAuth)
+	m.probeHandler.RegisterRoutes(router)
+}
+
+// CronTaskGroups returns the scheduled task groups of the provider adapter module
+func (m *Module) CronTaskGroups() []*cron.TaskGroup {
+	if m.probeSchedule == "" {
+		return nil
+	}
+
+	return []*cron.TaskGroup{
+		{
+			Name:     "provider_probes",
+			Schedule: m.probeSchedule,
+			Tasks: []cron.CronTask{
+				{
+					Name:    "run_provider_probes",
+					Handler: m.probeService.RunProbes,
+				},
+			},
+		},
+		{
+			Name:     "prune_provider_probes",
+			Schedule: "0 30 3 * * *", // Execute daily at 03:30 UTC (6-field cron expression)
+			Tasks: []cron.CronTask{
+				{
+					Name:    "prune_probe_history",
+					Handler: m.probeService.PruneHistory,
+				},
+			},
+		},
+	}
+}
+
+// ReadinessChecks returns a check for every executable the loaded adapters need, such as npx or uvx
+func (m *Module) ReadinessChecks() []health.Check {
+	var checks []health.Check
+	for _, command := range m.probeService.RequiredCommands() {
+		command := command
+		checks = append(checks, health.Check{
+			Name: utils.StringsBuilder("command_", filepath.Base(command)),
+			Run: func(ctx context.Context) error {
+				if _, err := exec.LookPath(command); err != nil {
+					return fmt.Errorf("%s is required by MCP providers: %w", command, err)
+				}
+				return nil
+			},
+		})
+	}
+	return checks
+}
+
+// RecordReplayMiddleware returns the middleware selecting the record/replay mode per request
+func (m *Module) RecordReplayMiddleware() gin.HandlerFunc {
+	return http.RecordReplayMiddleware(m.recorder)
 }
 
 // Initialize loads all provider adapters from configuration
@@ -19,6 +19,7 @@ func ProviderToDTONoTranslation(provider *domain.Provider, needOperations bool)
 		IconURL:     provider.IconURL,
 		Categories:  provider.Categories,
 		Embedding:   provider.Embedding,
+		Maintenance: MaintenanceToDTO(provider.Maintenance),
 	}
 
 	if needOperations {
@@ -43,6 +44,7 @@ func ProviderToDTO(provider *domain.Provider, translation *contractTranslation.P
 		IconURL:     provider.IconURL,
 		Categories:  provider.Categories,
 		Embedding:   provider.Embedding,
+		Maintenance: MaintenanceToDTO(provider.Maintenance),
 	}
 
 	if needOperations {
@@ -89,6 +91,8 @@ func OperationToDTO(operation *domain.Operation) contractProvider.OperationDTO {
 		Category:            operation.Category,
 		RequiredPermissions: operation.RequiredPermissions,
 		Parameters:          make([]contractProvider.ParameterDTO, 0, len(operation.Parameters)),
+		RiskLevel:           string(operation.RiskLevel),
+		CompactFields:       operation.CompactFields,
 	}
 	for _, param := range operation.Parameters {
 		operationDTO.Parameters = append(operationDTO.Parameters, contractProvider.ParameterDTO{
@@ -102,3 +106,19 @@ func OperationToDTO(operation *domain.Operation) contractProvider.OperationDTO {
 	}
 	return operationDTO
 }
+
+// MaintenanceToDTO converts a maintenance window to its contract representation
+func MaintenanceToDTO(window *domain.MaintenanceWindow) *contractProvider.MaintenanceDTO {
+	if window == nil {
+		return nil
+	}
+
+	dto := &contractProvider.MaintenanceDTO{Message: window.Message}
+	if window.StartsAt != nil {
+		dto.StartsAt = window.StartsAt.Unix()
+	}
+	if window.EndsAt != nil {
+		dto.EndsAt = window.EndsAt.Unix()
+	}
+	return dto
+}
@@ -17,6 +17,8 @@ type Operation struct {
 	Category            string
 	RequiredPermissions []types.Permission
 	Parameters          []Parameter
+	RiskLevel           types.OperationRiskLevel
+	CompactFields       []string  // Default response projection for clients asking for compact responses
 	Embedding           []float64 // Vector embedding for semantic search
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
@@ -34,7 +36,17 @@ func NewOperation(identifier, providerID, name, description, category string, re
 		Category:            category,
 		RequiredPermissions: requiredPermissions,
 		Parameters:          parameters,
+		RiskLevel:           types.RiskLevelRead,
 		CreatedAt:           time.Now(),
 		UpdatedAt:           time.Now(),
 	}
 }
+
+// SetRiskLevel sets the risk level of the operation, falling back to read for unknown values
+func (o *Operation) SetRiskLevel(riskLevel types.OperationRiskLevel) {
+	if !riskLevel.IsValid() {
+		riskLevel = types.RiskLevelRead
+	}
+	o.RiskLevel = riskLevel
+	o.UpdatedAt = time.Now()
+}
@@ -27,6 +27,27 @@ type TranslatedProvider struct {
 	language    language.Tag
 }
 
+// MaintenanceWindow describes a planned or ongoing provider outage announced in the catalog
+type MaintenanceWindow struct {
+	Message  string     `json:"message,omitempty"`
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+}
+
+// IsActiveAt returns true if the window covers the given time, open ended bounds always match
+func (w *MaintenanceWindow) IsActiveAt(now time.Time) bool {
+	if w == nil {
+		return false
+	}
+	if w.StartsAt != nil && now.Before(*w.StartsAt) {
+		return false
+	}
+	if w.EndsAt != nil && !now.Before(*w.EndsAt) {
+		return false
+	}
+	return true
+}
+
 // Provider represents a third-party provider integration
 type Provider struct {
 	ID           string                 `json:"id"`
@@ -39,6 +60,7 @@ type Provider struct {
 	Categories   []string               `json:"categories"`
 	Tags         []string               `json:"tags"`
 	Operations   []Operation            `json:"operations"`
+	Maintenance  *MaintenanceWindow     `json:"maintenance,omitempty"`
 	Embedding    []float64              `json:"-"` // Vector embedding for semantic search
 	CreatedAt    time.Time              `json:"created_at"`
 	UpdatedAt    time.Time              `json:"updated_at"`
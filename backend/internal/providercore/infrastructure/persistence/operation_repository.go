@@ -206,14 +206,22 @@ func (r *OperationRepository) Delete(ctx context.Context, id string) error {
 // mapToDomain maps an operation model to a domain operation
 func (r *OperationRepository) mapToDomain(model *OperationModel) (*domain.Operation, error) {
 	var jsonAttributes struct {
-		RequiredPermissions []types.Permission `json:"required_permissions"`
-		Parameters          []domain.Parameter `json:"parameters"`
+		RequiredPermissions []types.Permission       `json:"required_permissions"`
+		Parameters          []domain.Parameter       `json:"parameters"`
+		RiskLevel           types.OperationRiskLevel `json:"risk_level"`
+		CompactFields       []string                 `json:"compact_fields"`
 	}
 
 	if err := sonic.Unmarshal(model.JSONAttributes, &jsonAttributes); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal operation info metadata: %w", err)
 	}
 
+	// Operations loaded before risk levels existed are treated as read-only
+	riskLevel := jsonAttributes.RiskLevel
+	if !riskLevel.IsValid() {
+		riskLevel = types.RiskLevelRead
+	}
+
 	return &domain.Operation{
 		ID:                  model.ID,
 		Identifier:          model.Identifier,
@@ -223,6 +231,8 @@ func (r *OperationRepository) mapToDomain(model *OperationModel) (*domain.Operat
 		Category:            model.Category,
 		RequiredPermissions: jsonAttributes.RequiredPermissions,
 		Parameters:          jsonAttributes.Parameters,
+		RiskLevel:           riskLevel,
+		CompactFields:       jsonAttributes.CompactFields,
 		CreatedAt:           model.CreatedAt,
 		UpdatedAt:           model.UpdatedAt,
 		DeletedAt:           parseGormDeletedAt(model.DeletedAt),
@@ -232,11 +242,15 @@ func (r *OperationRepository) mapToDomain(model *OperationModel) (*domain.Operat
 // mapToModel maps a domain operation to an operation model
 func (r *OperationRepository) mapToModel(operation *domain.Operation) (*OperationModel, error) {
 	jsonAttributes := struct {
-		RequiredPermissions []types.Permission `json:"required_permissions"`
-		Parameters          []domain.Parameter `json:"parameters"`
+		RequiredPermissions []types.Permission       `json:"required_permissions"`
+		Parameters          []domain.Parameter       `json:"parameters"`
+		RiskLevel           types.OperationRiskLevel `json:"risk_level"`
+		CompactFields       []string                 `json:"compact_fields,omitempty"`
 	}{
 		RequiredPermissions: operation.RequiredPermissions,
 		Parameters:          operation.Parameters,
+		RiskLevel:           operation.RiskLevel,
+		CompactFields:       operation.CompactFields,
 	}
 
 	jsonAttributesJSON, err := sonic.Marshal(jsonAttributes)
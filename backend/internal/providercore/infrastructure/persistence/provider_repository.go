@@ -263,8 +263,9 @@ func (r *ProviderRepository) loadOperations(ctx context.Context, providerID stri
 // mapToDomain maps a provider model to a domain provider
 func (r *ProviderRepository) mapToDomain(model *ProviderModel) (*domain.Provider, error) {
 	var jsonAttributes struct {
-		Categories []string `json:"categories"`
-		Tags       []string `json:"tags"`
+		Categories  []string                  `json:"categories"`
+		Tags        []string                  `json:"tags"`
+		Maintenance *domain.MaintenanceWindow `json:"maintenance,omitempty"`
 	}
 
 	if err := sonic.Unmarshal(model.JSONAttributes, &jsonAttributes); err != nil {
@@ -281,6 +282,7 @@ func (r *ProviderRepository) mapToDomain(model *ProviderModel) (*domain.Provider
 		IconURL:     model.IconURL,
 		Categories:  jsonAttributes.Categories,
 		Tags:        jsonAttributes.Tags,
+		Maintenance: jsonAttributes.Maintenance,
 		CreatedAt:   model.CreatedAt,
 		UpdatedAt:   model.UpdatedAt,
 		DeletedAt:   parseGormDeletedAt(model.DeletedAt),
@@ -290,9 +292,11 @@ func (r *ProviderRepository) mapToDomain(model *ProviderModel) (*domain.Provider
 // mapToModel maps a domain provider to a provider model
 func (r *ProviderRepository) mapToModel(provider *domain.Provider) (*ProviderModel, error) {
 	jsonAttributes := struct {
-		Categories []string `json:"categories"`
+		Categories  []string                  `json:"categories"`
+		Maintenance *domain.MaintenanceWindow `json:"maintenance,omitempty"`
 	}{
-		Categories: provider.Categories,
+		Categories:  provider.Categories,
+		Maintenance: provider.Maintenance,
 	}
 
 	jsonAttributesJSON, err := sonic.Marshal(jsonAttributes)
@@ -329,8 +333,9 @@ func (r *ProviderRepository) SyncTagsToProvider(ctx context.Context, providerIde
 
 	// Parse existing json_attributes
 	var jsonAttributes struct {
-		Categories []string `json:"categories"`
-		Tags       []string `json:"tags"`
+		Categories  []string                  `json:"categories"`
+		Tags        []string                  `json:"tags"`
+		Maintenance *domain.MaintenanceWindow `json:"maintenance,omitempty"`
 	}
 
 	if err := sonic.Unmarshal(model.JSONAttributes, &jsonAttributes); err != nil {
@@ -0,0 +1,34 @@
+package audit
+
+import "context"
+
+// requestInfoKeyType is the context key type for the request info
+type requestInfoKeyType string
+
+// requestInfoKey is the context key under which the request info is stored
+const requestInfoKey requestInfoKeyType = "audit.requestInfo"
+
+// RequestInfo describes the request a security-relevant action is performed in
+type RequestInfo struct {
+	// ActorID is the ID of the authenticated user, empty for unauthenticated requests
+	ActorID string
+	// AuthType is how the actor authenticated, the identity provider name or api_key
+	AuthType string
+	// APIKeyID is the ID of the API key that authenticated the request, empty for session requests
+	APIKeyID  string
+	IPAddress string
+	UserAgent string
+}
+
+// WithRequestInfo returns a copy of ctx carrying the request info
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey, info)
+}
+
+// RequestInfoFromContext returns the request info stored in ctx, or an empty one
+func RequestInfoFromContext(ctx context.Context) RequestInfo {
+	if info, ok := ctx.Value(requestInfoKey).(RequestInfo); ok {
+		return info
+	}
+	return RequestInfo{}
+}
@@ -2,26 +2,36 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 )
 
 // Config represents the application configuration
 type Config struct {
-	Environment   string              `json:"environment"`
-	Server        ServerConfig        `json:"server"`
-	Supabase      SupabaseConfig      `json:"supabase"`
-	Database      DatabaseConfig      `json:"database"`
-	Redis         RedisConfig         `json:"redis"`
-	Vault         VaultConfig         `json:"vault"`
-	Logging       LoggingConfig       `json:"logging"`
-	Provider      ProviderConfig      `json:"provider"`
-	Observability ObservabilityConfig `json:"observability"`
-	Security      SecurityConfig      `json:"security"`
-	OpenAI        OpenAIConfig        `json:"openai"`
-	Discovery     DiscoveryConfig     `json:"discovery"`
-	GRPC          GRPCConfig          `json:"grpc"`
+	Environment            string                       `json:"environment"`
+	Server                 ServerConfig                 `json:"server"`
+	Auth                   AuthConfig                   `json:"auth"`
+	Supabase               SupabaseConfig               `json:"supabase"`
+	Database               DatabaseConfig               `json:"database"`
+	Redis                  RedisConfig                  `json:"redis"`
+	Vault                  VaultConfig                  `json:"vault"`
+	Logging                LoggingConfig                `json:"logging"`
+	Provider               ProviderConfig               `json:"provider"`
+	Observability          ObservabilityConfig          `json:"observability"`
+	Security               SecurityConfig               `json:"security"`
+	OpenAI                 OpenAIConfig                 `json:"openai"`
+	Discovery              DiscoveryConfig              `json:"discovery"`
+	GRPC                   GRPCConfig                   `json:"grpc"`
+	Quota                  QuotaConfig                  `json:"quota"`
+	VCR                    VCRConfig                    `json:"vcr"`
+	HealthProbe            HealthProbeConfig            `json:"health_probe"`
+	KeyRotation            KeyRotationConfig            `json:"key_rotation"`
+	CredentialVerification CredentialVerificationConfig `json:"credential_verification"`
+	Webhook                WebhookConfig                `json:"webhook"`
+	ScheduledInvocation    ScheduledInvocationConfig    `json:"scheduled_invocation"`
 }
 
 // ServerConfig holds the server specific configuration
@@ -36,6 +46,30 @@ type SupabaseConfig struct {
 	JWTSecret   string `json:"jwt_secret"`
 }
 
+// AuthConfig selects the identity provider that validates bearer tokens
+type AuthConfig struct {
+	Provider string     `json:"provider"` // supabase or oidc
+	OIDC     OIDCConfig `json:"oidc"`
+}
+
+// OIDCConfig holds the configuration of a generic OpenID Connect identity provider
+type OIDCConfig struct {
+	IssuerURL         string           `json:"issuer_url"`
+	Audiences         []string         `json:"audiences"`          // Accepted aud values, at least one is required
+	AllowedAlgorithms []string         `json:"allowed_algorithms"` // Defaults to the common asymmetric algorithms
+	JWKSCacheSeconds  int              `json:"jwks_cache_seconds"`
+	ClockSkewSeconds  int              `json:"clock_skew_seconds"`
+	Claims            OIDCClaimMapping `json:"claims"`
+}
+
+// OIDCClaimMapping names the token claims users are provisioned from, nested claims use dot-separated paths
+type OIDCClaimMapping struct {
+	Subject       string `json:"subject"`
+	Email         string `json:"email"`
+	EmailVerified string `json:"email_verified"`
+	Anonymous     string `json:"anonymous"`
+}
+
 // DatabaseConfig holds the database configuration
 type DatabaseConfig struct {
 	Host              string `json:"host"`
@@ -66,8 +100,17 @@ type VaultRegionalConfig struct {
 
 // VaultConfig defines configuration for Vault service
 type VaultConfig struct {
+	Backend       string                          `json:"backend"` // vault or envelope
 	Regions       map[string]*VaultRegionalConfig `json:"regions"`
 	DefaultRegion string                          `json:"default_region"`
+	Envelope      EnvelopeConfig                  `json:"envelope"`
+}
+
+// EnvelopeConfig holds the configuration of the in-process envelope encryption backend
+type EnvelopeConfig struct {
+	KeyRingFile      string            `json:"key_ring_file"`       // JSON key ring, the environment is used when empty
+	KeyRingEnvPrefix string            `json:"key_ring_env_prefix"` // Keys are read from <prefix>_<REGION>_V<version>
+	Algorithms       map[string]string `json:"algorithms"`          // Algorithm per region, aes-gcm or sm4-gcm
 }
 
 // LoggingConfig holds the logging configuration
@@ -80,6 +123,8 @@ type LoggingConfig struct {
 // ProviderConfig holds the provider configuration
 type ProviderConfig struct {
 	OAuthRedirectURL string `json:"oauth_redirect_url"`
+	// ConsentRedirectURL is where users land after granting additional permissions requested by an invocation
+	ConsentRedirectURL string `json:"consent_redirect_url"`
 }
 
 // ObservabilityConfig holds the observability configuration
@@ -105,6 +150,7 @@ type MetricsConfig struct {
 type SecurityConfig struct {
 	RedirectURLValidator RedirectURLValidatorConfig `json:"redirect_url_validator"`
 	CORS                 CORSConfig                 `json:"cors"`
+	AdminUserIDs         []string                   `json:"admin_user_ids"` // Users allowed to call the admin API
 }
 
 type RedirectURLValidatorConfig struct {
@@ -148,6 +194,80 @@ type GRPCConfig struct {
 	ShutdownTimeout       int    `json:"shutdown_timeout"`
 }
 
+// QuotaConfig holds invocation quota configuration
+type QuotaConfig struct {
+	Enabled        bool                   `json:"enabled"` // Off by default, the limits only apply once enabled
+	User           QuotaLimits            `json:"user"`
+	ServiceAccount QuotaLimits            `json:"service_account"` // Replaces the user limits for service accounts
+	APIKey         QuotaLimits            `json:"api_key"`
+	Providers      map[string]QuotaLimits `json:"providers"` // Keyed by provider or provider.operation identifier
+}
+
+// QuotaLimits holds the invocation limits per time window, zero means unlimited
+type QuotaLimits struct {
+	PerMinute int64 `json:"per_minute"`
+	PerDay    int64 `json:"per_day"`
+	PerMonth  int64 `json:"per_month"`
+}
+
+// VCRConfig holds the record/replay configuration of provider traffic
+type VCRConfig struct {
+	Mode        string `json:"mode"` // off, record or replay
+	CassetteDir string `json:"cassette_dir"`
+	AllowHeader bool   `json:"allow_header"` // Allow selecting the mode per request with the X-VCR-Mode header
+	// HeaderPrincipals are the dev/CI users allowed to select the mode per request in production,
+	// where record mode is never honoured per request
+	HeaderPrincipals []string `json:"header_principals"`
+}
+
+// HealthProbeConfig holds the synthetic provider probe configuration
+type HealthProbeConfig struct {
+	Enabled            bool                   `json:"enabled"`  // Probes only providers whose auth type is none
+	Schedule           string                 `json:"schedule"` // 6-field cron expression
+	TimeoutSeconds     int                    `json:"timeout_seconds"`
+	RetentionHours     int                    `json:"retention_hours"`
+	ReadinessTimeoutMs int                    `json:"readiness_timeout_ms"` // Per check timeout of /readyz
+	Providers          map[string]ProbeTarget `json:"providers"`            // MCP providers without a target are probed with tools/list
+}
+
+// KeyRotationConfig holds the transit key rotation configuration
+type KeyRotationConfig struct {
+	Enabled   bool   `json:"enabled"`  // Rotate every key on schedule, admins can always trigger rotations
+	Schedule  string `json:"schedule"` // 6-field cron expression
+	BatchSize int    `json:"batch_size"`
+}
+
+// CredentialVerificationConfig holds the scheduled credential verification configuration
+type CredentialVerificationConfig struct {
+	Enabled       bool   `json:"enabled"`        // Calls the provider of every credential and invalidates the rejected ones
+	Schedule      string `json:"schedule"`       // 6-field cron expression
+	IntervalHours int    `json:"interval_hours"` // Minimum time between two verifications of a credential
+	BatchSize     int    `json:"batch_size"`     // Maximum credentials verified per run
+}
+
+// WebhookConfig holds the inbound provider webhook configuration
+type WebhookConfig struct {
+	MaxBodyBytes int64 `json:"max_body_bytes"` // Maximum size of a delivery body
+	// RetentionHours is how long received events are kept, deliveries are only de-duplicated while their event is kept,
+	// so the signed body of a provider without signed timestamps, such as GitHub, is accepted again after it
+	RetentionHours int `json:"retention_hours"`
+}
+
+// ScheduledInvocationConfig holds the user-defined scheduled invocation configuration
+type ScheduledInvocationConfig struct {
+	Enabled                bool   `json:"enabled"`
+	Schedule               string `json:"schedule"`                 // 6-field cron expression checking for due schedules
+	BatchSize              int    `json:"batch_size"`               // Maximum schedules run per check
+	MaxPerUser             int    `json:"max_per_user"`             // Maximum schedules a user can create
+	MaxConsecutiveFailures int    `json:"max_consecutive_failures"` // Failed runs in a row after which a schedule is paused
+}
+
+// ProbeTarget is the cheap, credential-free operation used to probe a provider
+type ProbeTarget struct {
+	Operation  string                 `json:"operation"`
+	Parameters map[string]interface{} `json:"parameters"`
+}
+
 // Load loads configuration from file and environment variables
 func Load() (*Config, error) {
 	// Load from configuration file
@@ -162,9 +282,28 @@ func Load() (*Config, error) {
 		Server: ServerConfig{
 			Address: ":8080",
 		},
+		Auth: AuthConfig{
+			Provider: "supabase",
+			OIDC: OIDCConfig{
+				JWKSCacheSeconds: 3600,
+				ClockSkewSeconds: 60,
+				Claims: OIDCClaimMapping{
+					Subject:       "sub",
+					Email:         "email",
+					EmailVerified: "email_verified",
+				},
+			},
+		},
 		Vault: VaultConfig{
+			Backend:       "vault",
 			DefaultRegion: "eu",
 			Regions:       make(map[string]*VaultRegionalConfig),
+			Envelope: EnvelopeConfig{
+				KeyRingEnvPrefix: "ENVELOPE_KEK",
+				Algorithms: map[string]string{
+					"cn": "sm4-gcm",
+				},
+			},
 		},
 		Logging: LoggingConfig{
 			Level:      "info",
@@ -200,6 +339,54 @@ func Load() (*Config, error) {
 			EnableReflection:      true,            // Enable for development
 			ShutdownTimeout:       30,              // 30 seconds
 		},
+		Quota: QuotaConfig{
+			Enabled: false,
+			User: QuotaLimits{
+				PerMinute: 60,
+				PerDay:    5000,
+				PerMonth:  100000,
+			},
+			ServiceAccount: QuotaLimits{
+				PerMinute: 60,
+				PerDay:    5000,
+				PerMonth:  100000,
+			},
+			Providers: make(map[string]QuotaLimits),
+		},
+		VCR: VCRConfig{
+			Mode:        "off",
+			CassetteDir: "testdata/cassettes",
+		},
+		HealthProbe: HealthProbeConfig{
+			Enabled:            false,
+			Schedule:           "0 */5 * * * *",
+			TimeoutSeconds:     20,
+			RetentionHours:     168,
+			ReadinessTimeoutMs: 3000,
+			Providers:          make(map[string]ProbeTarget),
+		},
+		KeyRotation: KeyRotationConfig{
+			Enabled:   false,
+			Schedule:  "0 0 3 1 * *",
+			BatchSize: 100,
+		},
+		CredentialVerification: CredentialVerificationConfig{
+			Enabled:       false,
+			Schedule:      "0 */15 * * * *",
+			IntervalHours: 24,
+			BatchSize:     200,
+		},
+		Webhook: WebhookConfig{
+			MaxBodyBytes:   1 << 20,
+			RetentionHours: 168,
+		},
+		ScheduledInvocation: ScheduledInvocationConfig{
+			Enabled:                true,
+			Schedule:               "0 * * * * *",
+			BatchSize:              100,
+			MaxPerUser:             20,
+			MaxConsecutiveFailures: 5,
+		},
 	}
 
 	var configFile string
@@ -229,18 +416,43 @@ func Load() (*Config, error) {
 	}
 
 	// Override with environment variables
-	overrideWithEnv(config)
+	if err := overrideWithEnv(config); err != nil {
+		return nil, fmt.Errorf("error reading environment variables: %w", err)
+	}
 
 	return config, nil
 }
 
 // overrideWithEnv overrides config values with environment variables
-func overrideWithEnv(config *Config) {
+func overrideWithEnv(config *Config) error {
+	var errs []error
+
 	// Server config
 	if envVal := os.Getenv("SERVER_ADDRESS"); envVal != "" {
 		config.Server.Address = envVal
 	}
 
+	// Auth config
+	if envVal := os.Getenv("AUTH_PROVIDER"); envVal != "" {
+		config.Auth.Provider = envVal
+	}
+	if envVal := os.Getenv("OIDC_ISSUER_URL"); envVal != "" {
+		config.Auth.OIDC.IssuerURL = envVal
+	}
+	if envVal := os.Getenv("OIDC_AUDIENCES"); envVal != "" {
+		config.Auth.OIDC.Audiences = nil
+		for _, audience := range strings.Split(envVal, ",") {
+			if audience = strings.TrimSpace(audience); audience != "" {
+				config.Auth.OIDC.Audiences = append(config.Auth.OIDC.Audiences, audience)
+			}
+		}
+	}
+
+	// Security config
+	if envVal := os.Getenv("ADMIN_USER_IDS"); envVal != "" {
+		config.Security.AdminUserIDs = strings.Split(envVal, ",")
+	}
+
 	// Supabase config
 	if envVal := os.Getenv("SUPABASE_PROJECT_REF"); envVal != "" {
 		config.Supabase.ProjectRef = envVal
@@ -296,11 +508,19 @@ func overrideWithEnv(config *Config) {
 	}
 
 	// Vault config
+	if envVal := os.Getenv("VAULT_BACKEND"); envVal != "" {
+		config.Vault.Backend = envVal
+	}
+	if envVal := os.Getenv("ENVELOPE_KEY_RING_FILE"); envVal != "" {
+		config.Vault.Envelope.KeyRingFile = envVal
+	}
 	if envVal := os.Getenv("VAULT_DEFAULT_REGION"); envVal != "" {
 		config.Vault.DefaultRegion = envVal
 	}
 	if envVal := os.Getenv("VAULT_DEFAULT_REGION_TOKEN"); envVal != "" {
-		config.Vault.Regions[config.Vault.DefaultRegion].Token = envVal
+		if regional, ok := config.Vault.Regions[config.Vault.DefaultRegion]; ok {
+			regional.Token = envVal
+		}
 	}
 
 	// Logging config
@@ -355,6 +575,92 @@ func overrideWithEnv(config *Config) {
 	if envVal := os.Getenv("DISCOVERY_EMBEDDING_MODEL"); envVal != "" {
 		config.Discovery.EmbeddingModel = envVal
 	}
+
+	// Quota config
+	if envVal := os.Getenv("QUOTA_ENABLED"); envVal != "" {
+		config.Quota.Enabled = strings.ToLower(envVal) == "true"
+	}
+	errs = append(errs, envInt("QUOTA_USER_PER_MINUTE", &config.Quota.User.PerMinute))
+	errs = append(errs, envInt("QUOTA_USER_PER_DAY", &config.Quota.User.PerDay))
+	errs = append(errs, envInt("QUOTA_USER_PER_MONTH", &config.Quota.User.PerMonth))
+	errs = append(errs, envInt("QUOTA_SERVICE_ACCOUNT_PER_MINUTE", &config.Quota.ServiceAccount.PerMinute))
+	errs = append(errs, envInt("QUOTA_SERVICE_ACCOUNT_PER_DAY", &config.Quota.ServiceAccount.PerDay))
+	errs = append(errs, envInt("QUOTA_SERVICE_ACCOUNT_PER_MONTH", &config.Quota.ServiceAccount.PerMonth))
+
+	// VCR config
+	if envVal := os.Getenv("VCR_MODE"); envVal != "" {
+		config.VCR.Mode = envVal
+	}
+	if envVal := os.Getenv("VCR_CASSETTE_DIR"); envVal != "" {
+		config.VCR.CassetteDir = envVal
+	}
+	if envVal := os.Getenv("VCR_ALLOW_HEADER"); envVal != "" {
+		config.VCR.AllowHeader = strings.ToLower(envVal) == "true"
+	}
+	if envVal := os.Getenv("VCR_HEADER_PRINCIPALS"); envVal != "" {
+		config.VCR.HeaderPrincipals = strings.Split(envVal, ",")
+	}
+
+	// Health probe config
+	if envVal := os.Getenv("HEALTH_PROBE_ENABLED"); envVal != "" {
+		config.HealthProbe.Enabled = strings.ToLower(envVal) == "true"
+	}
+	if envVal := os.Getenv("HEALTH_PROBE_SCHEDULE"); envVal != "" {
+		config.HealthProbe.Schedule = envVal
+	}
+	errs = append(errs, envInt("HEALTH_PROBE_TIMEOUT_SECONDS", &config.HealthProbe.TimeoutSeconds))
+	errs = append(errs, envInt("HEALTH_PROBE_RETENTION_HOURS", &config.HealthProbe.RetentionHours))
+
+	// Key rotation config
+	if envVal := os.Getenv("KEY_ROTATION_ENABLED"); envVal != "" {
+		config.KeyRotation.Enabled = strings.ToLower(envVal) == "true"
+	}
+	if envVal := os.Getenv("KEY_ROTATION_SCHEDULE"); envVal != "" {
+		config.KeyRotation.Schedule = envVal
+	}
+	errs = append(errs, envInt("KEY_ROTATION_BATCH_SIZE", &config.KeyRotation.BatchSize))
+
+	// Credential verification config
+	if envVal := os.Getenv("CREDENTIAL_VERIFICATION_ENABLED"); envVal != "" {
+		config.CredentialVerification.Enabled = strings.ToLower(envVal) == "true"
+	}
+	if envVal := os.Getenv("CREDENTIAL_VERIFICATION_SCHEDULE"); envVal != "" {
+		config.CredentialVerification.Schedule = envVal
+	}
+	errs = append(errs, envInt("CREDENTIAL_VERIFICATION_INTERVAL_HOURS", &config.CredentialVerification.IntervalHours))
+	errs = append(errs, envInt("CREDENTIAL_VERIFICATION_BATCH_SIZE", &config.CredentialVerification.BatchSize))
+
+	// Webhook config
+	errs = append(errs, envInt("WEBHOOK_MAX_BODY_BYTES", &config.Webhook.MaxBodyBytes))
+	errs = append(errs, envInt("WEBHOOK_RETENTION_HOURS", &config.Webhook.RetentionHours))
+
+	// Scheduled invocation config
+	if envVal := os.Getenv("SCHEDULED_INVOCATION_ENABLED"); envVal != "" {
+		config.ScheduledInvocation.Enabled = strings.ToLower(envVal) == "true"
+	}
+	if envVal := os.Getenv("SCHEDULED_INVOCATION_SCHEDULE"); envVal != "" {
+		config.ScheduledInvocation.Schedule = envVal
+	}
+	errs = append(errs, envInt("SCHEDULED_INVOCATION_BATCH_SIZE", &config.ScheduledInvocation.BatchSize))
+	errs = append(errs, envInt("SCHEDULED_INVOCATION_MAX_PER_USER", &config.ScheduledInvocation.MaxPerUser))
+	errs = append(errs, envInt("SCHEDULED_INVOCATION_MAX_CONSECUTIVE_FAILURES", &config.ScheduledInvocation.MaxConsecutiveFailures))
+
+	return errors.Join(errs...)
+}
+
+// envInt sets target to the integer value of an environment variable, if it is set
+func envInt[T int | int64](name string, target *T) error {
+	envVal := os.Getenv(name)
+	if envVal == "" {
+		return nil
+	}
+
+	value, err := strconv.ParseInt(strings.TrimSpace(envVal), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%s must be an integer, got %q", name, envVal)
+	}
+	*target = T(value)
+	return nil
 }
 
 // GetDatabaseDSN returns the database connection string
@@ -27,3 +27,16 @@ type CredentialResponse struct {
 	Success    bool        `json:"success"`
 	Message    string      `json:"message,omitempty"`
 }
+
+// IncrementalAuthorizationDTO represents an OAuth flow started to grant additional permissions
+type IncrementalAuthorizationDTO struct {
+	AuthURL      string   `json:"auth_url"`
+	OAuthStateID string   `json:"oauth_state_id"`
+	Permissions  []string `json:"permissions"`
+}
+
+// StoredSecretDTO represents a secret another module stored encrypted, identified by its row in the store
+type StoredSecretDTO struct {
+	RowID              string
+	EncryptionMetadata string
+}
@@ -1,6 +1,13 @@
 package credentialmanagement
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrConsentRedirectNotConfigured is returned when no incremental authorization can be started
+// because the URL users land on after granting consent is not configured
+var ErrConsentRedirectNotConfigured = errors.New("consent redirect URL is not configured")
 
 // CredentialManagementContract defines the contract interface for credential management
 // This is used for cross-module communication through the contract layer
@@ -16,8 +23,13 @@ import "context"
 type CredentialManagementContract interface {
 	// GetCredentialByUserAndProviderContract retrieves a credential by user ID and provider ID
 	// Returns the raw credential object (domain entity)
+	// Returns nil if the user has no credential for the provider
 	GetCredentialByUserAndProviderContract(ctx context.Context, userID, providerIdentifier string) (interface{}, error)
 
+	// GetCredentialByOrganizationAndProviderContract retrieves the credential an organization shares for a provider
+	// Returns nil if the organization has no credential for the provider
+	GetCredentialByOrganizationAndProviderContract(ctx context.Context, organizationID, providerIdentifier string) (interface{}, error)
+
 	// CreateNoneCredentialContract creates a new no-auth credential
 	// Returns a standardized DTO for cross-module communication
 	CreateNoneCredentialContract(ctx context.Context, userID, providerIdentifier string) (*CredentialDTO, error)
@@ -29,4 +41,38 @@ type CredentialManagementContract interface {
 	// RefreshAccessTokenContract refreshes OAuth access token if needed
 	// Returns updated credential or original if refresh not needed
 	RefreshAccessTokenContract(ctx context.Context, providerIdentifier string, credential interface{}) (interface{}, error)
+
+	// CreateIncrementalAuthorizationContract starts an OAuth flow requesting the missing permissions
+	// in addition to the ones already granted to the credential, the organization's when organizationID is set
+	// and the user's own otherwise
+	// Returns ErrConsentRedirectNotConfigured if no consent flow can be offered
+	CreateIncrementalAuthorizationContract(ctx context.Context, userID, organizationID, providerIdentifier string, missingPermissions []string) (*IncrementalAuthorizationDTO, error)
+
+	// EncryptSecretContract encrypts a secret stored by another module, such as a webhook signing secret
+	// Returns the encryption metadata as JSON, to be stored under "encryption_metadata" in the json attributes
+	// of the row so that it is rewrapped when the key is rotated
+	EncryptSecretContract(ctx context.Context, plaintext string) (string, error)
+
+	// DecryptSecretContract decrypts a secret encrypted with EncryptSecretContract
+	DecryptSecretContract(ctx context.Context, encryptionMetadata string) (string, error)
+
+	// RegisterSecretStoreContract registers the store of a module keeping secrets encrypted with EncryptSecretContract,
+	// so that they are rewrapped when the key is rotated. Stores are registered while the modules are wired
+	RegisterSecretStoreContract(name string, store SecretStore)
+}
+
+// SecretStore is implemented by the modules storing secrets encrypted with EncryptSecretContract.
+// Encryption metadata is exchanged as the JSON EncryptSecretContract returns, its "region", "key_version"
+// and "ciphertext" fields select the secrets to rewrap
+type SecretStore interface {
+	// CountSecretsBelowVersion counts the secrets encrypted in the region with a key version older than keyVersion
+	CountSecretsBelowVersion(ctx context.Context, region string, keyVersion int) (int, error)
+
+	// ListSecretsBelowVersion lists the secrets encrypted in the region with a key version older than keyVersion,
+	// ordered by row ID and starting after the given row ID
+	ListSecretsBelowVersion(ctx context.Context, region string, keyVersion int, afterRowID string, limit int) ([]*StoredSecretDTO, error)
+
+	// ReplaceSecret stores the rewrapped encryption metadata of a row if it still holds the original ciphertext,
+	// it returns false otherwise
+	ReplaceSecret(ctx context.Context, rowID, originalCiphertext, encryptionMetadata string) (bool, error)
 }
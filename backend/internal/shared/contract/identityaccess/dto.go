@@ -0,0 +1,12 @@
+package identityaccess
+
+// MembershipDTO represents the membership of a user in an organization for contract communication
+type MembershipDTO struct {
+	OrganizationID string
+	UserID         string
+	Role           string
+	// CanManageCredentials is true for members allowed to connect and delete organization credentials
+	CanManageCredentials bool
+	// CanUseSharedCredentials is true for members allowed to invoke operations with organization credentials
+	CanUseSharedCredentials bool
+}
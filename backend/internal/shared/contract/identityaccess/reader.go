@@ -0,0 +1,9 @@
+package identityaccess
+
+import "context"
+
+// OrganizationReader exposes organization memberships to other modules
+type OrganizationReader interface {
+	// GetMembershipContract returns the membership of a user in an organization, or nil if the user is not a member
+	GetMembershipContract(ctx context.Context, organizationID, userID string) (*MembershipDTO, error)
+}
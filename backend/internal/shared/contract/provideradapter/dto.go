@@ -1,6 +1,10 @@
 package provideradapter
 
-import "github.com/context-space/context-space/backend/internal/shared/types"
+import (
+	"fmt"
+
+	"github.com/context-space/context-space/backend/internal/shared/types"
+)
 
 // AdapterInfoDTO contains the adapter information for cross-module communication
 // This is the contract DTO, used for communication between modules
@@ -41,3 +45,39 @@ type ParameterDTO struct {
 	Enum        []string    `json:"enum,omitempty"`
 	Default     interface{} `json:"default"`
 }
+
+// PaginateOptionsDTO controls how many pages an execution follows
+type PaginateOptionsDTO struct {
+	// Cursor is the next cursor of a previous execution
+	Cursor string
+	// MaxItems stops fetching pages once this many items are collected, the last page is kept whole
+	MaxItems int
+	// MaxPages is the number of pages to fetch at most, 0 for a single page
+	MaxPages int
+}
+
+// IsZero reports whether no pagination was requested
+func (o PaginateOptionsDTO) IsZero() bool {
+	return o == PaginateOptionsDTO{}
+}
+
+// PageResultDTO is the result of an execution with the items of every fetched page merged
+type PageResultDTO struct {
+	Result interface{}
+	// NextCursor resumes after the last fetched page, empty when there are no more pages or the operation does not paginate
+	NextCursor string
+	Pages      int
+}
+
+// InsufficientScopeError is returned by ExecuteContract when the credential lacks permissions the operation requires
+type InsufficientScopeError struct {
+	ProviderIdentifier  string
+	OperationIdentifier string
+	MissingPermissions  []string
+}
+
+// Error implements the error interface
+func (e *InsufficientScopeError) Error() string {
+	return fmt.Sprintf("[%s] %s: missing required permissions: %v",
+		e.ProviderIdentifier, e.OperationIdentifier, e.MissingPermissions)
+}
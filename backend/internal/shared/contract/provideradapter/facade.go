@@ -2,10 +2,23 @@ package provideradapter
 
 import (
 	"context"
+	"errors"
 
 	"golang.org/x/oauth2"
 )
 
+// ErrTokenRevocationNotSupported is returned when the provider offers no way to revoke issued tokens
+var ErrTokenRevocationNotSupported = errors.New("token revocation not supported")
+
+// ErrCredentialVerificationNotSupported is returned when the adapter has no way to verify a credential
+var ErrCredentialVerificationNotSupported = errors.New("credential verification not supported")
+
+// ErrCredentialRejected is returned when the provider rejects a credential as invalid, revoked or expired
+var ErrCredentialRejected = errors.New("credential rejected by provider")
+
+// ErrPaginationNotSupported is returned when pagination options are given for an operation that does not paginate
+var ErrPaginationNotSupported = errors.New("operation does not support pagination")
+
 // AdapterDTO defines the contract interface for provider adapters
 // This is used for cross-module communication through the contract layer
 type AdapterContract interface {
@@ -17,12 +30,23 @@ type AdapterContract interface {
 		credential interface{},
 	) (interface{}, error)
 
+	// ExecutePagesContract executes an operation and follows its pages as far as the options allow
+	// Operations that do not paginate are executed once, unless options are given which returns ErrPaginationNotSupported
+	ExecutePagesContract(
+		ctx context.Context,
+		operationID string,
+		params map[string]interface{},
+		credential interface{},
+		options PaginateOptionsDTO,
+	) (*PageResultDTO, error)
+
 	// GetAdapterInfo returns information about this adapter
 	GetAdapterInfoContract() *AdapterInfoDTO
 }
 
 // ProviderAdapterContract defines the contract interface for provider adapter operations
 // This provides a stable interface for cross-module communication
+// The OAuth methods use the OAuth app of the context, see WithOAuthApp
 type ProviderAdapterContract interface {
 	// GetAdapter returns an adapter for the given provider ID
 	GetAdapterContract(ctx context.Context, providerIdentifier string) (AdapterContract, error)
@@ -37,6 +61,7 @@ type ProviderAdapterContract interface {
 	ShouldRefreshTokenContract(providerIdentifier string, token *oauth2.Token) (bool, error)
 
 	// RefreshTokenContract refreshes an OAuth token
+	// Returns ErrCredentialRejected when the provider no longer accepts the refresh token
 	RefreshTokenContract(ctx context.Context, providerIdentifier string, oldToken *oauth2.Token) (*oauth2.Token, error)
 
 	// GetScopesFromPermissionsContract gets the scopes from the permissions
@@ -44,4 +69,13 @@ type ProviderAdapterContract interface {
 
 	// GetPermissionIdentifiersFromScopesContract gets the permission identifiers from the scopes
 	GetPermissionIdentifiersFromScopesContract(ctx context.Context, providerIdentifier string, scopes []string) ([]string, error)
+
+	// RevokeTokenContract revokes an OAuth token at the provider
+	// Returns ErrTokenRevocationNotSupported when the provider has no revocation endpoint
+	RevokeTokenContract(ctx context.Context, providerIdentifier string, token *oauth2.Token) error
+
+	// VerifyCredentialContract checks a credential against the provider
+	// Returns ErrCredentialRejected when the provider rejects it and ErrCredentialVerificationNotSupported
+	// when the adapter cannot verify credentials
+	VerifyCredentialContract(ctx context.Context, providerIdentifier string, credential interface{}) error
 }
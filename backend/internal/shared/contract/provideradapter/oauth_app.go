@@ -0,0 +1,29 @@
+package provideradapter
+
+import "context"
+
+// OAuthAppDTO replaces the OAuth client and the endpoints of the provider manifest
+// Empty URLs fall back to the endpoints of the provider
+type OAuthAppDTO struct {
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	APIBaseURL   string
+}
+
+type oauthAppContextKey struct{}
+
+// WithOAuthApp returns a context whose OAuth flows and API calls use the given OAuth app
+func WithOAuthApp(ctx context.Context, app *OAuthAppDTO) context.Context {
+	if app == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, oauthAppContextKey{}, app)
+}
+
+// OAuthAppFromContext returns the OAuth app of the context, or nil if the provider manifest is used
+func OAuthAppFromContext(ctx context.Context) *OAuthAppDTO {
+	app, _ := ctx.Value(oauthAppContextKey{}).(*OAuthAppDTO)
+	return app
+}
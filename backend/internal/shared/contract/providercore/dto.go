@@ -4,20 +4,28 @@ import "github.com/context-space/context-space/backend/internal/shared/types"
 
 // ProviderDTO Provider data transfer object
 type ProviderDTO struct {
-	ID          string         `json:"id"`
-	Identifier  string         `json:"identifier"`
-	Name        string         `json:"name"`
-	Description string         `json:"description"`
-	AuthType    string         `json:"auth_type"`
-	Status      string         `json:"status"`
-	Tags        []string       `json:"tags"`
-	IconURL     string         `json:"icon_url"`
-	ApiDocURL   string         `json:"api_doc_url"`
-	Categories  []string       `json:"categories"`
-	Operations  []OperationDTO `json:"operations"`
-	Embedding   []float64      `json:"embedding"`
-	CreatedAt   int64          `json:"created_at"`
-	UpdatedAt   int64          `json:"updated_at"`
+	ID          string          `json:"id"`
+	Identifier  string          `json:"identifier"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	AuthType    string          `json:"auth_type"`
+	Status      string          `json:"status"`
+	Tags        []string        `json:"tags"`
+	IconURL     string          `json:"icon_url"`
+	ApiDocURL   string          `json:"api_doc_url"`
+	Categories  []string        `json:"categories"`
+	Operations  []OperationDTO  `json:"operations"`
+	Embedding   []float64       `json:"embedding"`
+	Maintenance *MaintenanceDTO `json:"maintenance,omitempty"`
+	CreatedAt   int64           `json:"created_at"`
+	UpdatedAt   int64           `json:"updated_at"`
+}
+
+// MaintenanceDTO Provider maintenance window data transfer object, zero timestamps are unbounded
+type MaintenanceDTO struct {
+	Message  string `json:"message,omitempty"`
+	StartsAt int64  `json:"starts_at,omitempty"`
+	EndsAt   int64  `json:"ends_at,omitempty"`
 }
 
 // OperationDTO Operation data transfer object
@@ -30,6 +38,8 @@ type OperationDTO struct {
 	Category            string             `json:"category"`
 	RequiredPermissions []types.Permission `json:"required_permissions"`
 	Parameters          []ParameterDTO     `json:"parameters"`
+	RiskLevel           string             `json:"risk_level"`
+	CompactFields       []string           `json:"compact_fields,omitempty"`
 	CreatedAt           int64              `json:"created_at"`
 	UpdatedAt           int64              `json:"updated_at"`
 }
@@ -43,6 +43,8 @@ func TestRefreshToken(t *testing.T) {
 		mockCredentialRepo,
 		mockOAuthCredentialRepo,
 		mockVaultService,
+		nil,
+		nil,
 		mockObs,
 	)
 
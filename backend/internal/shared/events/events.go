@@ -2,6 +2,7 @@ package events
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"sync"
 	"time"
@@ -78,14 +79,15 @@ func (b *Bus) Publish(ctx context.Context, event Event) error {
 	copy(handlersCopy, handlers)
 	b.mu.RUnlock()
 
+	// A failing handler does not keep the others from running, their errors are returned together
+	var errs []error
 	for _, handler := range handlersCopy {
 		if err := handler(ctx, event); err != nil {
-			// Log the error but continue with other handlers
-			return err
+			errs = append(errs, err)
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // PublishDomainEvent implements the EventPublisher interface for domain events
@@ -0,0 +1,161 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Status is the outcome of a readiness check
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+	// StatusWarn reports a misconfiguration that does not stop the service from serving traffic
+	StatusWarn Status = "warn"
+)
+
+// warningError marks a check failure as a warning
+type warningError struct {
+	err error
+}
+
+// Error implements the error interface
+func (e *warningError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the failure of the check
+func (e *warningError) Unwrap() error {
+	return e.err
+}
+
+// Warn marks a check failure as a warning, such as missing permissions to inspect a dependency that otherwise works
+func Warn(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &warningError{err: err}
+}
+
+// Check is a single dependency the service needs to serve traffic
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// CheckResult is the outcome of running one check
+type CheckResult struct {
+	Name      string `json:"name"`
+	Status    Status `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms,omitempty"`
+}
+
+// Report aggregates the results of all readiness checks, the service is up only if no check is down
+type Report struct {
+	Status    Status        `json:"status"`
+	CheckedAt int64         `json:"checked_at"`
+	Checks    []CheckResult `json:"checks"`
+}
+
+// Public returns the report with only the name and status of each check,
+// errors may hold addresses and key names that must not reach unauthenticated callers
+func (r Report) Public() Report {
+	public := Report{Status: r.Status, CheckedAt: r.CheckedAt, Checks: make([]CheckResult, len(r.Checks))}
+	for i, result := range r.Checks {
+		public.Checks[i] = CheckResult{Name: result.Name, Status: result.Status}
+	}
+	return public
+}
+
+// Runner runs readiness checks, serving the last report while it is recent
+// so that frequent or concurrent requests do not fan out to every dependency
+type Runner struct {
+	checks  func() []Check
+	timeout time.Duration
+	ttl     time.Duration
+
+	mu        sync.Mutex
+	report    Report
+	checkedAt time.Time
+}
+
+// NewRunner creates a runner bounding each check by timeout and reusing reports for ttl
+func NewRunner(checks func() []Check, timeout, ttl time.Duration) *Runner {
+	return &Runner{checks: checks, timeout: timeout, ttl: ttl}
+}
+
+// Run returns a recent report, running the checks if there is none, fresh is true when the checks ran
+func (r *Runner) Run(ctx context.Context) (report Report, fresh bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.checkedAt.IsZero() && time.Since(r.checkedAt) < r.ttl {
+		return r.report, false
+	}
+
+	// The report is shared, the request that happens to run the checks must not cancel them
+	r.report = RunChecks(context.WithoutCancel(ctx), r.timeout, r.checks())
+	r.checkedAt = time.Now()
+	return r.report, true
+}
+
+// RunChecks runs the checks concurrently, each bounded by the timeout
+func RunChecks(ctx context.Context, timeout time.Duration, checks []Check) Report {
+	results := make([]CheckResult, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = runCheck(ctx, timeout, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	report := Report{Status: StatusUp, CheckedAt: time.Now().Unix(), Checks: results}
+	for _, result := range results {
+		if result.Status == StatusDown {
+			report.Status = StatusDown
+			break
+		}
+	}
+	return report
+}
+
+// runCheck runs one check, reporting a timeout as a failure even if the check ignores the context
+func runCheck(ctx context.Context, timeout time.Duration, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Run(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{Name: check.Name, Status: StatusUp, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = StatusDown
+		var warning *warningError
+		if errors.As(err, &warning) {
+			result.Status = StatusWarn
+		}
+		result.Error = err.Error()
+	}
+	return result
+}
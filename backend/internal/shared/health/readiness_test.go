@@ -0,0 +1,65 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunChecks(t *testing.T) {
+	up := Check{Name: "postgres", Run: func(ctx context.Context) error { return nil }}
+	down := Check{Name: "redis", Run: func(ctx context.Context) error { return errors.New("dial tcp 10.0.0.5:6379: refused") }}
+	warn := Check{Name: "vault_eu_transit_keys", Run: func(ctx context.Context) error { return Warn(errors.New("permission denied")) }}
+
+	tests := []struct {
+		name     string
+		checks   []Check
+		expected Status
+	}{
+		{name: "AllUp", checks: []Check{up}, expected: StatusUp},
+		{name: "WarningKeepsUp", checks: []Check{up, warn}, expected: StatusUp},
+		{name: "Down", checks: []Check{up, warn, down}, expected: StatusDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := RunChecks(context.Background(), time.Second, tt.checks)
+			if report.Status != tt.expected {
+				t.Errorf("Expected status %s, got %s", tt.expected, report.Status)
+			}
+		})
+	}
+
+	report := RunChecks(context.Background(), time.Second, []Check{down, warn})
+	if report.Checks[1].Status != StatusWarn {
+		t.Errorf("Expected a warning check to report %s, got %s", StatusWarn, report.Checks[1].Status)
+	}
+	for _, result := range report.Public().Checks {
+		if result.Error != "" || result.LatencyMs != 0 {
+			t.Errorf("Expected the public report to hold only names and statuses, got %+v", result)
+		}
+	}
+}
+
+func TestRunnerCachesReport(t *testing.T) {
+	runs := 0
+	runner := NewRunner(func() []Check {
+		return []Check{{Name: "postgres", Run: func(ctx context.Context) error {
+			runs++
+			return nil
+		}}}
+	}, time.Second, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if report, fresh := runner.Run(ctx); !fresh || report.Status != StatusUp {
+		t.Fatalf("Expected the first run to check, and not be cancelled with its request, got %v %+v", fresh, report)
+	}
+	if _, fresh := runner.Run(context.Background()); fresh {
+		t.Error("Expected a recent report to be reused")
+	}
+	if runs != 1 {
+		t.Errorf("Expected the checks to run once, ran %d times", runs)
+	}
+}
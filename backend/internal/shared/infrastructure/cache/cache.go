@@ -11,6 +11,19 @@ type Cache interface {
 	Get(ctx context.Context, key string) (string, error)
 	Delete(ctx context.Context, key string) error
 	Close() error
+	Ping(ctx context.Context) error
 	AcquireLock(ctx context.Context, key string, expiration time.Duration) (bool, error)
 	ReleaseLock(ctx context.Context, key string) error
+	IncrementCounters(ctx context.Context, counters []Counter) ([]int64, bool, error)
+	DecrementCounters(ctx context.Context, counters []Counter) error
+	GetCounters(ctx context.Context, counters []Counter) ([]int64, error)
+	HashGetAll(ctx context.Context, key string) (map[string]string, error)
+}
+
+// Counter describes a counter read or incremented atomically with others
+type Counter struct {
+	Key   string
+	Field string        // When set, the counter is a field of the hash stored at Key
+	Limit int64         // Maximum value after an increment, 0 means unlimited
+	TTL   time.Duration // Expiration applied when the key has none
 }
@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	observability "github.com/context-space/cloud-observability"
@@ -17,6 +18,63 @@ const (
 	AccessTokenLockTimeout = 1 * time.Second
 )
 
+// incrementCountersScript increments every counter unless one of them would exceed its limit.
+// ARGV holds a (field, limit, ttl in ms) triple per key. The first element of the reply is 1 when
+// the counters were incremented and 0 when the call was rejected, followed by the counter values.
+var incrementCountersScript = redis.NewScript(`
+local values = {}
+local allowed = 1
+for i = 1, #KEYS do
+	local field = ARGV[(i - 1) * 3 + 1]
+	local limit = tonumber(ARGV[(i - 1) * 3 + 2])
+	local current
+	if field ~= '' then
+		current = tonumber(redis.call('HGET', KEYS[i], field) or '0')
+	else
+		current = tonumber(redis.call('GET', KEYS[i]) or '0')
+	end
+	values[i] = current
+	if limit > 0 and current + 1 > limit then
+		allowed = 0
+	end
+end
+if allowed == 1 then
+	for i = 1, #KEYS do
+		local field = ARGV[(i - 1) * 3 + 1]
+		local ttl = tonumber(ARGV[(i - 1) * 3 + 3])
+		if field ~= '' then
+			values[i] = redis.call('HINCRBY', KEYS[i], field, 1)
+		else
+			values[i] = redis.call('INCR', KEYS[i])
+		end
+		if ttl > 0 and redis.call('PTTL', KEYS[i]) < 0 then
+			redis.call('PEXPIRE', KEYS[i], ttl)
+		end
+	end
+end
+local reply = {allowed}
+for i = 1, #values do
+	reply[i + 1] = values[i]
+end
+return reply
+`)
+
+// decrementCountersScript takes back one increment from every counter, counters that are missing
+// or already at zero, such as the counters of a window that ended, are left alone. ARGV holds the field per key.
+var decrementCountersScript = redis.NewScript(`
+for i = 1, #KEYS do
+	local field = ARGV[i]
+	if field ~= '' then
+		if tonumber(redis.call('HGET', KEYS[i], field) or '0') > 0 then
+			redis.call('HINCRBY', KEYS[i], field, -1)
+		end
+	elseif tonumber(redis.call('GET', KEYS[i]) or '0') > 0 then
+		redis.call('DECR', KEYS[i])
+	end
+end
+return 1
+`)
+
 // RedisClient wraps a Redis client with observability
 type RedisClient struct {
 	client          *redis.Client
@@ -95,6 +153,11 @@ func (c *RedisClient) Close() error {
 	return c.client.Close()
 }
 
+// Ping checks the Redis server is reachable
+func (c *RedisClient) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
 func (c *RedisClient) AcquireLock(ctx context.Context, key string, expiration time.Duration) (bool, error) {
 	var span trace.Span
 	if c.traceOperations {
@@ -117,3 +180,111 @@ func (c *RedisClient) ReleaseLock(ctx context.Context, key string) error {
 
 	return c.client.Del(ctx, key).Err()
 }
+
+// IncrementCounters atomically increments all counters, or none of them if any limit would be exceeded.
+// It returns the counter values and whether the increment happened.
+func (c *RedisClient) IncrementCounters(ctx context.Context, counters []Counter) ([]int64, bool, error) {
+	var span trace.Span
+	if c.traceOperations {
+		ctx, span = c.obs.Tracer.Start(ctx, "redis.IncrementCounters")
+		span.SetAttributes(attribute.Int("counters", len(counters)))
+		defer span.End()
+	}
+
+	if len(counters) == 0 {
+		return []int64{}, true, nil
+	}
+
+	keys := make([]string, 0, len(counters))
+	args := make([]interface{}, 0, len(counters)*3)
+	for _, counter := range counters {
+		keys = append(keys, counter.Key)
+		args = append(args, counter.Field, counter.Limit, counter.TTL.Milliseconds())
+	}
+
+	reply, err := incrementCountersScript.Run(ctx, c.client, keys, args...).Int64Slice()
+	if err != nil {
+		return nil, false, err
+	}
+	if len(reply) != len(counters)+1 {
+		return nil, false, fmt.Errorf("unexpected counter reply length: %d", len(reply))
+	}
+
+	return reply[1:], reply[0] == 1, nil
+}
+
+// DecrementCounters atomically takes back one increment from every counter, it never decrements below zero
+func (c *RedisClient) DecrementCounters(ctx context.Context, counters []Counter) error {
+	var span trace.Span
+	if c.traceOperations {
+		ctx, span = c.obs.Tracer.Start(ctx, "redis.DecrementCounters")
+		span.SetAttributes(attribute.Int("counters", len(counters)))
+		defer span.End()
+	}
+
+	if len(counters) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(counters))
+	args := make([]interface{}, 0, len(counters))
+	for _, counter := range counters {
+		keys = append(keys, counter.Key)
+		args = append(args, counter.Field)
+	}
+
+	return decrementCountersScript.Run(ctx, c.client, keys, args...).Err()
+}
+
+// GetCounters returns the current values of the counters, missing counters read as zero
+func (c *RedisClient) GetCounters(ctx context.Context, counters []Counter) ([]int64, error) {
+	var span trace.Span
+	if c.traceOperations {
+		ctx, span = c.obs.Tracer.Start(ctx, "redis.GetCounters")
+		span.SetAttributes(attribute.Int("counters", len(counters)))
+		defer span.End()
+	}
+
+	pipe := c.client.Pipeline()
+	cmds := make([]interface{ Result() (string, error) }, 0, len(counters))
+	for _, counter := range counters {
+		if counter.Field != "" {
+			cmds = append(cmds, pipe.HGet(ctx, counter.Key, counter.Field))
+		} else {
+			cmds = append(cmds, pipe.Get(ctx, counter.Key))
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	values := make([]int64, 0, len(counters))
+	for _, cmd := range cmds {
+		raw, err := cmd.Result()
+		if err == redis.Nil {
+			values = append(values, 0)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid counter value %q: %w", raw, err)
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// HashGetAll returns all fields of the hash stored at key
+func (c *RedisClient) HashGetAll(ctx context.Context, key string) (map[string]string, error) {
+	var span trace.Span
+	if c.traceOperations {
+		ctx, span = c.obs.Tracer.Start(ctx, "redis.HashGetAll")
+		span.SetAttributes(attribute.String("key", key))
+		defer span.End()
+	}
+
+	return c.client.HGetAll(ctx, key).Result()
+}
@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedisClient(t *testing.T) (*RedisClient, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return &RedisClient{client: client}, server
+}
+
+func TestRedisClientIncrementCounters(t *testing.T) {
+	ctx := context.Background()
+	client, server := newTestRedisClient(t)
+
+	counters := []Counter{
+		{Key: "quota:user:u1:minute", Limit: 2, TTL: time.Minute},
+		{Key: "quota:api_key:k1:minute", Limit: 3, TTL: time.Minute},
+		{Key: "usage:2026-10-19", Field: "u1|k1|github|create_issue", TTL: time.Hour},
+	}
+
+	tests := []struct {
+		name            string
+		expectedValues  []int64
+		expectedAllowed bool
+	}{
+		{name: "FirstInvocation", expectedValues: []int64{1, 1, 1}, expectedAllowed: true},
+		{name: "ReachesLimit", expectedValues: []int64{2, 2, 2}, expectedAllowed: true},
+		// A rejected call leaves every counter, including the ones below their limit, untouched
+		{name: "ExceedsLimit", expectedValues: []int64{2, 2, 2}, expectedAllowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, allowed, err := client.IncrementCounters(ctx, counters)
+			if err != nil {
+				t.Fatalf("IncrementCounters() error = %v", err)
+			}
+			if allowed != tt.expectedAllowed {
+				t.Errorf("allowed = %v, want %v", allowed, tt.expectedAllowed)
+			}
+			if !reflect.DeepEqual(values, tt.expectedValues) {
+				t.Errorf("values = %v, want %v", values, tt.expectedValues)
+			}
+		})
+	}
+
+	if ttl := server.TTL("quota:user:u1:minute"); ttl != time.Minute {
+		t.Errorf("window counter TTL = %v, want %v", ttl, time.Minute)
+	}
+	if ttl := server.TTL("usage:2026-10-19"); ttl != time.Hour {
+		t.Errorf("usage hash TTL = %v, want %v", ttl, time.Hour)
+	}
+
+	values, err := client.GetCounters(ctx, append(counters, Counter{Key: "quota:user:u2:minute"}))
+	if err != nil {
+		t.Fatalf("GetCounters() error = %v", err)
+	}
+	if expected := []int64{2, 2, 2, 0}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("GetCounters() = %v, want %v", values, expected)
+	}
+}
+
+func TestRedisClientDecrementCounters(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestRedisClient(t)
+
+	counters := []Counter{
+		{Key: "quota:user:u1:minute", Limit: 1, TTL: time.Minute},
+		{Key: "usage:2026-10-19", Field: "u1|k1|github|create_issue", TTL: time.Hour},
+	}
+
+	if _, allowed, err := client.IncrementCounters(ctx, counters); err != nil || !allowed {
+		t.Fatalf("IncrementCounters() = %v, %v, want the invocation counted", allowed, err)
+	}
+
+	// Releasing twice must not take the counters below zero
+	for i := 0; i < 2; i++ {
+		if err := client.DecrementCounters(ctx, counters); err != nil {
+			t.Fatalf("DecrementCounters() error = %v", err)
+		}
+	}
+
+	values, err := client.GetCounters(ctx, counters)
+	if err != nil {
+		t.Fatalf("GetCounters() error = %v", err)
+	}
+	if expected := []int64{0, 0}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("GetCounters() = %v, want %v", values, expected)
+	}
+
+	// The released invocation no longer counts against the limit
+	if _, allowed, err := client.IncrementCounters(ctx, counters); err != nil || !allowed {
+		t.Errorf("IncrementCounters() = %v, %v, want the invocation counted after the release", allowed, err)
+	}
+}
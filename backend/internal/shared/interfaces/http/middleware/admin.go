@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/context-space/context-space/backend/internal/shared/audit"
+	httpapi "github.com/context-space/context-space/backend/internal/shared/interfaces/http"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireAdmin only lets the configured admin users through, it must run after the authentication middleware
+func RequireAdmin(adminUserIDs []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID := audit.RequestInfoFromContext(c.Request.Context()).ActorID
+		if actorID == "" {
+			httpapi.Unauthorized(c, "Authentication required")
+			c.Abort()
+			return
+		}
+		if !slices.Contains(adminUserIDs, actorID) {
+			httpapi.Forbidden(c, "Admin access required")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/context-space/context-space/backend/internal/shared/audit"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditMiddleware stores the client IP and user agent in the request context so that
+// security-relevant actions can be attributed to the request they were performed in
+func AuditMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := audit.WithRequestInfo(c.Request.Context(), audit.RequestInfo{
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -52,6 +52,13 @@ func RespondWithError(c *gin.Context, statusCode int, message string) {
 	c.JSON(http.StatusOK, ErrorResponse(statusCode, message))
 }
 
+// RespondWithErrorData sends an error response carrying details the client needs to recover
+func RespondWithErrorData(c *gin.Context, statusCode int, message string, data interface{}) {
+	response := ErrorResponse(statusCode, message)
+	response.Data = data
+	c.JSON(http.StatusOK, response)
+}
+
 // RespondWithAPIError handles an APIError and sends the appropriate response
 func RespondWithAPIError(c *gin.Context, err *apierrors.APIError) {
 	c.JSON(err.HTTPCode, ErrorResponse(err.HTTPCode, err.Message))
@@ -0,0 +1,133 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrBlockedAddress is returned when a URL resolves to an address that must not be reached from the server
+var ErrBlockedAddress = errors.New("destination address is not allowed")
+
+// Timeouts of the restricted transport
+const (
+	restrictedDialTimeout         = 10 * time.Second
+	restrictedTLSHandshakeTimeout = 10 * time.Second
+	restrictedIdleConnTimeout     = 90 * time.Second
+	restrictedMaxIdleConns        = 100
+)
+
+// blockedPrefixes are the networks that may not be connected to, on top of the ones netip classifies
+// as loopback, private, link-local, multicast or unspecified
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "This" network
+	netip.MustParsePrefix("100.64.0.0/10"),   // Carrier-grade NAT, also used by cloud metadata services
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // Documentation
+	netip.MustParsePrefix("198.18.0.0/15"),   // Benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // Documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // Documentation
+	netip.MustParsePrefix("240.0.0.0/4"),     // Reserved, including broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64, which can embed any IPv4 address
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // Local-use NAT64
+	netip.MustParsePrefix("2001:db8::/32"),   // Documentation
+}
+
+// IsBlockedAddress reports whether an address is internal to the server's network, such as loopback,
+// private, link-local (including the 169.254.169.254 metadata endpoint) or reserved ranges
+func IsBlockedAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return true
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// DialControl rejects connections to blocked addresses. It runs after DNS resolution for every connection,
+// including the ones opened to follow redirects, so a hostname cannot be rebound to an internal address.
+func DialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, address)
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || IsBlockedAddress(addr) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+	}
+	return nil
+}
+
+// IsBlockedHost reports whether a URL host is a literal IP address that is blocked or localhost,
+// hostnames are only checked once resolved, when connecting
+func IsBlockedHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	addr, err := netip.ParseAddr(host)
+	return err == nil && IsBlockedAddress(addr)
+}
+
+// NewRestrictedTransport creates a transport that refuses to connect to blocked addresses. Proxies are not used,
+// the checked address must be the one connected to.
+func NewRestrictedTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout: restrictedDialTimeout,
+		Control: DialControl,
+	}
+	return &http.Transport{
+		Proxy:               nil,
+		DialContext:         dialer.DialContext,
+		ForceAttemptHTTP2:   true,
+		TLSHandshakeTimeout: restrictedTLSHandshakeTimeout,
+		MaxIdleConns:        restrictedMaxIdleConns,
+		IdleConnTimeout:     restrictedIdleConnTimeout,
+	}
+}
+
+type restrictedNetworkContextKey struct{}
+
+// WithRestrictedNetwork returns a context whose requests sent through a NetworkTransport may not reach
+// blocked addresses, for requests to endpoints chosen by users
+func WithRestrictedNetwork(ctx context.Context) context.Context {
+	return context.WithValue(ctx, restrictedNetworkContextKey{}, true)
+}
+
+// IsRestrictedNetwork reports whether the requests of a context may not reach blocked addresses
+func IsRestrictedNetwork(ctx context.Context) bool {
+	restricted, _ := ctx.Value(restrictedNetworkContextKey{}).(bool)
+	return restricted
+}
+
+// restrictedTransport is shared by the requests of restricted contexts, so that their connections are pooled
+var restrictedTransport = NewRestrictedTransport()
+
+// NetworkTransport sends the requests of restricted contexts through a transport refusing blocked addresses,
+// and the other requests through Base, http.DefaultTransport when nil
+type NetworkTransport struct {
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *NetworkTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if IsRestrictedNetwork(req.Context()) {
+		return restrictedTransport.RoundTrip(req)
+	}
+	if t.Base != nil {
+		return t.Base.RoundTrip(req)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
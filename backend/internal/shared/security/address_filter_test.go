@@ -0,0 +1,173 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestIsBlockedAddress(t *testing.T) {
+	tests := []struct {
+		addr    string
+		blocked bool
+	}{
+		// Loopback, private and link-local networks
+		{addr: "127.0.0.1", blocked: true},
+		{addr: "127.255.255.254", blocked: true},
+		{addr: "10.0.0.1", blocked: true},
+		{addr: "172.16.0.1", blocked: true},
+		{addr: "172.31.255.255", blocked: true},
+		{addr: "192.168.1.1", blocked: true},
+		{addr: "169.254.169.254", blocked: true},
+		{addr: "::1", blocked: true},
+		{addr: "fc00::1", blocked: true},
+		{addr: "fd00:ec2::254", blocked: true},
+		{addr: "fe80::1", blocked: true},
+
+		// Unspecified, multicast and reserved networks
+		{addr: "0.0.0.0", blocked: true},
+		{addr: "0.1.2.3", blocked: true},
+		{addr: "::", blocked: true},
+		{addr: "224.0.0.1", blocked: true},
+		{addr: "ff02::1", blocked: true},
+		{addr: "100.64.0.1", blocked: true},
+		{addr: "100.100.100.200", blocked: true},
+		{addr: "192.0.0.170", blocked: true},
+		{addr: "198.18.0.1", blocked: true},
+		{addr: "240.0.0.1", blocked: true},
+		{addr: "255.255.255.255", blocked: true},
+
+		// IPv4 addresses embedded in IPv6
+		{addr: "::ffff:127.0.0.1", blocked: true},
+		{addr: "::ffff:169.254.169.254", blocked: true},
+		{addr: "64:ff9b::a9fe:a9fe", blocked: true},
+
+		// Public addresses
+		{addr: "8.8.8.8", blocked: false},
+		{addr: "1.1.1.1", blocked: false},
+		{addr: "172.32.0.1", blocked: false},
+		{addr: "100.128.0.1", blocked: false},
+		{addr: "::ffff:8.8.8.8", blocked: false},
+		{addr: "2606:4700:4700::1111", blocked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if blocked := IsBlockedAddress(netip.MustParseAddr(tt.addr)); blocked != tt.blocked {
+				t.Errorf("Expected blocked %v for %s, got: %v", tt.blocked, tt.addr, blocked)
+			}
+		})
+	}
+}
+
+func TestIsBlockedHost(t *testing.T) {
+	tests := []struct {
+		host    string
+		blocked bool
+	}{
+		{host: "localhost", blocked: true},
+		{host: "LOCALHOST.", blocked: true},
+		{host: "api.localhost", blocked: true},
+		{host: "127.0.0.1", blocked: true},
+		{host: "169.254.169.254", blocked: true},
+		{host: "::1", blocked: true},
+		{host: "example.com", blocked: false},
+		{host: "localhost.example.com", blocked: false},
+		{host: "8.8.8.8", blocked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if blocked := IsBlockedHost(tt.host); blocked != tt.blocked {
+				t.Errorf("Expected blocked %v for %s, got: %v", tt.blocked, tt.host, blocked)
+			}
+		})
+	}
+}
+
+func TestDialControl(t *testing.T) {
+	tests := []struct {
+		address string
+		wantErr bool
+	}{
+		{address: "127.0.0.1:80", wantErr: true},
+		{address: "[::1]:443", wantErr: true},
+		{address: "169.254.169.254:80", wantErr: true},
+		{address: "[::ffff:10.0.0.1]:443", wantErr: true},
+		{address: "internal.example.com:80", wantErr: true},
+		{address: "127.0.0.1", wantErr: true},
+		{address: "93.184.215.14:443", wantErr: false},
+		{address: "[2606:4700:4700::1111]:443", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := DialControl("tcp", tt.address, nil)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("Expected %s to be allowed, got: %v", tt.address, err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrBlockedAddress) {
+				t.Errorf("Expected ErrBlockedAddress for %s, got: %v", tt.address, err)
+			}
+		})
+	}
+}
+
+func TestNetworkTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// Redirects to an internal address must be refused too
+	redirect := httptest.NewServer(http.RedirectHandler(server.URL, http.StatusFound))
+	defer redirect.Close()
+
+	client := &http.Client{Transport: &NetworkTransport{}}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		url     string
+		wantErr bool
+	}{
+		{name: "Unrestricted", ctx: context.Background(), url: server.URL},
+		{name: "UnrestrictedRedirect", ctx: context.Background(), url: redirect.URL},
+		{name: "Restricted", ctx: WithRestrictedNetwork(context.Background()), url: server.URL, wantErr: true},
+		{name: "RestrictedRedirect", ctx: WithRestrictedNetwork(context.Background()), url: redirect.URL, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(tt.ctx, http.MethodGet, tt.url, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			resp, err := client.Do(req)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("Expected the request to succeed, got: %v", err)
+				}
+				resp.Body.Close()
+				if resp.StatusCode != http.StatusNoContent {
+					t.Errorf("Expected status 204, got: %d", resp.StatusCode)
+				}
+				return
+			}
+			if err == nil {
+				resp.Body.Close()
+				t.Fatalf("Expected the request to be refused")
+			}
+			if !errors.Is(err, ErrBlockedAddress) {
+				t.Errorf("Expected ErrBlockedAddress, got: %v", err)
+			}
+		})
+	}
+}
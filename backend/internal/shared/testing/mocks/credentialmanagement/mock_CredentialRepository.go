@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/context-space/context-space/backend/internal/credentialmanagement/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockCredentialRepository is an autogenerated mock type for the CredentialRepository type
@@ -175,6 +177,66 @@ func (_c *MockCredentialRepository_GetByID_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// GetByOrganizationAndProvider provides a mock function with given fields: ctx, organizationID, providerIdentifier
+func (_m *MockCredentialRepository) GetByOrganizationAndProvider(ctx context.Context, organizationID string, providerIdentifier string) (*domain.Credential, error) {
+	ret := _m.Called(ctx, organizationID, providerIdentifier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByOrganizationAndProvider")
+	}
+
+	var r0 *domain.Credential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Credential, error)); ok {
+		return rf(ctx, organizationID, providerIdentifier)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.Credential); ok {
+		r0 = rf(ctx, organizationID, providerIdentifier)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Credential)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, organizationID, providerIdentifier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCredentialRepository_GetByOrganizationAndProvider_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByOrganizationAndProvider'
+type MockCredentialRepository_GetByOrganizationAndProvider_Call struct {
+	*mock.Call
+}
+
+// GetByOrganizationAndProvider is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID string
+//   - providerIdentifier string
+func (_e *MockCredentialRepository_Expecter) GetByOrganizationAndProvider(ctx interface{}, organizationID interface{}, providerIdentifier interface{}) *MockCredentialRepository_GetByOrganizationAndProvider_Call {
+	return &MockCredentialRepository_GetByOrganizationAndProvider_Call{Call: _e.mock.On("GetByOrganizationAndProvider", ctx, organizationID, providerIdentifier)}
+}
+
+func (_c *MockCredentialRepository_GetByOrganizationAndProvider_Call) Run(run func(ctx context.Context, organizationID string, providerIdentifier string)) *MockCredentialRepository_GetByOrganizationAndProvider_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockCredentialRepository_GetByOrganizationAndProvider_Call) Return(_a0 *domain.Credential, _a1 error) *MockCredentialRepository_GetByOrganizationAndProvider_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCredentialRepository_GetByOrganizationAndProvider_Call) RunAndReturn(run func(context.Context, string, string) (*domain.Credential, error)) *MockCredentialRepository_GetByOrganizationAndProvider_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByUserAndProvider provides a mock function with given fields: ctx, userID, providerIdentifier
 func (_m *MockCredentialRepository) GetByUserAndProvider(ctx context.Context, userID string, providerIdentifier string) (*domain.Credential, error) {
 	ret := _m.Called(ctx, userID, providerIdentifier)
@@ -294,6 +356,65 @@ func (_c *MockCredentialRepository_ListByID_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// ListByOrganization provides a mock function with given fields: ctx, organizationID
+func (_m *MockCredentialRepository) ListByOrganization(ctx context.Context, organizationID string) ([]*domain.Credential, error) {
+	ret := _m.Called(ctx, organizationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByOrganization")
+	}
+
+	var r0 []*domain.Credential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.Credential, error)); ok {
+		return rf(ctx, organizationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.Credential); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Credential)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCredentialRepository_ListByOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByOrganization'
+type MockCredentialRepository_ListByOrganization_Call struct {
+	*mock.Call
+}
+
+// ListByOrganization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID string
+func (_e *MockCredentialRepository_Expecter) ListByOrganization(ctx interface{}, organizationID interface{}) *MockCredentialRepository_ListByOrganization_Call {
+	return &MockCredentialRepository_ListByOrganization_Call{Call: _e.mock.On("ListByOrganization", ctx, organizationID)}
+}
+
+func (_c *MockCredentialRepository_ListByOrganization_Call) Run(run func(ctx context.Context, organizationID string)) *MockCredentialRepository_ListByOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCredentialRepository_ListByOrganization_Call) Return(_a0 []*domain.Credential, _a1 error) *MockCredentialRepository_ListByOrganization_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCredentialRepository_ListByOrganization_Call) RunAndReturn(run func(context.Context, string) ([]*domain.Credential, error)) *MockCredentialRepository_ListByOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListByUser provides a mock function with given fields: ctx, userID
 func (_m *MockCredentialRepository) ListByUser(ctx context.Context, userID string) ([]*domain.Credential, error) {
 	ret := _m.Called(ctx, userID)
@@ -353,6 +474,66 @@ func (_c *MockCredentialRepository_ListByUser_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// ListDueForVerification provides a mock function with given fields: ctx, verifiedBefore, limit
+func (_m *MockCredentialRepository) ListDueForVerification(ctx context.Context, verifiedBefore time.Time, limit int) ([]*domain.Credential, error) {
+	ret := _m.Called(ctx, verifiedBefore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDueForVerification")
+	}
+
+	var r0 []*domain.Credential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]*domain.Credential, error)); ok {
+		return rf(ctx, verifiedBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*domain.Credential); ok {
+		r0 = rf(ctx, verifiedBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Credential)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, verifiedBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCredentialRepository_ListDueForVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDueForVerification'
+type MockCredentialRepository_ListDueForVerification_Call struct {
+	*mock.Call
+}
+
+// ListDueForVerification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - verifiedBefore time.Time
+//   - limit int
+func (_e *MockCredentialRepository_Expecter) ListDueForVerification(ctx interface{}, verifiedBefore interface{}, limit interface{}) *MockCredentialRepository_ListDueForVerification_Call {
+	return &MockCredentialRepository_ListDueForVerification_Call{Call: _e.mock.On("ListDueForVerification", ctx, verifiedBefore, limit)}
+}
+
+func (_c *MockCredentialRepository_ListDueForVerification_Call) Run(run func(ctx context.Context, verifiedBefore time.Time, limit int)) *MockCredentialRepository_ListDueForVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockCredentialRepository_ListDueForVerification_Call) Return(_a0 []*domain.Credential, _a1 error) *MockCredentialRepository_ListDueForVerification_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCredentialRepository_ListDueForVerification_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]*domain.Credential, error)) *MockCredentialRepository_ListDueForVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateLastUsedAt provides a mock function with given fields: ctx, id
 func (_m *MockCredentialRepository) UpdateLastUsedAt(ctx context.Context, id string) error {
 	ret := _m.Called(ctx, id)
@@ -400,6 +581,53 @@ func (_c *MockCredentialRepository_UpdateLastUsedAt_Call) RunAndReturn(run func(
 	return _c
 }
 
+// UpdateVerification provides a mock function with given fields: ctx, credential
+func (_m *MockCredentialRepository) UpdateVerification(ctx context.Context, credential *domain.Credential) error {
+	ret := _m.Called(ctx, credential)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Credential) error); ok {
+		r0 = rf(ctx, credential)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCredentialRepository_UpdateVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateVerification'
+type MockCredentialRepository_UpdateVerification_Call struct {
+	*mock.Call
+}
+
+// UpdateVerification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - credential *domain.Credential
+func (_e *MockCredentialRepository_Expecter) UpdateVerification(ctx interface{}, credential interface{}) *MockCredentialRepository_UpdateVerification_Call {
+	return &MockCredentialRepository_UpdateVerification_Call{Call: _e.mock.On("UpdateVerification", ctx, credential)}
+}
+
+func (_c *MockCredentialRepository_UpdateVerification_Call) Run(run func(ctx context.Context, credential *domain.Credential)) *MockCredentialRepository_UpdateVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Credential))
+	})
+	return _c
+}
+
+func (_c *MockCredentialRepository_UpdateVerification_Call) Return(_a0 error) *MockCredentialRepository_UpdateVerification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCredentialRepository_UpdateVerification_Call) RunAndReturn(run func(context.Context, *domain.Credential) error) *MockCredentialRepository_UpdateVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockCredentialRepository creates a new instance of MockCredentialRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockCredentialRepository(t interface {
@@ -326,6 +326,54 @@ func (_c *MockOAuthProvider_RefreshToken_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// RevokeToken provides a mock function with given fields: ctx, providerIdentifier, token
+func (_m *MockOAuthProvider) RevokeToken(ctx context.Context, providerIdentifier string, token *oauth2.Token) error {
+	ret := _m.Called(ctx, providerIdentifier, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *oauth2.Token) error); ok {
+		r0 = rf(ctx, providerIdentifier, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockOAuthProvider_RevokeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeToken'
+type MockOAuthProvider_RevokeToken_Call struct {
+	*mock.Call
+}
+
+// RevokeToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - providerIdentifier string
+//   - token *oauth2.Token
+func (_e *MockOAuthProvider_Expecter) RevokeToken(ctx interface{}, providerIdentifier interface{}, token interface{}) *MockOAuthProvider_RevokeToken_Call {
+	return &MockOAuthProvider_RevokeToken_Call{Call: _e.mock.On("RevokeToken", ctx, providerIdentifier, token)}
+}
+
+func (_c *MockOAuthProvider_RevokeToken_Call) Run(run func(ctx context.Context, providerIdentifier string, token *oauth2.Token)) *MockOAuthProvider_RevokeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*oauth2.Token))
+	})
+	return _c
+}
+
+func (_c *MockOAuthProvider_RevokeToken_Call) Return(_a0 error) *MockOAuthProvider_RevokeToken_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockOAuthProvider_RevokeToken_Call) RunAndReturn(run func(context.Context, string, *oauth2.Token) error) *MockOAuthProvider_RevokeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ShouldRefreshToken provides a mock function with given fields: providerIdentifier, oldToken
 func (_m *MockOAuthProvider) ShouldRefreshToken(providerIdentifier string, oldToken *oauth2.Token) (bool, error) {
 	ret := _m.Called(providerIdentifier, oldToken)
@@ -116,6 +116,53 @@ func (_c *MockAPIKeyRepository_Delete_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// DeleteByServiceAccountID provides a mock function with given fields: ctx, serviceAccountID
+func (_m *MockAPIKeyRepository) DeleteByServiceAccountID(ctx context.Context, serviceAccountID string) error {
+	ret := _m.Called(ctx, serviceAccountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByServiceAccountID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, serviceAccountID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAPIKeyRepository_DeleteByServiceAccountID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByServiceAccountID'
+type MockAPIKeyRepository_DeleteByServiceAccountID_Call struct {
+	*mock.Call
+}
+
+// DeleteByServiceAccountID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceAccountID string
+func (_e *MockAPIKeyRepository_Expecter) DeleteByServiceAccountID(ctx interface{}, serviceAccountID interface{}) *MockAPIKeyRepository_DeleteByServiceAccountID_Call {
+	return &MockAPIKeyRepository_DeleteByServiceAccountID_Call{Call: _e.mock.On("DeleteByServiceAccountID", ctx, serviceAccountID)}
+}
+
+func (_c *MockAPIKeyRepository_DeleteByServiceAccountID_Call) Run(run func(ctx context.Context, serviceAccountID string)) *MockAPIKeyRepository_DeleteByServiceAccountID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_DeleteByServiceAccountID_Call) Return(_a0 error) *MockAPIKeyRepository_DeleteByServiceAccountID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_DeleteByServiceAccountID_Call) RunAndReturn(run func(context.Context, string) error) *MockAPIKeyRepository_DeleteByServiceAccountID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function with given fields: ctx, id
 func (_m *MockAPIKeyRepository) Get(ctx context.Context, id string) (*domain.APIKey, error) {
 	ret := _m.Called(ctx, id)
@@ -234,6 +281,124 @@ func (_c *MockAPIKeyRepository_GetByKeyValue_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// ListByOrganizationID provides a mock function with given fields: ctx, organizationID
+func (_m *MockAPIKeyRepository) ListByOrganizationID(ctx context.Context, organizationID string) ([]*domain.APIKey, error) {
+	ret := _m.Called(ctx, organizationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByOrganizationID")
+	}
+
+	var r0 []*domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.APIKey, error)); ok {
+		return rf(ctx, organizationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.APIKey); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyRepository_ListByOrganizationID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByOrganizationID'
+type MockAPIKeyRepository_ListByOrganizationID_Call struct {
+	*mock.Call
+}
+
+// ListByOrganizationID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID string
+func (_e *MockAPIKeyRepository_Expecter) ListByOrganizationID(ctx interface{}, organizationID interface{}) *MockAPIKeyRepository_ListByOrganizationID_Call {
+	return &MockAPIKeyRepository_ListByOrganizationID_Call{Call: _e.mock.On("ListByOrganizationID", ctx, organizationID)}
+}
+
+func (_c *MockAPIKeyRepository_ListByOrganizationID_Call) Run(run func(ctx context.Context, organizationID string)) *MockAPIKeyRepository_ListByOrganizationID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_ListByOrganizationID_Call) Return(_a0 []*domain.APIKey, _a1 error) *MockAPIKeyRepository_ListByOrganizationID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_ListByOrganizationID_Call) RunAndReturn(run func(context.Context, string) ([]*domain.APIKey, error)) *MockAPIKeyRepository_ListByOrganizationID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByServiceAccountID provides a mock function with given fields: ctx, serviceAccountID
+func (_m *MockAPIKeyRepository) ListByServiceAccountID(ctx context.Context, serviceAccountID string) ([]*domain.APIKey, error) {
+	ret := _m.Called(ctx, serviceAccountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByServiceAccountID")
+	}
+
+	var r0 []*domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.APIKey, error)); ok {
+		return rf(ctx, serviceAccountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.APIKey); ok {
+		r0 = rf(ctx, serviceAccountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, serviceAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAPIKeyRepository_ListByServiceAccountID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByServiceAccountID'
+type MockAPIKeyRepository_ListByServiceAccountID_Call struct {
+	*mock.Call
+}
+
+// ListByServiceAccountID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serviceAccountID string
+func (_e *MockAPIKeyRepository_Expecter) ListByServiceAccountID(ctx interface{}, serviceAccountID interface{}) *MockAPIKeyRepository_ListByServiceAccountID_Call {
+	return &MockAPIKeyRepository_ListByServiceAccountID_Call{Call: _e.mock.On("ListByServiceAccountID", ctx, serviceAccountID)}
+}
+
+func (_c *MockAPIKeyRepository_ListByServiceAccountID_Call) Run(run func(ctx context.Context, serviceAccountID string)) *MockAPIKeyRepository_ListByServiceAccountID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_ListByServiceAccountID_Call) Return(_a0 []*domain.APIKey, _a1 error) *MockAPIKeyRepository_ListByServiceAccountID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAPIKeyRepository_ListByServiceAccountID_Call) RunAndReturn(run func(context.Context, string) ([]*domain.APIKey, error)) *MockAPIKeyRepository_ListByServiceAccountID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListByUserID provides a mock function with given fields: ctx, userID
 func (_m *MockAPIKeyRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.APIKey, error) {
 	ret := _m.Called(ctx, userID)
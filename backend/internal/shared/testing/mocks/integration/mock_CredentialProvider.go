@@ -23,6 +23,68 @@ func (_m *MockCredentialProvider) EXPECT() *MockCredentialProvider_Expecter {
 	return &MockCredentialProvider_Expecter{mock: &_m.Mock}
 }
 
+// CreateIncrementalAuthorization provides a mock function with given fields: ctx, userID, organizationID, providerIdentifier, missingPermissions
+func (_m *MockCredentialProvider) CreateIncrementalAuthorization(ctx context.Context, userID string, organizationID string, providerIdentifier string, missingPermissions []string) (*credentialmanagement.IncrementalAuthorizationDTO, error) {
+	ret := _m.Called(ctx, userID, organizationID, providerIdentifier, missingPermissions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateIncrementalAuthorization")
+	}
+
+	var r0 *credentialmanagement.IncrementalAuthorizationDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string) (*credentialmanagement.IncrementalAuthorizationDTO, error)); ok {
+		return rf(ctx, userID, organizationID, providerIdentifier, missingPermissions)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string) *credentialmanagement.IncrementalAuthorizationDTO); ok {
+		r0 = rf(ctx, userID, organizationID, providerIdentifier, missingPermissions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*credentialmanagement.IncrementalAuthorizationDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, []string) error); ok {
+		r1 = rf(ctx, userID, organizationID, providerIdentifier, missingPermissions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCredentialProvider_CreateIncrementalAuthorization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIncrementalAuthorization'
+type MockCredentialProvider_CreateIncrementalAuthorization_Call struct {
+	*mock.Call
+}
+
+// CreateIncrementalAuthorization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - organizationID string
+//   - providerIdentifier string
+//   - missingPermissions []string
+func (_e *MockCredentialProvider_Expecter) CreateIncrementalAuthorization(ctx interface{}, userID interface{}, organizationID interface{}, providerIdentifier interface{}, missingPermissions interface{}) *MockCredentialProvider_CreateIncrementalAuthorization_Call {
+	return &MockCredentialProvider_CreateIncrementalAuthorization_Call{Call: _e.mock.On("CreateIncrementalAuthorization", ctx, userID, organizationID, providerIdentifier, missingPermissions)}
+}
+
+func (_c *MockCredentialProvider_CreateIncrementalAuthorization_Call) Run(run func(ctx context.Context, userID string, organizationID string, providerIdentifier string, missingPermissions []string)) *MockCredentialProvider_CreateIncrementalAuthorization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].([]string))
+	})
+	return _c
+}
+
+func (_c *MockCredentialProvider_CreateIncrementalAuthorization_Call) Return(_a0 *credentialmanagement.IncrementalAuthorizationDTO, _a1 error) *MockCredentialProvider_CreateIncrementalAuthorization_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCredentialProvider_CreateIncrementalAuthorization_Call) RunAndReturn(run func(context.Context, string, string, string, []string) (*credentialmanagement.IncrementalAuthorizationDTO, error)) *MockCredentialProvider_CreateIncrementalAuthorization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateNone provides a mock function with given fields: ctx, userID, providerIdentifier
 func (_m *MockCredentialProvider) CreateNone(ctx context.Context, userID string, providerIdentifier string) (*credentialmanagement.CredentialDTO, error) {
 	ret := _m.Called(ctx, userID, providerIdentifier)
@@ -83,6 +145,66 @@ func (_c *MockCredentialProvider_CreateNone_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// GetCredentialByOrganizationAndProvider provides a mock function with given fields: ctx, organizationID, providerIdentifier
+func (_m *MockCredentialProvider) GetCredentialByOrganizationAndProvider(ctx context.Context, organizationID string, providerIdentifier string) (interface{}, error) {
+	ret := _m.Called(ctx, organizationID, providerIdentifier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCredentialByOrganizationAndProvider")
+	}
+
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (interface{}, error)); ok {
+		return rf(ctx, organizationID, providerIdentifier)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) interface{}); ok {
+		r0 = rf(ctx, organizationID, providerIdentifier)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, organizationID, providerIdentifier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCredentialProvider_GetCredentialByOrganizationAndProvider_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCredentialByOrganizationAndProvider'
+type MockCredentialProvider_GetCredentialByOrganizationAndProvider_Call struct {
+	*mock.Call
+}
+
+// GetCredentialByOrganizationAndProvider is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID string
+//   - providerIdentifier string
+func (_e *MockCredentialProvider_Expecter) GetCredentialByOrganizationAndProvider(ctx interface{}, organizationID interface{}, providerIdentifier interface{}) *MockCredentialProvider_GetCredentialByOrganizationAndProvider_Call {
+	return &MockCredentialProvider_GetCredentialByOrganizationAndProvider_Call{Call: _e.mock.On("GetCredentialByOrganizationAndProvider", ctx, organizationID, providerIdentifier)}
+}
+
+func (_c *MockCredentialProvider_GetCredentialByOrganizationAndProvider_Call) Run(run func(ctx context.Context, organizationID string, providerIdentifier string)) *MockCredentialProvider_GetCredentialByOrganizationAndProvider_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockCredentialProvider_GetCredentialByOrganizationAndProvider_Call) Return(_a0 interface{}, _a1 error) *MockCredentialProvider_GetCredentialByOrganizationAndProvider_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCredentialProvider_GetCredentialByOrganizationAndProvider_Call) RunAndReturn(run func(context.Context, string, string) (interface{}, error)) *MockCredentialProvider_GetCredentialByOrganizationAndProvider_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCredentialByUserAndProvider provides a mock function with given fields: ctx, userID, providerIdentifier
 func (_m *MockCredentialProvider) GetCredentialByUserAndProvider(ctx context.Context, userID string, providerIdentifier string) (interface{}, error) {
 	ret := _m.Called(ctx, userID, providerIdentifier)
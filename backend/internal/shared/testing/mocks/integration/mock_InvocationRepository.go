@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/context-space/context-space/backend/internal/integration/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockInvocationRepository is an autogenerated mock type for the InvocationRepository type
@@ -194,6 +196,64 @@ func (_c *MockInvocationRepository_CountByUserID_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// CountByUserIDAndStatus provides a mock function with given fields: ctx, userID, status
+func (_m *MockInvocationRepository) CountByUserIDAndStatus(ctx context.Context, userID string, status domain.InvocationStatus) (int64, error) {
+	ret := _m.Called(ctx, userID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByUserIDAndStatus")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.InvocationStatus) (int64, error)); ok {
+		return rf(ctx, userID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.InvocationStatus) int64); ok {
+		r0 = rf(ctx, userID, status)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.InvocationStatus) error); ok {
+		r1 = rf(ctx, userID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInvocationRepository_CountByUserIDAndStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByUserIDAndStatus'
+type MockInvocationRepository_CountByUserIDAndStatus_Call struct {
+	*mock.Call
+}
+
+// CountByUserIDAndStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - status domain.InvocationStatus
+func (_e *MockInvocationRepository_Expecter) CountByUserIDAndStatus(ctx interface{}, userID interface{}, status interface{}) *MockInvocationRepository_CountByUserIDAndStatus_Call {
+	return &MockInvocationRepository_CountByUserIDAndStatus_Call{Call: _e.mock.On("CountByUserIDAndStatus", ctx, userID, status)}
+}
+
+func (_c *MockInvocationRepository_CountByUserIDAndStatus_Call) Run(run func(ctx context.Context, userID string, status domain.InvocationStatus)) *MockInvocationRepository_CountByUserIDAndStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.InvocationStatus))
+	})
+	return _c
+}
+
+func (_c *MockInvocationRepository_CountByUserIDAndStatus_Call) Return(_a0 int64, _a1 error) *MockInvocationRepository_CountByUserIDAndStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInvocationRepository_CountByUserIDAndStatus_Call) RunAndReturn(run func(context.Context, string, domain.InvocationStatus) (int64, error)) *MockInvocationRepository_CountByUserIDAndStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, invocation
 func (_m *MockInvocationRepository) Create(ctx context.Context, invocation *domain.Invocation) error {
 	ret := _m.Called(ctx, invocation)
@@ -300,6 +360,66 @@ func (_c *MockInvocationRepository_GetByID_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// ListAwaitingApprovalExpiredBefore provides a mock function with given fields: ctx, before, limit
+func (_m *MockInvocationRepository) ListAwaitingApprovalExpiredBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Invocation, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAwaitingApprovalExpiredBefore")
+	}
+
+	var r0 []*domain.Invocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]*domain.Invocation, error)); ok {
+		return rf(ctx, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*domain.Invocation); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Invocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInvocationRepository_ListAwaitingApprovalExpiredBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAwaitingApprovalExpiredBefore'
+type MockInvocationRepository_ListAwaitingApprovalExpiredBefore_Call struct {
+	*mock.Call
+}
+
+// ListAwaitingApprovalExpiredBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+//   - limit int
+func (_e *MockInvocationRepository_Expecter) ListAwaitingApprovalExpiredBefore(ctx interface{}, before interface{}, limit interface{}) *MockInvocationRepository_ListAwaitingApprovalExpiredBefore_Call {
+	return &MockInvocationRepository_ListAwaitingApprovalExpiredBefore_Call{Call: _e.mock.On("ListAwaitingApprovalExpiredBefore", ctx, before, limit)}
+}
+
+func (_c *MockInvocationRepository_ListAwaitingApprovalExpiredBefore_Call) Run(run func(ctx context.Context, before time.Time, limit int)) *MockInvocationRepository_ListAwaitingApprovalExpiredBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockInvocationRepository_ListAwaitingApprovalExpiredBefore_Call) Return(_a0 []*domain.Invocation, _a1 error) *MockInvocationRepository_ListAwaitingApprovalExpiredBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInvocationRepository_ListAwaitingApprovalExpiredBefore_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]*domain.Invocation, error)) *MockInvocationRepository_ListAwaitingApprovalExpiredBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListByUserID provides a mock function with given fields: ctx, userID, limit, offset
 func (_m *MockInvocationRepository) ListByUserID(ctx context.Context, userID string, limit int, offset int) ([]*domain.Invocation, error) {
 	ret := _m.Called(ctx, userID, limit, offset)
@@ -361,6 +481,68 @@ func (_c *MockInvocationRepository_ListByUserID_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// ListByUserIDAndStatus provides a mock function with given fields: ctx, userID, status, limit, offset
+func (_m *MockInvocationRepository) ListByUserIDAndStatus(ctx context.Context, userID string, status domain.InvocationStatus, limit int, offset int) ([]*domain.Invocation, error) {
+	ret := _m.Called(ctx, userID, status, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUserIDAndStatus")
+	}
+
+	var r0 []*domain.Invocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.InvocationStatus, int, int) ([]*domain.Invocation, error)); ok {
+		return rf(ctx, userID, status, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.InvocationStatus, int, int) []*domain.Invocation); ok {
+		r0 = rf(ctx, userID, status, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Invocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.InvocationStatus, int, int) error); ok {
+		r1 = rf(ctx, userID, status, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInvocationRepository_ListByUserIDAndStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUserIDAndStatus'
+type MockInvocationRepository_ListByUserIDAndStatus_Call struct {
+	*mock.Call
+}
+
+// ListByUserIDAndStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - status domain.InvocationStatus
+//   - limit int
+//   - offset int
+func (_e *MockInvocationRepository_Expecter) ListByUserIDAndStatus(ctx interface{}, userID interface{}, status interface{}, limit interface{}, offset interface{}) *MockInvocationRepository_ListByUserIDAndStatus_Call {
+	return &MockInvocationRepository_ListByUserIDAndStatus_Call{Call: _e.mock.On("ListByUserIDAndStatus", ctx, userID, status, limit, offset)}
+}
+
+func (_c *MockInvocationRepository_ListByUserIDAndStatus_Call) Run(run func(ctx context.Context, userID string, status domain.InvocationStatus, limit int, offset int)) *MockInvocationRepository_ListByUserIDAndStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.InvocationStatus), args[3].(int), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockInvocationRepository_ListByUserIDAndStatus_Call) Return(_a0 []*domain.Invocation, _a1 error) *MockInvocationRepository_ListByUserIDAndStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInvocationRepository_ListByUserIDAndStatus_Call) RunAndReturn(run func(context.Context, string, domain.InvocationStatus, int, int) ([]*domain.Invocation, error)) *MockInvocationRepository_ListByUserIDAndStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, invocation
 func (_m *MockInvocationRepository) Update(ctx context.Context, invocation *domain.Invocation) error {
 	ret := _m.Called(ctx, invocation)
@@ -408,6 +590,64 @@ func (_c *MockInvocationRepository_Update_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// UpdateIfStatus provides a mock function with given fields: ctx, invocation, status
+func (_m *MockInvocationRepository) UpdateIfStatus(ctx context.Context, invocation *domain.Invocation, status domain.InvocationStatus) (bool, error) {
+	ret := _m.Called(ctx, invocation, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateIfStatus")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Invocation, domain.InvocationStatus) (bool, error)); ok {
+		return rf(ctx, invocation, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Invocation, domain.InvocationStatus) bool); ok {
+		r0 = rf(ctx, invocation, status)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Invocation, domain.InvocationStatus) error); ok {
+		r1 = rf(ctx, invocation, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInvocationRepository_UpdateIfStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateIfStatus'
+type MockInvocationRepository_UpdateIfStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateIfStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - invocation *domain.Invocation
+//   - status domain.InvocationStatus
+func (_e *MockInvocationRepository_Expecter) UpdateIfStatus(ctx interface{}, invocation interface{}, status interface{}) *MockInvocationRepository_UpdateIfStatus_Call {
+	return &MockInvocationRepository_UpdateIfStatus_Call{Call: _e.mock.On("UpdateIfStatus", ctx, invocation, status)}
+}
+
+func (_c *MockInvocationRepository_UpdateIfStatus_Call) Run(run func(ctx context.Context, invocation *domain.Invocation, status domain.InvocationStatus)) *MockInvocationRepository_UpdateIfStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Invocation), args[2].(domain.InvocationStatus))
+	})
+	return _c
+}
+
+func (_c *MockInvocationRepository_UpdateIfStatus_Call) Return(_a0 bool, _a1 error) *MockInvocationRepository_UpdateIfStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInvocationRepository_UpdateIfStatus_Call) RunAndReturn(run func(context.Context, *domain.Invocation, domain.InvocationStatus) (bool, error)) *MockInvocationRepository_UpdateIfStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockInvocationRepository creates a new instance of MockInvocationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockInvocationRepository(t interface {
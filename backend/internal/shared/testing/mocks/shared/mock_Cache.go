@@ -4,9 +4,12 @@ package shared_mocks
 
 import (
 	context "context"
-	time "time"
+
+	cache "github.com/context-space/context-space/backend/internal/shared/infrastructure/cache"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockCache is an autogenerated mock type for the Cache type
@@ -125,6 +128,53 @@ func (_c *MockCache_Close_Call) RunAndReturn(run func() error) *MockCache_Close_
 	return _c
 }
 
+// DecrementCounters provides a mock function with given fields: ctx, counters
+func (_m *MockCache) DecrementCounters(ctx context.Context, counters []cache.Counter) error {
+	ret := _m.Called(ctx, counters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecrementCounters")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []cache.Counter) error); ok {
+		r0 = rf(ctx, counters)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCache_DecrementCounters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecrementCounters'
+type MockCache_DecrementCounters_Call struct {
+	*mock.Call
+}
+
+// DecrementCounters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - counters []cache.Counter
+func (_e *MockCache_Expecter) DecrementCounters(ctx interface{}, counters interface{}) *MockCache_DecrementCounters_Call {
+	return &MockCache_DecrementCounters_Call{Call: _e.mock.On("DecrementCounters", ctx, counters)}
+}
+
+func (_c *MockCache_DecrementCounters_Call) Run(run func(ctx context.Context, counters []cache.Counter)) *MockCache_DecrementCounters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]cache.Counter))
+	})
+	return _c
+}
+
+func (_c *MockCache_DecrementCounters_Call) Return(_a0 error) *MockCache_DecrementCounters_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCache_DecrementCounters_Call) RunAndReturn(run func(context.Context, []cache.Counter) error) *MockCache_DecrementCounters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: ctx, key
 func (_m *MockCache) Delete(ctx context.Context, key string) error {
 	ret := _m.Called(ctx, key)
@@ -229,6 +279,236 @@ func (_c *MockCache_Get_Call) RunAndReturn(run func(context.Context, string) (st
 	return _c
 }
 
+// GetCounters provides a mock function with given fields: ctx, counters
+func (_m *MockCache) GetCounters(ctx context.Context, counters []cache.Counter) ([]int64, error) {
+	ret := _m.Called(ctx, counters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCounters")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []cache.Counter) ([]int64, error)); ok {
+		return rf(ctx, counters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []cache.Counter) []int64); ok {
+		r0 = rf(ctx, counters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []cache.Counter) error); ok {
+		r1 = rf(ctx, counters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCache_GetCounters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCounters'
+type MockCache_GetCounters_Call struct {
+	*mock.Call
+}
+
+// GetCounters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - counters []cache.Counter
+func (_e *MockCache_Expecter) GetCounters(ctx interface{}, counters interface{}) *MockCache_GetCounters_Call {
+	return &MockCache_GetCounters_Call{Call: _e.mock.On("GetCounters", ctx, counters)}
+}
+
+func (_c *MockCache_GetCounters_Call) Run(run func(ctx context.Context, counters []cache.Counter)) *MockCache_GetCounters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]cache.Counter))
+	})
+	return _c
+}
+
+func (_c *MockCache_GetCounters_Call) Return(_a0 []int64, _a1 error) *MockCache_GetCounters_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCache_GetCounters_Call) RunAndReturn(run func(context.Context, []cache.Counter) ([]int64, error)) *MockCache_GetCounters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HashGetAll provides a mock function with given fields: ctx, key
+func (_m *MockCache) HashGetAll(ctx context.Context, key string) (map[string]string, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HashGetAll")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]string, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCache_HashGetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HashGetAll'
+type MockCache_HashGetAll_Call struct {
+	*mock.Call
+}
+
+// HashGetAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockCache_Expecter) HashGetAll(ctx interface{}, key interface{}) *MockCache_HashGetAll_Call {
+	return &MockCache_HashGetAll_Call{Call: _e.mock.On("HashGetAll", ctx, key)}
+}
+
+func (_c *MockCache_HashGetAll_Call) Run(run func(ctx context.Context, key string)) *MockCache_HashGetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCache_HashGetAll_Call) Return(_a0 map[string]string, _a1 error) *MockCache_HashGetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCache_HashGetAll_Call) RunAndReturn(run func(context.Context, string) (map[string]string, error)) *MockCache_HashGetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementCounters provides a mock function with given fields: ctx, counters
+func (_m *MockCache) IncrementCounters(ctx context.Context, counters []cache.Counter) ([]int64, bool, error) {
+	ret := _m.Called(ctx, counters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementCounters")
+	}
+
+	var r0 []int64
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []cache.Counter) ([]int64, bool, error)); ok {
+		return rf(ctx, counters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []cache.Counter) []int64); ok {
+		r0 = rf(ctx, counters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []cache.Counter) bool); ok {
+		r1 = rf(ctx, counters)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []cache.Counter) error); ok {
+		r2 = rf(ctx, counters)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockCache_IncrementCounters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementCounters'
+type MockCache_IncrementCounters_Call struct {
+	*mock.Call
+}
+
+// IncrementCounters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - counters []cache.Counter
+func (_e *MockCache_Expecter) IncrementCounters(ctx interface{}, counters interface{}) *MockCache_IncrementCounters_Call {
+	return &MockCache_IncrementCounters_Call{Call: _e.mock.On("IncrementCounters", ctx, counters)}
+}
+
+func (_c *MockCache_IncrementCounters_Call) Run(run func(ctx context.Context, counters []cache.Counter)) *MockCache_IncrementCounters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]cache.Counter))
+	})
+	return _c
+}
+
+func (_c *MockCache_IncrementCounters_Call) Return(_a0 []int64, _a1 bool, _a2 error) *MockCache_IncrementCounters_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockCache_IncrementCounters_Call) RunAndReturn(run func(context.Context, []cache.Counter) ([]int64, bool, error)) *MockCache_IncrementCounters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Ping provides a mock function with given fields: ctx
+func (_m *MockCache) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCache_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type MockCache_Ping_Call struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCache_Expecter) Ping(ctx interface{}) *MockCache_Ping_Call {
+	return &MockCache_Ping_Call{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *MockCache_Ping_Call) Run(run func(ctx context.Context)) *MockCache_Ping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCache_Ping_Call) Return(_a0 error) *MockCache_Ping_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCache_Ping_Call) RunAndReturn(run func(context.Context) error) *MockCache_Ping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReleaseLock provides a mock function with given fields: ctx, key
 func (_m *MockCache) ReleaseLock(ctx context.Context, key string) error {
 	ret := _m.Called(ctx, key)
@@ -24,3 +24,33 @@ type Permission struct {
 	Description string   `json:"description"`
 	OAuthScopes []string `json:"oauth_scopes"`
 }
+
+type OperationRiskLevel string
+
+const (
+	RiskLevelRead        OperationRiskLevel = "read"
+	RiskLevelWrite       OperationRiskLevel = "write"
+	RiskLevelDestructive OperationRiskLevel = "destructive"
+)
+
+// Severity returns an ordinal for comparing risk levels, unknown levels rank as read
+func (r OperationRiskLevel) Severity() int {
+	switch r {
+	case RiskLevelWrite:
+		return 1
+	case RiskLevelDestructive:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// IsValid reports whether the risk level is one of the known values
+func (r OperationRiskLevel) IsValid() bool {
+	switch r {
+	case RiskLevelRead, RiskLevelWrite, RiskLevelDestructive:
+		return true
+	default:
+		return false
+	}
+}
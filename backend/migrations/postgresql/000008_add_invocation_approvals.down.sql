@@ -0,0 +1,8 @@
+-- Drop approval_policies table
+DROP TABLE IF EXISTS approval_policies;
+
+-- Drop index for pending approvals lookup
+DROP INDEX IF EXISTS idx_invocations_status_approval_expires_at;
+
+-- Drop approval_expires_at column from invocations table
+ALTER TABLE invocations DROP COLUMN IF EXISTS approval_expires_at;
@@ -0,0 +1,25 @@
+-- Add approval_expires_at column to invocations table
+ALTER TABLE invocations ADD COLUMN approval_expires_at TIMESTAMP WITH TIME ZONE;
+
+-- Add index for pending approvals lookup
+CREATE INDEX IF NOT EXISTS idx_invocations_status_approval_expires_at ON invocations(status, approval_expires_at);
+
+-- Create approval_policies table
+CREATE TABLE IF NOT EXISTS approval_policies (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    min_risk_level VARCHAR(20) NOT NULL DEFAULT 'destructive',
+    approval_ttl_seconds BIGINT NOT NULL DEFAULT 86400,
+    json_attributes JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Add unique index for user_id
+CREATE UNIQUE INDEX IF NOT EXISTS idx_approval_policies_user_id ON approval_policies(user_id)
+WHERE
+    deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_approval_policies_deleted_at ON approval_policies(deleted_at);
@@ -0,0 +1,2 @@
+-- Drop invocation_usage table
+DROP TABLE IF EXISTS invocation_usage;
@@ -0,0 +1,15 @@
+-- Create invocation_usage table
+CREATE TABLE IF NOT EXISTS invocation_usage (
+    day DATE NOT NULL,
+    user_id UUID NOT NULL,
+    api_key_id VARCHAR(36) NOT NULL DEFAULT '',
+    provider_identifier VARCHAR(50) NOT NULL,
+    operation_identifier VARCHAR(50) NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (day, user_id, api_key_id, provider_identifier, operation_identifier)
+);
+
+-- Add index for per-user usage lookup
+CREATE INDEX IF NOT EXISTS idx_invocation_usage_user_id_day ON invocation_usage(user_id, day);
@@ -0,0 +1,2 @@
+-- Drop token_revocations table
+DROP TABLE IF EXISTS token_revocations;
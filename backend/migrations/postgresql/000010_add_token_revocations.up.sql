@@ -0,0 +1,22 @@
+-- Create token_revocations table
+CREATE TABLE IF NOT EXISTS token_revocations (
+    id UUID PRIMARY KEY,
+    credential_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    provider_identifier VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    json_attributes JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Add index for due retries lookup
+CREATE INDEX IF NOT EXISTS idx_token_revocations_status_next_attempt_at ON token_revocations(status, next_attempt_at);
+
+-- Add indexes for per-user and per-credential lookup
+CREATE INDEX IF NOT EXISTS idx_token_revocations_user_id ON token_revocations(user_id);
+CREATE INDEX IF NOT EXISTS idx_token_revocations_credential_id ON token_revocations(credential_id);
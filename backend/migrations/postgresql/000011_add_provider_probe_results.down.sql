@@ -0,0 +1,2 @@
+-- Drop provider_probe_results table
+DROP TABLE IF EXISTS provider_probe_results;
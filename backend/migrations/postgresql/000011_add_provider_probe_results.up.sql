@@ -0,0 +1,16 @@
+-- Create provider_probe_results table
+CREATE TABLE IF NOT EXISTS provider_probe_results (
+    id UUID PRIMARY KEY,
+    provider_identifier VARCHAR(50) NOT NULL,
+    operation VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    latency_ms BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    checked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Add index for per-provider history lookup
+CREATE INDEX IF NOT EXISTS idx_provider_probe_results_provider_checked_at ON provider_probe_results(provider_identifier, checked_at DESC);
+
+-- Add index for history pruning
+CREATE INDEX IF NOT EXISTS idx_provider_probe_results_checked_at ON provider_probe_results(checked_at);
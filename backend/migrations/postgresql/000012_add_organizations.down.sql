@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_invocations_organization_id;
+ALTER TABLE invocations DROP COLUMN IF EXISTS organization_id;
+
+DROP INDEX IF EXISTS idx_credentials_organization_id;
+ALTER TABLE credentials DROP COLUMN IF EXISTS organization_id;
+
+DROP INDEX IF EXISTS idx_user_api_keys_organization_id;
+ALTER TABLE user_api_keys DROP COLUMN IF EXISTS organization_id;
+
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
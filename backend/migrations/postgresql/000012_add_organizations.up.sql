@@ -0,0 +1,38 @@
+-- Create organizations table
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_by UUID NOT NULL,
+    shared_credential_role VARCHAR(20) NOT NULL DEFAULT 'member',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_organizations_deleted_at ON organizations(deleted_at);
+
+-- Create organization_members table
+CREATE TABLE IF NOT EXISTS organization_members (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- A user is a member of an organization at most once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_members_organization_user ON organization_members(organization_id, user_id);
+
+-- Add index for listing the organizations of a user
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members(user_id);
+
+-- Add organization ownership to API keys, credentials and invocations
+ALTER TABLE user_api_keys ADD COLUMN IF NOT EXISTS organization_id UUID;
+CREATE INDEX IF NOT EXISTS idx_user_api_keys_organization_id ON user_api_keys(organization_id);
+
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS organization_id UUID;
+CREATE INDEX IF NOT EXISTS idx_credentials_organization_id ON credentials(organization_id);
+
+ALTER TABLE invocations ADD COLUMN IF NOT EXISTS organization_id UUID;
+CREATE INDEX IF NOT EXISTS idx_invocations_organization_id ON invocations(organization_id);
@@ -0,0 +1,2 @@
+-- Fails if users of a non-Supabase identity provider exist
+ALTER TABLE users ALTER COLUMN sup_id TYPE UUID USING sup_id::uuid;
@@ -0,0 +1,2 @@
+-- Subjects of generic OIDC identity providers are not necessarily UUIDs
+ALTER TABLE users ALTER COLUMN sup_id TYPE VARCHAR(255) USING sup_id::text;
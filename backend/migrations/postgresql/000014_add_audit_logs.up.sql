@@ -0,0 +1,37 @@
+-- Create append-only audit_logs table, the entries of each user form a SHA-256 hash chain
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    sequence BIGINT NOT NULL,
+    actor_id VARCHAR(36) NOT NULL DEFAULT '',
+    auth_type VARCHAR(20) NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    action VARCHAR(50) NOT NULL,
+    resource_type VARCHAR(50) NOT NULL DEFAULT '',
+    resource_id VARCHAR(255) NOT NULL DEFAULT '',
+    outcome VARCHAR(20) NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    prev_hash CHAR(64) NOT NULL,
+    hash CHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Each position of a chain is taken once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_logs_user_sequence ON audit_logs(user_id, sequence);
+
+-- Add indexes for filtering the audit log of a user
+CREATE INDEX IF NOT EXISTS idx_audit_logs_user_action ON audit_logs(user_id, action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_user_created_at ON audit_logs(user_id, created_at);
+
+-- Reject updates and deletes so that entries can only be appended
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_audit_logs_append_only ON audit_logs;
+CREATE TRIGGER trg_audit_logs_append_only
+    BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
@@ -0,0 +1,26 @@
+-- Create key_rotations table tracking transit key rotations and the rewrap of older ciphertexts
+CREATE TABLE IF NOT EXISTS key_rotations (
+    id UUID PRIMARY KEY,
+    region VARCHAR(10) NOT NULL,
+    credential_type VARCHAR(20) NOT NULL,
+    key_version INT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    source VARCHAR(50) NOT NULL DEFAULT '',
+    cursor VARCHAR(36) NOT NULL DEFAULT '',
+    total INT NOT NULL DEFAULT 0,
+    rewrapped INT NOT NULL DEFAULT 0,
+    skipped INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    triggered_by VARCHAR(36) NOT NULL DEFAULT '',
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- A key is rotated by at most one running rotation at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_key_rotations_running ON key_rotations(region, credential_type) WHERE status = 'running';
+
+-- Add index for listing rotations
+CREATE INDEX IF NOT EXISTS idx_key_rotations_created_at ON key_rotations(created_at DESC);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_credentials_last_verified_at;
+ALTER TABLE credentials DROP COLUMN IF EXISTS verification_error;
+ALTER TABLE credentials DROP COLUMN IF EXISTS verification_status;
+ALTER TABLE credentials DROP COLUMN IF EXISTS last_verified_at;
@@ -0,0 +1,7 @@
+-- Add the result of the last verification of a credential against its provider
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS last_verified_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS verification_status VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE credentials ADD COLUMN IF NOT EXISTS verification_error TEXT NOT NULL DEFAULT '';
+
+-- Add index for picking the credentials due for verification
+CREATE INDEX IF NOT EXISTS idx_credentials_last_verified_at ON credentials(last_verified_at NULLS FIRST) WHERE is_valid = TRUE AND deleted_at IS NULL;
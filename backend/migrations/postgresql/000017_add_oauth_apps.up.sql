@@ -0,0 +1,23 @@
+-- Create oauth_apps table
+CREATE TABLE IF NOT EXISTS oauth_apps (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    organization_id UUID,
+    provider_identifier VARCHAR(50) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    auth_url TEXT NOT NULL DEFAULT '',
+    token_url TEXT NOT NULL DEFAULT '',
+    api_base_url TEXT NOT NULL DEFAULT '',
+    json_attributes JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- An owner registers at most one OAuth app per provider
+CREATE UNIQUE INDEX IF NOT EXISTS idx_oauth_apps_user_provider ON oauth_apps(user_id, provider_identifier)
+    WHERE organization_id IS NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_oauth_apps_organization_provider ON oauth_apps(organization_id, provider_identifier)
+    WHERE organization_id IS NOT NULL AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_oauth_apps_deleted_at ON oauth_apps(deleted_at);
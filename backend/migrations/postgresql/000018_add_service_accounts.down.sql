@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_user_api_keys_service_account_id;
+ALTER TABLE user_api_keys DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE user_api_keys DROP COLUMN IF EXISTS scopes;
+ALTER TABLE user_api_keys DROP COLUMN IF EXISTS service_account_id;
+
+DROP TABLE IF EXISTS service_accounts;
@@ -0,0 +1,21 @@
+-- Create service_accounts table
+CREATE TABLE IF NOT EXISTS service_accounts (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_by UUID NOT NULL,
+    organization_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_service_accounts_created_by ON service_accounts(created_by);
+CREATE INDEX IF NOT EXISTS idx_service_accounts_organization_id ON service_accounts(organization_id);
+CREATE INDEX IF NOT EXISTS idx_service_accounts_deleted_at ON service_accounts(deleted_at);
+
+-- Add service account binding, scopes and expiry to API keys
+ALTER TABLE user_api_keys ADD COLUMN IF NOT EXISTS service_account_id UUID;
+ALTER TABLE user_api_keys ADD COLUMN IF NOT EXISTS scopes JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE user_api_keys ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_user_api_keys_service_account_id ON user_api_keys(service_account_id);
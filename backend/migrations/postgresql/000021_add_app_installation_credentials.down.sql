@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS app_installation_credentials;
+
+-- Values cannot be removed from an enum, remove the credentials of the type so that it is no longer used
+DELETE FROM credentials WHERE credential_type = 'app_installation';
@@ -0,0 +1,16 @@
+-- Add app installation credentials, authenticating as an installation of a provider app
+ALTER TYPE credential_type ADD VALUE IF NOT EXISTS 'app_installation';
+
+-- Create app_installation_credentials table, the app private key is stored encrypted in json_attributes
+CREATE TABLE IF NOT EXISTS app_installation_credentials (
+    credential_id UUID PRIMARY KEY,
+    app_id VARCHAR(255) NOT NULL,
+    installation_id VARCHAR(255) NOT NULL,
+    json_attributes JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_app_installation_credentials_credential FOREIGN KEY (credential_id) REFERENCES credentials(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_app_installation_credentials_deleted_at ON app_installation_credentials(deleted_at);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS min_decryption_versions;
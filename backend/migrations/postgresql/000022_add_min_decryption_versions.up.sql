@@ -0,0 +1,9 @@
+-- Create min_decryption_versions table holding the oldest key version the envelope backend may decrypt with
+CREATE TABLE IF NOT EXISTS min_decryption_versions (
+    region VARCHAR(10) NOT NULL,
+    credential_type VARCHAR(20) NOT NULL,
+    version INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (region, credential_type)
+);